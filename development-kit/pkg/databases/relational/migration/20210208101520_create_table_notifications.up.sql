@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "notifications"
+(
+    "notification_id"   UUID NOT NULL,
+    "company_id"        UUID NOT NULL,
+    "repository_id"     UUID,
+    "description"       VARCHAR(255),
+    "channel"           VARCHAR(255) NOT NULL,
+    "url"               VARCHAR(500) NOT NULL,
+    "events"            TEXT[] NOT NULL DEFAULT '{}',
+    "created_at"        DATE NOT NULL,
+    "updated_at"        DATE,
+    PRIMARY KEY (notification_id),
+    FOREIGN KEY (repository_id) REFERENCES repositories (repository_id) ON DELETE CASCADE,
+    FOREIGN KEY (company_id) REFERENCES companies (company_id) ON DELETE CASCADE
+);
+
+COMMIT;
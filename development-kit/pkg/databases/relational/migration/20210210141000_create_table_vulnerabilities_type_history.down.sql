@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS vulnerabilities_type_history CASCADE;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "vulnerabilities_type_history"
+(
+    "history_id"        UUID NOT NULL,
+    "vulnerability_id"  UUID NOT NULL,
+    "account_id"        UUID,
+    "old_type"          VARCHAR(255) NOT NULL,
+    "new_type"          VARCHAR(255) NOT NULL,
+    "comment"           VARCHAR,
+    "created_at"        TIMESTAMP NOT NULL,
+    PRIMARY KEY (history_id),
+    FOREIGN KEY (vulnerability_id) REFERENCES vulnerabilities (vulnerability_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "vulnerabilities_type_history_vulnerability_id_idx"
+    ON "vulnerabilities_type_history" (vulnerability_id);
+
+COMMIT;
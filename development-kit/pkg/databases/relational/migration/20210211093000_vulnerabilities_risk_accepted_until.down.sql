@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE "vulnerabilities"
+DROP COLUMN "risk_accepted_until",
+DROP COLUMN "risk_expiration_warned";
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE "vulnerabilities"
+ADD
+    "risk_accepted_until" TIMESTAMP,
+ADD
+    "risk_expiration_warned" BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
\ No newline at end of file
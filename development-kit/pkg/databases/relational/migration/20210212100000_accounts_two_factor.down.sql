@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE "accounts"
+DROP COLUMN "two_factor_secret",
+DROP COLUMN "is_two_factor_enabled",
+DROP COLUMN "recovery_codes";
+
+ALTER TABLE "companies"
+DROP COLUMN "require_two_factor";
+
+COMMIT;
\ No newline at end of file
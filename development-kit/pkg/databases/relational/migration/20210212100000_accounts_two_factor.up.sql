@@ -0,0 +1,15 @@
+BEGIN;
+
+ALTER TABLE "accounts"
+ADD
+    "two_factor_secret" VARCHAR(255) NOT NULL DEFAULT '',
+ADD
+    "is_two_factor_enabled" BOOLEAN NOT NULL DEFAULT FALSE,
+ADD
+    "recovery_codes" TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE "companies"
+ADD
+    "require_two_factor" BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
\ No newline at end of file
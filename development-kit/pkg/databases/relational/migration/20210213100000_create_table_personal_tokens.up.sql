@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "personal_tokens"
+(
+    "personal_token_id" UUID NOT NULL,
+    "account_id"        UUID NOT NULL,
+    "description"       VARCHAR(255) NOT NULL,
+    "scopes"            TEXT[] NOT NULL,
+    "permissions"       TEXT[] NOT NULL DEFAULT '{}',
+    "suffix_value"      VARCHAR(5) NOT NULL,
+    "value"             VARCHAR(255) NOT NULL UNIQUE,
+    "created_at"        TIMESTAMP NOT NULL,
+    "expires_at"        TIMESTAMP NOT NULL,
+    "last_used_at"      TIMESTAMP,
+    PRIMARY KEY (personal_token_id),
+    FOREIGN KEY (account_id) REFERENCES accounts (account_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "personal_tokens_account_id_idx"
+    ON "personal_tokens" (account_id);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,17 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "tokens_value_idx";
+
+DROP INDEX IF EXISTS "tokens_previous_value_idx";
+
+ALTER TABLE "tokens"
+DROP COLUMN "never_expires",
+DROP COLUMN "is_expiration_warned",
+DROP COLUMN "previous_value",
+DROP COLUMN "previous_value_expires_at",
+DROP COLUMN "last_used_at",
+DROP COLUMN "last_used_ip",
+ALTER COLUMN "created_at" TYPE DATE,
+ALTER COLUMN "expires_at" TYPE DATE;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,25 @@
+BEGIN;
+
+ALTER TABLE "tokens"
+ALTER COLUMN "created_at" TYPE TIMESTAMP,
+ALTER COLUMN "expires_at" TYPE TIMESTAMP,
+ADD
+    "never_expires" BOOLEAN NOT NULL DEFAULT FALSE,
+ADD
+    "is_expiration_warned" BOOLEAN NOT NULL DEFAULT FALSE,
+ADD
+    "previous_value" VARCHAR(255) NOT NULL DEFAULT '',
+ADD
+    "previous_value_expires_at" TIMESTAMP,
+ADD
+    "last_used_at" TIMESTAMP,
+ADD
+    "last_used_ip" VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS "tokens_value_idx"
+    ON "tokens" (value);
+
+CREATE INDEX IF NOT EXISTS "tokens_previous_value_idx"
+    ON "tokens" (previous_value);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "audit_events"
+(
+    "event_id"      UUID NOT NULL,
+    "action"        VARCHAR(255) NOT NULL,
+    "account_id"    UUID NOT NULL,
+    "company_id"    UUID NOT NULL,
+    "repository_id" UUID NOT NULL,
+    "resource_id"   VARCHAR(255) NOT NULL DEFAULT '',
+    "ip"            VARCHAR(255) NOT NULL DEFAULT '',
+    "details"       TEXT NOT NULL DEFAULT '',
+    "created_at"    TIMESTAMP NOT NULL,
+    PRIMARY KEY (event_id)
+);
+
+CREATE INDEX IF NOT EXISTS "audit_events_company_id_created_at_idx"
+    ON "audit_events" (company_id, created_at);
+
+CREATE INDEX IF NOT EXISTS "audit_events_created_at_idx"
+    ON "audit_events" (created_at);
+
+COMMIT;
\ No newline at end of file
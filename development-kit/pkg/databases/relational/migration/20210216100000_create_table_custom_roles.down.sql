@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE "account_repository" DROP COLUMN IF EXISTS "custom_role_id";
+
+DROP TABLE IF EXISTS "custom_roles";
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "custom_roles"
+(
+    "custom_role_id" UUID NOT NULL,
+    "company_id"     UUID NOT NULL,
+    "name"           VARCHAR(255) NOT NULL,
+    "description"    VARCHAR(255) NOT NULL DEFAULT '',
+    "permissions"    TEXT[] NOT NULL,
+    "created_at"     TIMESTAMP NOT NULL,
+    "updated_at"     TIMESTAMP NOT NULL,
+    PRIMARY KEY (custom_role_id),
+    FOREIGN KEY (company_id) REFERENCES companies (company_id) ON DELETE CASCADE,
+    UNIQUE (company_id, name)
+);
+
+ALTER TABLE "account_repository"
+    ADD COLUMN IF NOT EXISTS "custom_role_id" UUID REFERENCES custom_roles (custom_role_id) ON DELETE SET NULL;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "scim_group_members";
+DROP TABLE IF EXISTS "scim_groups";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "external_id";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "is_disabled";
+
+COMMIT;
\ No newline at end of file
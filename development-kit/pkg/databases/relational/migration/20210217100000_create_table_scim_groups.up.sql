@@ -0,0 +1,26 @@
+BEGIN;
+
+ALTER TABLE "accounts" ADD COLUMN IF NOT EXISTS "is_disabled" BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE "accounts" ADD COLUMN IF NOT EXISTS "external_id" VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS "scim_groups"
+(
+    "group_id"     UUID NOT NULL,
+    "display_name" VARCHAR(255) NOT NULL,
+    "external_id"  VARCHAR(255) NOT NULL DEFAULT '',
+    "created_at"   TIMESTAMP NOT NULL,
+    "updated_at"   TIMESTAMP NOT NULL,
+    PRIMARY KEY (group_id),
+    UNIQUE (display_name)
+);
+
+CREATE TABLE IF NOT EXISTS "scim_group_members"
+(
+    "group_id"   UUID NOT NULL,
+    "account_id" UUID NOT NULL,
+    PRIMARY KEY (group_id, account_id),
+    FOREIGN KEY (group_id) REFERENCES scim_groups (group_id) ON DELETE CASCADE,
+    FOREIGN KEY (account_id) REFERENCES accounts (account_id) ON DELETE CASCADE
+);
+
+COMMIT;
\ No newline at end of file
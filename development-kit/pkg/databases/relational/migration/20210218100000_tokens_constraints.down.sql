@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE "analysis"
+DROP COLUMN "branch",
+DROP COLUMN "commit_hash";
+
+ALTER TABLE "tokens"
+DROP COLUMN "allowed_repository_names",
+DROP COLUMN "allowed_branches",
+DROP COLUMN "disable_auto_create";
+
+COMMIT;
\ No newline at end of file
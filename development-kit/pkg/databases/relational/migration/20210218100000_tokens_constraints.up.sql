@@ -0,0 +1,17 @@
+BEGIN;
+
+ALTER TABLE "tokens"
+ADD
+    "allowed_repository_names" TEXT[] NOT NULL DEFAULT '{}',
+ADD
+    "allowed_branches" TEXT[] NOT NULL DEFAULT '{}',
+ADD
+    "disable_auto_create" BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE "analysis"
+ADD
+    "branch" VARCHAR(255) NOT NULL DEFAULT '',
+ADD
+    "commit_hash" VARCHAR(255) NOT NULL DEFAULT '';
+
+COMMIT;
\ No newline at end of file
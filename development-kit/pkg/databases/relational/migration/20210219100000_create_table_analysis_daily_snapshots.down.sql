@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "analysis_daily_snapshots";
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,26 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "analysis_daily_snapshots"
+(
+    "snapshot_date"   TIMESTAMP NOT NULL,
+    "company_id"      UUID NOT NULL,
+    "repository_id"   UUID NOT NULL,
+    "repository_name" VARCHAR(255) NOT NULL,
+    "finished_at"     TIMESTAMP NOT NULL,
+    "severity"        VARCHAR(255) NOT NULL DEFAULT '',
+    "language"        VARCHAR(255) NOT NULL DEFAULT '',
+    "security_tool"   VARCHAR(255) NOT NULL DEFAULT '',
+    "type"            VARCHAR(255) NOT NULL DEFAULT '',
+    "total"           INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (snapshot_date, repository_id, severity, language, security_tool, type),
+    FOREIGN KEY (company_id) REFERENCES companies (company_id) ON DELETE CASCADE,
+    FOREIGN KEY (repository_id) REFERENCES repositories (repository_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "analysis_daily_snapshots_company_date_idx"
+    ON "analysis_daily_snapshots" (company_id, snapshot_date);
+
+CREATE INDEX IF NOT EXISTS "analysis_daily_snapshots_repository_date_idx"
+    ON "analysis_daily_snapshots" (repository_id, snapshot_date);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "vulnerabilities_security_tool_rule_id";
+
+ALTER TABLE "vulnerabilities" DROP COLUMN IF EXISTS "rule_id";
+
+COMMIT;
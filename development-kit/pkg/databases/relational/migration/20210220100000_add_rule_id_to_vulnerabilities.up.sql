@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE "vulnerabilities" ADD COLUMN IF NOT EXISTS "rule_id" VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS "vulnerabilities_security_tool_rule_id" ON "vulnerabilities" (security_tool, rule_id);
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "analysis_vulnerabilities_vulnerability_id_idx";
+
+DROP INDEX IF EXISTS "analysis_company_finished_at_idx";
+
+DROP INDEX IF EXISTS "analysis_repository_finished_at_idx";
+
+ALTER TABLE "companies" DROP COLUMN IF EXISTS "analysis_retention_days";
+
+COMMIT;
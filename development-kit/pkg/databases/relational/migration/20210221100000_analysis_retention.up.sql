@@ -0,0 +1,16 @@
+BEGIN;
+
+ALTER TABLE "companies"
+ADD
+    "analysis_retention_days" INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS "analysis_repository_finished_at_idx"
+    ON "analysis" (repository_id, finished_at);
+
+CREATE INDEX IF NOT EXISTS "analysis_company_finished_at_idx"
+    ON "analysis" (company_id, finished_at);
+
+CREATE INDEX IF NOT EXISTS "analysis_vulnerabilities_vulnerability_id_idx"
+    ON "analysis_vulnerabilities" (vulnerability_id);
+
+COMMIT;
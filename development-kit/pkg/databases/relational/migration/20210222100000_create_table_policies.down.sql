@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE "analysis" DROP COLUMN IF EXISTS "policy_reasons", DROP COLUMN IF EXISTS "policy_status";
+
+DROP TABLE IF EXISTS "policies";
+
+COMMIT;
@@ -0,0 +1,28 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "policies"
+(
+    "policy_id"                   UUID NOT NULL,
+    "company_id"                  UUID NOT NULL,
+    "repository_id"               UUID,
+    "name"                        VARCHAR(255) NOT NULL,
+    "max_high_vulnerabilities"    INTEGER,
+    "required_tools"              TEXT[],
+    "required_languages"          TEXT[],
+    "max_risk_accept_age_in_days" INTEGER NOT NULL DEFAULT 0,
+    "created_at"                  TIMESTAMP NOT NULL,
+    "updated_at"                  TIMESTAMP NOT NULL,
+    PRIMARY KEY (policy_id),
+    FOREIGN KEY (company_id) REFERENCES companies (company_id) ON DELETE CASCADE,
+    FOREIGN KEY (repository_id) REFERENCES repositories (repository_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "policies_company_id_idx" ON "policies" (company_id);
+
+ALTER TABLE "analysis"
+ADD
+    "policy_status" VARCHAR(255) NOT NULL DEFAULT '',
+ADD
+    "policy_reasons" TEXT[];
+
+COMMIT;
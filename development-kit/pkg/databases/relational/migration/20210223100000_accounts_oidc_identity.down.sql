@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "accounts_oidc_identity";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "oidc_subject", DROP COLUMN IF EXISTS "oidc_issuer";
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE "accounts" ADD COLUMN IF NOT EXISTS "oidc_issuer" VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE "accounts" ADD COLUMN IF NOT EXISTS "oidc_subject" VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS "accounts_oidc_identity" ON "accounts" ("oidc_issuer", "oidc_subject")
+    WHERE "oidc_subject" <> '';
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "two_factor_last_step";
+
+COMMIT;
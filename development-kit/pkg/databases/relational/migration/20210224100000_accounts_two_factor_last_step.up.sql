@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "accounts" ADD COLUMN IF NOT EXISTS "two_factor_last_step" BIGINT NOT NULL DEFAULT 0;
+
+COMMIT;
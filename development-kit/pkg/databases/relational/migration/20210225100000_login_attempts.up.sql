@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "login_attempts"
+(
+    "key"             VARCHAR(255) NOT NULL,
+    "failures"        INTEGER NOT NULL DEFAULT 0,
+    "next_attempt_at" TIMESTAMP,
+    "locked_until"    TIMESTAMP,
+    "expires_at"      TIMESTAMP NOT NULL,
+    PRIMARY KEY (key)
+);
+
+CREATE INDEX IF NOT EXISTS "login_attempts_expires_at_idx" ON "login_attempts" (expires_at);
+
+DELETE FROM "cache" WHERE key LIKE 'login-attempts:%';
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE "analysis_daily_snapshots" DROP COLUMN IF EXISTS "analysis_id";
+
+ALTER TABLE "analysis"
+ALTER COLUMN
+    "created_at" TYPE DATE,
+ALTER COLUMN
+    "finished_at" TYPE DATE;
+
+COMMIT;
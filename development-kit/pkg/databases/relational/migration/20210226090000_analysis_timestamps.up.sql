@@ -0,0 +1,21 @@
+BEGIN;
+
+ALTER TABLE "analysis"
+ALTER COLUMN
+    "created_at" TYPE TIMESTAMP,
+ALTER COLUMN
+    "finished_at" TYPE TIMESTAMP;
+
+ALTER TABLE "analysis_daily_snapshots"
+ADD
+    "analysis_id" UUID;
+
+UPDATE "analysis_daily_snapshots" AS snapshots
+SET analysis_id = (SELECT analysis.analysis_id
+                   FROM "analysis"
+                   WHERE analysis.repository_id = snapshots.repository_id
+                     AND DATE_TRUNC('day', analysis.finished_at) = snapshots.snapshot_date
+                   ORDER BY analysis.finished_at DESC, analysis.created_at DESC, analysis.analysis_id DESC
+                   LIMIT 1);
+
+COMMIT;
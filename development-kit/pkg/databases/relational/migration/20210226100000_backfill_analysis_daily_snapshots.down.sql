@@ -0,0 +1,5 @@
+BEGIN;
+
+DELETE FROM "analysis_daily_snapshots";
+
+COMMIT;
@@ -0,0 +1,30 @@
+BEGIN;
+
+INSERT INTO "analysis_daily_snapshots" (snapshot_date, analysis_id, company_id, repository_id, repository_name,
+                                        finished_at, severity, language, security_tool, type, total)
+SELECT latest.snapshot_date, latest.analysis_id, latest.company_id, latest.repository_id, latest.repository_name,
+       latest.finished_at,
+       COALESCE(vulnerabilities.severity, ''), COALESCE(vulnerabilities.language, ''),
+       COALESCE(vulnerabilities.security_tool, ''), COALESCE(vulnerabilities.type, ''),
+       COUNT(vulnerabilities.vulnerability_id)
+FROM (
+         SELECT DISTINCT ON (analysis.repository_id, DATE_TRUNC('day', analysis.finished_at))
+             analysis.analysis_id, analysis.company_id, analysis.repository_id, analysis.repository_name,
+             analysis.finished_at, DATE_TRUNC('day', analysis.finished_at) AS snapshot_date
+         FROM "analysis"
+                  JOIN "repositories" ON repositories.repository_id = analysis.repository_id
+                  JOIN "companies" ON companies.company_id = analysis.company_id
+         ORDER BY analysis.repository_id, DATE_TRUNC('day', analysis.finished_at), analysis.finished_at DESC,
+                  analysis.created_at DESC, analysis.analysis_id DESC
+     ) AS latest
+         LEFT JOIN "analysis_vulnerabilities" ON analysis_vulnerabilities.analysis_id = latest.analysis_id
+         LEFT JOIN "vulnerabilities" ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id
+WHERE NOT EXISTS(SELECT 1
+                 FROM "analysis_daily_snapshots" AS snapshots
+                 WHERE snapshots.repository_id = latest.repository_id
+                   AND snapshots.snapshot_date = latest.snapshot_date)
+GROUP BY latest.snapshot_date, latest.analysis_id, latest.company_id, latest.repository_id, latest.repository_name, latest.finished_at,
+         COALESCE(vulnerabilities.severity, ''), COALESCE(vulnerabilities.language, ''),
+         COALESCE(vulnerabilities.security_tool, ''), COALESCE(vulnerabilities.type, '');
+
+COMMIT;
@@ -24,8 +24,13 @@ type IAccount interface {
 	Create(account *authEntities.Account) error
 	GetByAccountID(accountID uuid.UUID) (*authEntities.Account, error)
 	GetByEmail(email string) (*authEntities.Account, error)
+	GetByOIDCIdentity(issuer, subject string) (*authEntities.Account, error)
 	Update(account *authEntities.Account) error
 	UpdatePassword(account *authEntities.Account) error
+	UpdateTwoFactor(account *authEntities.Account) error
+	UpdateTwoFactorStep(account *authEntities.Account) (bool, error)
+	UpdateProvisioning(account *authEntities.Account) error
+	UpdateOIDCIdentity(account *authEntities.Account) error
 	GetByUsername(username string) (*authEntities.Account, error)
 	DeleteAccount(accountID uuid.UUID) error
 }
@@ -60,6 +65,13 @@ func (a *Account) GetByEmail(email string) (*authEntities.Account, error) {
 	return account, result.GetError()
 }
 
+func (a *Account) GetByOIDCIdentity(issuer, subject string) (*authEntities.Account, error) {
+	account := &authEntities.Account{}
+	filter := a.databaseRead.SetFilter(map[string]interface{}{"oidc_issuer": issuer, "oidc_subject": subject})
+	result := a.databaseRead.Find(account, filter, account.GetTable())
+	return account, result.GetError()
+}
+
 func (a *Account) Update(account *authEntities.Account) error {
 	account.SetUpdatedAt()
 	return a.databaseWrite.Update(account.ToUpdateMap(), map[string]interface{}{"account_id": account.AccountID},
@@ -72,6 +84,30 @@ func (a *Account) UpdatePassword(account *authEntities.Account) error {
 		account.GetTable()).GetError()
 }
 
+func (a *Account) UpdateTwoFactor(account *authEntities.Account) error {
+	return a.databaseWrite.Update(account.ToUpdateTwoFactorMap(), map[string]interface{}{"account_id": account.AccountID},
+		account.GetTable()).GetError()
+}
+
+// UpdateTwoFactorStep saves the last accepted totp step only when it is newer, so concurrent logins can not reuse a code
+func (a *Account) UpdateTwoFactorStep(account *authEntities.Account) (bool, error) {
+	result := a.databaseWrite.GetConnection().Table(account.GetTable()).
+		Where("account_id = ? AND two_factor_last_step < ?", account.AccountID, account.TwoFactorLastStep).
+		Update("two_factor_last_step", account.TwoFactorLastStep)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (a *Account) UpdateProvisioning(account *authEntities.Account) error {
+	account.SetUpdatedAt()
+	return a.databaseWrite.Update(account.ToUpdateProvisioningMap(),
+		map[string]interface{}{"account_id": account.AccountID}, account.GetTable()).GetError()
+}
+
+func (a *Account) UpdateOIDCIdentity(account *authEntities.Account) error {
+	return a.databaseWrite.Update(account.ToUpdateOIDCIdentityMap(),
+		map[string]interface{}{"account_id": account.AccountID}, account.GetTable()).GetError()
+}
+
 func (a *Account) GetByUsername(username string) (*authEntities.Account, error) {
 	account := &authEntities.Account{}
 	filter := a.databaseRead.SetFilter(map[string]interface{}{"username": username})
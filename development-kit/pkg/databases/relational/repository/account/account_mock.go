@@ -0,0 +1,86 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Create(account *authEntities.Account) error {
+	args := m.MethodCalled("Create")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) GetByAccountID(accountID uuid.UUID) (*authEntities.Account, error) {
+	args := m.MethodCalled("GetByAccountID")
+	return args.Get(0).(*authEntities.Account), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetByEmail(email string) (*authEntities.Account, error) {
+	args := m.MethodCalled("GetByEmail")
+	return args.Get(0).(*authEntities.Account), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetByOIDCIdentity(issuer, subject string) (*authEntities.Account, error) {
+	args := m.MethodCalled("GetByOIDCIdentity")
+	return args.Get(0).(*authEntities.Account), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) Update(account *authEntities.Account) error {
+	args := m.MethodCalled("Update")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) UpdatePassword(account *authEntities.Account) error {
+	args := m.MethodCalled("UpdatePassword")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) UpdateTwoFactor(account *authEntities.Account) error {
+	args := m.MethodCalled("UpdateTwoFactor")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) UpdateTwoFactorStep(account *authEntities.Account) (bool, error) {
+	args := m.MethodCalled("UpdateTwoFactorStep")
+	return args.Bool(0), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) UpdateProvisioning(account *authEntities.Account) error {
+	args := m.MethodCalled("UpdateProvisioning")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) UpdateOIDCIdentity(account *authEntities.Account) error {
+	args := m.MethodCalled("UpdateOIDCIdentity")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) GetByUsername(username string) (*authEntities.Account, error) {
+	args := m.MethodCalled("GetByUsername")
+	return args.Get(0).(*authEntities.Account), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) DeleteAccount(accountID uuid.UUID) error {
+	args := m.MethodCalled("DeleteAccount")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
 	"github.com/stretchr/testify/assert"
 )
 
@@ -73,6 +74,23 @@ func TestGetByEmail(t *testing.T) {
 	})
 }
 
+func TestGetByOIDCIdentity(t *testing.T) {
+	t.Run("should success get account by oidc identity with no errors", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+
+		resp := &response.Response{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(resp.SetData(&authEntities.Account{}))
+
+		repository := NewAccountRepository(mockRead, mockWrite)
+		account, err := repository.GetByOIDCIdentity("http://idp.example.com", "f3a1")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, account)
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	t.Run("should update data with no errors", func(t *testing.T) {
 		mockRead := &relational.MockRead{}
@@ -87,6 +105,73 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
+func TestUpdateTwoFactor(t *testing.T) {
+	t.Run("should update two factor data with no errors", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+
+		resp := &response.Response{}
+		mockWrite.On("Update").Return(resp)
+
+		repository := NewAccountRepository(mockRead, mockWrite)
+
+		assert.NoError(t, repository.UpdateTwoFactor(&authEntities.Account{}))
+	})
+}
+
+func TestUpdateTwoFactorStep(t *testing.T) {
+	conn, _ := gorm.Open("sqlite3", ":memory:")
+	conn.Exec("CREATE TABLE accounts (account_id TEXT, two_factor_last_step INTEGER)")
+	account := &authEntities.Account{AccountID: uuid.New()}
+	conn.Exec("INSERT INTO accounts VALUES (?, 10)", account.AccountID)
+
+	mockWrite := &relational.MockWrite{}
+	mockWrite.On("GetConnection").Return(conn)
+	repository := NewAccountRepository(&relational.MockRead{}, mockWrite)
+
+	t.Run("should update when the step is newer than the last accepted", func(t *testing.T) {
+		updated, err := repository.UpdateTwoFactorStep(&authEntities.Account{AccountID: account.AccountID,
+			TwoFactorLastStep: 11})
+		assert.NoError(t, err)
+		assert.True(t, updated)
+	})
+
+	t.Run("should not update when the step was already accepted", func(t *testing.T) {
+		updated, err := repository.UpdateTwoFactorStep(&authEntities.Account{AccountID: account.AccountID,
+			TwoFactorLastStep: 11})
+		assert.NoError(t, err)
+		assert.False(t, updated)
+	})
+}
+
+func TestUpdateProvisioning(t *testing.T) {
+	t.Run("should update provisioning data with no errors", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+
+		resp := &response.Response{}
+		mockWrite.On("Update").Return(resp)
+
+		repository := NewAccountRepository(mockRead, mockWrite)
+
+		assert.NoError(t, repository.UpdateProvisioning(&authEntities.Account{}))
+	})
+}
+
+func TestUpdateOIDCIdentity(t *testing.T) {
+	t.Run("should update oidc identity with no errors", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+
+		resp := &response.Response{}
+		mockWrite.On("Update").Return(resp)
+
+		repository := NewAccountRepository(mockRead, mockWrite)
+
+		assert.NoError(t, repository.UpdateOIDCIdentity(&authEntities.Account{}))
+	})
+}
+
 func TestGetByUsername(t *testing.T) {
 	t.Run("should success get account by username with no errors", func(t *testing.T) {
 		mockRead := &relational.MockRead{}
@@ -0,0 +1,48 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountcompany
+
+import (
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	accountEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/account"
+	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) CreateAccountCompany(_, _ uuid.UUID, _ accountEnums.Role, _ SQL.InterfaceWrite) error {
+	args := m.MethodCalled("CreateAccountCompany")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) GetAccountCompany(_, _ uuid.UUID) (*roles.AccountCompany, error) {
+	args := m.MethodCalled("GetAccountCompany")
+	return args.Get(0).(*roles.AccountCompany), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) UpdateAccountCompany(_ *roles.AccountCompany) error {
+	args := m.MethodCalled("UpdateAccountCompany")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) DeleteAccountCompany(_, _ uuid.UUID) error {
+	args := m.MethodCalled("DeleteAccountCompany")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
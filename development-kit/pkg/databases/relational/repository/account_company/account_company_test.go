@@ -121,3 +121,16 @@ func TestDeleteAccountCompany(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("CreateAccountCompany").Return(nil)
+	m.On("GetAccountCompany").Return(&roles.AccountCompany{}, nil)
+	m.On("UpdateAccountCompany").Return(nil)
+	m.On("DeleteAccountCompany").Return(nil)
+	assert.NoError(t, m.CreateAccountCompany(uuid.New(), uuid.New(), rolesEnum.Admin, nil))
+	_, err := m.GetAccountCompany(uuid.New(), uuid.New())
+	assert.NoError(t, err)
+	assert.NoError(t, m.UpdateAccountCompany(&roles.AccountCompany{}))
+	assert.NoError(t, m.DeleteAccountCompany(uuid.New(), uuid.New()))
+}
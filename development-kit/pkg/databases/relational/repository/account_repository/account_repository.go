@@ -73,7 +73,9 @@ func (a *AccountRepository) UpdateAccountRepository(accountRepository *roles.Acc
 		return err
 	}
 
-	return a.databaseWrite.Update(toUpdate.SetUpdateData(accountRepository.Role),
+	toUpdate.SetUpdateData(accountRepository.Role).SetCustomRoleID(accountRepository.CustomRoleID)
+	return a.databaseWrite.Update(map[string]interface{}{"role": toUpdate.Role,
+		"custom_role_id": toUpdate.CustomRoleID, "updated_at": toUpdate.UpdatedAt},
 		filter, accountRepository.GetTable()).GetError()
 }
 
@@ -0,0 +1,57 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountrepository
+
+import (
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) GetAccountRepository(_, _ uuid.UUID) (*roles.AccountRepository, error) {
+	args := m.MethodCalled("GetAccountRepository")
+	return args.Get(0).(*roles.AccountRepository), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) Create(_ *roles.AccountRepository, _ SQL.InterfaceWrite) error {
+	args := m.MethodCalled("Create")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) UpdateAccountRepository(_ *roles.AccountRepository) error {
+	args := m.MethodCalled("UpdateAccountRepository")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) GetOfAccount(_ uuid.UUID) ([]roles.AccountRepository, error) {
+	args := m.MethodCalled("GetOfAccount")
+	return args.Get(0).([]roles.AccountRepository), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) DeleteAccountRepository(_, _ uuid.UUID) error {
+	args := m.MethodCalled("DeleteAccountRepository")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) DeleteFromAllRepositories(_, _ uuid.UUID) error {
+	args := m.MethodCalled("DeleteFromAllRepositories")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
@@ -152,3 +152,21 @@ func TestGetOfAccount(t *testing.T) {
 		assert.Nil(t, roles)
 	})
 }
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("GetAccountRepository").Return(&roles.AccountRepository{}, nil)
+	m.On("Create").Return(nil)
+	m.On("UpdateAccountRepository").Return(nil)
+	m.On("GetOfAccount").Return([]roles.AccountRepository{}, nil)
+	m.On("DeleteAccountRepository").Return(nil)
+	m.On("DeleteFromAllRepositories").Return(nil)
+	_, err := m.GetAccountRepository(uuid.New(), uuid.New())
+	assert.NoError(t, err)
+	assert.NoError(t, m.Create(&roles.AccountRepository{}, nil))
+	assert.NoError(t, m.UpdateAccountRepository(&roles.AccountRepository{}))
+	_, err = m.GetOfAccount(uuid.New())
+	assert.NoError(t, err)
+	assert.NoError(t, m.DeleteAccountRepository(uuid.New(), uuid.New()))
+	assert.NoError(t, m.DeleteFromAllRepositories(uuid.New(), uuid.New()))
+}
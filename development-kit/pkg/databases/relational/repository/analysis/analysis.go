@@ -24,6 +24,9 @@ import (
 	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/confidence"
+	horusecEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/pagination"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
@@ -50,6 +53,21 @@ type IAnalysisRepository interface {
 		finalDate time.Time) (vulnByRepository []dashboard.VulnByRepository, err error)
 	GetVulnByTime(companyID, repositoryID uuid.UUID, initialDate,
 		finalDate time.Time) (vulnByTime []dashboard.VulnByTime, err error)
+	GetVulnBySecurityTool(companyID, repositoryID uuid.UUID, initialDate,
+		finalDate time.Time) (vulnBySecurityTool []dashboard.VulnBySecurityTool, err error)
+	GetVulnByRule(companyID, repositoryID uuid.UUID, initialDate,
+		finalDate time.Time) (vulnByRule []dashboard.VulnByRule, err error)
+	GetVulnOccurrences(companyID, repositoryID uuid.UUID, initialDate,
+		finalDate time.Time) (vulnOccurrences []dashboard.VulnOccurrence, err error)
+	GetAnalysisDates(companyID, repositoryID uuid.UUID, initialDate,
+		finalDate time.Time) (analysisDates []dashboard.AnalysisDate, err error)
+	ListAnalysis(filter *dashboard.Filter) (analysis []horusec.Analysis, totalCount int, err error)
+	ListVulnerabilities(filter *dashboard.Filter) (vulnDetails []dashboard.VulnDetails, totalCount int, err error)
+	ListHistory(filter *horusec.HistoryFilter) (*horusec.AnalysisHistory, error)
+	ListToPrune(companyID uuid.UUID, finishedBefore time.Time, size int) ([]horusec.Analysis, error)
+	DeleteByIDs(analysisIDs []uuid.UUID) error
+	DeleteOrphanVulnerabilities(size int) (int64, error)
+	UpdatePolicyVerdict(analysis *horusec.Analysis) error
 }
 
 type Repository struct {
@@ -307,12 +325,12 @@ func (ar *Repository) GetVulnByRepository(companyID, repositoryID uuid.UUID, ini
 		GetConnection().
 		Select(" MAX(analysis.repository_name) AS repository, COUNT( DISTINCT (vulnerabilities.vulnerability_id) ) AS total,"+
 			" (?) AS low, (?) AS medium, (?) AS high, (?) AS audit, (?) AS no_sec, (?) AS info",
-			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "repository_id", "LOW"),
-			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "repository_id", "MEDIUM"),
-			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "repository_id", "HIGH"),
-			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "repository_id", "AUDIT"),
-			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "repository_id", "NOSEC"),
-			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "repository_id", "INFO")).
+			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "%s.repository_id", "LOW"),
+			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "%s.repository_id", "MEDIUM"),
+			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "%s.repository_id", "HIGH"),
+			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "%s.repository_id", "AUDIT"),
+			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "%s.repository_id", "NOSEC"),
+			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "%s.repository_id", "INFO")).
 		Table("analysis").
 		Joins("JOIN analysis_vulnerabilities ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
 		Joins("JOIN vulnerabilities ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id").
@@ -325,37 +343,316 @@ func (ar *Repository) GetVulnByRepository(companyID, repositoryID uuid.UUID, ini
 	return vulnByRepository, query.Error
 }
 
+// dayOfFinishedAt groups the analysis by day, finished_at has the time since the analysis timestamps migration
+const dayOfFinishedAt = "CAST(%s.finished_at AS DATE)"
+
 func (ar *Repository) GetVulnByTime(companyID, repositoryID uuid.UUID, initialDate,
 	finalDate time.Time) (vulnByTime []dashboard.VulnByTime, err error) {
 	query := ar.databaseRead.
 		GetConnection().
-		Select("analysis.finished_at AS time, COUNT( DISTINCT (vulnerabilities.vulnerability_id) ) AS total,"+
+		Select(fmt.Sprintf(dayOfFinishedAt, "analysis")+" AS time,"+
+			" COUNT( DISTINCT (vulnerabilities.vulnerability_id) ) AS total,"+
 			" (?) AS low, (?) AS medium, (?) AS high, (?) AS audit, (?) AS no_sec, (?) AS info",
-			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "finished_at", "LOW"),
-			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "finished_at", "MEDIUM"),
-			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "finished_at", "HIGH"),
-			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "finished_at", "AUDIT"),
-			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "finished_at", "NOSEC"),
-			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, "finished_at", "INFO")).
+			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, dayOfFinishedAt, "LOW"),
+			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, dayOfFinishedAt, "MEDIUM"),
+			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, dayOfFinishedAt, "HIGH"),
+			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, dayOfFinishedAt, "AUDIT"),
+			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, dayOfFinishedAt, "NOSEC"),
+			ar.getSubQueryByAnalysis(companyID, repositoryID, initialDate, finalDate, dayOfFinishedAt, "INFO")).
 		Table("analysis").
 		Joins("JOIN analysis_vulnerabilities ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
 		Joins("JOIN vulnerabilities ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id").
-		Group("analysis.finished_at")
+		Group(fmt.Sprintf(dayOfFinishedAt, "analysis"))
 
 	query = ar.setWhereFilter(query, companyID, repositoryID, initialDate, finalDate).Find(&vulnByTime)
 
 	return vulnByTime, query.Error
 }
 
+// severitiesByRank and confidencesByRank are sorted from the lowest to the highest value
+var severitiesByRank = []string{severity.NoSec.ToString(), severity.Info.ToString(), severity.Audit.ToString(),
+	severity.Low.ToString(), severity.Medium.ToString(), severity.High.ToString()}
+var confidencesByRank = []string{confidence.Low.ToString(), confidence.Medium.ToString(),
+	confidence.High.ToString()}
+
+// GetVulnOccurrences returns when each vulnerability hash was first and last seen by repository on successful
+// analysis, false positives are ignored because they are never fixed
+func (ar *Repository) GetVulnOccurrences(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (vulnOccurrences []dashboard.VulnOccurrence, err error) {
+	query := ar.databaseRead.
+		GetConnection().
+		Select("analysis.repository_id AS repository_id, vulnerabilities.vuln_hash AS vuln_hash, "+
+			getMaxByRank("vulnerabilities.severity", severitiesByRank)+" AS severity, "+
+			getMaxByRank("vulnerabilities.confidence", confidencesByRank)+" AS confidence,"+
+			" MAX(CASE WHEN vulnerabilities.type = ? THEN 1 ELSE 0 END) AS risk_accepted,"+
+			" MIN(analysis.finished_at) AS first_seen, MAX(analysis.finished_at) AS last_seen",
+			horusecEnums.RiskAccepted).
+		Table("analysis").
+		Joins("JOIN analysis_vulnerabilities ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
+		Joins("JOIN vulnerabilities ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id").
+		Where("vulnerabilities.type <> ? AND analysis.status = ?", horusecEnums.FalsePositive, horusecEnums.Success).
+		Group("analysis.repository_id, vulnerabilities.vuln_hash")
+
+	query = ar.setWhereFilter(query, companyID, repositoryID, initialDate, finalDate).Find(&vulnOccurrences)
+
+	return vulnOccurrences, query.Error
+}
+
+// getMaxByRank returns the highest value of the column by its position in the values, instead of the alphabetical
+// order of MAX, values out of the list are ignored
+func getMaxByRank(column string, values []string) string {
+	toRank, toValue := "", ""
+	for rank, value := range values {
+		toRank += fmt.Sprintf(" WHEN '%s' THEN %d", value, rank)
+		toValue += fmt.Sprintf(" WHEN %d THEN '%s'", rank, value)
+	}
+
+	return fmt.Sprintf("CASE MAX(CASE %s%s END)%s END", column, toRank, toValue)
+}
+
+// GetAnalysisDates returns the dates of the successful analysis, an analysis that failed does not fix vulnerabilities
+func (ar *Repository) GetAnalysisDates(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (analysisDates []dashboard.AnalysisDate, err error) {
+	query := ar.databaseRead.
+		GetConnection().
+		Select("repository_id, repository_name, finished_at").
+		Table("analysis").
+		Where("status = ?", horusecEnums.Success).
+		Order("finished_at ASC")
+
+	query = ar.setWhereFilter(query, companyID, repositoryID, initialDate, finalDate).Find(&analysisDates)
+
+	return analysisDates, query.Error
+}
+
+func (ar *Repository) GetVulnBySecurityTool(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (vulnBySecurityTool []dashboard.VulnBySecurityTool, err error) {
+	query := ar.getTypeCountQuery("vulnerabilities.security_tool AS security_tool").
+		Group("vulnerabilities.security_tool").
+		Order("total DESC", true)
+
+	query = ar.setWhereFilter(query, companyID, repositoryID, initialDate, finalDate).Find(&vulnBySecurityTool)
+	for index := range vulnBySecurityTool {
+		vulnBySecurityTool[index].SetFalsePositiveRate()
+	}
+
+	return vulnBySecurityTool, query.Error
+}
+
+// GetVulnByRule returns the rules with more false positives first, limited to the 50 rules that need more tuning
+func (ar *Repository) GetVulnByRule(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (vulnByRule []dashboard.VulnByRule, err error) {
+	query := ar.getTypeCountQuery("vulnerabilities.security_tool AS security_tool, vulnerabilities.rule_id AS rule_id").
+		Group("vulnerabilities.security_tool, vulnerabilities.rule_id").
+		Order("false_positive DESC, total DESC", true).
+		Limit(50)
+
+	query = ar.setWhereFilter(query, companyID, repositoryID, initialDate, finalDate).Find(&vulnByRule)
+	for index := range vulnByRule {
+		vulnByRule[index].SetFalsePositiveRate()
+	}
+
+	return vulnByRule, query.Error
+}
+
+func (ar *Repository) getTypeCountQuery(fields string) *gorm.DB {
+	return ar.databaseRead.
+		GetConnection().
+		Select(fields+", COUNT( DISTINCT (vulnerabilities.vulnerability_id) ) AS total,"+
+			" COUNT( DISTINCT (CASE WHEN vulnerabilities.type = ? THEN vulnerabilities.vulnerability_id END) )"+
+			" AS false_positive,"+
+			" COUNT( DISTINCT (CASE WHEN vulnerabilities.type = ? THEN vulnerabilities.vulnerability_id END) )"+
+			" AS risk_accepted", horusecEnums.FalsePositive, horusecEnums.RiskAccepted).
+		Table("analysis").
+		Joins("JOIN analysis_vulnerabilities ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
+		Joins("JOIN vulnerabilities ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id")
+}
+
+// ListAnalysis returns the analysis without vulnerabilities, newest first
+func (ar *Repository) ListAnalysis(filter *dashboard.Filter) (
+	analysis []horusec.Analysis, totalCount int, err error) {
+	query := ar.setListFilter(ar.databaseRead.GetConnection().Table("analysis"), filter)
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order("analysis.finished_at DESC").Offset(filter.Offset).Limit(filter.Size).Find(&analysis)
+
+	return analysis, totalCount, query.Error
+}
+
+// ListVulnerabilities returns each vulnerability once with the data of one of its analysis
+func (ar *Repository) ListVulnerabilities(filter *dashboard.Filter) (
+	vulnDetails []dashboard.VulnDetails, totalCount int, err error) {
+	query := ar.setListFilter(ar.databaseRead.
+		GetConnection().
+		Table("analysis").
+		Joins("JOIN analysis_vulnerabilities ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
+		Joins("JOIN vulnerabilities ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id"),
+		filter)
+	err = query.Select("COUNT( DISTINCT ( vulnerabilities.vulnerability_id ) )").Count(&totalCount).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query = query.
+		Select("DISTINCT ON (vulnerabilities.vulnerability_id) vulnerabilities.*, analysis.repository_id," +
+			" analysis.repository_name, analysis.company_id, analysis.company_name, analysis.status," +
+			" analysis.errors, analysis.created_at, analysis.finished_at").
+		Order("vulnerabilities.vulnerability_id").
+		Offset(filter.Offset).
+		Limit(filter.Size).
+		Find(&vulnDetails)
+
+	return vulnDetails, totalCount, query.Error
+}
+
+// ListHistory returns the analyses of the repository newest first with the totals of their vulnerabilities
+func (ar *Repository) ListHistory(filter *horusec.HistoryFilter) (*horusec.AnalysisHistory, error) {
+	history := &horusec.AnalysisHistory{Data: []horusec.AnalysisSummary{}}
+	query := ar.databaseRead.GetConnection().Table("analysis").Where("analysis.repository_id = ?", filter.RepositoryID)
+	if filter.Branch != "" {
+		query = query.Where("analysis.branch = ?", filter.Branch)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("analysis.status = ?", filter.Status)
+	}
+
+	if err := query.Count(&history.TotalItems).Error; err != nil {
+		return nil, err
+	}
+
+	columns := "analysis.analysis_id, analysis.branch, analysis.commit_hash, analysis.status, analysis.created_at," +
+		" analysis.finished_at"
+	return history, query.
+		Select(columns + ", COUNT(vulnerabilities.vulnerability_id) AS total, " + ar.getCountBySeverity()).
+		Joins("LEFT JOIN analysis_vulnerabilities ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
+		Joins("LEFT JOIN vulnerabilities ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id").
+		Group(columns).
+		Order("analysis.finished_at DESC").
+		Limit(filter.GetSize()).
+		Offset(pagination.GetSkip(int64(filter.Page), int64(filter.GetSize()))).
+		Find(&history.Data).Error
+}
+
+func (ar *Repository) getCountBySeverity() string {
+	return ar.countBySeverity(severity.Low, "low") + ", " +
+		ar.countBySeverity(severity.Medium, "medium") + ", " +
+		ar.countBySeverity(severity.High, "high") + ", " +
+		ar.countBySeverity(severity.Audit, "audit") + ", " +
+		ar.countBySeverity(severity.NoSec, "no_sec") + ", " +
+		ar.countBySeverity(severity.Info, "info")
+}
+
+func (ar *Repository) countBySeverity(vulnSeverity severity.Severity, alias string) string {
+	return fmt.Sprintf("SUM(CASE WHEN vulnerabilities.severity = '%s' THEN 1 ELSE 0 END) AS %s",
+		vulnSeverity.ToString(), alias)
+}
+
+// ListToPrune returns the analyses of the company finished before the date newest first, the latest analysis of
+// each repository is never returned because it is the current state of the repository, neither the analyses that
+// daily snapshots were created from because they keep the history of the analytics
+func (ar *Repository) ListToPrune(companyID uuid.UUID, finishedBefore time.Time, size int) (
+	analysis []horusec.Analysis, err error) {
+	query := ar.databaseRead.
+		GetConnection().
+		Select("analysis_id, company_id, repository_id, repository_name, finished_at").
+		Table("analysis").
+		Where("analysis.company_id = ? AND analysis.finished_at < ?", companyID, finishedBefore).
+		Where("EXISTS (SELECT 1 FROM analysis AS newer WHERE newer.repository_id = analysis.repository_id" +
+			" AND newer.finished_at > analysis.finished_at)").
+		Where("NOT EXISTS (SELECT 1 FROM analysis_daily_snapshots AS snapshots" +
+			" WHERE snapshots.analysis_id = analysis.analysis_id)").
+		Order("finished_at DESC, created_at DESC, analysis_id DESC").
+		Limit(size).
+		Find(&analysis)
+
+	return analysis, query.Error
+}
+
+// DeleteByIDs removes the analyses with their relations to the vulnerabilities, the vulnerabilities are kept
+func (ar *Repository) DeleteByIDs(analysisIDs []uuid.UUID) error {
+	tx := ar.databaseWrite.StartTransaction()
+	conn := tx.GetConnection()
+	err := conn.Table("analysis_vulnerabilities").Where("analysis_id IN (?)", analysisIDs).Delete(nil).Error
+	if err == nil {
+		err = conn.Table("analysis").Where("analysis_id IN (?)", analysisIDs).Delete(nil).Error
+	}
+
+	if err != nil {
+		_ = tx.RollbackTransaction()
+		return err
+	}
+
+	return tx.CommitTransaction().GetError()
+}
+
+// DeleteOrphanVulnerabilities removes up to size vulnerabilities without analysis and returns how many were removed
+func (ar *Repository) DeleteOrphanVulnerabilities(size int) (int64, error) {
+	orphans := ar.databaseWrite.
+		GetConnection().
+		Select("vulnerabilities.vulnerability_id").
+		Table("vulnerabilities").
+		Where("NOT EXISTS (SELECT 1 FROM analysis_vulnerabilities" +
+			" WHERE analysis_vulnerabilities.vulnerability_id = vulnerabilities.vulnerability_id)").
+		Limit(size).
+		SubQuery()
+	query := ar.databaseWrite.GetConnection().Table("vulnerabilities").Where("vulnerability_id IN ?", orphans).
+		Delete(nil)
+
+	return query.RowsAffected, query.Error
+}
+
+func (ar *Repository) UpdatePolicyVerdict(analysis *horusec.Analysis) error {
+	return ar.databaseWrite.Update(map[string]interface{}{
+		"policy_status":  analysis.PolicyStatus,
+		"policy_reasons": analysis.PolicyReasons,
+	}, map[string]interface{}{"analysis_id": analysis.ID}, analysis.GetTable()).GetError()
+}
+
+func (ar *Repository) setListFilter(query *gorm.DB, filter *dashboard.Filter) *gorm.DB {
+	for column, value := range map[string]uuid.UUID{"analysis.company_id": filter.CompanyID,
+		"analysis.repository_id": filter.RepositoryID, "analysis.analysis_id": filter.AnalysisID} {
+		if value != uuid.Nil {
+			query = query.Where(column+" = ?", value)
+		}
+	}
+
+	for column, value := range map[string]string{"analysis.status": filter.Status, "analysis.branch": filter.Branch,
+		"vulnerabilities.severity": filter.Severity, "vulnerabilities.type": filter.Type,
+		"vulnerabilities.language": filter.Language, "vulnerabilities.security_tool": filter.SecurityTool} {
+		if value != "" {
+			query = query.Where(column+" = ?", value)
+		}
+	}
+
+	return ar.setListDateFilter(query, filter)
+}
+
+func (ar *Repository) setListDateFilter(query *gorm.DB, filter *dashboard.Filter) *gorm.DB {
+	if !filter.InitialDate.IsZero() {
+		query = query.Where("analysis.finished_at >= ?", filter.InitialDate)
+	}
+
+	if !filter.FinalDate.IsZero() {
+		query = query.Where("analysis.finished_at <= ?", filter.FinalDate)
+	}
+
+	return query
+}
+
+// getSubQueryByAnalysis counts the vulnerabilities of the severity with the same column of the analysis, the column
+// has a %s placeholder for the table alias
 func (ar *Repository) getSubQueryByAnalysis(companyID, repositoryID uuid.UUID, initialDate,
-	finalDate time.Time, field, severity string) *gorm.SqlExpr {
+	finalDate time.Time, column, severity string) *gorm.SqlExpr {
 	subQuery := ar.databaseRead.
 		GetConnection().
 		Select("COUNT( DISTINCT (vuln.vulnerability_id) )").
 		Table("analysis AS ana").
 		Joins("JOIN analysis_vulnerabilities ON ana.analysis_id = analysis_vulnerabilities.analysis_id").
 		Joins("JOIN vulnerabilities AS vuln ON vuln.vulnerability_id = analysis_vulnerabilities.vulnerability_id").
-		Where(fmt.Sprintf("ana.%s = analysis.%s AND vuln.severity = ?", field, field), severity)
+		Where(fmt.Sprintf(column, "ana")+" = "+fmt.Sprintf(column, "analysis")+" AND vuln.severity = ?", severity)
 
 	return ar.setWhereFilter(subQuery, companyID, repositoryID, initialDate, finalDate).SubQuery()
 }
@@ -69,6 +69,18 @@ func (m *Mock) GetVulnBySeverity(companyID, repositoryID uuid.UUID, initialDate,
 	return args.Get(0).([]dashboard.VulnBySeverity), mockUtils.ReturnNilOrError(args, 1)
 }
 
+func (m *Mock) GetVulnBySecurityTool(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (vulnBySecurityTool []dashboard.VulnBySecurityTool, err error) {
+	args := m.MethodCalled("GetVulnBySecurityTool")
+	return args.Get(0).([]dashboard.VulnBySecurityTool), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetVulnByRule(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (vulnByRule []dashboard.VulnByRule, err error) {
+	args := m.MethodCalled("GetVulnByRule")
+	return args.Get(0).([]dashboard.VulnByRule), mockUtils.ReturnNilOrError(args, 1)
+}
+
 func (m *Mock) GetVulnByDeveloper(companyID, repositoryID uuid.UUID, initialDate,
 	finalDate time.Time) (vulnByDeveloper []dashboard.VulnByDeveloper, err error) {
 	args := m.MethodCalled("GetVulnByDeveloper")
@@ -92,3 +104,51 @@ func (m *Mock) GetVulnByTime(companyID, repositoryID uuid.UUID, initialDate,
 	args := m.MethodCalled("GetVulnByTime")
 	return args.Get(0).([]dashboard.VulnByTime), mockUtils.ReturnNilOrError(args, 1)
 }
+
+func (m *Mock) GetVulnOccurrences(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (vulnOccurrences []dashboard.VulnOccurrence, err error) {
+	args := m.MethodCalled("GetVulnOccurrences")
+	return args.Get(0).([]dashboard.VulnOccurrence), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetAnalysisDates(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (analysisDates []dashboard.AnalysisDate, err error) {
+	args := m.MethodCalled("GetAnalysisDates")
+	return args.Get(0).([]dashboard.AnalysisDate), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) ListAnalysis(_ *dashboard.Filter) (analysis []horusec.Analysis, totalCount int, err error) {
+	args := m.MethodCalled("ListAnalysis")
+	return args.Get(0).([]horusec.Analysis), args.Get(1).(int), mockUtils.ReturnNilOrError(args, 2)
+}
+
+func (m *Mock) ListVulnerabilities(_ *dashboard.Filter) (
+	vulnDetails []dashboard.VulnDetails, totalCount int, err error) {
+	args := m.MethodCalled("ListVulnerabilities")
+	return args.Get(0).([]dashboard.VulnDetails), args.Get(1).(int), mockUtils.ReturnNilOrError(args, 2)
+}
+
+func (m *Mock) ListHistory(_ *horusec.HistoryFilter) (*horusec.AnalysisHistory, error) {
+	args := m.MethodCalled("ListHistory")
+	return args.Get(0).(*horusec.AnalysisHistory), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) ListToPrune(_ uuid.UUID, _ time.Time, _ int) ([]horusec.Analysis, error) {
+	args := m.MethodCalled("ListToPrune")
+	return args.Get(0).([]horusec.Analysis), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) DeleteByIDs(_ []uuid.UUID) error {
+	args := m.MethodCalled("DeleteByIDs")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) DeleteOrphanVulnerabilities(_ int) (int64, error) {
+	args := m.MethodCalled("DeleteOrphanVulnerabilities")
+	return args.Get(0).(int64), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) UpdatePolicyVerdict(_ *horusec.Analysis) error {
+	args := m.MethodCalled("UpdatePolicyVerdict")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
@@ -30,7 +30,12 @@ import (
 	rolesEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/account"
 	enumHorusec "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/tools"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
 	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
+	"github.com/stretchr/testify/assert"
 )
 
 var accountID = uuid.New()
@@ -169,9 +174,20 @@ func TestMock(t *testing.T) {
 		mock.On("GetRepositoryCount").Return(0, nil)
 		mock.On("GetVulnBySeverity").Return([]dashboardEntities.VulnBySeverity{}, nil)
 		mock.On("GetVulnByDeveloper").Return([]dashboardEntities.VulnByDeveloper{}, nil)
+		mock.On("GetVulnBySecurityTool").Return([]dashboardEntities.VulnBySecurityTool{}, nil)
+		mock.On("GetVulnByRule").Return([]dashboardEntities.VulnByRule{}, nil)
 		mock.On("GetVulnByLanguage").Return([]dashboardEntities.VulnByLanguage{}, nil)
 		mock.On("GetVulnByRepository").Return([]dashboardEntities.VulnByRepository{}, nil)
 		mock.On("GetVulnByTime").Return([]dashboardEntities.VulnByTime{}, nil)
+		mock.On("GetVulnOccurrences").Return([]dashboardEntities.VulnOccurrence{}, nil)
+		mock.On("GetAnalysisDates").Return([]dashboardEntities.AnalysisDate{}, nil)
+		mock.On("ListAnalysis").Return([]horusec.Analysis{}, 0, nil)
+		mock.On("ListVulnerabilities").Return([]dashboardEntities.VulnDetails{}, 0, nil)
+		mock.On("ListHistory").Return(&horusec.AnalysisHistory{}, nil)
+		mock.On("ListToPrune").Return([]horusec.Analysis{}, nil)
+		mock.On("DeleteByIDs").Return(nil)
+		mock.On("DeleteOrphanVulnerabilities").Return(int64(0), nil)
+		mock.On("UpdatePolicyVerdict").Return(nil)
 		var tx SQL.InterfaceWrite
 		_ = mock.Create(&horusec.Analysis{}, tx)
 		_, _ = mock.GetByID(uuid.New())
@@ -181,9 +197,357 @@ func TestMock(t *testing.T) {
 		_, _ = mock.GetRepositoryCount(uuid.New(), uuid.New(), time.Now(), time.Now())
 		_, _ = mock.GetVulnBySeverity(uuid.New(), uuid.New(), time.Now(), time.Now())
 		_, _ = mock.GetVulnByDeveloper(uuid.New(), uuid.New(), time.Now(), time.Now())
+		_, _ = mock.GetVulnBySecurityTool(uuid.New(), uuid.New(), time.Now(), time.Now())
+		_, _ = mock.GetVulnByRule(uuid.New(), uuid.New(), time.Now(), time.Now())
 		_, _ = mock.GetVulnByLanguage(uuid.New(), uuid.New(), time.Now(), time.Now())
 		_, _ = mock.GetVulnByRepository(uuid.New(), uuid.New(), time.Now(), time.Now())
 		_, _ = mock.GetVulnByTime(uuid.New(), uuid.New(), time.Now(), time.Now())
+		_, _ = mock.GetVulnOccurrences(uuid.New(), uuid.New(), time.Now(), time.Now())
+		_, _ = mock.GetAnalysisDates(uuid.New(), uuid.New(), time.Now(), time.Now())
+		_, _, _ = mock.ListAnalysis(&dashboardEntities.Filter{})
+		_, _, _ = mock.ListVulnerabilities(&dashboardEntities.Filter{})
+		_, _ = mock.ListHistory(&horusec.HistoryFilter{})
+		_, _ = mock.ListToPrune(uuid.New(), time.Now(), 1)
+		_ = mock.DeleteByIDs([]uuid.UUID{uuid.New()})
+		_, _ = mock.DeleteOrphanVulnerabilities(1)
+		_ = mock.UpdatePolicyVerdict(&horusec.Analysis{})
+	})
+}
+
+func TestGetAnalysisDates(t *testing.T) {
+	t.Run("should return the successful analysis dates of the repository sorted", func(t *testing.T) {
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		assert.NoError(t, conn.Table("analysis").AutoMigrate(&horusec.Analysis{}).Error)
+
+		repositoryID := uuid.New()
+		for _, analysis := range []*horusec.Analysis{
+			{ID: uuid.New(), RepositoryID: repositoryID, RepositoryName: "test", Status: enumHorusec.Success,
+				FinishedAt: getCreatedAtTime().AddDate(0, 0, 1)},
+			{ID: uuid.New(), RepositoryID: repositoryID, Status: enumHorusec.Success, FinishedAt: getCreatedAtTime()},
+			{ID: uuid.New(), RepositoryID: repositoryID, Status: enumHorusec.Error,
+				FinishedAt: getCreatedAtTime().AddDate(0, 0, 2)},
+			{ID: uuid.New(), RepositoryID: uuid.New(), Status: enumHorusec.Success, FinishedAt: getCreatedAtTime()},
+		} {
+			assert.NoError(t, conn.Table("analysis").Create(analysis).Error)
+		}
+
+		mockRead := &SQL.MockRead{}
+		mockRead.On("GetConnection").Return(conn)
+
+		analysisDates, err := NewAnalysisRepository(mockRead, nil).GetAnalysisDates(uuid.Nil, repositoryID,
+			time.Time{}, time.Time{})
+
+		assert.NoError(t, err)
+		assert.Len(t, analysisDates, 2)
+		assert.True(t, analysisDates[0].FinishedAt.Before(analysisDates[1].FinishedAt))
+		assert.Equal(t, "test", analysisDates[1].RepositoryName)
+	})
+}
+
+func TestGetMaxByRank(t *testing.T) {
+	t.Run("should return the highest severity and confidence instead of the alphabetical max", func(t *testing.T) {
+		conn := newHistoryConn(t)
+		for _, vulnerability := range []*horusec.Vulnerability{
+			{VulnerabilityID: uuid.New(), Severity: severity.High, Confidence: "HIGH"},
+			{VulnerabilityID: uuid.New(), Severity: severity.Low, Confidence: "MEDIUM"},
+			{VulnerabilityID: uuid.New(), Severity: severity.Info, Confidence: "LOW"},
+		} {
+			assert.NoError(t, conn.Table("vulnerabilities").Create(vulnerability).Error)
+		}
+
+		var highestSeverity, highestConfidence string
+		err := conn.Table("vulnerabilities").Select(getMaxByRank("severity", severitiesByRank)+", "+
+			getMaxByRank("confidence", confidencesByRank)).Row().Scan(&highestSeverity, &highestConfidence)
+
+		assert.NoError(t, err)
+		assert.Equal(t, severity.High.ToString(), highestSeverity)
+		assert.Equal(t, "HIGH", highestConfidence)
+	})
+}
+
+func TestListAnalysis(t *testing.T) {
+	conn, err := gorm.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Table("analysis").AutoMigrate(&horusec.Analysis{}).Error)
+
+	companyID := uuid.New()
+	analysis := []*horusec.Analysis{
+		{ID: uuid.New(), CompanyID: companyID, Branch: "main", FinishedAt: getCreatedAtTime()},
+		{ID: uuid.New(), CompanyID: companyID, Branch: "develop", FinishedAt: getCreatedAtTime().Add(time.Hour)},
+		{ID: uuid.New(), CompanyID: companyID, Branch: "main", FinishedAt: getCreatedAtTime().Add(2 * time.Hour)},
+		{ID: uuid.New(), CompanyID: uuid.New(), Branch: "main", FinishedAt: getCreatedAtTime()},
+	}
+	for _, item := range analysis {
+		assert.NoError(t, conn.Table("analysis").Create(item).Error)
+	}
+
+	mockRead := &SQL.MockRead{}
+	mockRead.On("GetConnection").Return(conn)
+	repository := NewAnalysisRepository(mockRead, nil)
+
+	t.Run("should list the analysis of the company newest first with the total count", func(t *testing.T) {
+		result, totalCount, err := repository.ListAnalysis(&dashboardEntities.Filter{CompanyID: companyID, Size: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, totalCount)
+		assert.Len(t, result, 2)
+		assert.Equal(t, analysis[2].ID, result[0].ID)
+		assert.Equal(t, analysis[1].ID, result[1].ID)
+	})
+
+	t.Run("should filter by branch and dates with offset", func(t *testing.T) {
+		result, totalCount, err := repository.ListAnalysis(&dashboardEntities.Filter{CompanyID: companyID,
+			Branch: "main", InitialDate: getCreatedAtTime(), FinalDate: getCreatedAtTime().Add(3 * time.Hour),
+			Offset: 1, Size: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, totalCount)
+		assert.Len(t, result, 1)
+		assert.Equal(t, analysis[0].ID, result[0].ID)
+	})
+
+	t.Run("should return error when query fails", func(t *testing.T) {
+		brokenConn, _ := gorm.Open("sqlite3", ":memory:")
+		brokenRead := &SQL.MockRead{}
+		brokenRead.On("GetConnection").Return(brokenConn)
+
+		_, _, err := NewAnalysisRepository(brokenRead, nil).ListAnalysis(&dashboardEntities.Filter{Size: 10})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetVulnBySecurityToolAndRule(t *testing.T) {
+	conn, err := gorm.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Table("analysis").AutoMigrate(&horusec.Analysis{}).Error)
+	assert.NoError(t, conn.Table("analysis_vulnerabilities").AutoMigrate(&horusec.AnalysisVulnerabilities{}).Error)
+	assert.NoError(t, conn.Table("vulnerabilities").AutoMigrate(&horusec.Vulnerability{}).Error)
+
+	companyID := uuid.New()
+	analysis := &horusec.Analysis{ID: uuid.New(), CompanyID: companyID, FinishedAt: getCreatedAtTime()}
+	assert.NoError(t, conn.Table("analysis").Create(analysis).Error)
+	vulnerabilities := []*horusec.Vulnerability{
+		{VulnerabilityID: uuid.New(), SecurityTool: tools.GoSec, RuleID: "G101", Type: enumHorusec.FalsePositive},
+		{VulnerabilityID: uuid.New(), SecurityTool: tools.GoSec, RuleID: "G101", Type: enumHorusec.Vulnerability},
+		{VulnerabilityID: uuid.New(), SecurityTool: tools.GoSec, RuleID: "G104", Type: enumHorusec.RiskAccepted},
+		{VulnerabilityID: uuid.New(), SecurityTool: tools.Bandit, RuleID: "B101", Type: enumHorusec.Vulnerability},
+	}
+	for _, vulnerability := range vulnerabilities {
+		assert.NoError(t, conn.Table("vulnerabilities").Create(vulnerability).Error)
+		assert.NoError(t, conn.Table("analysis_vulnerabilities").Create(&horusec.AnalysisVulnerabilities{
+			AnalysisID: analysis.ID, VulnerabilityID: vulnerability.VulnerabilityID}).Error)
+	}
+
+	mockRead := &SQL.MockRead{}
+	mockRead.On("GetConnection").Return(conn)
+	repository := NewAnalysisRepository(mockRead, nil)
+
+	t.Run("should return the vulnerabilities by security tool with the false positive rate", func(t *testing.T) {
+		result, err := repository.GetVulnBySecurityTool(companyID, uuid.Nil, time.Time{}, time.Time{})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, dashboardEntities.VulnBySecurityTool{SecurityTool: tools.GoSec.ToString(), Total: 3,
+			FalsePositive: 1, RiskAccepted: 1, FalsePositiveRate: 33.33}, result[0])
+	})
+
+	t.Run("should return the rules with more false positives first", func(t *testing.T) {
+		result, err := repository.GetVulnByRule(companyID, uuid.Nil, time.Time{}, time.Time{})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 3)
+		assert.Equal(t, "G101", result[0].RuleID)
+		assert.Equal(t, 2, result[0].Total)
+		assert.Equal(t, float64(50), result[0].FalsePositiveRate)
+	})
+
+	t.Run("should return error when query fails", func(t *testing.T) {
+		brokenConn, _ := gorm.Open("sqlite3", ":memory:")
+		brokenRead := &SQL.MockRead{}
+		brokenRead.On("GetConnection").Return(brokenConn)
+
+		_, err := NewAnalysisRepository(brokenRead, nil).GetVulnByRule(companyID, uuid.Nil, time.Time{}, time.Time{})
+
+		assert.Error(t, err)
+	})
+}
+
+func newHistoryConn(t *testing.T) *gorm.DB {
+	conn, err := gorm.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Table("analysis").AutoMigrate(&horusec.Analysis{}).Error)
+	assert.NoError(t, conn.Table("analysis_vulnerabilities").AutoMigrate(&horusec.AnalysisVulnerabilities{}).Error)
+	assert.NoError(t, conn.Table("vulnerabilities").AutoMigrate(&horusec.Vulnerability{}).Error)
+	assert.NoError(t, conn.Table("analysis_daily_snapshots").AutoMigrate(&dashboardEntities.DailySnapshot{}).Error)
+
+	return conn
+}
+
+func newHistoryRepository(conn *gorm.DB) IAnalysisRepository {
+	mockRead := &SQL.MockRead{}
+	mockRead.On("GetConnection").Return(conn)
+	mockWrite := &SQL.MockWrite{}
+	mockWrite.On("GetConnection").Return(conn)
+	mockWrite.On("StartTransaction").Return(mockWrite)
+	mockWrite.On("CommitTransaction").Return(&response.Response{})
+	mockWrite.On("RollbackTransaction").Return(&response.Response{})
+
+	return NewAnalysisRepository(mockRead, mockWrite)
+}
+
+func createHistoryAnalysis(t *testing.T, conn *gorm.DB, repositoryID uuid.UUID, finishedAt time.Time,
+	vulnerabilities ...*horusec.Vulnerability) *horusec.Analysis {
+	analysis := &horusec.Analysis{ID: uuid.New(), CompanyID: companyID, RepositoryID: repositoryID,
+		Branch: "main", Status: enumHorusec.Success, CreatedAt: finishedAt, FinishedAt: finishedAt}
+	assert.NoError(t, conn.Table("analysis").Create(analysis.GetAnalysisWithoutAnalysisVulnerabilities()).Error)
+
+	for _, vulnerability := range vulnerabilities {
+		if conn.Table("vulnerabilities").Where("vulnerability_id = ?", vulnerability.VulnerabilityID).
+			Find(&horusec.Vulnerability{}).RecordNotFound() {
+			assert.NoError(t, conn.Table("vulnerabilities").Create(vulnerability).Error)
+		}
+		assert.NoError(t, conn.Table("analysis_vulnerabilities").Create(&horusec.AnalysisVulnerabilities{
+			AnalysisID: analysis.ID, VulnerabilityID: vulnerability.VulnerabilityID}).Error)
+	}
+
+	return analysis
+}
+
+func TestListHistory(t *testing.T) {
+	conn := newHistoryConn(t)
+	repositoryID := uuid.New()
+	high := &horusec.Vulnerability{VulnerabilityID: uuid.New(), Severity: severity.High}
+	low := &horusec.Vulnerability{VulnerabilityID: uuid.New(), Severity: severity.Low}
+	older := createHistoryAnalysis(t, conn, repositoryID, getCreatedAtTime(), high)
+	newer := createHistoryAnalysis(t, conn, repositoryID, getCreatedAtTime().Add(time.Hour), high, low)
+	createHistoryAnalysis(t, conn, uuid.New(), getCreatedAtTime(), high)
+	repository := newHistoryRepository(conn)
+
+	t.Run("should list the analyses of the repository newest first with the totals", func(t *testing.T) {
+		history, err := repository.ListHistory(&horusec.HistoryFilter{RepositoryID: repositoryID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, history.TotalItems)
+		assert.Len(t, history.Data, 2)
+		assert.Equal(t, newer.ID, history.Data[0].ID)
+		assert.Equal(t, 2, history.Data[0].TotalVulnerabilities)
+		assert.Equal(t, 1, history.Data[0].High)
+		assert.Equal(t, 1, history.Data[0].Low)
+		assert.Equal(t, "main", history.Data[0].Branch)
+		assert.Equal(t, enumHorusec.Success, history.Data[0].Status)
+	})
+
+	t.Run("should paginate and filter by branch and status", func(t *testing.T) {
+		history, err := repository.ListHistory(&horusec.HistoryFilter{RepositoryID: repositoryID, Branch: "main",
+			Status: enumHorusec.Success, Page: 2, Size: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, history.TotalItems)
+		assert.Len(t, history.Data, 1)
+		assert.Equal(t, older.ID, history.Data[0].ID)
+	})
+
+	t.Run("should return empty when the branch has no analysis", func(t *testing.T) {
+		history, err := repository.ListHistory(&horusec.HistoryFilter{RepositoryID: repositoryID, Branch: "other"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, history.TotalItems)
+		assert.Empty(t, history.Data)
+	})
+
+	t.Run("should return error when query fails", func(t *testing.T) {
+		brokenConn, _ := gorm.Open("sqlite3", ":memory:")
+
+		_, err := newHistoryRepository(brokenConn).ListHistory(&horusec.HistoryFilter{RepositoryID: repositoryID})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRetention(t *testing.T) {
+	conn := newHistoryConn(t)
+	repositoryID := uuid.New()
+	shared := &horusec.Vulnerability{VulnerabilityID: uuid.New()}
+	fixed := &horusec.Vulnerability{VulnerabilityID: uuid.New()}
+	oldest := createHistoryAnalysis(t, conn, repositoryID, getCreatedAtTime(), shared, fixed)
+	old := createHistoryAnalysis(t, conn, repositoryID, getCreatedAtTime().Add(time.Hour), shared)
+	latest := createHistoryAnalysis(t, conn, repositoryID, getCreatedAtTime().Add(2*time.Hour), shared)
+	onlyAnalysis := createHistoryAnalysis(t, conn, uuid.New(), getCreatedAtTime())
+	repository := newHistoryRepository(conn)
+
+	t.Run("should list the analyses to prune newest first keeping the latest of each repository", func(t *testing.T) {
+		analysis, err := repository.ListToPrune(companyID, getCreatedAtTime().AddDate(0, 0, 1), 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, analysis, 2)
+		assert.Equal(t, old.ID, analysis[0].ID)
+		assert.Equal(t, oldest.ID, analysis[1].ID)
+		for index := range analysis {
+			assert.NotEqual(t, latest.ID, analysis[index].ID)
+			assert.NotEqual(t, onlyAnalysis.ID, analysis[index].ID)
+		}
+	})
+
+	t.Run("should list only the analyses finished before the date", func(t *testing.T) {
+		analysis, err := repository.ListToPrune(companyID, getCreatedAtTime().Add(time.Minute), 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, analysis, 1)
+		assert.Equal(t, oldest.ID, analysis[0].ID)
+	})
+
+	t.Run("should not list the analyses that daily snapshots were created from", func(t *testing.T) {
+		snapshot := &dashboardEntities.DailySnapshot{SnapshotDate: old.FinishedAt, RepositoryID: repositoryID,
+			AnalysisID: old.ID}
+		assert.NoError(t, conn.Table("analysis_daily_snapshots").Create(snapshot).Error)
+		defer conn.Table("analysis_daily_snapshots").Delete(&dashboardEntities.DailySnapshot{})
+
+		analysis, err := repository.ListToPrune(companyID, getCreatedAtTime().AddDate(0, 0, 1), 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, analysis, 1)
+		assert.Equal(t, oldest.ID, analysis[0].ID)
+	})
+
+	t.Run("should delete the analyses and then the orphan vulnerabilities", func(t *testing.T) {
+		assert.NoError(t, repository.DeleteByIDs([]uuid.UUID{oldest.ID, old.ID}))
+
+		count := 0
+		assert.NoError(t, conn.Table("analysis").Where("repository_id = ?", repositoryID).Count(&count).Error)
+		assert.Equal(t, 1, count)
+		assert.NoError(t, conn.Table("analysis_vulnerabilities").Count(&count).Error)
+		assert.Equal(t, 1, count)
+
+		removed, err := repository.DeleteOrphanVulnerabilities(10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), removed)
+		assert.NoError(t, conn.Table("vulnerabilities").Where("vulnerability_id = ?", shared.VulnerabilityID).
+			Count(&count).Error)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("should return error when delete fails", func(t *testing.T) {
+		brokenConn, _ := gorm.Open("sqlite3", ":memory:")
+		repository := newHistoryRepository(brokenConn)
+
+		assert.Error(t, repository.DeleteByIDs([]uuid.UUID{uuid.New()}))
+		_, err := repository.DeleteOrphanVulnerabilities(10)
+		assert.Error(t, err)
+		_, err = repository.ListToPrune(companyID, time.Now(), 10)
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdatePolicyVerdict(t *testing.T) {
+	t.Run("should update the policy verdict without errors", func(t *testing.T) {
+		mockWrite := &SQL.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
+		repository := NewAnalysisRepository(&SQL.MockRead{}, mockWrite)
+
+		analysis := (&horusec.Analysis{ID: uuid.New()}).SetPolicyVerdict([]string{"test"})
+		assert.NoError(t, repository.UpdatePolicyVerdict(analysis))
 	})
 }
 
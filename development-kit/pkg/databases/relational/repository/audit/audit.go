@@ -0,0 +1,78 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/pagination"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+)
+
+type IRepository interface {
+	Create(event *audit.Event) error
+	List(filter *audit.Filter) (*audit.Events, error)
+}
+
+type Repository struct {
+	databaseRead  SQL.InterfaceRead
+	databaseWrite SQL.InterfaceWrite
+}
+
+func NewAuditRepository(databaseRead SQL.InterfaceRead, databaseWrite SQL.InterfaceWrite) IRepository {
+	return &Repository{
+		databaseRead:  databaseRead,
+		databaseWrite: databaseWrite,
+	}
+}
+
+func (r *Repository) Create(event *audit.Event) error {
+	return r.databaseWrite.Create(event, event.GetTable()).GetError()
+}
+
+func (r *Repository) List(filter *audit.Filter) (*audit.Events, error) {
+	events := &audit.Events{Data: []audit.Event{}}
+	query := r.setWhereFilter(r.databaseRead.GetConnection().Table((&audit.Event{}).GetTable()), filter)
+	if err := query.Count(&events.TotalItems).Error; err != nil {
+		return nil, err
+	}
+
+	return events, query.Order("created_at DESC").Limit(filter.GetSize()).
+		Offset(pagination.GetSkip(int64(filter.Page), int64(filter.GetSize()))).Find(&events.Data).Error
+}
+
+func (r *Repository) setWhereFilter(query *gorm.DB, filter *audit.Filter) *gorm.DB {
+	for column, value := range map[string]uuid.UUID{"company_id": filter.CompanyID,
+		"repository_id": filter.RepositoryID, "account_id": filter.AccountID} {
+		if value != uuid.Nil {
+			query = query.Where(column+" = ?", value)
+		}
+	}
+
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+
+	if !filter.InitialDate.IsZero() {
+		query = query.Where("created_at >= ?", filter.InitialDate)
+	}
+
+	if !filter.FinalDate.IsZero() {
+		query = query.Where("created_at <= ?", filter.FinalDate)
+	}
+
+	return query
+}
@@ -0,0 +1,35 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Create(_ *audit.Event) error {
+	args := m.MethodCalled("Create")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) List(_ *audit.Filter) (*audit.Events, error) {
+	args := m.MethodCalled("List")
+	return args.Get(0).(*audit.Events), mockUtils.ReturnNilOrError(args, 1)
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock(t *testing.T) {
+	t.Run("should mock audit repository", func(t *testing.T) {
+		m := &Mock{}
+		m.On("Create").Return(nil)
+		m.On("List").Return(&audit.Events{}, nil)
+
+		assert.NoError(t, m.Create(&audit.Event{}))
+		_, err := m.List(&audit.Filter{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("should create audit event", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Create").Return(response.NewResponse(1, nil, nil))
+
+		repository := NewAuditRepository(&relational.MockRead{}, mockWrite)
+
+		assert.NoError(t, repository.Create(audit.NewEvent(auditEnums.TokenCreate, uuid.New())))
+	})
+
+	t.Run("should return error when create fails", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Create").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		repository := NewAuditRepository(&relational.MockRead{}, mockWrite)
+
+		assert.Error(t, repository.Create(audit.NewEvent(auditEnums.TokenCreate, uuid.New())))
+	})
+}
+
+func TestList(t *testing.T) {
+	conn, err := gorm.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Table("audit_events").AutoMigrate(&audit.Event{}).Error)
+
+	companyID := uuid.New()
+	accountID := uuid.New()
+	events := []*audit.Event{
+		audit.NewEvent(auditEnums.TokenCreate, accountID).SetCompanyID(companyID),
+		audit.NewEvent(auditEnums.RepositoryDelete, accountID).SetCompanyID(companyID),
+		audit.NewEvent(auditEnums.TokenCreate, uuid.New()).SetCompanyID(companyID),
+		audit.NewEvent(auditEnums.TokenCreate, accountID).SetCompanyID(uuid.New()),
+	}
+	for index, event := range events {
+		event.CreatedAt = time.Now().Add(time.Duration(index) * time.Minute)
+		assert.NoError(t, conn.Table("audit_events").Create(event).Error)
+	}
+
+	mockRead := &relational.MockRead{}
+	mockRead.On("GetConnection").Return(conn)
+	repository := NewAuditRepository(mockRead, &relational.MockWrite{})
+
+	t.Run("should list events of the company newest first", func(t *testing.T) {
+		result, err := repository.List(&audit.Filter{CompanyID: companyID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.TotalItems)
+		assert.Equal(t, events[2].EventID, result.Data[0].EventID)
+	})
+
+	t.Run("should list events of all companies", func(t *testing.T) {
+		result, err := repository.List(&audit.Filter{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, result.TotalItems)
+	})
+
+	t.Run("should filter by account, action and dates with pagination", func(t *testing.T) {
+		result, err := repository.List(&audit.Filter{CompanyID: companyID, AccountID: accountID,
+			Action: auditEnums.TokenCreate, InitialDate: time.Now().Add(-time.Minute),
+			FinalDate: time.Now().Add(time.Hour), Page: 1, Size: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.TotalItems)
+		assert.Len(t, result.Data, 1)
+		assert.Equal(t, events[0].EventID, result.Data[0].EventID)
+	})
+
+	t.Run("should return error when query fails", func(t *testing.T) {
+		brokenConn, _ := gorm.Open("sqlite3", ":memory:")
+		brokenRead := &relational.MockRead{}
+		brokenRead.On("GetConnection").Return(brokenConn)
+
+		_, err := NewAuditRepository(brokenRead, &relational.MockWrite{}).List(&audit.Filter{})
+
+		assert.Error(t, err)
+	})
+}
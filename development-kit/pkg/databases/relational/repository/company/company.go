@@ -20,6 +20,7 @@ import (
 	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
@@ -32,6 +33,7 @@ type ICompanyRepository interface {
 	Delete(companyID uuid.UUID) error
 	GetAllAccountsInCompany(companyID uuid.UUID) (*[]roles.AccountRole, error)
 	ListByLdapPermissions(permissions []string) (*[]accountEntities.CompanyResponse, error)
+	ListAll() (*[]accountEntities.Company, error)
 }
 
 type Repository struct {
@@ -92,8 +94,8 @@ func (r *Repository) GetAllOfAccount(accountID uuid.UUID) (*[]accountEntities.Co
 	query := r.databaseRead.
 		GetConnection().
 		Select(
-			"comp.company_id, comp.name, comp.description, accountComp.role,"+
-				"comp.created_at, comp.updated_at",
+			"comp.company_id, comp.name, comp.description, accountComp.role, comp.require_two_factor,"+
+				"comp.analysis_retention_days, comp.created_at, comp.updated_at",
 		).
 		Table("companies AS comp").
 		Joins("JOIN account_company AS accountComp ON accountComp.company_id = comp.company_id"+
@@ -104,6 +106,17 @@ func (r *Repository) GetAllOfAccount(accountID uuid.UUID) (*[]accountEntities.Co
 	return companies, query.Error
 }
 
+func (r *Repository) ListAll() (*[]accountEntities.Company, error) {
+	companies := &[]accountEntities.Company{}
+	response := r.databaseRead.Find(companies,
+		r.databaseRead.SetFilter(map[string]interface{}{}), (&accountEntities.Company{}).GetTable())
+	if response.GetError() != nil && response.GetError() != errorsEnum.ErrNotFoundRecords {
+		return nil, response.GetError()
+	}
+
+	return companies, nil
+}
+
 func getCompanyByIDFilter(companyID uuid.UUID) map[string]interface{} {
 	return map[string]interface{}{"company_id": companyID}
 }
@@ -38,7 +38,7 @@ func (m *Mock) Update(companyID uuid.UUID, data *accountEntities.Company) (*acco
 }
 
 func (m *Mock) GetByID(companyID uuid.UUID) (*accountEntities.Company, error) {
-	args := m.MethodCalled("Update")
+	args := m.MethodCalled("GetByID")
 	return args.Get(0).(*accountEntities.Company), mockUtils.ReturnNilOrError(args, 1)
 }
 
@@ -61,3 +61,8 @@ func (m *Mock) ListByLdapPermissions(permissions []string) (*[]accountEntities.C
 	args := m.MethodCalled("ListByLdapPermissions")
 	return args.Get(0).(*[]accountEntities.CompanyResponse), mockUtils.ReturnNilOrError(args, 1)
 }
+
+func (m *Mock) ListAll() (*[]accountEntities.Company, error) {
+	args := m.MethodCalled("ListAll")
+	return args.Get(0).(*[]accountEntities.Company), mockUtils.ReturnNilOrError(args, 1)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
@@ -47,6 +48,9 @@ func TestMock(t *testing.T) {
 	assert.NoError(t, err)
 	_, err = m.GetAllAccountsInCompany(uuid.New())
 	assert.NoError(t, err)
+	m.On("ListAll").Return(&[]accountEntities.Company{}, nil)
+	_, err = m.ListAll()
+	assert.NoError(t, err)
 }
 
 func TestCreateCompany(t *testing.T) {
@@ -284,3 +288,34 @@ func TestGetAllAccountsInCompany(t *testing.T) {
 // 		assert.NotNil(t, result)
 // 	})
 // }
+
+func TestListAll(t *testing.T) {
+	t.Run("should list all companies", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(1, nil, &[]accountEntities.Company{{Name: "test"}}))
+
+		companies, err := NewCompanyRepository(mockRead, &relational.MockWrite{}).ListAll()
+		assert.NoError(t, err)
+		assert.Len(t, *companies, 1)
+	})
+
+	t.Run("should return empty list when not found records", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(0, errorsEnum.ErrNotFoundRecords, nil))
+
+		companies, err := NewCompanyRepository(mockRead, &relational.MockWrite{}).ListAll()
+		assert.NoError(t, err)
+		assert.Empty(t, *companies)
+	})
+
+	t.Run("should return error when find fails", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		_, err := NewCompanyRepository(mockRead, &relational.MockWrite{}).ListAll()
+		assert.Error(t, err)
+	})
+}
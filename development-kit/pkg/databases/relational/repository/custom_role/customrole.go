@@ -0,0 +1,88 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+)
+
+type IRepository interface {
+	Create(customRole *roles.CustomRole) error
+	Update(customRole *roles.CustomRole) error
+	Delete(customRoleID, companyID uuid.UUID) error
+	Get(customRoleID, companyID uuid.UUID) (*roles.CustomRole, error)
+	ListByCompanyID(companyID uuid.UUID) (*[]roles.CustomRole, error)
+}
+
+type Repository struct {
+	databaseRead  SQL.InterfaceRead
+	databaseWrite SQL.InterfaceWrite
+}
+
+func NewCustomRoleRepository(databaseRead SQL.InterfaceRead, databaseWrite SQL.InterfaceWrite) IRepository {
+	return &Repository{
+		databaseRead:  databaseRead,
+		databaseWrite: databaseWrite,
+	}
+}
+
+func (r *Repository) Create(customRole *roles.CustomRole) error {
+	return r.databaseWrite.Create(customRole, customRole.GetTable()).GetError()
+}
+
+func (r *Repository) Update(customRole *roles.CustomRole) error {
+	return r.databaseWrite.Update(map[string]interface{}{
+		"name":        customRole.Name,
+		"description": customRole.Description,
+		"permissions": customRole.Permissions,
+		"updated_at":  customRole.UpdatedAt,
+	}, map[string]interface{}{"custom_role_id": customRole.CustomRoleID}, customRole.GetTable()).GetError()
+}
+
+// Delete also removes the custom role of the repository members, the company filter avoids removing roles of others
+func (r *Repository) Delete(customRoleID, companyID uuid.UUID) error {
+	result := r.databaseWrite.Delete(map[string]interface{}{"custom_role_id": customRoleID, "company_id": companyID},
+		(&roles.CustomRole{}).GetTable())
+	if result.GetError() != nil {
+		return result.GetError()
+	}
+
+	if result.GetRowsAffected() == 0 {
+		return errors.ErrNotFoundRecords
+	}
+
+	return nil
+}
+
+func (r *Repository) Get(customRoleID, companyID uuid.UUID) (*roles.CustomRole, error) {
+	customRole := &roles.CustomRole{}
+	filter := r.databaseRead.SetFilter(map[string]interface{}{"custom_role_id": customRoleID, "company_id": companyID})
+	result := r.databaseRead.Find(customRole, filter, customRole.GetTable())
+	return customRole, result.GetError()
+}
+
+func (r *Repository) ListByCompanyID(companyID uuid.UUID) (*[]roles.CustomRole, error) {
+	customRoles := &[]roles.CustomRole{}
+	filter := r.databaseRead.SetFilter(map[string]interface{}{"company_id": companyID})
+	result := r.databaseRead.Find(customRoles, filter, (&roles.CustomRole{}).GetTable())
+	if result.GetError() == errors.ErrNotFoundRecords {
+		return customRoles, nil
+	}
+
+	return customRoles, result.GetError()
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("Create").Return(nil)
+	m.On("Update").Return(nil)
+	m.On("Delete").Return(nil)
+	m.On("Get").Return(&roles.CustomRole{}, nil)
+	m.On("ListByCompanyID").Return(&[]roles.CustomRole{}, nil)
+	assert.NoError(t, m.Create(&roles.CustomRole{}))
+	assert.NoError(t, m.Update(&roles.CustomRole{}))
+	assert.NoError(t, m.Delete(uuid.New(), uuid.New()))
+	_, err := m.Get(uuid.New(), uuid.New())
+	assert.NoError(t, err)
+	_, err = m.ListByCompanyID(uuid.New())
+	assert.NoError(t, err)
+}
+
+func TestNewCustomRoleRepository(t *testing.T) {
+	assert.NotEmpty(t, NewCustomRoleRepository(&relational.MockRead{}, &relational.MockWrite{}))
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("should create custom role without errors", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Create").Return(response.NewResponse(1, nil, nil))
+		r := NewCustomRoleRepository(&relational.MockRead{}, mockWrite)
+		assert.NoError(t, r.Create(&roles.CustomRole{}))
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("should update custom role without errors", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
+		r := NewCustomRoleRepository(&relational.MockRead{}, mockWrite)
+		assert.NoError(t, r.Update(&roles.CustomRole{}))
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("should delete custom role without errors", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(1, nil, nil))
+		r := NewCustomRoleRepository(&relational.MockRead{}, mockWrite)
+		assert.NoError(t, r.Delete(uuid.New(), uuid.New()))
+	})
+	t.Run("should return not found when custom role is not of the company", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(0, nil, nil))
+		r := NewCustomRoleRepository(&relational.MockRead{}, mockWrite)
+		assert.Equal(t, EnumErrors.ErrNotFoundRecords, r.Delete(uuid.New(), uuid.New()))
+	})
+	t.Run("should return error when delete fails", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(0, errors.New("test"), nil))
+		r := NewCustomRoleRepository(&relational.MockRead{}, mockWrite)
+		assert.Error(t, r.Delete(uuid.New(), uuid.New()))
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("should return custom role", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(1, nil, &roles.CustomRole{}))
+		r := NewCustomRoleRepository(mockRead, &relational.MockWrite{})
+		_, err = r.Get(uuid.New(), uuid.New())
+		assert.NoError(t, err)
+	})
+}
+
+func TestListByCompanyID(t *testing.T) {
+	t.Run("should return empty list when not found", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil))
+		r := NewCustomRoleRepository(mockRead, &relational.MockWrite{})
+		result, err := r.ListByCompanyID(uuid.New())
+		assert.NoError(t, err)
+		assert.Empty(t, *result)
+	})
+	t.Run("should return error when find fails", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, errors.New("test"), nil))
+		r := NewCustomRoleRepository(mockRead, &relational.MockWrite{})
+		_, err = r.ListByCompanyID(uuid.New())
+		assert.Error(t, err)
+	})
+}
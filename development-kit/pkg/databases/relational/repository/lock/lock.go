@@ -0,0 +1,52 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+)
+
+type IRepository interface {
+	RunLocked(name string, run func()) (bool, error)
+}
+
+type Repository struct {
+	databaseWrite SQL.InterfaceWrite
+}
+
+func NewLockRepository(databaseWrite SQL.InterfaceWrite) IRepository {
+	return &Repository{
+		databaseWrite: databaseWrite,
+	}
+}
+
+// RunLocked calls run only when the advisory lock of name is free, so the same job is not executed by many replicas
+// at once. The lock belongs to a transaction and is released when it ends, even if the replica stops while running.
+func (r *Repository) RunLocked(name string, run func()) (bool, error) {
+	tx := r.databaseWrite.StartTransaction()
+	locked, err := r.tryLock(tx, name)
+	if err != nil || !locked {
+		_ = tx.RollbackTransaction()
+		return false, err
+	}
+
+	run()
+	return true, tx.CommitTransaction().GetError()
+}
+
+func (r *Repository) tryLock(tx SQL.InterfaceWrite, name string) (locked bool, err error) {
+	row := tx.GetConnection().Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", name).Row()
+	return locked, row.Scan(&locked)
+}
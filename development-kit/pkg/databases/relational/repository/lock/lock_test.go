@@ -0,0 +1,123 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+// lockResult is returned by the fake pg_try_advisory_xact_lock registered in sqlite
+var lockResult = true
+
+func init() {
+	sql.Register("sqlite3_lock", &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			if err := conn.RegisterFunc("hashtext", func(name string) int64 { return int64(len(name)) }, true); err != nil {
+				return err
+			}
+			return conn.RegisterFunc("pg_try_advisory_xact_lock", func(_ int64) bool { return lockResult }, false)
+		},
+	})
+}
+
+func getConnection(t *testing.T) *gorm.DB {
+	db, err := sql.Open("sqlite3_lock", ":memory:")
+	assert.NoError(t, err)
+	conn, err := gorm.Open("sqlite3", db)
+	assert.NoError(t, err)
+	return conn
+}
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("RunLocked").Return(true, nil)
+	called := false
+	locked, err := m.RunLocked("test", func() { called = true })
+	assert.NoError(t, err)
+	assert.True(t, locked)
+	assert.True(t, called)
+}
+
+func TestNewLockRepository(t *testing.T) {
+	assert.NotEmpty(t, NewLockRepository(&relational.MockWrite{}))
+}
+
+func TestRunLocked(t *testing.T) {
+	t.Run("should run and commit when the lock is acquired", func(t *testing.T) {
+		lockResult = true
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("StartTransaction").Return(mockWrite)
+		mockWrite.On("GetConnection").Return(getConnection(t))
+		mockWrite.On("CommitTransaction").Return(&response.Response{})
+
+		called := false
+		locked, err := NewLockRepository(mockWrite).RunLocked("test", func() { called = true })
+		assert.NoError(t, err)
+		assert.True(t, locked)
+		assert.True(t, called)
+		mockWrite.AssertCalled(t, "CommitTransaction")
+	})
+
+	t.Run("should not run when the lock is held by another replica", func(t *testing.T) {
+		lockResult = false
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("StartTransaction").Return(mockWrite)
+		mockWrite.On("GetConnection").Return(getConnection(t))
+		mockWrite.On("RollbackTransaction").Return(&response.Response{})
+
+		called := false
+		locked, err := NewLockRepository(mockWrite).RunLocked("test", func() { called = true })
+		assert.NoError(t, err)
+		assert.False(t, locked)
+		assert.False(t, called)
+		mockWrite.AssertCalled(t, "RollbackTransaction")
+	})
+
+	t.Run("should return error and not run when the lock can not be checked", func(t *testing.T) {
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("StartTransaction").Return(mockWrite)
+		mockWrite.On("GetConnection").Return(conn)
+		mockWrite.On("RollbackTransaction").Return(&response.Response{})
+
+		called := false
+		locked, err := NewLockRepository(mockWrite).RunLocked("test", func() { called = true })
+		assert.Error(t, err)
+		assert.False(t, locked)
+		assert.False(t, called)
+	})
+
+	t.Run("should return error when the commit fails", func(t *testing.T) {
+		lockResult = true
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("StartTransaction").Return(mockWrite)
+		mockWrite.On("GetConnection").Return(getConnection(t))
+		mockWrite.On("CommitTransaction").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		locked, err := NewLockRepository(mockWrite).RunLocked("test", func() {})
+		assert.Error(t, err)
+		assert.True(t, locked)
+	})
+}
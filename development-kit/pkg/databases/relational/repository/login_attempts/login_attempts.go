@@ -0,0 +1,96 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loginattempts
+
+import (
+	"time"
+
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+)
+
+// incrementFailuresQuery counts the failure in a single statement, so concurrent logins never read the same count.
+// The count starts again when the last failures expired or their lock ended.
+const incrementFailuresQuery = `
+INSERT INTO login_attempts (key, failures, expires_at) VALUES (?, 1, ?)
+ON CONFLICT (key) DO UPDATE SET
+	failures = CASE WHEN login_attempts.expires_at <= ? OR login_attempts.locked_until <= ?
+		THEN 1 ELSE login_attempts.failures + 1 END,
+	next_attempt_at = CASE WHEN login_attempts.expires_at <= ? OR login_attempts.locked_until <= ?
+		THEN NULL ELSE login_attempts.next_attempt_at END,
+	locked_until = CASE WHEN login_attempts.expires_at <= ? OR login_attempts.locked_until <= ?
+		THEN NULL ELSE login_attempts.locked_until END,
+	expires_at = EXCLUDED.expires_at
+RETURNING failures`
+
+// setNextAttemptQuery only moves the dates forward, a slower request with less failures can not shorten the delay
+const setNextAttemptQuery = `
+UPDATE login_attempts SET next_attempt_at = GREATEST(next_attempt_at, ?), locked_until = GREATEST(locked_until, ?)
+WHERE key = ?`
+
+type IRepository interface {
+	Get(key string) (*authEntities.LoginAttempts, error)
+	IncrementFailures(key string, expiration time.Duration) (int, error)
+	SetNextAttempt(attempts *authEntities.LoginAttempts) error
+	Delete(key string) error
+}
+
+type Repository struct {
+	databaseRead  SQL.InterfaceRead
+	databaseWrite SQL.InterfaceWrite
+}
+
+func NewLoginAttemptsRepository(databaseRead SQL.InterfaceRead, databaseWrite SQL.InterfaceWrite) IRepository {
+	return &Repository{
+		databaseRead:  databaseRead,
+		databaseWrite: databaseWrite,
+	}
+}
+
+// Get returns empty login attempts when the key has no failures or they already expired
+func (r *Repository) Get(key string) (*authEntities.LoginAttempts, error) {
+	attempts := &authEntities.LoginAttempts{}
+	query := r.databaseRead.GetConnection().Where("key = ? AND expires_at > ?", key, time.Now())
+	result := r.databaseRead.Find(attempts, query, attempts.GetTable())
+	if err := result.GetError(); err != nil {
+		if err == errorsEnum.ErrNotFoundRecords {
+			return &authEntities.LoginAttempts{}, nil
+		}
+
+		return &authEntities.LoginAttempts{}, err
+	}
+
+	return attempts, nil
+}
+
+// IncrementFailures returns the failures of the key counting this one
+func (r *Repository) IncrementFailures(key string, expiration time.Duration) (failures int, err error) {
+	now := time.Now()
+	row := r.databaseWrite.GetConnection().
+		Raw(incrementFailuresQuery, key, now.Add(expiration), now, now, now, now, now, now).Row()
+	return failures, row.Scan(&failures)
+}
+
+func (r *Repository) SetNextAttempt(attempts *authEntities.LoginAttempts) error {
+	return r.databaseWrite.GetConnection().
+		Exec(setNextAttemptQuery, attempts.NextAttemptAt, attempts.LockedUntil, attempts.Key).Error
+}
+
+// Delete also removes the expired keys, failures of ips that never logged in again would stay in the table forever
+func (r *Repository) Delete(key string) error {
+	query := r.databaseWrite.GetConnection().Where("key = ? OR expires_at <= ?", key, time.Now())
+	return r.databaseWrite.DeleteByQuery(query, (&authEntities.LoginAttempts{}).GetTable()).GetError()
+}
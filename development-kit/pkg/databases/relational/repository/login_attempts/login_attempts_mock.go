@@ -0,0 +1,47 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loginattempts
+
+import (
+	"time"
+
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Get(_ string) (*authEntities.LoginAttempts, error) {
+	args := m.MethodCalled("Get")
+	return args.Get(0).(*authEntities.LoginAttempts), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) IncrementFailures(_ string, _ time.Duration) (int, error) {
+	args := m.MethodCalled("IncrementFailures")
+	return args.Int(0), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) SetNextAttempt(_ *authEntities.LoginAttempts) error {
+	args := m.MethodCalled("SetNextAttempt")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) Delete(_ string) error {
+	args := m.MethodCalled("Delete")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
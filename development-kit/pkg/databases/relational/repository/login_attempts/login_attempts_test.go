@@ -0,0 +1,146 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loginattempts
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	sql.Register("sqlite3_login_attempts", &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("greatest", func(first, second interface{}) string {
+				firstValue, _ := first.(string)
+				secondValue, _ := second.(string)
+				if secondValue > firstValue {
+					return secondValue
+				}
+				return firstValue
+			}, true)
+		},
+	})
+}
+
+func getConnection(t *testing.T) *gorm.DB {
+	db, err := sql.Open("sqlite3_login_attempts", ":memory:")
+	assert.NoError(t, err)
+	conn, err := gorm.Open("sqlite3", db)
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Exec(`CREATE TABLE login_attempts (key TEXT PRIMARY KEY, failures INTEGER,
+		next_attempt_at TEXT, locked_until TEXT, expires_at TEXT)`).Error)
+	return conn
+}
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("Get").Return(&authEntities.LoginAttempts{}, nil)
+	m.On("IncrementFailures").Return(1, nil)
+	m.On("SetNextAttempt").Return(nil)
+	m.On("Delete").Return(nil)
+	_, err := m.Get("")
+	assert.NoError(t, err)
+	failures, err := m.IncrementFailures("", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, failures)
+	assert.NoError(t, m.SetNextAttempt(&authEntities.LoginAttempts{}))
+	assert.NoError(t, m.Delete(""))
+}
+
+func TestNewLoginAttemptsRepository(t *testing.T) {
+	assert.NotEmpty(t, NewLoginAttemptsRepository(&relational.MockRead{}, &relational.MockWrite{}))
+}
+
+func TestGet(t *testing.T) {
+	t.Run("should return login attempts of the key", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(getConnection(t))
+		mockRead.On("Find").Return(response.NewResponse(1, nil, &authEntities.LoginAttempts{Failures: 2}))
+
+		attempts, err := NewLoginAttemptsRepository(mockRead, &relational.MockWrite{}).Get("key")
+		assert.NoError(t, err)
+		assert.NotNil(t, attempts)
+	})
+
+	t.Run("should return empty login attempts when key has no failures", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(getConnection(t))
+		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil))
+
+		attempts, err := NewLoginAttemptsRepository(mockRead, &relational.MockWrite{}).Get("key")
+		assert.NoError(t, err)
+		assert.Equal(t, &authEntities.LoginAttempts{}, attempts)
+	})
+
+	t.Run("should return error when find fails", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(getConnection(t))
+		mockRead.On("Find").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		_, err := NewLoginAttemptsRepository(mockRead, &relational.MockWrite{}).Get("key")
+		assert.Error(t, err)
+	})
+}
+
+func TestIncrementFailures(t *testing.T) {
+	t.Run("should return error when the query fails", func(t *testing.T) {
+		conn := getConnection(t)
+		assert.NoError(t, conn.Exec("DROP TABLE login_attempts").Error)
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("GetConnection").Return(conn)
+
+		_, err := NewLoginAttemptsRepository(&relational.MockRead{}, mockWrite).IncrementFailures("key", time.Minute)
+		assert.Error(t, err)
+	})
+}
+
+func TestSetNextAttempt(t *testing.T) {
+	t.Run("should only move the next attempt forward", func(t *testing.T) {
+		conn := getConnection(t)
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("GetConnection").Return(conn)
+		assert.NoError(t, conn.Exec("INSERT INTO login_attempts (key, failures, next_attempt_at) VALUES (?, 1, ?)",
+			"key", "2099-01-01").Error)
+		nextAttemptAt := time.Now()
+		r := NewLoginAttemptsRepository(&relational.MockRead{}, mockWrite)
+
+		assert.NoError(t, r.SetNextAttempt(&authEntities.LoginAttempts{Key: "key", NextAttemptAt: &nextAttemptAt}))
+
+		var result string
+		assert.NoError(t, conn.Raw("SELECT next_attempt_at FROM login_attempts").Row().Scan(&result))
+		assert.Equal(t, "2099-01-01", result)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("should delete the key and the expired keys", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("GetConnection").Return(getConnection(t))
+		mockWrite.On("DeleteByQuery").Return(response.NewResponse(1, nil, nil))
+
+		assert.NoError(t, NewLoginAttemptsRepository(&relational.MockRead{}, mockWrite).Delete("key"))
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/notification"
+	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	"github.com/google/uuid"
+)
+
+type INotification interface {
+	GetByNotificationID(notificationID uuid.UUID) (*notification.Notification, error)
+	GetAllByCompanyID(companyID uuid.UUID) (*[]notification.Notification, error)
+	GetAllToDispatch(companyID, repositoryID uuid.UUID,
+		event notificationEnum.Event) ([]notification.Notification, error)
+	Create(entity *notification.Notification) error
+	Update(entity *notification.Notification) error
+	Remove(notificationID uuid.UUID) error
+}
+
+type Notification struct {
+	databaseRead  relational.InterfaceRead
+	databaseWrite relational.InterfaceWrite
+}
+
+func NewNotificationRepository(databaseRead relational.InterfaceRead,
+	databaseWrite relational.InterfaceWrite) INotification {
+	return &Notification{
+		databaseRead:  databaseRead,
+		databaseWrite: databaseWrite,
+	}
+}
+
+func (n *Notification) GetByNotificationID(notificationID uuid.UUID) (*notification.Notification, error) {
+	entity := &notification.Notification{}
+	filter := n.databaseRead.SetFilter(map[string]interface{}{"notification_id": notificationID}).Limit(1)
+	response := n.databaseRead.Find(entity, filter, entity.GetTable())
+	return entity, response.GetError()
+}
+
+func (n *Notification) GetAllByCompanyID(companyID uuid.UUID) (*[]notification.Notification, error) {
+	entity := &notification.Notification{}
+	entityList := &[]notification.Notification{}
+	filter := n.databaseRead.SetFilter(map[string]interface{}{"company_id": companyID})
+	response := n.databaseRead.Find(entityList, filter, entity.GetTable())
+	return entityList, response.GetError()
+}
+
+func (n *Notification) GetAllToDispatch(companyID, repositoryID uuid.UUID,
+	event notificationEnum.Event) (toDispatch []notification.Notification, err error) {
+	entityList, err := n.GetAllByCompanyID(companyID)
+	if err != nil {
+		if err == EnumErrors.ErrNotFoundRecords {
+			return toDispatch, nil
+		}
+		return nil, err
+	}
+
+	for index := range *entityList {
+		item := (*entityList)[index]
+		if item.HasEvent(event) && item.IsToRepository(repositoryID) {
+			toDispatch = append(toDispatch, item)
+		}
+	}
+
+	return toDispatch, nil
+}
+
+func (n *Notification) Create(entity *notification.Notification) error {
+	r := n.databaseWrite.Create(entity, entity.GetTable())
+	if r.GetError() != nil {
+		return r.GetError()
+	}
+	if r.GetRowsAffected() == 0 {
+		return EnumErrors.ErrNotFoundRecords
+	}
+	return nil
+}
+
+func (n *Notification) Update(entity *notification.Notification) error {
+	condition := map[string]interface{}{
+		"notification_id": entity.NotificationID,
+	}
+	r := n.databaseWrite.Update(entity, condition, entity.GetTable())
+	return r.GetError()
+}
+
+func (n *Notification) Remove(notificationID uuid.UUID) error {
+	entity := &notification.Notification{}
+	condition := map[string]interface{}{
+		"notification_id": notificationID,
+	}
+	r := n.databaseWrite.Delete(condition, entity.GetTable())
+	return r.GetError()
+}
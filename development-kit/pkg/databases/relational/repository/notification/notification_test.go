@@ -0,0 +1,208 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	entitiesNotification "github.com/ZupIT/horusec/development-kit/pkg/entities/notification"
+	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("GetByNotificationID").Return(&entitiesNotification.Notification{}, nil)
+	m.On("GetAllByCompanyID").Return(&[]entitiesNotification.Notification{}, nil)
+	m.On("GetAllToDispatch").Return([]entitiesNotification.Notification{}, nil)
+	m.On("Create").Return(nil)
+	m.On("Update").Return(nil)
+	m.On("Remove").Return(nil)
+	_, err := m.GetByNotificationID(uuid.New())
+	assert.NoError(t, err)
+	_, err = m.GetAllByCompanyID(uuid.New())
+	assert.NoError(t, err)
+	_, err = m.GetAllToDispatch(uuid.New(), uuid.New(), notificationEnum.AnalysisFinished)
+	assert.NoError(t, err)
+	assert.NoError(t, m.Create(&entitiesNotification.Notification{}))
+	assert.NoError(t, m.Update(&entitiesNotification.Notification{}))
+	assert.NoError(t, m.Remove(uuid.New()))
+}
+
+func TestNewNotificationRepository(t *testing.T) {
+	assert.NotEmpty(t, NewNotificationRepository(&relational.MockRead{}, &relational.MockWrite{}))
+}
+
+func TestNotification_GetByNotificationID(t *testing.T) {
+	t.Run("should return error when get notification by id", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil))
+		r := NewNotificationRepository(mockRead, &relational.MockWrite{})
+		result, err := r.GetByNotificationID(uuid.New())
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("should return notification by id with success", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		notificationData := &entitiesNotification.Notification{
+			NotificationID: uuid.New(),
+			URL:            "http://example.com",
+			Channel:        notificationEnum.Slack,
+		}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, nil, notificationData))
+		r := NewNotificationRepository(mockRead, &relational.MockWrite{})
+		result, err := r.GetByNotificationID(uuid.New())
+		assert.NoError(t, err)
+		assert.Equal(t, notificationData, result)
+	})
+}
+
+func TestNotification_GetAllByCompanyID(t *testing.T) {
+	t.Run("should return error when get notifications by company id", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, errors.New("unexpected error"), nil))
+		r := NewNotificationRepository(mockRead, &relational.MockWrite{})
+		result, err := r.GetAllByCompanyID(uuid.New())
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("should return notifications by company id with success", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		notificationData := &[]entitiesNotification.Notification{
+			{NotificationID: uuid.New(), URL: "http://example.com", Channel: notificationEnum.Teams},
+		}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, nil, notificationData))
+		r := NewNotificationRepository(mockRead, &relational.MockWrite{})
+		result, err := r.GetAllByCompanyID(uuid.New())
+		assert.NoError(t, err)
+		assert.Equal(t, notificationData, result)
+	})
+}
+
+func TestNotification_GetAllToDispatch(t *testing.T) {
+	repositoryID := uuid.New()
+	otherRepositoryID := uuid.New()
+	notificationData := &[]entitiesNotification.Notification{
+		{Channel: notificationEnum.Slack, Events: []string{notificationEnum.AnalysisFinished.ToString()}},
+		{Channel: notificationEnum.Teams, Events: []string{notificationEnum.UserInvited.ToString()}},
+		{Channel: notificationEnum.Chat, Events: []string{notificationEnum.AnalysisFinished.ToString()},
+			RepositoryID: &repositoryID},
+		{Channel: notificationEnum.Chat, Events: []string{notificationEnum.AnalysisFinished.ToString()},
+			RepositoryID: &otherRepositoryID},
+	}
+
+	t.Run("should return only notifications of event and repository", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, nil, notificationData))
+		r := NewNotificationRepository(mockRead, &relational.MockWrite{})
+		result, err := r.GetAllToDispatch(uuid.New(), repositoryID, notificationEnum.AnalysisFinished)
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+	})
+	t.Run("should return empty when not found records", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil))
+		r := NewNotificationRepository(mockRead, &relational.MockWrite{})
+		result, err := r.GetAllToDispatch(uuid.New(), repositoryID, notificationEnum.AnalysisFinished)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("should return error when get notifications", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, errors.New("unexpected error"), nil))
+		r := NewNotificationRepository(mockRead, &relational.MockWrite{})
+		_, err = r.GetAllToDispatch(uuid.New(), repositoryID, notificationEnum.AnalysisFinished)
+		assert.Error(t, err)
+	})
+}
+
+func TestNotification_Create(t *testing.T) {
+	t.Run("should return unexpected error when create notification", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Create").Return(response.NewResponse(0, errors.New("unexpected error"), nil))
+		r := NewNotificationRepository(&relational.MockRead{}, mockWrite)
+		assert.Error(t, r.Create(&entitiesNotification.Notification{}))
+	})
+	t.Run("should return not found when not return rows affected in create notification", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Create").Return(response.NewResponse(0, nil, nil))
+		r := NewNotificationRepository(&relational.MockRead{}, mockWrite)
+		assert.Equal(t, EnumErrors.ErrNotFoundRecords, r.Create(&entitiesNotification.Notification{}))
+	})
+	t.Run("should return success when create notification", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Create").Return(response.NewResponse(1, nil, nil))
+		r := NewNotificationRepository(&relational.MockRead{}, mockWrite)
+		assert.NoError(t, r.Create(&entitiesNotification.Notification{}))
+	})
+}
+
+func TestNotification_Update(t *testing.T) {
+	t.Run("should return unexpected error when update notification", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(0, errors.New("unexpected error"), nil))
+		r := NewNotificationRepository(&relational.MockRead{}, mockWrite)
+		assert.Error(t, r.Update(&entitiesNotification.Notification{}))
+	})
+	t.Run("should return success when update notification", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
+		r := NewNotificationRepository(&relational.MockRead{}, mockWrite)
+		assert.NoError(t, r.Update(&entitiesNotification.Notification{}))
+	})
+}
+
+func TestNotification_Remove(t *testing.T) {
+	t.Run("should return unexpected error when remove notification", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(0, errors.New("unexpected error"), nil))
+		r := NewNotificationRepository(&relational.MockRead{}, mockWrite)
+		assert.Error(t, r.Remove(uuid.New()))
+	})
+	t.Run("should return success when remove notification", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(1, nil, nil))
+		r := NewNotificationRepository(&relational.MockRead{}, mockWrite)
+		assert.NoError(t, r.Remove(uuid.New()))
+	})
+}
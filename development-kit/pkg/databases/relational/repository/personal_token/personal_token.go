@@ -0,0 +1,87 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package personaltoken
+
+import (
+	"time"
+
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+)
+
+type IRepository interface {
+	Create(personalToken *authEntities.PersonalToken) error
+	GetByValue(value string) (*authEntities.PersonalToken, error)
+	ListByAccountID(accountID uuid.UUID) (*[]authEntities.PersonalToken, error)
+	UpdateLastUsedAt(personalTokenID uuid.UUID) error
+	Delete(personalTokenID, accountID uuid.UUID) error
+}
+
+type Repository struct {
+	databaseRead  SQL.InterfaceRead
+	databaseWrite SQL.InterfaceWrite
+}
+
+func NewPersonalTokenRepository(databaseRead SQL.InterfaceRead, databaseWrite SQL.InterfaceWrite) IRepository {
+	return &Repository{
+		databaseRead:  databaseRead,
+		databaseWrite: databaseWrite,
+	}
+}
+
+func (r *Repository) Create(personalToken *authEntities.PersonalToken) error {
+	return r.databaseWrite.Create(personalToken, personalToken.GetTable()).GetError()
+}
+
+func (r *Repository) GetByValue(value string) (*authEntities.PersonalToken, error) {
+	personalToken := &authEntities.PersonalToken{}
+	filter := r.databaseRead.SetFilter(map[string]interface{}{"value": value})
+	result := r.databaseRead.Find(personalToken, filter, personalToken.GetTable())
+	return personalToken, result.GetError()
+}
+
+func (r *Repository) ListByAccountID(accountID uuid.UUID) (*[]authEntities.PersonalToken, error) {
+	personalTokens := &[]authEntities.PersonalToken{}
+	filter := r.databaseRead.SetFilter(map[string]interface{}{"account_id": accountID})
+	result := r.databaseRead.Find(personalTokens, filter, (&authEntities.PersonalToken{}).GetTable())
+	if result.GetError() == errors.ErrNotFoundRecords {
+		return personalTokens, nil
+	}
+
+	return personalTokens, result.GetError()
+}
+
+func (r *Repository) UpdateLastUsedAt(personalTokenID uuid.UUID) error {
+	return r.databaseWrite.Update(map[string]interface{}{"last_used_at": time.Now()},
+		map[string]interface{}{"personal_token_id": personalTokenID},
+		(&authEntities.PersonalToken{}).GetTable()).GetError()
+}
+
+// Delete revokes the token, the account filter avoids removing tokens of other users
+func (r *Repository) Delete(personalTokenID, accountID uuid.UUID) error {
+	result := r.databaseWrite.Delete(map[string]interface{}{"personal_token_id": personalTokenID, "account_id": accountID},
+		(&authEntities.PersonalToken{}).GetTable())
+	if result.GetError() != nil {
+		return result.GetError()
+	}
+
+	if result.GetRowsAffected() == 0 {
+		return errors.ErrNotFoundRecords
+	}
+
+	return nil
+}
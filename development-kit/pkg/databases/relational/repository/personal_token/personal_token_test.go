@@ -0,0 +1,137 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package personaltoken
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("Create").Return(nil)
+	m.On("GetByValue").Return(&authEntities.PersonalToken{}, nil)
+	m.On("ListByAccountID").Return(&[]authEntities.PersonalToken{}, nil)
+	m.On("UpdateLastUsedAt").Return(nil)
+	m.On("Delete").Return(nil)
+	assert.NoError(t, m.Create(&authEntities.PersonalToken{}))
+	_, err := m.GetByValue("")
+	assert.NoError(t, err)
+	_, err = m.ListByAccountID(uuid.New())
+	assert.NoError(t, err)
+	assert.NoError(t, m.UpdateLastUsedAt(uuid.New()))
+	assert.NoError(t, m.Delete(uuid.New(), uuid.New()))
+}
+
+func TestNewPersonalTokenRepository(t *testing.T) {
+	assert.NotEmpty(t, NewPersonalTokenRepository(&relational.MockRead{}, &relational.MockWrite{}))
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("should create personal token without errors", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Create").Return(response.NewResponse(1, nil, nil))
+		r := NewPersonalTokenRepository(&relational.MockRead{}, mockWrite)
+		assert.NoError(t, r.Create(&authEntities.PersonalToken{}))
+	})
+}
+
+func TestGetByValue(t *testing.T) {
+	t.Run("should return personal token by value", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		personalToken := &authEntities.PersonalToken{PersonalTokenID: uuid.New(), Description: "test"}
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(1, nil, personalToken))
+		r := NewPersonalTokenRepository(mockRead, &relational.MockWrite{})
+		result, err := r.GetByValue("value")
+		assert.NoError(t, err)
+		assert.Equal(t, personalToken.PersonalTokenID, result.PersonalTokenID)
+	})
+	t.Run("should return error when not found", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil))
+		r := NewPersonalTokenRepository(mockRead, &relational.MockWrite{})
+		_, err = r.GetByValue("value")
+		assert.Equal(t, EnumErrors.ErrNotFoundRecords, err)
+	})
+}
+
+func TestListByAccountID(t *testing.T) {
+	t.Run("should return empty list when not found records", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil))
+		r := NewPersonalTokenRepository(mockRead, &relational.MockWrite{})
+		result, err := r.ListByAccountID(uuid.New())
+		assert.NoError(t, err)
+		assert.Empty(t, *result)
+	})
+	t.Run("should return error when something went wrong", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, errors.New("test"), nil))
+		r := NewPersonalTokenRepository(mockRead, &relational.MockWrite{})
+		_, err = r.ListByAccountID(uuid.New())
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateLastUsedAt(t *testing.T) {
+	t.Run("should update last used at without errors", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
+		r := NewPersonalTokenRepository(&relational.MockRead{}, mockWrite)
+		assert.NoError(t, r.UpdateLastUsedAt(uuid.New()))
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("should delete personal token without errors", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(1, nil, nil))
+		r := NewPersonalTokenRepository(&relational.MockRead{}, mockWrite)
+		assert.NoError(t, r.Delete(uuid.New(), uuid.New()))
+	})
+	t.Run("should return not found when no rows affected", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(0, nil, nil))
+		r := NewPersonalTokenRepository(&relational.MockRead{}, mockWrite)
+		assert.Equal(t, EnumErrors.ErrNotFoundRecords, r.Delete(uuid.New(), uuid.New()))
+	})
+	t.Run("should return error when delete fails", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(0, errors.New("test"), nil))
+		r := NewPersonalTokenRepository(&relational.MockRead{}, mockWrite)
+		assert.Error(t, r.Delete(uuid.New(), uuid.New()))
+	})
+}
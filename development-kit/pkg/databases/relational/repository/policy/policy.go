@@ -0,0 +1,97 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+)
+
+type IRepository interface {
+	Create(policy *accountEntities.Policy) error
+	Update(policy *accountEntities.Policy) error
+	Delete(policyID, companyID uuid.UUID) error
+	Get(policyID, companyID uuid.UUID) (*accountEntities.Policy, error)
+	ListByCompanyID(companyID uuid.UUID) (*[]accountEntities.Policy, error)
+	ListApplicable(companyID, repositoryID uuid.UUID) (*[]accountEntities.Policy, error)
+}
+
+type Repository struct {
+	databaseRead  SQL.InterfaceRead
+	databaseWrite SQL.InterfaceWrite
+}
+
+func NewPolicyRepository(databaseRead SQL.InterfaceRead, databaseWrite SQL.InterfaceWrite) IRepository {
+	return &Repository{
+		databaseRead:  databaseRead,
+		databaseWrite: databaseWrite,
+	}
+}
+
+func (r *Repository) Create(policy *accountEntities.Policy) error {
+	return r.databaseWrite.Create(policy, policy.GetTable()).GetError()
+}
+
+func (r *Repository) Update(policy *accountEntities.Policy) error {
+	return r.databaseWrite.Update(policy.MapToUpdate(), map[string]interface{}{"policy_id": policy.PolicyID},
+		policy.GetTable()).GetError()
+}
+
+// Delete uses the company filter to avoid removing policies of others
+func (r *Repository) Delete(policyID, companyID uuid.UUID) error {
+	result := r.databaseWrite.Delete(map[string]interface{}{"policy_id": policyID, "company_id": companyID},
+		(&accountEntities.Policy{}).GetTable())
+	if result.GetError() != nil {
+		return result.GetError()
+	}
+
+	if result.GetRowsAffected() == 0 {
+		return errors.ErrNotFoundRecords
+	}
+
+	return nil
+}
+
+func (r *Repository) Get(policyID, companyID uuid.UUID) (*accountEntities.Policy, error) {
+	policy := &accountEntities.Policy{}
+	filter := r.databaseRead.SetFilter(map[string]interface{}{"policy_id": policyID, "company_id": companyID})
+	result := r.databaseRead.Find(policy, filter, policy.GetTable())
+	return policy, result.GetError()
+}
+
+func (r *Repository) ListByCompanyID(companyID uuid.UUID) (*[]accountEntities.Policy, error) {
+	policies := &[]accountEntities.Policy{}
+	filter := r.databaseRead.SetFilter(map[string]interface{}{"company_id": companyID})
+	result := r.databaseRead.Find(policies, filter, (&accountEntities.Policy{}).GetTable())
+	if result.GetError() == errors.ErrNotFoundRecords {
+		return policies, nil
+	}
+
+	return policies, result.GetError()
+}
+
+// ListApplicable returns the policies of the company and the policies of the repository
+func (r *Repository) ListApplicable(companyID, repositoryID uuid.UUID) (*[]accountEntities.Policy, error) {
+	policies := &[]accountEntities.Policy{}
+	result := r.databaseRead.GetConnection().
+		Table((&accountEntities.Policy{}).GetTable()).
+		Where("company_id = ? AND (repository_id IS NULL OR repository_id = ?)", companyID, repositoryID).
+		Order("created_at").
+		Find(policies)
+
+	return policies, result.Error
+}
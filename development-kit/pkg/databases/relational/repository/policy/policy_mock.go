@@ -0,0 +1,56 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Create(_ *accountEntities.Policy) error {
+	args := m.MethodCalled("Create")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) Update(_ *accountEntities.Policy) error {
+	args := m.MethodCalled("Update")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) Delete(_, _ uuid.UUID) error {
+	args := m.MethodCalled("Delete")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) Get(_, _ uuid.UUID) (*accountEntities.Policy, error) {
+	args := m.MethodCalled("Get")
+	return args.Get(0).(*accountEntities.Policy), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) ListByCompanyID(_ uuid.UUID) (*[]accountEntities.Policy, error) {
+	args := m.MethodCalled("ListByCompanyID")
+	return args.Get(0).(*[]accountEntities.Policy), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) ListApplicable(_, _ uuid.UUID) (*[]accountEntities.Policy, error) {
+	args := m.MethodCalled("ListApplicable")
+	return args.Get(0).(*[]accountEntities.Policy), mockUtils.ReturnNilOrError(args, 1)
+}
@@ -0,0 +1,164 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("Create").Return(nil)
+	m.On("Update").Return(nil)
+	m.On("Delete").Return(nil)
+	m.On("Get").Return(&accountEntities.Policy{}, nil)
+	m.On("ListByCompanyID").Return(&[]accountEntities.Policy{}, nil)
+	m.On("ListApplicable").Return(&[]accountEntities.Policy{}, nil)
+	assert.NoError(t, m.Create(&accountEntities.Policy{}))
+	assert.NoError(t, m.Update(&accountEntities.Policy{}))
+	assert.NoError(t, m.Delete(uuid.New(), uuid.New()))
+	_, err := m.Get(uuid.New(), uuid.New())
+	assert.NoError(t, err)
+	_, err = m.ListByCompanyID(uuid.New())
+	assert.NoError(t, err)
+	_, err = m.ListApplicable(uuid.New(), uuid.New())
+	assert.NoError(t, err)
+}
+
+func TestNewPolicyRepository(t *testing.T) {
+	assert.NotEmpty(t, NewPolicyRepository(&relational.MockRead{}, &relational.MockWrite{}))
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("should create policy without errors", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Create").Return(response.NewResponse(1, nil, nil))
+		r := NewPolicyRepository(&relational.MockRead{}, mockWrite)
+		assert.NoError(t, r.Create(&accountEntities.Policy{}))
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("should update policy without errors", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
+		r := NewPolicyRepository(&relational.MockRead{}, mockWrite)
+		assert.NoError(t, r.Update(&accountEntities.Policy{}))
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("should delete policy without errors", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(1, nil, nil))
+		r := NewPolicyRepository(&relational.MockRead{}, mockWrite)
+		assert.NoError(t, r.Delete(uuid.New(), uuid.New()))
+	})
+	t.Run("should return not found when policy is not of the company", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(0, nil, nil))
+		r := NewPolicyRepository(&relational.MockRead{}, mockWrite)
+		assert.Equal(t, EnumErrors.ErrNotFoundRecords, r.Delete(uuid.New(), uuid.New()))
+	})
+	t.Run("should return error when delete fails", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(0, errors.New("test"), nil))
+		r := NewPolicyRepository(&relational.MockRead{}, mockWrite)
+		assert.Error(t, r.Delete(uuid.New(), uuid.New()))
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("should return policy", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(1, nil, &accountEntities.Policy{}))
+		r := NewPolicyRepository(mockRead, &relational.MockWrite{})
+		_, err = r.Get(uuid.New(), uuid.New())
+		assert.NoError(t, err)
+	})
+}
+
+func TestListByCompanyID(t *testing.T) {
+	t.Run("should return empty list when not found", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil))
+		r := NewPolicyRepository(mockRead, &relational.MockWrite{})
+		result, err := r.ListByCompanyID(uuid.New())
+		assert.NoError(t, err)
+		assert.Empty(t, *result)
+	})
+	t.Run("should return error when find fails", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, errors.New("test"), nil))
+		r := NewPolicyRepository(mockRead, &relational.MockWrite{})
+		_, err = r.ListByCompanyID(uuid.New())
+		assert.Error(t, err)
+	})
+}
+
+func TestListApplicable(t *testing.T) {
+	t.Run("should return the policies of the company and of the repository", func(t *testing.T) {
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		assert.NoError(t, conn.Table("policies").AutoMigrate(&accountEntities.Policy{}).Error)
+
+		companyID := uuid.New()
+		repositoryID := uuid.New()
+		otherRepositoryID := uuid.New()
+		maxHigh := 0
+		policies := []*accountEntities.Policy{
+			{Name: "company", MaxHighVulnerabilities: &maxHigh, RequiredTools: []string{"GoSec"}},
+			{Name: "repository", RepositoryID: &repositoryID},
+			{Name: "other repository", RepositoryID: &otherRepositoryID},
+		}
+		for index, policy := range policies {
+			policy.SetCreateData(companyID).CreatedAt = time.Now().Add(time.Duration(index) * time.Minute)
+			assert.NoError(t, conn.Table("policies").Create(policy).Error)
+		}
+		assert.NoError(t, conn.Table("policies").Create((&accountEntities.Policy{Name: "other company"}).
+			SetCreateData(uuid.New())).Error)
+
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(conn)
+		result, err := NewPolicyRepository(mockRead, &relational.MockWrite{}).ListApplicable(companyID, repositoryID)
+
+		assert.NoError(t, err)
+		assert.Len(t, *result, 2)
+		assert.Equal(t, "company", (*result)[0].Name)
+		assert.Equal(t, 0, *(*result)[0].MaxHighVulnerabilities)
+		assert.Equal(t, []string{"GoSec"}, []string((*result)[0].RequiredTools))
+		assert.Equal(t, "repository", (*result)[1].Name)
+	})
+}
@@ -37,6 +37,7 @@ type IRepository interface {
 	GetByName(companyID uuid.UUID, repositoryName string) (*accountEntities.Repository, error)
 	GetAccountCompanyRole(accountID, companyID uuid.UUID) (*roles.AccountCompany, error)
 	ListByLdapPermissions(companyID uuid.UUID, permissions []string) (*[]accountEntities.RepositoryResponse, error)
+	ListAllInCompany(companyID uuid.UUID) (*[]accountEntities.Repository, error)
 }
 
 type Repository struct {
@@ -97,6 +98,18 @@ func (r *Repository) Get(repositoryID uuid.UUID) (*accountEntities.Repository, e
 	return repository, response.GetError()
 }
 
+func (r *Repository) ListAllInCompany(companyID uuid.UUID) (*[]accountEntities.Repository, error) {
+	repositories := &[]accountEntities.Repository{}
+	response := r.databaseRead.Find(repositories,
+		r.databaseRead.SetFilter(map[string]interface{}{"company_id": companyID}),
+		(&accountEntities.Repository{}).GetTable())
+	if response.GetError() != nil && response.GetError() != errors.ErrNotFoundRecords {
+		return nil, response.GetError()
+	}
+
+	return repositories, nil
+}
+
 func (r *Repository) List(accountID, companyID uuid.UUID) (*[]accountEntities.RepositoryResponse, error) {
 	accountCompany, err := r.GetAccountCompanyRole(accountID, companyID)
 	if err != nil {
@@ -71,3 +71,8 @@ func (m *Mock) ListByLdapPermissions(_ uuid.UUID, _ []string) (*[]accountEntitie
 	args := m.MethodCalled("ListByLdapPermissions")
 	return args.Get(0).(*[]accountEntities.RepositoryResponse), mockUtils.ReturnNilOrError(args, 1)
 }
+
+func (m *Mock) ListAllInCompany(_ uuid.UUID) (*[]accountEntities.Repository, error) {
+	args := m.MethodCalled("ListAllInCompany")
+	return args.Get(0).(*[]accountEntities.Repository), mockUtils.ReturnNilOrError(args, 1)
+}
@@ -55,6 +55,9 @@ func TestMock(t *testing.T) {
 	assert.NoError(t, err)
 	_, err = m.GetAccountCompanyRole(uuid.New(), uuid.New())
 	assert.NoError(t, err)
+	m.On("ListAllInCompany").Return(&[]accountEntities.Repository{}, nil)
+	_, err = m.ListAllInCompany(uuid.New())
+	assert.NoError(t, err)
 }
 
 func TestCreateRepository(t *testing.T) {
@@ -298,3 +301,34 @@ func TestGetAllAccountsInRepository(t *testing.T) {
 // 		assert.NotNil(t, retrievedRepositories)
 // 	})
 // }
+
+func TestListAllInCompany(t *testing.T) {
+	t.Run("should list all repositories of company", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(1, nil, &[]accountEntities.Repository{{Name: "test"}}))
+
+		repositories, err := NewRepository(mockRead, &relational.MockWrite{}).ListAllInCompany(uuid.New())
+		assert.NoError(t, err)
+		assert.Len(t, *repositories, 1)
+	})
+
+	t.Run("should return empty list when not found records", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(0, errorsEnums.ErrNotFoundRecords, nil))
+
+		repositories, err := NewRepository(mockRead, &relational.MockWrite{}).ListAllInCompany(uuid.New())
+		assert.NoError(t, err)
+		assert.Empty(t, *repositories)
+	})
+
+	t.Run("should return error when find fails", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		_, err := NewRepository(mockRead, &relational.MockWrite{}).ListAllInCompany(uuid.New())
+		assert.Error(t, err)
+	})
+}
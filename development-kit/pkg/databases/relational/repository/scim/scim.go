@@ -0,0 +1,188 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	scimEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/scim"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+)
+
+var userFilterColumns = map[string]string{"userName": "username", "externalId": "external_id",
+	"emails.value": "email", "emails": "email"}
+var groupFilterColumns = map[string]string{"displayName": "display_name", "externalId": "external_id"}
+
+type IRepository interface {
+	ListUsers(filter *scimEntities.Filter) (*[]authEntities.Account, int, error)
+	ListGroups(filter *scimEntities.Filter) (*[]authEntities.ScimGroup, int, error)
+	GetGroup(groupID uuid.UUID) (*authEntities.ScimGroup, error)
+	CreateGroup(group *authEntities.ScimGroup) error
+	UpdateGroup(group *authEntities.ScimGroup) error
+	DeleteGroup(groupID uuid.UUID) error
+	ListMembers(groupID uuid.UUID) (*[]authEntities.Account, error)
+	SetMembers(groupID uuid.UUID, accountIDs []uuid.UUID) error
+	ListActiveMemberships() (*[]authEntities.ScimGroupMembership, error)
+	DeleteAccountMemberships(accountID uuid.UUID) error
+}
+
+type Repository struct {
+	databaseRead  SQL.InterfaceRead
+	databaseWrite SQL.InterfaceWrite
+}
+
+func NewScimRepository(databaseRead SQL.InterfaceRead, databaseWrite SQL.InterfaceWrite) IRepository {
+	return &Repository{
+		databaseRead:  databaseRead,
+		databaseWrite: databaseWrite,
+	}
+}
+
+func (r *Repository) ListUsers(filter *scimEntities.Filter) (*[]authEntities.Account, int, error) {
+	accounts := &[]authEntities.Account{}
+	total, err := r.listPaginated(accounts, (&authEntities.Account{}).GetTable(), filter, userFilterColumns)
+	return accounts, total, err
+}
+
+func (r *Repository) ListGroups(filter *scimEntities.Filter) (*[]authEntities.ScimGroup, int, error) {
+	groups := &[]authEntities.ScimGroup{}
+	total, err := r.listPaginated(groups, (&authEntities.ScimGroup{}).GetTable(), filter, groupFilterColumns)
+	return groups, total, err
+}
+
+func (r *Repository) listPaginated(entities interface{}, table string, filter *scimEntities.Filter,
+	columns map[string]string) (total int, err error) {
+	query := r.databaseRead.GetConnection().Table(table)
+	if filter.Attribute != "" {
+		column := filter.GetColumn(columns)
+		if column == "" {
+			return 0, errors.ErrorScimInvalidFilter
+		}
+
+		query = query.Where(column+" = ?", filter.Value)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, query.Order("created_at").Limit(filter.Count).Offset(filter.GetOffset()).Find(entities).Error
+}
+
+func (r *Repository) GetGroup(groupID uuid.UUID) (*authEntities.ScimGroup, error) {
+	group := &authEntities.ScimGroup{}
+	filter := r.databaseRead.SetFilter(map[string]interface{}{"group_id": groupID})
+	return group, r.databaseRead.Find(group, filter, group.GetTable()).GetError()
+}
+
+func (r *Repository) CreateGroup(group *authEntities.ScimGroup) error {
+	return r.databaseWrite.Create(group, group.GetTable()).GetError()
+}
+
+func (r *Repository) UpdateGroup(group *authEntities.ScimGroup) error {
+	return r.databaseWrite.Update(map[string]interface{}{
+		"display_name": group.DisplayName,
+		"external_id":  group.ExternalID,
+		"updated_at":   group.UpdatedAt,
+	}, map[string]interface{}{"group_id": group.GroupID}, group.GetTable()).GetError()
+}
+
+// DeleteGroup also removes the members of the group by the foreign key cascade
+func (r *Repository) DeleteGroup(groupID uuid.UUID) error {
+	result := r.databaseWrite.Delete(map[string]interface{}{"group_id": groupID}, (&authEntities.ScimGroup{}).GetTable())
+	if result.GetError() != nil {
+		return result.GetError()
+	}
+
+	if result.GetRowsAffected() == 0 {
+		return errors.ErrNotFoundRecords
+	}
+
+	return nil
+}
+
+func (r *Repository) ListMembers(groupID uuid.UUID) (*[]authEntities.Account, error) {
+	accounts := &[]authEntities.Account{}
+	return accounts, r.databaseRead.GetConnection().Table("accounts").Select("accounts.*").
+		Joins("JOIN scim_group_members ON scim_group_members.account_id = accounts.account_id").
+		Where("scim_group_members.group_id = ?", groupID).Order("accounts.username").Find(accounts).Error
+}
+
+// SetMembers replaces all members of the group, every account must exist
+func (r *Repository) SetMembers(groupID uuid.UUID, accountIDs []uuid.UUID) error {
+	if err := r.checkAccountsExist(accountIDs); err != nil {
+		return err
+	}
+
+	tx := r.databaseWrite.StartTransaction()
+	if err := tx.Delete(map[string]interface{}{"group_id": groupID},
+		(&authEntities.ScimGroupMember{}).GetTable()).GetError(); err != nil {
+		_ = tx.RollbackTransaction()
+		return err
+	}
+
+	for _, accountID := range accountIDs {
+		member := &authEntities.ScimGroupMember{GroupID: groupID, AccountID: accountID}
+		if err := tx.Create(member, member.GetTable()).GetError(); err != nil {
+			_ = tx.RollbackTransaction()
+			return err
+		}
+	}
+
+	return tx.CommitTransaction().GetError()
+}
+
+func (r *Repository) checkAccountsExist(accountIDs []uuid.UUID) error {
+	if len(accountIDs) == 0 {
+		return nil
+	}
+
+	count := 0
+	if err := r.databaseRead.GetConnection().Table("accounts").Where("account_id IN (?)", accountIDs).
+		Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count != len(accountIDs) {
+		return errors.ErrorScimInvalidMember
+	}
+
+	return nil
+}
+
+// ListActiveMemberships returns the enabled accounts of each group, groups without them come with a nil account id
+func (r *Repository) ListActiveMemberships() (*[]authEntities.ScimGroupMembership, error) {
+	memberships := &[]authEntities.ScimGroupMembership{}
+	return memberships, r.databaseRead.GetConnection().Table("scim_groups").
+		Select("scim_groups.display_name, accounts.account_id").
+		Joins("LEFT JOIN scim_group_members ON scim_group_members.group_id = scim_groups.group_id").
+		Joins("LEFT JOIN accounts ON accounts.account_id = scim_group_members.account_id AND "+
+			"accounts.is_disabled = ?", false).Scan(memberships).Error
+}
+
+// DeleteAccountMemberships removes the account from all companies and repositories
+func (r *Repository) DeleteAccountMemberships(accountID uuid.UUID) error {
+	tx := r.databaseWrite.StartTransaction()
+	for _, table := range []string{(&roles.AccountRepository{}).GetTable(), (&roles.AccountCompany{}).GetTable()} {
+		if err := tx.Delete(map[string]interface{}{"account_id": accountID}, table).GetError(); err != nil {
+			_ = tx.RollbackTransaction()
+			return err
+		}
+	}
+
+	return tx.CommitTransaction().GetError()
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	scimEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/scim"
+	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) ListUsers(_ *scimEntities.Filter) (*[]authEntities.Account, int, error) {
+	args := m.MethodCalled("ListUsers")
+	return args.Get(0).(*[]authEntities.Account), args.Int(1), mockUtils.ReturnNilOrError(args, 2)
+}
+
+func (m *Mock) ListGroups(_ *scimEntities.Filter) (*[]authEntities.ScimGroup, int, error) {
+	args := m.MethodCalled("ListGroups")
+	return args.Get(0).(*[]authEntities.ScimGroup), args.Int(1), mockUtils.ReturnNilOrError(args, 2)
+}
+
+func (m *Mock) GetGroup(_ uuid.UUID) (*authEntities.ScimGroup, error) {
+	args := m.MethodCalled("GetGroup")
+	return args.Get(0).(*authEntities.ScimGroup), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) CreateGroup(_ *authEntities.ScimGroup) error {
+	args := m.MethodCalled("CreateGroup")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) UpdateGroup(_ *authEntities.ScimGroup) error {
+	args := m.MethodCalled("UpdateGroup")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) DeleteGroup(_ uuid.UUID) error {
+	args := m.MethodCalled("DeleteGroup")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) ListMembers(_ uuid.UUID) (*[]authEntities.Account, error) {
+	args := m.MethodCalled("ListMembers")
+	return args.Get(0).(*[]authEntities.Account), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) SetMembers(_ uuid.UUID, _ []uuid.UUID) error {
+	args := m.MethodCalled("SetMembers")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) ListActiveMemberships() (*[]authEntities.ScimGroupMembership, error) {
+	args := m.MethodCalled("ListActiveMemberships")
+	return args.Get(0).(*[]authEntities.ScimGroupMembership), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) DeleteAccountMemberships(_ uuid.UUID) error {
+	args := m.MethodCalled("DeleteAccountMemberships")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
@@ -0,0 +1,296 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	scimEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/scim"
+	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
+	"github.com/stretchr/testify/assert"
+)
+
+func newConnection(t *testing.T) *gorm.DB {
+	conn, err := gorm.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Exec(`CREATE TABLE accounts (account_id TEXT PRIMARY KEY, email TEXT, username TEXT,
+		password TEXT, is_confirmed BOOLEAN, is_application_admin BOOLEAN, is_disabled BOOLEAN, external_id TEXT,
+		created_at DATETIME, updated_at DATETIME)`).Error)
+	assert.NoError(t, conn.Table("scim_groups").AutoMigrate(&authEntities.ScimGroup{}).Error)
+	assert.NoError(t, conn.Table("scim_group_members").AutoMigrate(&authEntities.ScimGroupMember{}).Error)
+	return conn
+}
+
+func createAccount(t *testing.T, conn *gorm.DB, username string, isDisabled bool, createdAt time.Time) uuid.UUID {
+	accountID := uuid.New()
+	assert.NoError(t, conn.Exec(`INSERT INTO accounts (account_id, email, username, is_disabled, external_id,
+		created_at) VALUES (?, ?, ?, ?, ?, ?)`, accountID, username+"@horusec.io", username, isDisabled,
+		"external-"+username, createdAt).Error)
+	return accountID
+}
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("ListUsers").Return(&[]authEntities.Account{}, 0, nil)
+	m.On("ListGroups").Return(&[]authEntities.ScimGroup{}, 0, nil)
+	m.On("GetGroup").Return(&authEntities.ScimGroup{}, nil)
+	m.On("CreateGroup").Return(nil)
+	m.On("UpdateGroup").Return(nil)
+	m.On("DeleteGroup").Return(nil)
+	m.On("ListMembers").Return(&[]authEntities.Account{}, nil)
+	m.On("SetMembers").Return(nil)
+	m.On("ListActiveMemberships").Return(&[]authEntities.ScimGroupMembership{}, nil)
+	m.On("DeleteAccountMemberships").Return(nil)
+	_, _, err := m.ListUsers(&scimEntities.Filter{})
+	assert.NoError(t, err)
+	_, _, err = m.ListGroups(&scimEntities.Filter{})
+	assert.NoError(t, err)
+	_, err = m.GetGroup(uuid.New())
+	assert.NoError(t, err)
+	assert.NoError(t, m.CreateGroup(&authEntities.ScimGroup{}))
+	assert.NoError(t, m.UpdateGroup(&authEntities.ScimGroup{}))
+	assert.NoError(t, m.DeleteGroup(uuid.New()))
+	_, err = m.ListMembers(uuid.New())
+	assert.NoError(t, err)
+	assert.NoError(t, m.SetMembers(uuid.New(), nil))
+	_, err = m.ListActiveMemberships()
+	assert.NoError(t, err)
+	assert.NoError(t, m.DeleteAccountMemberships(uuid.New()))
+}
+
+func TestNewScimRepository(t *testing.T) {
+	assert.NotEmpty(t, NewScimRepository(&relational.MockRead{}, &relational.MockWrite{}))
+}
+
+func TestListUsers(t *testing.T) {
+	conn := newConnection(t)
+	firstID := createAccount(t, conn, "first", false, time.Now().Add(-time.Hour))
+	createAccount(t, conn, "second", false, time.Now())
+	mockRead := &relational.MockRead{}
+	mockRead.On("GetConnection").Return(conn)
+	repository := NewScimRepository(mockRead, &relational.MockWrite{})
+
+	t.Run("should list users with pagination", func(t *testing.T) {
+		filter, _ := scimEntities.NewFilter("", 1, 1)
+
+		accounts, total, err := repository.ListUsers(filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, *accounts, 1)
+		assert.Equal(t, firstID, (*accounts)[0].AccountID)
+	})
+
+	t.Run("should list users by filter", func(t *testing.T) {
+		filter, _ := scimEntities.NewFilter(`externalId eq "external-second"`, 1, 10)
+
+		accounts, total, err := repository.ListUsers(filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, "second", (*accounts)[0].Username)
+	})
+
+	t.Run("should return error when filter attribute is not supported", func(t *testing.T) {
+		filter, _ := scimEntities.NewFilter(`title eq "test"`, 1, 10)
+
+		_, _, err := repository.ListUsers(filter)
+
+		assert.Equal(t, EnumErrors.ErrorScimInvalidFilter, err)
+	})
+}
+
+func TestListGroups(t *testing.T) {
+	t.Run("should list groups by display name", func(t *testing.T) {
+		conn := newConnection(t)
+		group := (&authEntities.ScimGroup{DisplayName: "security-team"}).SetCreateData()
+		assert.NoError(t, conn.Table("scim_groups").Create(group).Error)
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(conn)
+		filter, _ := scimEntities.NewFilter(`displayName eq "security-team"`, 1, 10)
+
+		groups, total, err := NewScimRepository(mockRead, &relational.MockWrite{}).ListGroups(filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, group.GroupID, (*groups)[0].GroupID)
+	})
+
+	t.Run("should return error when query fails", func(t *testing.T) {
+		conn, _ := gorm.Open("sqlite3", ":memory:")
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(conn)
+
+		_, _, err := NewScimRepository(mockRead, &relational.MockWrite{}).ListGroups(&scimEntities.Filter{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetGroup(t *testing.T) {
+	t.Run("should get group without errors", func(t *testing.T) {
+		conn, _ := gorm.Open("sqlite3", ":memory:")
+		mockRead := &relational.MockRead{}
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(1, nil, nil))
+
+		_, err := NewScimRepository(mockRead, &relational.MockWrite{}).GetGroup(uuid.New())
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateAndUpdateGroup(t *testing.T) {
+	t.Run("should create and update group without errors", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Create").Return(response.NewResponse(1, nil, nil))
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
+		repository := NewScimRepository(&relational.MockRead{}, mockWrite)
+
+		assert.NoError(t, repository.CreateGroup(&authEntities.ScimGroup{}))
+		assert.NoError(t, repository.UpdateGroup(&authEntities.ScimGroup{}))
+	})
+}
+
+func TestDeleteGroup(t *testing.T) {
+	t.Run("should delete group without errors", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(1, nil, nil))
+
+		assert.NoError(t, NewScimRepository(&relational.MockRead{}, mockWrite).DeleteGroup(uuid.New()))
+	})
+
+	t.Run("should return not found when group does not exist", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(0, nil, nil))
+
+		assert.Equal(t, EnumErrors.ErrNotFoundRecords,
+			NewScimRepository(&relational.MockRead{}, mockWrite).DeleteGroup(uuid.New()))
+	})
+
+	t.Run("should return error when delete fails", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Delete").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		assert.Error(t, NewScimRepository(&relational.MockRead{}, mockWrite).DeleteGroup(uuid.New()))
+	})
+}
+
+func TestMembers(t *testing.T) {
+	conn := newConnection(t)
+	activeID := createAccount(t, conn, "active", false, time.Now())
+	disabledID := createAccount(t, conn, "disabled", true, time.Now())
+	group := (&authEntities.ScimGroup{DisplayName: "security-team"}).SetCreateData()
+	assert.NoError(t, conn.Table("scim_groups").Create(group).Error)
+	for _, accountID := range []uuid.UUID{activeID, disabledID} {
+		assert.NoError(t, conn.Table("scim_group_members").Create(
+			&authEntities.ScimGroupMember{GroupID: group.GroupID, AccountID: accountID}).Error)
+	}
+
+	mockRead := &relational.MockRead{}
+	mockRead.On("GetConnection").Return(conn)
+	repository := NewScimRepository(mockRead, &relational.MockWrite{})
+
+	t.Run("should list members of the group", func(t *testing.T) {
+		accounts, err := repository.ListMembers(group.GroupID)
+
+		assert.NoError(t, err)
+		assert.Len(t, *accounts, 2)
+		assert.Equal(t, "active", (*accounts)[0].Username)
+	})
+
+	t.Run("should list memberships of active accounts", func(t *testing.T) {
+		memberships, err := repository.ListActiveMemberships()
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []authEntities.ScimGroupMembership{{DisplayName: "security-team",
+			AccountID: activeID}, {DisplayName: "security-team", AccountID: uuid.Nil}}, *memberships)
+	})
+
+	t.Run("should return error when member does not exist", func(t *testing.T) {
+		assert.Equal(t, EnumErrors.ErrorScimInvalidMember,
+			repository.SetMembers(group.GroupID, []uuid.UUID{activeID, uuid.New()}))
+	})
+}
+
+func TestSetMembers(t *testing.T) {
+	t.Run("should replace the members in a transaction", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("StartTransaction").Return(mockWrite)
+		mockWrite.On("Delete").Return(response.NewResponse(1, nil, nil))
+		mockWrite.On("Create").Return(response.NewResponse(1, nil, nil))
+		mockWrite.On("CommitTransaction").Return(response.NewResponse(0, nil, nil))
+		conn := newConnection(t)
+		accountID := createAccount(t, conn, "test", false, time.Now())
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(conn)
+
+		assert.NoError(t, NewScimRepository(mockRead, mockWrite).SetMembers(uuid.New(), []uuid.UUID{accountID}))
+		mockWrite.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("should rollback when create fails", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("StartTransaction").Return(mockWrite)
+		mockWrite.On("Delete").Return(response.NewResponse(1, nil, nil))
+		mockWrite.On("Create").Return(response.NewResponse(0, errors.New("test"), nil))
+		mockWrite.On("RollbackTransaction").Return(response.NewResponse(0, nil, nil))
+		conn := newConnection(t)
+		accountID := createAccount(t, conn, "test", false, time.Now())
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(conn)
+
+		assert.Error(t, NewScimRepository(mockRead, mockWrite).SetMembers(uuid.New(), []uuid.UUID{accountID}))
+		mockWrite.AssertCalled(t, "RollbackTransaction")
+	})
+
+	t.Run("should rollback when delete fails", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("StartTransaction").Return(mockWrite)
+		mockWrite.On("Delete").Return(response.NewResponse(0, errors.New("test"), nil))
+		mockWrite.On("RollbackTransaction").Return(response.NewResponse(0, nil, nil))
+
+		assert.Error(t, NewScimRepository(&relational.MockRead{}, mockWrite).SetMembers(uuid.New(), nil))
+	})
+}
+
+func TestDeleteAccountMemberships(t *testing.T) {
+	t.Run("should delete companies and repositories of the account", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("StartTransaction").Return(mockWrite)
+		mockWrite.On("Delete").Return(response.NewResponse(1, nil, nil))
+		mockWrite.On("CommitTransaction").Return(response.NewResponse(0, nil, nil))
+
+		assert.NoError(t, NewScimRepository(&relational.MockRead{}, mockWrite).DeleteAccountMemberships(uuid.New()))
+		mockWrite.AssertNumberOfCalls(t, "Delete", 2)
+	})
+
+	t.Run("should rollback when delete fails", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("StartTransaction").Return(mockWrite)
+		mockWrite.On("Delete").Return(response.NewResponse(0, errors.New("test"), nil))
+		mockWrite.On("RollbackTransaction").Return(response.NewResponse(0, nil, nil))
+
+		assert.Error(t, NewScimRepository(&relational.MockRead{}, mockWrite).DeleteAccountMemberships(uuid.New()))
+	})
+}
@@ -0,0 +1,321 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"fmt"
+	"time"
+
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/pagination"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+)
+
+type ISnapshotRepository interface {
+	ITotalsRepository
+	Refresh(analysis *horusec.Analysis) error
+	RefreshByVulnerabilities(vulnerabilityIDs []uuid.UUID) error
+	RefreshByRepository(repositoryID uuid.UUID) error
+	Exists(analysis *horusec.Analysis) (bool, error)
+	GetDaily(analysis *horusec.Analysis) (*dashboard.DailySnapshot, error)
+	ListAnalysisToRefresh(initialDate time.Time, page, size int) ([]horusec.Analysis, error)
+}
+
+// ITotalsRepository has the dashboard totals that can be read from the daily snapshots or from the analysis
+type ITotalsRepository interface {
+	GetRepositoryCount(companyID, repositoryID uuid.UUID, initialDate,
+		finalDate time.Time) (count int, err error)
+	GetVulnBySeverity(companyID, repositoryID uuid.UUID, initialDate,
+		finalDate time.Time) (vulnBySeverity []dashboard.VulnBySeverity, err error)
+	GetVulnByLanguage(companyID, repositoryID uuid.UUID, initialDate,
+		finalDate time.Time) (vulnByLanguage []dashboard.VulnByLanguage, err error)
+	GetVulnByRepository(companyID, repositoryID uuid.UUID, initialDate,
+		finalDate time.Time) (vulnByRepository []dashboard.VulnByRepository, err error)
+	GetVulnByTime(companyID, repositoryID uuid.UUID, initialDate,
+		finalDate time.Time) (vulnByTime []dashboard.VulnByTime, err error)
+}
+
+type Repository struct {
+	databaseRead  SQL.InterfaceRead
+	databaseWrite SQL.InterfaceWrite
+}
+
+func NewSnapshotRepository(databaseRead SQL.InterfaceRead, databaseWrite SQL.InterfaceWrite) ISnapshotRepository {
+	return &Repository{
+		databaseRead:  databaseRead,
+		databaseWrite: databaseWrite,
+	}
+}
+
+// Refresh replaces the snapshot of the analysis day by the vulnerabilities of the analysis,
+// it is ignored when the day already has a snapshot of another analysis of the repository that finished later
+func (r *Repository) Refresh(analysis *horusec.Analysis) error {
+	isOutdated, err := r.isOutdated(analysis)
+	if err != nil || isOutdated {
+		return err
+	}
+
+	counts, err := r.getSnapshotCounts(analysis.GetID())
+	if err != nil {
+		return err
+	}
+
+	return r.replace(analysis, dashboard.NewDailySnapshots(analysis, counts))
+}
+
+// RefreshByVulnerabilities refreshes the snapshots of the analysis that found the vulnerabilities, it must be called
+// after their type changes
+func (r *Repository) RefreshByVulnerabilities(vulnerabilityIDs []uuid.UUID) error {
+	if len(vulnerabilityIDs) == 0 {
+		return nil
+	}
+
+	return r.refreshAll(r.getAnalysisToRefreshQuery().
+		Joins("JOIN analysis_vulnerabilities ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
+		Where("analysis_vulnerabilities.vulnerability_id IN (?)", vulnerabilityIDs))
+}
+
+// RefreshByRepository refreshes all snapshots of the repository, it must be called after a change of type that can
+// not list the vulnerabilities changed
+func (r *Repository) RefreshByRepository(repositoryID uuid.UUID) error {
+	return r.refreshAll(r.getAnalysisToRefreshQuery().Where("analysis.repository_id = ?", repositoryID))
+}
+
+func (r *Repository) getAnalysisToRefreshQuery() *gorm.DB {
+	return r.databaseRead.
+		GetConnection().
+		Select("DISTINCT analysis.analysis_id, analysis.company_id, analysis.repository_id," +
+			" analysis.repository_name, analysis.finished_at").
+		Table("analysis")
+}
+
+func (r *Repository) refreshAll(query *gorm.DB) error {
+	var analysis []horusec.Analysis
+	if err := query.Find(&analysis).Error; err != nil {
+		return err
+	}
+
+	for index := range analysis {
+		if err := r.Refresh(&analysis[index]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Exists returns if the repository already has a snapshot in the day of the analysis
+func (r *Repository) Exists(analysis *horusec.Analysis) (bool, error) {
+	count := 0
+	err := r.databaseRead.GetConnection().
+		Table((&dashboard.DailySnapshot{}).GetTable()).
+		Where("snapshot_date = ? AND repository_id = ?",
+			dashboard.GetSnapshotDate(analysis.FinishedAt), analysis.RepositoryID).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// GetDaily returns a row of the snapshot of the analysis day with the analysis it was created from, it is nil when
+// the repository has no snapshot in the day
+func (r *Repository) GetDaily(analysis *horusec.Analysis) (*dashboard.DailySnapshot, error) {
+	snapshot := &dashboard.DailySnapshot{}
+	err := r.databaseRead.GetConnection().
+		Table(snapshot.GetTable()).
+		Where("snapshot_date = ? AND repository_id = ?",
+			dashboard.GetSnapshotDate(analysis.FinishedAt), analysis.RepositoryID).
+		First(snapshot).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+
+	return snapshot, err
+}
+
+func (r *Repository) isOutdated(analysis *horusec.Analysis) (bool, error) {
+	latest := &dashboard.DailySnapshot{}
+	err := r.databaseRead.GetConnection().
+		Table(latest.GetTable()).
+		Where("snapshot_date = ? AND repository_id = ?",
+			dashboard.GetSnapshotDate(analysis.FinishedAt), analysis.RepositoryID).
+		Order("finished_at DESC").
+		First(latest).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return false, nil
+	}
+
+	return err == nil && latest.AnalysisID != analysis.ID && latest.FinishedAt.After(analysis.FinishedAt), err
+}
+
+func (r *Repository) getSnapshotCounts(analysisID uuid.UUID) (counts []dashboard.SnapshotCount, err error) {
+	query := r.databaseRead.
+		GetConnection().
+		Select("vulnerabilities.severity AS severity, vulnerabilities.language AS language,"+
+			" vulnerabilities.security_tool AS security_tool, vulnerabilities.type AS type, COUNT(*) AS total").
+		Table("analysis_vulnerabilities").
+		Joins("JOIN vulnerabilities ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id").
+		Where("analysis_vulnerabilities.analysis_id = ?", analysisID).
+		Group("vulnerabilities.severity, vulnerabilities.language," +
+			" vulnerabilities.security_tool, vulnerabilities.type").
+		Find(&counts)
+
+	return counts, query.Error
+}
+
+func (r *Repository) replace(analysis *horusec.Analysis, snapshots []dashboard.DailySnapshot) error {
+	tx := r.databaseWrite.StartTransaction()
+	conn := tx.GetConnection().Table((&dashboard.DailySnapshot{}).GetTable())
+	err := conn.Where("snapshot_date = ? AND repository_id = ?",
+		dashboard.GetSnapshotDate(analysis.FinishedAt), analysis.RepositoryID).Delete(nil).Error
+
+	for index := 0; err == nil && index < len(snapshots); index++ {
+		err = conn.Create(&snapshots[index]).Error
+	}
+
+	if err != nil {
+		_ = tx.RollbackTransaction()
+		return err
+	}
+
+	return tx.CommitTransaction().GetError()
+}
+
+func (r *Repository) ListAnalysisToRefresh(initialDate time.Time, page, size int) (
+	analysis []horusec.Analysis, err error) {
+	query := r.databaseRead.
+		GetConnection().
+		Select("analysis_id, company_id, repository_id, repository_name, finished_at").
+		Table("analysis").
+		Where("finished_at >= ?", initialDate).
+		Order("finished_at DESC").
+		Limit(size).
+		Offset(pagination.GetSkip(int64(page), int64(size))).
+		Find(&analysis)
+
+	return analysis, query.Error
+}
+
+func (r *Repository) GetRepositoryCount(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (count int, err error) {
+	query := r.databaseRead.
+		GetConnection().
+		Table("analysis_daily_snapshots AS snapshots").
+		Select("COUNT( DISTINCT ( snapshots.repository_id ) )")
+
+	query = r.setWhereFilter(query, "snapshots", companyID, repositoryID, initialDate, finalDate).Count(&count)
+
+	return count, query.Error
+}
+
+func (r *Repository) GetVulnBySeverity(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (vulnBySeverity []dashboard.VulnBySeverity, err error) {
+	query := r.getLatestSnapshots(companyID, repositoryID, initialDate, finalDate).
+		Select("snapshots.severity AS severity, SUM(snapshots.total) AS total").
+		Group("snapshots.severity").
+		Find(&vulnBySeverity)
+
+	return vulnBySeverity, query.Error
+}
+
+func (r *Repository) GetVulnByLanguage(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (vulnByLanguage []dashboard.VulnByLanguage, err error) {
+	query := r.getLatestSnapshots(companyID, repositoryID, initialDate, finalDate).
+		Select("snapshots.language AS language, " + r.getTotalsBySeverity()).
+		Group("snapshots.language").
+		Find(&vulnByLanguage)
+
+	return vulnByLanguage, query.Error
+}
+
+func (r *Repository) GetVulnByRepository(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (vulnByRepository []dashboard.VulnByRepository, err error) {
+	query := r.getLatestSnapshots(companyID, repositoryID, initialDate, finalDate).
+		Select("MAX(snapshots.repository_name) AS repository, " + r.getTotalsBySeverity()).
+		Group("snapshots.repository_id").
+		Order("total DESC").
+		Limit(5).
+		Find(&vulnByRepository)
+
+	return vulnByRepository, query.Error
+}
+
+// GetVulnByTime returns the sum of the snapshots of the repositories analysed in each day
+func (r *Repository) GetVulnByTime(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) (vulnByTime []dashboard.VulnByTime, err error) {
+	query := r.databaseRead.
+		GetConnection().
+		Select("snapshots.snapshot_date AS time, " + r.getTotalsBySeverity()).
+		Table("analysis_daily_snapshots AS snapshots").
+		Where("snapshots.total > 0").
+		Group("snapshots.snapshot_date").
+		Order("snapshots.snapshot_date ASC")
+
+	query = r.setWhereFilter(query, "snapshots", companyID, repositoryID, initialDate, finalDate).Find(&vulnByTime)
+
+	return vulnByTime, query.Error
+}
+
+// getLatestSnapshots filters the snapshots of the last day that each repository was analysed in the period
+func (r *Repository) getLatestSnapshots(companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) *gorm.DB {
+	subQuery := r.databaseRead.
+		GetConnection().
+		Select("MAX(latest.snapshot_date)").
+		Table("analysis_daily_snapshots AS latest").
+		Where("latest.repository_id = snapshots.repository_id")
+
+	subQuery = r.setWhereFilter(subQuery, "latest", companyID, repositoryID, initialDate, finalDate)
+
+	query := r.databaseRead.
+		GetConnection().
+		Table("analysis_daily_snapshots AS snapshots").
+		Where("snapshots.snapshot_date = ? AND snapshots.total > 0", subQuery.SubQuery())
+
+	return r.setWhereFilter(query, "snapshots", companyID, repositoryID, initialDate, finalDate)
+}
+
+func (r *Repository) getTotalsBySeverity() string {
+	return "SUM(snapshots.total) AS total, " +
+		r.sumBySeverity(severity.Low, "low") + ", " +
+		r.sumBySeverity(severity.Medium, "medium") + ", " +
+		r.sumBySeverity(severity.High, "high") + ", " +
+		r.sumBySeverity(severity.Audit, "audit") + ", " +
+		r.sumBySeverity(severity.NoSec, "no_sec") + ", " +
+		r.sumBySeverity(severity.Info, "info")
+}
+
+func (r *Repository) sumBySeverity(vulnSeverity severity.Severity, alias string) string {
+	return fmt.Sprintf("SUM(CASE WHEN snapshots.severity = '%s' THEN snapshots.total ELSE 0 END) AS %s",
+		vulnSeverity.ToString(), alias)
+}
+
+func (r *Repository) setWhereFilter(query *gorm.DB, alias string, companyID, repositoryID uuid.UUID, initialDate,
+	finalDate time.Time) *gorm.DB {
+	if companyID != uuid.Nil {
+		query = query.Where(alias+".company_id = ?", companyID)
+	} else {
+		query = query.Where(alias+".repository_id = ?", repositoryID)
+	}
+
+	if (initialDate == time.Time{} && finalDate == time.Time{}) {
+		return query
+	}
+
+	return query.Where(alias+".snapshot_date BETWEEN ? AND ?", dashboard.GetSnapshotDate(initialDate), finalDate)
+}
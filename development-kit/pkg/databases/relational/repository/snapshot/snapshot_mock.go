@@ -0,0 +1,87 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Refresh(_ *horusec.Analysis) error {
+	args := m.MethodCalled("Refresh")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) RefreshByVulnerabilities(_ []uuid.UUID) error {
+	args := m.MethodCalled("RefreshByVulnerabilities")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) RefreshByRepository(_ uuid.UUID) error {
+	args := m.MethodCalled("RefreshByRepository")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) Exists(_ *horusec.Analysis) (bool, error) {
+	args := m.MethodCalled("Exists")
+	return args.Get(0).(bool), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetDaily(_ *horusec.Analysis) (*dashboard.DailySnapshot, error) {
+	args := m.MethodCalled("GetDaily")
+	return args.Get(0).(*dashboard.DailySnapshot), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) ListAnalysisToRefresh(_ time.Time, _, _ int) ([]horusec.Analysis, error) {
+	args := m.MethodCalled("ListAnalysisToRefresh")
+	return args.Get(0).([]horusec.Analysis), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetRepositoryCount(_, _ uuid.UUID, _, _ time.Time) (count int, err error) {
+	args := m.MethodCalled("GetRepositoryCount")
+	return args.Get(0).(int), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetVulnBySeverity(_, _ uuid.UUID, _, _ time.Time) (
+	vulnBySeverity []dashboard.VulnBySeverity, err error) {
+	args := m.MethodCalled("GetVulnBySeverity")
+	return args.Get(0).([]dashboard.VulnBySeverity), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetVulnByLanguage(_, _ uuid.UUID, _, _ time.Time) (
+	vulnByLanguage []dashboard.VulnByLanguage, err error) {
+	args := m.MethodCalled("GetVulnByLanguage")
+	return args.Get(0).([]dashboard.VulnByLanguage), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetVulnByRepository(_, _ uuid.UUID, _, _ time.Time) (
+	vulnByRepository []dashboard.VulnByRepository, err error) {
+	args := m.MethodCalled("GetVulnByRepository")
+	return args.Get(0).([]dashboard.VulnByRepository), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetVulnByTime(_, _ uuid.UUID, _, _ time.Time) (vulnByTime []dashboard.VulnByTime, err error) {
+	args := m.MethodCalled("GetVulnByTime")
+	return args.Get(0).([]dashboard.VulnByTime), mockUtils.ReturnNilOrError(args, 1)
+}
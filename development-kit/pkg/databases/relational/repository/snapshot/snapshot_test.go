@@ -0,0 +1,369 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	horusecEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/languages"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/tools"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
+	"github.com/stretchr/testify/assert"
+)
+
+func newConnection(t *testing.T) *gorm.DB {
+	conn, err := gorm.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Table("analysis").AutoMigrate(&horusec.Analysis{}).Error)
+	assert.NoError(t, conn.Table("analysis_vulnerabilities").AutoMigrate(&horusec.AnalysisVulnerabilities{}).Error)
+	assert.NoError(t, conn.Table("vulnerabilities").AutoMigrate(&horusec.Vulnerability{}).Error)
+	assert.NoError(t, conn.Table("analysis_daily_snapshots").AutoMigrate(&dashboard.DailySnapshot{}).Error)
+
+	return conn
+}
+
+func newRepository(conn *gorm.DB) ISnapshotRepository {
+	mockRead := &relational.MockRead{}
+	mockRead.On("GetConnection").Return(conn)
+	mockWrite := &relational.MockWrite{}
+	mockWrite.On("GetConnection").Return(conn)
+	mockWrite.On("StartTransaction").Return(mockWrite)
+	mockWrite.On("CommitTransaction").Return(&response.Response{})
+	mockWrite.On("RollbackTransaction").Return(&response.Response{})
+
+	return NewSnapshotRepository(mockRead, mockWrite)
+}
+
+func createAnalysis(t *testing.T, conn *gorm.DB, companyID, repositoryID uuid.UUID, finishedAt time.Time,
+	severities ...severity.Severity) *horusec.Analysis {
+	analysis := &horusec.Analysis{ID: uuid.New(), CompanyID: companyID, RepositoryID: repositoryID,
+		RepositoryName: repositoryID.String(), FinishedAt: finishedAt}
+	assert.NoError(t, conn.Table("analysis").Create(analysis.GetAnalysisWithoutAnalysisVulnerabilities()).Error)
+
+	for _, vulnSeverity := range severities {
+		vulnerability := &horusec.Vulnerability{VulnerabilityID: uuid.New(), Severity: vulnSeverity,
+			Language: languages.Go, SecurityTool: tools.GoSec, Type: horusecEnums.Vulnerability}
+		assert.NoError(t, conn.Table("vulnerabilities").Create(vulnerability).Error)
+		assert.NoError(t, conn.Table("analysis_vulnerabilities").Create(&horusec.AnalysisVulnerabilities{
+			AnalysisID: analysis.ID, VulnerabilityID: vulnerability.VulnerabilityID}).Error)
+	}
+
+	return analysis
+}
+
+func TestMock(t *testing.T) {
+	t.Run("should mock snapshot repository", func(t *testing.T) {
+		m := &Mock{}
+		m.On("Refresh").Return(nil)
+		m.On("RefreshByVulnerabilities").Return(nil)
+		m.On("RefreshByRepository").Return(nil)
+		m.On("Exists").Return(true, nil)
+		m.On("GetDaily").Return(&dashboard.DailySnapshot{}, nil)
+		m.On("ListAnalysisToRefresh").Return([]horusec.Analysis{}, nil)
+		m.On("GetRepositoryCount").Return(1, nil)
+		m.On("GetVulnBySeverity").Return([]dashboard.VulnBySeverity{}, nil)
+		m.On("GetVulnByLanguage").Return([]dashboard.VulnByLanguage{}, nil)
+		m.On("GetVulnByRepository").Return([]dashboard.VulnByRepository{}, nil)
+		m.On("GetVulnByTime").Return([]dashboard.VulnByTime{}, nil)
+
+		assert.NoError(t, m.Refresh(&horusec.Analysis{}))
+		assert.NoError(t, m.RefreshByVulnerabilities([]uuid.UUID{uuid.New()}))
+		assert.NoError(t, m.RefreshByRepository(uuid.New()))
+		_, err := m.Exists(&horusec.Analysis{})
+		assert.NoError(t, err)
+		_, err = m.GetDaily(&horusec.Analysis{})
+		assert.NoError(t, err)
+		_, err = m.ListAnalysisToRefresh(time.Now(), 1, 10)
+		assert.NoError(t, err)
+		_, err = m.GetRepositoryCount(uuid.New(), uuid.Nil, time.Now(), time.Now())
+		assert.NoError(t, err)
+		_, err = m.GetVulnBySeverity(uuid.New(), uuid.Nil, time.Now(), time.Now())
+		assert.NoError(t, err)
+		_, err = m.GetVulnByLanguage(uuid.New(), uuid.Nil, time.Now(), time.Now())
+		assert.NoError(t, err)
+		_, err = m.GetVulnByRepository(uuid.New(), uuid.Nil, time.Now(), time.Now())
+		assert.NoError(t, err)
+		_, err = m.GetVulnByTime(uuid.New(), uuid.Nil, time.Now(), time.Now())
+		assert.NoError(t, err)
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	day := time.Date(2021, 2, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should replace the snapshot of the day by the latest analysis", func(t *testing.T) {
+		conn := newConnection(t)
+		repository := newRepository(conn)
+		companyID, repositoryID := uuid.New(), uuid.New()
+		first := createAnalysis(t, conn, companyID, repositoryID, day, severity.High, severity.High, severity.Low)
+		second := createAnalysis(t, conn, companyID, repositoryID, day.Add(time.Hour), severity.High)
+
+		assert.NoError(t, repository.Refresh(first))
+		assert.NoError(t, repository.Refresh(second))
+
+		var snapshots []dashboard.DailySnapshot
+		assert.NoError(t, conn.Table("analysis_daily_snapshots").Find(&snapshots).Error)
+		assert.Len(t, snapshots, 1)
+		assert.Equal(t, severity.High.ToString(), snapshots[0].Severity)
+		assert.Equal(t, 1, snapshots[0].Total)
+	})
+
+	t.Run("should ignore an analysis older than the snapshot of the day", func(t *testing.T) {
+		conn := newConnection(t)
+		repository := newRepository(conn)
+		companyID, repositoryID := uuid.New(), uuid.New()
+		first := createAnalysis(t, conn, companyID, repositoryID, day, severity.High, severity.Low)
+		second := createAnalysis(t, conn, companyID, repositoryID, day.Add(time.Hour))
+
+		assert.NoError(t, repository.Refresh(second))
+		assert.NoError(t, repository.Refresh(first))
+
+		var snapshots []dashboard.DailySnapshot
+		assert.NoError(t, conn.Table("analysis_daily_snapshots").Find(&snapshots).Error)
+		assert.Len(t, snapshots, 1)
+		assert.Equal(t, 0, snapshots[0].Total)
+	})
+
+	t.Run("should return error when query fails", func(t *testing.T) {
+		brokenConn, _ := gorm.Open("sqlite3", ":memory:")
+
+		assert.Error(t, newRepository(brokenConn).Refresh(&horusec.Analysis{}))
+	})
+}
+
+func TestRefreshByTypeChange(t *testing.T) {
+	day := time.Date(2021, 2, 19, 10, 0, 0, 0, time.UTC)
+
+	getTypes := func(t *testing.T, conn *gorm.DB) (types []string) {
+		assert.NoError(t, conn.Table("analysis_daily_snapshots").Order("type").Pluck("type", &types).Error)
+		return types
+	}
+
+	setFalsePositive := func(t *testing.T, conn *gorm.DB, analysis *horusec.Analysis) uuid.UUID {
+		relation := &horusec.AnalysisVulnerabilities{}
+		assert.NoError(t, conn.Table("analysis_vulnerabilities").Where("analysis_id = ?", analysis.ID).
+			First(relation).Error)
+		assert.NoError(t, conn.Table("vulnerabilities").Where("vulnerability_id = ?", relation.VulnerabilityID).
+			Update("type", horusecEnums.FalsePositive).Error)
+		return relation.VulnerabilityID
+	}
+
+	t.Run("should refresh the snapshots of the analysis with the vulnerabilities", func(t *testing.T) {
+		conn := newConnection(t)
+		repository := newRepository(conn)
+		analysis := createAnalysis(t, conn, uuid.New(), uuid.New(), day, severity.High)
+		assert.NoError(t, repository.Refresh(analysis))
+
+		vulnerabilityID := setFalsePositive(t, conn, analysis)
+
+		assert.NoError(t, repository.RefreshByVulnerabilities([]uuid.UUID{vulnerabilityID}))
+		assert.Equal(t, []string{horusecEnums.FalsePositive.ToString()}, getTypes(t, conn))
+	})
+
+	t.Run("should refresh a snapshot written with the finish time of the upload", func(t *testing.T) {
+		conn := newConnection(t)
+		repository := newRepository(conn)
+		analysis := createAnalysis(t, conn, uuid.New(), uuid.New(), day, severity.High)
+		uploaded := *analysis
+		uploaded.FinishedAt = day.Add(4 * time.Hour)
+		assert.NoError(t, repository.Refresh(&uploaded))
+
+		vulnerabilityID := setFalsePositive(t, conn, analysis)
+
+		assert.NoError(t, repository.RefreshByVulnerabilities([]uuid.UUID{vulnerabilityID}))
+		assert.Equal(t, []string{horusecEnums.FalsePositive.ToString()}, getTypes(t, conn))
+	})
+
+	t.Run("should refresh the snapshots of the repository", func(t *testing.T) {
+		conn := newConnection(t)
+		repository := newRepository(conn)
+		companyID, repositoryID := uuid.New(), uuid.New()
+		analysis := createAnalysis(t, conn, companyID, repositoryID, day, severity.High)
+		other := createAnalysis(t, conn, companyID, uuid.New(), day, severity.Low)
+		assert.NoError(t, repository.Refresh(analysis))
+		assert.NoError(t, repository.Refresh(other))
+
+		setFalsePositive(t, conn, analysis)
+		setFalsePositive(t, conn, other)
+
+		assert.NoError(t, repository.RefreshByRepository(repositoryID))
+		assert.Equal(t, []string{horusecEnums.FalsePositive.ToString(), horusecEnums.Vulnerability.ToString()},
+			getTypes(t, conn))
+	})
+
+	t.Run("should do nothing without vulnerabilities", func(t *testing.T) {
+		brokenConn, _ := gorm.Open("sqlite3", ":memory:")
+
+		assert.NoError(t, newRepository(brokenConn).RefreshByVulnerabilities(nil))
+	})
+
+	t.Run("should return error when query fails", func(t *testing.T) {
+		brokenConn, _ := gorm.Open("sqlite3", ":memory:")
+
+		assert.Error(t, newRepository(brokenConn).RefreshByRepository(uuid.New()))
+	})
+}
+
+func TestExists(t *testing.T) {
+	day := time.Date(2021, 2, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should return if the day of the analysis has a snapshot", func(t *testing.T) {
+		conn := newConnection(t)
+		repository := newRepository(conn)
+		companyID, repositoryID := uuid.New(), uuid.New()
+		analysis := createAnalysis(t, conn, companyID, repositoryID, day, severity.High)
+		otherDay := createAnalysis(t, conn, companyID, repositoryID, day.AddDate(0, 0, -1))
+		assert.NoError(t, repository.Refresh(analysis))
+
+		exists, err := repository.Exists(analysis)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = repository.Exists(otherDay)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("should return error when query fails", func(t *testing.T) {
+		brokenConn, _ := gorm.Open("sqlite3", ":memory:")
+
+		_, err := newRepository(brokenConn).Exists(&horusec.Analysis{})
+		assert.Error(t, err)
+	})
+}
+
+func TestGetDaily(t *testing.T) {
+	day := time.Date(2021, 2, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should return the snapshot of the day with its analysis", func(t *testing.T) {
+		conn := newConnection(t)
+		repository := newRepository(conn)
+		companyID, repositoryID := uuid.New(), uuid.New()
+		first := createAnalysis(t, conn, companyID, repositoryID, day, severity.High)
+		second := createAnalysis(t, conn, companyID, repositoryID, day.Add(time.Hour), severity.High)
+		otherDay := createAnalysis(t, conn, companyID, repositoryID, day.AddDate(0, 0, -1))
+		assert.NoError(t, repository.Refresh(second))
+
+		snapshot, err := repository.GetDaily(first)
+		assert.NoError(t, err)
+		assert.Equal(t, second.ID, snapshot.AnalysisID)
+
+		snapshot, err = repository.GetDaily(otherDay)
+		assert.NoError(t, err)
+		assert.Nil(t, snapshot)
+	})
+
+	t.Run("should return error when query fails", func(t *testing.T) {
+		brokenConn, _ := gorm.Open("sqlite3", ":memory:")
+
+		_, err := newRepository(brokenConn).GetDaily(&horusec.Analysis{})
+		assert.Error(t, err)
+	})
+}
+
+func TestListAnalysisToRefresh(t *testing.T) {
+	t.Run("should list analysis from the initial date newest first", func(t *testing.T) {
+		conn := newConnection(t)
+		now := time.Now().UTC()
+		createAnalysis(t, conn, uuid.New(), uuid.New(), now.Add(-48*time.Hour))
+		older := createAnalysis(t, conn, uuid.New(), uuid.New(), now.Add(-2*time.Hour))
+		newer := createAnalysis(t, conn, uuid.New(), uuid.New(), now.Add(-time.Hour))
+
+		result, err := newRepository(conn).ListAnalysisToRefresh(now.Add(-24*time.Hour), 1, 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, newer.ID, result[0].ID)
+		assert.Equal(t, older.ID, result[1].ID)
+	})
+}
+
+func TestGetDashboardData(t *testing.T) {
+	conn := newConnection(t)
+	repository := newRepository(conn)
+	companyID, firstRepositoryID, secondRepositoryID := uuid.New(), uuid.New(), uuid.New()
+	day := time.Date(2021, 2, 19, 10, 0, 0, 0, time.UTC)
+	analysis := []*horusec.Analysis{
+		createAnalysis(t, conn, companyID, firstRepositoryID, day.Add(-24*time.Hour), severity.High, severity.Low),
+		createAnalysis(t, conn, companyID, firstRepositoryID, day, severity.High),
+		createAnalysis(t, conn, companyID, secondRepositoryID, day, severity.Medium, severity.Medium),
+		createAnalysis(t, conn, uuid.New(), uuid.New(), day, severity.Low),
+	}
+	for _, item := range analysis {
+		assert.NoError(t, repository.Refresh(item))
+	}
+
+	initialDate, finalDate := day.Add(-48*time.Hour), day.Add(time.Hour)
+
+	t.Run("should count repositories of the company", func(t *testing.T) {
+		count, err := repository.GetRepositoryCount(companyID, uuid.Nil, initialDate, finalDate)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("should return vulnerabilities by severity of the latest snapshot of each repository", func(t *testing.T) {
+		result, err := repository.GetVulnBySeverity(companyID, uuid.Nil, initialDate, finalDate)
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []dashboard.VulnBySeverity{{Severity: "HIGH", Total: 1},
+			{Severity: "MEDIUM", Total: 2}}, result)
+	})
+
+	t.Run("should return the previous snapshot when the period ends before the latest day", func(t *testing.T) {
+		result, err := repository.GetVulnBySeverity(uuid.Nil, firstRepositoryID, initialDate, day.Add(-12*time.Hour))
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []dashboard.VulnBySeverity{{Severity: "HIGH", Total: 1},
+			{Severity: "LOW", Total: 1}}, result)
+	})
+
+	t.Run("should return vulnerabilities by language", func(t *testing.T) {
+		result, err := repository.GetVulnByLanguage(companyID, uuid.Nil, initialDate, finalDate)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []dashboard.VulnByLanguage{{Language: "Go", Total: 3, Medium: 2, High: 1}}, result)
+	})
+
+	t.Run("should return vulnerabilities by repository ordered by total", func(t *testing.T) {
+		result, err := repository.GetVulnByRepository(companyID, uuid.Nil, time.Time{}, time.Time{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []dashboard.VulnByRepository{
+			{Repository: secondRepositoryID.String(), Total: 2, Medium: 2},
+			{Repository: firstRepositoryID.String(), Total: 1, High: 1},
+		}, result)
+	})
+
+	t.Run("should return vulnerabilities by day", func(t *testing.T) {
+		result, err := repository.GetVulnByTime(companyID, uuid.Nil, initialDate, finalDate)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, 2, result[0].Total)
+		assert.Equal(t, 1, result[0].Low)
+		assert.Equal(t, 3, result[1].Total)
+		assert.True(t, result[1].Time.Equal(dashboard.GetSnapshotDate(day)))
+	})
+}
@@ -15,6 +15,8 @@
 package token
 
 import (
+	"time"
+
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
 	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
@@ -28,6 +30,11 @@ type IRepository interface {
 	GetByValue(value string) (*api.Token, error)
 	GetAllOfRepository(repositoryID uuid.UUID) (*[]api.Token, error)
 	GetAllOfCompany(CompanyID uuid.UUID) (*[]api.Token, error)
+	Get(tokenID uuid.UUID) (*api.Token, error)
+	Rotate(token *api.Token) error
+	UpdateLastUsed(tokenID uuid.UUID, ip string) error
+	ListExpiringUntil(date time.Time) (*[]api.Token, error)
+	SetExpirationWarned(tokenIDs []uuid.UUID) error
 }
 
 type Repository struct {
@@ -60,13 +67,70 @@ func (t *Repository) Delete(tokenID uuid.UUID) error {
 	return nil
 }
 
+// GetByValue also finds rotated tokens by the previous value until the end of the grace period
 func (t *Repository) GetByValue(value string) (*api.Token, error) {
 	token := &api.Token{}
 	condition := t.databaseRead.SetFilter(map[string]interface{}{"value": value})
 	r := t.databaseRead.Find(token, condition, token.GetTable())
+	if r.GetError() == EnumErrors.ErrNotFoundRecords {
+		return t.getByPreviousValue(value)
+	}
+
 	return t.parseTokenResponse(r)
 }
 
+func (t *Repository) getByPreviousValue(value string) (*api.Token, error) {
+	token := &api.Token{}
+	condition := t.databaseRead.SetFilter(map[string]interface{}{"previous_value": value})
+	r := t.databaseRead.Find(token, condition, token.GetTable())
+	if r.GetError() != nil {
+		return nil, r.GetError()
+	}
+
+	if token.PreviousValueExpiresAt == nil || token.PreviousValueExpiresAt.Before(time.Now()) {
+		return nil, EnumErrors.ErrNotFoundRecords
+	}
+
+	return token, nil
+}
+
+func (t *Repository) Get(tokenID uuid.UUID) (*api.Token, error) {
+	token := &api.Token{}
+	condition := t.databaseRead.SetFilter(map[string]interface{}{"token_id": tokenID})
+	r := t.databaseRead.Find(token, condition, token.GetTable())
+	return t.parseTokenResponse(r)
+}
+
+func (t *Repository) Rotate(token *api.Token) error {
+	return t.databaseWrite.Update(token.ToRotateMap(),
+		map[string]interface{}{"token_id": token.TokenID}, token.GetTable()).GetError()
+}
+
+func (t *Repository) UpdateLastUsed(tokenID uuid.UUID, ip string) error {
+	return t.databaseWrite.Update(map[string]interface{}{"last_used_at": time.Now(), "last_used_ip": ip},
+		map[string]interface{}{"token_id": tokenID}, (&api.Token{}).GetTable()).GetError()
+}
+
+func (t *Repository) ListExpiringUntil(date time.Time) (*[]api.Token, error) {
+	query := t.databaseRead.GetConnection().Where("never_expires = ? AND is_expiration_warned = ? AND expires_at <= ?",
+		false, false, date)
+	r := t.databaseRead.Find(&[]api.Token{}, query, (&api.Token{}).GetTable())
+	if r.GetError() == EnumErrors.ErrNotFoundRecords {
+		return &[]api.Token{}, nil
+	}
+
+	return t.parseResponseToTokenArray(r)
+}
+
+func (t *Repository) SetExpirationWarned(tokenIDs []uuid.UUID) error {
+	if len(tokenIDs) == 0 {
+		return nil
+	}
+
+	return t.databaseWrite.Update(map[string]interface{}{"is_expiration_warned": true},
+		map[string]interface{}{"token_id": tokenIDs}, (&api.Token{}).GetTable()).GetError()
+}
+
 func (t *Repository) GetAllOfRepository(repositoryID uuid.UUID) (*[]api.Token, error) {
 	table := api.Token{}
 	query := t.databaseRead.SetFilter(map[string]interface{}{"repository_id": repositoryID})
@@ -0,0 +1,78 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Create(_ *api.Token) (*api.Token, error) {
+	args := m.MethodCalled("Create")
+	return args.Get(0).(*api.Token), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) Delete(_ uuid.UUID) error {
+	args := m.MethodCalled("Delete")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) GetByValue(_ string) (*api.Token, error) {
+	args := m.MethodCalled("GetByValue")
+	return args.Get(0).(*api.Token), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetAllOfRepository(_ uuid.UUID) (*[]api.Token, error) {
+	args := m.MethodCalled("GetAllOfRepository")
+	return args.Get(0).(*[]api.Token), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetAllOfCompany(_ uuid.UUID) (*[]api.Token, error) {
+	args := m.MethodCalled("GetAllOfCompany")
+	return args.Get(0).(*[]api.Token), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) Get(_ uuid.UUID) (*api.Token, error) {
+	args := m.MethodCalled("Get")
+	return args.Get(0).(*api.Token), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) Rotate(_ *api.Token) error {
+	args := m.MethodCalled("Rotate")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) UpdateLastUsed(_ uuid.UUID, _ string) error {
+	args := m.MethodCalled("UpdateLastUsed")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) ListExpiringUntil(_ time.Time) (*[]api.Token, error) {
+	args := m.MethodCalled("ListExpiringUntil")
+	return args.Get(0).(*[]api.Token), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) SetExpirationWarned(_ []uuid.UUID) error {
+	args := m.MethodCalled("SetExpirationWarned")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
@@ -21,13 +21,45 @@ import (
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
 
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("Create").Return(&api.Token{}, nil)
+	m.On("Delete").Return(nil)
+	m.On("GetByValue").Return(&api.Token{}, nil)
+	m.On("GetAllOfRepository").Return(&[]api.Token{}, nil)
+	m.On("GetAllOfCompany").Return(&[]api.Token{}, nil)
+	m.On("Get").Return(&api.Token{}, nil)
+	m.On("Rotate").Return(nil)
+	m.On("UpdateLastUsed").Return(nil)
+	m.On("ListExpiringUntil").Return(&[]api.Token{}, nil)
+	m.On("SetExpirationWarned").Return(nil)
+	_, err := m.Create(&api.Token{})
+	assert.NoError(t, err)
+	assert.NoError(t, m.Delete(uuid.New()))
+	_, err = m.GetByValue("")
+	assert.NoError(t, err)
+	_, err = m.GetAllOfRepository(uuid.New())
+	assert.NoError(t, err)
+	_, err = m.GetAllOfCompany(uuid.New())
+	assert.NoError(t, err)
+	_, err = m.Get(uuid.New())
+	assert.NoError(t, err)
+	assert.NoError(t, m.Rotate(&api.Token{}))
+	assert.NoError(t, m.UpdateLastUsed(uuid.New(), ""))
+	_, err = m.ListExpiringUntil(time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, m.SetExpirationWarned(nil))
+}
+
 func TestNewTokenRepository(t *testing.T) {
 	t.Run("should create a new token repository", func(t *testing.T) {
 		mockRead := &relational.MockRead{}
@@ -320,3 +352,107 @@ func TestRepository_GetAllOfCompany(t *testing.T) {
 	assert.NoError(t, err)
 	mockRead.AssertCalled(t, "Find")
 }
+
+func TestGetByPreviousValue(t *testing.T) {
+	t.Run("should return token by previous value during the grace period", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		previousValueExpiresAt := time.Now().Add(time.Hour)
+		tokenID := uuid.New()
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil)).Once()
+		mockRead.On("Find").Return(response.NewResponse(1, nil,
+			&api.Token{TokenID: tokenID, PreviousValueExpiresAt: &previousValueExpiresAt}))
+
+		token, err := NewTokenRepository(mockRead, &relational.MockWrite{}).GetByValue("test")
+
+		assert.NoError(t, err)
+		assert.Equal(t, tokenID, token.TokenID)
+	})
+
+	t.Run("should return not found when grace period is over", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		previousValueExpiresAt := time.Now().Add(-time.Hour)
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil)).Once()
+		mockRead.On("Find").Return(response.NewResponse(1, nil,
+			&api.Token{PreviousValueExpiresAt: &previousValueExpiresAt}))
+
+		_, err := NewTokenRepository(mockRead, &relational.MockWrite{}).GetByValue("test")
+
+		assert.Equal(t, EnumErrors.ErrNotFoundRecords, err)
+	})
+
+	t.Run("should return error when previous value not found", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil))
+
+		_, err := NewTokenRepository(mockRead, &relational.MockWrite{}).GetByValue("test")
+
+		assert.Equal(t, EnumErrors.ErrNotFoundRecords, err)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("should return token by id", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(1, nil, &api.Token{}))
+
+		_, err := NewTokenRepository(mockRead, &relational.MockWrite{}).Get(uuid.New())
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestRotateAndUpdateLastUsed(t *testing.T) {
+	t.Run("should update rotated token and last used", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
+		repository := NewTokenRepository(&relational.MockRead{}, mockWrite)
+
+		assert.NoError(t, repository.Rotate(&api.Token{}))
+		assert.NoError(t, repository.UpdateLastUsed(uuid.New(), "127.0.0.1"))
+		mockWrite.AssertNumberOfCalls(t, "Update", 2)
+	})
+}
+
+func TestListExpiringUntil(t *testing.T) {
+	t.Run("should return tokens expiring until the date", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("GetConnection").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(1, nil, &[]api.Token{{}}))
+
+		tokens, err := NewTokenRepository(mockRead, &relational.MockWrite{}).ListExpiringUntil(time.Now())
+
+		assert.NoError(t, err)
+		assert.Len(t, *tokens, 1)
+	})
+
+	t.Run("should return empty list when not found records", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("GetConnection").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil))
+
+		tokens, err := NewTokenRepository(mockRead, &relational.MockWrite{}).ListExpiringUntil(time.Now())
+
+		assert.NoError(t, err)
+		assert.Empty(t, *tokens)
+	})
+}
+
+func TestSetExpirationWarned(t *testing.T) {
+	t.Run("should set expiration warned of the tokens", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
+		repository := NewTokenRepository(&relational.MockRead{}, mockWrite)
+
+		assert.NoError(t, repository.SetExpirationWarned([]uuid.UUID{uuid.New()}))
+		assert.NoError(t, repository.SetExpirationWarned(nil))
+		mockWrite.AssertNumberOfCalls(t, "Update", 1)
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnerability
+
+import (
+	"strings"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
+	managementEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/management"
+	"github.com/jinzhu/gorm"
+)
+
+// condition is a single sql clause that is only applied to the query when enabled, which allows
+// any combination of filters without having to handle each one of them separately
+type condition struct {
+	enabled bool
+	query   string
+	args    []interface{}
+}
+
+var likeReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func newCondition(enabled bool, query string, args ...interface{}) condition {
+	return condition{enabled: enabled, query: query, args: args}
+}
+
+func setWhereFilter(query *gorm.DB, filter *dto.VulnFilter) *gorm.DB {
+	for _, item := range getWhereConditions(filter) {
+		if item.enabled {
+			query = query.Where(item.query, item.args...)
+		}
+	}
+
+	return query
+}
+
+func getWhereConditions(filter *dto.VulnFilter) []condition {
+	return []condition{
+		newCondition(filter.IsCompanyScope(), "analysis.company_id = ?", filter.CompanyID),
+		newCondition(!filter.IsCompanyScope(), "analysis.repository_id = ?", filter.RepositoryID),
+		newCondition(filter.Severity != "", "vulnerabilities.severity = ?", filter.Severity),
+		newCondition(filter.Type != "", "vulnerabilities.type = ?", filter.Type),
+		newCondition(filter.VulnHash != "", "vulnerabilities.vuln_hash ~ ?", filter.VulnHash),
+		newCondition(filter.SecurityTool != "", "vulnerabilities.security_tool = ?", filter.SecurityTool),
+		newCondition(filter.Language != "", "vulnerabilities.language = ?", filter.Language),
+		newCondition(filter.FilePrefix != "", `vulnerabilities.file LIKE ? ESCAPE '\'`,
+			likeReplacer.Replace(filter.FilePrefix)+"%"),
+		newCondition(filter.CommitAuthor != "",
+			"(vulnerabilities.commit_author = ? OR vulnerabilities.commit_email = ?)",
+			filter.CommitAuthor, filter.CommitAuthor),
+		newCondition(filter.Details != "", `LOWER(vulnerabilities.details) LIKE ? ESCAPE '\'`,
+			"%"+likeReplacer.Replace(strings.ToLower(filter.Details))+"%"),
+	}
+}
+
+func setFirstSeenFilter(query *gorm.DB, filter *dto.VulnManagementFilter) *gorm.DB {
+	if !filter.FirstSeenInitialDate.IsZero() {
+		query = query.Having("MIN(analysis.created_at) >= ?", filter.FirstSeenInitialDate)
+	}
+
+	if !filter.FirstSeenFinalDate.IsZero() {
+		query = query.Having("MIN(analysis.created_at) <= ?", filter.FirstSeenFinalDate)
+	}
+
+	return query
+}
+
+func getOrderBy(sortBy managementEnums.SortBy) string {
+	switch sortBy {
+	case managementEnums.File:
+		return "tmpTable.file ASC, tmpTable.line ASC, tmpTable.vulnerability_id"
+	case managementEnums.Age:
+		return "tmpTable.first_seen ASC, tmpTable.vulnerability_id"
+	default:
+		return "CASE tmpTable.severity WHEN 'HIGH' THEN 1 WHEN 'MEDIUM' THEN 2 WHEN 'LOW' THEN 3" +
+			" WHEN 'AUDIT' THEN 4 WHEN 'INFO' THEN 5 END, tmpTable.type DESC, tmpTable.vulnerability_id"
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnerability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
+	managementEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/management"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListVulnManagementData(t *testing.T) {
+	companyID := uuid.New()
+	repositoryID := uuid.New()
+
+	list := func(t *testing.T, filter *dto.VulnManagementFilter) dto.VulnManagement {
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(newTestConnection(t, companyID, repositoryID))
+		repo := NewManagementRepository(mockRead, &relational.MockWrite{})
+
+		filter.Page, filter.Size = 1, 10
+		result, err := repo.ListVulnManagementData(filter)
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("should list distinct vulnerabilities of repository sorted by severity", func(t *testing.T) {
+		result := list(t, &dto.VulnManagementFilter{VulnFilter: dto.VulnFilter{RepositoryID: repositoryID}})
+
+		assert.Equal(t, 2, result.TotalItems)
+		assert.Len(t, result.Data, 2)
+		assert.Equal(t, severity.High, result.Data[0].Severity)
+	})
+
+	t.Run("should list vulnerabilities sorted by file", func(t *testing.T) {
+		result := list(t, &dto.VulnManagementFilter{VulnFilter: dto.VulnFilter{RepositoryID: repositoryID},
+			SortBy: managementEnums.File})
+
+		assert.Len(t, result.Data, 2)
+		assert.Equal(t, "config/app_%.yaml", result.Data[0].File)
+	})
+
+	t.Run("should combine tool and language filters", func(t *testing.T) {
+		result := list(t, &dto.VulnManagementFilter{VulnFilter: dto.VulnFilter{RepositoryID: repositoryID,
+			SecurityTool: "GoSec", Language: "Go"}})
+		assert.Equal(t, 1, result.TotalItems)
+
+		result = list(t, &dto.VulnManagementFilter{VulnFilter: dto.VulnFilter{RepositoryID: repositoryID,
+			SecurityTool: "GoSec", Language: "Leaks"}})
+		assert.Equal(t, 0, result.TotalItems)
+	})
+
+	t.Run("should filter by file prefix escaping wildcards", func(t *testing.T) {
+		result := list(t, &dto.VulnManagementFilter{VulnFilter: dto.VulnFilter{CompanyID: companyID,
+			FilePrefix: "internal/"}})
+		assert.Equal(t, 1, result.TotalItems)
+		assert.Equal(t, "internal/api/main.go", result.Data[0].File)
+
+		result = list(t, &dto.VulnManagementFilter{VulnFilter: dto.VulnFilter{CompanyID: companyID,
+			FilePrefix: "config/app_%"}})
+		assert.Equal(t, 1, result.TotalItems)
+
+		result = list(t, &dto.VulnManagementFilter{VulnFilter: dto.VulnFilter{CompanyID: companyID,
+			FilePrefix: "config/app__"}})
+		assert.Equal(t, 0, result.TotalItems)
+	})
+
+	t.Run("should filter by commit author name or email", func(t *testing.T) {
+		result := list(t, &dto.VulnManagementFilter{VulnFilter: dto.VulnFilter{RepositoryID: repositoryID,
+			CommitAuthor: "horus"}})
+		assert.Equal(t, 1, result.TotalItems)
+		assert.Equal(t, "horus", result.Data[0].CommitAuthor)
+
+		result = list(t, &dto.VulnManagementFilter{VulnFilter: dto.VulnFilter{RepositoryID: repositoryID,
+			CommitAuthor: "zup@example.com"}})
+		assert.Equal(t, 1, result.TotalItems)
+	})
+
+	t.Run("should filter by free text on details ignoring case", func(t *testing.T) {
+		result := list(t, &dto.VulnManagementFilter{VulnFilter: dto.VulnFilter{RepositoryID: repositoryID,
+			Details: "sql injection"}})
+
+		assert.Equal(t, 1, result.TotalItems)
+		assert.Equal(t, severity.High, result.Data[0].Severity)
+	})
+
+	t.Run("should filter by date first seen", func(t *testing.T) {
+		result := list(t, &dto.VulnManagementFilter{VulnFilter: dto.VulnFilter{RepositoryID: repositoryID},
+			FirstSeenInitialDate: time.Now().Add(-time.Hour), FirstSeenFinalDate: time.Now().Add(time.Hour)})
+		assert.Equal(t, 2, result.TotalItems)
+
+		result = list(t, &dto.VulnManagementFilter{VulnFilter: dto.VulnFilter{RepositoryID: repositoryID},
+			FirstSeenInitialDate: time.Now().Add(time.Hour)})
+		assert.Equal(t, 0, result.TotalItems)
+	})
+}
+
+func TestGetWhereConditions(t *testing.T) {
+	t.Run("should enable only scope condition when filter is empty", func(t *testing.T) {
+		enabled := 0
+		for _, item := range getWhereConditions(&dto.VulnFilter{RepositoryID: uuid.New()}) {
+			if item.enabled {
+				enabled++
+				assert.Equal(t, "analysis.repository_id = ?", item.query)
+			}
+		}
+
+		assert.Equal(t, 1, enabled)
+	})
+
+	t.Run("should enable all conditions except one scope when all filters are set", func(t *testing.T) {
+		filter := &dto.VulnFilter{CompanyID: uuid.New(), Severity: severity.High, Type: "Vulnerability",
+			VulnHash: "hash", SecurityTool: "GoSec", Language: "Go", FilePrefix: "internal/",
+			CommitAuthor: "horus", Details: "sql"}
+
+		conditions := getWhereConditions(filter)
+		enabled := 0
+		for _, item := range conditions {
+			if item.enabled {
+				enabled++
+			}
+		}
+
+		assert.Equal(t, len(conditions)-1, enabled)
+	})
+}
+
+func TestGetOrderBy(t *testing.T) {
+	t.Run("should return order by of each sort", func(t *testing.T) {
+		assert.Contains(t, getOrderBy(managementEnums.Severity), "CASE tmpTable.severity")
+		assert.Contains(t, getOrderBy(managementEnums.File), "tmpTable.file ASC")
+		assert.Contains(t, getOrderBy(managementEnums.Age), "tmpTable.first_seen ASC")
+	})
+}
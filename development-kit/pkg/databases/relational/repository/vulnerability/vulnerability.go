@@ -15,22 +15,31 @@
 package vulnerability
 
 import (
+	"time"
+
 	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	horusecEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
-	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/pagination"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 )
 
 type IRepository interface {
-	ListVulnManagementData(repositoryID uuid.UUID, page, size int, vulnSeverity severity.Severity,
-		vulnType horusecEnums.VulnerabilityType, vulnHash string) (vulnManagement dto.VulnManagement, err error)
-	UpdateVulnType(vulnerabilityID uuid.UUID,
+	ListVulnManagementData(filter *dto.VulnManagementFilter) (vulnManagement dto.VulnManagement, err error)
+	UpdateVulnType(repositoryID, vulnerabilityID uuid.UUID,
 		updateTypeData *dto.UpdateVulnType) (*horusec.Vulnerability, error)
 	GetVulnByID(vulnerabilityID uuid.UUID) (*horusec.Vulnerability, error)
+	ExportVulnerabilities(filter *dto.VulnExportFilter, handle func(vulnExport *dto.VulnExport) error) error
+	BulkUpdateVulnType(repositoryID uuid.UUID, bulkData *dto.BulkUpdateVulnType) (int, error)
+	ListVulnTypeHistory(repositoryID, vulnerabilityID uuid.UUID) (*[]horusec.VulnerabilityTypeHistory, error)
+	ListRiskAcceptExpired(repositoryID uuid.UUID) ([]dto.RiskAcceptExpiration, error)
+	RevertExpiredRiskAccept(expirations []dto.RiskAcceptExpiration) error
+	ListRiskAcceptExpiringUntil(date time.Time) ([]dto.RiskAcceptExpiration, error)
+	SetRiskExpirationWarned(vulnerabilityIDs []uuid.UUID) error
+	ListRiskAcceptedDates(vulnerabilityIDs []uuid.UUID) ([]time.Time, error)
 }
 
 type Repository struct {
@@ -45,80 +54,139 @@ func NewManagementRepository(databaseRead SQL.InterfaceRead, databaseWrite SQL.I
 	}
 }
 
-func (r *Repository) ListVulnManagementData(repositoryID uuid.UUID, page, size int, vulnSeverity severity.Severity,
-	vulnType horusecEnums.VulnerabilityType, vulnHash string) (vulnManagement dto.VulnManagement, err error) {
-	query := r.databaseRead.GetConnection().Raw("SELECT * FROM ? AS tmpTable"+
-		" ORDER BY CASE tmpTable.severity"+
-		" WHEN 'HIGH' THEN 1 WHEN 'MEDIUM' THEN 2 WHEN 'LOW' THEN 3 WHEN 'AUDIT' THEN 4"+
-		" WHEN 'INFO' THEN 5 END, tmpTable.type DESC LIMIT ? OFFSET ?",
-		r.listVulnManagementDataSubQuery(repositoryID, vulnSeverity, vulnType, vulnHash),
-		size, pagination.GetSkip(int64(page), int64(size)))
+func (r *Repository) ListVulnManagementData(
+	filter *dto.VulnManagementFilter) (vulnManagement dto.VulnManagement, err error) {
+	query := r.databaseRead.GetConnection().Raw("SELECT * FROM ? AS tmpTable ORDER BY "+
+		getOrderBy(filter.GetSortBy())+" LIMIT ? OFFSET ?", r.listVulnManagementDataSubQuery(filter),
+		filter.Size, pagination.GetSkip(int64(filter.Page), int64(filter.Size)))
 
-	vulnManagement.TotalItems = r.getTotalVulnManagementData(repositoryID, vulnSeverity, vulnType, vulnHash)
+	vulnManagement.TotalItems = r.getTotalVulnManagementData(filter)
 	return vulnManagement, query.Find(&vulnManagement.Data).Error
 }
 
-func (r *Repository) getTotalVulnManagementData(repositoryID uuid.UUID,
-	vulnSeverity severity.Severity, vulnType horusecEnums.VulnerabilityType, vulnHash string) (count int) {
-	query := r.databaseRead.
-		GetConnection().
-		Select("COUNT( DISTINCT ( vulnerabilities.vulnerability_id ) )").
-		Table("analysis").
-		Joins("JOIN analysis_vulnerabilities ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
-		Joins("JOIN vulnerabilities ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id")
-
-	_ = r.setWhereFilter(query, repositoryID, vulnSeverity, vulnType, vulnHash).Count(&count)
+func (r *Repository) getTotalVulnManagementData(filter *dto.VulnManagementFilter) (count int) {
+	_ = r.databaseRead.GetConnection().
+		Raw("SELECT COUNT(*) FROM ? AS tmpTable", r.listVulnManagementDataSubQuery(filter)).
+		Row().Scan(&count)
 	return count
 }
 
-// nolint
-func (r *Repository) setWhereFilter(query *gorm.DB, repositoryID uuid.UUID, vulnSeverity severity.Severity,
-	vulnType horusecEnums.VulnerabilityType, vulnHash string) *gorm.DB {
-	if vulnHash != "" && vulnSeverity != "" && vulnType != "" {
-		return query.Where("repository_id = ? AND vulnerabilities.severity = ? AND vulnerabilities.type = ?"+
-			" AND vulnerabilities.vuln_hash ~ ?", repositoryID, vulnSeverity, vulnType, vulnHash)
+// UpdateVulnType returns not found when the vulnerability is not of the repository, so the type of vulnerabilities
+// of other tenants can not be changed
+func (r *Repository) UpdateVulnType(repositoryID, vulnerabilityID uuid.UUID,
+	updateTypeData *dto.UpdateVulnType) (*horusec.Vulnerability, error) {
+	if !r.isVulnOfRepository(repositoryID, vulnerabilityID) {
+		return nil, errorsEnum.ErrNotFoundRecords
+	}
+
+	toUpdate, err := r.GetVulnByID(vulnerabilityID)
+	if err != nil {
+		return nil, err
 	}
 
-	if vulnHash != "" && vulnSeverity != "" {
-		return query.Where("repository_id = ? AND vulnerabilities.severity = ? AND vulnerabilities.vuln_hash ~ ?",
-			repositoryID, vulnSeverity, vulnHash)
+	newType := updateTypeData.GetType()
+	if newType == toUpdate.Type && newType != horusecEnums.RiskAccepted {
+		return toUpdate, nil
 	}
 
-	if vulnHash != "" && vulnType != "" {
-		return query.Where("repository_id = ? AND vulnerabilities.type = ? AND vulnerabilities.vuln_hash ~ ?",
-			repositoryID, vulnType, vulnHash)
+	history := toUpdate.NewTypeHistory(newType, updateTypeData.AccountID, updateTypeData.Comment)
+	toUpdate.SetType(newType)
+	toUpdate.SetRiskAcceptedUntil(updateTypeData.RiskAcceptedUntil)
+	return toUpdate, r.updateTypeWithHistory(toUpdate, []uuid.UUID{vulnerabilityID}, history)
+}
+
+func (r *Repository) BulkUpdateVulnType(repositoryID uuid.UUID, bulkData *dto.BulkUpdateVulnType) (int, error) {
+	vulnerabilities, err := r.getVulnsToBulkUpdate(repositoryID, bulkData)
+	if err != nil || len(vulnerabilities) == 0 {
+		return 0, err
 	}
 
-	if vulnSeverity != "" && vulnType != "" {
-		return query.Where("repository_id = ? AND vulnerabilities.type = ? AND vulnerabilities.severity = ?",
-			repositoryID, vulnType, vulnSeverity)
+	var vulnerabilityIDs []uuid.UUID
+	var histories []*horusec.VulnerabilityTypeHistory
+	for index := range vulnerabilities {
+		vulnerabilityIDs = append(vulnerabilityIDs, vulnerabilities[index].VulnerabilityID)
+		histories = append(histories,
+			vulnerabilities[index].NewTypeHistory(bulkData.Type, bulkData.AccountID, bulkData.Comment))
 	}
 
-	if vulnHash != "" {
-		return query.Where("repository_id = ? AND vulnerabilities.vuln_hash ~ ?", repositoryID, vulnHash)
+	toUpdate := &horusec.Vulnerability{Type: bulkData.Type}
+	toUpdate.SetRiskAcceptedUntil(bulkData.RiskAcceptedUntil)
+	return len(vulnerabilityIDs), r.updateTypeWithHistory(toUpdate, vulnerabilityIDs, histories...)
+}
+
+func (r *Repository) getVulnsToBulkUpdate(repositoryID uuid.UUID,
+	bulkData *dto.BulkUpdateVulnType) (vulnerabilities []horusec.Vulnerability, err error) {
+	if len(bulkData.VulnerabilityIDs) == 0 && bulkData.IsFilterEmpty() {
+		return nil, nil
 	}
 
-	if vulnSeverity != "" {
-		return query.Where("repository_id = ? AND vulnerabilities.severity = ?", repositoryID, vulnSeverity)
+	query := r.databaseRead.GetConnection().
+		Select("DISTINCT vulnerabilities.vulnerability_id, vulnerabilities.type").
+		Table("analysis").
+		Joins("JOIN analysis_vulnerabilities ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
+		Joins("JOIN vulnerabilities ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id")
+
+	query = setWhereFilter(query, bulkData.GetFilter(repositoryID))
+	if bulkData.Type != horusecEnums.RiskAccepted {
+		query = query.Where("vulnerabilities.type <> ?", bulkData.Type)
 	}
 
-	if vulnType != "" {
-		return query.Where("repository_id = ? AND vulnerabilities.type = ?", repositoryID, vulnType)
+	if len(bulkData.VulnerabilityIDs) > 0 {
+		query = query.Where("vulnerabilities.vulnerability_id IN (?)", bulkData.VulnerabilityIDs)
 	}
 
-	return query.Where("repository_id = ?", repositoryID)
+	return vulnerabilities, query.Find(&vulnerabilities).Error
 }
 
-func (r *Repository) UpdateVulnType(vulnerabilityID uuid.UUID,
-	updateTypeData *dto.UpdateVulnType) (*horusec.Vulnerability, error) {
-	toUpdate, err := r.GetVulnByID(vulnerabilityID)
-	if err != nil {
-		return nil, err
+func (r *Repository) updateTypeWithHistory(toUpdate *horusec.Vulnerability, vulnerabilityIDs []uuid.UUID,
+	histories ...*horusec.VulnerabilityTypeHistory) error {
+	tx := r.databaseWrite.StartTransaction()
+	if err := tx.Update(map[string]interface{}{
+		"type":                   toUpdate.Type,
+		"risk_accepted_until":    toUpdate.RiskAcceptedUntil,
+		"risk_expiration_warned": toUpdate.RiskExpirationWarned,
+	}, map[string]interface{}{"vulnerability_id": vulnerabilityIDs}, toUpdate.GetTable()).GetError(); err != nil {
+		_ = tx.RollbackTransaction()
+		return err
 	}
 
-	toUpdate.SetType(updateTypeData.Type)
-	return toUpdate, r.databaseWrite.Update(toUpdate,
-		map[string]interface{}{"vulnerability_id": vulnerabilityID}, toUpdate.GetTable()).GetError()
+	for _, history := range histories {
+		if err := tx.Create(history, history.GetTable()).GetError(); err != nil {
+			_ = tx.RollbackTransaction()
+			return err
+		}
+	}
+
+	return tx.CommitTransaction().GetError()
+}
+
+// ListVulnTypeHistory returns not found when the vulnerability is not of the repository, so the history of other
+// tenants is not revealed
+func (r *Repository) ListVulnTypeHistory(repositoryID,
+	vulnerabilityID uuid.UUID) (*[]horusec.VulnerabilityTypeHistory, error) {
+	if !r.isVulnOfRepository(repositoryID, vulnerabilityID) {
+		return nil, errorsEnum.ErrNotFoundRecords
+	}
+
+	histories := &[]horusec.VulnerabilityTypeHistory{}
+	query := r.databaseRead.
+		SetFilter(map[string]interface{}{"vulnerability_id": vulnerabilityID}).
+		Order("created_at DESC")
+
+	return histories, r.databaseRead.Find(histories, query,
+		(&horusec.VulnerabilityTypeHistory{}).GetTable()).GetError()
+}
+
+func (r *Repository) isVulnOfRepository(repositoryID, vulnerabilityID uuid.UUID) bool {
+	count := 0
+	_ = r.databaseRead.GetConnection().
+		Table("analysis_vulnerabilities").
+		Joins("JOIN analysis ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
+		Where("analysis_vulnerabilities.vulnerability_id = ? AND analysis.repository_id = ?",
+			vulnerabilityID, repositoryID).
+		Count(&count)
+
+	return count > 0
 }
 
 func (r *Repository) GetVulnByID(vulnerabilityID uuid.UUID) (*horusec.Vulnerability, error) {
@@ -130,16 +198,150 @@ func (r *Repository) GetVulnByID(vulnerabilityID uuid.UUID) (*horusec.Vulnerabil
 	return vulnerability, response.GetError()
 }
 
-func (r *Repository) listVulnManagementDataSubQuery(repositoryID uuid.UUID,
-	vulnSeverity severity.Severity, vulnType horusecEnums.VulnerabilityType, vulnHash string) *gorm.SqlExpr {
+func (r *Repository) listVulnManagementDataSubQuery(filter *dto.VulnManagementFilter) *gorm.SqlExpr {
 	query := r.databaseRead.GetConnection().
-		Select("DISTINCT ON (vulnerabilities.vulnerability_id) vulnerabilities.vulnerability_id," +
-			" vulnerabilities.type, vulnerabilities.vuln_hash, vulnerabilities.line, vulnerabilities.column," +
-			" vulnerabilities.confidence, vulnerabilities.file, vulnerabilities.code, vulnerabilities.details," +
-			" vulnerabilities.security_tool, vulnerabilities.language, vulnerabilities.severity").
+		Select("vulnerabilities.vulnerability_id, vulnerabilities.type, vulnerabilities.vuln_hash," +
+			" vulnerabilities.line, vulnerabilities.column, vulnerabilities.confidence, vulnerabilities.file," +
+			" vulnerabilities.code, vulnerabilities.details, vulnerabilities.security_tool," +
+			" vulnerabilities.language, vulnerabilities.severity, vulnerabilities.commit_author," +
+			" vulnerabilities.risk_accepted_until, MIN(analysis.created_at) AS first_seen").
+		Table("analysis").
+		Joins("JOIN analysis_vulnerabilities ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
+		Joins("JOIN vulnerabilities ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id")
+
+	query = setWhereFilter(query, &filter.VulnFilter).Group("vulnerabilities.vulnerability_id")
+	return setFirstSeenFilter(query, filter).SubQuery()
+}
+
+func (r *Repository) ExportVulnerabilities(filter *dto.VulnExportFilter,
+	handle func(vulnExport *dto.VulnExport) error) error {
+	rows, err := r.setExportFilter(r.exportVulnerabilitiesQuery(), filter).Rows()
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		vulnExport := &dto.VulnExport{}
+		if err := r.databaseRead.GetConnection().ScanRows(rows, vulnExport); err != nil {
+			return err
+		}
+
+		if err := handle(vulnExport); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// exportVulnerabilitiesQuery takes the current name of the repository, the name saved on each analysis would export
+// the vulnerability once for each name of a renamed repository
+func (r *Repository) exportVulnerabilitiesQuery() *gorm.DB {
+	return r.databaseRead.GetConnection().
+		Select("DISTINCT analysis.repository_id, repositories.name AS repository_name, vulnerabilities.*").
 		Table("analysis").
+		Joins("JOIN repositories ON repositories.repository_id = analysis.repository_id").
 		Joins("JOIN analysis_vulnerabilities ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
 		Joins("JOIN vulnerabilities ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id")
+}
+
+func (r *Repository) setExportFilter(query *gorm.DB, filter *dto.VulnExportFilter) *gorm.DB {
+	query = setWhereFilter(query, &filter.VulnFilter)
+	if !filter.InitialDate.IsZero() {
+		query = query.Where("analysis.created_at >= ?", filter.InitialDate)
+	}
+
+	if !filter.FinalDate.IsZero() {
+		query = query.Where("analysis.created_at <= ?", filter.FinalDate)
+	}
+
+	return query
+}
+
+// ListRiskAcceptExpired returns the expired risk acceptances of the repository, or of all repositories when the
+// repository id is nil
+func (r *Repository) ListRiskAcceptExpired(repositoryID uuid.UUID) ([]dto.RiskAcceptExpiration, error) {
+	query := r.riskAcceptExpirationQuery(time.Now())
+	if repositoryID != uuid.Nil {
+		query = query.Where("analysis.repository_id = ?", repositoryID)
+	}
+
+	return r.findRiskAcceptExpirations(query)
+}
+
+func (r *Repository) RevertExpiredRiskAccept(expirations []dto.RiskAcceptExpiration) error {
+	if len(expirations) == 0 {
+		return nil
+	}
+
+	var histories []*horusec.VulnerabilityTypeHistory
+	for index := range expirations {
+		expired := &horusec.Vulnerability{VulnerabilityID: expirations[index].VulnerabilityID,
+			Type: horusecEnums.RiskAccepted}
+		histories = append(histories, expired.NewTypeHistory(horusecEnums.Vulnerability, uuid.Nil,
+			"Risk acceptance expired"))
+	}
+
+	return r.updateTypeWithHistory(&horusec.Vulnerability{Type: horusecEnums.Vulnerability},
+		dto.GetRiskAcceptExpirationIDs(expirations), histories...)
+}
+
+func (r *Repository) ListRiskAcceptExpiringUntil(date time.Time) ([]dto.RiskAcceptExpiration, error) {
+	return r.findRiskAcceptExpirations(r.riskAcceptExpirationQuery(date).
+		Where("vulnerabilities.risk_expiration_warned = ?", false))
+}
+
+func (r *Repository) SetRiskExpirationWarned(vulnerabilityIDs []uuid.UUID) error {
+	if len(vulnerabilityIDs) == 0 {
+		return nil
+	}
+
+	return r.databaseWrite.Update(map[string]interface{}{"risk_expiration_warned": true},
+		map[string]interface{}{"vulnerability_id": vulnerabilityIDs}, (&horusec.Vulnerability{}).GetTable()).GetError()
+}
+
+// ListRiskAcceptedDates returns when the risk of each vulnerability was accepted for the last time, a risk accepted
+// before the type history existed has no date
+func (r *Repository) ListRiskAcceptedDates(vulnerabilityIDs []uuid.UUID) (dates []time.Time, err error) {
+	if len(vulnerabilityIDs) == 0 {
+		return dates, nil
+	}
+
+	histories := []horusec.VulnerabilityTypeHistory{}
+	err = r.databaseRead.GetConnection().
+		Table((&horusec.VulnerabilityTypeHistory{}).GetTable()).
+		Where("vulnerability_id IN (?) AND new_type = ?", vulnerabilityIDs, horusecEnums.RiskAccepted).
+		Find(&histories).Error
+
+	lastByVulnerability := map[uuid.UUID]time.Time{}
+	for index := range histories {
+		if histories[index].CreatedAt.After(lastByVulnerability[histories[index].VulnerabilityID]) {
+			lastByVulnerability[histories[index].VulnerabilityID] = histories[index].CreatedAt
+		}
+	}
+
+	for _, date := range lastByVulnerability {
+		dates = append(dates, date)
+	}
+
+	return dates, err
+}
+
+func (r *Repository) riskAcceptExpirationQuery(date time.Time) *gorm.DB {
+	return r.databaseRead.GetConnection().
+		Select("DISTINCT vulnerabilities.vulnerability_id, analysis.company_id, analysis.repository_id,"+
+			" repositories.name AS repository_name, vulnerabilities.vuln_hash, vulnerabilities.file,"+
+			" vulnerabilities.line, vulnerabilities.severity, vulnerabilities.risk_accepted_until").
+		Table("analysis").
+		Joins("JOIN repositories ON repositories.repository_id = analysis.repository_id").
+		Joins("JOIN analysis_vulnerabilities ON analysis.analysis_id = analysis_vulnerabilities.analysis_id").
+		Joins("JOIN vulnerabilities ON vulnerabilities.vulnerability_id = analysis_vulnerabilities.vulnerability_id").
+		Where("vulnerabilities.type = ?", horusecEnums.RiskAccepted).
+		Where("vulnerabilities.risk_accepted_until IS NOT NULL").
+		Where("vulnerabilities.risk_accepted_until <= ?", date)
+}
 
-	return r.setWhereFilter(query, repositoryID, vulnSeverity, vulnType, vulnHash).SubQuery()
+func (r *Repository) findRiskAcceptExpirations(query *gorm.DB) (expirations []dto.RiskAcceptExpiration, err error) {
+	return expirations, query.Find(&expirations).Error
 }
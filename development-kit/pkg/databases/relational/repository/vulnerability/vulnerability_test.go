@@ -17,16 +17,19 @@ package vulnerability
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
 	entitiesHorusec "github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
 	horusecEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
-	"github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	horusecEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,12 +38,33 @@ func TestMock(t *testing.T) {
 	m.On("ListVulnManagementData").Return(dto.VulnManagement{}, nil)
 	m.On("UpdateVulnType").Return(&entitiesHorusec.Vulnerability{}, nil)
 	m.On("GetVulnByID").Return(&entitiesHorusec.Vulnerability{}, nil)
-	_, err := m.ListVulnManagementData(uuid.New(), 1, 10, severity.High, horusec.Vulnerability, "123")
+	m.On("ExportVulnerabilities").Return(nil)
+	m.On("BulkUpdateVulnType").Return(1, nil)
+	m.On("ListVulnTypeHistory").Return(&[]entitiesHorusec.VulnerabilityTypeHistory{}, nil)
+	m.On("ListRiskAcceptExpired").Return([]dto.RiskAcceptExpiration{}, nil)
+	m.On("RevertExpiredRiskAccept").Return(nil)
+	m.On("ListRiskAcceptExpiringUntil").Return([]dto.RiskAcceptExpiration{}, nil)
+	m.On("SetRiskExpirationWarned").Return(nil)
+	m.On("ListRiskAcceptedDates").Return([]time.Time{}, nil)
+	_, err := m.ListVulnManagementData(&dto.VulnManagementFilter{})
 	assert.NoError(t, err)
-	_, err = m.UpdateVulnType(uuid.New(), &dto.UpdateVulnType{})
+	_, err = m.UpdateVulnType(uuid.New(), uuid.New(), &dto.UpdateVulnType{})
 	assert.NoError(t, err)
 	_, err = m.GetVulnByID(uuid.New())
 	assert.NoError(t, err)
+	assert.NoError(t, m.ExportVulnerabilities(&dto.VulnExportFilter{}, nil))
+	_, err = m.BulkUpdateVulnType(uuid.New(), &dto.BulkUpdateVulnType{})
+	assert.NoError(t, err)
+	_, err = m.ListVulnTypeHistory(uuid.New(), uuid.New())
+	assert.NoError(t, err)
+	_, err = m.ListRiskAcceptExpired(uuid.New())
+	assert.NoError(t, err)
+	assert.NoError(t, m.RevertExpiredRiskAccept([]dto.RiskAcceptExpiration{}))
+	_, err = m.ListRiskAcceptExpiringUntil(time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, m.SetRiskExpirationWarned([]uuid.UUID{}))
+	_, err = m.ListRiskAcceptedDates([]uuid.UUID{})
+	assert.NoError(t, err)
 }
 
 // func TestGetAllVulnManagementData(t *testing.T) {
@@ -222,40 +246,331 @@ func TestMock(t *testing.T) {
 // 	})
 // }
 
-// func TestUpdate(t *testing.T) {
-// 	t.Run("should success update data with no errors", func(t *testing.T) {
-// 		mockWrite := &relational.MockWrite{}
-// 		mockRead := &relational.MockRead{}
+func TestUpdate(t *testing.T) {
+	repositoryID := uuid.New()
+	vulnerabilityID := uuid.New()
 
-// 		resp := &response.Response{}
-// 		mockWrite.On("Update").Return(resp)
-// 		mockRead.On("SetFilter").Return(&gorm.DB{})
-// 		mockRead.On("Find").Return(resp.SetData(horusecEntities.Vulnerability{}))
+	t.Run("should success update data and create history with no errors", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockRead := &relational.MockRead{}
+		mockTx := &relational.MockWrite{}
 
-// 		repo := NewManagementRepository(mockRead, mockWrite)
+		resp := &response.Response{}
+		mockRead.On("GetConnection").Return(newVulnOfRepositoryConnection(t, repositoryID, vulnerabilityID))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(resp.SetData(horusecEntities.Vulnerability{}))
+		mockWrite.On("StartTransaction").Return(mockTx)
+		mockTx.On("Update").Return(&response.Response{})
+		mockTx.On("Create").Return(&response.Response{})
+		mockTx.On("CommitTransaction").Return(&response.Response{})
 
-// 		result, err := repo.UpdateVulnType(uuid.New(), &dto.UpdateVulnType{})
+		repo := NewManagementRepository(mockRead, mockWrite)
 
-// 		assert.NoError(t, err)
-// 		assert.NotNil(t, result)
-// 	})
+		result, err := repo.UpdateVulnType(repositoryID, vulnerabilityID, &dto.UpdateVulnType{Type: horusecEnums.FalsePositive})
 
-// 	t.Run("should return error when getting vulnerability", func(t *testing.T) {
-// 		mockWrite := &relational.MockWrite{}
-// 		mockRead := &relational.MockRead{}
+		assert.NoError(t, err)
+		assert.Equal(t, horusecEnums.FalsePositive, result.Type)
+		mockTx.AssertCalled(t, "Create")
+	})
 
-// 		resp := &response.Response{}
-// 		mockRead.On("SetFilter").Return(&gorm.DB{})
-// 		mockRead.On("Find").Return(resp.SetError(errors.New("test")))
+	t.Run("should set vulnerability type when empty type", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockRead := &relational.MockRead{}
+		mockTx := &relational.MockWrite{}
 
-// 		repo := NewManagementRepository(mockRead, mockWrite)
+		resp := &response.Response{}
+		mockRead.On("GetConnection").Return(newVulnOfRepositoryConnection(t, repositoryID, vulnerabilityID))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(resp.SetData(horusecEntities.Vulnerability{}))
+		mockWrite.On("StartTransaction").Return(mockTx)
+		mockTx.On("Update").Return(&response.Response{})
+		mockTx.On("Create").Return(&response.Response{})
+		mockTx.On("CommitTransaction").Return(&response.Response{})
 
-// 		_, err := repo.UpdateVulnType(uuid.New(), &dto.UpdateVulnType{})
+		repo := NewManagementRepository(mockRead, mockWrite)
 
-// 		assert.Error(t, err)
-// 		assert.Equal(t, errors.New("test"), err)
-// 	})
-// }
+		result, err := repo.UpdateVulnType(repositoryID, vulnerabilityID, &dto.UpdateVulnType{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, horusecEnums.Vulnerability, result.Type)
+	})
+
+	t.Run("should set risk accepted until when risk accepted", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockRead := &relational.MockRead{}
+		mockTx := &relational.MockWrite{}
+
+		resp := &response.Response{}
+		mockRead.On("GetConnection").Return(newVulnOfRepositoryConnection(t, repositoryID, vulnerabilityID))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(resp.SetData(horusecEntities.Vulnerability{}))
+		mockWrite.On("StartTransaction").Return(mockTx)
+		mockTx.On("Update").Return(&response.Response{})
+		mockTx.On("Create").Return(&response.Response{})
+		mockTx.On("CommitTransaction").Return(&response.Response{})
+
+		repo := NewManagementRepository(mockRead, mockWrite)
+
+		until := time.Now().Add(time.Hour)
+		result, err := repo.UpdateVulnType(repositoryID, vulnerabilityID, &dto.UpdateVulnType{Type: horusecEnums.RiskAccepted,
+			RiskAcceptedUntil: &until})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &until, result.RiskAcceptedUntil)
+	})
+
+	t.Run("should rollback when failed to create history", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockRead := &relational.MockRead{}
+		mockTx := &relational.MockWrite{}
+
+		resp := &response.Response{}
+		mockRead.On("GetConnection").Return(newVulnOfRepositoryConnection(t, repositoryID, vulnerabilityID))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(resp.SetData(horusecEntities.Vulnerability{}))
+		mockWrite.On("StartTransaction").Return(mockTx)
+		mockTx.On("Update").Return(&response.Response{})
+		mockTx.On("Create").Return((&response.Response{}).SetError(errors.New("test")))
+		mockTx.On("RollbackTransaction").Return(&response.Response{})
+
+		repo := NewManagementRepository(mockRead, mockWrite)
+
+		_, err := repo.UpdateVulnType(repositoryID, vulnerabilityID, &dto.UpdateVulnType{Type: horusecEnums.RiskAccepted})
+
+		assert.Equal(t, errors.New("test"), err)
+		mockTx.AssertCalled(t, "RollbackTransaction")
+	})
+
+	t.Run("should rollback when failed to update", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockRead := &relational.MockRead{}
+		mockTx := &relational.MockWrite{}
+
+		resp := &response.Response{}
+		mockRead.On("GetConnection").Return(newVulnOfRepositoryConnection(t, repositoryID, vulnerabilityID))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(resp.SetData(horusecEntities.Vulnerability{}))
+		mockWrite.On("StartTransaction").Return(mockTx)
+		mockTx.On("Update").Return((&response.Response{}).SetError(errors.New("test")))
+		mockTx.On("RollbackTransaction").Return(&response.Response{})
+
+		repo := NewManagementRepository(mockRead, mockWrite)
+
+		_, err := repo.UpdateVulnType(repositoryID, vulnerabilityID, &dto.UpdateVulnType{Type: horusecEnums.RiskAccepted})
+
+		assert.Equal(t, errors.New("test"), err)
+	})
+
+	t.Run("should return error when getting vulnerability", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockRead := &relational.MockRead{}
+
+		resp := &response.Response{}
+		mockRead.On("GetConnection").Return(newVulnOfRepositoryConnection(t, repositoryID, vulnerabilityID))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(resp.SetError(errors.New("test")))
+
+		repo := NewManagementRepository(mockRead, mockWrite)
+
+		_, err := repo.UpdateVulnType(repositoryID, vulnerabilityID, &dto.UpdateVulnType{})
+
+		assert.Error(t, err)
+		assert.Equal(t, errors.New("test"), err)
+	})
+
+	t.Run("should return not found when vulnerability is of other repository", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(newVulnOfRepositoryConnection(t, repositoryID, vulnerabilityID))
+
+		repo := NewManagementRepository(mockRead, mockWrite)
+
+		_, err := repo.UpdateVulnType(uuid.New(), vulnerabilityID, &dto.UpdateVulnType{})
+
+		assert.Equal(t, errorsEnum.ErrNotFoundRecords, err)
+		mockRead.AssertNotCalled(t, "Find")
+		mockWrite.AssertNotCalled(t, "StartTransaction")
+	})
+}
+
+func TestBulkUpdateVulnType(t *testing.T) {
+	companyID := uuid.New()
+	repositoryID := uuid.New()
+
+	newRepository := func(t *testing.T, mockTx *relational.MockWrite) IRepository {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		mockRead.On("GetConnection").Return(newTestConnection(t, companyID, repositoryID))
+		mockWrite.On("StartTransaction").Return(mockTx)
+		return NewManagementRepository(mockRead, mockWrite)
+	}
+
+	t.Run("should update vulnerabilities selected by filter", func(t *testing.T) {
+		mockTx := &relational.MockWrite{}
+		mockTx.On("Update").Return(&response.Response{})
+		mockTx.On("Create").Return(&response.Response{})
+		mockTx.On("CommitTransaction").Return(&response.Response{})
+
+		count, err := newRepository(t, mockTx).BulkUpdateVulnType(repositoryID, &dto.BulkUpdateVulnType{
+			Filter: &dto.VulnFilter{Severity: severity.High}, Type: horusecEnums.FalsePositive, Comment: "test"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		mockTx.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("should update vulnerabilities selected by ids", func(t *testing.T) {
+		mockTx := &relational.MockWrite{}
+		mockTx.On("Update").Return(&response.Response{})
+		mockTx.On("Create").Return(&response.Response{})
+		mockTx.On("CommitTransaction").Return(&response.Response{})
+
+		count, err := newRepository(t, mockTx).BulkUpdateVulnType(repositoryID, &dto.BulkUpdateVulnType{
+			VulnerabilityIDs: []uuid.UUID{uuid.New()}, Type: horusecEnums.FalsePositive, Comment: "test"})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+		mockTx.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("should skip vulnerabilities of other repositories", func(t *testing.T) {
+		mockTx := &relational.MockWrite{}
+
+		count, err := newRepository(t, mockTx).BulkUpdateVulnType(uuid.New(), &dto.BulkUpdateVulnType{
+			Filter: &dto.VulnFilter{Severity: severity.High}, Type: horusecEnums.FalsePositive, Comment: "test"})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("should skip vulnerabilities already with same type", func(t *testing.T) {
+		mockTx := &relational.MockWrite{}
+
+		count, err := newRepository(t, mockTx).BulkUpdateVulnType(repositoryID, &dto.BulkUpdateVulnType{
+			Filter: &dto.VulnFilter{Severity: severity.High}, Type: horusecEnums.Vulnerability, Comment: "test"})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("should not update all vulnerabilities when filter and ids are empty", func(t *testing.T) {
+		mockTx := &relational.MockWrite{}
+
+		count, err := newRepository(t, mockTx).BulkUpdateVulnType(repositoryID, &dto.BulkUpdateVulnType{
+			Filter: &dto.VulnFilter{}, Type: horusecEnums.FalsePositive, Comment: "test"})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+		mockTx.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("should return error when failed to update", func(t *testing.T) {
+		mockTx := &relational.MockWrite{}
+		mockTx.On("Update").Return((&response.Response{}).SetError(errors.New("test")))
+		mockTx.On("RollbackTransaction").Return(&response.Response{})
+
+		_, err := newRepository(t, mockTx).BulkUpdateVulnType(repositoryID, &dto.BulkUpdateVulnType{
+			Filter: &dto.VulnFilter{Severity: severity.High}, Type: horusecEnums.RiskAccepted, Comment: "test"})
+		assert.Equal(t, errors.New("test"), err)
+	})
+}
+
+func TestListVulnTypeHistory(t *testing.T) {
+	repositoryID := uuid.New()
+	vulnerabilityID := uuid.New()
+
+	t.Run("should success list history with no errors", func(t *testing.T) {
+		conn := newVulnOfRepositoryConnection(t, repositoryID, vulnerabilityID)
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(conn)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(&response.Response{})
+
+		repo := NewManagementRepository(mockRead, &relational.MockWrite{})
+
+		result, err := repo.ListVulnTypeHistory(repositoryID, vulnerabilityID)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("should return not found when vulnerability is of other repository", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(newVulnOfRepositoryConnection(t, repositoryID, vulnerabilityID))
+
+		repo := NewManagementRepository(mockRead, &relational.MockWrite{})
+
+		_, err := repo.ListVulnTypeHistory(uuid.New(), vulnerabilityID)
+		assert.Equal(t, errorsEnum.ErrNotFoundRecords, err)
+		mockRead.AssertNotCalled(t, "Find")
+	})
+
+	t.Run("should return error when failed to list history", func(t *testing.T) {
+		conn := newVulnOfRepositoryConnection(t, repositoryID, vulnerabilityID)
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(conn)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return((&response.Response{}).SetError(errors.New("test")))
+
+		repo := NewManagementRepository(mockRead, &relational.MockWrite{})
+
+		_, err := repo.ListVulnTypeHistory(repositoryID, vulnerabilityID)
+		assert.Equal(t, errors.New("test"), err)
+	})
+}
+
+func newVulnOfRepositoryConnection(t *testing.T, repositoryID, vulnerabilityID uuid.UUID) *gorm.DB {
+	conn, err := gorm.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	analysisID := uuid.New()
+	conn.Exec("CREATE TABLE analysis (analysis_id TEXT, repository_id TEXT)")
+	conn.Exec("CREATE TABLE analysis_vulnerabilities (analysis_id TEXT, vulnerability_id TEXT)")
+	conn.Exec("INSERT INTO analysis VALUES (?, ?)", analysisID, repositoryID)
+	conn.Exec("INSERT INTO analysis_vulnerabilities VALUES (?, ?)", analysisID, vulnerabilityID)
+	return conn
+}
+
+func TestListRiskAcceptedDates(t *testing.T) {
+	t.Run("should return the last risk accept date of each vulnerability", func(t *testing.T) {
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		history := &horusecEntities.VulnerabilityTypeHistory{}
+		assert.NoError(t, conn.Table(history.GetTable()).AutoMigrate(history).Error)
+
+		firstAccept := time.Now().AddDate(0, 0, -30).UTC()
+		lastAccept := time.Now().AddDate(0, 0, -10).UTC()
+		otherAccept := time.Now().AddDate(0, 0, -5).UTC()
+		vulnerability := &horusecEntities.Vulnerability{VulnerabilityID: uuid.New()}
+		other := &horusecEntities.Vulnerability{VulnerabilityID: uuid.New()}
+		for _, item := range []struct {
+			vulnerability *horusecEntities.Vulnerability
+			newType       horusecEnums.VulnerabilityType
+			createdAt     time.Time
+		}{
+			{vulnerability, horusecEnums.RiskAccepted, firstAccept},
+			{vulnerability, horusecEnums.RiskAccepted, lastAccept},
+			{vulnerability, horusecEnums.FalsePositive, time.Now()},
+			{other, horusecEnums.RiskAccepted, otherAccept},
+			{&horusecEntities.Vulnerability{VulnerabilityID: uuid.New()}, horusecEnums.RiskAccepted, time.Now()},
+		} {
+			toCreate := item.vulnerability.NewTypeHistory(item.newType, uuid.New(), "")
+			toCreate.CreatedAt = item.createdAt
+			assert.NoError(t, conn.Table(history.GetTable()).Create(toCreate).Error)
+		}
+
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(conn)
+		repo := NewManagementRepository(mockRead, &relational.MockWrite{})
+
+		dates, err := repo.ListRiskAcceptedDates([]uuid.UUID{vulnerability.VulnerabilityID, other.VulnerabilityID})
+		assert.NoError(t, err)
+		assert.Len(t, dates, 2)
+		assert.Contains(t, dates, lastAccept)
+		assert.Contains(t, dates, otherAccept)
+	})
+
+	t.Run("should not query when there are no vulnerabilities", func(t *testing.T) {
+		repo := NewManagementRepository(&relational.MockRead{}, &relational.MockWrite{})
+
+		dates, err := repo.ListRiskAcceptedDates(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, dates)
+	})
+}
 
 func TestGetByID(t *testing.T) {
 	t.Run("should success update data with no errors", func(t *testing.T) {
@@ -290,3 +605,187 @@ func TestGetByID(t *testing.T) {
 		assert.Equal(t, errors.New("test"), err)
 	})
 }
+
+func newTestConnection(t *testing.T, companyID, repositoryID uuid.UUID) *gorm.DB {
+	conn, err := gorm.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	conn.Exec("CREATE TABLE analysis (analysis_id TEXT, repository_id TEXT, repository_name TEXT," +
+		" company_id TEXT, created_at DATETIME)")
+	conn.Exec("CREATE TABLE analysis_vulnerabilities (analysis_id TEXT, vulnerability_id TEXT)")
+	conn.Exec("CREATE TABLE repositories (repository_id TEXT, name TEXT)")
+	conn.Exec("CREATE TABLE vulnerabilities (vulnerability_id TEXT, line TEXT, column TEXT, confidence TEXT," +
+		" file TEXT, code TEXT, details TEXT, security_tool TEXT, language TEXT, severity TEXT, vuln_hash TEXT," +
+		" type TEXT, commit_author TEXT, commit_email TEXT, commit_hash TEXT, commit_message TEXT, commit_date TEXT," +
+		" risk_accepted_until DATETIME, risk_expiration_warned BOOLEAN DEFAULT FALSE)")
+
+	// the repository was renamed after the first analysis
+	conn.Exec("INSERT INTO repositories VALUES (?, 'test')", repositoryID)
+	highID, lowID := uuid.New(), uuid.New()
+	for _, repositoryName := range []string{"old name", "test"} {
+		analysisID := uuid.New()
+		conn.Exec("INSERT INTO analysis VALUES (?, ?, ?, ?, ?)", analysisID, repositoryID, repositoryName, companyID,
+			time.Now())
+		conn.Exec("INSERT INTO analysis_vulnerabilities VALUES (?, ?)", analysisID, highID)
+		conn.Exec("INSERT INTO analysis_vulnerabilities VALUES (?, ?)", analysisID, lowID)
+	}
+
+	conn.Exec("INSERT INTO vulnerabilities (vulnerability_id, severity, type, security_tool, language, file,"+
+		" line, details, commit_author, commit_email) VALUES (?, 'HIGH', 'Vulnerability', 'GoSec', 'Go',"+
+		" 'internal/api/main.go', '10', 'SQL Injection in query', 'horus', 'horus@example.com')", highID)
+	conn.Exec("INSERT INTO vulnerabilities (vulnerability_id, severity, type, security_tool, language, file,"+
+		" line, details, commit_author, commit_email) VALUES (?, 'LOW', 'Vulnerability', 'HorusecLeaks', 'Leaks',"+
+		" 'config/app_%.yaml', '2', 'Hard coded password', 'zup', 'zup@example.com')", lowID)
+	return conn
+}
+
+func TestExportVulnerabilities(t *testing.T) {
+	companyID := uuid.New()
+	repositoryID := uuid.New()
+
+	countExported := func(t *testing.T, filter *dto.VulnExportFilter) (count int, err error) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(newTestConnection(t, companyID, repositoryID))
+		repo := NewManagementRepository(mockRead, &relational.MockWrite{})
+
+		err = repo.ExportVulnerabilities(filter, func(vulnExport *dto.VulnExport) error {
+			assert.Equal(t, repositoryID, vulnExport.RepositoryID)
+			assert.Equal(t, "test", vulnExport.RepositoryName)
+			count++
+			return nil
+		})
+
+		return count, err
+	}
+
+	t.Run("should export distinct vulnerabilities of repository", func(t *testing.T) {
+		count, err := countExported(t, &dto.VulnExportFilter{VulnFilter: dto.VulnFilter{RepositoryID: repositoryID}})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("should export vulnerabilities of company filtered by severity", func(t *testing.T) {
+		count, err := countExported(t, &dto.VulnExportFilter{VulnFilter: dto.VulnFilter{CompanyID: companyID,
+			Severity: severity.High}})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("should export vulnerabilities filtered by tool and language", func(t *testing.T) {
+		count, err := countExported(t, &dto.VulnExportFilter{VulnFilter: dto.VulnFilter{CompanyID: companyID,
+			SecurityTool: "HorusecLeaks", Language: "Leaks"}})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("should export nothing when out of date range", func(t *testing.T) {
+		count, err := countExported(t, &dto.VulnExportFilter{VulnFilter: dto.VulnFilter{CompanyID: companyID},
+			InitialDate: time.Now().Add(time.Hour), FinalDate: time.Now().Add(2 * time.Hour)})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("should return error when handle fails", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(newTestConnection(t, companyID, repositoryID))
+		repo := NewManagementRepository(mockRead, &relational.MockWrite{})
+
+		err := repo.ExportVulnerabilities(&dto.VulnExportFilter{VulnFilter: dto.VulnFilter{CompanyID: companyID}},
+			func(vulnExport *dto.VulnExport) error {
+				return errors.New("test")
+			})
+		assert.Equal(t, errors.New("test"), err)
+	})
+
+	t.Run("should return error when query fails", func(t *testing.T) {
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(conn)
+		repo := NewManagementRepository(mockRead, &relational.MockWrite{})
+
+		assert.Error(t, repo.ExportVulnerabilities(&dto.VulnExportFilter{VulnFilter: dto.VulnFilter{CompanyID: companyID}}, nil))
+	})
+}
+
+func TestRiskAcceptExpiration(t *testing.T) {
+	companyID := uuid.New()
+	repositoryID := uuid.New()
+
+	newRepository := func(t *testing.T, until time.Time, mockWrite *relational.MockWrite) IRepository {
+		conn := newTestConnection(t, companyID, repositoryID)
+		conn.Exec("UPDATE vulnerabilities SET type = ?, risk_accepted_until = ? WHERE severity = 'HIGH'",
+			horusecEnums.RiskAccepted, until)
+
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(conn)
+		return NewManagementRepository(mockRead, mockWrite)
+	}
+
+	t.Run("should list expired risk accept of the repository", func(t *testing.T) {
+		expirations, err := newRepository(t, time.Now().AddDate(0, 0, -2), &relational.MockWrite{}).
+			ListRiskAcceptExpired(repositoryID)
+		assert.NoError(t, err)
+		assert.Len(t, expirations, 1)
+		assert.Equal(t, repositoryID, expirations[0].RepositoryID)
+		assert.Equal(t, "test", expirations[0].RepositoryName)
+		assert.Equal(t, companyID, expirations[0].CompanyID)
+	})
+
+	t.Run("should not list expired risk accept of other repository", func(t *testing.T) {
+		expirations, err := newRepository(t, time.Now().AddDate(0, 0, -2), &relational.MockWrite{}).
+			ListRiskAcceptExpired(uuid.New())
+		assert.NoError(t, err)
+		assert.Empty(t, expirations)
+	})
+
+	t.Run("should not list risk accept not expired", func(t *testing.T) {
+		expirations, err := newRepository(t, time.Now().AddDate(0, 0, 2), &relational.MockWrite{}).
+			ListRiskAcceptExpired(uuid.Nil)
+		assert.NoError(t, err)
+		assert.Empty(t, expirations)
+	})
+
+	t.Run("should revert expired risk accept to vulnerability with history", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockTx := &relational.MockWrite{}
+		mockWrite.On("StartTransaction").Return(mockTx)
+		mockTx.On("Update").Return(&response.Response{})
+		mockTx.On("Create").Return(&response.Response{})
+		mockTx.On("CommitTransaction").Return(&response.Response{})
+
+		assert.NoError(t, NewManagementRepository(&relational.MockRead{}, mockWrite).RevertExpiredRiskAccept(
+			[]dto.RiskAcceptExpiration{{VulnerabilityID: uuid.New()}, {VulnerabilityID: uuid.New()}}))
+		mockTx.AssertNumberOfCalls(t, "Update", 1)
+		mockTx.AssertNumberOfCalls(t, "Create", 2)
+	})
+
+	t.Run("should not revert without expirations", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+
+		assert.NoError(t, NewManagementRepository(&relational.MockRead{}, mockWrite).RevertExpiredRiskAccept(nil))
+		mockWrite.AssertNotCalled(t, "StartTransaction")
+	})
+
+	t.Run("should list risk accept expiring until date", func(t *testing.T) {
+		repo := newRepository(t, time.Now().AddDate(0, 0, 2), &relational.MockWrite{})
+
+		expirations, err := repo.ListRiskAcceptExpiringUntil(time.Now().AddDate(0, 0, 3))
+		assert.NoError(t, err)
+		assert.Len(t, expirations, 1)
+
+		expirations, err = repo.ListRiskAcceptExpiringUntil(time.Now())
+		assert.NoError(t, err)
+		assert.Empty(t, expirations)
+	})
+
+	t.Run("should set risk expiration warned", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(&response.Response{})
+		repo := NewManagementRepository(&relational.MockRead{}, mockWrite)
+
+		assert.NoError(t, repo.SetRiskExpirationWarned([]uuid.UUID{uuid.New()}))
+		assert.NoError(t, repo.SetRiskExpirationWarned(nil))
+		mockWrite.AssertNumberOfCalls(t, "Update", 1)
+	})
+}
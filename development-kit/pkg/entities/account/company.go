@@ -25,29 +25,34 @@ import (
 )
 
 type Company struct {
-	CompanyID   uuid.UUID      `json:"companyID" gorm:"primary_key" swaggerignore:"true"`
-	Name        string         `json:"name"`
-	Description string         `json:"description"`
-	AuthzMember pq.StringArray `json:"authzMember"`
-	AuthzAdmin  pq.StringArray `json:"authzAdmin"`
-	CreatedAt   time.Time      `json:"createdAt" swaggerignore:"true"`
-	UpdatedAt   time.Time      `json:"updatedAt" swaggerignore:"true"`
+	CompanyID             uuid.UUID      `json:"companyID" gorm:"primary_key" swaggerignore:"true"`
+	Name                  string         `json:"name"`
+	Description           string         `json:"description"`
+	AuthzMember           pq.StringArray `json:"authzMember"`
+	AuthzAdmin            pq.StringArray `json:"authzAdmin"`
+	RequireTwoFactor      bool           `json:"requireTwoFactor"`
+	AnalysisRetentionDays int            `json:"analysisRetentionDays"`
+	CreatedAt             time.Time      `json:"createdAt" swaggerignore:"true"`
+	UpdatedAt             time.Time      `json:"updatedAt" swaggerignore:"true"`
 }
 
 type CompanyResponse struct {
-	CompanyID   uuid.UUID      `json:"companyID"`
-	Name        string         `json:"name"`
-	Role        rolesEnum.Role `json:"role"`
-	Description string         `json:"description"`
-	AuthzMember pq.StringArray `json:"authzMember"`
-	AuthzAdmin  pq.StringArray `json:"authzAdmin"`
-	CreatedAt   time.Time      `json:"createdAt"`
-	UpdatedAt   time.Time      `json:"updatedAt"`
+	CompanyID             uuid.UUID      `json:"companyID"`
+	Name                  string         `json:"name"`
+	Role                  rolesEnum.Role `json:"role"`
+	Description           string         `json:"description"`
+	AuthzMember           pq.StringArray `json:"authzMember"`
+	AuthzAdmin            pq.StringArray `json:"authzAdmin"`
+	RequireTwoFactor      bool           `json:"requireTwoFactor"`
+	AnalysisRetentionDays int            `json:"analysisRetentionDays"`
+	CreatedAt             time.Time      `json:"createdAt"`
+	UpdatedAt             time.Time      `json:"updatedAt"`
 }
 
 func (c *Company) Validate() error {
 	return validation.ValidateStruct(c,
 		validation.Field(&c.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.AnalysisRetentionDays, validation.Min(0)),
 	)
 }
 
@@ -66,11 +71,13 @@ func (c *Company) SetUpdateData() *Company {
 
 func (c *Company) MapToUpdate() map[string]interface{} {
 	return map[string]interface{}{
-		"name":         c.Name,
-		"description":  c.Description,
-		"authz_member": c.AuthzMember,
-		"authz_admin":  c.AuthzAdmin,
-		"updated_at":   c.UpdatedAt,
+		"name":                    c.Name,
+		"description":             c.Description,
+		"authz_member":            c.AuthzMember,
+		"authz_admin":             c.AuthzAdmin,
+		"require_two_factor":      c.RequireTwoFactor,
+		"analysis_retention_days": c.AnalysisRetentionDays,
+		"updated_at":              c.UpdatedAt,
 	}
 }
 
@@ -80,14 +87,16 @@ func (c *Company) GetTable() string {
 
 func (c *Company) ToCompanyResponse(role rolesEnum.Role) *CompanyResponse {
 	return &CompanyResponse{
-		CompanyID:   c.CompanyID,
-		Name:        c.Name,
-		Role:        role,
-		Description: c.Description,
-		AuthzAdmin:  c.AuthzAdmin,
-		AuthzMember: c.AuthzMember,
-		CreatedAt:   c.CreatedAt,
-		UpdatedAt:   c.UpdatedAt,
+		CompanyID:             c.CompanyID,
+		Name:                  c.Name,
+		Role:                  role,
+		Description:           c.Description,
+		AuthzAdmin:            c.AuthzAdmin,
+		AuthzMember:           c.AuthzMember,
+		RequireTwoFactor:      c.RequireTwoFactor,
+		AnalysisRetentionDays: c.AnalysisRetentionDays,
+		CreatedAt:             c.CreatedAt,
+		UpdatedAt:             c.UpdatedAt,
 	}
 }
 
@@ -32,6 +32,11 @@ func TestCompanyValidate(t *testing.T) {
 		company := &Company{Name: "test"}
 		assert.Nil(t, company.Validate())
 	})
+
+	t.Run("validate should return an error when the analysis retention is negative", func(t *testing.T) {
+		company := &Company{Name: "test", AnalysisRetentionDays: -1}
+		assert.Error(t, company.Validate())
+	})
 }
 
 func TestCompanyGetTable(t *testing.T) {
@@ -0,0 +1,184 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/languages"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/tools"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Policy is a gate defined by the company admins and evaluated by horusec-api on each uploaded analysis, a policy
+// without repository applies to all repositories of the company. Empty rules are not evaluated
+type Policy struct {
+	PolicyID               uuid.UUID      `json:"policyID" gorm:"primary_key" swaggerignore:"true"`
+	CompanyID              uuid.UUID      `json:"companyID" swaggerignore:"true"`
+	RepositoryID           *uuid.UUID     `json:"repositoryID"`
+	Name                   string         `json:"name"`
+	MaxHighVulnerabilities *int           `json:"maxHighVulnerabilities"`
+	RequiredTools          pq.StringArray `json:"requiredTools" swaggertype:"array,string" gorm:"type:text[]"`
+	RequiredLanguages      pq.StringArray `json:"requiredLanguages" swaggertype:"array,string" gorm:"type:text[]"`
+	MaxRiskAcceptAgeInDays int            `json:"maxRiskAcceptAgeInDays"`
+	CreatedAt              time.Time      `json:"createdAt" swaggerignore:"true"`
+	UpdatedAt              time.Time      `json:"updatedAt" swaggerignore:"true"`
+}
+
+// PolicyInput is the data of an uploaded analysis used to evaluate the policies
+type PolicyInput struct {
+	HighVulnerabilities int
+	DisabledTools       []string
+	Languages           []string
+	RiskAcceptedAt      []time.Time
+}
+
+func (p *Policy) GetTable() string {
+	return "policies"
+}
+
+func (p *Policy) Validate() error {
+	return validation.ValidateStruct(p,
+		validation.Field(&p.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&p.MaxHighVulnerabilities, validation.Min(0)),
+		validation.Field(&p.RequiredTools, validation.Each(validation.By(p.validateTool))),
+		validation.Field(&p.RequiredLanguages, validation.Each(validation.By(p.validateLanguage))),
+		validation.Field(&p.MaxRiskAcceptAgeInDays, validation.Min(0)),
+	)
+}
+
+func (p *Policy) validateTool(value interface{}) error {
+	if tools.Tool(value.(string)).IsInvalid() {
+		return errors.ErrorInvalidPolicyTool
+	}
+
+	return nil
+}
+
+func (p *Policy) validateLanguage(value interface{}) error {
+	if languages.ParseStringToLanguage(value.(string)) == languages.Unknown {
+		return errors.ErrorInvalidPolicyLanguage
+	}
+
+	return nil
+}
+
+func (p *Policy) SetCreateData(companyID uuid.UUID) *Policy {
+	p.PolicyID = uuid.New()
+	p.CompanyID = companyID
+	p.CreatedAt = time.Now()
+	p.UpdatedAt = time.Now()
+	return p
+}
+
+func (p *Policy) SetUpdateData(data *Policy) *Policy {
+	p.RepositoryID = data.RepositoryID
+	p.Name = data.Name
+	p.MaxHighVulnerabilities = data.MaxHighVulnerabilities
+	p.RequiredTools = data.RequiredTools
+	p.RequiredLanguages = data.RequiredLanguages
+	p.MaxRiskAcceptAgeInDays = data.MaxRiskAcceptAgeInDays
+	p.UpdatedAt = time.Now()
+	return p
+}
+
+func (p *Policy) MapToUpdate() map[string]interface{} {
+	return map[string]interface{}{
+		"repository_id":               p.RepositoryID,
+		"name":                        p.Name,
+		"max_high_vulnerabilities":    p.MaxHighVulnerabilities,
+		"required_tools":              p.RequiredTools,
+		"required_languages":          p.RequiredLanguages,
+		"max_risk_accept_age_in_days": p.MaxRiskAcceptAgeInDays,
+		"updated_at":                  p.UpdatedAt,
+	}
+}
+
+// Evaluate returns the reasons why the analysis does not pass in the policy, empty when it passes
+func (p *Policy) Evaluate(input *PolicyInput, now time.Time) (reasons []string) {
+	reasons = append(reasons, p.evaluateHighVulnerabilities(input)...)
+	reasons = append(reasons, p.evaluateRequiredTools(input)...)
+	reasons = append(reasons, p.evaluateRequiredLanguages(input)...)
+	return append(reasons, p.evaluateRiskAcceptAge(input, now)...)
+}
+
+func (p *Policy) evaluateHighVulnerabilities(input *PolicyInput) []string {
+	if p.MaxHighVulnerabilities == nil || input.HighVulnerabilities <= *p.MaxHighVulnerabilities {
+		return nil
+	}
+
+	return []string{p.newReason("found %d HIGH vulnerabilities and the maximum allowed is %d",
+		input.HighVulnerabilities, *p.MaxHighVulnerabilities)}
+}
+
+func (p *Policy) evaluateRequiredTools(input *PolicyInput) (reasons []string) {
+	for _, tool := range p.RequiredTools {
+		if containsFold(input.DisabledTools, tool) {
+			reasons = append(reasons, p.newReason("the tool %s is required and was disabled", tool))
+		}
+	}
+
+	return reasons
+}
+
+func (p *Policy) evaluateRequiredLanguages(input *PolicyInput) (reasons []string) {
+	for _, language := range p.RequiredLanguages {
+		if !containsFold(input.Languages, language) {
+			reasons = append(reasons, p.newReason("the language %s is required and was not scanned", language))
+		}
+	}
+
+	return reasons
+}
+
+func (p *Policy) evaluateRiskAcceptAge(input *PolicyInput, now time.Time) []string {
+	if p.MaxRiskAcceptAgeInDays <= 0 {
+		return nil
+	}
+
+	total := 0
+	limit := now.AddDate(0, 0, -p.MaxRiskAcceptAgeInDays)
+	for _, riskAcceptedAt := range input.RiskAcceptedAt {
+		if riskAcceptedAt.Before(limit) {
+			total++
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	return []string{p.newReason("found %d risk acceptances older than %d days",
+		total, p.MaxRiskAcceptAgeInDays)}
+}
+
+func (p *Policy) newReason(format string, args ...interface{}) string {
+	return fmt.Sprintf("[%s] ", p.Name) + fmt.Sprintf(format, args...)
+}
+
+func containsFold(values []string, search string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, search) {
+			return true
+		}
+	}
+
+	return false
+}
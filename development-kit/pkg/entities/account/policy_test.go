@@ -0,0 +1,118 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	t.Run("should return nil when the policy is valid", func(t *testing.T) {
+		maxHigh := 0
+		policy := &Policy{Name: "test", MaxHighVulnerabilities: &maxHigh, RequiredTools: []string{"GoSec"},
+			RequiredLanguages: []string{"Go"}, MaxRiskAcceptAgeInDays: 30}
+		assert.NoError(t, policy.Validate())
+	})
+
+	t.Run("should return an error when the name is empty", func(t *testing.T) {
+		policy := &Policy{}
+		assert.Error(t, policy.Validate())
+	})
+
+	t.Run("should return an error when the max high vulnerabilities is negative", func(t *testing.T) {
+		maxHigh := -1
+		policy := &Policy{Name: "test", MaxHighVulnerabilities: &maxHigh}
+		assert.Error(t, policy.Validate())
+	})
+
+	t.Run("should return an error when the required tool is invalid", func(t *testing.T) {
+		policy := &Policy{Name: "test", RequiredTools: []string{"test"}}
+		assert.Contains(t, policy.Validate().Error(), errors.ErrorInvalidPolicyTool.Error())
+	})
+
+	t.Run("should return an error when the required language is invalid", func(t *testing.T) {
+		policy := &Policy{Name: "test", RequiredLanguages: []string{"test"}}
+		assert.Contains(t, policy.Validate().Error(), errors.ErrorInvalidPolicyLanguage.Error())
+	})
+
+	t.Run("should return an error when the max risk accept age is negative", func(t *testing.T) {
+		policy := &Policy{Name: "test", MaxRiskAcceptAgeInDays: -1}
+		assert.Error(t, policy.Validate())
+	})
+}
+
+func TestPolicyGetTable(t *testing.T) {
+	t.Run("should return the table name", func(t *testing.T) {
+		assert.Equal(t, "policies", (&Policy{}).GetTable())
+	})
+}
+
+func TestPolicySetCreateAndUpdateData(t *testing.T) {
+	t.Run("should set the create data", func(t *testing.T) {
+		companyID := uuid.New()
+		policy := (&Policy{}).SetCreateData(companyID)
+		assert.NotEqual(t, uuid.Nil, policy.PolicyID)
+		assert.Equal(t, companyID, policy.CompanyID)
+		assert.False(t, policy.CreatedAt.IsZero())
+	})
+
+	t.Run("should set the update data", func(t *testing.T) {
+		repositoryID := uuid.New()
+		policy := (&Policy{Name: "old"}).SetUpdateData(&Policy{Name: "new", RepositoryID: &repositoryID,
+			RequiredTools: []string{"GoSec"}, MaxRiskAcceptAgeInDays: 10})
+		assert.Equal(t, "new", policy.Name)
+		assert.Equal(t, &repositoryID, policy.RepositoryID)
+		assert.Equal(t, 10, policy.MapToUpdate()["max_risk_accept_age_in_days"])
+		assert.False(t, policy.UpdatedAt.IsZero())
+	})
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should return no reasons when the policy has no rules", func(t *testing.T) {
+		policy := &Policy{Name: "test"}
+		assert.Empty(t, policy.Evaluate(&PolicyInput{HighVulnerabilities: 10, DisabledTools: []string{"GoSec"}}, now))
+	})
+
+	t.Run("should return no reasons when the analysis passes", func(t *testing.T) {
+		maxHigh := 1
+		policy := &Policy{Name: "test", MaxHighVulnerabilities: &maxHigh, RequiredTools: []string{"GoSec"},
+			RequiredLanguages: []string{"Go"}, MaxRiskAcceptAgeInDays: 30}
+		input := &PolicyInput{HighVulnerabilities: 1, DisabledTools: []string{"Bandit"}, Languages: []string{"go"},
+			RiskAcceptedAt: []time.Time{now.AddDate(0, 0, -29)}}
+		assert.Empty(t, policy.Evaluate(input, now))
+	})
+
+	t.Run("should return a reason for each rule that fails", func(t *testing.T) {
+		maxHigh := 0
+		policy := &Policy{Name: "test", MaxHighVulnerabilities: &maxHigh, RequiredTools: []string{"GoSec"},
+			RequiredLanguages: []string{"Go", "Leaks"}, MaxRiskAcceptAgeInDays: 30}
+		input := &PolicyInput{HighVulnerabilities: 2, DisabledTools: []string{"gosec"}, Languages: []string{"Leaks"},
+			RiskAcceptedAt: []time.Time{now.AddDate(0, 0, -31), now.AddDate(0, 0, -40), now}}
+
+		assert.Equal(t, []string{
+			"[test] found 2 HIGH vulnerabilities and the maximum allowed is 0",
+			"[test] the tool GoSec is required and was disabled",
+			"[test] the language Go is required and was not scanned",
+			"[test] found 2 risk acceptances older than 30 days",
+		}, policy.Evaluate(input, now))
+	})
+}
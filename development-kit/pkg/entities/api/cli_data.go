@@ -22,6 +22,12 @@ import (
 type AnalysisData struct {
 	Analysis       *horusec.Analysis `json:"analysis"`
 	RepositoryName string            `json:"repositoryName"`
+	// DisabledTools and Languages are sent by the CLI to evaluate the policies of the company and repository, they
+	// are advisory because the server can not check which tools and languages the client ran
+	DisabledTools []string `json:"disabledTools"`
+	Languages     []string `json:"languages"`
+	// DisableAutoCreate is filled by the server with the constraint of the token that sent the analysis
+	DisableAutoCreate bool `json:"-"`
 }
 
 func (a *AnalysisData) ToBytes() []byte {
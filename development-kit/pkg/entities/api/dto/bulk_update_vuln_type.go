@@ -0,0 +1,78 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	horusecEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type BulkUpdateVulnType struct {
+	VulnerabilityIDs  []uuid.UUID                    `json:"vulnerabilityIDs"`
+	Filter            *VulnFilter                    `json:"filter"`
+	Type              horusecEnums.VulnerabilityType `json:"type"`
+	Comment           string                         `json:"comment"`
+	RiskAcceptedUntil *time.Time                     `json:"riskAcceptedUntil"`
+	AccountID         uuid.UUID                      `json:"-"`
+}
+
+func (b *BulkUpdateVulnType) Validate() error {
+	return validation.ValidateStruct(b,
+		validation.Field(&b.Type, validation.Required, validation.In(b.TypeValues()...)),
+		validation.Field(&b.Comment, validation.Required, validation.Length(1, 1000)),
+		validation.Field(&b.VulnerabilityIDs, validation.When(b.IsFilterEmpty(), validation.Required)),
+		validation.Field(&b.RiskAcceptedUntil, validation.When(b.Type != horusecEnums.RiskAccepted, validation.Nil),
+			validation.Min(time.Now())),
+	)
+}
+
+// IsFilterEmpty returns true when the filter does not select any criteria, so it would update all vulnerabilities
+func (b *BulkUpdateVulnType) IsFilterEmpty() bool {
+	return b.Filter == nil || b.Filter.IsEmpty()
+}
+
+func (b *BulkUpdateVulnType) SetAccountID(accountID uuid.UUID) *BulkUpdateVulnType {
+	b.AccountID = accountID
+	return b
+}
+
+func (b *BulkUpdateVulnType) GetFilter(repositoryID uuid.UUID) *VulnFilter {
+	filter := &VulnFilter{}
+	if b.Filter != nil {
+		*filter = *b.Filter
+	}
+
+	filter.CompanyID = uuid.Nil
+	filter.RepositoryID = repositoryID
+	return filter
+}
+
+func (b *BulkUpdateVulnType) ToBytes() []byte {
+	content, _ := json.Marshal(b)
+	return content
+}
+
+func (b BulkUpdateVulnType) TypeValues() []interface{} {
+	return []interface{}{
+		horusecEnums.FalsePositive,
+		horusecEnums.RiskAccepted,
+		horusecEnums.Vulnerability,
+		horusecEnums.Corrected,
+	}
+}
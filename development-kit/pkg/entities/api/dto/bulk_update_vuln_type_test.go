@@ -0,0 +1,149 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"testing"
+	"time"
+
+	horusecEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBulkUpdateVulnType(t *testing.T) {
+	t.Run("should return no error when valid ids", func(t *testing.T) {
+		bulk := &BulkUpdateVulnType{
+			VulnerabilityIDs: []uuid.UUID{uuid.New()},
+			Type:             horusecEnum.FalsePositive,
+			Comment:          "test",
+		}
+
+		assert.NoError(t, bulk.Validate())
+	})
+
+	t.Run("should return no error when valid filter", func(t *testing.T) {
+		bulk := &BulkUpdateVulnType{
+			Filter:  &VulnFilter{Severity: severity.Low},
+			Type:    horusecEnum.RiskAccepted,
+			Comment: "test",
+		}
+
+		assert.NoError(t, bulk.Validate())
+	})
+
+	t.Run("should return error when missing ids and filter", func(t *testing.T) {
+		bulk := &BulkUpdateVulnType{
+			Type:    horusecEnum.RiskAccepted,
+			Comment: "test",
+		}
+
+		err := bulk.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "vulnerabilityIDs: cannot be blank.", err.Error())
+	})
+
+	t.Run("should return error when missing ids and empty filter", func(t *testing.T) {
+		bulk := &BulkUpdateVulnType{
+			Filter:  &VulnFilter{},
+			Type:    horusecEnum.FalsePositive,
+			Comment: "test",
+		}
+
+		err := bulk.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "vulnerabilityIDs: cannot be blank.", err.Error())
+	})
+
+	t.Run("should return error when missing comment", func(t *testing.T) {
+		bulk := &BulkUpdateVulnType{
+			VulnerabilityIDs: []uuid.UUID{uuid.New()},
+			Type:             horusecEnum.RiskAccepted,
+		}
+
+		err := bulk.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "comment: cannot be blank.", err.Error())
+	})
+
+	t.Run("should return error when invalid type", func(t *testing.T) {
+		bulk := &BulkUpdateVulnType{
+			VulnerabilityIDs: []uuid.UUID{uuid.New()},
+			Type:             "test",
+			Comment:          "test",
+		}
+
+		err := bulk.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "type: must be a valid value.", err.Error())
+	})
+
+	t.Run("should return error when risk accepted until and other type", func(t *testing.T) {
+		until := time.Now().Add(time.Hour)
+		bulk := &BulkUpdateVulnType{
+			VulnerabilityIDs:  []uuid.UUID{uuid.New()},
+			Type:              horusecEnum.FalsePositive,
+			Comment:           "test",
+			RiskAcceptedUntil: &until,
+		}
+
+		err := bulk.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, "riskAcceptedUntil: must be blank.", err.Error())
+	})
+
+	t.Run("should return error when risk accepted until past date", func(t *testing.T) {
+		until := time.Now().Add(-time.Hour)
+		bulk := &BulkUpdateVulnType{
+			VulnerabilityIDs:  []uuid.UUID{uuid.New()},
+			Type:              horusecEnum.RiskAccepted,
+			Comment:           "test",
+			RiskAcceptedUntil: &until,
+		}
+
+		assert.Error(t, bulk.Validate())
+	})
+}
+
+func TestGetFilterBulkUpdateVulnType(t *testing.T) {
+	t.Run("should return filter scoped to repository", func(t *testing.T) {
+		repositoryID := uuid.New()
+		bulk := &BulkUpdateVulnType{Filter: &VulnFilter{CompanyID: uuid.New(), Severity: severity.High}}
+
+		filter := bulk.GetFilter(repositoryID)
+		assert.Equal(t, repositoryID, filter.RepositoryID)
+		assert.Equal(t, uuid.Nil, filter.CompanyID)
+		assert.Equal(t, severity.High, filter.Severity)
+		assert.NotEqual(t, repositoryID, bulk.Filter.RepositoryID)
+	})
+
+	t.Run("should return filter scoped to repository when nil filter", func(t *testing.T) {
+		repositoryID := uuid.New()
+		bulk := &BulkUpdateVulnType{}
+
+		assert.Equal(t, repositoryID, bulk.GetFilter(repositoryID).RepositoryID)
+	})
+}
+
+func TestSetAccountIDBulkUpdateVulnType(t *testing.T) {
+	t.Run("should set account id and parse to bytes", func(t *testing.T) {
+		accountID := uuid.New()
+		bulk := (&BulkUpdateVulnType{}).SetAccountID(accountID)
+
+		assert.Equal(t, accountID, bulk.AccountID)
+		assert.NotEmpty(t, bulk.ToBytes())
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+)
+
+type RiskAcceptExpiration struct {
+	VulnerabilityID   uuid.UUID         `json:"vulnerabilityID" gorm:"Column:vulnerability_id"`
+	CompanyID         uuid.UUID         `json:"companyID" gorm:"Column:company_id"`
+	RepositoryID      uuid.UUID         `json:"repositoryID" gorm:"Column:repository_id"`
+	RepositoryName    string            `json:"repositoryName" gorm:"Column:repository_name"`
+	VulnHash          string            `json:"vulnHash" gorm:"Column:vuln_hash"`
+	File              string            `json:"file" gorm:"Column:file"`
+	Line              string            `json:"line" gorm:"Column:line"`
+	Severity          severity.Severity `json:"severity" gorm:"Column:severity"`
+	RiskAcceptedUntil time.Time         `json:"riskAcceptedUntil" gorm:"Column:risk_accepted_until"`
+}
+
+func GroupRiskAcceptExpirationsByRepository(
+	expirations []RiskAcceptExpiration) map[uuid.UUID][]RiskAcceptExpiration {
+	grouped := map[uuid.UUID][]RiskAcceptExpiration{}
+	for index := range expirations {
+		repositoryID := expirations[index].RepositoryID
+		grouped[repositoryID] = append(grouped[repositoryID], expirations[index])
+	}
+
+	return grouped
+}
+
+func GetRiskAcceptExpirationIDs(expirations []RiskAcceptExpiration) (vulnerabilityIDs []uuid.UUID) {
+	for index := range expirations {
+		vulnerabilityIDs = append(vulnerabilityIDs, expirations[index].VulnerabilityID)
+	}
+
+	return vulnerabilityIDs
+}
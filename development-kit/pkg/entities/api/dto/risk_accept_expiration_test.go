@@ -0,0 +1,51 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupRiskAcceptExpirationsByRepository(t *testing.T) {
+	t.Run("should group expirations by repository", func(t *testing.T) {
+		firstRepositoryID, secondRepositoryID := uuid.New(), uuid.New()
+		expirations := []RiskAcceptExpiration{
+			{RepositoryID: firstRepositoryID},
+			{RepositoryID: secondRepositoryID},
+			{RepositoryID: firstRepositoryID},
+		}
+
+		grouped := GroupRiskAcceptExpirationsByRepository(expirations)
+		assert.Len(t, grouped, 2)
+		assert.Len(t, grouped[firstRepositoryID], 2)
+		assert.Len(t, grouped[secondRepositoryID], 1)
+	})
+}
+
+func TestGetRiskAcceptExpirationIDs(t *testing.T) {
+	t.Run("should return vulnerability ids of expirations", func(t *testing.T) {
+		vulnerabilityID := uuid.New()
+
+		ids := GetRiskAcceptExpirationIDs([]RiskAcceptExpiration{{VulnerabilityID: vulnerabilityID}})
+		assert.Equal(t, []uuid.UUID{vulnerabilityID}, ids)
+	})
+
+	t.Run("should return empty when no expirations", func(t *testing.T) {
+		assert.Empty(t, GetRiskAcceptExpirationIDs(nil))
+	})
+}
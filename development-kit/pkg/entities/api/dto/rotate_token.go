@@ -0,0 +1,47 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type RotateToken struct {
+	GracePeriodInHours int        `json:"gracePeriodInHours"`
+	ExpiresAt          *time.Time `json:"expiresAt"`
+}
+
+func (r *RotateToken) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.GracePeriodInHours, validation.Min(0), validation.Max(api.TokenMaxGracePeriodInHours)),
+		validation.Field(&r.ExpiresAt, validation.Min(time.Now())),
+	)
+}
+
+func (r *RotateToken) GetGracePeriod() time.Duration {
+	return time.Duration(r.GracePeriodInHours) * time.Hour
+}
+
+// GetExpiresAt returns zero when not informed, so the token receives the default expiration
+func (r *RotateToken) GetExpiresAt() time.Time {
+	if r.ExpiresAt == nil {
+		return time.Time{}
+	}
+
+	return *r.ExpiresAt
+}
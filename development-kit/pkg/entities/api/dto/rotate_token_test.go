@@ -0,0 +1,51 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateTokenValidate(t *testing.T) {
+	t.Run("should return no errors when valid", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		assert.NoError(t, (&RotateToken{GracePeriodInHours: 24, ExpiresAt: &expiresAt}).Validate())
+		assert.NoError(t, (&RotateToken{}).Validate())
+	})
+
+	t.Run("should return error when invalid grace period", func(t *testing.T) {
+		assert.Error(t, (&RotateToken{GracePeriodInHours: -1}).Validate())
+		assert.Error(t, (&RotateToken{GracePeriodInHours: 721}).Validate())
+	})
+
+	t.Run("should return error when expires at is in the past", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+		assert.Error(t, (&RotateToken{ExpiresAt: &expiresAt}).Validate())
+	})
+}
+
+func TestRotateTokenGetters(t *testing.T) {
+	t.Run("should return grace period and expires at", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		rotateToken := &RotateToken{GracePeriodInHours: 2, ExpiresAt: &expiresAt}
+
+		assert.Equal(t, 2*time.Hour, rotateToken.GetGracePeriod())
+		assert.Equal(t, expiresAt, rotateToken.GetExpiresAt())
+		assert.True(t, (&RotateToken{}).GetExpiresAt().IsZero())
+	})
+}
@@ -16,20 +16,41 @@ package dto
 
 import (
 	"encoding/json"
+	"time"
+
 	horusecEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
 )
 
 type UpdateVulnType struct {
-	Type horusecEnums.VulnerabilityType `json:"type"`
+	Type              horusecEnums.VulnerabilityType `json:"type"`
+	Comment           string                         `json:"comment"`
+	RiskAcceptedUntil *time.Time                     `json:"riskAcceptedUntil"`
+	AccountID         uuid.UUID                      `json:"-"`
 }
 
 func (u *UpdateVulnType) Validate() error {
 	return validation.ValidateStruct(u,
 		validation.Field(&u.Type, validation.In(u.TypeValues()...)),
+		validation.Field(&u.RiskAcceptedUntil, validation.When(u.Type != horusecEnums.RiskAccepted, validation.Nil),
+			validation.Min(time.Now())),
 	)
 }
 
+func (u *UpdateVulnType) GetType() horusecEnums.VulnerabilityType {
+	if u.Type == "" {
+		return horusecEnums.Vulnerability
+	}
+
+	return u.Type
+}
+
+func (u *UpdateVulnType) SetAccountID(accountID uuid.UUID) *UpdateVulnType {
+	u.AccountID = accountID
+	return u
+}
+
 func (u *UpdateVulnType) ToBytes() []byte {
 	content, _ := json.Marshal(u)
 	return content
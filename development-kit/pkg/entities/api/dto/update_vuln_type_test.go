@@ -16,8 +16,10 @@ package dto
 
 import (
 	horusecEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestValidateUpdateVulnType(t *testing.T) {
@@ -47,3 +49,42 @@ func TestValidateUpdateVulnType(t *testing.T) {
 		assert.NotEmpty(t, updateManagementData.ToBytes())
 	})
 }
+
+func TestSetAccountIDUpdateVulnType(t *testing.T) {
+	t.Run("should set account id", func(t *testing.T) {
+		accountID := uuid.New()
+		updateData := (&UpdateVulnType{}).SetAccountID(accountID)
+
+		assert.Equal(t, accountID, updateData.AccountID)
+	})
+}
+
+func TestValidateRiskAcceptedUntilUpdateVulnType(t *testing.T) {
+	t.Run("should return no error when risk accepted until future date", func(t *testing.T) {
+		until := time.Now().Add(time.Hour)
+		updateData := &UpdateVulnType{Type: horusecEnum.RiskAccepted, RiskAcceptedUntil: &until}
+
+		assert.NoError(t, updateData.Validate())
+	})
+
+	t.Run("should return error when risk accepted until past date", func(t *testing.T) {
+		until := time.Now().Add(-time.Hour)
+		updateData := &UpdateVulnType{Type: horusecEnum.RiskAccepted, RiskAcceptedUntil: &until}
+
+		assert.Error(t, updateData.Validate())
+	})
+
+	t.Run("should return error when risk accepted until and other type", func(t *testing.T) {
+		until := time.Now().Add(time.Hour)
+		updateData := &UpdateVulnType{Type: horusecEnum.FalsePositive, RiskAcceptedUntil: &until}
+
+		assert.Error(t, updateData.Validate())
+	})
+}
+
+func TestGetTypeUpdateVulnType(t *testing.T) {
+	t.Run("should return vulnerability when empty type", func(t *testing.T) {
+		assert.Equal(t, horusecEnum.Vulnerability, (&UpdateVulnType{}).GetType())
+		assert.Equal(t, horusecEnum.Corrected, (&UpdateVulnType{Type: horusecEnum.Corrected}).GetType())
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	exportEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/export"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type VulnExportFilter struct {
+	VulnFilter
+	Format      exportEnums.Format
+	InitialDate time.Time
+	FinalDate   time.Time
+}
+
+type VulnExport struct {
+	RepositoryID   uuid.UUID `json:"repositoryID" gorm:"Column:repository_id"`
+	RepositoryName string    `json:"repositoryName" gorm:"Column:repository_name"`
+	horusec.Vulnerability
+}
+
+func (v *VulnExportFilter) Validate() error {
+	return validation.ValidateStruct(v,
+		validation.Field(&v.Format, validation.Required, validation.In(v.FormatValues()...)),
+		validation.Field(&v.FinalDate, validation.When(!v.InitialDate.IsZero() && !v.FinalDate.IsZero(),
+			validation.Min(v.InitialDate))),
+	)
+}
+
+func (v VulnExportFilter) FormatValues() (values []interface{}) {
+	for _, format := range v.Format.Values() {
+		values = append(values, format)
+	}
+
+	return values
+}
+
+func (v *VulnExport) ToBytes() []byte {
+	content, _ := json.Marshal(v)
+	return content
+}
+
+func (v *VulnExport) GetCSVHeader() []string {
+	return []string{"repositoryID", "repositoryName", "vulnerabilityID", "vulnHash", "type", "severity",
+		"confidence", "securityTool", "language", "file", "line", "column", "code", "details", "commitAuthor",
+		"commitEmail", "commitHash", "commitMessage", "commitDate"}
+}
+
+func (v *VulnExport) ToCSVRecord() []string {
+	return []string{v.RepositoryID.String(), v.RepositoryName, v.VulnerabilityID.String(), v.VulnHash,
+		v.Type.ToString(), v.Severity.ToString(), v.Confidence, v.SecurityTool.ToString(), v.Language.ToString(),
+		v.File, v.Line, v.Column, v.Code, v.Details, v.CommitAuthor, v.CommitEmail, v.CommitHash,
+		v.CommitMessage, v.CommitDate}
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	exportEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/export"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVulnExportFilter(t *testing.T) {
+	t.Run("should return no error when valid data", func(t *testing.T) {
+		filter := &VulnExportFilter{
+			VulnFilter:  VulnFilter{CompanyID: uuid.New()},
+			Format:      exportEnums.CSV,
+			InitialDate: time.Now().Add(-time.Hour),
+			FinalDate:   time.Now(),
+		}
+
+		assert.NoError(t, filter.Validate())
+	})
+
+	t.Run("should return error when invalid format", func(t *testing.T) {
+		filter := &VulnExportFilter{Format: "xlsx"}
+
+		assert.Error(t, filter.Validate())
+	})
+
+	t.Run("should return error when final date is before initial date", func(t *testing.T) {
+		filter := &VulnExportFilter{
+			Format:      exportEnums.NDJSON,
+			InitialDate: time.Now(),
+			FinalDate:   time.Now().Add(-time.Hour),
+		}
+
+		assert.Error(t, filter.Validate())
+	})
+}
+
+func TestVulnExport(t *testing.T) {
+	vulnExport := &VulnExport{
+		RepositoryID:   uuid.New(),
+		RepositoryName: "test",
+		Vulnerability:  horusec.Vulnerability{VulnerabilityID: uuid.New(), Severity: severity.High},
+	}
+
+	t.Run("should parse to bytes", func(t *testing.T) {
+		assert.NotEmpty(t, vulnExport.ToBytes())
+	})
+
+	t.Run("should return csv record with the same size of header", func(t *testing.T) {
+		assert.Len(t, vulnExport.ToCSVRecord(), len(vulnExport.GetCSVHeader()))
+		assert.Equal(t, "HIGH", vulnExport.ToCSVRecord()[5])
+	})
+}
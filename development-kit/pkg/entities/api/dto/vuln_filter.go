@@ -0,0 +1,84 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"time"
+
+	horusecEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/languages"
+	managementEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/management"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/tools"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type VulnFilter struct {
+	CompanyID    uuid.UUID                      `json:"-"`
+	RepositoryID uuid.UUID                      `json:"-"`
+	Severity     severity.Severity              `json:"vulnSeverity"`
+	Type         horusecEnums.VulnerabilityType `json:"vulnType"`
+	VulnHash     string                         `json:"vulnHash"`
+	SecurityTool tools.Tool                     `json:"securityTool"`
+	Language     languages.Language             `json:"language"`
+	FilePrefix   string                         `json:"filePrefix"`
+	CommitAuthor string                         `json:"commitAuthor"`
+	Details      string                         `json:"details"`
+}
+
+type VulnManagementFilter struct {
+	VulnFilter
+	Page                 int
+	Size                 int
+	FirstSeenInitialDate time.Time
+	FirstSeenFinalDate   time.Time
+	SortBy               managementEnums.SortBy
+}
+
+func (v *VulnFilter) IsCompanyScope() bool {
+	return v.RepositoryID == uuid.Nil
+}
+
+// IsEmpty returns true when no criteria is set, the scope of company or repository is not a criteria
+func (v *VulnFilter) IsEmpty() bool {
+	return v.Severity == "" && v.Type == "" && v.VulnHash == "" && v.SecurityTool == "" && v.Language == "" &&
+		v.FilePrefix == "" && v.CommitAuthor == "" && v.Details == ""
+}
+
+func (v *VulnManagementFilter) Validate() error {
+	return validation.ValidateStruct(v,
+		validation.Field(&v.SortBy, validation.In(v.SortByValues()...)),
+		validation.Field(&v.FirstSeenFinalDate, validation.When(
+			!v.FirstSeenInitialDate.IsZero() && !v.FirstSeenFinalDate.IsZero(),
+			validation.Min(v.FirstSeenInitialDate))),
+	)
+}
+
+func (v VulnManagementFilter) SortByValues() (values []interface{}) {
+	for _, sortBy := range v.SortBy.Values() {
+		values = append(values, sortBy)
+	}
+
+	return values
+}
+
+func (v *VulnManagementFilter) GetSortBy() managementEnums.SortBy {
+	if v.SortBy == "" {
+		return managementEnums.Severity
+	}
+
+	return v.SortBy
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"testing"
+	"time"
+
+	managementEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/management"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsCompanyScope(t *testing.T) {
+	t.Run("should return true when repository id is not set", func(t *testing.T) {
+		assert.True(t, (&VulnFilter{CompanyID: uuid.New()}).IsCompanyScope())
+	})
+
+	t.Run("should return false when repository id is set", func(t *testing.T) {
+		assert.False(t, (&VulnFilter{RepositoryID: uuid.New()}).IsCompanyScope())
+	})
+}
+
+func TestIsEmptyVulnFilter(t *testing.T) {
+	t.Run("should return true when only the scope is set", func(t *testing.T) {
+		assert.True(t, (&VulnFilter{CompanyID: uuid.New(), RepositoryID: uuid.New()}).IsEmpty())
+	})
+
+	t.Run("should return false when some criteria is set", func(t *testing.T) {
+		assert.False(t, (&VulnFilter{FilePrefix: "src/"}).IsEmpty())
+	})
+}
+
+func TestValidateVulnManagementFilter(t *testing.T) {
+	t.Run("should return no error when valid data", func(t *testing.T) {
+		filter := &VulnManagementFilter{
+			SortBy:               managementEnums.Age,
+			FirstSeenInitialDate: time.Now().Add(-time.Hour),
+			FirstSeenFinalDate:   time.Now(),
+		}
+
+		assert.NoError(t, filter.Validate())
+	})
+
+	t.Run("should return no error when sort is empty", func(t *testing.T) {
+		assert.NoError(t, (&VulnManagementFilter{}).Validate())
+	})
+
+	t.Run("should return error when invalid sort", func(t *testing.T) {
+		assert.Error(t, (&VulnManagementFilter{SortBy: "line"}).Validate())
+	})
+
+	t.Run("should return error when final date is before initial date", func(t *testing.T) {
+		filter := &VulnManagementFilter{
+			FirstSeenInitialDate: time.Now(),
+			FirstSeenFinalDate:   time.Now().Add(-time.Hour),
+		}
+
+		assert.Error(t, filter.Validate())
+	})
+}
+
+func TestGetSortBy(t *testing.T) {
+	t.Run("should return severity as default sort", func(t *testing.T) {
+		assert.Equal(t, managementEnums.Severity, (&VulnManagementFilter{}).GetSortBy())
+	})
+
+	t.Run("should return sort when set", func(t *testing.T) {
+		assert.Equal(t, managementEnums.File, (&VulnManagementFilter{SortBy: managementEnums.File}).GetSortBy())
+	})
+}
@@ -15,6 +15,8 @@
 package dto
 
 import (
+	"time"
+
 	horusecEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/languages"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
@@ -28,16 +30,18 @@ type VulnManagement struct {
 }
 
 type Data struct {
-	VulnerabilityID uuid.UUID                      `json:"vulnerabilityID"`
-	Type            horusecEnums.VulnerabilityType `json:"type"`
-	VulnHash        string                         `json:"vulnHash"`
-	Line            string                         `json:"line"`
-	Column          string                         `json:"column"`
-	Confidence      string                         `json:"confidence"`
-	File            string                         `json:"file"`
-	Code            string                         `json:"code"`
-	Details         string                         `json:"details"`
-	SecurityTool    tools.Tool                     `json:"securityTool"`
-	Language        languages.Language             `json:"language"`
-	Severity        severity.Severity              `json:"severity"`
+	VulnerabilityID   uuid.UUID                      `json:"vulnerabilityID"`
+	Type              horusecEnums.VulnerabilityType `json:"type"`
+	VulnHash          string                         `json:"vulnHash"`
+	Line              string                         `json:"line"`
+	Column            string                         `json:"column"`
+	Confidence        string                         `json:"confidence"`
+	File              string                         `json:"file"`
+	Code              string                         `json:"code"`
+	Details           string                         `json:"details"`
+	SecurityTool      tools.Tool                     `json:"securityTool"`
+	Language          languages.Language             `json:"language"`
+	Severity          severity.Severity              `json:"severity"`
+	CommitAuthor      string                         `json:"commitAuthor"`
+	RiskAcceptedUntil *time.Time                     `json:"riskAcceptedUntil,omitempty"`
 }
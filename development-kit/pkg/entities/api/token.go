@@ -16,24 +16,43 @@ package api
 
 import (
 	"encoding/json"
-	"github.com/ZupIT/horusec/development-kit/pkg/utils/hash"
-	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"path"
 	"time"
 
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/hash"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	TokenDefaultDurationInMonths = 3
+	TokenMaxGracePeriodInHours   = 720
 )
 
 type Token struct {
-	TokenID      uuid.UUID  `json:"tokenID" swaggerignore:"true" gorm:"Column:token_id"`
-	Description  string     `json:"description" gorm:"Column:description"`
-	RepositoryID *uuid.UUID `json:"repositoryID" swaggerignore:"true" gorm:"Column:repository_id"`
-	CompanyID    uuid.UUID  `json:"companyID" swaggerignore:"true" gorm:"Column:company_id"`
-	SuffixValue  string     `json:"suffixValue" swaggerignore:"true" gorm:"Column:suffix_value"`
-	Value        string     `json:"value" swaggerignore:"true" gorm:"Column:value"`
-	CreatedAt    time.Time  `json:"createdAt" swaggerignore:"true" gorm:"Column:created_at"`
-	ExpiresAt    time.Time  `json:"expiresAt" swaggerignore:"true" gorm:"Column:expires_at"`
-	key          uuid.UUID  `gorm:"-"`
+	TokenID                uuid.UUID  `json:"tokenID" swaggerignore:"true" gorm:"Column:token_id"`
+	Description            string     `json:"description" gorm:"Column:description"`
+	RepositoryID           *uuid.UUID `json:"repositoryID" swaggerignore:"true" gorm:"Column:repository_id"`
+	CompanyID              uuid.UUID  `json:"companyID" swaggerignore:"true" gorm:"Column:company_id"`
+	SuffixValue            string     `json:"suffixValue" swaggerignore:"true" gorm:"Column:suffix_value"`
+	Value                  string     `json:"value" swaggerignore:"true" gorm:"Column:value"`
+	CreatedAt              time.Time  `json:"createdAt" swaggerignore:"true" gorm:"Column:created_at"`
+	ExpiresAt              time.Time  `json:"expiresAt" gorm:"Column:expires_at"`
+	NeverExpires           bool       `json:"neverExpires" gorm:"Column:never_expires"`
+	ConfirmNeverExpires    bool       `json:"confirmNeverExpires" gorm:"-"`
+	IsExpirationWarned     bool       `json:"-" gorm:"Column:is_expiration_warned"`
+	PreviousValue          string     `json:"-" gorm:"Column:previous_value"`
+	PreviousValueExpiresAt *time.Time `json:"previousValueExpiresAt" swaggerignore:"true" gorm:"Column:previous_value_expires_at"`
+	LastUsedAt             *time.Time `json:"lastUsedAt" swaggerignore:"true" gorm:"Column:last_used_at"`
+	LastUsedIP             string     `json:"lastUsedIP" swaggerignore:"true" gorm:"Column:last_used_ip"`
+	// AllowedRepositoryNames and AllowedBranches accept patterns like "team-*", empty allows any value
+	AllowedRepositoryNames pq.StringArray `json:"allowedRepositoryNames" swaggertype:"array,string" gorm:"Column:allowed_repository_names"` //nolint:lll gorm usage
+	AllowedBranches        pq.StringArray `json:"allowedBranches" swaggertype:"array,string" gorm:"Column:allowed_branches"`
+	DisableAutoCreate      bool           `json:"disableAutoCreate" gorm:"Column:disable_auto_create"`
+	key                    uuid.UUID      `gorm:"-"`
 }
 
 func (t *Token) TableName() string {
@@ -55,13 +74,20 @@ func (t *Token) ToString() string {
 
 func (t *Token) Map() map[string]interface{} {
 	return map[string]interface{}{
-		"tokenID":      t.TokenID,
-		"description":  t.Description,
-		"repositoryID": t.RepositoryID,
-		"companyID":    t.CompanyID,
-		"suffixValue":  t.SuffixValue,
-		"value":        t.Value,
-		"createdAt":    t.CreatedAt,
+		"tokenID":                t.TokenID,
+		"description":            t.Description,
+		"repositoryID":           t.RepositoryID,
+		"companyID":              t.CompanyID,
+		"suffixValue":            t.SuffixValue,
+		"value":                  t.Value,
+		"createdAt":              t.CreatedAt,
+		"expiresAt":              t.ExpiresAt,
+		"neverExpires":           t.NeverExpires,
+		"lastUsedAt":             t.LastUsedAt,
+		"lastUsedIP":             t.LastUsedIP,
+		"allowedRepositoryNames": t.AllowedRepositoryNames,
+		"allowedBranches":        t.AllowedBranches,
+		"disableAutoCreate":      t.DisableAutoCreate,
 	}
 }
 
@@ -73,18 +99,113 @@ func (t *Token) Validate(isRequiredRepositoryID bool) error {
 	return validation.ValidateStruct(t,
 		validation.Field(&t.Description, validation.Required),
 		validation.Field(&t.CompanyID, validation.Required),
+		validation.Field(&t.ExpiresAt, validation.By(validateExpiresAt)),
+		validation.Field(&t.NeverExpires, validation.By(t.validateNeverExpires)),
+		validation.Field(&t.AllowedRepositoryNames, validation.Each(validation.Required, validation.By(validatePattern))),
+		validation.Field(&t.AllowedBranches, validation.Each(validation.Required, validation.By(validatePattern))),
 		validationRepositoryID,
 	)
 }
 
+func validatePattern(value interface{}) error {
+	if _, err := path.Match(value.(string), ""); err != nil {
+		return errors.ErrorInvalidTokenPattern
+	}
+
+	return nil
+}
+
+func validateExpiresAt(value interface{}) error {
+	if expiresAt := value.(time.Time); !expiresAt.IsZero() && expiresAt.Before(time.Now()) {
+		return errors.ErrorInvalidTokenExpiration
+	}
+
+	return nil
+}
+
+// validateNeverExpires avoids creating tokens without expiration by mistake, it needs an explicit confirmation
+func (t *Token) validateNeverExpires(value interface{}) error {
+	if value.(bool) && !t.ConfirmNeverExpires {
+		return errors.ErrorNeverExpiresNotConfirmed
+	}
+
+	return nil
+}
+
+// SetCreateData sets the default expiration of three months when the expiration date is not informed
 func (t *Token) SetCreateData() *Token {
 	t.CreatedAt = time.Now()
 	t.TokenID = uuid.New()
-	t.ExpiresAt = t.CreatedAt.AddDate(0, 3, 0)
+	t.setExpiresAt(t.ExpiresAt)
 
 	return t
 }
 
+func (t *Token) setExpiresAt(expiresAt time.Time) {
+	t.IsExpirationWarned = false
+	switch {
+	case t.NeverExpires:
+		t.ExpiresAt = time.Time{}
+	case expiresAt.IsZero():
+		t.ExpiresAt = time.Now().AddDate(0, TokenDefaultDurationInMonths, 0)
+	default:
+		t.ExpiresAt = expiresAt
+	}
+}
+
+// Rotate replaces the key keeping the current value valid until the end of the grace period
+func (t *Token) Rotate(key uuid.UUID, gracePeriod time.Duration, expiresAt time.Time) *Token {
+	previousValueExpiresAt := time.Now().Add(gracePeriod)
+	t.PreviousValue = t.Value
+	t.PreviousValueExpiresAt = &previousValueExpiresAt
+	t.setExpiresAt(expiresAt)
+
+	return t.SetKey(key)
+}
+
+func (t *Token) IsExpired() bool {
+	return !t.NeverExpires && t.ExpiresAt.Before(time.Now())
+}
+
+// ValidateAnalysisConstraints checks the repository name and branch sent with an analysis against the token
+// constraints, the repository name is only checked by company tokens because repository tokens have a fixed repository
+func (t *Token) ValidateAnalysisConstraints(repositoryName, branch string) error {
+	if t.RepositoryID == nil && !matchAnyPattern(t.AllowedRepositoryNames, repositoryName) {
+		return errors.ErrorTokenRepositoryNotAllowed
+	}
+
+	if !matchAnyPattern(t.AllowedBranches, branch) {
+		return errors.ErrorTokenBranchNotAllowed
+	}
+
+	return nil
+}
+
+func matchAnyPattern(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *Token) ToRotateMap() map[string]interface{} {
+	return map[string]interface{}{
+		"value":                     t.Value,
+		"suffix_value":              t.SuffixValue,
+		"previous_value":            t.PreviousValue,
+		"previous_value_expires_at": t.PreviousValueExpiresAt,
+		"expires_at":                t.ExpiresAt,
+		"is_expiration_warned":      t.IsExpirationWarned,
+	}
+}
+
 func (t *Token) SetKey(value uuid.UUID) *Token {
 	t.key = value
 	t.setHashValue()
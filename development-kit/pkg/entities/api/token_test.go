@@ -17,7 +17,9 @@ package api
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -97,6 +99,20 @@ func TestTokenValidate(t *testing.T) {
 		}
 		assert.Nil(t, token.Validate(false))
 	})
+
+	t.Run("validate should return an error when allowed names have invalid patterns", func(t *testing.T) {
+		token := &Token{CompanyID: uuid.New(), Description: "test", AllowedRepositoryNames: []string{"team-["}}
+		assert.Error(t, token.Validate(false))
+
+		token = &Token{CompanyID: uuid.New(), Description: "test", AllowedBranches: []string{""}}
+		assert.Error(t, token.Validate(false))
+	})
+
+	t.Run("validate should return nil when allowed names have valid patterns", func(t *testing.T) {
+		token := &Token{CompanyID: uuid.New(), Description: "test",
+			AllowedRepositoryNames: []string{"team-*"}, AllowedBranches: []string{"main", "release/*"}}
+		assert.NoError(t, token.Validate(false))
+	})
 }
 
 func TestTokenSetHashValue(t *testing.T) {
@@ -142,3 +158,89 @@ func TestTokenGetKey(t *testing.T) {
 		assert.NotEmpty(t, token.GetKey())
 	})
 }
+
+func TestTokenExpiration(t *testing.T) {
+	t.Run("should set default expiration of three months", func(t *testing.T) {
+		token := (&Token{}).SetCreateData()
+
+		assert.WithinDuration(t, time.Now().AddDate(0, 3, 0), token.ExpiresAt, time.Minute)
+		assert.False(t, token.IsExpired())
+	})
+
+	t.Run("should keep informed expiration", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		token := (&Token{ExpiresAt: expiresAt}).SetCreateData()
+
+		assert.Equal(t, expiresAt, token.ExpiresAt)
+	})
+
+	t.Run("should never expire when never expires", func(t *testing.T) {
+		token := (&Token{NeverExpires: true}).SetCreateData()
+
+		assert.True(t, token.ExpiresAt.IsZero())
+		assert.False(t, token.IsExpired())
+	})
+
+	t.Run("should return expired when expires at is in the past", func(t *testing.T) {
+		assert.True(t, (&Token{ExpiresAt: time.Now().Add(-time.Hour)}).IsExpired())
+	})
+
+	t.Run("should return error when never expires is not confirmed", func(t *testing.T) {
+		token := &Token{CompanyID: uuid.New(), Description: "test", NeverExpires: true}
+		assert.Error(t, token.Validate(false))
+
+		token.ConfirmNeverExpires = true
+		assert.NoError(t, token.Validate(false))
+	})
+
+	t.Run("should return error when expires at is in the past", func(t *testing.T) {
+		token := &Token{CompanyID: uuid.New(), Description: "test", ExpiresAt: time.Now().Add(-time.Hour)}
+		assert.Error(t, token.Validate(false))
+	})
+}
+
+func TestTokenRotate(t *testing.T) {
+	t.Run("should keep the previous value valid during the grace period", func(t *testing.T) {
+		token := (&Token{}).SetKey(uuid.New())
+		previousValue := token.Value
+
+		token.Rotate(uuid.New(), time.Hour, time.Time{})
+
+		assert.NotEqual(t, previousValue, token.Value)
+		assert.Equal(t, previousValue, token.PreviousValue)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), *token.PreviousValueExpiresAt, time.Minute)
+		assert.Equal(t, token.Value, token.ToRotateMap()["value"])
+	})
+}
+
+func TestTokenValidateAnalysisConstraints(t *testing.T) {
+	t.Run("should allow any repository and branch without constraints", func(t *testing.T) {
+		assert.NoError(t, (&Token{}).ValidateAnalysisConstraints("test", ""))
+	})
+
+	t.Run("should allow repository and branch that match the patterns", func(t *testing.T) {
+		token := &Token{AllowedRepositoryNames: []string{"other", "team-*"}, AllowedBranches: []string{"release/*"}}
+
+		assert.NoError(t, token.ValidateAnalysisConstraints("team-api", "release/1.0"))
+	})
+
+	t.Run("should return error when repository does not match the patterns", func(t *testing.T) {
+		token := &Token{AllowedRepositoryNames: []string{"team-*"}}
+
+		assert.Equal(t, errors.ErrorTokenRepositoryNotAllowed, token.ValidateAnalysisConstraints("test", ""))
+	})
+
+	t.Run("should ignore repository names of repository tokens", func(t *testing.T) {
+		repositoryID := uuid.New()
+		token := &Token{RepositoryID: &repositoryID, AllowedRepositoryNames: []string{"team-*"}}
+
+		assert.NoError(t, token.ValidateAnalysisConstraints("test", ""))
+	})
+
+	t.Run("should return error when branch does not match the patterns or is empty", func(t *testing.T) {
+		token := &Token{AllowedBranches: []string{"main"}}
+
+		assert.Equal(t, errors.ErrorTokenBranchNotAllowed, token.ValidateAnalysisConstraints("test", "feature"))
+		assert.Equal(t, errors.ErrorTokenBranchNotAllowed, token.ValidateAnalysisConstraints("test", ""))
+	})
+}
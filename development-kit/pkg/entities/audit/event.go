@@ -0,0 +1,90 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	"github.com/google/uuid"
+)
+
+// Event records who did a security relevant change, the company and repository ids are empty when the change is
+// outside of them
+type Event struct {
+	EventID      uuid.UUID         `json:"eventID" gorm:"primary_key"`
+	Action       auditEnums.Action `json:"action"`
+	AccountID    uuid.UUID         `json:"accountID"`
+	CompanyID    uuid.UUID         `json:"companyID"`
+	RepositoryID uuid.UUID         `json:"repositoryID"`
+	ResourceID   string            `json:"resourceID"`
+	IP           string            `json:"ip"`
+	Details      string            `json:"details"`
+	CreatedAt    time.Time         `json:"createdAt"`
+}
+
+type Events struct {
+	TotalItems int     `json:"totalItems"`
+	Data       []Event `json:"data"`
+}
+
+func NewEvent(action auditEnums.Action, accountID uuid.UUID) *Event {
+	return &Event{
+		EventID:   uuid.New(),
+		Action:    action,
+		AccountID: accountID,
+		CreatedAt: time.Now(),
+	}
+}
+
+func NewEventFromBytes(content []byte) (*Event, error) {
+	event := &Event{}
+	return event, json.Unmarshal(content, event)
+}
+
+func (e *Event) GetTable() string {
+	return "audit_events"
+}
+
+func (e *Event) SetCompanyID(companyID uuid.UUID) *Event {
+	e.CompanyID = companyID
+	return e
+}
+
+func (e *Event) SetRepositoryID(repositoryID uuid.UUID) *Event {
+	e.RepositoryID = repositoryID
+	return e
+}
+
+func (e *Event) SetResourceID(resourceID string) *Event {
+	e.ResourceID = resourceID
+	return e
+}
+
+func (e *Event) SetIP(ip string) *Event {
+	e.IP = ip
+	return e
+}
+
+func (e *Event) SetDetails(details string) *Event {
+	e.Details = details
+	return e
+}
+
+func (e *Event) ToBytes() []byte {
+	content, _ := json.Marshal(e)
+	return content
+}
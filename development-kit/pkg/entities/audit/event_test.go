@@ -0,0 +1,99 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"testing"
+	"time"
+
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEvent(t *testing.T) {
+	t.Run("should create event with the data", func(t *testing.T) {
+		accountID := uuid.New()
+		companyID := uuid.New()
+		repositoryID := uuid.New()
+
+		event := NewEvent(auditEnums.TokenCreate, accountID).SetCompanyID(companyID).SetRepositoryID(repositoryID).
+			SetResourceID("test").SetIP("127.0.0.1").SetDetails("details")
+
+		assert.NotEqual(t, uuid.Nil, event.EventID)
+		assert.Equal(t, auditEnums.TokenCreate, event.Action)
+		assert.Equal(t, accountID, event.AccountID)
+		assert.Equal(t, companyID, event.CompanyID)
+		assert.Equal(t, repositoryID, event.RepositoryID)
+		assert.Equal(t, "test", event.ResourceID)
+		assert.Equal(t, "127.0.0.1", event.IP)
+		assert.Equal(t, "details", event.Details)
+		assert.False(t, event.CreatedAt.IsZero())
+	})
+}
+
+func TestNewEventFromBytes(t *testing.T) {
+	t.Run("should parse event", func(t *testing.T) {
+		event := NewEvent(auditEnums.TokenDelete, uuid.New())
+
+		result, err := NewEventFromBytes(event.ToBytes())
+
+		assert.NoError(t, err)
+		assert.Equal(t, event.EventID, result.EventID)
+	})
+
+	t.Run("should return error when content is invalid", func(t *testing.T) {
+		_, err := NewEventFromBytes([]byte("test"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetTable(t *testing.T) {
+	t.Run("should return table name", func(t *testing.T) {
+		assert.Equal(t, "audit_events", (&Event{}).GetTable())
+	})
+}
+
+func TestFilterValidate(t *testing.T) {
+	t.Run("should return no error when filter is valid", func(t *testing.T) {
+		filter := &Filter{Action: auditEnums.RepositoryDelete, Size: 50, InitialDate: time.Now(),
+			FinalDate: time.Now().Add(time.Hour)}
+
+		assert.NoError(t, filter.Validate())
+	})
+
+	t.Run("should return error when action is invalid", func(t *testing.T) {
+		assert.Error(t, (&Filter{Action: "test"}).Validate())
+	})
+
+	t.Run("should return error when size is bigger than max", func(t *testing.T) {
+		assert.Error(t, (&Filter{Size: FilterMaxSize + 1}).Validate())
+	})
+
+	t.Run("should return error when final date is before initial date", func(t *testing.T) {
+		assert.Error(t, (&Filter{InitialDate: time.Now(), FinalDate: time.Now().Add(-time.Hour)}).Validate())
+	})
+}
+
+func TestFilterGetSize(t *testing.T) {
+	t.Run("should return default size", func(t *testing.T) {
+		assert.Equal(t, 10, (&Filter{}).GetSize())
+	})
+
+	t.Run("should return informed size", func(t *testing.T) {
+		assert.Equal(t, 30, (&Filter{Size: 30}).GetSize())
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"time"
+
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+const FilterMaxSize = 100
+
+// Filter lists the events of all companies when the company id is empty, only application admins can use it this way
+type Filter struct {
+	CompanyID    uuid.UUID
+	RepositoryID uuid.UUID
+	AccountID    uuid.UUID
+	Action       auditEnums.Action
+	InitialDate  time.Time
+	FinalDate    time.Time
+	Page         int
+	Size         int
+}
+
+func (f *Filter) Validate() error {
+	return validation.ValidateStruct(f,
+		validation.Field(&f.Action, validation.By(f.validateAction)),
+		validation.Field(&f.Page, validation.Min(0)),
+		validation.Field(&f.Size, validation.Min(0), validation.Max(FilterMaxSize)),
+		validation.Field(&f.FinalDate, validation.When(!f.InitialDate.IsZero() && !f.FinalDate.IsZero(),
+			validation.Min(f.InitialDate))),
+	)
+}
+
+func (f *Filter) validateAction(_ interface{}) error {
+	if f.Action != "" && f.Action.IsInvalid() {
+		return validation.NewError("validation_in_invalid", "must be a valid value")
+	}
+
+	return nil
+}
+
+// GetSize returns the default page size when it is not informed
+func (f *Filter) GetSize() int {
+	if f.Size <= 0 {
+		return 10
+	}
+
+	return f.Size
+}
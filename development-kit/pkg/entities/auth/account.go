@@ -22,9 +22,11 @@ import (
 
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/crypto"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/totp"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // nolint
@@ -37,6 +39,14 @@ type Account struct {
 	IsApplicationAdmin bool                         `json:"isApplicationAdmin"`
 	CreatedAt          time.Time                    `json:"createdAt"`
 	UpdatedAt          time.Time                    `json:"updatedAt"`
+	TwoFactorSecret    string                       `json:"-"`
+	IsTwoFactorEnabled bool                         `json:"isTwoFactorEnabled"`
+	TwoFactorLastStep  int64                        `json:"-"`
+	RecoveryCodes      pq.StringArray               `json:"-"`
+	IsDisabled         bool                         `json:"isDisabled"`
+	ExternalID         string                       `json:"-"`
+	OIDCIssuer         string                       `json:"-" gorm:"column:oidc_issuer"`
+	OIDCSubject        string                       `json:"-" gorm:"column:oidc_subject"`
 	Companies          []accountEntities.Company    `gorm:"many2many:account_company;association_jointable_foreignkey:company_id;jointable_foreignkey:account_id"`       // nolint
 	Repositories       []accountEntities.Repository `gorm:"many2many:account_repository;association_jointable_foreignkey:repository_id;jointable_foreignkey:account_id"` // nolint
 }
@@ -100,6 +110,14 @@ func (a *Account) IsAccountConfirmed() error {
 	return nil
 }
 
+func (a *Account) IsAccountEnabled() error {
+	if a.IsDisabled {
+		return errors.ErrorAccountDisabled
+	}
+
+	return nil
+}
+
 func (a *Account) ToBytes() []byte {
 	bytes, _ := json.Marshal(a)
 	return bytes
@@ -107,14 +125,19 @@ func (a *Account) ToBytes() []byte {
 
 func (a *Account) ToMap() map[string]interface{} {
 	return map[string]interface{}{
-		"account_id":           a.AccountID,
-		"password":             a.Password,
-		"email":                a.Email,
-		"username":             a.Username,
-		"is_confirmed":         a.IsConfirmed,
-		"is_application_admin": a.IsApplicationAdmin,
-		"created_at":           a.CreatedAt,
-		"updated_at":           a.UpdatedAt,
+		"account_id":            a.AccountID,
+		"password":              a.Password,
+		"email":                 a.Email,
+		"username":              a.Username,
+		"is_confirmed":          a.IsConfirmed,
+		"is_application_admin":  a.IsApplicationAdmin,
+		"created_at":            a.CreatedAt,
+		"updated_at":            a.UpdatedAt,
+		"two_factor_secret":     a.TwoFactorSecret,
+		"is_two_factor_enabled": a.IsTwoFactorEnabled,
+		"recovery_codes":        a.RecoveryCodes,
+		"is_disabled":           a.IsDisabled,
+		"external_id":           a.ExternalID,
 	}
 }
 
@@ -127,6 +150,31 @@ func (a *Account) ToUpdateMap() map[string]interface{} {
 	}
 }
 
+// ToUpdateProvisioningMap contains the fields managed by the identity provider through scim
+func (a *Account) ToUpdateProvisioningMap() map[string]interface{} {
+	return map[string]interface{}{
+		"email":       a.Email,
+		"username":    a.Username,
+		"updated_at":  a.UpdatedAt,
+		"is_disabled": a.IsDisabled,
+		"external_id": a.ExternalID,
+	}
+}
+
+func (a *Account) ToUpdateOIDCIdentityMap() map[string]interface{} {
+	return map[string]interface{}{
+		"oidc_issuer":  a.OIDCIssuer,
+		"oidc_subject": a.OIDCSubject,
+	}
+}
+
+// SetOIDCIdentity links the account to the subject of the issuer, used to find it on the next logins
+func (a *Account) SetOIDCIdentity(issuer, subject string) *Account {
+	a.OIDCIssuer = issuer
+	a.OIDCSubject = subject
+	return a
+}
+
 func (a *Account) ToUpdatePasswordMap() map[string]interface{} {
 	return map[string]interface{}{
 		"password": a.Password,
@@ -136,3 +184,63 @@ func (a *Account) ToUpdatePasswordMap() map[string]interface{} {
 func (a *Account) IsNotApplicationAdminAccount() bool {
 	return !a.IsApplicationAdmin
 }
+
+func (a *Account) ToUpdateTwoFactorMap() map[string]interface{} {
+	return map[string]interface{}{
+		"two_factor_secret":     a.TwoFactorSecret,
+		"is_two_factor_enabled": a.IsTwoFactorEnabled,
+		"recovery_codes":        a.RecoveryCodes,
+		"two_factor_last_step":  a.TwoFactorLastStep,
+	}
+}
+
+func (a *Account) SetTwoFactorSecret(secret string) *Account {
+	a.TwoFactorSecret = secret
+	a.IsTwoFactorEnabled = false
+	a.TwoFactorLastStep = 0
+	a.RecoveryCodes = pq.StringArray{}
+	return a
+}
+
+// EnableTwoFactor stores only the hash of the recovery codes, the plain ones are shown once to the user.
+func (a *Account) EnableTwoFactor(recoveryCodes []string) *Account {
+	a.IsTwoFactorEnabled = true
+	a.RecoveryCodes = pq.StringArray{}
+	for _, code := range recoveryCodes {
+		hash, _ := crypto.HashPassword(code)
+		a.RecoveryCodes = append(a.RecoveryCodes, hash)
+	}
+
+	return a
+}
+
+func (a *Account) ResetTwoFactor() *Account {
+	return a.SetTwoFactorSecret("")
+}
+
+// UseTotpCode accepts only codes newer than the last accepted one, so a code can not be replayed in its time window.
+func (a *Account) UseTotpCode(code string) bool {
+	if a.TwoFactorSecret == "" {
+		return false
+	}
+
+	step, ok := totp.ValidateStepAt(code, a.TwoFactorSecret, time.Now())
+	if !ok || step <= a.TwoFactorLastStep {
+		return false
+	}
+
+	a.TwoFactorLastStep = step
+	return true
+}
+
+// UseRecoveryCode removes the matching recovery code from the account, so each one is accepted only once.
+func (a *Account) UseRecoveryCode(code string) bool {
+	for index, hash := range a.RecoveryCodes {
+		if crypto.CheckPasswordHash(code, hash) {
+			a.RecoveryCodes = append(a.RecoveryCodes[:index:index], a.RecoveryCodes[index+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
@@ -18,6 +18,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/totp"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -74,6 +76,38 @@ func TestIsAccountConfirmed(t *testing.T) {
 	})
 }
 
+func TestIsAccountEnabled(t *testing.T) {
+	t.Run("should return no error when account is not disabled", func(t *testing.T) {
+		assert.NoError(t, (&Account{}).IsAccountEnabled())
+	})
+
+	t.Run("should return error when account is disabled", func(t *testing.T) {
+		assert.Equal(t, errors.ErrorAccountDisabled, (&Account{IsDisabled: true}).IsAccountEnabled())
+	})
+}
+
+func TestToUpdateProvisioningMap(t *testing.T) {
+	t.Run("should contain the fields managed by the identity provider", func(t *testing.T) {
+		account := &Account{Email: "test@horusec.io", Username: "test", IsDisabled: true, ExternalID: "123"}
+
+		updateMap := account.ToUpdateProvisioningMap()
+
+		assert.Equal(t, true, updateMap["is_disabled"])
+		assert.Equal(t, "123", updateMap["external_id"])
+		assert.NotContains(t, updateMap, "password")
+	})
+}
+
+func TestSetOIDCIdentity(t *testing.T) {
+	t.Run("should set the issuer and subject used to update the account", func(t *testing.T) {
+		updateMap := (&Account{}).SetOIDCIdentity("http://idp.example.com", "f3a1").ToUpdateOIDCIdentityMap()
+
+		assert.Equal(t, "http://idp.example.com", updateMap["oidc_issuer"])
+		assert.Equal(t, "f3a1", updateMap["oidc_subject"])
+		assert.Len(t, updateMap, 2)
+	})
+}
+
 func TestSetAccountData(t *testing.T) {
 	t.Run("should success set account data", func(t *testing.T) {
 		account := &Account{}
@@ -149,3 +183,68 @@ func TestIsNotApplicationAdminAccount(t *testing.T) {
 		assert.True(t, account.IsNotApplicationAdminAccount())
 	})
 }
+
+func TestTwoFactor(t *testing.T) {
+	t.Run("should set secret and reset previous enrollment", func(t *testing.T) {
+		account := &Account{IsTwoFactorEnabled: true, RecoveryCodes: []string{"hash"}}
+
+		account.SetTwoFactorSecret("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+
+		assert.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", account.TwoFactorSecret)
+		assert.False(t, account.IsTwoFactorEnabled)
+		assert.Empty(t, account.RecoveryCodes)
+	})
+
+	t.Run("should enable with hashed recovery codes", func(t *testing.T) {
+		account := &Account{}
+
+		account.EnableTwoFactor([]string{"aaaaa-bbbbb", "ccccc-ddddd"})
+
+		assert.True(t, account.IsTwoFactorEnabled)
+		assert.Len(t, account.RecoveryCodes, 2)
+		assert.NotContains(t, account.RecoveryCodes, "aaaaa-bbbbb")
+	})
+
+	t.Run("should use recovery code only once", func(t *testing.T) {
+		account := (&Account{}).EnableTwoFactor([]string{"aaaaa-bbbbb", "ccccc-ddddd"})
+
+		assert.True(t, account.UseRecoveryCode("ccccc-ddddd"))
+		assert.False(t, account.UseRecoveryCode("ccccc-ddddd"))
+		assert.False(t, account.UseRecoveryCode("invalid"))
+		assert.Len(t, account.RecoveryCodes, 1)
+	})
+
+	t.Run("should validate totp code of the secret", func(t *testing.T) {
+		secret, _ := totp.NewSecret()
+		code, _ := totp.GenerateCode(secret, time.Now())
+		account := (&Account{}).SetTwoFactorSecret(secret)
+
+		assert.False(t, account.UseTotpCode("000000a"))
+		assert.False(t, (&Account{}).UseTotpCode(code))
+		assert.True(t, account.UseTotpCode(code))
+		assert.NotZero(t, account.TwoFactorLastStep)
+	})
+
+	t.Run("should not accept the same totp code twice", func(t *testing.T) {
+		secret, _ := totp.NewSecret()
+		code, _ := totp.GenerateCode(secret, time.Now())
+		previous, _ := totp.GenerateCode(secret, time.Now().Add(-totp.Period*time.Second))
+		account := (&Account{}).SetTwoFactorSecret(secret)
+
+		assert.True(t, account.UseTotpCode(code))
+		assert.False(t, account.UseTotpCode(code))
+		assert.False(t, account.UseTotpCode(previous))
+	})
+
+	t.Run("should reset two factor data", func(t *testing.T) {
+		account := (&Account{TwoFactorSecret: "secret"}).EnableTwoFactor([]string{"aaaaa-bbbbb"})
+
+		account.ResetTwoFactor()
+
+		assert.Empty(t, account.TwoFactorSecret)
+		assert.False(t, account.IsTwoFactorEnabled)
+		assert.Empty(t, account.RecoveryCodes)
+		assert.Zero(t, account.TwoFactorLastStep)
+		assert.Len(t, account.ToUpdateTwoFactorMap(), 4)
+	})
+}
@@ -27,6 +27,7 @@ type AuthorizationData struct {
 	Role         authEnums.HorusecRoles `json:"role"`
 	CompanyID    uuid.UUID              `json:"companyID"`
 	RepositoryID uuid.UUID              `json:"repositoryID"`
+	Permission   authEnums.Permission   `json:"permission"`
 }
 
 func (a *AuthorizationData) Validate() error {
@@ -23,15 +23,28 @@ type Credentials struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 	Otp      string `json:"otp"`
+	Code     string `json:"code"`
+	State    string `json:"state"`
 }
 
 func (c *Credentials) Validate() error {
+	if c.IsAuthorizationCode() {
+		return validation.ValidateStruct(c,
+			validation.Field(&c.Code, validation.Length(1, 2048)),
+			validation.Field(&c.State, validation.Required, validation.Length(1, 255)),
+		)
+	}
+
 	return validation.ValidateStruct(c,
 		validation.Field(&c.Username, validation.Required, validation.Length(1, 255), validation.Required),
 		validation.Field(&c.Password, validation.Length(1, 255), validation.Required),
 	)
 }
 
+func (c *Credentials) IsAuthorizationCode() bool {
+	return c.Code != ""
+}
+
 func (c *Credentials) ToBytes() []byte {
 	content, _ := json.Marshal(c)
 	return content
@@ -35,6 +35,23 @@ func TestValidateCredentials(t *testing.T) {
 		assert.Error(t, credentials.Validate())
 	})
 
+	t.Run("should return no error when valid authorization code", func(t *testing.T) {
+		credentials := &Credentials{
+			Code:  "authorization-code",
+			State: "state",
+		}
+
+		assert.NoError(t, credentials.Validate())
+	})
+
+	t.Run("should return error when authorization code without state", func(t *testing.T) {
+		credentials := &Credentials{
+			Code: "authorization-code",
+		}
+
+		assert.Error(t, credentials.Validate())
+	})
+
 	t.Run("Should not empty when marshal", func(t *testing.T) {
 		credentials := &Credentials{
 			Username: "horus@test.com",
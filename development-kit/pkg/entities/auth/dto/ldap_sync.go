@@ -0,0 +1,71 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	accountEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/account"
+	"github.com/google/uuid"
+)
+
+type LdapSyncAction string
+
+const (
+	LdapSyncAdd    LdapSyncAction = "add"
+	LdapSyncUpdate LdapSyncAction = "update"
+	LdapSyncRemove LdapSyncAction = "remove"
+)
+
+type LdapSyncChange struct {
+	Action       LdapSyncAction    `json:"action"`
+	CompanyID    uuid.UUID         `json:"companyID"`
+	RepositoryID *uuid.UUID        `json:"repositoryID,omitempty"`
+	Name         string            `json:"name"`
+	Username     string            `json:"username"`
+	Role         accountEnums.Role `json:"role,omitempty"`
+	PreviousRole accountEnums.Role `json:"previousRole,omitempty"`
+}
+
+// LdapSyncReport lists what the sync did, or would do when running as dry-run
+type LdapSyncReport struct {
+	DryRun          bool             `json:"dryRun"`
+	CreatedAccounts []string         `json:"createdAccounts"`
+	Changes         []LdapSyncChange `json:"changes"`
+}
+
+func NewLdapSyncReport(dryRun bool) *LdapSyncReport {
+	return &LdapSyncReport{
+		DryRun:          dryRun,
+		CreatedAccounts: []string{},
+		Changes:         []LdapSyncChange{},
+	}
+}
+
+func (l *LdapSyncReport) AddCreatedAccount(username string) {
+	l.CreatedAccounts = append(l.CreatedAccounts, username)
+}
+
+func (l *LdapSyncReport) AddChange(change *LdapSyncChange) {
+	l.Changes = append(l.Changes, *change)
+}
+
+func (l *LdapSyncReport) CountChanges(action LdapSyncAction) (count int) {
+	for index := range l.Changes {
+		if l.Changes[index].Action == action {
+			count++
+		}
+	}
+
+	return count
+}
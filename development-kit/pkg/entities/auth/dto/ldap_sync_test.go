@@ -0,0 +1,45 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"testing"
+
+	accountEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/account"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLdapSyncReport(t *testing.T) {
+	t.Run("should create empty report", func(t *testing.T) {
+		report := NewLdapSyncReport(true)
+
+		assert.True(t, report.DryRun)
+		assert.NotNil(t, report.CreatedAccounts)
+		assert.NotNil(t, report.Changes)
+	})
+
+	t.Run("should add created accounts and changes", func(t *testing.T) {
+		report := NewLdapSyncReport(false)
+
+		report.AddCreatedAccount("test")
+		report.AddChange(&LdapSyncChange{Action: LdapSyncAdd, Username: "test", Role: accountEnums.Admin})
+		report.AddChange(&LdapSyncChange{Action: LdapSyncRemove, Username: "other"})
+
+		assert.Equal(t, []string{"test"}, report.CreatedAccounts)
+		assert.Equal(t, 1, report.CountChanges(LdapSyncAdd))
+		assert.Equal(t, 1, report.CountChanges(LdapSyncRemove))
+		assert.Equal(t, 0, report.CountChanges(LdapSyncUpdate))
+	})
+}
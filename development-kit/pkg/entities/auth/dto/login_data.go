@@ -25,6 +25,7 @@ import (
 type LoginData struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	Otp      string `json:"otp"`
 }
 
 func (l *LoginData) IsInvalid(email, passwordHash string) bool {
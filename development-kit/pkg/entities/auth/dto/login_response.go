@@ -23,4 +23,5 @@ type LoginResponse struct {
 	Email              string    `json:"email"`
 	ExpiresAt          time.Time `json:"expiresAt"`
 	IsApplicationAdmin bool      `json:"isApplicationAdmin"`
+	IsTwoFactorEnabled bool      `json:"isTwoFactorEnabled"`
 }
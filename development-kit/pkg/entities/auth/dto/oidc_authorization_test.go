@@ -0,0 +1,32 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCSessionToBytes(t *testing.T) {
+	t.Run("should parse session to bytes and back", func(t *testing.T) {
+		session := &OIDCSession{CodeVerifier: "verifier", Nonce: "nonce"}
+
+		parsed := &OIDCSession{}
+		assert.NoError(t, json.Unmarshal(session.ToBytes(), parsed))
+		assert.Equal(t, session, parsed)
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"time"
+
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+)
+
+type CreatePersonalToken struct {
+	Description string    `json:"description"`
+	Scopes      []string  `json:"scopes"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+func (c *CreatePersonalToken) ToPersonalToken() *authEntities.PersonalToken {
+	return &authEntities.PersonalToken{
+		Description: c.Description,
+		Scopes:      c.Scopes,
+		ExpiresAt:   c.ExpiresAt,
+	}
+}
+
+// PersonalTokenCreated is the only response that contains the token, it can't be recovered after it
+type PersonalTokenCreated struct {
+	authEntities.PersonalToken
+	Token string `json:"token"`
+}
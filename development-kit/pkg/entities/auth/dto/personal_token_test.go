@@ -0,0 +1,38 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePersonalTokenToPersonalToken(t *testing.T) {
+	t.Run("should parse to personal token entity", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		createPersonalToken := &CreatePersonalToken{
+			Description: "ci",
+			Scopes:      []string{"read:analytics"},
+			ExpiresAt:   expiresAt,
+		}
+
+		personalToken := createPersonalToken.ToPersonalToken()
+		assert.Equal(t, "ci", personalToken.Description)
+		assert.Equal(t, []string{"read:analytics"}, []string(personalToken.Scopes))
+		assert.Equal(t, expiresAt, personalToken.ExpiresAt)
+	})
+}
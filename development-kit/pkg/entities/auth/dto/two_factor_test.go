@@ -0,0 +1,33 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTwoFactorCode(t *testing.T) {
+	t.Run("should return no error when valid totp or recovery code", func(t *testing.T) {
+		assert.NoError(t, (&TwoFactorCode{Code: "123456"}).Validate())
+		assert.NoError(t, (&TwoFactorCode{Code: "a1b2c-d3e4f"}).Validate())
+	})
+
+	t.Run("should return error when invalid code", func(t *testing.T) {
+		assert.Error(t, (&TwoFactorCode{}).Validate())
+		assert.Error(t, (&TwoFactorCode{Code: "123"}).Validate())
+	})
+}
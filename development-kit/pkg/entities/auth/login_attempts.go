@@ -0,0 +1,87 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"time"
+)
+
+const (
+	LoginAttemptsAccountKeyPrefix = "login-attempts:account:"
+	LoginAttemptsIPKeyPrefix      = "login-attempts:ip:"
+	LoginUnlockKeyPrefix          = "login-unlock:"
+	loginMaxDelay                 = time.Minute
+)
+
+// LoginAttempts keeps the failed logins of an account or ip in the login attempts table. Each failure doubles the delay
+// before the next attempt is accepted and after the max attempts every login is denied until the lockout ends.
+type LoginAttempts struct {
+	Key           string     `json:"key" gorm:"primary_key"`
+	Failures      int        `json:"failures"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt"`
+	LockedUntil   *time.Time `json:"lockedUntil"`
+	ExpiresAt     time.Time  `json:"expiresAt"`
+}
+
+func (l *LoginAttempts) GetTable() string {
+	return "login_attempts"
+}
+
+// SetFailures receives the failures counted by the database and returns true only for the failure that reached the
+// max attempts, so concurrent failures lock the account or ip only once
+func (l *LoginAttempts) SetFailures(failures, maxAttempts int, baseDelay, lockout time.Duration) bool {
+	now := time.Now()
+	nextAttemptAt := now.Add(l.getDelay(failures, baseDelay))
+	l.Failures = failures
+	l.NextAttemptAt = &nextAttemptAt
+	if maxAttempts <= 0 || failures < maxAttempts {
+		return false
+	}
+
+	lockedUntil := now.Add(lockout)
+	l.LockedUntil = &lockedUntil
+	l.NextAttemptAt = &lockedUntil
+	return failures == maxAttempts
+}
+
+func (l *LoginAttempts) getDelay(failures int, baseDelay time.Duration) time.Duration {
+	delay := baseDelay
+	for i := 1; i < failures && delay < loginMaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > loginMaxDelay {
+		return loginMaxDelay
+	}
+
+	return delay
+}
+
+func (l *LoginAttempts) IsLocked() bool {
+	return l.LockedUntil != nil && time.Now().Before(*l.LockedUntil)
+}
+
+// RetryAfter returns how long the next login attempt must wait, zero when it is already allowed
+func (l *LoginAttempts) RetryAfter() time.Duration {
+	if l.NextAttemptAt == nil {
+		return 0
+	}
+
+	if wait := time.Until(*l.NextAttemptAt); wait > 0 {
+		return wait
+	}
+
+	return 0
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTableLoginAttempts(t *testing.T) {
+	t.Run("should return login attempts table", func(t *testing.T) {
+		assert.Equal(t, "login_attempts", (&LoginAttempts{}).GetTable())
+	})
+}
+
+func TestSetFailures(t *testing.T) {
+	t.Run("should double the delay on each failure", func(t *testing.T) {
+		attempts := &LoginAttempts{}
+
+		assert.False(t, attempts.SetFailures(1, 5, time.Second, time.Minute))
+		assert.InDelta(t, time.Second, attempts.RetryAfter(), float64(100*time.Millisecond))
+
+		assert.False(t, attempts.SetFailures(3, 5, time.Second, time.Minute))
+		assert.InDelta(t, 4*time.Second, attempts.RetryAfter(), float64(100*time.Millisecond))
+		assert.False(t, attempts.IsLocked())
+	})
+
+	t.Run("should limit the delay to one minute", func(t *testing.T) {
+		attempts := &LoginAttempts{}
+
+		attempts.SetFailures(21, 0, time.Second, time.Minute)
+
+		assert.InDelta(t, time.Minute, attempts.RetryAfter(), float64(100*time.Millisecond))
+	})
+
+	t.Run("should lock when reach the max attempts", func(t *testing.T) {
+		attempts := &LoginAttempts{}
+
+		assert.True(t, attempts.SetFailures(3, 3, time.Second, 15*time.Minute))
+		assert.True(t, attempts.IsLocked())
+		assert.InDelta(t, 15*time.Minute, attempts.RetryAfter(), float64(time.Second))
+	})
+
+	t.Run("should keep locked without locking again after the max attempts", func(t *testing.T) {
+		attempts := &LoginAttempts{}
+
+		assert.False(t, attempts.SetFailures(4, 3, time.Second, 15*time.Minute))
+		assert.True(t, attempts.IsLocked())
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Run("should return zero when next attempt is allowed", func(t *testing.T) {
+		nextAttemptAt := time.Now().Add(-time.Second)
+		attempts := &LoginAttempts{NextAttemptAt: &nextAttemptAt}
+
+		assert.Equal(t, time.Duration(0), attempts.RetryAfter())
+	})
+
+	t.Run("should return zero without failures", func(t *testing.T) {
+		attempts := &LoginAttempts{}
+
+		assert.Equal(t, time.Duration(0), attempts.RetryAfter())
+		assert.False(t, attempts.IsLocked())
+	})
+}
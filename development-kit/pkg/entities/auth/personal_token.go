@@ -0,0 +1,137 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"strings"
+	"time"
+
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/hash"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	PersonalTokenPrefix          = "hpat_"
+	PersonalTokenDefaultDuration = 90 * 24 * time.Hour
+	PersonalTokenMaxDuration     = 365 * 24 * time.Hour
+)
+
+type PersonalToken struct {
+	PersonalTokenID uuid.UUID      `json:"personalTokenID" gorm:"primary_key" swaggerignore:"true"`
+	AccountID       uuid.UUID      `json:"accountID" swaggerignore:"true"`
+	Description     string         `json:"description"`
+	Scopes          pq.StringArray `json:"scopes" swaggertype:"array,string"`
+	Permissions     pq.StringArray `json:"-"`
+	SuffixValue     string         `json:"suffixValue" swaggerignore:"true"`
+	Value           string         `json:"-"`
+	CreatedAt       time.Time      `json:"createdAt" swaggerignore:"true"`
+	ExpiresAt       time.Time      `json:"expiresAt"`
+	LastUsedAt      *time.Time     `json:"lastUsedAt" swaggerignore:"true"`
+	key             string
+}
+
+func (p *PersonalToken) GetTable() string {
+	return "personal_tokens"
+}
+
+func (p *PersonalToken) Validate() error {
+	return validation.ValidateStruct(p,
+		validation.Field(&p.Description, validation.Required, validation.Length(1, 255)),
+		validation.Field(&p.Scopes, validation.Required, validation.Each(validation.By(p.validateScope))),
+		validation.Field(&p.ExpiresAt, validation.By(p.validateExpiresAt)),
+	)
+}
+
+func (p *PersonalToken) validateScope(value interface{}) error {
+	if authEnums.Scope(value.(string)).IsInvalid() {
+		return errors.ErrorInvalidPersonalTokenScope
+	}
+
+	return nil
+}
+
+func (p *PersonalToken) validateExpiresAt(value interface{}) error {
+	expiresAt := value.(time.Time)
+	if expiresAt.IsZero() {
+		return nil
+	}
+
+	if expiresAt.Before(time.Now()) || expiresAt.After(time.Now().Add(PersonalTokenMaxDuration)) {
+		return errors.ErrorInvalidPersonalTokenExpiration
+	}
+
+	return nil
+}
+
+// SetCreateData generates the token key, only its hash is stored and the key is returned once to the user.
+func (p *PersonalToken) SetCreateData(accountID uuid.UUID, permissions []string) *PersonalToken {
+	p.PersonalTokenID = uuid.New()
+	p.AccountID = accountID
+	p.Permissions = permissions
+	p.CreatedAt = time.Now()
+	if p.ExpiresAt.IsZero() {
+		p.ExpiresAt = p.CreatedAt.Add(PersonalTokenDefaultDuration)
+	}
+
+	p.key = PersonalTokenPrefix + strings.ReplaceAll(uuid.New().String(), "-", "")
+	p.Value = HashPersonalToken(p.key)
+	p.SuffixValue = p.key[len(p.key)-5:]
+	return p
+}
+
+func (p *PersonalToken) GetKey() string {
+	return p.key
+}
+
+func (p *PersonalToken) IsExpired() bool {
+	return p.ExpiresAt.Before(time.Now())
+}
+
+func (p *PersonalToken) HasScope(scope authEnums.Scope) bool {
+	for _, value := range p.Scopes {
+		if value == scope.ToString() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FilterPermissions returns the permissions of the token that are in the groups
+func (p *PersonalToken) FilterPermissions(groups []string) (permissions []string) {
+	for _, permission := range p.Permissions {
+		for _, group := range groups {
+			if permission == group {
+				permissions = append(permissions, permission)
+				break
+			}
+		}
+	}
+
+	return permissions
+}
+
+func IsPersonalToken(token string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(token, "Bearer "), PersonalTokenPrefix)
+}
+
+func HashPersonalToken(token string) string {
+	value, _ := hash.GenerateSHA256(strings.TrimPrefix(token, "Bearer "))
+	return value
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonalTokenGetTable(t *testing.T) {
+	t.Run("should return table personal_tokens", func(t *testing.T) {
+		assert.Equal(t, "personal_tokens", (&PersonalToken{}).GetTable())
+	})
+}
+
+func TestPersonalTokenValidate(t *testing.T) {
+	t.Run("should return no errors when valid", func(t *testing.T) {
+		personalToken := &PersonalToken{
+			Description: "ci",
+			Scopes:      []string{authEnums.ReadAnalytics.ToString()},
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+		assert.NoError(t, personalToken.Validate())
+	})
+	t.Run("should return error when scope is invalid", func(t *testing.T) {
+		personalToken := &PersonalToken{Description: "ci", Scopes: []string{"write:everything"}}
+		assert.Error(t, personalToken.Validate())
+	})
+	t.Run("should return error when scopes are empty", func(t *testing.T) {
+		assert.Error(t, (&PersonalToken{Description: "ci"}).Validate())
+	})
+	t.Run("should return error when expires at is in the past", func(t *testing.T) {
+		personalToken := &PersonalToken{
+			Description: "ci",
+			Scopes:      []string{authEnums.ReadAnalytics.ToString()},
+			ExpiresAt:   time.Now().Add(-time.Hour),
+		}
+		assert.Error(t, personalToken.Validate())
+	})
+	t.Run("should return error when expires at is greater than max duration", func(t *testing.T) {
+		personalToken := &PersonalToken{
+			Description: "ci",
+			Scopes:      []string{authEnums.ReadAnalytics.ToString()},
+			ExpiresAt:   time.Now().Add(PersonalTokenMaxDuration + time.Hour),
+		}
+		assert.Error(t, personalToken.Validate())
+	})
+}
+
+func TestPersonalTokenSetCreateData(t *testing.T) {
+	t.Run("should set create data and generate key", func(t *testing.T) {
+		accountID := uuid.New()
+		personalToken := (&PersonalToken{}).SetCreateData(accountID, []string{"group"})
+		assert.Equal(t, accountID, personalToken.AccountID)
+		assert.NotEqual(t, uuid.Nil, personalToken.PersonalTokenID)
+		assert.True(t, IsPersonalToken(personalToken.GetKey()))
+		assert.Equal(t, HashPersonalToken(personalToken.GetKey()), personalToken.Value)
+		assert.NotEqual(t, personalToken.GetKey(), personalToken.Value)
+		assert.Len(t, personalToken.SuffixValue, 5)
+		assert.False(t, personalToken.IsExpired())
+		assert.WithinDuration(t, time.Now().Add(PersonalTokenDefaultDuration), personalToken.ExpiresAt, time.Minute)
+	})
+	t.Run("should keep informed expires at", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		personalToken := (&PersonalToken{ExpiresAt: expiresAt}).SetCreateData(uuid.New(), nil)
+		assert.Equal(t, expiresAt, personalToken.ExpiresAt)
+	})
+}
+
+func TestPersonalTokenIsExpired(t *testing.T) {
+	t.Run("should return true when expired", func(t *testing.T) {
+		assert.True(t, (&PersonalToken{ExpiresAt: time.Now().Add(-time.Second)}).IsExpired())
+	})
+}
+
+func TestPersonalTokenHasScope(t *testing.T) {
+	t.Run("should return true when has scope", func(t *testing.T) {
+		personalToken := &PersonalToken{Scopes: []string{authEnums.ManageRepositories.ToString()}}
+		assert.True(t, personalToken.HasScope(authEnums.ManageRepositories))
+		assert.False(t, personalToken.HasScope(authEnums.ReadAnalytics))
+	})
+}
+
+func TestPersonalTokenFilterPermissions(t *testing.T) {
+	t.Run("should keep only the permissions in the groups", func(t *testing.T) {
+		personalToken := &PersonalToken{Permissions: []string{"devs", "admins"}}
+
+		assert.Equal(t, []string{"devs"}, personalToken.FilterPermissions([]string{"leads", "devs"}))
+		assert.Empty(t, personalToken.FilterPermissions(nil))
+	})
+}
+
+func TestIsPersonalToken(t *testing.T) {
+	t.Run("should identify personal tokens with and without bearer", func(t *testing.T) {
+		assert.True(t, IsPersonalToken("hpat_123"))
+		assert.True(t, IsPersonalToken("Bearer hpat_123"))
+		assert.False(t, IsPersonalToken("Bearer eyJhbGciOiJIUzI1NiJ9"))
+	})
+}
+
+func TestHashPersonalToken(t *testing.T) {
+	t.Run("should ignore bearer prefix", func(t *testing.T) {
+		assert.Equal(t, HashPersonalToken("hpat_123"), HashPersonalToken("Bearer hpat_123"))
+	})
+}
+
+func TestValidateScopeError(t *testing.T) {
+	t.Run("should return invalid scope error", func(t *testing.T) {
+		assert.Equal(t, errors.ErrorInvalidPersonalTokenScope, (&PersonalToken{}).validateScope("invalid"))
+	})
+}
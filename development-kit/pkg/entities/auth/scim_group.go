@@ -0,0 +1,62 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScimGroup is a group provisioned by the identity provider, its display name is matched with the groups configured
+// in the companies and repositories to give roles to its members
+type ScimGroup struct {
+	GroupID     uuid.UUID `json:"groupID" gorm:"primary_key"`
+	DisplayName string    `json:"displayName"`
+	ExternalID  string    `json:"externalID"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type ScimGroupMember struct {
+	GroupID   uuid.UUID `json:"groupID" gorm:"primary_key"`
+	AccountID uuid.UUID `json:"accountID" gorm:"primary_key"`
+}
+
+// ScimGroupMembership is an active account in a scim group, used to resolve the roles by the group display names
+type ScimGroupMembership struct {
+	DisplayName string
+	AccountID   uuid.UUID
+}
+
+func (s *ScimGroup) GetTable() string {
+	return "scim_groups"
+}
+
+func (s *ScimGroup) SetCreateData() *ScimGroup {
+	s.GroupID = uuid.New()
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = time.Now()
+	return s
+}
+
+func (s *ScimGroup) SetUpdatedAt() *ScimGroup {
+	s.UpdatedAt = time.Now()
+	return s
+}
+
+func (s *ScimGroupMember) GetTable() string {
+	return "scim_group_members"
+}
@@ -0,0 +1,40 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScimGroup(t *testing.T) {
+	t.Run("should set create data", func(t *testing.T) {
+		group := (&ScimGroup{DisplayName: "developers"}).SetCreateData()
+
+		assert.NotEqual(t, uuid.Nil, group.GroupID)
+		assert.NotEmpty(t, group.CreatedAt)
+		assert.Equal(t, "scim_groups", group.GetTable())
+	})
+
+	t.Run("should set updated at", func(t *testing.T) {
+		assert.NotEmpty(t, (&ScimGroup{}).SetUpdatedAt().UpdatedAt)
+	})
+
+	t.Run("should return members table", func(t *testing.T) {
+		assert.Equal(t, "scim_group_members", (&ScimGroupMember{}).GetTable())
+	})
+}
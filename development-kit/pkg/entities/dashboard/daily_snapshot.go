@@ -0,0 +1,81 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/google/uuid"
+)
+
+type DailySnapshot struct {
+	SnapshotDate   time.Time `json:"snapshotDate" gorm:"Column:snapshot_date"`
+	AnalysisID     uuid.UUID `json:"analysisID" gorm:"Column:analysis_id"`
+	CompanyID      uuid.UUID `json:"companyID" gorm:"Column:company_id"`
+	RepositoryID   uuid.UUID `json:"repositoryID" gorm:"Column:repository_id"`
+	RepositoryName string    `json:"repositoryName" gorm:"Column:repository_name"`
+	FinishedAt     time.Time `json:"finishedAt" gorm:"Column:finished_at"`
+	Severity       string    `json:"severity" gorm:"Column:severity"`
+	Language       string    `json:"language" gorm:"Column:language"`
+	SecurityTool   string    `json:"securityTool" gorm:"Column:security_tool"`
+	Type           string    `json:"type" gorm:"Column:type"`
+	Total          int       `json:"total" gorm:"Column:total"`
+}
+
+type SnapshotCount struct {
+	Severity     string `gorm:"Column:severity"`
+	Language     string `gorm:"Column:language"`
+	SecurityTool string `gorm:"Column:security_tool"`
+	Type         string `gorm:"Column:type"`
+	Total        int    `gorm:"Column:total"`
+}
+
+func (d *DailySnapshot) GetTable() string {
+	return "analysis_daily_snapshots"
+}
+
+// NewDailySnapshots creates the rollup rows of the analysis day, an analysis without vulnerabilities creates an
+// empty row to keep the day as the latest state of the repository
+func NewDailySnapshots(analysis *horusec.Analysis, counts []SnapshotCount) []DailySnapshot {
+	if len(counts) == 0 {
+		counts = []SnapshotCount{{}}
+	}
+
+	snapshots := make([]DailySnapshot, 0, len(counts))
+	for _, count := range counts {
+		snapshots = append(snapshots, DailySnapshot{
+			SnapshotDate:   GetSnapshotDate(analysis.FinishedAt),
+			AnalysisID:     analysis.ID,
+			CompanyID:      analysis.CompanyID,
+			RepositoryID:   analysis.RepositoryID,
+			RepositoryName: analysis.RepositoryName,
+			FinishedAt:     analysis.FinishedAt,
+			Severity:       count.Severity,
+			Language:       count.Language,
+			SecurityTool:   count.SecurityTool,
+			Type:           count.Type,
+			Total:          count.Total,
+		})
+	}
+
+	return snapshots
+}
+
+// GetSnapshotDate returns the day of the date in UTC, it is the key of the daily snapshots
+func GetSnapshotDate(date time.Time) time.Time {
+	year, month, day := date.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,49 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDailySnapshots(t *testing.T) {
+	analysis := &horusec.Analysis{CompanyID: uuid.New(), RepositoryID: uuid.New(), RepositoryName: "test",
+		FinishedAt: time.Date(2021, 2, 19, 23, 30, 0, 0, time.FixedZone("test", -3*60*60))}
+
+	t.Run("should create a snapshot by count in the utc day of the analysis", func(t *testing.T) {
+		snapshots := NewDailySnapshots(analysis,
+			[]SnapshotCount{{Severity: "HIGH", Total: 2}, {Severity: "LOW", Total: 1}})
+
+		assert.Len(t, snapshots, 2)
+		assert.Equal(t, time.Date(2021, 2, 20, 0, 0, 0, 0, time.UTC), snapshots[0].SnapshotDate)
+		assert.Equal(t, analysis.RepositoryID, snapshots[0].RepositoryID)
+		assert.Equal(t, "test", snapshots[0].RepositoryName)
+		assert.Equal(t, 2, snapshots[0].Total)
+		assert.Equal(t, "LOW", snapshots[1].Severity)
+	})
+
+	t.Run("should create an empty snapshot when analysis has no vulnerabilities", func(t *testing.T) {
+		snapshots := NewDailySnapshots(analysis, nil)
+
+		assert.Len(t, snapshots, 1)
+		assert.Equal(t, 0, snapshots[0].Total)
+		assert.Equal(t, analysis.FinishedAt, snapshots[0].FinishedAt)
+	})
+}
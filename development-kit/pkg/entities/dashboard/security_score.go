@@ -0,0 +1,167 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+)
+
+// ScoreModel weights the open vulnerabilities to calculate the security score, the confidence and risk accepted
+// weights are percentages of the severity weight
+type ScoreModel struct {
+	SeverityWeights    map[severity.Severity]int
+	ConfidenceWeights  map[string]int
+	RiskAcceptedWeight int
+	AgeDaysToDouble    int
+	MaxAgeMultiplier   int
+	Scale              int
+}
+
+type SecurityScore struct {
+	Time                time.Time `json:"time"`
+	Score               float64   `json:"score"`
+	Repositories        int       `json:"repositories"`
+	OpenVulnerabilities int       `json:"openVulnerabilities"`
+}
+
+type RepositoryScore struct {
+	RepositoryID        uuid.UUID `json:"repositoryID"`
+	RepositoryName      string    `json:"repositoryName"`
+	Score               float64   `json:"score"`
+	OpenVulnerabilities int       `json:"openVulnerabilities"`
+	RiskAccepted        int       `json:"riskAccepted"`
+}
+
+// GetPenalty returns the weight of the vulnerability at the time, it grows with the age until the max multiplier
+func (s *ScoreModel) GetPenalty(lifecycle *VulnLifecycle, at time.Time) float64 {
+	penalty := float64(s.SeverityWeights[lifecycle.Severity]) * s.getAgeMultiplier(lifecycle.GetAgeInDays(at))
+	if weight, ok := s.ConfidenceWeights[lifecycle.Confidence]; ok {
+		penalty = penalty * float64(weight) / 100
+	}
+
+	if lifecycle.RiskAccepted {
+		penalty = penalty * float64(s.RiskAcceptedWeight) / 100
+	}
+
+	return penalty
+}
+
+func (s *ScoreModel) getAgeMultiplier(ageInDays int) float64 {
+	if s.AgeDaysToDouble <= 0 {
+		return 1
+	}
+
+	return math.Min(1+float64(ageInDays)/float64(s.AgeDaysToDouble), float64(s.MaxAgeMultiplier))
+}
+
+// GetScore returns a score from 0 to 100, a repository without open vulnerabilities has 100 and a penalty equal to the
+// scale has 50
+func (s *ScoreModel) GetScore(penalty float64) float64 {
+	scale := math.Max(float64(s.Scale), 1)
+	return math.Round(100*scale/(scale+penalty)*100) / 100
+}
+
+// NewRepositoryScores returns the score of the repositories analysed until the time, the worst scores first
+func NewRepositoryScores(model *ScoreModel, lifecycles []VulnLifecycle, analysisDates []AnalysisDate,
+	at time.Time) []RepositoryScore {
+	scores, penalties := map[uuid.UUID]*RepositoryScore{}, map[uuid.UUID]float64{}
+	for index := range analysisDates {
+		if !analysisDates[index].FinishedAt.After(at) {
+			scores[analysisDates[index].RepositoryID] = &RepositoryScore{RepositoryID: analysisDates[index].RepositoryID,
+				RepositoryName: analysisDates[index].RepositoryName}
+		}
+	}
+
+	for index := range lifecycles {
+		score, ok := scores[lifecycles[index].RepositoryID]
+		if ok && lifecycles[index].IsOpenAt(at) {
+			penalties[score.RepositoryID] += model.GetPenalty(&lifecycles[index], at)
+			score.addOpenVulnerability(lifecycles[index].RiskAccepted)
+		}
+	}
+
+	return sortRepositoryScores(model, scores, penalties)
+}
+
+func (r *RepositoryScore) addOpenVulnerability(riskAccepted bool) {
+	r.OpenVulnerabilities++
+	if riskAccepted {
+		r.RiskAccepted++
+	}
+}
+
+func sortRepositoryScores(model *ScoreModel, scores map[uuid.UUID]*RepositoryScore,
+	penalties map[uuid.UUID]float64) []RepositoryScore {
+	sorted := make([]RepositoryScore, 0, len(scores))
+	for repositoryID, score := range scores {
+		score.Score = model.GetScore(penalties[repositoryID])
+		sorted = append(sorted, *score)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Score == sorted[j].Score {
+			return sorted[i].RepositoryName < sorted[j].RepositoryName
+		}
+
+		return sorted[i].Score < sorted[j].Score
+	})
+
+	return sorted
+}
+
+// NewScoreHistory returns the mean score of the repositories at each time, times without analysed repositories are
+// ignored
+func NewScoreHistory(model *ScoreModel, lifecycles []VulnLifecycle, analysisDates []AnalysisDate,
+	times []time.Time) []SecurityScore {
+	history := []SecurityScore{}
+	for _, at := range times {
+		scores := NewRepositoryScores(model, lifecycles, analysisDates, at)
+		if len(scores) == 0 {
+			continue
+		}
+
+		history = append(history, newSecurityScore(scores, at))
+	}
+
+	return history
+}
+
+func newSecurityScore(scores []RepositoryScore, at time.Time) SecurityScore {
+	securityScore := SecurityScore{Time: at, Repositories: len(scores)}
+	total := 0.0
+	for index := range scores {
+		total += scores[index].Score
+		securityScore.OpenVulnerabilities += scores[index].OpenVulnerabilities
+	}
+
+	securityScore.Score = math.Round(total/float64(len(scores))*100) / 100
+	return securityScore
+}
+
+// GetScoreTimes returns the start of each month in the period and the final date, to compare the score month over
+// month and quarter over quarter
+func GetScoreTimes(initialDate, finalDate time.Time) (times []time.Time) {
+	month := time.Date(initialDate.Year(), initialDate.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+	for ; month.Before(finalDate); month = month.AddDate(0, 1, 0) {
+		times = append(times, month)
+	}
+
+	return append(times, finalDate)
+}
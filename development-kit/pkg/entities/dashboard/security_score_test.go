@@ -0,0 +1,128 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestScoreModel() *ScoreModel {
+	return &ScoreModel{
+		SeverityWeights:    map[severity.Severity]int{severity.High: 10, severity.Low: 2},
+		ConfidenceWeights:  map[string]int{"HIGH": 100, "LOW": 40},
+		RiskAcceptedWeight: 25,
+		AgeDaysToDouble:    90,
+		MaxAgeMultiplier:   3,
+		Scale:              100,
+	}
+}
+
+func TestGetPenalty(t *testing.T) {
+	now := time.Now()
+	model := newTestScoreModel()
+
+	t.Run("should return the severity weight for new vulnerabilities", func(t *testing.T) {
+		lifecycle := &VulnLifecycle{VulnOccurrence: VulnOccurrence{Severity: severity.High, FirstSeen: now}}
+
+		assert.Equal(t, float64(10), model.GetPenalty(lifecycle, now))
+	})
+
+	t.Run("should increase the weight with the age until the max multiplier", func(t *testing.T) {
+		lifecycle := &VulnLifecycle{VulnOccurrence: VulnOccurrence{Severity: severity.High,
+			FirstSeen: now.AddDate(0, 0, -90)}}
+		assert.Equal(t, float64(20), model.GetPenalty(lifecycle, now))
+
+		lifecycle.FirstSeen = now.AddDate(-3, 0, 0)
+		assert.Equal(t, float64(30), model.GetPenalty(lifecycle, now))
+	})
+
+	t.Run("should discount low confidence and risk accepted vulnerabilities", func(t *testing.T) {
+		lifecycle := &VulnLifecycle{VulnOccurrence: VulnOccurrence{Severity: severity.High, Confidence: "LOW",
+			FirstSeen: now}}
+		assert.Equal(t, float64(4), model.GetPenalty(lifecycle, now))
+
+		lifecycle.Confidence = "HIGH"
+		lifecycle.RiskAccepted = true
+		assert.Equal(t, 2.5, model.GetPenalty(lifecycle, now))
+	})
+
+	t.Run("should not use age when days to double is not set", func(t *testing.T) {
+		lifecycle := &VulnLifecycle{VulnOccurrence: VulnOccurrence{Severity: severity.Low,
+			FirstSeen: now.AddDate(-1, 0, 0)}}
+
+		assert.Equal(t, float64(2), (&ScoreModel{SeverityWeights: model.SeverityWeights}).GetPenalty(lifecycle, now))
+	})
+}
+
+func TestGetScore(t *testing.T) {
+	t.Run("should return 100 without penalty and 50 when penalty is the scale", func(t *testing.T) {
+		model := newTestScoreModel()
+
+		assert.Equal(t, float64(100), model.GetScore(0))
+		assert.Equal(t, float64(50), model.GetScore(100))
+		assert.Equal(t, 90.91, model.GetScore(10))
+	})
+}
+
+func TestNewRepositoryScores(t *testing.T) {
+	now := time.Now()
+	first, second := uuid.New(), uuid.New()
+	analysisDates := []AnalysisDate{
+		{RepositoryID: first, RepositoryName: "first", FinishedAt: now},
+		{RepositoryID: second, RepositoryName: "second", FinishedAt: now},
+		{RepositoryID: uuid.New(), RepositoryName: "later", FinishedAt: now.Add(time.Hour)},
+	}
+	fixedAt := now.Add(-time.Minute)
+	lifecycles := []VulnLifecycle{
+		{VulnOccurrence: VulnOccurrence{RepositoryID: first, Severity: severity.High, FirstSeen: now}},
+		{VulnOccurrence: VulnOccurrence{RepositoryID: second, Severity: severity.High, FirstSeen: now.Add(-time.Hour)},
+			FixedAt: &fixedAt},
+	}
+
+	t.Run("should return the scores of the repositories analysed with the worst first", func(t *testing.T) {
+		scores := NewRepositoryScores(newTestScoreModel(), lifecycles, analysisDates, now)
+
+		assert.Len(t, scores, 2)
+		assert.Equal(t, RepositoryScore{RepositoryID: first, RepositoryName: "first", Score: 90.91,
+			OpenVulnerabilities: 1}, scores[0])
+		assert.Equal(t, float64(100), scores[1].Score)
+	})
+
+	t.Run("should return the mean score of each time with analysed repositories", func(t *testing.T) {
+		history := NewScoreHistory(newTestScoreModel(), lifecycles, analysisDates,
+			[]time.Time{now.Add(-time.Hour), now})
+
+		assert.Len(t, history, 1)
+		assert.Equal(t, 2, history[0].Repositories)
+		assert.Equal(t, 1, history[0].OpenVulnerabilities)
+		assert.InDelta(t, 95.46, history[0].Score, 0.01)
+	})
+}
+
+func TestGetScoreTimes(t *testing.T) {
+	t.Run("should return the start of the months in the period and the final date", func(t *testing.T) {
+		finalDate := time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)
+
+		times := GetScoreTimes(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC), finalDate)
+
+		assert.Equal(t, []time.Time{time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), finalDate}, times)
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+)
+
+type TimeToFix struct {
+	Severity    severity.Severity `json:"severity"`
+	Fixed       int               `json:"fixed"`
+	Open        int               `json:"open"`
+	MeanInHours float64           `json:"meanInHours"`
+	P50InHours  float64           `json:"p50InHours"`
+	P90InHours  float64           `json:"p90InHours"`
+}
+
+func NewTimeToFixBySeverity(lifecycles []VulnLifecycle) (timeToFix []TimeToFix) {
+	durations := map[severity.Severity][]time.Duration{}
+	open := map[severity.Severity]int{}
+	for index := range lifecycles {
+		if lifecycles[index].IsOpen() {
+			open[lifecycles[index].Severity]++
+			continue
+		}
+
+		durations[lifecycles[index].Severity] = append(durations[lifecycles[index].Severity],
+			lifecycles[index].GetTimeToFix())
+	}
+
+	for _, value := range []severity.Severity{severity.High, severity.Medium, severity.Low, severity.Audit,
+		severity.Info, severity.NoSec} {
+		if len(durations[value]) > 0 || open[value] > 0 {
+			timeToFix = append(timeToFix, newTimeToFix(value, durations[value], open[value]))
+		}
+	}
+
+	return timeToFix
+}
+
+func newTimeToFix(value severity.Severity, durations []time.Duration, open int) TimeToFix {
+	timeToFix := TimeToFix{Severity: value, Fixed: len(durations), Open: open}
+	if len(durations) == 0 {
+		return timeToFix
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+	}
+
+	timeToFix.MeanInHours = toRoundedHours(total / time.Duration(len(durations)))
+	timeToFix.P50InHours = toRoundedHours(getPercentile(durations, 50))
+	timeToFix.P90InHours = toRoundedHours(getPercentile(durations, 90))
+	return timeToFix
+}
+
+// getPercentile uses the nearest rank method, the durations must be sorted
+func getPercentile(durations []time.Duration, percentile float64) time.Duration {
+	rank := int(math.Ceil(percentile / 100 * float64(len(durations))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return durations[rank-1]
+}
+
+func toRoundedHours(duration time.Duration) float64 {
+	return math.Round(duration.Hours()*100) / 100
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+)
+
+type VulnAging struct {
+	Range   string `json:"range"`
+	MinDays int    `json:"minDays"`
+	Total   int    `json:"total"`
+	Low     int    `json:"low"`
+	Medium  int    `json:"medium"`
+	High    int    `json:"high"`
+	Audit   int    `json:"audit"`
+	Info    int    `json:"info"`
+	NoSec   int    `json:"noSec"`
+}
+
+// NewVulnAging returns the histogram of open vulnerabilities by days since they were first seen
+func NewVulnAging(lifecycles []VulnLifecycle, now time.Time) []VulnAging {
+	aging := []VulnAging{{Range: "0-7", MinDays: 0}, {Range: "8-30", MinDays: 8}, {Range: "31-90", MinDays: 31},
+		{Range: "90+", MinDays: 91}}
+
+	for index := range lifecycles {
+		if !lifecycles[index].IsOpen() {
+			continue
+		}
+
+		bucket := getAgingBucket(aging, lifecycles[index].GetAgeInDays(now))
+		bucket.add(lifecycles[index].Severity)
+	}
+
+	return aging
+}
+
+func getAgingBucket(aging []VulnAging, days int) *VulnAging {
+	for index := len(aging) - 1; index > 0; index-- {
+		if days >= aging[index].MinDays {
+			return &aging[index]
+		}
+	}
+
+	return &aging[0]
+}
+
+func (v *VulnAging) add(value severity.Severity) {
+	v.Total++
+	switch value {
+	case severity.Low:
+		v.Low++
+	case severity.Medium:
+		v.Medium++
+	case severity.High:
+		v.High++
+	case severity.Audit:
+		v.Audit++
+	case severity.Info:
+		v.Info++
+	case severity.NoSec:
+		v.NoSec++
+	}
+}
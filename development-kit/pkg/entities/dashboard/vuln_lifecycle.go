@@ -0,0 +1,88 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+)
+
+type VulnOccurrence struct {
+	RepositoryID uuid.UUID         `json:"repositoryID" gorm:"Column:repository_id"`
+	VulnHash     string            `json:"vulnHash" gorm:"Column:vuln_hash"`
+	Severity     severity.Severity `json:"severity" gorm:"Column:severity"`
+	Confidence   string            `json:"confidence" gorm:"Column:confidence"`
+	RiskAccepted bool              `json:"riskAccepted" gorm:"Column:risk_accepted"`
+	FirstSeen    time.Time         `json:"firstSeen" gorm:"Column:first_seen"`
+	LastSeen     time.Time         `json:"lastSeen" gorm:"Column:last_seen"`
+}
+
+type AnalysisDate struct {
+	RepositoryID   uuid.UUID `json:"repositoryID" gorm:"Column:repository_id"`
+	RepositoryName string    `json:"repositoryName" gorm:"Column:repository_name"`
+	FinishedAt     time.Time `json:"finishedAt" gorm:"Column:finished_at"`
+}
+
+type VulnLifecycle struct {
+	VulnOccurrence
+	FixedAt *time.Time `json:"fixedAt"`
+}
+
+// NewVulnLifecycles considers a vulnerability fixed by the first successful analysis of the repository after its last
+// occurrence, the analysis dates must be only of successful analysis sorted by finished at
+func NewVulnLifecycles(occurrences []VulnOccurrence, analysisDates []AnalysisDate) []VulnLifecycle {
+	lifecycles := make([]VulnLifecycle, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		lifecycles = append(lifecycles, VulnLifecycle{
+			VulnOccurrence: occurrence,
+			FixedAt:        getNextAnalysisDate(analysisDates, occurrence.RepositoryID, occurrence.LastSeen),
+		})
+	}
+
+	return lifecycles
+}
+
+func getNextAnalysisDate(analysisDates []AnalysisDate, repositoryID uuid.UUID, lastSeen time.Time) *time.Time {
+	for index := range analysisDates {
+		if analysisDates[index].RepositoryID == repositoryID && analysisDates[index].FinishedAt.After(lastSeen) {
+			return &analysisDates[index].FinishedAt
+		}
+	}
+
+	return nil
+}
+
+func (v *VulnLifecycle) IsOpen() bool {
+	return v.FixedAt == nil
+}
+
+// IsOpenAt returns if the vulnerability was found and not fixed yet at the time
+func (v *VulnLifecycle) IsOpenAt(at time.Time) bool {
+	return !v.FirstSeen.After(at) && (v.FixedAt == nil || v.FixedAt.After(at))
+}
+
+func (v *VulnLifecycle) GetTimeToFix() time.Duration {
+	if v.IsOpen() {
+		return 0
+	}
+
+	return v.FixedAt.Sub(v.FirstSeen)
+}
+
+func (v *VulnLifecycle) GetAgeInDays(now time.Time) int {
+	return int(now.Sub(v.FirstSeen).Hours() / 24)
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVulnLifecycles(t *testing.T) {
+	repositoryID := uuid.New()
+	otherRepositoryID := uuid.New()
+	now := time.Now()
+	analysisDates := []AnalysisDate{
+		{RepositoryID: repositoryID, FinishedAt: now.Add(-10 * 24 * time.Hour)},
+		{RepositoryID: otherRepositoryID, FinishedAt: now.Add(-8 * 24 * time.Hour)},
+		{RepositoryID: repositoryID, FinishedAt: now.Add(-5 * 24 * time.Hour)},
+		{RepositoryID: repositoryID, FinishedAt: now.Add(-1 * 24 * time.Hour)},
+	}
+
+	t.Run("should set fixed at with the next analysis of the repository", func(t *testing.T) {
+		lifecycles := NewVulnLifecycles([]VulnOccurrence{{RepositoryID: repositoryID, VulnHash: "1",
+			FirstSeen: now.Add(-10 * 24 * time.Hour), LastSeen: now.Add(-10 * 24 * time.Hour)}}, analysisDates)
+
+		assert.False(t, lifecycles[0].IsOpen())
+		assert.Equal(t, now.Add(-5*24*time.Hour), *lifecycles[0].FixedAt)
+		assert.Equal(t, 5*24*time.Hour, lifecycles[0].GetTimeToFix())
+	})
+
+	t.Run("should be open when it is in the last analysis of the repository", func(t *testing.T) {
+		lifecycles := NewVulnLifecycles([]VulnOccurrence{{RepositoryID: repositoryID, VulnHash: "1",
+			FirstSeen: now.Add(-10 * 24 * time.Hour), LastSeen: now.Add(-1 * 24 * time.Hour)}}, analysisDates)
+
+		assert.True(t, lifecycles[0].IsOpen())
+		assert.Equal(t, time.Duration(0), lifecycles[0].GetTimeToFix())
+		assert.Equal(t, 10, lifecycles[0].GetAgeInDays(now))
+	})
+}
+
+func TestNewTimeToFixBySeverity(t *testing.T) {
+	t.Run("should return mean and percentiles of fixed vulnerabilities by severity", func(t *testing.T) {
+		now := time.Now()
+		var lifecycles []VulnLifecycle
+		for days := 1; days <= 10; days++ {
+			fixedAt := now.Add(time.Duration(days) * 24 * time.Hour)
+			lifecycles = append(lifecycles, VulnLifecycle{
+				VulnOccurrence: VulnOccurrence{Severity: severity.High, FirstSeen: now}, FixedAt: &fixedAt})
+		}
+		lifecycles = append(lifecycles, VulnLifecycle{VulnOccurrence: VulnOccurrence{Severity: severity.High}},
+			VulnLifecycle{VulnOccurrence: VulnOccurrence{Severity: severity.Low}})
+
+		timeToFix := NewTimeToFixBySeverity(lifecycles)
+
+		assert.Len(t, timeToFix, 2)
+		assert.Equal(t, TimeToFix{Severity: severity.High, Fixed: 10, Open: 1, MeanInHours: 132,
+			P50InHours: 120, P90InHours: 216}, timeToFix[0])
+		assert.Equal(t, TimeToFix{Severity: severity.Low, Open: 1}, timeToFix[1])
+	})
+
+	t.Run("should return empty without vulnerabilities", func(t *testing.T) {
+		assert.Empty(t, NewTimeToFixBySeverity(nil))
+	})
+}
+
+func TestNewVulnAging(t *testing.T) {
+	t.Run("should count open vulnerabilities by age", func(t *testing.T) {
+		now := time.Now()
+		fixedAt := now
+		lifecycles := []VulnLifecycle{
+			{VulnOccurrence: VulnOccurrence{Severity: severity.High, FirstSeen: now.Add(-7 * 24 * time.Hour)}},
+			{VulnOccurrence: VulnOccurrence{Severity: severity.Medium, FirstSeen: now.Add(-8 * 24 * time.Hour)}},
+			{VulnOccurrence: VulnOccurrence{Severity: severity.Low, FirstSeen: now.Add(-90 * 24 * time.Hour)}},
+			{VulnOccurrence: VulnOccurrence{Severity: severity.Audit, FirstSeen: now.Add(-91 * 24 * time.Hour)}},
+			{VulnOccurrence: VulnOccurrence{Severity: severity.High, FirstSeen: now.Add(-200 * 24 * time.Hour)},
+				FixedAt: &fixedAt},
+		}
+
+		aging := NewVulnAging(lifecycles, now)
+
+		assert.Equal(t, []VulnAging{
+			{Range: "0-7", MinDays: 0, Total: 1, High: 1},
+			{Range: "8-30", MinDays: 8, Total: 1, Medium: 1},
+			{Range: "31-90", MinDays: 31, Total: 1, Low: 1},
+			{Range: "90+", MinDays: 91, Total: 1, Audit: 1},
+		}, aging)
+	})
+}
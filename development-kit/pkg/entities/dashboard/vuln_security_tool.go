@@ -0,0 +1,51 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import "math"
+
+type VulnBySecurityTool struct {
+	SecurityTool      string  `json:"securityTool"`
+	Total             int     `json:"total"`
+	FalsePositive     int     `json:"falsePositive"`
+	RiskAccepted      int     `json:"riskAccepted"`
+	FalsePositiveRate float64 `json:"falsePositiveRate"`
+}
+
+type VulnByRule struct {
+	SecurityTool      string  `json:"securityTool"`
+	RuleID            string  `json:"ruleID"`
+	Total             int     `json:"total"`
+	FalsePositive     int     `json:"falsePositive"`
+	RiskAccepted      int     `json:"riskAccepted"`
+	FalsePositiveRate float64 `json:"falsePositiveRate"`
+}
+
+func (v *VulnBySecurityTool) SetFalsePositiveRate() {
+	v.FalsePositiveRate = getFalsePositiveRate(v.FalsePositive, v.Total)
+}
+
+func (v *VulnByRule) SetFalsePositiveRate() {
+	v.FalsePositiveRate = getFalsePositiveRate(v.FalsePositive, v.Total)
+}
+
+// getFalsePositiveRate returns the percentage of the vulnerabilities marked as false positive with two decimals
+func getFalsePositiveRate(falsePositive, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return math.Round(float64(falsePositive)/float64(total)*10000) / 100
+}
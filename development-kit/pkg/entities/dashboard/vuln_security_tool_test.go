@@ -0,0 +1,47 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetFalsePositiveRate(t *testing.T) {
+	t.Run("should set the percentage of false positives of the tool", func(t *testing.T) {
+		vulnBySecurityTool := &VulnBySecurityTool{Total: 3, FalsePositive: 1}
+
+		vulnBySecurityTool.SetFalsePositiveRate()
+
+		assert.Equal(t, 33.33, vulnBySecurityTool.FalsePositiveRate)
+	})
+
+	t.Run("should set the percentage of false positives of the rule", func(t *testing.T) {
+		vulnByRule := &VulnByRule{Total: 4, FalsePositive: 4}
+
+		vulnByRule.SetFalsePositiveRate()
+
+		assert.Equal(t, float64(100), vulnByRule.FalsePositiveRate)
+	})
+
+	t.Run("should set zero when there are no vulnerabilities", func(t *testing.T) {
+		vulnByRule := &VulnByRule{}
+
+		vulnByRule.SetFalsePositiveRate()
+
+		assert.Equal(t, float64(0), vulnByRule.FalsePositiveRate)
+	})
+}
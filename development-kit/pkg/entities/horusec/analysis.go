@@ -23,6 +23,7 @@ import (
 
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type Analysis struct {
@@ -31,10 +32,14 @@ type Analysis struct {
 	RepositoryName          string                    `json:"repositoryName" gorm:"Column:repository_name"`
 	CompanyID               uuid.UUID                 `json:"companyID" gorm:"Column:company_id"`
 	CompanyName             string                    `json:"companyName" gorm:"Column:company_name"`
+	Branch                  string                    `json:"branch" gorm:"Column:branch"`
+	Commit                  string                    `json:"commit" gorm:"Column:commit_hash"`
 	Status                  horusec.Status            `json:"status" gorm:"Column:status"`
 	Errors                  string                    `json:"errors" gorm:"Column:errors"`
 	CreatedAt               time.Time                 `json:"createdAt" gorm:"Column:created_at"`
 	FinishedAt              time.Time                 `json:"finishedAt" gorm:"Column:finished_at"`
+	PolicyStatus            horusec.PolicyStatus      `json:"policyStatus" gorm:"Column:policy_status"`
+	PolicyReasons           pq.StringArray            `json:"policyReasons" gorm:"Column:policy_reasons;type:text[]"`
 	AnalysisVulnerabilities []AnalysisVulnerabilities `json:"analysisVulnerabilities" gorm:"foreignkey:AnalysisID;association_foreignkey:ID"` //nolint:lll gorm usage
 }
 
@@ -67,9 +72,13 @@ func (a *Analysis) Map() map[string]interface{} {
 		"repositoryName":          a.RepositoryName,
 		"companyName":             a.CompanyName,
 		"companyID":               a.CompanyID,
+		"branch":                  a.Branch,
+		"commit":                  a.Commit,
 		"status":                  a.Status,
 		"errors":                  a.Errors,
 		"finishedAt":              a.FinishedAt,
+		"policyStatus":            a.PolicyStatus,
+		"policyReasons":           a.PolicyReasons,
 		"analysisVulnerabilities": a.AnalysisVulnerabilities,
 	}
 }
@@ -108,6 +117,12 @@ func (a *Analysis) SetRepositoryName(repositoryName string) *Analysis {
 	return a
 }
 
+func (a *Analysis) SetGitInfo(branch, commit string) *Analysis {
+	a.Branch = branch
+	a.Commit = commit
+	return a
+}
+
 func (a *Analysis) SetRepositoryID(repositoryID uuid.UUID) *Analysis {
 	a.RepositoryID = repositoryID
 	return a
@@ -125,6 +140,36 @@ func (a *Analysis) SetAnalysisFinishedData() *Analysis {
 	return a
 }
 
+// SetPolicyVerdict sets the analysis as failed in the policies when there are reasons
+func (a *Analysis) SetPolicyVerdict(reasons []string) *Analysis {
+	a.PolicyReasons = reasons
+	if len(reasons) > 0 {
+		a.PolicyStatus = horusec.PolicyFailed
+		return a
+	}
+
+	a.PolicyStatus = horusec.PolicyPassed
+	return a
+}
+
+// SetPolicyNotEvaluated sets the analysis as not evaluated in the policies with the reason
+func (a *Analysis) SetPolicyNotEvaluated(reason string) *Analysis {
+	a.PolicyStatus = horusec.PolicyNotEvaluated
+	a.PolicyReasons = []string{reason}
+	return a
+}
+
+// ClearPolicyVerdict removes the verdict sent by the client, only horusec-api evaluates the policies
+func (a *Analysis) ClearPolicyVerdict() *Analysis {
+	a.PolicyStatus = ""
+	a.PolicyReasons = nil
+	return a
+}
+
+func (a *Analysis) IsPolicyFailed() bool {
+	return a.PolicyStatus == horusec.PolicyFailed
+}
+
 func (a *Analysis) HasErrors() bool {
 	return len(a.Errors) > 0
 }
@@ -189,10 +234,14 @@ func (a *Analysis) GetAnalysisWithoutAnalysisVulnerabilities() *Analysis {
 		RepositoryName: a.RepositoryName,
 		CompanyID:      a.CompanyID,
 		CompanyName:    a.CompanyName,
+		Branch:         a.Branch,
+		Commit:         a.Commit,
 		Status:         a.Status,
 		Errors:         a.Errors,
 		CreatedAt:      a.CreatedAt,
 		FinishedAt:     a.FinishedAt,
+		PolicyStatus:   a.PolicyStatus,
+		PolicyReasons:  a.PolicyReasons,
 	}
 }
 
@@ -225,7 +274,7 @@ func (a *Analysis) SetFalsePositivesAndRiskAcceptInVulnerabilities(
 	listFalsePositive, listRiskAccept []string) *Analysis {
 	for key := range a.AnalysisVulnerabilities {
 		a.setVulnerabilityType(key, listFalsePositive, horusec.FalsePositive)
-		a.setVulnerabilityType(key, listRiskAccept, horusec.RiskAccepted)
+		a.setRiskAcceptType(key, listRiskAccept)
 	}
 	return a
 }
@@ -240,6 +289,33 @@ func (a *Analysis) setVulnerabilityType(keyAnalysisVulnerabilities int,
 	}
 }
 
+func (a *Analysis) setRiskAcceptType(keyAnalysisVulnerabilities int, listRiskAccept []string) {
+	vulnerability := &a.AnalysisVulnerabilities[keyAnalysisVulnerabilities].Vulnerability
+	for _, value := range listRiskAccept {
+		riskAccept, err := NewRiskAcceptFromString(value)
+		if err != nil || riskAccept.VulnHash == "" || riskAccept.IsExpired(time.Now()) {
+			continue
+		}
+
+		if strings.TrimSpace(vulnerability.VulnHash) == riskAccept.VulnHash {
+			vulnerability.Type = horusec.RiskAccepted
+			vulnerability.RiskAcceptedUntil = riskAccept.ExpiresAt
+		}
+	}
+}
+
+func (a *Analysis) SetExpiredRiskAcceptToVulnerability() *Analysis {
+	for key := range a.AnalysisVulnerabilities {
+		vulnerability := &a.AnalysisVulnerabilities[key].Vulnerability
+		if vulnerability.IsRiskAcceptExpired(time.Now()) {
+			vulnerability.SetType(horusec.Vulnerability)
+			vulnerability.SetRiskAcceptedUntil(nil)
+		}
+	}
+
+	return a
+}
+
 func (a *Analysis) ParseResponseBytesToAnalysis(body []byte) (analysis *Analysis, err error) {
 	var response map[string]interface{}
 	err = json.Unmarshal(body, &response)
@@ -0,0 +1,128 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horusec
+
+import (
+	"strings"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+)
+
+// AnalysisComparison is the difference between two analyses of the same repository, the vulnerabilities are matched
+// by hash and the errors of the tools by message
+type AnalysisComparison struct {
+	BaseAnalysisID    uuid.UUID        `json:"baseAnalysisID"`
+	HeadAnalysisID    uuid.UUID        `json:"headAnalysisID"`
+	New               ComparisonBucket `json:"new"`
+	Fixed             ComparisonBucket `json:"fixed"`
+	Unchanged         ComparisonBucket `json:"unchanged"`
+	AppearedErrors    []string         `json:"appearedErrors"`
+	DisappearedErrors []string         `json:"disappearedErrors"`
+}
+
+type ComparisonBucket struct {
+	Total           int                       `json:"total"`
+	TotalBySeverity map[severity.Severity]int `json:"totalBySeverity"`
+	Vulnerabilities []Vulnerability           `json:"vulnerabilities"`
+}
+
+// NewAnalysisComparison returns the new and unchanged vulnerabilities as they are in the head analysis and the fixed
+// as they were in the base analysis
+func NewAnalysisComparison(base, head *Analysis) *AnalysisComparison {
+	baseHashes, headHashes := base.getVulnHashes(), head.getVulnHashes()
+	comparison := &AnalysisComparison{
+		BaseAnalysisID:    base.ID,
+		HeadAnalysisID:    head.ID,
+		New:               newComparisonBucket(),
+		Fixed:             newComparisonBucket(),
+		Unchanged:         newComparisonBucket(),
+		AppearedErrors:    getMissingErrors(head.GetErrors(), base.GetErrors()),
+		DisappearedErrors: getMissingErrors(base.GetErrors(), head.GetErrors()),
+	}
+
+	for index := range head.AnalysisVulnerabilities {
+		vulnerability := head.AnalysisVulnerabilities[index].Vulnerability
+		if baseHashes[vulnerability.VulnHash] {
+			comparison.Unchanged.add(&vulnerability)
+		} else {
+			comparison.New.add(&vulnerability)
+		}
+	}
+
+	for index := range base.AnalysisVulnerabilities {
+		if vulnerability := base.AnalysisVulnerabilities[index].Vulnerability; !headHashes[vulnerability.VulnHash] {
+			comparison.Fixed.add(&vulnerability)
+		}
+	}
+
+	return comparison
+}
+
+func newComparisonBucket() ComparisonBucket {
+	return ComparisonBucket{
+		TotalBySeverity: map[severity.Severity]int{},
+		Vulnerabilities: []Vulnerability{},
+	}
+}
+
+func (c *ComparisonBucket) add(vulnerability *Vulnerability) {
+	c.Total++
+	c.TotalBySeverity[vulnerability.Severity]++
+	c.Vulnerabilities = append(c.Vulnerabilities, *vulnerability)
+}
+
+func (a *Analysis) getVulnHashes() map[string]bool {
+	hashes := map[string]bool{}
+	for index := range a.AnalysisVulnerabilities {
+		hashes[a.AnalysisVulnerabilities[index].Vulnerability.VulnHash] = true
+	}
+
+	return hashes
+}
+
+// GetErrors returns the errors of the tools without duplicates, they are saved in the analysis separated by "; "
+func (a *Analysis) GetErrors() (errors []string) {
+	found := map[string]bool{}
+	for _, err := range strings.Split(a.Errors, "; ") {
+		if err = strings.TrimSpace(err); err != "" && !found[err] {
+			found[err] = true
+			errors = append(errors, err)
+		}
+	}
+
+	return errors
+}
+
+func getMissingErrors(errors, others []string) []string {
+	missing := []string{}
+	for _, err := range errors {
+		if !containsError(others, err) {
+			missing = append(missing, err)
+		}
+	}
+
+	return missing
+}
+
+func containsError(errors []string, search string) bool {
+	for _, err := range errors {
+		if err == search {
+			return true
+		}
+	}
+
+	return false
+}
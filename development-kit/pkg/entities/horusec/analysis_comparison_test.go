@@ -0,0 +1,89 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horusec
+
+import (
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAnalysisToCompare(errors string, vulnerabilities ...Vulnerability) *Analysis {
+	analysis := &Analysis{ID: uuid.New(), Errors: errors}
+	for index := range vulnerabilities {
+		analysis.AnalysisVulnerabilities = append(analysis.AnalysisVulnerabilities,
+			AnalysisVulnerabilities{Vulnerability: vulnerabilities[index]})
+	}
+
+	return analysis
+}
+
+func TestNewAnalysisComparison(t *testing.T) {
+	t.Run("should split vulnerabilities in new, fixed and unchanged by hash", func(t *testing.T) {
+		base := newAnalysisToCompare("",
+			Vulnerability{VulnHash: "fixed", Severity: severity.High},
+			Vulnerability{VulnHash: "unchanged", Severity: severity.Low, Line: "1"})
+		head := newAnalysisToCompare("",
+			Vulnerability{VulnHash: "unchanged", Severity: severity.Low, Line: "2"},
+			Vulnerability{VulnHash: "new", Severity: severity.High},
+			Vulnerability{VulnHash: "other-new", Severity: severity.Medium})
+
+		comparison := NewAnalysisComparison(base, head)
+
+		assert.Equal(t, base.ID, comparison.BaseAnalysisID)
+		assert.Equal(t, head.ID, comparison.HeadAnalysisID)
+		assert.Equal(t, 2, comparison.New.Total)
+		assert.Equal(t, 1, comparison.New.TotalBySeverity[severity.High])
+		assert.Equal(t, 1, comparison.New.TotalBySeverity[severity.Medium])
+		assert.Equal(t, 1, comparison.Fixed.Total)
+		assert.Equal(t, "fixed", comparison.Fixed.Vulnerabilities[0].VulnHash)
+		assert.Equal(t, 1, comparison.Unchanged.Total)
+		assert.Equal(t, "2", comparison.Unchanged.Vulnerabilities[0].Line)
+	})
+
+	t.Run("should return empty buckets when both analyses have no vulnerabilities", func(t *testing.T) {
+		comparison := NewAnalysisComparison(&Analysis{}, &Analysis{})
+
+		assert.Equal(t, 0, comparison.New.Total)
+		assert.Empty(t, comparison.Fixed.Vulnerabilities)
+		assert.NotNil(t, comparison.Unchanged.TotalBySeverity)
+		assert.Empty(t, comparison.AppearedErrors)
+		assert.Empty(t, comparison.DisappearedErrors)
+	})
+
+	t.Run("should return the errors that appeared and disappeared", func(t *testing.T) {
+		base := newAnalysisToCompare("gosec failed; bandit timeout")
+		head := newAnalysisToCompare("bandit timeout; eslint failed")
+
+		comparison := NewAnalysisComparison(base, head)
+
+		assert.Equal(t, []string{"eslint failed"}, comparison.AppearedErrors)
+		assert.Equal(t, []string{"gosec failed"}, comparison.DisappearedErrors)
+	})
+}
+
+func TestGetErrors(t *testing.T) {
+	t.Run("should split errors removing empty and duplicated", func(t *testing.T) {
+		analysis := &Analysis{Errors: "gosec failed; ; gosec failed; bandit timeout"}
+
+		assert.Equal(t, []string{"gosec failed", "bandit timeout"}, analysis.GetErrors())
+	})
+
+	t.Run("should return empty when there are no errors", func(t *testing.T) {
+		assert.Empty(t, (&Analysis{}).GetErrors())
+	})
+}
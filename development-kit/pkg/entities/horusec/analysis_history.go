@@ -0,0 +1,72 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horusec
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+const HistoryFilterMaxSize = 100
+
+// AnalysisSummary is an analysis of the history of a repository with the totals of its vulnerabilities
+type AnalysisSummary struct {
+	ID                   uuid.UUID      `json:"id" gorm:"Column:analysis_id"`
+	Branch               string         `json:"branch" gorm:"Column:branch"`
+	Commit               string         `json:"commit" gorm:"Column:commit_hash"`
+	Status               horusec.Status `json:"status" gorm:"Column:status"`
+	CreatedAt            time.Time      `json:"createdAt" gorm:"Column:created_at"`
+	FinishedAt           time.Time      `json:"finishedAt" gorm:"Column:finished_at"`
+	TotalVulnerabilities int            `json:"totalVulnerabilities" gorm:"Column:total"`
+	Low                  int            `json:"low" gorm:"Column:low"`
+	Medium               int            `json:"medium" gorm:"Column:medium"`
+	High                 int            `json:"high" gorm:"Column:high"`
+	Audit                int            `json:"audit" gorm:"Column:audit"`
+	NoSec                int            `json:"noSec" gorm:"Column:no_sec"`
+	Info                 int            `json:"info" gorm:"Column:info"`
+}
+
+type AnalysisHistory struct {
+	TotalItems int               `json:"totalItems"`
+	Data       []AnalysisSummary `json:"data"`
+}
+
+type HistoryFilter struct {
+	RepositoryID uuid.UUID
+	Branch       string
+	Status       horusec.Status
+	Page         int
+	Size         int
+}
+
+func (f *HistoryFilter) Validate() error {
+	return validation.ValidateStruct(f,
+		validation.Field(&f.Status, validation.In(horusec.Running, horusec.Success, horusec.Error)),
+		validation.Field(&f.Page, validation.Min(0)),
+		validation.Field(&f.Size, validation.Min(0), validation.Max(HistoryFilterMaxSize)),
+	)
+}
+
+// GetSize returns the default page size when it is not informed
+func (f *HistoryFilter) GetSize() int {
+	if f.Size <= 0 {
+		return 10
+	}
+
+	return f.Size
+}
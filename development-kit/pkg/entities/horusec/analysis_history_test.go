@@ -0,0 +1,49 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horusec
+
+import (
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryFilterValidate(t *testing.T) {
+	t.Run("should return nil when filter is valid", func(t *testing.T) {
+		filter := &HistoryFilter{Status: horusec.Success, Page: 1, Size: HistoryFilterMaxSize}
+		assert.NoError(t, filter.Validate())
+	})
+
+	t.Run("should return error when status is invalid", func(t *testing.T) {
+		filter := &HistoryFilter{Status: "unknown"}
+		assert.Error(t, filter.Validate())
+	})
+
+	t.Run("should return error when size is greater than max", func(t *testing.T) {
+		filter := &HistoryFilter{Size: HistoryFilterMaxSize + 1}
+		assert.Error(t, filter.Validate())
+	})
+}
+
+func TestHistoryFilterGetSize(t *testing.T) {
+	t.Run("should return default size when it is not informed", func(t *testing.T) {
+		assert.Equal(t, 10, (&HistoryFilter{}).GetSize())
+	})
+
+	t.Run("should return the informed size", func(t *testing.T) {
+		assert.Equal(t, 50, (&HistoryFilter{Size: 50}).GetSize())
+	})
+}
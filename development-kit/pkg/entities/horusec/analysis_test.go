@@ -22,6 +22,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestTableName(t *testing.T) {
@@ -110,6 +111,15 @@ func TestSetRepositoryName(t *testing.T) {
 	})
 }
 
+func TestSetGitInfo(t *testing.T) {
+	t.Run("should success set branch and commit", func(t *testing.T) {
+		analysis := (&Analysis{}).SetGitInfo("main", "a1b2c3")
+
+		assert.Equal(t, "main", analysis.Branch)
+		assert.Equal(t, "a1b2c3", analysis.GetAnalysisWithoutAnalysisVulnerabilities().Commit)
+	})
+}
+
 func TestGenerateID(t *testing.T) {
 	t.Run("should success return a new id", func(t *testing.T) {
 		analysis := &Analysis{
@@ -143,6 +153,38 @@ func TestSetAnalysisFinishedData(t *testing.T) {
 	})
 }
 
+func TestSetPolicyVerdict(t *testing.T) {
+	t.Run("should set the analysis as passed when there are no reasons", func(t *testing.T) {
+		analysis := (&Analysis{}).SetPolicyVerdict(nil)
+
+		assert.Equal(t, horusecEnum.PolicyPassed, analysis.PolicyStatus)
+		assert.False(t, analysis.IsPolicyFailed())
+	})
+
+	t.Run("should set the analysis as failed when there are reasons", func(t *testing.T) {
+		analysis := (&Analysis{}).SetPolicyVerdict([]string{"test"})
+
+		assert.Equal(t, horusecEnum.PolicyFailed, analysis.PolicyStatus)
+		assert.Len(t, analysis.PolicyReasons, 1)
+		assert.True(t, analysis.IsPolicyFailed())
+	})
+
+	t.Run("should set the analysis as not evaluated with the reason", func(t *testing.T) {
+		analysis := (&Analysis{}).SetPolicyNotEvaluated("test")
+
+		assert.Equal(t, horusecEnum.PolicyNotEvaluated, analysis.PolicyStatus)
+		assert.Equal(t, []string{"test"}, []string(analysis.PolicyReasons))
+		assert.False(t, analysis.IsPolicyFailed())
+	})
+
+	t.Run("should clear the policy verdict", func(t *testing.T) {
+		analysis := (&Analysis{}).SetPolicyVerdict([]string{"test"}).ClearPolicyVerdict()
+
+		assert.Empty(t, analysis.PolicyStatus)
+		assert.Empty(t, analysis.PolicyReasons)
+	})
+}
+
 func TestGetTotalVulnerabilities(t *testing.T) {
 	t.Run("should return total of 1 vulnerability", func(t *testing.T) {
 		analysis := &Analysis{
@@ -302,6 +344,42 @@ func TestSetFalsePositivesAndRiskAcceptInVulnerabilities(t *testing.T) {
 		assert.Equal(t, analysis.AnalysisVulnerabilities[0].Vulnerability.Type, horusecEnum.FalsePositive)
 		assert.Equal(t, analysis.AnalysisVulnerabilities[1].Vulnerability.Type, horusecEnum.RiskAccepted)
 	})
+
+	t.Run("should set risk accepted until when entry has expiration date", func(t *testing.T) {
+		analysis := &Analysis{
+			AnalysisVulnerabilities: []AnalysisVulnerabilities{
+				{Vulnerability: Vulnerability{VulnHash: "1"}},
+				{Vulnerability: Vulnerability{VulnHash: "2", Type: horusecEnum.Vulnerability}},
+			},
+		}
+
+		tomorrow := time.Now().AddDate(0, 0, 1).Format(RiskAcceptDateLayout)
+		analysis.SetFalsePositivesAndRiskAcceptInVulnerabilities([]string{}, []string{"1:" + tomorrow, "2:2020-01-01"})
+		assert.Equal(t, horusecEnum.RiskAccepted, analysis.AnalysisVulnerabilities[0].Vulnerability.Type)
+		assert.NotNil(t, analysis.AnalysisVulnerabilities[0].Vulnerability.RiskAcceptedUntil)
+		assert.Equal(t, horusecEnum.Vulnerability, analysis.AnalysisVulnerabilities[1].Vulnerability.Type)
+		assert.Nil(t, analysis.AnalysisVulnerabilities[1].Vulnerability.RiskAcceptedUntil)
+	})
+}
+
+func TestSetExpiredRiskAcceptToVulnerability(t *testing.T) {
+	t.Run("should set vulnerability type when risk accept is expired", func(t *testing.T) {
+		yesterday := time.Now().AddDate(0, 0, -1)
+		tomorrow := time.Now().AddDate(0, 0, 1)
+		analysis := &Analysis{
+			AnalysisVulnerabilities: []AnalysisVulnerabilities{
+				{Vulnerability: Vulnerability{Type: horusecEnum.RiskAccepted, RiskAcceptedUntil: &yesterday}},
+				{Vulnerability: Vulnerability{Type: horusecEnum.RiskAccepted, RiskAcceptedUntil: &tomorrow}},
+				{Vulnerability: Vulnerability{Type: horusecEnum.RiskAccepted}},
+			},
+		}
+
+		analysis.SetExpiredRiskAcceptToVulnerability()
+		assert.Equal(t, horusecEnum.Vulnerability, analysis.AnalysisVulnerabilities[0].Vulnerability.Type)
+		assert.Nil(t, analysis.AnalysisVulnerabilities[0].Vulnerability.RiskAcceptedUntil)
+		assert.Equal(t, horusecEnum.RiskAccepted, analysis.AnalysisVulnerabilities[1].Vulnerability.Type)
+		assert.Equal(t, horusecEnum.RiskAccepted, analysis.AnalysisVulnerabilities[2].Vulnerability.Type)
+	})
 }
 
 func TestParseResponseBytesToAnalysis(t *testing.T) {
@@ -0,0 +1,57 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horusec
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	RiskAcceptSeparator  = ":"
+	RiskAcceptDateLayout = "2006-01-02"
+)
+
+var ErrInvalidRiskAcceptDate = errors.New("{HORUSEC} risk accept expiration date must be in the format " +
+	RiskAcceptDateLayout)
+
+// RiskAccept represents an entry of the risk accept list, that can be only the vulnerability hash
+// or the hash followed by the last day that the risk is accepted, like "hash:2021-12-31"
+type RiskAccept struct {
+	VulnHash  string
+	ExpiresAt *time.Time
+}
+
+func NewRiskAcceptFromString(value string) (*RiskAccept, error) {
+	values := strings.SplitN(strings.TrimSpace(value), RiskAcceptSeparator, 2)
+	riskAccept := &RiskAccept{VulnHash: strings.TrimSpace(values[0])}
+	if len(values) == 1 {
+		return riskAccept, nil
+	}
+
+	date, err := time.Parse(RiskAcceptDateLayout, strings.TrimSpace(values[1]))
+	if err != nil {
+		return nil, ErrInvalidRiskAcceptDate
+	}
+
+	expiresAt := date.AddDate(0, 0, 1)
+	riskAccept.ExpiresAt = &expiresAt
+	return riskAccept, nil
+}
+
+func (r *RiskAccept) IsExpired(now time.Time) bool {
+	return r.ExpiresAt != nil && !now.Before(*r.ExpiresAt)
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horusec
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRiskAcceptFromString(t *testing.T) {
+	t.Run("should return risk accept without expiration", func(t *testing.T) {
+		riskAccept, err := NewRiskAcceptFromString(" hash ")
+		assert.NoError(t, err)
+		assert.Equal(t, "hash", riskAccept.VulnHash)
+		assert.Nil(t, riskAccept.ExpiresAt)
+		assert.False(t, riskAccept.IsExpired(time.Now()))
+	})
+
+	t.Run("should return risk accept expiring at the end of the day", func(t *testing.T) {
+		riskAccept, err := NewRiskAcceptFromString("hash:2021-03-01")
+		assert.NoError(t, err)
+		assert.Equal(t, "hash", riskAccept.VulnHash)
+		assert.Equal(t, time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC), *riskAccept.ExpiresAt)
+		assert.False(t, riskAccept.IsExpired(time.Date(2021, 3, 1, 23, 0, 0, 0, time.UTC)))
+		assert.True(t, riskAccept.IsExpired(time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("should return error when invalid date", func(t *testing.T) {
+		_, err := NewRiskAcceptFromString("hash:01/03/2021")
+		assert.Equal(t, ErrInvalidRiskAcceptDate, err)
+	})
+}
@@ -15,6 +15,8 @@
 package horusec
 
 import (
+	"time"
+
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/languages"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
@@ -23,23 +25,26 @@ import (
 )
 
 type Vulnerability struct {
-	VulnerabilityID uuid.UUID                 `json:"vulnerabilityID" gorm:"Column:vulnerability_id"`
-	Line            string                    `json:"line" gorm:"Column:line"`
-	Column          string                    `json:"column" gorm:"Column:column"`
-	Confidence      string                    `json:"confidence" gorm:"Column:confidence"`
-	File            string                    `json:"file" gorm:"Column:file"`
-	Code            string                    `json:"code" gorm:"Column:code"`
-	Details         string                    `json:"details" gorm:"Column:details"`
-	SecurityTool    tools.Tool                `json:"securityTool" gorm:"Column:security_tool"`
-	Language        languages.Language        `json:"language" gorm:"Column:language"`
-	Severity        severity.Severity         `json:"severity" gorm:"Column:severity"`
-	VulnHash        string                    `json:"vulnHash" gorm:"Column:vuln_hash"`
-	Type            horusec.VulnerabilityType `json:"type" gorm:"Column:type"`
-	CommitAuthor    string                    `json:"commitAuthor" gorm:"Column:commit_author"`
-	CommitEmail     string                    `json:"commitEmail" gorm:"Column:commit_email"`
-	CommitHash      string                    `json:"commitHash" gorm:"Column:commit_hash"`
-	CommitMessage   string                    `json:"commitMessage" gorm:"Column:commit_message"`
-	CommitDate      string                    `json:"commitDate" gorm:"Column:commit_date"`
+	VulnerabilityID      uuid.UUID                 `json:"vulnerabilityID" gorm:"Column:vulnerability_id"`
+	Line                 string                    `json:"line" gorm:"Column:line"`
+	Column               string                    `json:"column" gorm:"Column:column"`
+	Confidence           string                    `json:"confidence" gorm:"Column:confidence"`
+	File                 string                    `json:"file" gorm:"Column:file"`
+	Code                 string                    `json:"code" gorm:"Column:code"`
+	Details              string                    `json:"details" gorm:"Column:details"`
+	RuleID               string                    `json:"ruleID" gorm:"Column:rule_id"`
+	SecurityTool         tools.Tool                `json:"securityTool" gorm:"Column:security_tool"`
+	Language             languages.Language        `json:"language" gorm:"Column:language"`
+	Severity             severity.Severity         `json:"severity" gorm:"Column:severity"`
+	VulnHash             string                    `json:"vulnHash" gorm:"Column:vuln_hash"`
+	Type                 horusec.VulnerabilityType `json:"type" gorm:"Column:type"`
+	CommitAuthor         string                    `json:"commitAuthor" gorm:"Column:commit_author"`
+	CommitEmail          string                    `json:"commitEmail" gorm:"Column:commit_email"`
+	CommitHash           string                    `json:"commitHash" gorm:"Column:commit_hash"`
+	CommitMessage        string                    `json:"commitMessage" gorm:"Column:commit_message"`
+	CommitDate           string                    `json:"commitDate" gorm:"Column:commit_date"`
+	RiskAcceptedUntil    *time.Time                `json:"riskAcceptedUntil,omitempty" gorm:"Column:risk_accepted_until"`
+	RiskExpirationWarned bool                      `json:"-" gorm:"Column:risk_expiration_warned"`
 }
 
 func (v *Vulnerability) GetTable() string {
@@ -57,3 +62,30 @@ func (v *Vulnerability) SetType(vulnType horusec.VulnerabilityType) {
 		v.Type = horusec.Vulnerability
 	}
 }
+
+func (v *Vulnerability) SetRiskAcceptedUntil(riskAcceptedUntil *time.Time) {
+	if v.Type == horusec.RiskAccepted {
+		v.RiskAcceptedUntil = riskAcceptedUntil
+	} else {
+		v.RiskAcceptedUntil = nil
+	}
+
+	v.RiskExpirationWarned = false
+}
+
+func (v *Vulnerability) IsRiskAcceptExpired(now time.Time) bool {
+	return v.Type == horusec.RiskAccepted && v.RiskAcceptedUntil != nil && !now.Before(*v.RiskAcceptedUntil)
+}
+
+func (v *Vulnerability) NewTypeHistory(newType horusec.VulnerabilityType, accountID uuid.UUID,
+	comment string) *VulnerabilityTypeHistory {
+	return &VulnerabilityTypeHistory{
+		HistoryID:       uuid.New(),
+		VulnerabilityID: v.VulnerabilityID,
+		AccountID:       accountID,
+		OldType:         v.Type,
+		NewType:         newType,
+		Comment:         comment,
+		CreatedAt:       time.Now(),
+	}
+}
@@ -16,8 +16,10 @@ package horusec
 
 import (
 	horusecEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestGetTable(t *testing.T) {
@@ -48,3 +50,49 @@ func TestSetType(t *testing.T) {
 		assert.Equal(t, horusecEnum.Vulnerability, vulnerability.Type)
 	})
 }
+
+func TestNewTypeHistory(t *testing.T) {
+	t.Run("should return history with old and new type", func(t *testing.T) {
+		accountID := uuid.New()
+		vulnerability := &Vulnerability{VulnerabilityID: uuid.New(), Type: horusecEnum.Vulnerability}
+
+		history := vulnerability.NewTypeHistory(horusecEnum.FalsePositive, accountID, "test")
+		assert.NotEqual(t, uuid.Nil, history.HistoryID)
+		assert.Equal(t, vulnerability.VulnerabilityID, history.VulnerabilityID)
+		assert.Equal(t, accountID, history.AccountID)
+		assert.Equal(t, horusecEnum.Vulnerability, history.OldType)
+		assert.Equal(t, horusecEnum.FalsePositive, history.NewType)
+		assert.Equal(t, "test", history.Comment)
+		assert.NotEmpty(t, history.CreatedAt)
+		assert.NotEmpty(t, history.ToBytes())
+		assert.Equal(t, "vulnerabilities_type_history", history.GetTable())
+	})
+}
+
+func TestSetRiskAcceptedUntil(t *testing.T) {
+	t.Run("should set risk accepted until when type is risk accepted", func(t *testing.T) {
+		until := time.Now()
+		vulnerability := &Vulnerability{Type: horusecEnum.RiskAccepted, RiskExpirationWarned: true}
+		vulnerability.SetRiskAcceptedUntil(&until)
+		assert.Equal(t, &until, vulnerability.RiskAcceptedUntil)
+		assert.False(t, vulnerability.RiskExpirationWarned)
+	})
+
+	t.Run("should clear risk accepted until when type is not risk accepted", func(t *testing.T) {
+		until := time.Now()
+		vulnerability := &Vulnerability{Type: horusecEnum.FalsePositive, RiskAcceptedUntil: &until}
+		vulnerability.SetRiskAcceptedUntil(&until)
+		assert.Nil(t, vulnerability.RiskAcceptedUntil)
+	})
+}
+
+func TestIsRiskAcceptExpired(t *testing.T) {
+	t.Run("should return if risk accept is expired", func(t *testing.T) {
+		now := time.Now()
+		until := now.Add(-time.Minute)
+
+		assert.True(t, (&Vulnerability{Type: horusecEnum.RiskAccepted, RiskAcceptedUntil: &until}).IsRiskAcceptExpired(now))
+		assert.False(t, (&Vulnerability{Type: horusecEnum.RiskAccepted}).IsRiskAcceptExpired(now))
+		assert.False(t, (&Vulnerability{Type: horusecEnum.Vulnerability, RiskAcceptedUntil: &until}).IsRiskAcceptExpired(now))
+	})
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horusec
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/google/uuid"
+)
+
+type VulnerabilityTypeHistory struct {
+	HistoryID       uuid.UUID                 `json:"historyID" gorm:"Column:history_id"`
+	VulnerabilityID uuid.UUID                 `json:"vulnerabilityID" gorm:"Column:vulnerability_id"`
+	AccountID       uuid.UUID                 `json:"accountID" gorm:"Column:account_id"`
+	OldType         horusec.VulnerabilityType `json:"oldType" gorm:"Column:old_type"`
+	NewType         horusec.VulnerabilityType `json:"newType" gorm:"Column:new_type"`
+	Comment         string                    `json:"comment" gorm:"Column:comment"`
+	CreatedAt       time.Time                 `json:"createdAt" gorm:"Column:created_at"`
+}
+
+func (v *VulnerabilityTypeHistory) GetTable() string {
+	return "vulnerabilities_type_history"
+}
+
+func (v *VulnerabilityTypeHistory) ToBytes() []byte {
+	bytes, _ := json.Marshal(v)
+	return bytes
+}
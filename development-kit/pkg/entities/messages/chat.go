@@ -0,0 +1,56 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messages
+
+import (
+	"encoding/json"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+)
+
+type ChatMessage struct {
+	Channel notification.Channel `json:"channel"`
+	URL     string               `json:"url"`
+	Title   string               `json:"title"`
+	Text    string               `json:"text"`
+	Link    string               `json:"link"`
+	Fields  []ChatField          `json:"fields"`
+}
+
+type ChatField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (c *ChatMessage) ToBytes() []byte {
+	bytes, _ := json.Marshal(c)
+	return bytes
+}
+
+func (c *ChatMessage) SetDestination(channel notification.Channel, url string) *ChatMessage {
+	return &ChatMessage{
+		Channel: channel,
+		URL:     url,
+		Title:   c.Title,
+		Text:    c.Text,
+		Link:    c.Link,
+		Fields:  c.Fields,
+	}
+}
+
+func (c *ChatMessage) AddField(name, value string) *ChatMessage {
+	c.Fields = append(c.Fields, ChatField{Name: name, Value: value})
+	return c
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messages
+
+import (
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatMessage_ToBytes(t *testing.T) {
+	t.Run("should success parse to bytes", func(t *testing.T) {
+		chatMessage := &ChatMessage{}
+		assert.NotEmpty(t, chatMessage.ToBytes())
+	})
+}
+
+func TestChatMessage_SetDestination(t *testing.T) {
+	t.Run("should return a copy of message with channel and url", func(t *testing.T) {
+		chatMessage := &ChatMessage{Title: "test", Text: "test"}
+		chatMessage.AddField("name", "value")
+
+		result := chatMessage.SetDestination(notification.Slack, "http://example.com")
+
+		assert.Equal(t, notification.Slack, result.Channel)
+		assert.Equal(t, "http://example.com", result.URL)
+		assert.Equal(t, "test", result.Title)
+		assert.Len(t, result.Fields, 1)
+		assert.Empty(t, chatMessage.URL)
+	})
+}
+
+func TestChatMessage_AddField(t *testing.T) {
+	t.Run("should append fields to message", func(t *testing.T) {
+		chatMessage := &ChatMessage{}
+		chatMessage.AddField("first", "1").AddField("second", "2")
+
+		assert.Len(t, chatMessage.Fields, 2)
+		assert.Equal(t, "second", chatMessage.Fields[1].Name)
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"encoding/json"
+	"time"
+
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Notification struct {
+	NotificationID uuid.UUID                `json:"notificationID" gorm:"primary_key" swaggerignore:"true"`
+	Description    string                   `json:"description"`
+	Channel        notificationEnum.Channel `json:"channel"`
+	URL            string                   `json:"url"`
+	Events         pq.StringArray           `json:"events" gorm:"type:text[]"`
+	CompanyID      uuid.UUID                `json:"companyID" swaggerignore:"true"`
+	RepositoryID   *uuid.UUID               `json:"repositoryID"`
+	CreatedAt      time.Time                `json:"createdAt" swaggerignore:"true"`
+	UpdatedAt      time.Time                `json:"updatedAt" swaggerignore:"true"`
+}
+
+func (n *Notification) GetTable() string {
+	return "notifications"
+}
+
+func (n *Notification) Validate() error {
+	return validation.ValidateStruct(n,
+		validation.Field(&n.URL, validation.Required, is.URL),
+		validation.Field(&n.Channel, validation.Required, validation.In(n.channelValues()...)),
+		validation.Field(&n.Events, validation.Required, validation.Each(validation.In(n.eventValues()...))),
+		validation.Field(&n.CompanyID, validation.Required, is.UUID),
+	)
+}
+
+func (n *Notification) channelValues() (values []interface{}) {
+	for _, channel := range notificationEnum.Unknown.Values() {
+		values = append(values, channel)
+	}
+
+	return values
+}
+
+func (n *Notification) eventValues() (values []interface{}) {
+	for _, event := range notificationEnum.AnalysisFinished.Values() {
+		values = append(values, event.ToString())
+	}
+
+	return values
+}
+
+func (n *Notification) ToBytes() []byte {
+	bytes, _ := json.Marshal(n)
+	return bytes
+}
+
+func (n *Notification) SetCompanyID(companyIDString string) (*Notification, error) {
+	companyID, err := uuid.Parse(companyIDString)
+	if err != nil || companyID == uuid.Nil {
+		return nil, errorsEnum.ErrorInvalidCompanyID
+	}
+
+	n.CompanyID = companyID
+	return n, nil
+}
+
+func (n *Notification) SetNotificationID(id uuid.UUID) *Notification {
+	n.NotificationID = id
+	return n
+}
+
+func (n *Notification) SetCreateData() *Notification {
+	n.NotificationID = uuid.New()
+	n.CreatedAt = time.Now()
+	n.UpdatedAt = time.Now()
+	return n
+}
+
+func (n *Notification) HasEvent(event notificationEnum.Event) bool {
+	for _, item := range n.Events {
+		if item == event.ToString() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (n *Notification) IsToRepository(repositoryID uuid.UUID) bool {
+	return n.RepositoryID == nil || *n.RepositoryID == uuid.Nil || *n.RepositoryID == repositoryID
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"testing"
+
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotification_GetTable(t *testing.T) {
+	t.Run("should return table name", func(t *testing.T) {
+		n := &Notification{}
+		assert.Equal(t, "notifications", n.GetTable())
+	})
+}
+
+func TestNotification_Validate(t *testing.T) {
+	t.Run("should return no error when notification is valid", func(t *testing.T) {
+		n := &Notification{
+			URL:       "https://hooks.slack.com/services/test",
+			Channel:   notificationEnum.Slack,
+			Events:    []string{notificationEnum.AnalysisFinished.ToString()},
+			CompanyID: uuid.New(),
+		}
+		assert.NoError(t, n.Validate())
+	})
+	t.Run("should return error when url is invalid", func(t *testing.T) {
+		n := &Notification{
+			URL:       "invalid url",
+			Channel:   notificationEnum.Slack,
+			Events:    []string{notificationEnum.AnalysisFinished.ToString()},
+			CompanyID: uuid.New(),
+		}
+		assert.Equal(t, "url: must be a valid URL.", n.Validate().Error())
+	})
+	t.Run("should return error when channel is invalid", func(t *testing.T) {
+		n := &Notification{
+			URL:       "http://example.com",
+			Channel:   "irc",
+			Events:    []string{notificationEnum.AnalysisFinished.ToString()},
+			CompanyID: uuid.New(),
+		}
+		assert.Equal(t, "channel: must be a valid value.", n.Validate().Error())
+	})
+	t.Run("should return error when event is invalid", func(t *testing.T) {
+		n := &Notification{
+			URL:       "http://example.com",
+			Channel:   notificationEnum.Teams,
+			Events:    []string{"invalid"},
+			CompanyID: uuid.New(),
+		}
+		assert.Error(t, n.Validate())
+	})
+}
+
+func TestNotification_ToBytes(t *testing.T) {
+	n := &Notification{URL: "http://example.com"}
+	assert.NotEmpty(t, n.ToBytes())
+}
+
+func TestNotification_SetCompanyID(t *testing.T) {
+	t.Run("should return error when company id is invalid", func(t *testing.T) {
+		n := &Notification{}
+		result, err := n.SetCompanyID("invalid")
+		assert.Equal(t, errorsEnum.ErrorInvalidCompanyID, err)
+		assert.Nil(t, result)
+	})
+	t.Run("should set company id with success", func(t *testing.T) {
+		n := &Notification{}
+		result, err := n.SetCompanyID(uuid.New().String())
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, result.CompanyID)
+	})
+}
+
+func TestNotification_SetNotificationID(t *testing.T) {
+	n := &Notification{}
+	assert.NotEqual(t, uuid.Nil, n.SetNotificationID(uuid.New()).NotificationID)
+}
+
+func TestNotification_SetCreateData(t *testing.T) {
+	n := (&Notification{}).SetCreateData()
+	assert.NotEqual(t, uuid.Nil, n.NotificationID)
+	assert.False(t, n.CreatedAt.IsZero())
+	assert.False(t, n.UpdatedAt.IsZero())
+}
+
+func TestNotification_HasEvent(t *testing.T) {
+	n := &Notification{Events: []string{notificationEnum.UserInvited.ToString()}}
+	assert.True(t, n.HasEvent(notificationEnum.UserInvited))
+	assert.False(t, n.HasEvent(notificationEnum.AnalysisFinished))
+}
+
+func TestNotification_IsToRepository(t *testing.T) {
+	t.Run("should return true when notification is company wide", func(t *testing.T) {
+		n := &Notification{}
+		assert.True(t, n.IsToRepository(uuid.New()))
+	})
+	t.Run("should return true only to the configured repository", func(t *testing.T) {
+		repositoryID := uuid.New()
+		n := &Notification{RepositoryID: &repositoryID}
+		assert.True(t, n.IsToRepository(repositoryID))
+		assert.False(t, n.IsToRepository(uuid.New()))
+	})
+}
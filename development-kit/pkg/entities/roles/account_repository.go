@@ -18,6 +18,7 @@ import (
 	"time"
 
 	accountEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/account"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/google/uuid"
@@ -28,6 +29,7 @@ type AccountRepository struct {
 	AccountID    uuid.UUID         `json:"accountID" swaggerignore:"true"`
 	CompanyID    uuid.UUID         `json:"companyID" swaggerignore:"true"`
 	Role         accountEnums.Role `json:"role"`
+	CustomRoleID *uuid.UUID        `json:"customRoleID"`
 	CreatedAt    time.Time         `json:"createdAt" swaggerignore:"true"`
 	UpdatedAt    time.Time         `json:"updatedAt" swaggerignore:"true"`
 }
@@ -53,6 +55,15 @@ func (a *AccountRepository) SetUpdateData(role accountEnums.Role) *AccountReposi
 	return a
 }
 
+func (a *AccountRepository) SetCustomRoleID(customRoleID *uuid.UUID) *AccountRepository {
+	a.CustomRoleID = customRoleID
+	return a
+}
+
+func (a *AccountRepository) HasCustomRole() bool {
+	return a.CustomRoleID != nil && *a.CustomRoleID != uuid.Nil
+}
+
 func (a *AccountRepository) GetTable() string {
 	return "account_repository"
 }
@@ -70,3 +81,15 @@ func (a *AccountRepository) IsNotSupervisorOrAdmin() bool {
 func (a *AccountRepository) IsNotAdmin() bool {
 	return a.Role != accountEnums.Admin
 }
+
+// GetRolePermissions returns the permissions of the built-in role, without the permissions of the custom role
+func (a *AccountRepository) GetRolePermissions() []authEnums.Permission {
+	switch a.Role {
+	case accountEnums.Admin:
+		return authEnums.RepositoryAdmin.Permissions()
+	case accountEnums.Supervisor:
+		return authEnums.RepositorySupervisor.Permissions()
+	}
+
+	return authEnums.RepositoryMember.Permissions()
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/account"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -98,3 +99,28 @@ func TestIsNotAdminRepository(t *testing.T) {
 		assert.False(t, accountRepository.IsNotAdmin())
 	})
 }
+
+func TestCustomRoleRepository(t *testing.T) {
+	t.Run("should set the custom role", func(t *testing.T) {
+		customRoleID := uuid.New()
+		accountRepository := (&AccountRepository{}).SetCustomRoleID(&customRoleID)
+
+		assert.True(t, accountRepository.HasCustomRole())
+	})
+
+	t.Run("should return false when without custom role", func(t *testing.T) {
+		customRoleID := uuid.Nil
+
+		assert.False(t, (&AccountRepository{}).HasCustomRole())
+		assert.False(t, (&AccountRepository{CustomRoleID: &customRoleID}).HasCustomRole())
+	})
+}
+
+func TestGetRolePermissions(t *testing.T) {
+	t.Run("should return the permissions of the built-in role", func(t *testing.T) {
+		assert.Equal(t, authEnums.RepositoryAdmin.Permissions(), (&AccountRepository{Role: "admin"}).GetRolePermissions())
+		assert.Equal(t, []authEnums.Permission{authEnums.ManageVulnerabilityTypes},
+			(&AccountRepository{Role: "supervisor"}).GetRolePermissions())
+		assert.Empty(t, (&AccountRepository{Role: "member"}).GetRolePermissions())
+	})
+}
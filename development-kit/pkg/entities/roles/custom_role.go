@@ -0,0 +1,83 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roles
+
+import (
+	"time"
+
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// CustomRole is a set of permissions created by the company admins, it is given to repository members in addition to
+// the permissions of their built-in role
+type CustomRole struct {
+	CustomRoleID uuid.UUID      `json:"customRoleID" gorm:"primary_key" swaggerignore:"true"`
+	CompanyID    uuid.UUID      `json:"companyID" swaggerignore:"true"`
+	Name         string         `json:"name"`
+	Description  string         `json:"description"`
+	Permissions  pq.StringArray `json:"permissions" swaggertype:"array,string"`
+	CreatedAt    time.Time      `json:"createdAt" swaggerignore:"true"`
+	UpdatedAt    time.Time      `json:"updatedAt" swaggerignore:"true"`
+}
+
+func (c *CustomRole) GetTable() string {
+	return "custom_roles"
+}
+
+func (c *CustomRole) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.Description, validation.Length(0, 255)),
+		validation.Field(&c.Permissions, validation.Required, validation.Each(validation.By(c.validatePermission))),
+	)
+}
+
+func (c *CustomRole) validatePermission(value interface{}) error {
+	if authEnums.Permission(value.(string)).IsInvalid() {
+		return errors.ErrorInvalidCustomRolePermission
+	}
+
+	return nil
+}
+
+func (c *CustomRole) SetCreateData(companyID uuid.UUID) *CustomRole {
+	c.CustomRoleID = uuid.New()
+	c.CompanyID = companyID
+	c.CreatedAt = time.Now()
+	c.UpdatedAt = time.Now()
+	return c
+}
+
+func (c *CustomRole) SetUpdateData(data *CustomRole) *CustomRole {
+	c.Name = data.Name
+	c.Description = data.Description
+	c.Permissions = data.Permissions
+	c.UpdatedAt = time.Now()
+	return c
+}
+
+func (c *CustomRole) HasPermission(permission authEnums.Permission) bool {
+	for _, value := range c.Permissions {
+		if value == permission.ToString() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roles
+
+import (
+	"testing"
+
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCustomRole(t *testing.T) {
+	t.Run("should return no error when valid custom role", func(t *testing.T) {
+		customRole := &CustomRole{Name: "triager", Permissions: []string{"vulnerabilities:manage"}}
+		assert.NoError(t, customRole.Validate())
+	})
+
+	t.Run("should return error when without permissions", func(t *testing.T) {
+		customRole := &CustomRole{Name: "triager"}
+		assert.Error(t, customRole.Validate())
+	})
+
+	t.Run("should return error when invalid permission", func(t *testing.T) {
+		customRole := &CustomRole{Name: "triager", Permissions: []string{"tokens:delete"}}
+
+		err := customRole.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), errors.ErrorInvalidCustomRolePermission.Error())
+	})
+
+	t.Run("should return error when without name", func(t *testing.T) {
+		customRole := &CustomRole{Permissions: []string{"vulnerabilities:manage"}}
+		assert.Error(t, customRole.Validate())
+	})
+}
+
+func TestSetCreateDataCustomRole(t *testing.T) {
+	t.Run("should set the create data", func(t *testing.T) {
+		companyID := uuid.New()
+		customRole := (&CustomRole{}).SetCreateData(companyID)
+
+		assert.NotEqual(t, uuid.Nil, customRole.CustomRoleID)
+		assert.Equal(t, companyID, customRole.CompanyID)
+		assert.NotEmpty(t, customRole.CreatedAt)
+	})
+}
+
+func TestSetUpdateDataCustomRole(t *testing.T) {
+	t.Run("should only update the name, description and permissions", func(t *testing.T) {
+		customRoleID := uuid.New()
+		customRole := (&CustomRole{CustomRoleID: customRoleID}).SetUpdateData(&CustomRole{
+			CustomRoleID: uuid.New(), Name: "triager", Description: "test", Permissions: []string{"repository:manage"}})
+
+		assert.Equal(t, customRoleID, customRole.CustomRoleID)
+		assert.Equal(t, "triager", customRole.Name)
+		assert.Equal(t, "test", customRole.Description)
+		assert.Len(t, customRole.Permissions, 1)
+	})
+}
+
+func TestHasPermissionCustomRole(t *testing.T) {
+	t.Run("should check if the custom role has the permission", func(t *testing.T) {
+		customRole := &CustomRole{Permissions: []string{"vulnerabilities:manage"}}
+
+		assert.True(t, customRole.HasPermission(authEnums.ManageVulnerabilityTypes))
+		assert.False(t, customRole.HasPermission(authEnums.ManageRepositoryTokens))
+	})
+}
+
+func TestGetTableCustomRole(t *testing.T) {
+	t.Run("should return the table name", func(t *testing.T) {
+		assert.Equal(t, "custom_roles", (&CustomRole{}).GetTable())
+	})
+}
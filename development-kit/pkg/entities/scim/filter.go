@@ -0,0 +1,70 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+)
+
+var filterRegex = regexp.MustCompile(`^\s*([\w.]+)\s+(?i:eq)\s+"([^"]*)"\s*$`)
+
+// Filter is the only filter supported by the identity providers provisioning clients, an attribute equal to a value
+type Filter struct {
+	Attribute  string
+	Value      string
+	StartIndex int
+	Count      int
+}
+
+func NewFilter(filter string, startIndex, count int) (*Filter, error) {
+	result := &Filter{StartIndex: startIndex, Count: count}
+	if startIndex < 1 {
+		result.StartIndex = 1
+	}
+
+	if count <= 0 || count > MaxCount {
+		result.Count = MaxCount
+	}
+
+	if strings.TrimSpace(filter) == "" {
+		return result, nil
+	}
+
+	matches := filterRegex.FindStringSubmatch(filter)
+	if matches == nil {
+		return nil, errors.ErrorScimInvalidFilter
+	}
+
+	result.Attribute, result.Value = matches[1], matches[2]
+	return result, nil
+}
+
+func (f *Filter) GetOffset() int {
+	return f.StartIndex - 1
+}
+
+// GetColumn returns the database column of the filter attribute, empty when the attribute is not supported
+func (f *Filter) GetColumn(columns map[string]string) string {
+	for attribute, column := range columns {
+		if strings.EqualFold(attribute, f.Attribute) {
+			return column
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFilter(t *testing.T) {
+	t.Run("should parse an equal filter", func(t *testing.T) {
+		filter, err := NewFilter(`userName Eq "test@horusec.io"`, 2, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "userName", filter.Attribute)
+		assert.Equal(t, "test@horusec.io", filter.Value)
+		assert.Equal(t, 1, filter.GetOffset())
+		assert.Equal(t, 10, filter.Count)
+	})
+
+	t.Run("should use default pagination when it is invalid", func(t *testing.T) {
+		filter, err := NewFilter("", 0, 1000)
+
+		assert.NoError(t, err)
+		assert.Empty(t, filter.Attribute)
+		assert.Equal(t, 0, filter.GetOffset())
+		assert.Equal(t, MaxCount, filter.Count)
+	})
+
+	t.Run("should return error when filter is not supported", func(t *testing.T) {
+		_, err := NewFilter(`userName sw "test"`, 1, 10)
+
+		assert.Equal(t, errors.ErrorScimInvalidFilter, err)
+	})
+}
+
+func TestGetColumn(t *testing.T) {
+	t.Run("should return the column of the attribute ignoring case", func(t *testing.T) {
+		filter, _ := NewFilter(`username eq "test"`, 1, 10)
+
+		assert.Equal(t, "username", filter.GetColumn(map[string]string{"userName": "username"}))
+		assert.Empty(t, filter.GetColumn(map[string]string{"externalId": "external_id"}))
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type Group struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []Member `json:"members"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+type Member struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+func NewGroup(group *authEntities.ScimGroup, members []authEntities.Account) *Group {
+	result := &Group{
+		Schemas:     []string{GroupSchema},
+		ID:          group.GroupID.String(),
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		Members:     []Member{},
+		Meta: &Meta{ResourceType: GroupResourceType, Created: group.CreatedAt,
+			LastModified: group.UpdatedAt},
+	}
+
+	for _, member := range members {
+		result.Members = append(result.Members, Member{Value: member.AccountID.String(), Display: member.Username})
+	}
+
+	return result
+}
+
+func (g *Group) Validate() error {
+	return validation.ValidateStruct(g,
+		validation.Field(&g.DisplayName, validation.Required, validation.Length(1, 255)),
+		validation.Field(&g.ExternalID, validation.Length(0, 255)),
+		validation.Field(&g.Members, validation.Each(validation.By(g.validateMember))),
+	)
+}
+
+func (g *Group) validateMember(value interface{}) error {
+	if _, err := uuid.Parse(value.(Member).Value); err != nil {
+		return validation.NewError("validation_is_uuid", "must be the id of an user")
+	}
+
+	return nil
+}
+
+// GetMemberIDs returns the ids of the members without duplicates
+func (g *Group) GetMemberIDs() (ids []uuid.UUID) {
+	seen := map[uuid.UUID]bool{}
+	for _, member := range g.Members {
+		id, err := uuid.Parse(member.Value)
+		if err == nil && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+func (g *Group) ToScimGroup(group *authEntities.ScimGroup) *authEntities.ScimGroup {
+	group.DisplayName = g.DisplayName
+	group.ExternalID = g.ExternalID
+	return group
+}
+
+func (g *Group) addMembers(members []Member) {
+	g.Members = append(g.Members, members...)
+}
+
+func (g *Group) removeMember(id string) {
+	members := []Member{}
+	for _, member := range g.Members {
+		if member.Value != id {
+			members = append(members, member)
+		}
+	}
+
+	g.Members = members
+}
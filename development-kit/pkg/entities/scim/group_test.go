@@ -0,0 +1,78 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"testing"
+
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGroup(t *testing.T) {
+	t.Run("should create scim group with members", func(t *testing.T) {
+		group := &authEntities.ScimGroup{GroupID: uuid.New(), DisplayName: "security-team"}
+		accountID := uuid.New()
+
+		result := NewGroup(group, []authEntities.Account{{AccountID: accountID, Username: "test"}})
+
+		assert.Equal(t, group.GroupID.String(), result.ID)
+		assert.Equal(t, "security-team", result.DisplayName)
+		assert.Equal(t, []Member{{Value: accountID.String(), Display: "test"}}, result.Members)
+	})
+
+	t.Run("should create scim group with empty members", func(t *testing.T) {
+		result := NewGroup(&authEntities.ScimGroup{}, nil)
+
+		assert.NotNil(t, result.Members)
+	})
+}
+
+func TestValidateGroup(t *testing.T) {
+	t.Run("should return no error when group is valid", func(t *testing.T) {
+		group := &Group{DisplayName: "test", Members: []Member{{Value: uuid.New().String()}}}
+
+		assert.NoError(t, group.Validate())
+	})
+
+	t.Run("should return error when member is not an uuid", func(t *testing.T) {
+		group := &Group{DisplayName: "test", Members: []Member{{Value: "test"}}}
+
+		assert.Error(t, group.Validate())
+	})
+
+	t.Run("should return error when display name is empty", func(t *testing.T) {
+		assert.Error(t, (&Group{}).Validate())
+	})
+}
+
+func TestGetMemberIDs(t *testing.T) {
+	t.Run("should return member ids without duplicates", func(t *testing.T) {
+		id := uuid.New()
+		group := &Group{Members: []Member{{Value: id.String()}, {Value: id.String()}, {Value: "test"}}}
+
+		assert.Equal(t, []uuid.UUID{id}, group.GetMemberIDs())
+	})
+}
+
+func TestToScimGroup(t *testing.T) {
+	t.Run("should set the group data", func(t *testing.T) {
+		group := (&Group{DisplayName: "test", ExternalID: "external"}).ToScimGroup(&authEntities.ScimGroup{})
+
+		assert.Equal(t, "test", group.DisplayName)
+		assert.Equal(t, "external", group.ExternalID)
+	})
+}
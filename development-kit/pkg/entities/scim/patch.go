@@ -0,0 +1,272 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+)
+
+const (
+	OperationAdd     = "add"
+	OperationRemove  = "remove"
+	OperationReplace = "replace"
+)
+
+var memberPathRegex = regexp.MustCompile(`^members\[\s*value\s+(?i:eq)\s+"([^"]*)"\s*\]$`)
+
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type userPatchValue struct {
+	UserName   *string         `json:"userName"`
+	ExternalID *string         `json:"externalId"`
+	Active     json.RawMessage `json:"active"`
+	Emails     []Email         `json:"emails"`
+}
+
+type groupPatchValue struct {
+	DisplayName *string  `json:"displayName"`
+	ExternalID  *string  `json:"externalId"`
+	Members     []Member `json:"members"`
+}
+
+// ApplyToUser changes the user with the operations, attributes not stored by horusec are ignored
+func (p *PatchRequest) ApplyToUser(user *User) error {
+	for index := range p.Operations {
+		operation := &p.Operations[index]
+		if operation.getOp() == OperationRemove {
+			continue
+		}
+
+		value, err := operation.getUserValue()
+		if err != nil {
+			return err
+		}
+
+		if err := value.apply(user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ApplyToGroup changes the group with the operations, members are identified by the user id
+func (p *PatchRequest) ApplyToGroup(group *Group) error {
+	for index := range p.Operations {
+		if err := p.Operations[index].applyToGroup(group); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *PatchOperation) getOp() string {
+	return strings.ToLower(o.Op)
+}
+
+func (o *PatchOperation) getUserValue() (*userPatchValue, error) {
+	if o.Path == "" {
+		value := &userPatchValue{}
+		return value, o.unmarshalValue(value)
+	}
+
+	switch path := strings.ToLower(o.Path); {
+	case path == "username":
+		return o.getUserStringValue(func(value *userPatchValue, text *string) { value.UserName = text })
+	case path == "externalid":
+		return o.getUserStringValue(func(value *userPatchValue, text *string) { value.ExternalID = text })
+	case path == "active":
+		return &userPatchValue{Active: o.Value}, nil
+	case strings.HasPrefix(path, "emails"):
+		return o.getUserEmailValue(path)
+	default:
+		return &userPatchValue{}, nil
+	}
+}
+
+func (o *PatchOperation) getUserStringValue(set func(value *userPatchValue, text *string)) (*userPatchValue, error) {
+	var text string
+	if err := o.unmarshalValue(&text); err != nil {
+		return nil, err
+	}
+
+	value := &userPatchValue{}
+	set(value, &text)
+	return value, nil
+}
+
+func (o *PatchOperation) getUserEmailValue(path string) (*userPatchValue, error) {
+	if path == "emails" {
+		value := &userPatchValue{}
+		return value, o.unmarshalValue(&value.Emails)
+	}
+
+	var email string
+	if err := o.unmarshalValue(&email); err != nil {
+		return nil, err
+	}
+
+	return &userPatchValue{Emails: []Email{{Value: email, Primary: true}}}, nil
+}
+
+func (o *PatchOperation) unmarshalValue(value interface{}) error {
+	if err := json.Unmarshal(o.Value, value); err != nil {
+		return errors.ErrorScimInvalidPatchValue
+	}
+
+	return nil
+}
+
+func (v *userPatchValue) apply(user *User) error {
+	if v.UserName != nil {
+		user.UserName = *v.UserName
+	}
+
+	if v.ExternalID != nil {
+		user.ExternalID = *v.ExternalID
+	}
+
+	if len(v.Emails) > 0 {
+		user.Emails = v.Emails
+	}
+
+	return v.applyActive(user)
+}
+
+// applyActive accepts booleans and strings because some identity providers send "False" as the active value
+func (v *userPatchValue) applyActive(user *User) error {
+	if len(v.Active) == 0 {
+		return nil
+	}
+
+	var active bool
+	if err := json.Unmarshal(v.Active, &active); err == nil {
+		user.SetActive(active)
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(v.Active, &text); err != nil {
+		return errors.ErrorScimInvalidPatchValue
+	}
+
+	active, err := strconv.ParseBool(strings.ToLower(text))
+	if err != nil {
+		return errors.ErrorScimInvalidPatchValue
+	}
+
+	user.SetActive(active)
+	return nil
+}
+
+func (o *PatchOperation) applyToGroup(group *Group) error {
+	switch o.getOp() {
+	case OperationAdd, OperationReplace:
+		return o.setGroupValue(group)
+	case OperationRemove:
+		return o.removeGroupMembers(group)
+	default:
+		return errors.ErrorScimInvalidPatchOperation
+	}
+}
+
+func (o *PatchOperation) setGroupValue(group *Group) error {
+	value := &groupPatchValue{}
+	switch path := strings.ToLower(o.Path); path {
+	case "":
+		if err := o.unmarshalValue(value); err != nil {
+			return err
+		}
+	case "displayname":
+		if err := o.unmarshalValue(&value.DisplayName); err != nil {
+			return err
+		}
+	case "externalid":
+		if err := o.unmarshalValue(&value.ExternalID); err != nil {
+			return err
+		}
+	case "members":
+		if err := o.unmarshalValue(&value.Members); err != nil {
+			return err
+		}
+	default:
+		return errors.ErrorScimInvalidPatchPath
+	}
+
+	value.apply(group, o.getOp() == OperationReplace)
+	return nil
+}
+
+func (v *groupPatchValue) apply(group *Group, isReplace bool) {
+	if v.DisplayName != nil {
+		group.DisplayName = *v.DisplayName
+	}
+
+	if v.ExternalID != nil {
+		group.ExternalID = *v.ExternalID
+	}
+
+	if v.Members == nil {
+		return
+	}
+
+	if isReplace {
+		group.Members = []Member{}
+	}
+
+	group.addMembers(v.Members)
+}
+
+func (o *PatchOperation) removeGroupMembers(group *Group) error {
+	if matches := memberPathRegex.FindStringSubmatch(o.Path); matches != nil {
+		group.removeMember(matches[1])
+		return nil
+	}
+
+	if !strings.EqualFold(o.Path, "members") {
+		return errors.ErrorScimInvalidPatchPath
+	}
+
+	if len(o.Value) == 0 {
+		group.Members = []Member{}
+		return nil
+	}
+
+	var members []Member
+	if err := o.unmarshalValue(&members); err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		group.removeMember(member.Value)
+	}
+
+	return nil
+}
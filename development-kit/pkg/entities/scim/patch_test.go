@@ -0,0 +1,117 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPatchRequest(operations string) *PatchRequest {
+	request := &PatchRequest{}
+	_ = json.Unmarshal([]byte(`{"Operations": `+operations+`}`), request)
+	return request
+}
+
+func TestApplyToUser(t *testing.T) {
+	t.Run("should apply operations with path", func(t *testing.T) {
+		user := &User{UserName: "test"}
+		request := newPatchRequest(`[{"op": "Replace", "path": "active", "value": "False"},
+			{"op": "replace", "path": "userName", "value": "new"},
+			{"op": "replace", "path": "externalId", "value": "external"},
+			{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "new@horusec.io"},
+			{"op": "replace", "path": "name.givenName", "value": "ignored"},
+			{"op": "remove", "path": "title"}]`)
+
+		assert.NoError(t, request.ApplyToUser(user))
+		assert.False(t, user.IsActive())
+		assert.Equal(t, "new", user.UserName)
+		assert.Equal(t, "external", user.ExternalID)
+		assert.Equal(t, "new@horusec.io", user.GetEmail())
+	})
+
+	t.Run("should apply operations without path", func(t *testing.T) {
+		user := (&User{UserName: "test"}).SetActive(false)
+		request := newPatchRequest(`[{"op": "replace", "value": {"active": true, "userName": "new",
+			"emails": [{"value": "new@horusec.io", "primary": true}]}}]`)
+
+		assert.NoError(t, request.ApplyToUser(user))
+		assert.True(t, user.IsActive())
+		assert.Equal(t, "new", user.UserName)
+		assert.Equal(t, "new@horusec.io", user.GetEmail())
+	})
+
+	t.Run("should return error when value is invalid", func(t *testing.T) {
+		user := &User{}
+
+		assert.Equal(t, errors.ErrorScimInvalidPatchValue, newPatchRequest(
+			`[{"op": "replace", "path": "active", "value": "test"}]`).ApplyToUser(user))
+		assert.Equal(t, errors.ErrorScimInvalidPatchValue, newPatchRequest(
+			`[{"op": "replace", "path": "active", "value": 1}]`).ApplyToUser(user))
+		assert.Equal(t, errors.ErrorScimInvalidPatchValue, newPatchRequest(
+			`[{"op": "replace", "path": "userName", "value": true}]`).ApplyToUser(user))
+		assert.Equal(t, errors.ErrorScimInvalidPatchValue, newPatchRequest(
+			`[{"op": "replace", "value": "test"}]`).ApplyToUser(user))
+	})
+}
+
+func TestApplyToGroup(t *testing.T) {
+	t.Run("should add and remove members", func(t *testing.T) {
+		group := &Group{DisplayName: "test", Members: []Member{{Value: "1"}, {Value: "2"}, {Value: "3"}}}
+		request := newPatchRequest(`[{"op": "add", "path": "members", "value": [{"value": "4"}]},
+			{"op": "remove", "path": "members[value eq \"1\"]"},
+			{"op": "remove", "path": "members", "value": [{"value": "2"}]}]`)
+
+		assert.NoError(t, request.ApplyToGroup(group))
+		assert.Equal(t, []Member{{Value: "3"}, {Value: "4"}}, group.Members)
+	})
+
+	t.Run("should replace members and display name", func(t *testing.T) {
+		group := &Group{DisplayName: "test", Members: []Member{{Value: "1"}}}
+		request := newPatchRequest(`[{"op": "replace", "path": "members", "value": [{"value": "2"}]},
+			{"op": "replace", "path": "displayName", "value": "new"},
+			{"op": "replace", "value": {"externalId": "external"}}]`)
+
+		assert.NoError(t, request.ApplyToGroup(group))
+		assert.Equal(t, []Member{{Value: "2"}}, group.Members)
+		assert.Equal(t, "new", group.DisplayName)
+		assert.Equal(t, "external", group.ExternalID)
+	})
+
+	t.Run("should remove all members", func(t *testing.T) {
+		group := &Group{Members: []Member{{Value: "1"}}}
+
+		assert.NoError(t, newPatchRequest(`[{"op": "remove", "path": "members"}]`).ApplyToGroup(group))
+		assert.Empty(t, group.Members)
+	})
+
+	t.Run("should return error when operation is invalid", func(t *testing.T) {
+		group := &Group{}
+
+		assert.Equal(t, errors.ErrorScimInvalidPatchOperation, newPatchRequest(
+			`[{"op": "move", "path": "members"}]`).ApplyToGroup(group))
+		assert.Equal(t, errors.ErrorScimInvalidPatchPath, newPatchRequest(
+			`[{"op": "remove", "path": "displayName"}]`).ApplyToGroup(group))
+		assert.Equal(t, errors.ErrorScimInvalidPatchPath, newPatchRequest(
+			`[{"op": "add", "path": "owner", "value": "test"}]`).ApplyToGroup(group))
+		assert.Equal(t, errors.ErrorScimInvalidPatchValue, newPatchRequest(
+			`[{"op": "add", "path": "members", "value": "test"}]`).ApplyToGroup(group))
+		assert.Equal(t, errors.ErrorScimInvalidPatchValue, newPatchRequest(
+			`[{"op": "remove", "path": "members", "value": "test"}]`).ApplyToGroup(group))
+	})
+}
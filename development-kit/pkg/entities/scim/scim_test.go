@@ -0,0 +1,42 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewListResponse(t *testing.T) {
+	t.Run("should create a list response with the list schema", func(t *testing.T) {
+		response := NewListResponse([]*User{}, 10, 0, 1)
+
+		assert.Equal(t, []string{ListResponseSchema}, response.Schemas)
+		assert.Equal(t, 10, response.TotalResults)
+		assert.Equal(t, 1, response.StartIndex)
+	})
+}
+
+func TestNewError(t *testing.T) {
+	t.Run("should create an error with the status as string", func(t *testing.T) {
+		err := NewError(404, "", errors.New("test"))
+
+		assert.Equal(t, []string{ErrorSchema}, err.Schemas)
+		assert.Equal(t, "404", err.Status)
+		assert.Equal(t, "test", err.Detail)
+	})
+}
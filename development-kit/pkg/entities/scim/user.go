@@ -0,0 +1,104 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"strings"
+
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+type User struct {
+	Schemas    []string `json:"schemas"`
+	ID         string   `json:"id,omitempty"`
+	ExternalID string   `json:"externalId,omitempty"`
+	UserName   string   `json:"userName"`
+	Active     *bool    `json:"active,omitempty"`
+	Emails     []Email  `json:"emails,omitempty"`
+	Meta       *Meta    `json:"meta,omitempty"`
+}
+
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+func NewUser(account *authEntities.Account) *User {
+	active := !account.IsDisabled
+	return &User{
+		Schemas:    []string{UserSchema},
+		ID:         account.AccountID.String(),
+		ExternalID: account.ExternalID,
+		UserName:   account.Username,
+		Active:     &active,
+		Emails:     []Email{{Value: account.Email, Type: "work", Primary: true}},
+		Meta: &Meta{ResourceType: UserResourceType, Created: account.CreatedAt,
+			LastModified: account.UpdatedAt},
+	}
+}
+
+func (u *User) Validate() error {
+	err := validation.ValidateStruct(u,
+		validation.Field(&u.UserName, validation.Required, validation.Length(1, 255)),
+		validation.Field(&u.ExternalID, validation.Length(0, 255)),
+	)
+	if err != nil {
+		return err
+	}
+
+	return validation.Validate(u.GetEmail(), validation.Required.ErrorObject(
+		validation.NewError("validation_required", errors.ErrorScimUserEmailRequired.Error())), is.EmailFormat)
+}
+
+// GetEmail returns the primary email, the first one or the username when it is an email
+func (u *User) GetEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+
+	if is.EmailFormat.Validate(u.UserName) == nil && strings.Contains(u.UserName, "@") {
+		return u.UserName
+	}
+
+	return ""
+}
+
+// IsActive considers the user active when the identity provider does not send the attribute
+func (u *User) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+func (u *User) SetActive(active bool) *User {
+	u.Active = &active
+	return u
+}
+
+func (u *User) ToAccount(account *authEntities.Account) *authEntities.Account {
+	account.Username = u.UserName
+	account.Email = u.GetEmail()
+	account.ExternalID = u.ExternalID
+	account.IsDisabled = !u.IsActive()
+	return account
+}
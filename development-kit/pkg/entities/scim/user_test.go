@@ -0,0 +1,93 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"testing"
+
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUser(t *testing.T) {
+	t.Run("should create scim user from account", func(t *testing.T) {
+		account := &authEntities.Account{AccountID: uuid.New(), Username: "test", Email: "test@horusec.io",
+			ExternalID: "external", IsDisabled: true}
+
+		user := NewUser(account)
+
+		assert.Equal(t, account.AccountID.String(), user.ID)
+		assert.Equal(t, "test@horusec.io", user.GetEmail())
+		assert.Equal(t, "external", user.ExternalID)
+		assert.False(t, user.IsActive())
+		assert.Equal(t, UserResourceType, user.Meta.ResourceType)
+	})
+}
+
+func TestValidateUser(t *testing.T) {
+	t.Run("should return no error when user is valid", func(t *testing.T) {
+		user := &User{UserName: "test", Emails: []Email{{Value: "test@horusec.io"}}}
+
+		assert.NoError(t, user.Validate())
+	})
+
+	t.Run("should return error when user has no email", func(t *testing.T) {
+		assert.Error(t, (&User{UserName: "test"}).Validate())
+	})
+
+	t.Run("should return error when user has no user name", func(t *testing.T) {
+		assert.Error(t, (&User{Emails: []Email{{Value: "test@horusec.io"}}}).Validate())
+	})
+}
+
+func TestGetEmail(t *testing.T) {
+	t.Run("should return the primary email", func(t *testing.T) {
+		user := &User{Emails: []Email{{Value: "home@horusec.io"}, {Value: "work@horusec.io", Primary: true}}}
+
+		assert.Equal(t, "work@horusec.io", user.GetEmail())
+	})
+
+	t.Run("should return the first email when there is no primary", func(t *testing.T) {
+		user := &User{Emails: []Email{{Value: "home@horusec.io"}, {Value: "work@horusec.io"}}}
+
+		assert.Equal(t, "home@horusec.io", user.GetEmail())
+	})
+
+	t.Run("should return the user name when it is an email", func(t *testing.T) {
+		assert.Equal(t, "test@horusec.io", (&User{UserName: "test@horusec.io"}).GetEmail())
+		assert.Empty(t, (&User{UserName: "test"}).GetEmail())
+	})
+}
+
+func TestToAccount(t *testing.T) {
+	t.Run("should set the account provisioning data", func(t *testing.T) {
+		user := (&User{UserName: "test", ExternalID: "external",
+			Emails: []Email{{Value: "test@horusec.io"}}}).SetActive(false)
+
+		account := user.ToAccount(&authEntities.Account{})
+
+		assert.Equal(t, "test", account.Username)
+		assert.Equal(t, "test@horusec.io", account.Email)
+		assert.Equal(t, "external", account.ExternalID)
+		assert.True(t, account.IsDisabled)
+	})
+
+	t.Run("should enable the account when active is not sent", func(t *testing.T) {
+		account := (&User{}).ToAccount(&authEntities.Account{IsDisabled: true})
+
+		assert.False(t, account.IsDisabled)
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+type Action string
+
+const (
+	TokenCreate             Action = "token.create"
+	TokenDelete             Action = "token.delete"
+	TokenRotate             Action = "token.rotate"
+	PersonalTokenCreate     Action = "personal-token.create"
+	PersonalTokenRevoke     Action = "personal-token.revoke"
+	CompanyCreate           Action = "company.create"
+	CompanyUpdate           Action = "company.update"
+	CompanyDelete           Action = "company.delete"
+	CompanyRoleAdd          Action = "company-role.add"
+	CompanyRoleUpdate       Action = "company-role.update"
+	CompanyRoleRemove       Action = "company-role.remove"
+	RepositoryCreate        Action = "repository.create"
+	RepositoryUpdate        Action = "repository.update"
+	RepositoryDelete        Action = "repository.delete"
+	RepositoryRoleAdd       Action = "repository-role.add"
+	RepositoryRoleUpdate    Action = "repository-role.update"
+	RepositoryRoleRemove    Action = "repository-role.remove"
+	CustomRoleCreate        Action = "custom-role.create"
+	CustomRoleUpdate        Action = "custom-role.update"
+	CustomRoleDelete        Action = "custom-role.delete"
+	PolicyCreate            Action = "policy.create"
+	PolicyUpdate            Action = "policy.update"
+	PolicyDelete            Action = "policy.delete"
+	VulnerabilityTypeUpdate Action = "vulnerability-type.update"
+	AccountLocked           Action = "account.locked"
+	AccountUnlocked         Action = "account.unlocked"
+)
+
+func (a Action) IsInvalid() bool {
+	for _, v := range a.Values() {
+		if v == a {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (a Action) Values() []Action {
+	return []Action{
+		TokenCreate,
+		TokenDelete,
+		TokenRotate,
+		PersonalTokenCreate,
+		PersonalTokenRevoke,
+		CompanyCreate,
+		CompanyUpdate,
+		CompanyDelete,
+		CompanyRoleAdd,
+		CompanyRoleUpdate,
+		CompanyRoleRemove,
+		RepositoryCreate,
+		RepositoryUpdate,
+		RepositoryDelete,
+		RepositoryRoleAdd,
+		RepositoryRoleUpdate,
+		RepositoryRoleRemove,
+		CustomRoleCreate,
+		CustomRoleUpdate,
+		CustomRoleDelete,
+		PolicyCreate,
+		PolicyUpdate,
+		PolicyDelete,
+		VulnerabilityTypeUpdate,
+		AccountLocked,
+		AccountUnlocked,
+	}
+}
+
+func (a Action) ToString() string {
+	return string(a)
+}
@@ -0,0 +1,43 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsInvalid(t *testing.T) {
+	t.Run("should return false when action is valid", func(t *testing.T) {
+		assert.False(t, TokenCreate.IsInvalid())
+	})
+
+	t.Run("should return true when action is invalid", func(t *testing.T) {
+		assert.True(t, Action("test").IsInvalid())
+	})
+}
+
+func TestValues(t *testing.T) {
+	t.Run("should return all actions", func(t *testing.T) {
+		assert.Len(t, Action("").Values(), 26)
+	})
+}
+
+func TestToString(t *testing.T) {
+	t.Run("should parse action to string", func(t *testing.T) {
+		assert.Equal(t, "token.create", TokenCreate.ToString())
+	})
+}
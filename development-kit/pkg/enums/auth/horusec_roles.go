@@ -46,6 +46,28 @@ func (h HorusecRoles) Values() []HorusecRoles {
 	}
 }
 
+// Permissions returns the permissions of the built-in role, company admins have all permissions of its repositories
+func (h HorusecRoles) Permissions() []Permission {
+	switch h {
+	case RepositorySupervisor:
+		return []Permission{ManageVulnerabilityTypes}
+	case RepositoryAdmin, CompanyAdmin:
+		return ManageVulnerabilityTypes.Values()
+	}
+
+	return []Permission{}
+}
+
+func (h HorusecRoles) HasPermission(permission Permission) bool {
+	for _, value := range h.Permissions() {
+		if value == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (h HorusecRoles) IsEqual(value string) bool {
 	return value == h.ToString()
 }
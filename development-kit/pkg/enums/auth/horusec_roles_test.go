@@ -69,3 +69,17 @@ func TestToStringRoles(t *testing.T) {
 		assert.IsType(t, "", testType.ToString())
 	})
 }
+
+func TestPermissionsRoles(t *testing.T) {
+	t.Run("should return the permissions of the role", func(t *testing.T) {
+		assert.Equal(t, []Permission{ManageVulnerabilityTypes}, RepositorySupervisor.Permissions())
+		assert.Len(t, RepositoryAdmin.Permissions(), 4)
+		assert.Len(t, CompanyAdmin.Permissions(), 4)
+		assert.Empty(t, RepositoryMember.Permissions())
+	})
+
+	t.Run("should check if the role has the permission", func(t *testing.T) {
+		assert.True(t, RepositorySupervisor.HasPermission(ManageVulnerabilityTypes))
+		assert.False(t, RepositorySupervisor.HasPermission(ManageRepositoryTokens))
+	})
+}
@@ -0,0 +1,60 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+// Permission is checked by the authz middleware instead of the role name, the built-in roles and the custom roles of
+// the companies are sets of permissions
+type Permission string
+
+const (
+	ManageVulnerabilityTypes Permission = "vulnerabilities:manage"
+	ManageRepository         Permission = "repository:manage"
+	ManageRepositoryMembers  Permission = "repository:members:manage"
+	ManageRepositoryTokens   Permission = "repository:tokens:manage"
+)
+
+func (p Permission) IsInvalid() bool {
+	for _, v := range p.Values() {
+		if v == p {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p Permission) Values() []Permission {
+	return []Permission{
+		ManageVulnerabilityTypes,
+		ManageRepository,
+		ManageRepositoryMembers,
+		ManageRepositoryTokens,
+	}
+}
+
+// Roles returns the built-in roles that have the permission, from the least to the most privileged
+func (p Permission) Roles() (roles []HorusecRoles) {
+	for _, role := range []HorusecRoles{RepositorySupervisor, RepositoryAdmin, CompanyAdmin} {
+		if role.HasPermission(p) {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
+
+func (p Permission) ToString() string {
+	return string(p)
+}
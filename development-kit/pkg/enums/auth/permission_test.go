@@ -0,0 +1,57 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsInvalidPermission(t *testing.T) {
+	t.Run("should return true when invalid permission", func(t *testing.T) {
+		assert.True(t, Permission("tokens:delete").IsInvalid())
+	})
+
+	t.Run("should return false when valid permission", func(t *testing.T) {
+		for _, permission := range ManageRepository.Values() {
+			assert.False(t, permission.IsInvalid())
+		}
+	})
+}
+
+func TestValuesPermission(t *testing.T) {
+	t.Run("should return 4 valid permissions", func(t *testing.T) {
+		assert.Len(t, ManageRepository.Values(), 4)
+	})
+}
+
+func TestRolesPermission(t *testing.T) {
+	t.Run("should return the roles with the permission from the least privileged", func(t *testing.T) {
+		assert.Equal(t, []HorusecRoles{RepositorySupervisor, RepositoryAdmin, CompanyAdmin},
+			ManageVulnerabilityTypes.Roles())
+		assert.Equal(t, []HorusecRoles{RepositoryAdmin, CompanyAdmin}, ManageRepositoryTokens.Roles())
+	})
+
+	t.Run("should return empty when invalid permission", func(t *testing.T) {
+		assert.Empty(t, Permission("test").Roles())
+	})
+}
+
+func TestToStringPermission(t *testing.T) {
+	t.Run("should parse to string", func(t *testing.T) {
+		assert.Equal(t, "repository:manage", ManageRepository.ToString())
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+// Scope limits the areas a personal access token can reach, requests without a scope are denied for these tokens
+type Scope string
+
+const (
+	ReadAnalytics         Scope = "read:analytics"
+	ManageVulnerabilities Scope = "manage:vulnerabilities"
+	ManageRepositories    Scope = "manage:repositories"
+)
+
+func (s Scope) IsInvalid() bool {
+	for _, v := range s.Values() {
+		if v == s {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s Scope) Values() []Scope {
+	return []Scope{
+		ReadAnalytics,
+		ManageVulnerabilities,
+		ManageRepositories,
+	}
+}
+
+func (s Scope) ToString() string {
+	return string(s)
+}
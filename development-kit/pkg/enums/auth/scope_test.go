@@ -0,0 +1,41 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsInvalidScope(t *testing.T) {
+	t.Run("should return true when invalid scope", func(t *testing.T) {
+		assert.True(t, Scope("write:all").IsInvalid())
+		assert.True(t, Scope("").IsInvalid())
+	})
+
+	t.Run("should return false when valid scope", func(t *testing.T) {
+		assert.False(t, Scope("read:analytics").IsInvalid())
+		assert.False(t, Scope("manage:vulnerabilities").IsInvalid())
+		assert.False(t, Scope("manage:repositories").IsInvalid())
+	})
+}
+
+func TestValuesScope(t *testing.T) {
+	t.Run("should return 3 valid scopes", func(t *testing.T) {
+		assert.Len(t, ReadAnalytics.Values(), 3)
+		assert.Equal(t, "read:analytics", ReadAnalytics.ToString())
+	})
+}
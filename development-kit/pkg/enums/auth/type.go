@@ -20,6 +20,7 @@ const (
 	Keycloak AuthorizationType = "keycloak"
 	Ldap     AuthorizationType = "ldap"
 	Horusec  AuthorizationType = "horusec"
+	OIDC     AuthorizationType = "oidc"
 	Unknown  AuthorizationType = "unknown"
 )
 
@@ -38,9 +39,14 @@ func (a AuthorizationType) Values() []AuthorizationType {
 		Keycloak,
 		Ldap,
 		Horusec,
+		OIDC,
 	}
 }
 
+func (a AuthorizationType) IsGroupBased() bool {
+	return a == Ldap || a == OIDC
+}
+
 func (a AuthorizationType) ToString() string {
 	return string(a)
 }
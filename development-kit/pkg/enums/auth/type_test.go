@@ -34,13 +34,28 @@ func TestIsInvalid(t *testing.T) {
 
 		testType = "horusec"
 		assert.False(t, testType.IsInvalid())
+
+		testType = "oidc"
+		assert.False(t, testType.IsInvalid())
 	})
 }
 
 func TestValues(t *testing.T) {
-	t.Run("should 3 valid auth types", func(t *testing.T) {
+	t.Run("should 4 valid auth types", func(t *testing.T) {
 		var testType AuthorizationType
-		assert.Len(t, testType.Values(), 3)
+		assert.Len(t, testType.Values(), 4)
+	})
+}
+
+func TestIsGroupBased(t *testing.T) {
+	t.Run("should return true when ldap or oidc", func(t *testing.T) {
+		assert.True(t, Ldap.IsGroupBased())
+		assert.True(t, OIDC.IsGroupBased())
+	})
+
+	t.Run("should return false when horusec or keycloak", func(t *testing.T) {
+		assert.False(t, Horusec.IsGroupBased())
+		assert.False(t, Keycloak.IsGroupBased())
 	})
 }
 
@@ -49,6 +64,7 @@ func TestToString(t *testing.T) {
 		assert.Equal(t, "horusec", Horusec.ToString())
 		assert.Equal(t, "ldap", Ldap.ToString())
 		assert.Equal(t, "keycloak", Keycloak.ToString())
+		assert.Equal(t, "oidc", OIDC.ToString())
 	})
 }
 
@@ -57,6 +73,7 @@ func TestGetAuthTypeByString(t *testing.T) {
 		assert.Equal(t, Horusec, GetAuthTypeByString("horusec"))
 		assert.Equal(t, Ldap, GetAuthTypeByString("ldap"))
 		assert.Equal(t, Keycloak, GetAuthTypeByString("keycloak"))
+		assert.Equal(t, OIDC, GetAuthTypeByString("oidc"))
 		assert.Equal(t, Unknown, GetAuthTypeByString("test"))
 	})
 }
@@ -18,6 +18,7 @@ import "errors"
 
 var ErrorWrongEmailOrPassword = errors.New("{ACCOUNT} invalid username or password")
 var ErrorAccountEmailNotConfirmed = errors.New("{ACCOUNT} account email not confirmed")
+var ErrorAccountDisabled = errors.New("{ACCOUNT} account disabled by the identity provider")
 var ErrorEmailAlreadyInUse = errors.New("{ACCOUNT} email already in use")
 var ErrorNewPasswordNotEqualOldPassword = errors.New("{ACCOUNT} new password is can't equals current password")
 var ErrorNewPasswordOrPasswordHashNotBeEmpty = errors.New("{ACCOUNT} password or password hash can't be empty")
@@ -38,3 +39,9 @@ var ErrorInvalidKeycloakToken = errors.New("{ACCOUNT} keycloak token without ema
 var ErrorUserLoggedIsNotApplicationAdmin = errors.New("{ACCOUNT} user logged is not application admin")
 var ErrorInvalidUpdateAccountData = errors.New("{ACCOUNT} the data to update account is not valid")
 var ErrorInvalidLdapGroup = errors.New("{ACCOUNT} admin ldap group should be a valid one for this user")
+var ErrorAccountLocked = errors.New("{ACCOUNT} account locked after too many failed login attempts")
+var ErrorTooManyLoginAttempts = errors.New("{ACCOUNT} too many login attempts, wait before trying again")
+var ErrorInvalidUnlockCode = errors.New("{ACCOUNT} invalid or expired unlock code")
+
+const ErrorRegisterLoginFailure = "{ACCOUNT} error when register failed login attempt"
+const ErrorSendAccountLockedEmail = "{ACCOUNT} error when send account locked email"
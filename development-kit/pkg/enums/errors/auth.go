@@ -16,7 +16,7 @@ package errors
 
 import "errors"
 
-var ErrorInvalidAuthType = errors.New("{AUTH} invalid auth type, should be ldap, keycloak or horus")
+var ErrorInvalidAuthType = errors.New("{AUTH} invalid auth type, should be ldap, keycloak, oidc or horus")
 var ErrorTokenCanNotBeEmpty = errors.New("{AUTH} token can not be empty in authorization header")
 
 const (
@@ -0,0 +1,27 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import "errors"
+
+var ErrorInvalidCustomRolePermission = errors.New("{ERROR_CUSTOM_ROLE} invalid permission")
+var ErrorCustomRoleNotFound = errors.New("{ERROR_CUSTOM_ROLE} custom role not found in this company")
+var ErrorCustomRoleNameAlreadyInUse = errors.New("{ERROR_CUSTOM_ROLE} name already in use in this company")
+var ErrorInvalidCustomRoleID = errors.New("{ERROR_CUSTOM_ROLE} invalid custom role id")
+var ErrorRoleGrantNotAllowed = errors.New(
+	"{ERROR_CUSTOM_ROLE} only company and repository admins can grant more permissions than they have")
+
+const ErrorAlreadyExistingCustomRoleName = "pq: duplicate key value violates unique constraint" +
+	" \"custom_roles_company_id_name_key\""
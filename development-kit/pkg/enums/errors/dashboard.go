@@ -19,3 +19,10 @@ import "errors"
 var ErrorMissingGraphqlQuery = errors.New("missing graphql query")
 
 const ErrorGraphqlSchema = "{GetVulnerabilitiesByAuthor} something went wrong while making graphql schema"
+
+const ErrorRefreshDailySnapshot = "{HORUSEC_API} error when refresh the daily snapshot of the analysis"
+
+var ErrorInvalidGraphqlCursor = errors.New("invalid graphql pagination cursor")
+var ErrorInvalidGraphqlPageSize = errors.New("invalid graphql page size, it must be between 1 and 100")
+var ErrorGraphqlMaxDepth = errors.New("graphql query exceeds the max depth of nested fields")
+var ErrorGraphqlMaxComplexity = errors.New("graphql query exceeds the max complexity, request less fields or pages")
@@ -19,3 +19,6 @@ import "errors"
 var ErrorEmptyBindDNOrBindPassword = errors.New("{LDAP} empty bind dn or bind password")
 var ErrorUserDoesNotExist = errors.New("{LDAP} user does not exist")
 var ErrorTooManyEntries = errors.New("{LDAP} too many entries returned")
+var ErrorLdapSyncDisabled = errors.New("{LDAP} group sync is only available when the auth type is ldap")
+
+const ErrorLdapSync = "{LDAP} error when sync groups to company and repository roles"
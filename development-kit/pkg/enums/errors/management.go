@@ -18,3 +18,12 @@ import "errors"
 
 var ErrVulnerabilityNotFound = errors.New("no vulnerability was found with this id")
 var ErrInvalidVulnerabilityID = errors.New("invalid vulnerability id")
+
+const ErrExportVulnerabilities = "{HORUSEC_API} error when export vulnerabilities"
+const ErrRevertExpiredRiskAccept = "{HORUSEC_API} error when revert expired risk accept"
+const ErrWarnExpiringRiskAccept = "{HORUSEC_API} error when warn expiring risk accept"
+const ErrRunRiskAcceptJob = "{HORUSEC_API} error when lock the risk accept job"
+const ErrWarnExpiringToken = "{HORUSEC_API} error when warn expiring token"
+const ErrPruneExpiredAnalysis = "{HORUSEC_API} error when prune expired analysis of the company"
+const MsgPruneWithoutDailySnapshots = "{HORUSEC_API} analysis retention skipped because the dashboard does not read" +
+	" the daily snapshots, enable HORUSEC_DASHBOARD_DAILY_SNAPSHOTS to prune"
@@ -21,6 +21,8 @@ const (
 	ErrParsePacketToResetPassword      = "{ERROR_MESSAGES} error when parse broker packet to reset password data"
 	ErrParsePacketToOrganizationInvite = "{ERROR_MESSAGES} error when parse broker packet to organization invite data"
 	ErrSendingEmail                    = "{ERROR_MESSAGES} error when send email"
+	ErrParsePacketToChatMessage        = "{ERROR_MESSAGES} error when parse broker packet to chat message"
+	ErrSendingChatMessage              = "{ERROR_MESSAGES} error when send chat message"
 )
 
 var ErrorSMTPServerIsNotAvailable = errors.New("{ERROR_SMTP} smtp server is not available")
@@ -24,5 +24,15 @@ var ErrorUnauthorizedRepositoryMember = errors.New("user unauthorized as reposit
 var ErrorUnauthorizedRepositorySupervisor = errors.New("user unauthorized as repository supervisor")
 var ErrorUnauthorizedRepositoryAdmin = errors.New("user unauthorized as repository admin")
 var ErrorUnauthorizedApplicationAdmin = errors.New("user unauthorized as application admin")
+var ErrorInvalidTokenExpiration = errors.New("token expiration date should be in the future")
+var ErrorNeverExpiresNotConfirmed = errors.New("token that never expires should be confirmed with confirmNeverExpires")
+var ErrorInvalidTokenID = errors.New("invalid token id")
 
 const SomethingWentWrongInGrpcRequest = "something went wrong in grpc request"
+const ErrorUpdateTokenLastUsed = "failed to update token last used data"
+
+var ErrorInvalidTokenPattern = errors.New("invalid pattern, check the syntax of the allowed names")
+var ErrorTokenRepositoryNotAllowed = errors.New("this authorization token is not allowed to send analysis to this repository")
+var ErrorTokenBranchNotAllowed = errors.New("this authorization token is not allowed to send analysis of this branch")
+var ErrorTokenAutoCreateDisabled = errors.New(
+	"this authorization token can not create repositories, create it before sending the analysis")
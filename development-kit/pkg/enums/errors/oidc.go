@@ -0,0 +1,27 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import "errors"
+
+var ErrorOIDCDiscovery = errors.New("{OIDC} failed to load provider discovery document")
+var ErrorOIDCCodeExchange = errors.New("{OIDC} failed to exchange authorization code")
+var ErrorInvalidOIDCState = errors.New("{OIDC} invalid or expired login state")
+var ErrorInvalidOIDCToken = errors.New("{OIDC} invalid id token")
+var ErrorOIDCKeyNotFound = errors.New("{OIDC} signing key not found in provider jwks")
+var ErrorOIDCMissingEmail = errors.New("{OIDC} id token does not contain the email claim")
+var ErrorOIDCEmailNotVerified = errors.New("{OIDC} email of the id token is not verified by the provider")
+var ErrorOIDCAccountLinked = errors.New("{OIDC} account of the email is linked to another identity")
+var ErrorOIDCUnexpectedResponse = errors.New("{OIDC} unexpected response from provider")
@@ -0,0 +1,27 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import "errors"
+
+var ErrorInvalidPolicyID = errors.New("{ERROR_POLICY} invalid policy id")
+var ErrorPolicyNotFound = errors.New("{ERROR_POLICY} policy not found in this company")
+var ErrorInvalidPolicyTool = errors.New("{ERROR_POLICY} invalid required tool")
+var ErrorInvalidPolicyLanguage = errors.New("{ERROR_POLICY} invalid required language")
+var ErrorPolicyRepositoryNotFound = errors.New("{ERROR_POLICY} repository of the policy not found in this company")
+var ErrorPolicyNotEvaluated = errors.New("{ERROR_POLICY} policies could not be evaluated, check horusec-api logs")
+
+const ErrEvaluatePolicies = "{HORUSEC_API} error when evaluate the policies of the analysis"
+const ErrSavePolicyVerdict = "{HORUSEC_API} error when save the policies verdict of the analysis"
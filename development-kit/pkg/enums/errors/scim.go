@@ -0,0 +1,35 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import "errors"
+
+var ErrorScimDisabled = errors.New("{ERROR_SCIM} scim provisioning is disabled")
+var ErrorScimInvalidToken = errors.New("{ERROR_SCIM} invalid scim token")
+var ErrorScimInvalidFilter = errors.New("{ERROR_SCIM} only filters like 'attribute eq \"value\"' are supported")
+var ErrorScimInvalidPatchValue = errors.New("{ERROR_SCIM} invalid patch operation value")
+var ErrorScimInvalidPatchPath = errors.New("{ERROR_SCIM} unsupported patch operation path")
+var ErrorScimInvalidPatchOperation = errors.New("{ERROR_SCIM} unsupported patch operation")
+var ErrorScimUserEmailRequired = errors.New("{ERROR_SCIM} the user must have an email")
+var ErrorScimUserAlreadyExists = errors.New("{ERROR_SCIM} user name or email already in use")
+var ErrorScimGroupAlreadyExists = errors.New("{ERROR_SCIM} group display name already in use")
+var ErrorScimResourceNotFound = errors.New("{ERROR_SCIM} resource not found")
+var ErrorScimInvalidMember = errors.New("{ERROR_SCIM} group member is not an existing user")
+
+const ErrorScimUnexpected = "{ERROR_SCIM} unexpected error on scim request"
+const ErrorScimSyncMemberships = "{ERROR_SCIM} error to sync memberships of scim groups"
+
+const ErrorAlreadyExistingScimGroupName = "pq: duplicate key value violates unique constraint" +
+	" \"scim_groups_display_name_key\""
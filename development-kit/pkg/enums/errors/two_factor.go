@@ -0,0 +1,25 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import "errors"
+
+var ErrorTwoFactorRequired = errors.New("{TWO_FACTOR} verification code is required to complete the login")
+var ErrorInvalidTwoFactorCode = errors.New("{TWO_FACTOR} invalid verification code")
+var ErrorTwoFactorAlreadyEnabled = errors.New("{TWO_FACTOR} two factor authentication already enabled")
+var ErrorTwoFactorNotEnrolled = errors.New("{TWO_FACTOR} two factor enrollment was not started")
+var ErrorTwoFactorNotEnabled = errors.New("{TWO_FACTOR} two factor authentication is not enabled")
+var ErrorTwoFactorRequiredByCompany = errors.New(
+	"{TWO_FACTOR} company requires two factor authentication, enable it in your account settings")
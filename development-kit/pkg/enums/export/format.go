@@ -0,0 +1,62 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+type Format string
+
+const (
+	CSV     Format = "csv"
+	NDJSON  Format = "ndjson"
+	Unknown Format = "unknown"
+)
+
+func (f Format) IsInvalid() bool {
+	for _, v := range f.Values() {
+		if v == f {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f Format) Values() []Format {
+	return []Format{
+		CSV,
+		NDJSON,
+	}
+}
+
+func (f Format) ToString() string {
+	return string(f)
+}
+
+func (f Format) GetContentType() string {
+	if f == CSV {
+		return "text/csv"
+	}
+
+	return "application/x-ndjson"
+}
+
+func GetFormatByString(format string) (f Format) {
+	for _, v := range f.Values() {
+		if v.ToString() == format {
+			return v
+		}
+	}
+
+	return Unknown
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatIsInvalid(t *testing.T) {
+	t.Run("should return true when invalid format", func(t *testing.T) {
+		assert.True(t, Format("xlsx").IsInvalid())
+		assert.True(t, Unknown.IsInvalid())
+	})
+
+	t.Run("should return false when valid format", func(t *testing.T) {
+		assert.False(t, CSV.IsInvalid())
+		assert.False(t, NDJSON.IsInvalid())
+	})
+}
+
+func TestFormatValues(t *testing.T) {
+	t.Run("should return 2 valid formats", func(t *testing.T) {
+		var format Format
+		assert.Len(t, format.Values(), 2)
+	})
+}
+
+func TestFormatToString(t *testing.T) {
+	t.Run("should formats is correctly parse to string", func(t *testing.T) {
+		assert.Equal(t, "csv", CSV.ToString())
+		assert.Equal(t, "ndjson", NDJSON.ToString())
+	})
+}
+
+func TestFormatGetContentType(t *testing.T) {
+	t.Run("should return content type of each format", func(t *testing.T) {
+		assert.Equal(t, "text/csv", CSV.GetContentType())
+		assert.Equal(t, "application/x-ndjson", NDJSON.GetContentType())
+	})
+}
+
+func TestGetFormatByString(t *testing.T) {
+	t.Run("should return format correctly when exists", func(t *testing.T) {
+		assert.Equal(t, CSV, GetFormatByString("csv"))
+		assert.Equal(t, NDJSON, GetFormatByString("ndjson"))
+		assert.Equal(t, Unknown, GetFormatByString("xlsx"))
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package management
+
+type SortBy string
+
+const (
+	Severity SortBy = "severity"
+	File     SortBy = "file"
+	Age      SortBy = "age"
+)
+
+func (s SortBy) IsInvalid() bool {
+	for _, v := range s.Values() {
+		if v == s {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s SortBy) Values() []SortBy {
+	return []SortBy{
+		Severity,
+		File,
+		Age,
+	}
+}
+
+func (s SortBy) ToString() string {
+	return string(s)
+}
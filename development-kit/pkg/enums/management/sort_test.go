@@ -0,0 +1,48 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package management
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortByIsInvalid(t *testing.T) {
+	t.Run("should return true when invalid sort", func(t *testing.T) {
+		assert.True(t, SortBy("line").IsInvalid())
+	})
+
+	t.Run("should return false when valid sort", func(t *testing.T) {
+		assert.False(t, Severity.IsInvalid())
+		assert.False(t, File.IsInvalid())
+		assert.False(t, Age.IsInvalid())
+	})
+}
+
+func TestSortByValues(t *testing.T) {
+	t.Run("should return 3 valid sorts", func(t *testing.T) {
+		var sortBy SortBy
+		assert.Len(t, sortBy.Values(), 3)
+	})
+}
+
+func TestSortByToString(t *testing.T) {
+	t.Run("should sorts is correctly parse to string", func(t *testing.T) {
+		assert.Equal(t, "severity", Severity.ToString())
+		assert.Equal(t, "file", File.ToString())
+		assert.Equal(t, "age", Age.ToString())
+	})
+}
@@ -19,4 +19,8 @@ const (
 	ResetPassword      = "reset-password"
 	OrganizationInvite = "organization-invite"
 	RepositoryInvite   = "repository-invite"
+	RiskAcceptExpiring = "risk-accept-expiring"
+	RiskAcceptExpired  = "risk-accept-expired"
+	TokenExpiring      = "token-expiring"
+	AccountLocked      = "account-locked"
 )
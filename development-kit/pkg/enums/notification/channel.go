@@ -0,0 +1,56 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+type Channel string
+
+const (
+	Slack   Channel = "slack"
+	Teams   Channel = "teams"
+	Chat    Channel = "chat"
+	Unknown Channel = "unknown"
+)
+
+func (c Channel) IsInvalid() bool {
+	for _, v := range c.Values() {
+		if v == c {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c Channel) Values() []Channel {
+	return []Channel{
+		Slack,
+		Teams,
+		Chat,
+	}
+}
+
+func (c Channel) ToString() string {
+	return string(c)
+}
+
+func GetChannelByString(channel string) (c Channel) {
+	for _, v := range c.Values() {
+		if v.ToString() == channel {
+			return v
+		}
+	}
+
+	return Unknown
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelIsInvalid(t *testing.T) {
+	t.Run("should return true when invalid channel", func(t *testing.T) {
+		assert.True(t, Channel("test").IsInvalid())
+	})
+
+	t.Run("should return false when valid channel", func(t *testing.T) {
+		assert.False(t, Slack.IsInvalid())
+		assert.False(t, Teams.IsInvalid())
+		assert.False(t, Chat.IsInvalid())
+	})
+}
+
+func TestChannelValues(t *testing.T) {
+	t.Run("should return 3 valid channels", func(t *testing.T) {
+		var channel Channel
+		assert.Len(t, channel.Values(), 3)
+	})
+}
+
+func TestChannelToString(t *testing.T) {
+	t.Run("should channels is correctly parse to string", func(t *testing.T) {
+		assert.Equal(t, "slack", Slack.ToString())
+		assert.Equal(t, "teams", Teams.ToString())
+		assert.Equal(t, "chat", Chat.ToString())
+	})
+}
+
+func TestGetChannelByString(t *testing.T) {
+	t.Run("should return channel correctly when exists", func(t *testing.T) {
+		assert.Equal(t, Slack, GetChannelByString("slack"))
+		assert.Equal(t, Teams, GetChannelByString("teams"))
+		assert.Equal(t, Chat, GetChannelByString("chat"))
+		assert.Equal(t, Unknown, GetChannelByString("test"))
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+type Event string
+
+const (
+	AnalysisFinished   Event = "analysis-finished"
+	UserInvited        Event = "user-invited"
+	RiskAcceptExpiring Event = "risk-accept-expiring"
+	RiskAcceptExpired  Event = "risk-accept-expired"
+)
+
+func (e Event) IsInvalid() bool {
+	for _, v := range e.Values() {
+		if v == e {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e Event) Values() []Event {
+	return []Event{
+		AnalysisFinished,
+		UserInvited,
+		RiskAcceptExpiring,
+		RiskAcceptExpired,
+	}
+}
+
+func (e Event) ToString() string {
+	return string(e)
+}
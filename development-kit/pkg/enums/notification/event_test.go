@@ -0,0 +1,43 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventIsInvalid(t *testing.T) {
+	t.Run("should return true when invalid event", func(t *testing.T) {
+		assert.True(t, Event("test").IsInvalid())
+	})
+
+	t.Run("should return false when valid event", func(t *testing.T) {
+		assert.False(t, AnalysisFinished.IsInvalid())
+		assert.False(t, UserInvited.IsInvalid())
+		assert.False(t, RiskAcceptExpiring.IsInvalid())
+		assert.False(t, RiskAcceptExpired.IsInvalid())
+	})
+}
+
+func TestEventToString(t *testing.T) {
+	t.Run("should events is correctly parse to string", func(t *testing.T) {
+		assert.Equal(t, "analysis-finished", AnalysisFinished.ToString())
+		assert.Equal(t, "user-invited", UserInvited.ToString())
+		assert.Equal(t, "risk-accept-expiring", RiskAcceptExpiring.ToString())
+		assert.Equal(t, "risk-accept-expired", RiskAcceptExpired.ToString())
+	})
+}
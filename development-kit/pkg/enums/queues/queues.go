@@ -25,6 +25,8 @@ const (
 	HorusecAnalyser             Queue = "horusec-analyser"
 	HorusecEmail                Queue = "horusec-email"
 	HorusecWebhookDispatch      Queue = "horusec-webhook-dispatch"
+	HorusecChat                 Queue = "horusec-chat"
+	HorusecAudit                Queue = "horusec-audit"
 	UNKNOWN                     Queue = "unknown"
 )
 
@@ -35,6 +37,8 @@ func Values() []Queue {
 		HorusecAnalysisFinish,
 		HorusecAnalyser,
 		HorusecEmail,
+		HorusecChat,
+		HorusecAudit,
 	}
 }
 
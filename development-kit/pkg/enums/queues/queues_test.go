@@ -21,8 +21,8 @@ import (
 )
 
 func TestValues(t *testing.T) {
-	t.Run("should return all 5 queue values", func(t *testing.T) {
-		assert.Len(t, Values(), 5)
+	t.Run("should return all 7 queue values", func(t *testing.T) {
+		assert.Len(t, Values(), 7)
 	})
 }
 
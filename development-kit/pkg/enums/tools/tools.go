@@ -49,6 +49,46 @@ func (t Tool) ToString() string {
 	return string(t)
 }
 
+func (t Tool) IsInvalid() bool {
+	for _, v := range t.Values() {
+		if v == t {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nolint:funlen all tools is greater than 15
+func (t Tool) Values() []Tool {
+	return []Tool{
+		GoSec,
+		SecurityCodeScan,
+		Brakeman,
+		Safety,
+		Bandit,
+		NpmAudit,
+		YarnAudit,
+		SpotBugs,
+		HorusecKotlin,
+		HorusecJava,
+		HorusecLeaks,
+		GitLeaks,
+		TfSec,
+		Semgrep,
+		HorusecCsharp,
+		HorusecDart,
+		HorusecKubernetes,
+		Eslint,
+		HorusecNodejs,
+		Flawfinder,
+		PhpCS,
+		MixAudit,
+		Sobelow,
+		ShellCheck,
+	}
+}
+
 func (t Tool) ToLowerCamel() string {
 	return strcase.ToLowerCamel(strcase.ToSnake(t.ToString()))
 }
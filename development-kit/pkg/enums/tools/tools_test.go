@@ -24,3 +24,19 @@ func TestToString(t *testing.T) {
 		assert.Equal(t, "GoSec", GoSec.ToString())
 	})
 }
+
+func TestIsInvalid(t *testing.T) {
+	t.Run("should return false when tool is valid", func(t *testing.T) {
+		assert.False(t, GoSec.IsInvalid())
+	})
+
+	t.Run("should return true when tool is invalid", func(t *testing.T) {
+		assert.True(t, Tool("test").IsInvalid())
+	})
+}
+
+func TestValues(t *testing.T) {
+	t.Run("should return all tools", func(t *testing.T) {
+		assert.Len(t, Tool("").Values(), 24)
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	auditRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/audit"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/queues"
+	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+)
+
+type IService interface {
+	Publish(event *audit.Event)
+}
+
+type Service struct {
+	broker          brokerLib.IBroker
+	auditRepository auditRepository.IRepository
+}
+
+// NewAuditService publishes the events on the broker, when the broker is disabled they are saved in the database
+func NewAuditService(databaseRead relational.InterfaceRead, databaseWrite relational.InterfaceWrite,
+	broker brokerLib.IBroker) IService {
+	return &Service{
+		broker:          broker,
+		auditRepository: auditRepository.NewAuditRepository(databaseRead, databaseWrite),
+	}
+}
+
+// Publish only logs the errors, a failure to record the event should not undo the change already done
+func (s *Service) Publish(event *audit.Event) {
+	if err := s.publish(event); err != nil {
+		logger.LogError(errors.ErrorPublishAuditEvent, err, map[string]interface{}{
+			"action":    event.Action,
+			"accountID": event.AccountID,
+			"companyID": event.CompanyID,
+		})
+	}
+}
+
+func (s *Service) publish(event *audit.Event) error {
+	if s.broker == nil {
+		return s.auditRepository.Create(event)
+	}
+
+	return s.broker.Publish(queues.HorusecAudit.ToString(), "", "", event.ToBytes())
+}
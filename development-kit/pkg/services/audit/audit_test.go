@@ -0,0 +1,79 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	auditRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/audit"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock(t *testing.T) {
+	t.Run("should mock audit service", func(t *testing.T) {
+		m := &Mock{}
+		m.On("Publish")
+
+		m.Publish(&audit.Event{})
+
+		m.AssertCalled(t, "Publish")
+	})
+}
+
+func TestNewAuditService(t *testing.T) {
+	t.Run("should create a new service", func(t *testing.T) {
+		assert.NotNil(t, NewAuditService(&relational.MockRead{}, &relational.MockWrite{}, nil))
+	})
+}
+
+func TestPublish(t *testing.T) {
+	t.Run("should publish event on broker", func(t *testing.T) {
+		brokerMock := &broker.Mock{}
+		brokerMock.On("Publish").Return(nil)
+		repositoryMock := &auditRepository.Mock{}
+		service := &Service{broker: brokerMock, auditRepository: repositoryMock}
+
+		service.Publish(audit.NewEvent(auditEnums.TokenCreate, uuid.New()))
+
+		brokerMock.AssertCalled(t, "Publish")
+		repositoryMock.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("should save event in database when broker is disabled", func(t *testing.T) {
+		repositoryMock := &auditRepository.Mock{}
+		repositoryMock.On("Create").Return(nil)
+		service := &Service{auditRepository: repositoryMock}
+
+		service.Publish(audit.NewEvent(auditEnums.TokenCreate, uuid.New()))
+
+		repositoryMock.AssertCalled(t, "Create")
+	})
+
+	t.Run("should not panic when publish fails", func(t *testing.T) {
+		brokerMock := &broker.Mock{}
+		brokerMock.On("Publish").Return(errors.New("test"))
+		service := &Service{broker: brokerMock}
+
+		assert.NotPanics(t, func() {
+			service.Publish(audit.NewEvent(auditEnums.TokenCreate, uuid.New()))
+		})
+	})
+}
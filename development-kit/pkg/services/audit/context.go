@@ -0,0 +1,38 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+)
+
+// NewResourceContext returns the context of an audited request with the event where the handler records the resource
+// it created, the ids are only known after the handler answers
+func NewResourceContext(ctx context.Context) (context.Context, *audit.Event) {
+	resource := &audit.Event{}
+	return context.WithValue(ctx, auditEnums.Resource, resource), resource
+}
+
+// GetResource returns the event of the audited request, on requests without audit the changes are discarded
+func GetResource(ctx context.Context) *audit.Event {
+	if resource, ok := ctx.Value(auditEnums.Resource).(*audit.Event); ok {
+		return resource
+	}
+
+	return &audit.Event{}
+}
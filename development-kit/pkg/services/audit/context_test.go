@@ -0,0 +1,36 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResource(t *testing.T) {
+	t.Run("should return the resource of the audited request", func(t *testing.T) {
+		ctx, resource := NewResourceContext(context.Background())
+
+		GetResource(ctx).SetResourceID("test")
+
+		assert.Equal(t, "test", resource.ResourceID)
+	})
+
+	t.Run("should return an empty resource when request is not audited", func(t *testing.T) {
+		assert.NotNil(t, GetResource(context.Background()).SetResourceID("test"))
+	})
+}
@@ -15,17 +15,23 @@
 package broker
 
 import (
+	"context"
+
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	brokerConfig "github.com/ZupIT/horusec/development-kit/pkg/services/broker/config"
 	brokerPacket "github.com/ZupIT/horusec/development-kit/pkg/services/broker/packet"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/metrics"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type IBroker interface {
 	IsAvailable() bool
 	Consume(queue, exchange, exchangeKind string, handler func(packet brokerPacket.IPacket))
 	Publish(queue, exchange, exchangeKind string, body []byte) error
+	PublishWithContext(ctx context.Context, queue, exchange, exchangeKind string, body []byte) error
 	Close() error
 }
 
@@ -96,12 +102,15 @@ func (b *Broker) Close() error {
 	return b.connection.Close()
 }
 
-func (b *Broker) publish(queue string, data []byte, exchange string) error {
+func (b *Broker) publish(ctx context.Context, queue string, data []byte, exchange string) error {
 	packet := amqp.Publishing{
 		ContentType: "text/plain",
 		Body:        data,
+		Headers:     amqp.Table{},
 	}
 
+	tracing.Inject(ctx, brokerPacket.HeadersCarrier(packet.Headers))
+
 	return b.channel.Publish(exchange, queue, false, false, packet)
 }
 
@@ -115,6 +124,20 @@ func (b *Broker) exchangeDeclare(exchange, exchangeKind string) error {
 }
 
 func (b *Broker) Publish(queue, exchange, exchangeKind string, body []byte) error {
+	return b.PublishWithContext(context.Background(), queue, exchange, exchangeKind, body)
+}
+
+// PublishWithContext sends the trace context of ctx in the headers of the message, the consumer continues the trace
+func (b *Broker) PublishWithContext(ctx context.Context, queue, exchange, exchangeKind string, body []byte) error {
+	ctx, span := tracing.StartSpan(ctx, "publish "+queue+exchange, trace.WithSpanKind(trace.SpanKindProducer))
+	err := b.setUpChannelAndPublish(ctx, queue, exchange, exchangeKind, body)
+	metrics.ObserveBrokerPublish(queue, exchange, err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (b *Broker) setUpChannelAndPublish(ctx context.Context, queue, exchange, exchangeKind string,
+	body []byte) error {
 	if err := b.setUpChannel(); err != nil {
 		logger.LogError(errors.FailedCreateChannelPublish, err)
 		return err
@@ -125,7 +148,7 @@ func (b *Broker) Publish(queue, exchange, exchangeKind string, body []byte) erro
 		return err
 	}
 
-	return b.publish(queue, body, exchange)
+	return b.publish(ctx, queue, body, exchange)
 }
 
 func (b *Broker) Consume(queue, exchange, exchangeKing string, handler func(packet brokerPacket.IPacket)) {
@@ -160,11 +183,18 @@ func (b *Broker) handleDeliveries(queue string, handler func(packet brokerPacket
 
 	for delivery := range deliveries {
 		message := delivery
-		p := brokerPacket.NewPacket(&message)
-		handler(p)
+		b.handleDelivery(queue, brokerPacket.NewPacket(&message), handler)
 	}
 }
 
+func (b *Broker) handleDelivery(queue string, packet brokerPacket.IPacket, handler func(packet brokerPacket.IPacket)) {
+	ctx, span := tracing.StartSpan(packet.GetContext(), "consume "+queue, trace.WithSpanKind(trace.SpanKindConsumer))
+	defer span.End()
+
+	packet.SetContext(ctx)
+	handler(packet)
+}
+
 func (b *Broker) setConsumerPrefetch() {
 	if err := b.channel.Qos(1, 0, false); err != nil {
 		logger.LogPanic(errors.FailedSetConsumerPrefetch, err)
@@ -15,6 +15,8 @@
 package broker
 
 import (
+	"context"
+
 	brokerPacket "github.com/ZupIT/horusec/development-kit/pkg/services/broker/packet"
 	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
 	"github.com/stretchr/testify/mock"
@@ -34,6 +36,11 @@ func (m *Mock) Publish(queue, exchange, exchangeKind string, body []byte) error
 	return mockUtils.ReturnNilOrError(args, 0)
 }
 
+// PublishWithContext is recorded as Publish, the tests do not depend on the trace context
+func (m *Mock) PublishWithContext(_ context.Context, queue, exchange, exchangeKind string, body []byte) error {
+	return m.Publish(queue, exchange, exchangeKind, body)
+}
+
 func (m *Mock) Consume(queue, exchange, exchangeKind string, handler func(packet brokerPacket.IPacket)) {
 	_ = m.MethodCalled("Consume")
 }
@@ -0,0 +1,46 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packet
+
+import (
+	"fmt"
+
+	"github.com/streadway/amqp"
+)
+
+// HeadersCarrier propagates the trace context in the headers of the AMQP messages
+type HeadersCarrier amqp.Table
+
+func (h HeadersCarrier) Get(key string) string {
+	value, ok := h[key]
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+func (h HeadersCarrier) Set(key, value string) {
+	h[key] = value
+}
+
+func (h HeadersCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
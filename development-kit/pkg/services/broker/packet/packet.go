@@ -14,21 +14,33 @@
 
 package packet
 
-import "github.com/streadway/amqp"
+import (
+	"context"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
+	"github.com/streadway/amqp"
+)
 
 type IPacket interface {
 	Ack() error
 	Nack() error
 	GetBody() []byte
 	SetBody(body []byte)
+	GetContext() context.Context
+	SetContext(ctx context.Context)
 }
 
 type Packet struct {
 	message *amqp.Delivery
+	ctx     context.Context
 }
 
+// NewPacket continues the trace of the publisher when the message has the trace context in the headers
 func NewPacket(message *amqp.Delivery) IPacket {
-	return &Packet{message: message}
+	return &Packet{
+		message: message,
+		ctx:     tracing.Extract(context.Background(), HeadersCarrier(message.Headers)),
+	}
 }
 
 func (p *Packet) Ack() error {
@@ -46,3 +58,11 @@ func (p *Packet) GetBody() []byte {
 func (p *Packet) SetBody(body []byte) {
 	p.message.Body = body
 }
+
+func (p *Packet) GetContext() context.Context {
+	return p.ctx
+}
+
+func (p *Packet) SetContext(ctx context.Context) {
+	p.ctx = ctx
+}
@@ -15,9 +15,13 @@
 package packet
 
 import (
+	"context"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
 	"github.com/streadway/amqp"
 	"github.com/stretchr/testify/assert"
-	"testing"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestNewPacket(t *testing.T) {
@@ -57,3 +61,36 @@ func TestSetBody(t *testing.T) {
 		})
 	})
 }
+
+func TestGetContext(t *testing.T) {
+	t.Run("should continue the trace of the message headers", func(t *testing.T) {
+		tracing.SetUpTracing("test")
+		packet := NewPacket(&amqp.Delivery{Headers: amqp.Table{
+			"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}})
+
+		spanContext := trace.SpanContextFromContext(packet.GetContext())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanContext.TraceID().String())
+	})
+
+	t.Run("should return the context set", func(t *testing.T) {
+		packet := NewPacket(&amqp.Delivery{})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		packet.SetContext(ctx)
+
+		assert.Equal(t, ctx, packet.GetContext())
+	})
+}
+
+func TestHeadersCarrier(t *testing.T) {
+	t.Run("should set and get the headers", func(t *testing.T) {
+		carrier := HeadersCarrier(amqp.Table{})
+
+		carrier.Set("traceparent", "test")
+
+		assert.Equal(t, "test", carrier.Get("traceparent"))
+		assert.Empty(t, carrier.Get("tracestate"))
+		assert.Equal(t, []string{"traceparent"}, carrier.Keys())
+	})
+}
@@ -30,6 +30,8 @@ type ILDAPService interface {
 	Close()
 	Authenticate(username, password string) (bool, map[string]string, error)
 	GetGroupsOfUser(userDN string) ([]string, error)
+	GetGroupsOfUsername(username string) ([]string, error)
+	GetGroupMembers(groupName string) ([]map[string]string, error)
 	IsAvailable() bool
 }
 
@@ -160,7 +162,7 @@ func (s *Service) searchAndCreateUser(username, password string) (bool, map[stri
 		return false, nil, err
 	}
 
-	return true, s.createUser(searchResult), nil
+	return true, s.createUser(searchResult.Entries[0]), nil
 }
 
 func (s *Service) getDNBySearchResult(searchResult *ldap.SearchResult) string {
@@ -206,14 +208,14 @@ func (s *Service) validateSearchResult(searchResult *ldap.SearchResult) error {
 	return nil
 }
 
-func (s *Service) createUser(searchResult *ldap.SearchResult) map[string]string {
-	user := map[string]string{"dn": s.getDNBySearchResult(searchResult)}
+func (s *Service) createUser(entry *ldap.Entry) map[string]string {
+	user := map[string]string{"dn": entry.DN}
 
 	for _, attr := range []string{"sAMAccountName", "mail"} {
-		if value := searchResult.Entries[0].GetAttributeValue(attr); value != "" {
+		if value := entry.GetAttributeValue(attr); value != "" {
 			user[attr] = value
 		} else {
-			user[attr] = searchResult.Entries[0].GetAttributeValue(strings.ToLower(attr))
+			user[attr] = entry.GetAttributeValue(strings.ToLower(attr))
 		}
 	}
 
@@ -228,6 +230,21 @@ func (s *Service) GetGroupsOfUser(userDN string) ([]string, error) {
 	return s.getGroupsByDN(userDN)
 }
 
+// GetGroupsOfUsername searches the user without its password, so the current groups of an account can be checked
+// when it is not logging in
+func (s *Service) GetGroupsOfUsername(username string) ([]string, error) {
+	if err := s.connectAndBind(); err != nil {
+		return nil, err
+	}
+
+	searchResult, err := s.searchUserByUsername(ldap.EscapeFilter(username))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getGroupsByDN(s.getDNBySearchResult(searchResult))
+}
+
 func (s *Service) getGroupsByDN(userDN string) ([]string, error) {
 	searchResult, err := s.Conn.Search(s.newSearchRequestByGroupMember(userDN))
 	if err != nil {
@@ -257,6 +274,68 @@ func (s *Service) getGroupsNames(searchResult *ldap.SearchResult) []string {
 	return groups
 }
 
+func (s *Service) GetGroupMembers(groupName string) ([]map[string]string, error) {
+	if err := s.connectAndBind(); err != nil {
+		return nil, err
+	}
+
+	searchResult, err := s.Conn.Search(s.newSearchRequestByGroupName(groupName))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getUsersByDN(s.getMembersDN(searchResult))
+}
+
+func (s *Service) newSearchRequestByGroupName(groupName string) *ldap.SearchRequest {
+	return ldap.NewSearchRequest(
+		s.Base,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(cn=%s)", ldap.EscapeFilter(groupName)),
+		[]string{"member"},
+		nil,
+	)
+}
+
+func (s *Service) getMembersDN(searchResult *ldap.SearchResult) (membersDN []string) {
+	for _, entry := range searchResult.Entries {
+		membersDN = append(membersDN, entry.GetAttributeValues("member")...)
+	}
+
+	return membersDN
+}
+
+func (s *Service) getUsersByDN(membersDN []string) ([]map[string]string, error) {
+	var users []map[string]string
+
+	for _, memberDN := range membersDN {
+		searchResult, err := s.Conn.Search(s.newSearchRequestByDN(memberDN))
+		if err != nil {
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		for _, entry := range searchResult.Entries {
+			users = append(users, s.createUser(entry))
+		}
+	}
+
+	return users, nil
+}
+
+func (s *Service) newSearchRequestByDN(userDN string) *ldap.SearchRequest {
+	return ldap.NewSearchRequest(
+		userDN,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)",
+		[]string{"sAMAccountName", "mail"},
+		nil,
+	)
+}
+
 func (s *Service) IsAvailable() bool {
 	if err := s.Connect(); err != nil {
 		return false
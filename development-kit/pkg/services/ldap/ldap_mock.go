@@ -33,6 +33,16 @@ func (m *Mock) GetGroupsOfUser(userDN string) ([]string, error) {
 	return args.Get(0).([]string), mockUtils.ReturnNilOrError(args, 1)
 }
 
+func (m *Mock) GetGroupsOfUsername(username string) ([]string, error) {
+	args := m.MethodCalled("GetGroupsOfUsername")
+	return args.Get(0).([]string), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetGroupMembers(groupName string) ([]map[string]string, error) {
+	args := m.MethodCalled("GetGroupMembers")
+	return args.Get(0).([]map[string]string), mockUtils.ReturnNilOrError(args, 1)
+}
+
 func (m *Mock) Connect() error {
 	args := m.MethodCalled("Connect")
 	return mockUtils.ReturnNilOrError(args, 0)
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/ldap/ldaptest"
 	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
 	"github.com/go-ldap/ldap/v3"
 	"github.com/stretchr/testify/assert"
@@ -297,6 +298,50 @@ func TestGetGroupsOfUser(t *testing.T) {
 	})
 }
 
+func TestGetGroupsOfUsername(t *testing.T) {
+	t.Run("should search the user and get its groups", func(t *testing.T) {
+		ldapMock := &MockLdapConn{}
+
+		ldapMock.On("Bind").Return(nil)
+		ldapMock.On("Search").Return(&ldap.SearchResult{Entries: []*ldap.Entry{{DN: "cn=test"}}}, nil).Once()
+		ldapMock.On("Search").Return(&ldap.SearchResult{Entries: []*ldap.Entry{{DN: "cn=devs",
+			Attributes: []*ldap.EntryAttribute{{Name: "cn", Values: []string{"devs"}}}}}}, nil)
+
+		service := Service{BindDN: "test", BindPassword: "test", Conn: ldapMock, UserFilter: "(sAMAccountName=%s)"}
+
+		groups, err := service.GetGroupsOfUsername("test")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"devs"}, groups)
+	})
+
+	t.Run("should return error when user does not exist", func(t *testing.T) {
+		ldapMock := &MockLdapConn{}
+
+		ldapMock.On("Bind").Return(nil)
+		ldapMock.On("Search").Return(&ldap.SearchResult{}, nil)
+
+		service := Service{BindDN: "test", BindPassword: "test", Conn: ldapMock, UserFilter: "(sAMAccountName=%s)"}
+
+		groups, err := service.GetGroupsOfUsername("test")
+
+		assert.Nil(t, groups)
+		assert.Equal(t, errorsEnums.ErrorUserDoesNotExist, err)
+	})
+
+	t.Run("should return error while biding with env vars", func(t *testing.T) {
+		ldapMock := &MockLdapConn{}
+
+		ldapMock.On("Bind").Return(errors.New("test"))
+
+		service := Service{BindDN: "test", BindPassword: "test", Conn: ldapMock}
+
+		_, err := service.GetGroupsOfUsername("test")
+
+		assert.Error(t, err)
+	})
+}
+
 func TestCheck(t *testing.T) {
 	t.Run("should return no true when ldap is healthy", func(t *testing.T) {
 		ldapMock := &MockLdapConn{}
@@ -328,3 +373,69 @@ func TestCheck(t *testing.T) {
 		assert.False(t, service.IsAvailable())
 	})
 }
+
+func newInMemoryService(t *testing.T) (*Service, *ldaptest.Server) {
+	server, err := ldaptest.NewServer([]ldaptest.Entry{
+		{DN: "cn=admin,dc=horusec,dc=io", Attributes: map[string][]string{"userPassword": {"admin"}}},
+		{DN: "cn=john,ou=users,dc=horusec,dc=io",
+			Attributes: map[string][]string{"sAMAccountName": {"john"}, "mail": {"john@horusec.io"}}},
+		{DN: "cn=mary,ou=users,dc=horusec,dc=io", Attributes: map[string][]string{"samaccountname": {"mary"}}},
+		{DN: "cn=developers,ou=groups,dc=horusec,dc=io", Attributes: map[string][]string{"cn": {"developers"},
+			"member": {"cn=john,ou=users,dc=horusec,dc=io", "cn=mary,ou=users,dc=horusec,dc=io",
+				"cn=removed,ou=users,dc=horusec,dc=io"}}},
+	})
+	assert.NoError(t, err)
+
+	return &Service{Host: server.Host(), Port: server.Port(), Base: "dc=horusec,dc=io", SkipTLS: true,
+		BindDN: "cn=admin,dc=horusec,dc=io", BindPassword: "admin"}, server
+}
+
+func TestGetGroupMembers(t *testing.T) {
+	t.Run("should return members of group ignoring missing entries", func(t *testing.T) {
+		service, server := newInMemoryService(t)
+		defer server.Close()
+		defer service.Close()
+
+		members, err := service.GetGroupMembers("developers")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]string{
+			{"dn": "cn=john,ou=users,dc=horusec,dc=io", "sAMAccountName": "john", "mail": "john@horusec.io"},
+			{"dn": "cn=mary,ou=users,dc=horusec,dc=io", "sAMAccountName": "mary", "mail": ""},
+		}, members)
+	})
+
+	t.Run("should return empty when group does not exist", func(t *testing.T) {
+		service, server := newInMemoryService(t)
+		defer server.Close()
+		defer service.Close()
+
+		members, err := service.GetGroupMembers("unknown")
+
+		assert.NoError(t, err)
+		assert.Empty(t, members)
+	})
+
+	t.Run("should return error when bind fails", func(t *testing.T) {
+		service, server := newInMemoryService(t)
+		defer server.Close()
+		defer service.Close()
+		service.BindPassword = "wrong"
+
+		_, err := service.GetGroupMembers("developers")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when search fails", func(t *testing.T) {
+		ldapMock := &MockLdapConn{}
+		ldapMock.On("Bind").Return(nil)
+		ldapMock.On("Search").Return(&ldap.SearchResult{}, errors.New("test"))
+
+		service := &Service{Conn: ldapMock, BindDN: "test", BindPassword: "test"}
+
+		_, err := service.GetGroupMembers("developers")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,277 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ldaptest provides an in-memory LDAP server to test the ldap integration without a real directory.
+// It answers only simple bind, search and unbind operations, supporting the and, or, not, equality and presence
+// filters.
+package ldaptest
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+type Server struct {
+	listener net.Listener
+	mutex    sync.RWMutex
+	entries  []Entry
+}
+
+func NewServer(entries []Entry) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	server := &Server{listener: listener, entries: entries}
+	go server.serve()
+	return server, nil
+}
+
+func (s *Server) Host() string {
+	return s.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *Server) Close() {
+	_ = s.listener.Close()
+}
+
+func (s *Server) SetEntries(entries []Entry) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.entries = entries
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handleConnection(conn)
+	}
+}
+
+func (s *Server) handleConnection(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+
+		if !s.handleOperation(conn, packet.Children[0].Value, packet.Children[1]) {
+			return
+		}
+	}
+}
+
+func (s *Server) handleOperation(conn net.Conn, messageID interface{}, operation *ber.Packet) bool {
+	switch operation.Tag {
+	case ldap.ApplicationBindRequest:
+		return s.write(conn, messageID, ldap.ApplicationBindResponse, s.bind(operation))
+	case ldap.ApplicationSearchRequest:
+		return s.search(conn, messageID, operation)
+	case ldap.ApplicationUnbindRequest:
+		return false
+	}
+
+	return s.write(conn, messageID, ldap.ApplicationExtendedResponse, ldap.LDAPResultUnwillingToPerform)
+}
+
+func (s *Server) bind(operation *ber.Packet) uint16 {
+	name, password := operation.Children[1].Data.String(), operation.Children[2].Data.String()
+
+	entry := s.findEntry(name)
+	if entry == nil || !contains(getAttribute(entry, "userPassword"), password) {
+		return ldap.LDAPResultInvalidCredentials
+	}
+
+	return ldap.LDAPResultSuccess
+}
+
+func (s *Server) search(conn net.Conn, messageID interface{}, operation *ber.Packet) bool {
+	base, scope := operation.Children[0].Data.String(), operation.Children[1].Value.(int64)
+	if scope == ldap.ScopeBaseObject && s.findEntry(base) == nil {
+		return s.write(conn, messageID, ldap.ApplicationSearchResultDone, ldap.LDAPResultNoSuchObject)
+	}
+
+	for _, entry := range s.getEntries() {
+		if s.isInScope(entry.DN, base, scope) && s.matches(&entry, operation.Children[6]) {
+			if !s.writeEntry(conn, messageID, &entry, operation.Children[7]) {
+				return false
+			}
+		}
+	}
+
+	return s.write(conn, messageID, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess)
+}
+
+func (s *Server) getEntries() []Entry {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.entries
+}
+
+func (s *Server) findEntry(dn string) *Entry {
+	for _, entry := range s.getEntries() {
+		if strings.EqualFold(entry.DN, dn) {
+			return &entry
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) isInScope(dn, base string, scope int64) bool {
+	dn, base = strings.ToLower(dn), strings.ToLower(base)
+	if scope == ldap.ScopeBaseObject {
+		return dn == base
+	}
+
+	return base == "" || dn == base || strings.HasSuffix(dn, ","+base)
+}
+
+func (s *Server) matches(entry *Entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !s.matches(entry, child) {
+				return false
+			}
+		}
+
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if s.matches(entry, child) {
+				return true
+			}
+		}
+
+		return false
+	case ldap.FilterNot:
+		return !s.matches(entry, filter.Children[0])
+	}
+
+	return s.matchesAttribute(entry, filter)
+}
+
+func (s *Server) matchesAttribute(entry *Entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldap.FilterEqualityMatch:
+		return contains(getAttribute(entry, filter.Children[0].Data.String()), filter.Children[1].Data.String())
+	case ldap.FilterPresent:
+		attribute := filter.Data.String()
+		return strings.EqualFold(attribute, "objectClass") || len(getAttribute(entry, attribute)) > 0
+	}
+
+	return false
+}
+
+func (s *Server) writeEntry(conn net.Conn, messageID interface{}, entry *Entry, attributes *ber.Packet) bool {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, ""))
+
+	attributesPacket := ber.NewSequence("")
+	for name, values := range entry.Attributes {
+		if !s.isAttributeRequested(name, attributes) {
+			continue
+		}
+
+		attribute := ber.NewSequence("")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+		valuesPacket := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, value := range values {
+			valuesPacket.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+		}
+
+		attribute.AppendChild(valuesPacket)
+		attributesPacket.AppendChild(attribute)
+	}
+
+	response.AppendChild(attributesPacket)
+	return s.send(conn, messageID, response)
+}
+
+func (s *Server) isAttributeRequested(name string, attributes *ber.Packet) bool {
+	if strings.EqualFold(name, "userPassword") {
+		return false
+	}
+
+	if len(attributes.Children) == 0 {
+		return true
+	}
+
+	for _, attribute := range attributes.Children {
+		if strings.EqualFold(attribute.Data.String(), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) write(conn net.Conn, messageID interface{}, tag ber.Tag, resultCode uint16) bool {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(resultCode), ""))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+
+	return s.send(conn, messageID, response) && tag != ldap.ApplicationExtendedResponse
+}
+
+func (s *Server) send(conn net.Conn, messageID interface{}, operation *ber.Packet) bool {
+	envelope := ber.NewSequence("")
+	envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, ""))
+	envelope.AppendChild(operation)
+
+	_, err := conn.Write(envelope.Bytes())
+	return err == nil
+}
+
+func getAttribute(entry *Entry, name string) []string {
+	for attribute, values := range entry.Attributes {
+		if strings.EqualFold(attribute, name) {
+			return values
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldaptest
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEntries() []Entry {
+	return []Entry{
+		{DN: "cn=admin,dc=horusec,dc=io", Attributes: map[string][]string{"userPassword": {"admin"}}},
+		{DN: "cn=john,ou=users,dc=horusec,dc=io",
+			Attributes: map[string][]string{"sAMAccountName": {"john"}, "mail": {"john@horusec.io"}}},
+		{DN: "cn=mary,ou=users,dc=horusec,dc=io",
+			Attributes: map[string][]string{"sAMAccountName": {"mary"}}},
+		{DN: "cn=developers,ou=groups,dc=horusec,dc=io",
+			Attributes: map[string][]string{"cn": {"developers"}, "member": {"cn=john,ou=users,dc=horusec,dc=io"}}},
+	}
+}
+
+func connect(t *testing.T, server *Server) *ldap.Conn {
+	conn, err := ldap.Dial("tcp", server.listener.Addr().String())
+	assert.NoError(t, err)
+	return conn
+}
+
+func TestServer(t *testing.T) {
+	server, err := NewServer(newEntries())
+	assert.NoError(t, err)
+	defer server.Close()
+
+	t.Run("should bind with valid credentials", func(t *testing.T) {
+		conn := connect(t, server)
+		defer conn.Close()
+
+		assert.NoError(t, conn.Bind("cn=admin,dc=horusec,dc=io", "admin"))
+	})
+
+	t.Run("should return error when bind with invalid credentials", func(t *testing.T) {
+		conn := connect(t, server)
+		defer conn.Close()
+
+		err := conn.Bind("cn=admin,dc=horusec,dc=io", "wrong")
+		assert.True(t, ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials))
+	})
+
+	t.Run("should search entries by filter and return only requested attributes", func(t *testing.T) {
+		conn := connect(t, server)
+		defer conn.Close()
+
+		result, err := conn.Search(ldap.NewSearchRequest("ou=users,dc=horusec,dc=io", ldap.ScopeWholeSubtree,
+			ldap.NeverDerefAliases, 0, 0, false, "(&(objectClass=*)(|(sAMAccountName=john)(mail=*)))",
+			[]string{"mail"}, nil))
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Entries, 1)
+		assert.Equal(t, "john@horusec.io", result.Entries[0].GetAttributeValue("mail"))
+		assert.Empty(t, result.Entries[0].GetAttributeValue("sAMAccountName"))
+	})
+
+	t.Run("should search entries using not filter", func(t *testing.T) {
+		conn := connect(t, server)
+		defer conn.Close()
+
+		result, err := conn.Search(ldap.NewSearchRequest("ou=users,dc=horusec,dc=io", ldap.ScopeWholeSubtree,
+			ldap.NeverDerefAliases, 0, 0, false, "(!(sAMAccountName=john))", nil, nil))
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Entries, 1)
+		assert.Equal(t, "mary", result.Entries[0].GetAttributeValue("sAMAccountName"))
+	})
+
+	t.Run("should return no such object when base entry does not exist", func(t *testing.T) {
+		conn := connect(t, server)
+		defer conn.Close()
+
+		_, err := conn.Search(ldap.NewSearchRequest("cn=unknown,dc=horusec,dc=io", ldap.ScopeBaseObject,
+			ldap.NeverDerefAliases, 0, 0, false, "(objectClass=*)", nil, nil))
+
+		assert.True(t, ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject))
+	})
+
+	t.Run("should replace entries", func(t *testing.T) {
+		server.SetEntries([]Entry{})
+		defer server.SetEntries(newEntries())
+		conn := connect(t, server)
+		defer conn.Close()
+
+		result, err := conn.Search(ldap.NewSearchRequest("dc=horusec,dc=io", ldap.ScopeWholeSubtree,
+			ldap.NeverDerefAliases, 0, 0, false, "(objectClass=*)", nil, nil))
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Entries)
+	})
+
+	t.Run("should return host and port", func(t *testing.T) {
+		assert.Equal(t, "127.0.0.1", server.Host())
+		assert.NotZero(t, server.Port())
+	})
+}
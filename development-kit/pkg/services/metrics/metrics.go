@@ -0,0 +1,98 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+// The collectors are registered in the default registry, exposed by promhttp in /metrics of each service
+var (
+	analysesReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "horusec_analyses_received_total",
+		Help: "Total of analyses received by company, repository and status",
+	}, []string{"company", "repository", "status"})
+
+	analysisDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "horusec_analysis_duration_seconds",
+		Help:    "Duration of the analyses from the creation until finished",
+		Buckets: prometheus.ExponentialBuckets(5, 2, 10),
+	}, []string{"status"})
+
+	vulnerabilitiesIngested = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "horusec_vulnerabilities_ingested_total",
+		Help: "Total of vulnerabilities received in the analyses by severity, security tool and language",
+	}, []string{"severity", "security_tool", "language"})
+
+	webhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "horusec_webhook_deliveries_total",
+		Help: "Total of analyses delivered to the repository webhooks by status",
+	}, []string{"status"})
+
+	emailsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "horusec_emails_sent_total",
+		Help: "Total of emails sent by template and status",
+	}, []string{"template", "status"})
+
+	brokerPublishErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "horusec_broker_publish_errors_total",
+		Help: "Total of messages that failed to be published in the broker by queue and exchange",
+	}, []string{"queue", "exchange"})
+)
+
+// ObserveAnalysis records the analysis received and its vulnerabilities, the duration is only observed when the
+// analysis has finished
+func ObserveAnalysis(analysis *horusec.Analysis) {
+	status := string(analysis.Status)
+	analysesReceived.WithLabelValues(analysis.CompanyName, analysis.RepositoryName, status).Inc()
+	if !analysis.CreatedAt.IsZero() && analysis.FinishedAt.After(analysis.CreatedAt) {
+		analysisDuration.WithLabelValues(status).Observe(analysis.FinishedAt.Sub(analysis.CreatedAt).Seconds())
+	}
+
+	for index := range analysis.AnalysisVulnerabilities {
+		vulnerability := analysis.AnalysisVulnerabilities[index].Vulnerability
+		vulnerabilitiesIngested.WithLabelValues(string(vulnerability.Severity), string(vulnerability.SecurityTool),
+			string(vulnerability.Language)).Inc()
+	}
+}
+
+func ObserveWebhookDelivery(err error) {
+	webhookDeliveries.WithLabelValues(getStatus(err)).Inc()
+}
+
+func ObserveEmailSent(templateName string, err error) {
+	emailsSent.WithLabelValues(templateName, getStatus(err)).Inc()
+}
+
+func ObserveBrokerPublish(queue, exchange string, err error) {
+	if err != nil {
+		brokerPublishErrors.WithLabelValues(queue, exchange).Inc()
+	}
+}
+
+func getStatus(err error) string {
+	if err != nil {
+		return StatusFailed
+	}
+
+	return StatusSuccess
+}
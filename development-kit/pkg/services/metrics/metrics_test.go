@@ -0,0 +1,82 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	horusecEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/languages"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/tools"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveAnalysis(t *testing.T) {
+	t.Run("should count the analysis, its duration and vulnerabilities", func(t *testing.T) {
+		analysis := &horusec.Analysis{
+			CompanyName:    "company",
+			RepositoryName: "repository",
+			Status:         horusecEnums.Success,
+			CreatedAt:      time.Now().Add(-time.Minute),
+			FinishedAt:     time.Now(),
+			AnalysisVulnerabilities: []horusec.AnalysisVulnerabilities{
+				{Vulnerability: horusec.Vulnerability{Severity: severity.High, SecurityTool: tools.GoSec,
+					Language: languages.Go}},
+				{Vulnerability: horusec.Vulnerability{Severity: severity.High, SecurityTool: tools.GoSec,
+					Language: languages.Go}},
+			},
+		}
+
+		ObserveAnalysis(analysis)
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(
+			analysesReceived.WithLabelValues("company", "repository", "success")))
+		assert.Equal(t, float64(2), testutil.ToFloat64(
+			vulnerabilitiesIngested.WithLabelValues("HIGH", "GoSec", "Go")))
+		assert.Equal(t, 1, testutil.CollectAndCount(analysisDuration))
+	})
+}
+
+func TestObserveWebhookDelivery(t *testing.T) {
+	t.Run("should count the deliveries by status", func(t *testing.T) {
+		ObserveWebhookDelivery(nil)
+		ObserveWebhookDelivery(errors.New("test"))
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(webhookDeliveries.WithLabelValues(StatusSuccess)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(webhookDeliveries.WithLabelValues(StatusFailed)))
+	})
+}
+
+func TestObserveEmailSent(t *testing.T) {
+	t.Run("should count the emails by template and status", func(t *testing.T) {
+		ObserveEmailSent("reset-password", nil)
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(emailsSent.WithLabelValues("reset-password", StatusSuccess)))
+	})
+}
+
+func TestObserveBrokerPublish(t *testing.T) {
+	t.Run("should count only the publish errors", func(t *testing.T) {
+		ObserveBrokerPublish("queue", "", nil)
+		ObserveBrokerPublish("queue", "", errors.New("test"))
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(brokerPublishErrors.WithLabelValues("queue", "")))
+	})
+}
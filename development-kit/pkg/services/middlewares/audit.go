@@ -0,0 +1,117 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middlewares
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	auditService "github.com/ZupIT/horusec/development-kit/pkg/services/audit"
+	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	authGrpc "github.com/ZupIT/horusec/development-kit/pkg/services/grpc/auth"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/google/uuid"
+)
+
+type IAuditMiddleware interface {
+	Audit(action auditEnums.Action, resourceParam string) func(next http.Handler) http.Handler
+}
+
+type AuditMiddleware struct {
+	auditService auditService.IService
+}
+
+func NewAuditMiddleware(databaseRead relational.InterfaceRead, databaseWrite relational.InterfaceWrite,
+	broker brokerLib.IBroker) IAuditMiddleware {
+	return &AuditMiddleware{
+		auditService: auditService.NewAuditService(databaseRead, databaseWrite, broker),
+	}
+}
+
+// Audit records the action after the handler answers with a success status, it must be placed after one of the
+// authz middlewares so the account who made the request is available in the context. Handlers of create and invite
+// requests record the created resource with auditService.GetResource.
+func (a *AuditMiddleware) Audit(action auditEnums.Action, resourceParam string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, resource := auditService.NewResourceContext(r.Context())
+			writer := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(writer, r.WithContext(ctx))
+
+			if writer.Status() >= http.StatusOK && writer.Status() < http.StatusMultipleChoices {
+				a.auditService.Publish(a.newEvent(action, resourceParam, r, resource))
+			}
+		})
+	}
+}
+
+func (a *AuditMiddleware) newEvent(action auditEnums.Action, resourceParam string, r *http.Request,
+	resource *audit.Event) *audit.Event {
+	event := audit.NewEvent(action, a.getAccountID(r)).
+		SetCompanyID(a.getURLParamUUID(r, "companyID")).
+		SetRepositoryID(a.getURLParamUUID(r, "repositoryID")).
+		SetIP(a.getIP(r))
+
+	if resourceParam != "" {
+		event.SetResourceID(chi.URLParam(r, resourceParam))
+	}
+
+	return a.setResource(event, resource)
+}
+
+func (a *AuditMiddleware) setResource(event, resource *audit.Event) *audit.Event {
+	if resource.CompanyID != uuid.Nil {
+		event.SetCompanyID(resource.CompanyID)
+	}
+
+	if resource.RepositoryID != uuid.Nil {
+		event.SetRepositoryID(resource.RepositoryID)
+	}
+
+	if resource.ResourceID != "" {
+		event.SetResourceID(resource.ResourceID)
+	}
+
+	return event.SetDetails(resource.Details)
+}
+
+func (a *AuditMiddleware) getAccountID(r *http.Request) uuid.UUID {
+	accountData, ok := r.Context().Value(authEnums.AccountData).(*authGrpc.GetAccountDataResponse)
+	if !ok {
+		return uuid.Nil
+	}
+
+	accountID, _ := uuid.Parse(accountData.GetAccountID())
+	return accountID
+}
+
+func (a *AuditMiddleware) getURLParamUUID(r *http.Request, param string) uuid.UUID {
+	value, _ := uuid.Parse(chi.URLParam(r, param))
+	return value
+}
+
+func (a *AuditMiddleware) getIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,147 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	auditService "github.com/ZupIT/horusec/development-kit/pkg/services/audit"
+	authGrpc "github.com/ZupIT/horusec/development-kit/pkg/services/grpc/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/test"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditMiddleware(t *testing.T) {
+	t.Run("should create a new audit middleware", func(t *testing.T) {
+		assert.NotNil(t, NewAuditMiddleware(&relational.MockRead{}, &relational.MockWrite{}, nil))
+	})
+}
+
+func TestAudit(t *testing.T) {
+	t.Run("should publish event when request succeeds", func(t *testing.T) {
+		serviceMock := &auditService.Mock{}
+		serviceMock.On("Publish")
+		middleware := &AuditMiddleware{auditService: serviceMock}
+
+		handler := middleware.Audit(auditEnums.TokenCreate, "")(http.HandlerFunc(test.Handler))
+
+		req, _ := http.NewRequest("POST", "http://test", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		serviceMock.AssertCalled(t, "Publish")
+	})
+
+	t.Run("should not publish event when request fails", func(t *testing.T) {
+		serviceMock := &auditService.Mock{}
+		middleware := &AuditMiddleware{auditService: serviceMock}
+
+		handler := middleware.Audit(auditEnums.TokenCreate, "")(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+			}))
+
+		req, _ := http.NewRequest("POST", "http://test", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		serviceMock.AssertNotCalled(t, "Publish")
+	})
+
+	t.Run("should give the handler a context to record the created resource", func(t *testing.T) {
+		serviceMock := &auditService.Mock{}
+		serviceMock.On("Publish")
+		middleware := &AuditMiddleware{auditService: serviceMock}
+		audited := false
+
+		handler := middleware.Audit(auditEnums.CompanyCreate, "")(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, audited = r.Context().Value(auditEnums.Resource).(*audit.Event)
+				w.WriteHeader(http.StatusCreated)
+			}))
+
+		req, _ := http.NewRequest("POST", "http://test", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.True(t, audited)
+		serviceMock.AssertCalled(t, "Publish")
+	})
+}
+
+func TestNewEvent(t *testing.T) {
+	t.Run("should fill the event with the request data", func(t *testing.T) {
+		accountID := uuid.New()
+		companyID := uuid.New()
+		repositoryID := uuid.New()
+		middleware := &AuditMiddleware{}
+
+		req, _ := http.NewRequest("DELETE", "http://test", nil)
+		req.RemoteAddr = "10.0.0.1:5000"
+		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("companyID", companyID.String())
+		ctx.URLParams.Add("repositoryID", repositoryID.String())
+		ctx.URLParams.Add("tokenID", "token")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+		req = req.WithContext(context.WithValue(req.Context(), authEnums.AccountData,
+			&authGrpc.GetAccountDataResponse{AccountID: accountID.String()}))
+
+		event := middleware.newEvent(auditEnums.TokenDelete, "tokenID", req, &audit.Event{})
+
+		assert.Equal(t, auditEnums.TokenDelete, event.Action)
+		assert.Equal(t, accountID, event.AccountID)
+		assert.Equal(t, companyID, event.CompanyID)
+		assert.Equal(t, repositoryID, event.RepositoryID)
+		assert.Equal(t, "token", event.ResourceID)
+		assert.Equal(t, "10.0.0.1", event.IP)
+	})
+
+	t.Run("should use empty values when request has no data", func(t *testing.T) {
+		middleware := &AuditMiddleware{}
+
+		req, _ := http.NewRequest("DELETE", "http://test", nil)
+		req.RemoteAddr = "invalid"
+
+		event := middleware.newEvent(auditEnums.TokenDelete, "", req, &audit.Event{})
+
+		assert.Equal(t, uuid.Nil, event.AccountID)
+		assert.Equal(t, uuid.Nil, event.CompanyID)
+		assert.Equal(t, "invalid", event.IP)
+	})
+
+	t.Run("should use the resource recorded by the handler", func(t *testing.T) {
+		companyID := uuid.New()
+		middleware := &AuditMiddleware{}
+
+		req, _ := http.NewRequest("POST", "http://test", nil)
+		resource := (&audit.Event{CompanyID: companyID}).SetResourceID(companyID.String()).SetDetails("test")
+
+		event := middleware.newEvent(auditEnums.CompanyCreate, "", req, resource)
+
+		assert.Equal(t, companyID, event.CompanyID)
+		assert.Equal(t, companyID.String(), event.ResourceID)
+		assert.Equal(t, "test", event.Details)
+	})
+}
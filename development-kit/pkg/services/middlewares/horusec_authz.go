@@ -32,17 +32,20 @@ import (
 type IHorusAuthzMiddleware interface {
 	SetContextAccountID(next http.Handler) http.Handler
 	IsApplicationAdmin(next http.Handler) http.Handler
+	IsStrictApplicationAdmin(next http.Handler) http.Handler
 	IsCompanyMember(next http.Handler) http.Handler
 	IsCompanyAdmin(next http.Handler) http.Handler
 	IsRepositoryMember(next http.Handler) http.Handler
 	IsRepositoryAdmin(next http.Handler) http.Handler
 	IsRepositorySupervisor(next http.Handler) http.Handler
+	HasPermission(permission authEnums.Permission) func(next http.Handler) http.Handler
 }
 
 type HorusAuthzMiddleware struct {
 	httpUtil   httpClient.Interface
 	grpcClient authGrpc.AuthServiceClient
 	ctx        context.Context
+	scope      authEnums.Scope
 }
 
 func NewHorusAuthzMiddleware(grpcCon grpc.ClientConnInterface) IHorusAuthzMiddleware {
@@ -53,6 +56,17 @@ func NewHorusAuthzMiddleware(grpcCon grpc.ClientConnInterface) IHorusAuthzMiddle
 	}
 }
 
+// NewHorusAuthzMiddlewareWithScope also accepts personal access tokens containing the scope, the ones created by
+// NewHorusAuthzMiddleware only accept tokens of the web application
+func NewHorusAuthzMiddlewareWithScope(grpcCon grpc.ClientConnInterface, scope authEnums.Scope) IHorusAuthzMiddleware {
+	return &HorusAuthzMiddleware{
+		httpUtil:   httpClient.NewHTTPClient(10),
+		grpcClient: authGrpc.NewAuthServiceClient(grpcCon),
+		ctx:        context.Background(),
+		scope:      scope,
+	}
+}
+
 func (h *HorusAuthzMiddleware) SetContextAccountID(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		h.setContextAndReturn(next, w, r)
@@ -80,6 +94,21 @@ func (h *HorusAuthzMiddleware) IsApplicationAdmin(next http.Handler) http.Handle
 	})
 }
 
+// IsStrictApplicationAdmin denies everyone when the application admin is disabled, different of IsApplicationAdmin
+// that lets all accounts pass in this case
+func (h *HorusAuthzMiddleware) IsStrictApplicationAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, err := h.grpcClient.IsAuthorized(h.ctx, h.setAuthorizedData(r, authEnums.ApplicationAdmin))
+		if err != nil || !response.GetIsAuthorized() {
+			logger.LogError(errors.SomethingWentWrongInGrpcRequest, err)
+			httpUtil.StatusUnauthorized(w, errors.ErrorUnauthorized)
+			return
+		}
+
+		h.setContextAndReturn(next, w, r)
+	})
+}
+
 func (h *HorusAuthzMiddleware) IsCompanyMember(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response, err := h.grpcClient.IsAuthorized(h.ctx, h.setAuthorizedData(r, authEnums.CompanyMember))
@@ -145,6 +174,25 @@ func (h *HorusAuthzMiddleware) IsRepositoryAdmin(next http.Handler) http.Handler
 	})
 }
 
+// HasPermission authorizes the built-in repository roles containing the permission and the repository members with a
+// custom role of the company containing it
+func (h *HorusAuthzMiddleware) HasPermission(permission authEnums.Permission) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data := h.setAuthorizedData(r, "")
+			data.Permission = permission.ToString()
+			response, err := h.grpcClient.IsAuthorized(h.ctx, data)
+			if err != nil || !response.GetIsAuthorized() {
+				logger.LogError(errors.SomethingWentWrongInGrpcRequest, err)
+				httpUtil.StatusUnauthorized(w, errors.ErrorUnauthorized)
+				return
+			}
+
+			h.setContextAndReturn(next, w, r)
+		})
+	}
+}
+
 func (h *HorusAuthzMiddleware) setContextAndReturn(next http.Handler, w http.ResponseWriter, r *http.Request) {
 	ctx, err := h.setAccountIDInContext(r, r.Header.Get("X-Horusec-Authorization"))
 	if err != nil {
@@ -163,12 +211,14 @@ func (h *HorusAuthzMiddleware) setAuthorizedData(r *http.Request,
 		Role:         role.ToString(),
 		CompanyID:    chi.URLParam(r, "companyID"),
 		RepositoryID: chi.URLParam(r, "repositoryID"),
+		Scope:        h.scope.ToString(),
 	}
 }
 
 func (h *HorusAuthzMiddleware) setGetAccountIDData(token string) *authGrpc.GetAccountData {
 	return &authGrpc.GetAccountData{
 		Token: token,
+		Scope: h.scope.ToString(),
 	}
 }
 
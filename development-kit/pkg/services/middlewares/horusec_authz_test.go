@@ -35,6 +35,23 @@ func TestNewHorusAuthzMiddleware(t *testing.T) {
 	})
 }
 
+func TestNewHorusAuthzMiddlewareWithScope(t *testing.T) {
+	t.Run("should create a new middleware service with scope", func(t *testing.T) {
+		middleware := NewHorusAuthzMiddlewareWithScope(nil, authEnums.ReadAnalytics)
+		assert.NotNil(t, middleware)
+	})
+
+	t.Run("should send the scope in the grpc requests", func(t *testing.T) {
+		middleware := &HorusAuthzMiddleware{scope: authEnums.ManageRepositories}
+
+		req, _ := http.NewRequest("GET", "http://test", nil)
+		req.Header.Add("X-Horusec-Authorization", "hpat_test")
+
+		assert.Equal(t, "manage:repositories", middleware.setAuthorizedData(req, authEnums.CompanyMember).GetScope())
+		assert.Equal(t, "manage:repositories", middleware.setGetAccountIDData("hpat_test").GetScope())
+	})
+}
+
 func TestIsMember(t *testing.T) {
 	t.Run("should return 200 when valid request", func(t *testing.T) {
 		httpMock := &httpClient.Mock{}
@@ -388,3 +405,117 @@ func TestIsApplicationAdmin(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
+
+func TestIsStrictApplicationAdmin(t *testing.T) {
+	t.Run("should return 200 when valid request", func(t *testing.T) {
+		httpMock := &httpClient.Mock{}
+		grpcMock := &authGrpc.Mock{}
+
+		grpcMock.On("IsAuthorized").Return(&authGrpc.IsAuthorizedResponse{IsAuthorized: true}, nil)
+		grpcMock.On("GetAccountID").Return(&authGrpc.GetAccountDataResponse{AccountID: uuid.New().String()}, nil)
+
+		middleware := HorusAuthzMiddleware{
+			httpUtil:   httpMock,
+			grpcClient: grpcMock,
+		}
+
+		handler := middleware.IsStrictApplicationAdmin(http.HandlerFunc(test.Handler))
+
+		req, _ := http.NewRequest("GET", "http://test", nil)
+		req.Header.Add("X-Horusec-Authorization", "123")
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		grpcMock.AssertNotCalled(t, "GetAuthConfig")
+	})
+
+	t.Run("should return 401 when not authorized", func(t *testing.T) {
+		httpMock := &httpClient.Mock{}
+		grpcMock := &authGrpc.Mock{}
+
+		grpcMock.On("IsAuthorized").Return(&authGrpc.IsAuthorizedResponse{IsAuthorized: false}, nil)
+
+		middleware := HorusAuthzMiddleware{
+			httpUtil:   httpMock,
+			grpcClient: grpcMock,
+		}
+
+		handler := middleware.IsStrictApplicationAdmin(http.HandlerFunc(test.Handler))
+
+		req, _ := http.NewRequest("GET", "http://test", nil)
+		req.Header.Add("X-Horusec-Authorization", "123")
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestHasPermission(t *testing.T) {
+	t.Run("should return 200 when account has the permission", func(t *testing.T) {
+		grpcMock := &authGrpc.Mock{}
+
+		grpcMock.On("IsAuthorized").Return(&authGrpc.IsAuthorizedResponse{IsAuthorized: true}, nil)
+		grpcMock.On("GetAccountID").Return(&authGrpc.GetAccountDataResponse{AccountID: uuid.New().String()}, nil)
+
+		middleware := HorusAuthzMiddleware{
+			httpUtil:   &httpClient.Mock{},
+			grpcClient: grpcMock,
+		}
+
+		handler := middleware.HasPermission(authEnums.ManageVulnerabilityTypes)(http.HandlerFunc(test.Handler))
+
+		req, _ := http.NewRequest("GET", "http://test", nil)
+		req.Header.Add("X-Horusec-Authorization", "123")
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 401 when account does not have the permission", func(t *testing.T) {
+		grpcMock := &authGrpc.Mock{}
+
+		grpcMock.On("IsAuthorized").Return(&authGrpc.IsAuthorizedResponse{IsAuthorized: false}, nil)
+
+		middleware := HorusAuthzMiddleware{
+			httpUtil:   &httpClient.Mock{},
+			grpcClient: grpcMock,
+		}
+
+		handler := middleware.HasPermission(authEnums.ManageRepositoryTokens)(http.HandlerFunc(test.Handler))
+
+		req, _ := http.NewRequest("GET", "http://test", nil)
+		req.Header.Add("X-Horusec-Authorization", "123")
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("should return 401 when grpc request fails", func(t *testing.T) {
+		grpcMock := &authGrpc.Mock{}
+
+		grpcMock.On("IsAuthorized").Return(&authGrpc.IsAuthorizedResponse{}, errors.New("test"))
+
+		middleware := HorusAuthzMiddleware{
+			httpUtil:   &httpClient.Mock{},
+			grpcClient: grpcMock,
+		}
+
+		handler := middleware.HasPermission(authEnums.ManageRepository)(http.HandlerFunc(test.Handler))
+
+		req, _ := http.NewRequest("GET", "http://test", nil)
+		req.Header.Add("X-Horusec-Authorization", "123")
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middlewares
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+type IRealIPMiddleware interface {
+	RealIP(next http.Handler) http.Handler
+}
+
+type RealIPMiddleware struct {
+	trustedProxies []*net.IPNet
+}
+
+// NewRealIPMiddleware receives the ips or cidrs of the proxies in front of the service, forwarded headers sent by any
+// other peer are ignored so clients can not choose the ip used by the login protection and the audit events
+func NewRealIPMiddleware(trustedProxies []string) IRealIPMiddleware {
+	return &RealIPMiddleware{
+		trustedProxies: parseTrustedProxies(trustedProxies),
+	}
+}
+
+func parseTrustedProxies(trustedProxies []string) (networks []*net.IPNet) {
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			proxy = toCIDR(proxy)
+		}
+
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			networks = append(networks, network)
+		}
+	}
+
+	return networks
+}
+
+func toCIDR(ip string) string {
+	if strings.Contains(ip, ":") {
+		return ip + "/128"
+	}
+
+	return ip + "/32"
+}
+
+func (m *RealIPMiddleware) RealIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ip := m.getForwardedIP(r); ip != "" {
+			r.RemoteAddr = ip
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *RealIPMiddleware) getForwardedIP(r *http.Request) string {
+	if !m.isTrusted(getHost(r.RemoteAddr)) {
+		return ""
+	}
+
+	if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+		return m.getFirstUntrustedHop(strings.Split(forwardedFor, ","))
+	}
+
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+
+	return ""
+}
+
+// getFirstUntrustedHop walks the forwarded chain from the closest hop, the first address not owned by a trusted proxy
+// is the client, anything before it may have been sent by the client itself
+func (m *RealIPMiddleware) getFirstUntrustedHop(hops []string) string {
+	client := ""
+	for index := len(hops) - 1; index >= 0; index-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[index]))
+		if ip == nil {
+			return client
+		}
+
+		client = ip.String()
+		if !m.isTrusted(client) {
+			return client
+		}
+	}
+
+	return client
+}
+
+func (m *RealIPMiddleware) isTrusted(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range m.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getHost(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+
+	return host
+}
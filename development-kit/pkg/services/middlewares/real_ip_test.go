@@ -0,0 +1,89 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serveRealIP(trustedProxies []string, remoteAddr string, headers map[string]string) string {
+	remoteIP := ""
+	handler := NewRealIPMiddleware(trustedProxies).RealIP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteIP = r.RemoteAddr
+	}))
+
+	req, _ := http.NewRequest("POST", "http://test", nil)
+	req.RemoteAddr = remoteAddr
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	return remoteIP
+}
+
+func TestRealIP(t *testing.T) {
+	t.Run("should ignore forwarded headers when no proxy is trusted", func(t *testing.T) {
+		remoteIP := serveRealIP(nil, "10.0.0.1:1234",
+			map[string]string{"X-Forwarded-For": "1.1.1.1", "X-Real-IP": "2.2.2.2"})
+
+		assert.Equal(t, "10.0.0.1:1234", remoteIP)
+	})
+
+	t.Run("should ignore forwarded headers sent by an untrusted peer", func(t *testing.T) {
+		remoteIP := serveRealIP([]string{"10.0.0.0/8"}, "192.168.0.1:1234",
+			map[string]string{"X-Forwarded-For": "1.1.1.1"})
+
+		assert.Equal(t, "192.168.0.1:1234", remoteIP)
+	})
+
+	t.Run("should use the closest untrusted hop of the forwarded chain", func(t *testing.T) {
+		remoteIP := serveRealIP([]string{"10.0.0.0/8", "172.16.0.1"}, "10.0.0.1:1234",
+			map[string]string{"X-Forwarded-For": "9.9.9.9, 1.1.1.1, 172.16.0.1"})
+
+		assert.Equal(t, "1.1.1.1", remoteIP)
+	})
+
+	t.Run("should use the first hop when every hop is trusted", func(t *testing.T) {
+		remoteIP := serveRealIP([]string{"10.0.0.0/8"}, "10.0.0.1:1234",
+			map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"})
+
+		assert.Equal(t, "10.0.0.3", remoteIP)
+	})
+
+	t.Run("should stop at an invalid hop", func(t *testing.T) {
+		remoteIP := serveRealIP([]string{"10.0.0.0/8"}, "10.0.0.1:1234",
+			map[string]string{"X-Forwarded-For": "1.1.1.1, invalid, 10.0.0.2"})
+
+		assert.Equal(t, "10.0.0.2", remoteIP)
+	})
+
+	t.Run("should use x-real-ip from a trusted peer without x-forwarded-for", func(t *testing.T) {
+		remoteIP := serveRealIP([]string{"::1"}, "[::1]:1234", map[string]string{"X-Real-IP": "1.1.1.1"})
+
+		assert.Equal(t, "1.1.1.1", remoteIP)
+	})
+
+	t.Run("should keep remote address when trusted peer sends no valid header", func(t *testing.T) {
+		remoteIP := serveRealIP([]string{"10.0.0.1", "invalid/cidr"}, "10.0.0.1:1234",
+			map[string]string{"X-Real-IP": "invalid"})
+
+		assert.Equal(t, "10.0.0.1:1234", remoteIP)
+	})
+}
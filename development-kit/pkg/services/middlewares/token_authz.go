@@ -16,9 +16,10 @@ package middlewares
 
 import (
 	"context"
-	"github.com/ZupIT/horusec/development-kit/pkg/utils/hash"
 	"net/http"
-	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/hash"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	tokenRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/token"
@@ -33,6 +34,7 @@ type CtxKey string
 
 const RepositoryIDCtxKey CtxKey = "repositoryID"
 const CompanyIDCtxKey CtxKey = "companyID"
+const TokenCtxKey CtxKey = "token"
 
 type ITokenAuthz interface {
 	IsAuthorized(next http.Handler) http.Handler
@@ -42,9 +44,9 @@ type TokenAuthz struct {
 	repository tokenRepository.IRepository
 }
 
-func NewTokenAuthz(postgresRead relational.InterfaceRead) ITokenAuthz {
+func NewTokenAuthz(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite) ITokenAuthz {
 	return &TokenAuthz{
-		repository: tokenRepository.NewTokenRepository(postgresRead, nil),
+		repository: tokenRepository.NewTokenRepository(postgresRead, postgresWrite),
 	}
 }
 
@@ -95,7 +97,21 @@ func (t *TokenAuthz) getContextAndValidateIsValidToken(
 		ctx = t.bindRepositoryIDCtx(ctx, *token.RepositoryID)
 	}
 	ctx = t.bindCompanyIDCtx(ctx, token.CompanyID)
-	return ctx, t.returnErrorIfTokenIsExpired(token)
+	ctx = context.WithValue(ctx, TokenCtxKey, token)
+	if err := t.returnErrorIfTokenIsExpired(token); err != nil {
+		return nil, err
+	}
+
+	t.updateLastUsed(token.TokenID, r)
+	return ctx, nil
+}
+
+// updateLastUsed records the connection ip, the routers set it from forwarded headers only when they were sent by a
+// trusted proxy of the real ip middleware, so clients can not choose the ip shown in the token usage
+func (t *TokenAuthz) updateLastUsed(tokenID uuid.UUID, r *http.Request) {
+	if err := t.repository.UpdateLastUsed(tokenID, getHost(r.RemoteAddr)); err != nil {
+		logger.LogError(errors.ErrorUpdateTokenLastUsed, err)
+	}
 }
 
 func (t *TokenAuthz) bindRepositoryIDCtx(ctx context.Context, repositoryID uuid.UUID) context.Context {
@@ -107,7 +123,7 @@ func (t *TokenAuthz) bindCompanyIDCtx(ctx context.Context, companyID uuid.UUID)
 }
 
 func (t *TokenAuthz) returnErrorIfTokenIsExpired(token *api.Token) error {
-	if token.CreatedAt.AddDate(0, 3, 0).Before(time.Now()) {
+	if token.IsExpired() {
 		return errors.ErrorTokenExpired
 	}
 
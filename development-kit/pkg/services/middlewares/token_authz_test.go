@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	tokenRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/token"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
 	"github.com/google/uuid"
 
@@ -31,9 +32,21 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+type lastUsedRepository struct {
+	*tokenRepository.Mock
+	lastUsedIP string
+}
+
+func (r *lastUsedRepository) UpdateLastUsed(_ uuid.UUID, lastUsedIP string) error {
+	r.lastUsedIP = lastUsedIP
+	return nil
+}
+
 func TestIsAuthorized(t *testing.T) {
 	t.Run("should return 200 when token is authorized", func(t *testing.T) {
 		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
 
 		resp := response.Response{}
 		mockRead.On("SetFilter").Return(&gorm.DB{})
@@ -41,11 +54,62 @@ func TestIsAuthorized(t *testing.T) {
 		mockRead.On("Find").Return(resp.SetData(&api.Token{
 			RepositoryID: &repositoryID,
 			CreatedAt:    time.Now(),
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}))
+
+		middleware := NewTokenAuthz(mockRead, mockWrite)
+		handler := middleware.IsAuthorized(http.HandlerFunc(test.Handler))
+		req, _ := http.NewRequest("GET", "http://test", nil)
+		req.Header.Add("X-Horusec-Authorization", "123")
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockWrite.AssertCalled(t, "Update")
+	})
+
+	t.Run("should bind the token in the context", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
+
+		resp := response.Response{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(resp.SetData(&api.Token{
+			AllowedBranches: []string{"main"},
+			NeverExpires:    true,
 		}))
 
-		middleware := NewTokenAuthz(mockRead)
+		var token *api.Token
+		middleware := NewTokenAuthz(mockRead, mockWrite)
+		handler := middleware.IsAuthorized(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token = r.Context().Value(TokenCtxKey).(*api.Token)
+		}))
+		req, _ := http.NewRequest("GET", "http://test", nil)
+		req.Header.Add("X-Horusec-Authorization", "123")
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Equal(t, []string{"main"}, []string(token.AllowedBranches))
+	})
+
+	t.Run("should return 200 when token never expires and update last used fails", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		resp := response.Response{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(resp.SetData(&api.Token{
+			CreatedAt:    time.Date(0, 0, 0, 0, 0, 0, 0, time.UTC),
+			NeverExpires: true,
+		}))
+
+		middleware := NewTokenAuthz(mockRead, mockWrite)
 		handler := middleware.IsAuthorized(http.HandlerFunc(test.Handler))
 		req, _ := http.NewRequest("GET", "http://test", nil)
+		req.RemoteAddr = "10.0.0.1:5432"
 		req.Header.Add("X-Horusec-Authorization", "123")
 
 		w := httptest.NewRecorder()
@@ -56,6 +120,8 @@ func TestIsAuthorized(t *testing.T) {
 
 	t.Run("should return 401 when token is expired", func(t *testing.T) {
 		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
 
 		resp := response.Response{}
 		mockRead.On("SetFilter").Return(&gorm.DB{})
@@ -65,7 +131,7 @@ func TestIsAuthorized(t *testing.T) {
 			CreatedAt:    time.Date(0, 0, 0, 0, 0, 0, 0, time.UTC),
 		}))
 
-		middleware := NewTokenAuthz(mockRead)
+		middleware := NewTokenAuthz(mockRead, mockWrite)
 		handler := middleware.IsAuthorized(http.HandlerFunc(test.Handler))
 		req, _ := http.NewRequest("GET", "http://test", nil)
 		req.Header.Add("X-Horusec-Authorization", "123")
@@ -78,6 +144,8 @@ func TestIsAuthorized(t *testing.T) {
 
 	t.Run("should return 401 when token is not present", func(t *testing.T) {
 		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
 
 		resp := response.Response{}
 		mockRead.On("SetFilter").Return(&gorm.DB{})
@@ -87,7 +155,7 @@ func TestIsAuthorized(t *testing.T) {
 			CreatedAt:    time.Now(),
 		}))
 
-		middleware := NewTokenAuthz(mockRead)
+		middleware := NewTokenAuthz(mockRead, mockWrite)
 		handler := middleware.IsAuthorized(http.HandlerFunc(test.Handler))
 		req, _ := http.NewRequest("GET", "http://test", nil)
 
@@ -99,12 +167,14 @@ func TestIsAuthorized(t *testing.T) {
 
 	t.Run("should return 401 when token does not exist", func(t *testing.T) {
 		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
 
 		resp := response.Response{}
 		mockRead.On("SetFilter").Return(&gorm.DB{})
 		mockRead.On("Find").Return(resp.SetError(errors.New("test")))
 
-		middleware := NewTokenAuthz(mockRead)
+		middleware := NewTokenAuthz(mockRead, mockWrite)
 		handler := middleware.IsAuthorized(http.HandlerFunc(test.Handler))
 		req, _ := http.NewRequest("GET", "http://test", nil)
 		req.Header.Add("X-Horusec-Authorization", "123")
@@ -114,4 +184,22 @@ func TestIsAuthorized(t *testing.T) {
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
+
+	t.Run("should record the ip forwarded only by trusted proxies as last used", func(t *testing.T) {
+		repository := &lastUsedRepository{Mock: &tokenRepository.Mock{}}
+		repository.On("GetByValue").Return(&api.Token{NeverExpires: true}, nil)
+		handler := NewRealIPMiddleware([]string{"10.0.0.1"}).RealIP(
+			(&TokenAuthz{repository: repository}).IsAuthorized(http.HandlerFunc(test.Handler)))
+
+		req, _ := http.NewRequest("GET", "http://test", nil)
+		req.RemoteAddr = "203.0.113.7:5432"
+		req.Header.Add("X-Horusec-Authorization", "123")
+		req.Header.Add("X-Forwarded-For", "198.51.100.2")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		assert.Equal(t, "203.0.113.7", repository.lastUsedIP)
+
+		req.RemoteAddr = "10.0.0.1:5432"
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		assert.Equal(t, "198.51.100.2", repository.lastUsedIP)
+	})
 }
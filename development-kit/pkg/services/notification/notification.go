@@ -0,0 +1,58 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	notificationRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/notification"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/queues"
+	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/google/uuid"
+)
+
+type IService interface {
+	Dispatch(companyID, repositoryID uuid.UUID, event notificationEnum.Event, message *messages.ChatMessage) error
+}
+
+type Service struct {
+	broker                 brokerLib.IBroker
+	notificationRepository notificationRepository.INotification
+}
+
+func NewNotificationService(databaseRead relational.InterfaceRead, broker brokerLib.IBroker) IService {
+	return &Service{
+		broker:                 broker,
+		notificationRepository: notificationRepository.NewNotificationRepository(databaseRead, nil),
+	}
+}
+
+func (s *Service) Dispatch(companyID, repositoryID uuid.UUID, event notificationEnum.Event,
+	message *messages.ChatMessage) error {
+	notifications, err := s.notificationRepository.GetAllToDispatch(companyID, repositoryID, event)
+	if err != nil {
+		return err
+	}
+
+	for index := range notifications {
+		toSend := message.SetDestination(notifications[index].Channel, notifications[index].URL)
+		if err := s.broker.Publish(queues.HorusecChat.ToString(), "", "", toSend.ToBytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	notificationRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/notification"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	entitiesNotification "github.com/ZupIT/horusec/development-kit/pkg/entities/notification"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("Dispatch").Return(nil)
+	assert.NoError(t, m.Dispatch(uuid.New(), uuid.New(), notificationEnum.AnalysisFinished, &messages.ChatMessage{}))
+}
+
+func TestNewNotificationService(t *testing.T) {
+	assert.NotNil(t, NewNotificationService(&relational.MockRead{}, &broker.Mock{}))
+}
+
+func TestService_Dispatch(t *testing.T) {
+	t.Run("should publish one message for each notification found", func(t *testing.T) {
+		repositoryMock := &notificationRepository.Mock{}
+		repositoryMock.On("GetAllToDispatch").Return([]entitiesNotification.Notification{
+			{Channel: notificationEnum.Slack, URL: "http://example.com"},
+			{Channel: notificationEnum.Teams, URL: "http://example.com"},
+		}, nil)
+		brokerMock := &broker.Mock{}
+		brokerMock.On("Publish").Return(nil)
+
+		service := &Service{broker: brokerMock, notificationRepository: repositoryMock}
+
+		err := service.Dispatch(uuid.New(), uuid.New(), notificationEnum.AnalysisFinished, &messages.ChatMessage{})
+		assert.NoError(t, err)
+		brokerMock.AssertNumberOfCalls(t, "Publish", 2)
+	})
+	t.Run("should return error when get notifications", func(t *testing.T) {
+		repositoryMock := &notificationRepository.Mock{}
+		repositoryMock.On("GetAllToDispatch").Return([]entitiesNotification.Notification{}, errors.New("test"))
+
+		service := &Service{broker: &broker.Mock{}, notificationRepository: repositoryMock}
+
+		err := service.Dispatch(uuid.New(), uuid.New(), notificationEnum.AnalysisFinished, &messages.ChatMessage{})
+		assert.Error(t, err)
+	})
+	t.Run("should return error when publish message", func(t *testing.T) {
+		repositoryMock := &notificationRepository.Mock{}
+		repositoryMock.On("GetAllToDispatch").Return([]entitiesNotification.Notification{
+			{Channel: notificationEnum.Slack, URL: "http://example.com"},
+		}, nil)
+		brokerMock := &broker.Mock{}
+		brokerMock.On("Publish").Return(errors.New("test"))
+
+		service := &Service{broker: brokerMock, notificationRepository: repositoryMock}
+
+		err := service.Dispatch(uuid.New(), uuid.New(), notificationEnum.UserInvited, &messages.ChatMessage{})
+		assert.Error(t, err)
+	})
+}
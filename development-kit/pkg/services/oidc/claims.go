@@ -0,0 +1,67 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import "strings"
+
+type Claims map[string]interface{}
+
+// GetString returns the claim value on the path, nested claims are separated by dots.
+func (c Claims) GetString(path string) string {
+	value, _ := c.get(path).(string)
+	return value
+}
+
+// GetBool returns the claim value on the path, some providers send booleans as strings.
+func (c Claims) GetBool(path string) bool {
+	switch value := c.get(path).(type) {
+	case bool:
+		return value
+	case string:
+		return value == "true"
+	}
+
+	return false
+}
+
+// GetStrings returns the claim value on the path as a list, accepting both a single string and a list of strings.
+func (c Claims) GetStrings(path string) (values []string) {
+	switch value := c.get(path).(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		for _, item := range value {
+			if str, ok := item.(string); ok {
+				values = append(values, str)
+			}
+		}
+	}
+
+	return values
+}
+
+func (c Claims) get(path string) interface{} {
+	var current interface{} = map[string]interface{}(c)
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		current = object[key]
+	}
+
+	return current
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaims(t *testing.T) {
+	claims := Claims{
+		"email":        "horusec@example.com",
+		"groups":       []interface{}{"admin", "developers", 10},
+		"role":         "supervisor",
+		"verified":     true,
+		"verified_str": "true",
+		"realm_access": map[string]interface{}{"roles": []interface{}{"member"}},
+	}
+
+	t.Run("should return string claims", func(t *testing.T) {
+		assert.Equal(t, "horusec@example.com", claims.GetString("email"))
+		assert.Empty(t, claims.GetString("groups"))
+		assert.Empty(t, claims.GetString("not_found"))
+	})
+
+	t.Run("should return list claims", func(t *testing.T) {
+		assert.Equal(t, []string{"admin", "developers"}, claims.GetStrings("groups"))
+		assert.Equal(t, []string{"supervisor"}, claims.GetStrings("role"))
+		assert.Equal(t, []string{"member"}, claims.GetStrings("realm_access.roles"))
+		assert.Empty(t, claims.GetStrings("email.roles"))
+	})
+
+	t.Run("should return bool claims", func(t *testing.T) {
+		assert.True(t, claims.GetBool("verified"))
+		assert.True(t, claims.GetBool("verified_str"))
+		assert.False(t, claims.GetBool("email"))
+		assert.False(t, claims.GetBool("not_found"))
+	})
+}
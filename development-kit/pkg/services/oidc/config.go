@@ -0,0 +1,94 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+)
+
+const (
+	EnvIssuerURL     = "HORUSEC_OIDC_ISSUER_URL"
+	EnvClientID      = "HORUSEC_OIDC_CLIENT_ID"
+	EnvClientSecret  = "HORUSEC_OIDC_CLIENT_SECRET"
+	EnvRedirectURL   = "HORUSEC_OIDC_REDIRECT_URL"
+	EnvScopes        = "HORUSEC_OIDC_SCOPES"
+	EnvUsernameClaim = "HORUSEC_OIDC_USERNAME_CLAIM"
+	EnvEmailClaim    = "HORUSEC_OIDC_EMAIL_CLAIM"
+	EnvGroupsClaim   = "HORUSEC_OIDC_GROUPS_CLAIM"
+	EnvGroupsMapping = "HORUSEC_OIDC_GROUPS_MAPPING"
+)
+
+type Config struct {
+	IssuerURL     string
+	ClientID      string
+	ClientSecret  string
+	RedirectURL   string
+	Scopes        []string
+	UsernameClaim string
+	EmailClaim    string
+	GroupsClaims  []string
+	GroupsMapping map[string]string
+}
+
+func NewConfig() *Config {
+	return &Config{
+		IssuerURL:     strings.TrimSuffix(env.GetEnvOrDefault(EnvIssuerURL, ""), "/"),
+		ClientID:      env.GetEnvOrDefault(EnvClientID, ""),
+		ClientSecret:  env.GetEnvOrDefault(EnvClientSecret, ""),
+		RedirectURL:   env.GetEnvOrDefault(EnvRedirectURL, env.GetHorusecManagerURL()+"/auth/oidc/callback"),
+		Scopes:        splitAndTrim(env.GetEnvOrDefault(EnvScopes, "openid email profile"), " "),
+		UsernameClaim: env.GetEnvOrDefault(EnvUsernameClaim, "preferred_username"),
+		EmailClaim:    env.GetEnvOrDefault(EnvEmailClaim, "email"),
+		GroupsClaims:  splitAndTrim(env.GetEnvOrDefault(EnvGroupsClaim, "groups"), ","),
+		GroupsMapping: parseGroupsMapping(env.GetEnvOrDefault(EnvGroupsMapping, "")),
+	}
+}
+
+// MapGroup translates a value received from the provider, such as an Azure AD group object id,
+// to the group name configured in companies and repositories. Unmapped values are kept as they are.
+func (c *Config) MapGroup(value string) string {
+	if group, ok := c.GroupsMapping[value]; ok {
+		return group
+	}
+
+	return value
+}
+
+func parseGroupsMapping(value string) map[string]string {
+	mapping := map[string]string{}
+	if value == "" {
+		return mapping
+	}
+
+	if err := json.Unmarshal([]byte(value), &mapping); err != nil {
+		logger.LogError("{OIDC} invalid groups mapping, it should be a json object", err)
+	}
+
+	return mapping
+}
+
+func splitAndTrim(value, separator string) (values []string) {
+	for _, item := range strings.Split(value, separator) {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+
+	return values
+}
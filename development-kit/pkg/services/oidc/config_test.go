@@ -0,0 +1,67 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+	t.Run("should return config with default values", func(t *testing.T) {
+		config := NewConfig()
+
+		assert.Equal(t, []string{"openid", "email", "profile"}, config.Scopes)
+		assert.Equal(t, "preferred_username", config.UsernameClaim)
+		assert.Equal(t, "email", config.EmailClaim)
+		assert.Equal(t, []string{"groups"}, config.GroupsClaims)
+		assert.Empty(t, config.GroupsMapping)
+	})
+
+	t.Run("should return config with values from environment", func(t *testing.T) {
+		_ = os.Setenv(EnvIssuerURL, "http://idp.example.com/")
+		_ = os.Setenv(EnvGroupsClaim, "groups, realm_access.roles")
+		_ = os.Setenv(EnvGroupsMapping, `{"6f1c2b4e":"security-team"}`)
+		defer func() {
+			_ = os.Unsetenv(EnvIssuerURL)
+			_ = os.Unsetenv(EnvGroupsClaim)
+			_ = os.Unsetenv(EnvGroupsMapping)
+		}()
+
+		config := NewConfig()
+
+		assert.Equal(t, "http://idp.example.com", config.IssuerURL)
+		assert.Equal(t, []string{"groups", "realm_access.roles"}, config.GroupsClaims)
+		assert.Equal(t, map[string]string{"6f1c2b4e": "security-team"}, config.GroupsMapping)
+	})
+
+	t.Run("should ignore invalid groups mapping", func(t *testing.T) {
+		_ = os.Setenv(EnvGroupsMapping, "invalid")
+		defer func() { _ = os.Unsetenv(EnvGroupsMapping) }()
+
+		assert.Empty(t, NewConfig().GroupsMapping)
+	})
+}
+
+func TestMapGroup(t *testing.T) {
+	t.Run("should return mapped group or the value itself", func(t *testing.T) {
+		config := &Config{GroupsMapping: map[string]string{"6f1c2b4e": "security-team"}}
+
+		assert.Equal(t, "security-team", config.MapGroup("6f1c2b4e"))
+		assert.Equal(t, "developers", config.MapGroup("developers"))
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoveryIsValid(t *testing.T) {
+	t.Run("should return true when all endpoints are present", func(t *testing.T) {
+		discovery := &Discovery{
+			Issuer:                "http://idp.example.com",
+			AuthorizationEndpoint: "http://idp.example.com/authorize",
+			TokenEndpoint:         "http://idp.example.com/token",
+			JwksURI:               "http://idp.example.com/jwks",
+		}
+
+		assert.True(t, discovery.IsValid())
+	})
+
+	t.Run("should return false when missing endpoints", func(t *testing.T) {
+		assert.False(t, (&Discovery{Issuer: "http://idp.example.com"}).IsValid())
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicKeys(t *testing.T) {
+	t.Run("should parse rsa and ec signature keys", func(t *testing.T) {
+		keySet := &JSONWebKeySet{Keys: []JSONWebKey{
+			{KeyID: "rsa", KeyType: "RSA", Use: "sig", N: "sXchDaQebHnPiGvyDOAT4saGEUetSyo9MKLOoWFsueri23bOdgWp4Dy1Wl" +
+				"UzewbgBHod5pcM9H95GQRV3JDXboIRROSBigeC5yjU1hGzHHyXss8UDprecbAYxknTcQkhslANGRUZmdTOQ5qTRsLAt6BTYuyvVRdh" +
+				"S8exSZEy_c4gs_7svlJJQ4H9_NxsiIoLwAEk7-Q3UXERGYw_75IDrGA84-lA_-Ct4eTlXHBIY2EaV7t7LjJaynVJCpkv4LKjTTAumiG" +
+				"UIuQhrNhZLuF_RJLqHpM2kgWFLU7-VTdL1VbC2tejvcI2BlMkEpk1BzBZI0KQB0GaDWFLN-aEAw3vRw", E: "AQAB"},
+			{KeyID: "ec", KeyType: "EC", Curve: "P-256", X: "f83OJ3D2xF1Bg8vub9tLe1gHMzV76e8Tus9uPHvRVEU",
+				Y: "x_FEzRu9m36HLN_tue659LNpXW6pCyStikYjKIWI5a0"},
+			{KeyID: "enc", KeyType: "RSA", Use: "enc", N: "AQAB", E: "AQAB"},
+			{KeyID: "invalid", KeyType: "oct"},
+		}}
+
+		keys := keySet.PublicKeys()
+
+		assert.Len(t, keys, 2)
+		assert.IsType(t, &rsa.PublicKey{}, keys["rsa"])
+		assert.Equal(t, 65537, keys["rsa"].(*rsa.PublicKey).E)
+		assert.IsType(t, &ecdsa.PublicKey{}, keys["ec"])
+	})
+
+	t.Run("should return error when invalid key values", func(t *testing.T) {
+		_, err := (&JSONWebKey{KeyType: "RSA", N: "@", E: "AQAB"}).PublicKey()
+		assert.Error(t, err)
+
+		_, err = (&JSONWebKey{KeyType: "RSA", N: "AQAB", E: "@"}).PublicKey()
+		assert.Error(t, err)
+
+		_, err = (&JSONWebKey{KeyType: "EC", Curve: "P-000"}).PublicKey()
+		assert.Error(t, err)
+
+		_, err = (&JSONWebKey{KeyType: "EC", Curve: "P-256", X: "@"}).PublicKey()
+		assert.Error(t, err)
+
+		_, err = (&JSONWebKey{KeyType: "EC", Curve: "P-256", X: "AQAB", Y: "@"}).PublicKey()
+		assert.Error(t, err)
+	})
+}
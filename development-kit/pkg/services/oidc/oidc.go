@@ -0,0 +1,275 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	DiscoveryPath          = "/.well-known/openid-configuration"
+	MinKeysRefreshInterval = time.Minute
+)
+
+type IService interface {
+	GetAuthorizationURL(state, nonce, codeChallenge string) (string, error)
+	ExchangeCode(code, codeVerifier string) (*dto.OIDCToken, error)
+	ValidateIDToken(idToken, nonce string) (*Identity, error)
+}
+
+type Identity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Username      string
+	Groups        []string
+	ExpiresAt     time.Time
+}
+
+type Service struct {
+	config          *Config
+	httpClient      *http.Client
+	mutex           sync.RWMutex
+	discovery       *Discovery
+	keys            map[string]interface{}
+	refreshMutex    sync.Mutex
+	keysRefreshedAt time.Time
+}
+
+func NewOIDCService(config *Config) IService {
+	return &Service{
+		config:     config,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		keys:       map[string]interface{}{},
+	}
+}
+
+func (s *Service) GetAuthorizationURL(state, nonce, codeChallenge string) (string, error) {
+	discovery, err := s.getDiscovery()
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", s.config.ClientID)
+	query.Set("redirect_uri", s.config.RedirectURL)
+	query.Set("scope", strings.Join(s.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	return discovery.AuthorizationEndpoint + "?" + query.Encode(), nil
+}
+
+func (s *Service) ExchangeCode(code, codeVerifier string) (*dto.OIDCToken, error) {
+	discovery, err := s.getDiscovery()
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", s.config.RedirectURL)
+	form.Set("client_id", s.config.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if s.config.ClientSecret != "" {
+		form.Set("client_secret", s.config.ClientSecret)
+	}
+
+	token := &dto.OIDCToken{}
+	if err := s.doRequest(http.MethodPost, discovery.TokenEndpoint, form, token); err != nil || token.IDToken == "" {
+		return nil, errorsEnum.ErrorOIDCCodeExchange
+	}
+
+	return token, nil
+}
+
+func (s *Service) ValidateIDToken(idToken, nonce string) (*Identity, error) {
+	discovery, err := s.getDiscovery()
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := s.parseIDToken(idToken)
+	if err != nil {
+		return nil, errorsEnum.ErrorInvalidOIDCToken
+	}
+
+	if !s.isValidClaims(claims, discovery.Issuer, nonce) {
+		return nil, errorsEnum.ErrorInvalidOIDCToken
+	}
+
+	return s.newIdentity(claims), nil
+}
+
+func (s *Service) parseIDToken(idToken string) (Claims, error) {
+	parser := &jwt.Parser{ValidMethods: []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}}
+	token, err := parser.Parse(strings.ReplaceAll(idToken, "Bearer ", ""), func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header["kid"].(string)
+		return s.getKey(keyID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return Claims(token.Claims.(jwt.MapClaims)), nil
+}
+
+func (s *Service) isValidClaims(claims Claims, issuer, nonce string) bool {
+	if claims.GetString("iss") != issuer || claims.GetString("nonce") != nonce {
+		return false
+	}
+
+	for _, audience := range claims.GetStrings("aud") {
+		if audience == s.config.ClientID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Service) newIdentity(claims Claims) *Identity {
+	identity := &Identity{
+		Issuer:        claims.GetString("iss"),
+		Subject:       claims.GetString("sub"),
+		Email:         claims.GetString(s.config.EmailClaim),
+		EmailVerified: claims.GetBool("email_verified"),
+		Username:      claims.GetString(s.config.UsernameClaim),
+	}
+
+	if exp, ok := claims["exp"].(float64); ok {
+		identity.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+
+	for _, claim := range s.config.GroupsClaims {
+		for _, value := range claims.GetStrings(claim) {
+			identity.Groups = append(identity.Groups, s.config.MapGroup(value))
+		}
+	}
+
+	return identity
+}
+
+func (s *Service) getDiscovery() (*Discovery, error) {
+	s.mutex.RLock()
+	discovery := s.discovery
+	s.mutex.RUnlock()
+	if discovery != nil {
+		return discovery, nil
+	}
+
+	discovery = &Discovery{}
+	if err := s.doRequest(http.MethodGet, s.config.IssuerURL+DiscoveryPath, nil, discovery); err != nil ||
+		!discovery.IsValid() || strings.TrimSuffix(discovery.Issuer, "/") != s.config.IssuerURL {
+		return nil, errorsEnum.ErrorOIDCDiscovery
+	}
+
+	s.mutex.Lock()
+	s.discovery = discovery
+	s.mutex.Unlock()
+	return discovery, nil
+}
+
+// getKey returns the cached key of the id, when not found the jwks is loaded again to support key rotation.
+func (s *Service) getKey(keyID string) (interface{}, error) {
+	if key, ok := s.getCachedKey(keyID); ok {
+		return key, nil
+	}
+
+	if err := s.refreshKeys(); err != nil {
+		return nil, err
+	}
+
+	if key, ok := s.getCachedKey(keyID); ok {
+		return key, nil
+	}
+
+	return nil, errorsEnum.ErrorOIDCKeyNotFound
+}
+
+func (s *Service) getCachedKey(keyID string) (interface{}, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	key, ok := s.keys[keyID]
+	return key, ok
+}
+
+// refreshKeys loads the jwks at most once each min keys refresh interval, also when the last load failed, so tokens
+// with unknown key ids can not flood the provider. Concurrent refreshes wait the first one and use its keys.
+func (s *Service) refreshKeys() error {
+	s.refreshMutex.Lock()
+	defer s.refreshMutex.Unlock()
+	if time.Since(s.keysRefreshedAt) < MinKeysRefreshInterval {
+		return nil
+	}
+
+	s.keysRefreshedAt = time.Now()
+	return s.loadKeys()
+}
+
+func (s *Service) loadKeys() error {
+	discovery, err := s.getDiscovery()
+	if err != nil {
+		return err
+	}
+
+	keySet := &JSONWebKeySet{}
+	if err := s.doRequest(http.MethodGet, discovery.JwksURI, nil, keySet); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.keys = keySet.PublicKeys()
+	s.mutex.Unlock()
+	return nil
+}
+
+func (s *Service) doRequest(method, endpoint string, form url.Values, response interface{}) error {
+	request, err := http.NewRequest(method, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if form != nil {
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	httpResponse, err := s.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return errorsEnum.ErrorOIDCUnexpectedResponse
+	}
+
+	return json.NewDecoder(httpResponse.Body).Decode(response)
+}
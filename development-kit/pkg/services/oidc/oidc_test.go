@@ -0,0 +1,184 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestService(provider *test.OIDCProvider) IService {
+	return NewOIDCService(&Config{
+		IssuerURL:     provider.URL(),
+		ClientID:      provider.ClientID,
+		RedirectURL:   "http://localhost:8043/auth/oidc/callback",
+		Scopes:        []string{"openid", "email"},
+		UsernameClaim: "preferred_username",
+		EmailClaim:    "email",
+		GroupsClaims:  []string{"groups", "realm_access.roles"},
+		GroupsMapping: map[string]string{"6f1c2b4e": "security-team"},
+	})
+}
+
+func TestGetAuthorizationURL(t *testing.T) {
+	provider := test.NewOIDCProvider("horusec")
+	defer provider.Close()
+
+	t.Run("should return authorization url with pkce parameters", func(t *testing.T) {
+		authorizationURL, err := newTestService(provider).GetAuthorizationURL("state", "nonce", "challenge")
+		assert.NoError(t, err)
+
+		parsed, err := url.Parse(authorizationURL)
+		assert.NoError(t, err)
+		assert.Equal(t, provider.URL()+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+		assert.Equal(t, "code", parsed.Query().Get("response_type"))
+		assert.Equal(t, "horusec", parsed.Query().Get("client_id"))
+		assert.Equal(t, "openid email", parsed.Query().Get("scope"))
+		assert.Equal(t, "state", parsed.Query().Get("state"))
+		assert.Equal(t, "nonce", parsed.Query().Get("nonce"))
+		assert.Equal(t, "challenge", parsed.Query().Get("code_challenge"))
+		assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+	})
+
+	t.Run("should return error when failed to load discovery", func(t *testing.T) {
+		service := NewOIDCService(&Config{IssuerURL: provider.URL() + "/invalid"})
+
+		_, err := service.GetAuthorizationURL("state", "nonce", "challenge")
+		assert.Equal(t, errors.ErrorOIDCDiscovery, err)
+	})
+}
+
+func TestExchangeCodeAndValidateIDToken(t *testing.T) {
+	provider := test.NewOIDCProvider("horusec")
+	defer provider.Close()
+
+	provider.SetUser(map[string]interface{}{
+		"email":              "horusec@example.com",
+		"preferred_username": "horusec",
+		"groups":             []string{"6f1c2b4e", "developers"},
+		"realm_access":       map[string]interface{}{"roles": []string{"admin"}},
+	})
+
+	t.Run("should login with authorization code and return mapped identity", func(t *testing.T) {
+		service := newTestService(provider)
+		verifier, _ := NewRandomString()
+
+		authorizationURL, err := service.GetAuthorizationURL("state", "nonce", NewCodeChallenge(verifier))
+		assert.NoError(t, err)
+
+		code, state, err := provider.Authorize(authorizationURL)
+		assert.NoError(t, err)
+		assert.Equal(t, "state", state)
+
+		token, err := service.ExchangeCode(code, verifier)
+		assert.NoError(t, err)
+
+		identity, err := service.ValidateIDToken(token.IDToken, "nonce")
+		assert.NoError(t, err)
+		assert.Equal(t, "horusec@example.com", identity.Email)
+		assert.Equal(t, "horusec", identity.Username)
+		assert.NotEmpty(t, identity.Subject)
+		assert.Equal(t, provider.URL(), identity.Issuer)
+		assert.True(t, identity.ExpiresAt.After(time.Now()))
+		assert.Equal(t, []string{"security-team", "developers", "admin"}, identity.Groups)
+	})
+
+	t.Run("should return error when code verifier does not match challenge", func(t *testing.T) {
+		service := newTestService(provider)
+
+		authorizationURL, _ := service.GetAuthorizationURL("state", "nonce", NewCodeChallenge("verifier"))
+		code, _, _ := provider.Authorize(authorizationURL)
+
+		_, err := service.ExchangeCode(code, "other-verifier")
+		assert.Equal(t, errors.ErrorOIDCCodeExchange, err)
+	})
+
+	t.Run("should return error when nonce does not match", func(t *testing.T) {
+		idToken := provider.IssueIDToken(map[string]interface{}{"nonce": "other"})
+
+		_, err := newTestService(provider).ValidateIDToken(idToken, "nonce")
+		assert.Equal(t, errors.ErrorInvalidOIDCToken, err)
+	})
+
+	t.Run("should return error when audience is other client", func(t *testing.T) {
+		idToken := provider.IssueIDToken(map[string]interface{}{"aud": []string{"other-client"}})
+
+		_, err := newTestService(provider).ValidateIDToken(idToken, "")
+		assert.Equal(t, errors.ErrorInvalidOIDCToken, err)
+	})
+
+	t.Run("should return error when token is expired", func(t *testing.T) {
+		idToken := provider.IssueIDToken(map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()})
+
+		_, err := newTestService(provider).ValidateIDToken(idToken, "")
+		assert.Equal(t, errors.ErrorInvalidOIDCToken, err)
+	})
+
+	t.Run("should return error when token is signed by other provider", func(t *testing.T) {
+		otherProvider := test.NewOIDCProvider("horusec")
+		defer otherProvider.Close()
+
+		_, err := newTestService(provider).ValidateIDToken(otherProvider.IssueIDToken(nil), "")
+		assert.Equal(t, errors.ErrorInvalidOIDCToken, err)
+	})
+
+	t.Run("should accept audience list containing the client", func(t *testing.T) {
+		idToken := provider.IssueIDToken(map[string]interface{}{"aud": []string{"other-client", "horusec"}})
+
+		identity, err := newTestService(provider).ValidateIDToken("Bearer "+idToken, "")
+		assert.NoError(t, err)
+		assert.NotNil(t, identity)
+	})
+}
+
+func TestGetKey(t *testing.T) {
+	provider := test.NewOIDCProvider("horusec")
+	defer provider.Close()
+
+	t.Run("should load the jwks again for unknown key ids only after the min refresh interval", func(t *testing.T) {
+		service := newTestService(provider).(*Service)
+
+		_, err := service.getKey(test.OIDCProviderKeyID)
+		assert.NoError(t, err)
+
+		for i := 0; i < 5; i++ {
+			_, err = service.getKey("unknown")
+			assert.Equal(t, errors.ErrorOIDCKeyNotFound, err)
+		}
+
+		assert.Equal(t, 1, provider.GetJWKSRequests())
+
+		service.keysRefreshedAt = time.Now().Add(-MinKeysRefreshInterval)
+		_, err = service.getKey("unknown")
+		assert.Equal(t, errors.ErrorOIDCKeyNotFound, err)
+		assert.Equal(t, 2, provider.GetJWKSRequests())
+	})
+
+	t.Run("should return the cached key without loading the jwks", func(t *testing.T) {
+		service := newTestService(provider).(*Service)
+		service.keys = map[string]interface{}{"cached": "key"}
+		jwksRequests := provider.GetJWKSRequests()
+
+		key, err := service.getKey("cached")
+		assert.NoError(t, err)
+		assert.Equal(t, "key", key)
+		assert.Equal(t, jwksRequests, provider.GetJWKSRequests())
+	})
+}
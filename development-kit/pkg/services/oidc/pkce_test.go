@@ -0,0 +1,41 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRandomString(t *testing.T) {
+	t.Run("should return different url safe values", func(t *testing.T) {
+		first, err := NewRandomString()
+		assert.NoError(t, err)
+
+		second, err := NewRandomString()
+		assert.NoError(t, err)
+
+		assert.Len(t, first, 43)
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestNewCodeChallenge(t *testing.T) {
+	t.Run("should return base64 url encoded sha256 of the code verifier", func(t *testing.T) {
+		assert.Equal(t, "hnJM7YgEyf4KWZ6ZmG4Pfb6TOR55JPoDHCSMWieaLiw",
+			NewCodeChallenge("dBjftJeZ4CK-pY-cO0xGBDK4TKWaJ-jU4Kxz7eFP5U8"))
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	EnabledEnv          = "HORUSEC_TRACING_ENABLED"
+	OTLPEndpointEnv     = "HORUSEC_TRACING_OTLP_ENDPOINT"
+	OTLPInsecureEnv     = "HORUSEC_TRACING_OTLP_INSECURE"
+	DefaultOTLPEndpoint = "localhost:4318"
+	TracerName          = "github.com/ZupIT/horusec"
+)
+
+// SetUpTracing exports the spans of the service to the OTLP endpoint when the tracing is enabled, the trace context
+// is always propagated so a service without exporter does not break the trace of the others. The returned function
+// flushes the spans not exported yet
+func SetUpTracing(serviceName string) (shutdown func()) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	if !env.GetEnvOrDefaultBool(EnabledEnv, false) {
+		return func() {}
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), getExporterOptions()...)
+	if err != nil {
+		logger.LogError("{HORUSEC} error when create the OTLP exporter, the spans will not be exported", err)
+		return func() {}
+	}
+
+	provider := sdkTrace.NewTracerProvider(sdkTrace.WithBatcher(exporter),
+		sdkTrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))))
+	otel.SetTracerProvider(provider)
+	return func() {
+		if err := provider.Shutdown(context.Background()); err != nil {
+			logger.LogError("{HORUSEC} error when flush the spans to the OTLP exporter", err)
+		}
+	}
+}
+
+func getExporterOptions() []otlptracehttp.Option {
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(env.GetEnvOrDefault(OTLPEndpointEnv, DefaultOTLPEndpoint)),
+	}
+
+	if env.GetEnvOrDefaultBool(OTLPInsecureEnv, true) {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	return options
+}
+
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// EndSpan marks the span as failed when the error is not nil before ending it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+func InjectHTTPHeaders(ctx context.Context, header http.Header) {
+	Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Middleware starts a server span for each request continuing the trace of the traceparent header, the span is
+// renamed to the route pattern after the request to not create a name by id
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := StartSpan(ctx, r.Method+" "+r.URL.Path, trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPMethodKey.String(r.Method), semconv.HTTPTargetKey.String(r.URL.Path)))
+		defer span.End()
+
+		writer := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(writer, r.WithContext(ctx))
+
+		setRouteAndStatus(span, r, writer.Status())
+	})
+}
+
+func setRouteAndStatus(span trace.Span, r *http.Request, status int) {
+	if routeContext := chi.RouteContext(r.Context()); routeContext != nil && routeContext.RoutePattern() != "" {
+		span.SetName(r.Method + " " + routeContext.RoutePattern())
+		span.SetAttributes(semconv.HTTPRouteKey.String(routeContext.RoutePattern()))
+	}
+
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func setUpRecorder() *tracetest.SpanRecorder {
+	SetUpTracing("test")
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder)))
+	return recorder
+}
+
+func TestSetUpTracing(t *testing.T) {
+	t.Run("should return a shutdown without panic when tracing is disabled", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			SetUpTracing("test")()
+		})
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("should continue the trace of the header and name the span by route", func(t *testing.T) {
+		recorder := setUpRecorder()
+		router := chi.NewRouter()
+		router.Use(Middleware)
+		router.Get("/analysis/{analysisID}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		r, _ := http.NewRequest(http.MethodGet, "/analysis/1", nil)
+		r.Header.Set("traceparent", traceParent)
+		router.ServeHTTP(httptest.NewRecorder(), r)
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "GET /analysis/{analysisID}", spans[0].Name())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+	})
+}
+
+func TestEndSpan(t *testing.T) {
+	t.Run("should set error status when has error", func(t *testing.T) {
+		recorder := setUpRecorder()
+
+		_, span := StartSpan(context.Background(), "test")
+		EndSpan(span, errors.New("test"))
+
+		assert.Equal(t, codes.Error, recorder.Ended()[0].Status().Code)
+	})
+}
+
+func TestInjectHTTPHeaders(t *testing.T) {
+	t.Run("should set traceparent of the span in the headers", func(t *testing.T) {
+		setUpRecorder()
+		header := http.Header{}
+
+		ctx, span := StartSpan(context.Background(), "test")
+		InjectHTTPHeaders(ctx, header)
+		span.End()
+
+		assert.Contains(t, header.Get("traceparent"), span.SpanContext().TraceID().String())
+	})
+
+	t.Run("should not set traceparent without span", func(t *testing.T) {
+		header := http.Header{}
+
+		InjectHTTPHeaders(context.Background(), header)
+
+		assert.Empty(t, header.Get("traceparent"))
+	})
+}
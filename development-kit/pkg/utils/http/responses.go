@@ -16,7 +16,10 @@ package http
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 
@@ -104,6 +107,16 @@ func StatusUnprocessableEntity(w http.ResponseWriter, err error) {
 	setResponseWriter(w, response)
 }
 
+// StatusTooManyRequests sets the Retry-After header in seconds, rounded up so clients never retry too early
+func StatusTooManyRequests(w http.ResponseWriter, err error, retryAfter time.Duration) {
+	response := &httpEntities.Response{}
+	response.SetResponseData(http.StatusTooManyRequests,
+		http.StatusText(http.StatusTooManyRequests), getErrorMessage(err))
+
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	setResponseWriter(w, response)
+}
+
 func setResponseWriter(w http.ResponseWriter, response *httpEntities.Response) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	"github.com/stretchr/testify/assert"
@@ -157,3 +158,14 @@ func TestStatusUnprocessableEntity(t *testing.T) {
 		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 	})
 }
+
+func TestStatusTooManyRequests(t *testing.T) {
+	t.Run("should return status code 429 with retry after header", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		StatusTooManyRequests(w, EnumErrors.ErrTest, 1500*time.Millisecond)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	})
+}
@@ -0,0 +1,213 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+)
+
+const OIDCProviderKeyID = "horusec-test-key"
+
+// OIDCProvider is a local stand-in identity provider serving the discovery document, jwks, authorize and token
+// endpoints of the authorization code flow with pkce. The authorize endpoint logs in the user set with SetUser.
+type OIDCProvider struct {
+	Server       *httptest.Server
+	ClientID     string
+	privateKey   *rsa.PrivateKey
+	mutex        sync.Mutex
+	user         map[string]interface{}
+	codes        map[string]oidcAuthorizationCode
+	jwksRequests int
+}
+
+type oidcAuthorizationCode struct {
+	codeChallenge string
+	nonce         string
+	claims        map[string]interface{}
+}
+
+func NewOIDCProvider(clientID string) *OIDCProvider {
+	privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	provider := &OIDCProvider{
+		ClientID:   clientID,
+		privateKey: privateKey,
+		user:       map[string]interface{}{},
+		codes:      map[string]oidcAuthorizationCode{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", provider.discovery)
+	mux.HandleFunc("/jwks", provider.jwks)
+	mux.HandleFunc("/authorize", provider.authorize)
+	mux.HandleFunc("/token", provider.token)
+	provider.Server = httptest.NewServer(mux)
+
+	return provider
+}
+
+func (p *OIDCProvider) URL() string {
+	return p.Server.URL
+}
+
+func (p *OIDCProvider) Close() {
+	p.Server.Close()
+}
+
+// GetJWKSRequests returns how many times the jwks was requested
+func (p *OIDCProvider) GetJWKSRequests() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.jwksRequests
+}
+
+func (p *OIDCProvider) SetUser(claims map[string]interface{}) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.user = claims
+}
+
+// IssueIDToken signs an id token with the provider key, iss, aud, iat and exp are filled when not informed.
+func (p *OIDCProvider) IssueIDToken(claims map[string]interface{}) string {
+	mapClaims := jwt.MapClaims{
+		"iss": p.URL(),
+		"aud": p.ClientID,
+		"sub": uuid.New().String(),
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+
+	for key, value := range claims {
+		mapClaims[key] = value
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, mapClaims)
+	token.Header["kid"] = OIDCProviderKeyID
+	signed, _ := token.SignedString(p.privateKey)
+	return signed
+}
+
+// Authorize simulates the browser login on the authorization url and returns the code and state of the redirect.
+func (p *OIDCProvider) Authorize(authorizationURL string) (code, state string, err error) {
+	client := &http.Client{CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	response, err := client.Get(authorizationURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	defer response.Body.Close()
+	location, err := response.Location()
+	if err != nil {
+		return "", "", err
+	}
+
+	return location.Query().Get("code"), location.Query().Get("state"), nil
+}
+
+func (p *OIDCProvider) discovery(w http.ResponseWriter, _ *http.Request) {
+	p.writeJSON(w, map[string]interface{}{
+		"issuer":                           p.URL(),
+		"authorization_endpoint":           p.URL() + "/authorize",
+		"token_endpoint":                   p.URL() + "/token",
+		"jwks_uri":                         p.URL() + "/jwks",
+		"code_challenge_methods_supported": []string{"S256"},
+	})
+}
+
+func (p *OIDCProvider) jwks(w http.ResponseWriter, _ *http.Request) {
+	p.mutex.Lock()
+	p.jwksRequests++
+	p.mutex.Unlock()
+
+	p.writeJSON(w, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": OIDCProviderKeyID,
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(p.privateKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.privateKey.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *OIDCProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != p.ClientID || query.Get("code_challenge_method") != "S256" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	p.mutex.Lock()
+	code := uuid.New().String()
+	p.codes[code] = oidcAuthorizationCode{
+		codeChallenge: query.Get("code_challenge"),
+		nonce:         query.Get("nonce"),
+		claims:        p.user,
+	}
+	p.mutex.Unlock()
+
+	redirect := url.Values{}
+	redirect.Set("code", code)
+	redirect.Set("state", query.Get("state"))
+	http.Redirect(w, r, query.Get("redirect_uri")+"?"+redirect.Encode(), http.StatusFound)
+}
+
+func (p *OIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+	p.mutex.Lock()
+	authorizationCode, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mutex.Unlock()
+
+	hash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || r.PostForm.Get("client_id") != p.ClientID ||
+		base64.RawURLEncoding.EncodeToString(hash[:]) != authorizationCode.codeChallenge {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	claims := map[string]interface{}{"nonce": authorizationCode.nonce}
+	for key, value := range authorizationCode.claims {
+		claims[key] = value
+	}
+
+	p.writeJSON(w, map[string]interface{}{
+		"access_token": uuid.New().String(),
+		"id_token":     p.IssueIDToken(claims),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+func (p *OIDCProvider) writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // nolint:gosec // sha1 is the algorithm required by authenticator apps
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	Issuer             = "Horusec"
+	Digits             = 6
+	Period             = 30
+	Skew               = 1
+	SecretSize         = 20
+	RecoveryCodesCount = 10
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func NewSecret() (string, error) {
+	secret := make([]byte, SecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+func GetProvisioningURI(accountName, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", Issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(Period))
+
+	return fmt.Sprintf("otpauth://totp/%s:%s?%s", url.PathEscape(Issuer), url.PathEscape(accountName), query.Encode())
+}
+
+// GenerateCode returns the rfc 6238 code of the secret for the time step of the informed moment.
+func GenerateCode(secret string, at time.Time) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(at.Unix()/Period))
+
+	mac := hmac.New(sha1.New, key)
+	_, _ = mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", Digits, value%1000000), nil
+}
+
+// Validate checks the code against the current time step and the adjacent ones to tolerate clock drift.
+func Validate(code, secret string) bool {
+	return ValidateAt(code, secret, time.Now())
+}
+
+func ValidateAt(code, secret string, at time.Time) bool {
+	_, ok := ValidateStepAt(code, secret, at)
+	return ok
+}
+
+// ValidateStepAt returns the time step of the code, used to accept each code only once.
+func ValidateStepAt(code, secret string, at time.Time) (int64, bool) {
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	for step := -Skew; step <= Skew; step++ {
+		stepAt := at.Add(time.Duration(step*Period) * time.Second)
+		expected, err := GenerateCode(secret, stepAt)
+		if err != nil {
+			return 0, false
+		}
+
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return stepAt.Unix() / Period, true
+		}
+	}
+
+	return 0, false
+}
+
+func NewRecoveryCodes() ([]string, error) {
+	codes := make([]string, RecoveryCodesCount)
+	for index := range codes {
+		value := make([]byte, 5)
+		if _, err := rand.Read(value); err != nil {
+			return nil, err
+		}
+
+		code := hex.EncodeToString(value)
+		codes[index] = code[:5] + "-" + code[5:]
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the base32 of the sha1 seed "12345678901234567890" used by the rfc 6238 test vectors
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestNewSecret(t *testing.T) {
+	t.Run("should return a base32 secret without padding", func(t *testing.T) {
+		secret, err := NewSecret()
+
+		assert.NoError(t, err)
+		assert.Len(t, secret, 32)
+		assert.NotContains(t, secret, "=")
+	})
+
+	t.Run("should return a different secret each call", func(t *testing.T) {
+		first, _ := NewSecret()
+		second, _ := NewSecret()
+
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestGetProvisioningURI(t *testing.T) {
+	t.Run("should return the otpauth uri with issuer and secret", func(t *testing.T) {
+		uri := GetProvisioningURI("test@test.com", rfcSecret)
+
+		assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Horusec:test@test.com?"))
+		assert.Contains(t, uri, "secret="+rfcSecret)
+		assert.Contains(t, uri, "issuer=Horusec")
+	})
+}
+
+func TestGenerateCode(t *testing.T) {
+	t.Run("should return the rfc 6238 test vectors", func(t *testing.T) {
+		vectors := map[int64]string{
+			59:          "287082",
+			1111111109:  "081804",
+			1111111111:  "050471",
+			1234567890:  "005924",
+			2000000000:  "279037",
+			20000000000: "353130",
+		}
+
+		for unix, expected := range vectors {
+			code, err := GenerateCode(rfcSecret, time.Unix(unix, 0))
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, code)
+		}
+	})
+
+	t.Run("should return error when secret is not base32", func(t *testing.T) {
+		_, err := GenerateCode("!invalid!", time.Now())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateAt(t *testing.T) {
+	at := time.Unix(1111111111, 0)
+
+	t.Run("should return true for the code of the current step", func(t *testing.T) {
+		assert.True(t, ValidateAt("050471", rfcSecret, at))
+	})
+
+	t.Run("should return true for the code of the adjacent steps", func(t *testing.T) {
+		previous, _ := GenerateCode(rfcSecret, at.Add(-Period*time.Second))
+		next, _ := GenerateCode(rfcSecret, at.Add(Period*time.Second))
+
+		assert.True(t, ValidateAt(previous, rfcSecret, at))
+		assert.True(t, ValidateAt(next, rfcSecret, at))
+	})
+
+	t.Run("should return false for codes out of the window", func(t *testing.T) {
+		old, _ := GenerateCode(rfcSecret, at.Add(-3*Period*time.Second))
+
+		assert.False(t, ValidateAt(old, rfcSecret, at))
+	})
+
+	t.Run("should return false for invalid codes and secrets", func(t *testing.T) {
+		assert.False(t, ValidateAt("", rfcSecret, at))
+		assert.False(t, ValidateAt("12345", rfcSecret, at))
+		assert.False(t, ValidateAt("050471", "!invalid!", at))
+	})
+
+	t.Run("should return the time step of the code", func(t *testing.T) {
+		previous, _ := GenerateCode(rfcSecret, at.Add(-Period*time.Second))
+
+		step, ok := ValidateStepAt("050471", rfcSecret, at)
+		assert.True(t, ok)
+		assert.Equal(t, at.Unix()/Period, step)
+
+		step, ok = ValidateStepAt(previous, rfcSecret, at)
+		assert.True(t, ok)
+		assert.Equal(t, at.Unix()/Period-1, step)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("should validate the code of now", func(t *testing.T) {
+		secret, _ := NewSecret()
+		code, _ := GenerateCode(secret, time.Now())
+
+		assert.True(t, Validate(code, secret))
+	})
+}
+
+func TestNewRecoveryCodes(t *testing.T) {
+	t.Run("should return unique recovery codes", func(t *testing.T) {
+		codes, err := NewRecoveryCodes()
+
+		assert.NoError(t, err)
+		assert.Len(t, codes, RecoveryCodesCount)
+
+		unique := map[string]bool{}
+		for _, code := range codes {
+			assert.Len(t, code, 11)
+			unique[code] = true
+		}
+
+		assert.Len(t, unique, RecoveryCodesCount)
+	})
+}
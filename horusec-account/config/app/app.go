@@ -16,30 +16,37 @@ package app
 
 import (
 	"context"
+	"strings"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	authGrpc "github.com/ZupIT/horusec/development-kit/pkg/services/grpc/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	"google.golang.org/grpc"
 )
 
+const TrustedProxiesEnv = "HORUSEC_TRUSTED_PROXIES"
+
 type Config struct {
 	auth.ConfigAuth
-	grpcCon authGrpc.AuthServiceClient
-	context context.Context
+	TrustedProxies string
+	grpcCon        authGrpc.AuthServiceClient
+	context        context.Context
 }
 
 type IAppConfig interface {
 	IsDisabledBroker() bool
 	IsApplicationAdminEnable() bool
 	GetAuthType() authEnums.AuthorizationType
+	GetTrustedProxies() []string
 }
 
 func SetupApp(grpcCon grpc.ClientConnInterface) IAppConfig {
 	appConfig := &Config{
-		grpcCon: authGrpc.NewAuthServiceClient(grpcCon),
-		context: context.Background(),
+		TrustedProxies: env.GetEnvOrDefault(TrustedProxiesEnv, ""),
+		grpcCon:        authGrpc.NewAuthServiceClient(grpcCon),
+		context:        context.Background(),
 	}
 
 	return appConfig.getAuthConfig()
@@ -68,3 +75,12 @@ func (c *Config) IsApplicationAdminEnable() bool {
 func (c *Config) GetAuthType() authEnums.AuthorizationType {
 	return c.AuthType
 }
+
+// GetTrustedProxies returns the ips or cidrs allowed to send the client ip in forwarded headers
+func (c *Config) GetTrustedProxies() []string {
+	if strings.TrimSpace(c.TrustedProxies) == "" {
+		return []string{}
+	}
+
+	return strings.Split(c.TrustedProxies, ",")
+}
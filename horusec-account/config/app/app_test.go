@@ -92,3 +92,14 @@ func TestGetAuthType(t *testing.T) {
 		assert.Equal(t, authEnums.Ldap, appConfig.GetAuthType())
 	})
 }
+
+func TestGetTrustedProxies(t *testing.T) {
+	t.Run("should return no trusted proxies by default", func(t *testing.T) {
+		assert.Empty(t, (&Config{}).GetTrustedProxies())
+	})
+
+	t.Run("should split trusted proxies by comma", func(t *testing.T) {
+		appConfig := &Config{TrustedProxies: "10.0.0.0/8,172.16.0.1"}
+		assert.Equal(t, []string{"10.0.0.0/8", "172.16.0.1"}, appConfig.GetTrustedProxies())
+	})
+}
@@ -15,6 +15,8 @@
 package companies
 
 import (
+	"fmt"
+
 	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	repositoryAccount "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
 	repoAccountCompany "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_company"
@@ -25,12 +27,14 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
 	accountEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/account"
-	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	emailEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/messages"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/queues"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	notificationService "github.com/ZupIT/horusec/development-kit/pkg/services/notification"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	"github.com/ZupIT/horusec/horusec-account/config/app"
 	companyUseCases "github.com/ZupIT/horusec/horusec-account/internal/usecases/company"
 	"github.com/google/uuid"
@@ -47,6 +51,7 @@ type IController interface {
 	GetAllAccountsInCompany(companyID uuid.UUID) (*[]roles.AccountRole, error)
 	RemoveUser(removeUser *dto.RemoveUser) error
 	GetAccountIDByEmail(email string) (uuid.UUID, error)
+	ResetTwoFactor(companyID, accountID uuid.UUID) error
 }
 
 type Controller struct {
@@ -60,6 +65,7 @@ type Controller struct {
 	appConfig             app.IAppConfig
 	accountRepository     repositoryAccount.IAccount
 	companyUseCases       companyUseCases.ICompany
+	notificationService   notificationService.IService
 }
 
 func NewController(databaseWrite SQL.InterfaceWrite, databaseRead SQL.InterfaceRead,
@@ -75,12 +81,13 @@ func NewController(databaseWrite SQL.InterfaceWrite, databaseRead SQL.InterfaceR
 		appConfig:             appConfig,
 		companyUseCases:       companyUseCases.NewCompanyUseCases(),
 		accountRepository:     repositoryAccount.NewAccountRepository(databaseRead, databaseWrite),
+		notificationService:   notificationService.NewNotificationService(databaseRead, broker),
 	}
 }
 
 func (c *Controller) Create(accountID uuid.UUID, data *accountEntities.Company,
 	permissions []string) (*accountEntities.Company, error) {
-	if c.appConfig.GetAuthType() == authEnums.Ldap && c.companyUseCases.IsInvalidLdapGroup(data.AuthzAdmin, permissions) {
+	if c.appConfig.GetAuthType().IsGroupBased() && c.companyUseCases.IsInvalidLdapGroup(data.AuthzAdmin, permissions) {
 		return nil, errorsEnums.ErrorInvalidLdapGroup
 	}
 
@@ -107,7 +114,7 @@ func (c *Controller) createCompanyWithTransaction(accountID uuid.UUID,
 
 func (c *Controller) Update(companyID uuid.UUID,
 	data *accountEntities.Company, permissions []string) (*accountEntities.Company, error) {
-	if c.appConfig.GetAuthType() == authEnums.Ldap && c.companyUseCases.IsInvalidLdapGroup(data.AuthzAdmin, permissions) {
+	if c.appConfig.GetAuthType().IsGroupBased() && c.companyUseCases.IsInvalidLdapGroup(data.AuthzAdmin, permissions) {
 		return nil, errorsEnums.ErrorInvalidLdapGroup
 	}
 
@@ -129,7 +136,7 @@ func (c *Controller) Get(companyID, accountID uuid.UUID) (*accountEntities.Compa
 }
 
 func (c *Controller) List(accountID uuid.UUID, permissions []string) (*[]accountEntities.CompanyResponse, error) {
-	if c.appConfig.GetAuthType() == authEnums.Ldap {
+	if c.appConfig.GetAuthType().IsGroupBased() {
 		return c.repoCompany.ListByLdapPermissions(permissions)
 	}
 
@@ -159,7 +166,12 @@ func (c *Controller) InviteUser(inviteUser *dto.InviteUser) error {
 		inviteUser.Role, nil); err != nil {
 		return err
 	}
-	return c.sendInviteUserEmail(account.Email, account.Username, company.Name)
+	if err := c.sendInviteUserEmail(account.Email, account.Username, company.Name); err != nil {
+		return err
+	}
+
+	c.sendInviteUserChat(inviteUser, account.Username, company.Name)
+	return nil
 }
 
 func (c *Controller) sendInviteUserEmail(email, username, companyName string) error {
@@ -180,6 +192,24 @@ func (c *Controller) sendInviteUserEmail(email, username, companyName string) er
 	return c.broker.Publish(queues.HorusecEmail.ToString(), "", "", emailMessage.ToBytes())
 }
 
+// sendInviteUserChat does not fail the invite, chat delivery is best-effort and the member is already saved
+func (c *Controller) sendInviteUserChat(inviteUser *dto.InviteUser, username, companyName string) {
+	if c.appConfig.IsDisabledBroker() {
+		return
+	}
+
+	chatMessage := &messages.ChatMessage{
+		Title: "[Horusec] Organization invite",
+		Text:  fmt.Sprintf("%s was invited to the organization %s", username, companyName),
+		Link:  env.GetHorusecManagerURL(),
+	}
+
+	if err := c.notificationService.Dispatch(inviteUser.CompanyID, uuid.Nil, notificationEnum.UserInvited,
+		chatMessage.AddField("Role", string(inviteUser.Role))); err != nil {
+		logger.LogError(errorsEnums.ErrorPublishChatNotification, err)
+	}
+}
+
 func (c *Controller) GetAllAccountsInCompany(companyID uuid.UUID) (*[]roles.AccountRole, error) {
 	return c.repoCompany.GetAllAccountsInCompany(companyID)
 }
@@ -198,6 +228,20 @@ func (c *Controller) RemoveUser(removeUser *dto.RemoveUser) error {
 	return c.repoAccountCompany.DeleteAccountCompany(account.AccountID, removeUser.CompanyID)
 }
 
+// ResetTwoFactor removes the second factor of a company member that lost access to the authenticator app
+func (c *Controller) ResetTwoFactor(companyID, accountID uuid.UUID) error {
+	if _, err := c.repoAccountCompany.GetAccountCompany(accountID, companyID); err != nil {
+		return err
+	}
+
+	account, err := c.repoAccount.GetByAccountID(accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.repoAccount.UpdateTwoFactor(account.ResetTwoFactor())
+}
+
 func (c *Controller) GetAccountIDByEmail(email string) (uuid.UUID, error) {
 	account, err := c.accountRepository.GetByEmail(email)
 	if err != nil {
@@ -76,3 +76,8 @@ func (m *Mock) GetAccountIDByEmail(_ string) (uuid.UUID, error) {
 	args := m.MethodCalled("GetAccountIDByEmail")
 	return args.Get(0).(uuid.UUID), mockUtils.ReturnNilOrError(args, 1)
 }
+
+func (m *Mock) ResetTwoFactor(_, _ uuid.UUID) error {
+	args := m.MethodCalled("ResetTwoFactor")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
@@ -26,7 +26,9 @@ import (
 	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	notificationService "github.com/ZupIT/horusec/development-kit/pkg/services/notification"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
 	"github.com/ZupIT/horusec/horusec-account/config/app"
 	"github.com/google/uuid"
@@ -46,6 +48,7 @@ func TestMock(t *testing.T) {
 		mock.On("Delete").Return(nil)
 		mock.On("GetAllAccountsInCompany").Return(&[]roles.AccountRole{}, nil)
 		mock.On("RemoveUser").Return(nil)
+		mock.On("ResetTwoFactor").Return(nil)
 		_, _ = mock.Create(uuid.New(), &accountEntities.Company{}, []string{})
 		_, _ = mock.Update(uuid.New(), &accountEntities.Company{}, []string{})
 		_, _ = mock.Get(uuid.New(), uuid.New())
@@ -55,6 +58,7 @@ func TestMock(t *testing.T) {
 		_ = mock.Delete(uuid.New())
 		_, _ = mock.GetAllAccountsInCompany(uuid.New())
 		_ = mock.RemoveUser(&dto.RemoveUser{})
+		_ = mock.ResetTwoFactor(uuid.New(), uuid.New())
 	})
 }
 
@@ -402,6 +406,29 @@ func TestInviteUser(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("should not return error when dispatch chat notification fails", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockRead := &relational.MockRead{}
+		brokerMock := &broker.Mock{}
+		notificationMock := &notificationService.Mock{}
+
+		respCompany := &response.Response{}
+		respAccount := &response.Response{}
+		mockRead.On("Find").Once().Return(respAccount.SetData(account))
+		mockRead.On("Find").Return(respCompany.SetData(company))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockWrite.On("Create").Return(respCompany)
+		brokerMock.On("Publish").Return(nil)
+		notificationMock.On("Dispatch").Return(errors.New("test"))
+
+		controller := NewController(mockWrite, mockRead, brokerMock, &app.Config{}).(*Controller)
+		controller.notificationService = notificationMock
+
+		err := controller.InviteUser(inviteUser)
+		assert.NoError(t, err)
+		notificationMock.AssertCalled(t, "Dispatch")
+	})
+
 	t.Run("should return error when creating account company", func(t *testing.T) {
 		mockWrite := &relational.MockWrite{}
 		mockRead := &relational.MockRead{}
@@ -565,6 +592,43 @@ func TestRemoveUser(t *testing.T) {
 	})
 }
 
+func TestResetTwoFactor(t *testing.T) {
+	account := (&authEntities.Account{TwoFactorSecret: "secret"}).EnableTwoFactor([]string{"aaaaa-bbbbb"})
+
+	t.Run("should successfully reset two factor of company member", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		brokerMock := &broker.Mock{}
+
+		controller := NewController(mockWrite, mockRead, brokerMock, &app.Config{})
+
+		resp := &response.Response{}
+		mockWrite.On("Update").Return(resp)
+		mockRead.On("Find").Return(resp.SetData(account))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+
+		err := controller.ResetTwoFactor(uuid.New(), uuid.New())
+		assert.NoError(t, err)
+		mockWrite.AssertCalled(t, "Update")
+	})
+
+	t.Run("should return error when account is not a company member", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		brokerMock := &broker.Mock{}
+
+		controller := NewController(mockWrite, mockRead, brokerMock, &app.Config{})
+
+		resp := &response.Response{}
+		mockRead.On("Find").Return(resp.SetError(errorsEnums.ErrNotFoundRecords))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+
+		err := controller.ResetTwoFactor(uuid.New(), uuid.New())
+		assert.Equal(t, errorsEnums.ErrNotFoundRecords, err)
+		mockWrite.AssertNotCalled(t, "Update")
+	})
+}
+
 func TestGetAllAccountsInCompany(t *testing.T) {
 	t.Run("should successfully get roles", func(t *testing.T) {
 		mockRead := &relational.MockRead{}
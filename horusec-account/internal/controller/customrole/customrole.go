@@ -0,0 +1,77 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	customRoleRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/custom_role"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+)
+
+type IController interface {
+	ListAll(companyID uuid.UUID) (*[]roles.CustomRole, error)
+	Create(companyID uuid.UUID, customRole *roles.CustomRole) (*roles.CustomRole, error)
+	Update(companyID, customRoleID uuid.UUID, data *roles.CustomRole) (*roles.CustomRole, error)
+	Remove(companyID, customRoleID uuid.UUID) error
+}
+
+type Controller struct {
+	customRoleRepository customRoleRepository.IRepository
+}
+
+func NewController(databaseWrite SQL.InterfaceWrite, databaseRead SQL.InterfaceRead) IController {
+	return &Controller{
+		customRoleRepository: customRoleRepository.NewCustomRoleRepository(databaseRead, databaseWrite),
+	}
+}
+
+func (c *Controller) ListAll(companyID uuid.UUID) (*[]roles.CustomRole, error) {
+	return c.customRoleRepository.ListByCompanyID(companyID)
+}
+
+func (c *Controller) Create(companyID uuid.UUID, customRole *roles.CustomRole) (*roles.CustomRole, error) {
+	if err := c.customRoleRepository.Create(customRole.SetCreateData(companyID)); err != nil {
+		return nil, c.checkNameAlreadyInUse(err)
+	}
+
+	return customRole, nil
+}
+
+func (c *Controller) Update(companyID, customRoleID uuid.UUID, data *roles.CustomRole) (*roles.CustomRole, error) {
+	customRole, err := c.customRoleRepository.Get(customRoleID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.customRoleRepository.Update(customRole.SetUpdateData(data)); err != nil {
+		return nil, c.checkNameAlreadyInUse(err)
+	}
+
+	return customRole, nil
+}
+
+func (c *Controller) Remove(companyID, customRoleID uuid.UUID) error {
+	return c.customRoleRepository.Delete(customRoleID, companyID)
+}
+
+func (c *Controller) checkNameAlreadyInUse(err error) error {
+	if err.Error() == errorsEnum.ErrorAlreadyExistingCustomRoleName {
+		return errorsEnum.ErrorCustomRoleNameAlreadyInUse
+	}
+
+	return err
+}
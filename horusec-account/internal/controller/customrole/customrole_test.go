@@ -0,0 +1,139 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	customRoleRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/custom_role"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("ListAll").Return(&[]roles.CustomRole{}, nil)
+	m.On("Create").Return(&roles.CustomRole{}, nil)
+	m.On("Update").Return(&roles.CustomRole{}, nil)
+	m.On("Remove").Return(nil)
+	_, err := m.ListAll(uuid.New())
+	assert.NoError(t, err)
+	_, err = m.Create(uuid.New(), &roles.CustomRole{})
+	assert.NoError(t, err)
+	_, err = m.Update(uuid.New(), uuid.New(), &roles.CustomRole{})
+	assert.NoError(t, err)
+	assert.NoError(t, m.Remove(uuid.New(), uuid.New()))
+}
+
+func TestNewController(t *testing.T) {
+	assert.NotEmpty(t, NewController(&relational.MockWrite{}, &relational.MockRead{}))
+}
+
+func TestListAll(t *testing.T) {
+	t.Run("should return custom roles of the company", func(t *testing.T) {
+		repositoryMock := &customRoleRepository.Mock{}
+		repositoryMock.On("ListByCompanyID").Return(&[]roles.CustomRole{{Name: "triager"}}, nil)
+		controller := &Controller{customRoleRepository: repositoryMock}
+
+		result, err := controller.ListAll(uuid.New())
+
+		assert.NoError(t, err)
+		assert.Len(t, *result, 1)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("should create custom role in the company", func(t *testing.T) {
+		repositoryMock := &customRoleRepository.Mock{}
+		repositoryMock.On("Create").Return(nil)
+		controller := &Controller{customRoleRepository: repositoryMock}
+		companyID := uuid.New()
+
+		result, err := controller.Create(companyID, &roles.CustomRole{Name: "triager"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, companyID, result.CompanyID)
+		assert.NotEqual(t, uuid.Nil, result.CustomRoleID)
+	})
+
+	t.Run("should return name already in use when duplicated", func(t *testing.T) {
+		repositoryMock := &customRoleRepository.Mock{}
+		repositoryMock.On("Create").Return(errors.New(errorsEnum.ErrorAlreadyExistingCustomRoleName))
+		controller := &Controller{customRoleRepository: repositoryMock}
+
+		_, err := controller.Create(uuid.New(), &roles.CustomRole{Name: "triager"})
+
+		assert.Equal(t, errorsEnum.ErrorCustomRoleNameAlreadyInUse, err)
+	})
+
+	t.Run("should return error when create fails", func(t *testing.T) {
+		repositoryMock := &customRoleRepository.Mock{}
+		repositoryMock.On("Create").Return(errors.New("test"))
+		controller := &Controller{customRoleRepository: repositoryMock}
+
+		_, err := controller.Create(uuid.New(), &roles.CustomRole{Name: "triager"})
+
+		assert.Equal(t, errors.New("test"), err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("should update custom role of the company", func(t *testing.T) {
+		repositoryMock := &customRoleRepository.Mock{}
+		repositoryMock.On("Get").Return(&roles.CustomRole{Name: "old"}, nil)
+		repositoryMock.On("Update").Return(nil)
+		controller := &Controller{customRoleRepository: repositoryMock}
+
+		result, err := controller.Update(uuid.New(), uuid.New(), &roles.CustomRole{Name: "triager"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "triager", result.Name)
+	})
+
+	t.Run("should return error when custom role is not of the company", func(t *testing.T) {
+		repositoryMock := &customRoleRepository.Mock{}
+		repositoryMock.On("Get").Return(&roles.CustomRole{}, errorsEnum.ErrNotFoundRecords)
+		controller := &Controller{customRoleRepository: repositoryMock}
+
+		_, err := controller.Update(uuid.New(), uuid.New(), &roles.CustomRole{Name: "triager"})
+
+		assert.Equal(t, errorsEnum.ErrNotFoundRecords, err)
+	})
+
+	t.Run("should return name already in use when duplicated", func(t *testing.T) {
+		repositoryMock := &customRoleRepository.Mock{}
+		repositoryMock.On("Get").Return(&roles.CustomRole{Name: "old"}, nil)
+		repositoryMock.On("Update").Return(errors.New(errorsEnum.ErrorAlreadyExistingCustomRoleName))
+		controller := &Controller{customRoleRepository: repositoryMock}
+
+		_, err := controller.Update(uuid.New(), uuid.New(), &roles.CustomRole{Name: "triager"})
+
+		assert.Equal(t, errorsEnum.ErrorCustomRoleNameAlreadyInUse, err)
+	})
+}
+
+func TestRemove(t *testing.T) {
+	t.Run("should remove custom role of the company", func(t *testing.T) {
+		repositoryMock := &customRoleRepository.Mock{}
+		repositoryMock.On("Delete").Return(nil)
+		controller := &Controller{customRoleRepository: repositoryMock}
+
+		assert.NoError(t, controller.Remove(uuid.New(), uuid.New()))
+	})
+}
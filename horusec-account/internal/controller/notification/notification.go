@@ -0,0 +1,87 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"time"
+
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	notificationRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/notification"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/notification"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+)
+
+type IController interface {
+	ListAll(companyID uuid.UUID) (*[]notification.Notification, error)
+	Create(entity *notification.Notification) (uuid.UUID, error)
+	Update(entity *notification.Notification) error
+	Remove(companyID, notificationID uuid.UUID) error
+}
+
+type Controller struct {
+	notificationRepository notificationRepository.INotification
+}
+
+func NewController(databaseWrite SQL.InterfaceWrite, databaseRead SQL.InterfaceRead) IController {
+	return &Controller{
+		notificationRepository: notificationRepository.NewNotificationRepository(databaseRead, databaseWrite),
+	}
+}
+
+func (c *Controller) ListAll(companyID uuid.UUID) (*[]notification.Notification, error) {
+	return c.notificationRepository.GetAllByCompanyID(companyID)
+}
+
+func (c *Controller) Create(entity *notification.Notification) (uuid.UUID, error) {
+	if err := c.notificationRepository.Create(entity.SetCreateData()); err != nil {
+		return uuid.Nil, err
+	}
+
+	return entity.NotificationID, nil
+}
+
+func (c *Controller) Update(entity *notification.Notification) error {
+	existing, err := c.getNotificationOfCompany(entity.CompanyID, entity.NotificationID)
+	if err != nil {
+		return err
+	}
+
+	entity.CreatedAt = existing.CreatedAt
+	entity.UpdatedAt = time.Now()
+	return c.notificationRepository.Update(entity)
+}
+
+func (c *Controller) Remove(companyID, notificationID uuid.UUID) error {
+	if _, err := c.getNotificationOfCompany(companyID, notificationID); err != nil {
+		return err
+	}
+
+	return c.notificationRepository.Remove(notificationID)
+}
+
+func (c *Controller) getNotificationOfCompany(companyID,
+	notificationID uuid.UUID) (*notification.Notification, error) {
+	existing, err := c.notificationRepository.GetByNotificationID(notificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.CompanyID != companyID {
+		return nil, errorsEnum.ErrNotFoundRecords
+	}
+
+	return existing, nil
+}
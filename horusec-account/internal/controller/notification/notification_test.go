@@ -0,0 +1,145 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	notificationRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/notification"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/notification"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("ListAll").Return(&[]notification.Notification{}, nil)
+	m.On("Create").Return(uuid.New(), nil)
+	m.On("Update").Return(nil)
+	m.On("Remove").Return(nil)
+	_, err := m.ListAll(uuid.New())
+	assert.NoError(t, err)
+	_, err = m.Create(&notification.Notification{})
+	assert.NoError(t, err)
+	assert.NoError(t, m.Update(&notification.Notification{}))
+	assert.NoError(t, m.Remove(uuid.New(), uuid.New()))
+}
+
+func TestNewController(t *testing.T) {
+	assert.NotEmpty(t, NewController(&relational.MockWrite{}, &relational.MockRead{}))
+}
+
+func TestController_ListAll(t *testing.T) {
+	t.Run("should list all notifications with success", func(t *testing.T) {
+		repository := &notificationRepository.Mock{}
+		repository.On("GetAllByCompanyID").Return(&[]notification.Notification{{URL: "http://example.com"}}, nil)
+		c := &Controller{notificationRepository: repository}
+
+		result, err := c.ListAll(uuid.New())
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result)
+	})
+	t.Run("should list all notifications with error unexpected", func(t *testing.T) {
+		repository := &notificationRepository.Mock{}
+		repository.On("GetAllByCompanyID").Return(&[]notification.Notification{}, errors.New("unexpected error"))
+		c := &Controller{notificationRepository: repository}
+
+		_, err := c.ListAll(uuid.New())
+		assert.Error(t, err)
+	})
+}
+
+func TestController_Create(t *testing.T) {
+	t.Run("should create notification with success", func(t *testing.T) {
+		repository := &notificationRepository.Mock{}
+		repository.On("Create").Return(nil)
+		c := &Controller{notificationRepository: repository}
+
+		notificationID, err := c.Create(&notification.Notification{URL: "http://example.com"})
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, notificationID)
+	})
+	t.Run("should create notification with error unexpected", func(t *testing.T) {
+		repository := &notificationRepository.Mock{}
+		repository.On("Create").Return(errors.New("unexpected error"))
+		c := &Controller{notificationRepository: repository}
+
+		notificationID, err := c.Create(&notification.Notification{URL: "http://example.com"})
+		assert.Error(t, err)
+		assert.Equal(t, uuid.Nil, notificationID)
+	})
+}
+
+func TestController_Update(t *testing.T) {
+	companyID := uuid.New()
+
+	t.Run("should update notification with success", func(t *testing.T) {
+		repository := &notificationRepository.Mock{}
+		repository.On("GetByNotificationID").Return(&notification.Notification{CompanyID: companyID}, nil)
+		repository.On("Update").Return(nil)
+		c := &Controller{notificationRepository: repository}
+
+		assert.NoError(t, c.Update(&notification.Notification{CompanyID: companyID, NotificationID: uuid.New()}))
+	})
+	t.Run("should return not found when notification is of other company", func(t *testing.T) {
+		repository := &notificationRepository.Mock{}
+		repository.On("GetByNotificationID").Return(&notification.Notification{CompanyID: uuid.New()}, nil)
+		c := &Controller{notificationRepository: repository}
+
+		err := c.Update(&notification.Notification{CompanyID: companyID, NotificationID: uuid.New()})
+		assert.Equal(t, errorsEnum.ErrNotFoundRecords, err)
+	})
+	t.Run("should return error when get notification", func(t *testing.T) {
+		repository := &notificationRepository.Mock{}
+		repository.On("GetByNotificationID").Return(&notification.Notification{}, errorsEnum.ErrNotFoundRecords)
+		c := &Controller{notificationRepository: repository}
+
+		err := c.Update(&notification.Notification{CompanyID: companyID, NotificationID: uuid.New()})
+		assert.Equal(t, errorsEnum.ErrNotFoundRecords, err)
+	})
+}
+
+func TestController_Remove(t *testing.T) {
+	companyID := uuid.New()
+
+	t.Run("should remove notification with success", func(t *testing.T) {
+		repository := &notificationRepository.Mock{}
+		repository.On("GetByNotificationID").Return(&notification.Notification{CompanyID: companyID}, nil)
+		repository.On("Remove").Return(nil)
+		c := &Controller{notificationRepository: repository}
+
+		assert.NoError(t, c.Remove(companyID, uuid.New()))
+	})
+	t.Run("should return not found when notification is of other company", func(t *testing.T) {
+		repository := &notificationRepository.Mock{}
+		repository.On("GetByNotificationID").Return(&notification.Notification{CompanyID: uuid.New()}, nil)
+		repository.On("Remove").Return(nil)
+		c := &Controller{notificationRepository: repository}
+
+		assert.Equal(t, errorsEnum.ErrNotFoundRecords, c.Remove(companyID, uuid.New()))
+		repository.AssertNotCalled(t, "Remove")
+	})
+	t.Run("should remove notification with error unexpected", func(t *testing.T) {
+		repository := &notificationRepository.Mock{}
+		repository.On("GetByNotificationID").Return(&notification.Notification{CompanyID: companyID}, nil)
+		repository.On("Remove").Return(errors.New("unexpected error"))
+		c := &Controller{notificationRepository: repository}
+
+		assert.Error(t, c.Remove(companyID, uuid.New()))
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	policyRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/policy"
+	repositoryRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+)
+
+type IController interface {
+	ListAll(companyID uuid.UUID) (*[]accountEntities.Policy, error)
+	Create(companyID uuid.UUID, policy *accountEntities.Policy) (*accountEntities.Policy, error)
+	Update(companyID, policyID uuid.UUID, data *accountEntities.Policy) (*accountEntities.Policy, error)
+	Remove(companyID, policyID uuid.UUID) error
+}
+
+type Controller struct {
+	policyRepository     policyRepository.IRepository
+	repositoryRepository repositoryRepository.IRepository
+}
+
+func NewController(databaseWrite SQL.InterfaceWrite, databaseRead SQL.InterfaceRead) IController {
+	return &Controller{
+		policyRepository:     policyRepository.NewPolicyRepository(databaseRead, databaseWrite),
+		repositoryRepository: repositoryRepository.NewRepository(databaseRead, databaseWrite),
+	}
+}
+
+func (c *Controller) ListAll(companyID uuid.UUID) (*[]accountEntities.Policy, error) {
+	return c.policyRepository.ListByCompanyID(companyID)
+}
+
+func (c *Controller) Create(companyID uuid.UUID, policy *accountEntities.Policy) (*accountEntities.Policy, error) {
+	if err := c.checkRepositoryOfCompany(companyID, policy.RepositoryID); err != nil {
+		return nil, err
+	}
+
+	if err := c.policyRepository.Create(policy.SetCreateData(companyID)); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (c *Controller) Update(companyID, policyID uuid.UUID,
+	data *accountEntities.Policy) (*accountEntities.Policy, error) {
+	policy, err := c.policyRepository.Get(policyID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkRepositoryOfCompany(companyID, data.RepositoryID); err != nil {
+		return nil, err
+	}
+
+	if err := c.policyRepository.Update(policy.SetUpdateData(data)); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (c *Controller) Remove(companyID, policyID uuid.UUID) error {
+	return c.policyRepository.Delete(policyID, companyID)
+}
+
+// checkRepositoryOfCompany avoids policies of a company in the repositories of others
+func (c *Controller) checkRepositoryOfCompany(companyID uuid.UUID, repositoryID *uuid.UUID) error {
+	if repositoryID == nil {
+		return nil
+	}
+
+	repository, err := c.repositoryRepository.Get(*repositoryID)
+	if err == errorsEnum.ErrNotFoundRecords || (err == nil && repository.CompanyID != companyID) {
+		return errorsEnum.ErrorPolicyRepositoryNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,187 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	policyRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/policy"
+	repositoryRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("ListAll").Return(&[]accountEntities.Policy{}, nil)
+	m.On("Create").Return(&accountEntities.Policy{}, nil)
+	m.On("Update").Return(&accountEntities.Policy{}, nil)
+	m.On("Remove").Return(nil)
+	_, err := m.ListAll(uuid.New())
+	assert.NoError(t, err)
+	_, err = m.Create(uuid.New(), &accountEntities.Policy{})
+	assert.NoError(t, err)
+	_, err = m.Update(uuid.New(), uuid.New(), &accountEntities.Policy{})
+	assert.NoError(t, err)
+	assert.NoError(t, m.Remove(uuid.New(), uuid.New()))
+}
+
+func TestNewController(t *testing.T) {
+	assert.NotEmpty(t, NewController(&relational.MockWrite{}, &relational.MockRead{}))
+}
+
+func TestListAll(t *testing.T) {
+	t.Run("should return policies of the company", func(t *testing.T) {
+		policyMock := &policyRepository.Mock{}
+		policyMock.On("ListByCompanyID").Return(&[]accountEntities.Policy{{Name: "test"}}, nil)
+		controller := &Controller{policyRepository: policyMock}
+
+		result, err := controller.ListAll(uuid.New())
+
+		assert.NoError(t, err)
+		assert.Len(t, *result, 1)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("should create a company policy", func(t *testing.T) {
+		policyMock := &policyRepository.Mock{}
+		policyMock.On("Create").Return(nil)
+		controller := &Controller{policyRepository: policyMock}
+		companyID := uuid.New()
+
+		result, err := controller.Create(companyID, &accountEntities.Policy{Name: "test"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, companyID, result.CompanyID)
+		assert.NotEqual(t, uuid.Nil, result.PolicyID)
+	})
+
+	t.Run("should create a repository policy", func(t *testing.T) {
+		policyMock := &policyRepository.Mock{}
+		repositoryMock := &repositoryRepository.Mock{}
+		companyID := uuid.New()
+		repositoryID := uuid.New()
+		policyMock.On("Create").Return(nil)
+		repositoryMock.On("Get").Return(&accountEntities.Repository{RepositoryID: repositoryID,
+			CompanyID: companyID}, nil)
+		controller := &Controller{policyRepository: policyMock, repositoryRepository: repositoryMock}
+
+		result, err := controller.Create(companyID, &accountEntities.Policy{Name: "test",
+			RepositoryID: &repositoryID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &repositoryID, result.RepositoryID)
+	})
+
+	t.Run("should return error when the repository is of other company", func(t *testing.T) {
+		policyMock := &policyRepository.Mock{}
+		repositoryMock := &repositoryRepository.Mock{}
+		repositoryID := uuid.New()
+		repositoryMock.On("Get").Return(&accountEntities.Repository{RepositoryID: repositoryID,
+			CompanyID: uuid.New()}, nil)
+		controller := &Controller{policyRepository: policyMock, repositoryRepository: repositoryMock}
+
+		_, err := controller.Create(uuid.New(), &accountEntities.Policy{Name: "test", RepositoryID: &repositoryID})
+
+		assert.Equal(t, errorsEnum.ErrorPolicyRepositoryNotFound, err)
+		policyMock.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("should return error when the repository does not exist", func(t *testing.T) {
+		repositoryMock := &repositoryRepository.Mock{}
+		repositoryID := uuid.New()
+		repositoryMock.On("Get").Return(&accountEntities.Repository{}, errorsEnum.ErrNotFoundRecords)
+		controller := &Controller{policyRepository: &policyRepository.Mock{}, repositoryRepository: repositoryMock}
+
+		_, err := controller.Create(uuid.New(), &accountEntities.Policy{Name: "test", RepositoryID: &repositoryID})
+
+		assert.Equal(t, errorsEnum.ErrorPolicyRepositoryNotFound, err)
+	})
+
+	t.Run("should return error when create fails", func(t *testing.T) {
+		policyMock := &policyRepository.Mock{}
+		policyMock.On("Create").Return(errors.New("test"))
+		controller := &Controller{policyRepository: policyMock}
+
+		_, err := controller.Create(uuid.New(), &accountEntities.Policy{Name: "test"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("should update policy of the company", func(t *testing.T) {
+		policyMock := &policyRepository.Mock{}
+		policyMock.On("Get").Return(&accountEntities.Policy{Name: "old"}, nil)
+		policyMock.On("Update").Return(nil)
+		controller := &Controller{policyRepository: policyMock}
+
+		result, err := controller.Update(uuid.New(), uuid.New(), &accountEntities.Policy{Name: "new"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new", result.Name)
+	})
+
+	t.Run("should return not found when policy is not of the company", func(t *testing.T) {
+		policyMock := &policyRepository.Mock{}
+		policyMock.On("Get").Return(&accountEntities.Policy{}, errorsEnum.ErrNotFoundRecords)
+		controller := &Controller{policyRepository: policyMock}
+
+		_, err := controller.Update(uuid.New(), uuid.New(), &accountEntities.Policy{Name: "new"})
+
+		assert.Equal(t, errorsEnum.ErrNotFoundRecords, err)
+	})
+
+	t.Run("should return error when the new repository is of other company", func(t *testing.T) {
+		policyMock := &policyRepository.Mock{}
+		repositoryMock := &repositoryRepository.Mock{}
+		repositoryID := uuid.New()
+		policyMock.On("Get").Return(&accountEntities.Policy{Name: "old"}, nil)
+		repositoryMock.On("Get").Return(&accountEntities.Repository{CompanyID: uuid.New()}, nil)
+		controller := &Controller{policyRepository: policyMock, repositoryRepository: repositoryMock}
+
+		_, err := controller.Update(uuid.New(), uuid.New(), &accountEntities.Policy{Name: "new",
+			RepositoryID: &repositoryID})
+
+		assert.Equal(t, errorsEnum.ErrorPolicyRepositoryNotFound, err)
+		policyMock.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("should return error when update fails", func(t *testing.T) {
+		policyMock := &policyRepository.Mock{}
+		policyMock.On("Get").Return(&accountEntities.Policy{Name: "old"}, nil)
+		policyMock.On("Update").Return(errors.New("test"))
+		controller := &Controller{policyRepository: policyMock}
+
+		_, err := controller.Update(uuid.New(), uuid.New(), &accountEntities.Policy{Name: "new"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRemove(t *testing.T) {
+	t.Run("should remove policy of the company", func(t *testing.T) {
+		policyMock := &policyRepository.Mock{}
+		policyMock.On("Delete").Return(nil)
+		controller := &Controller{policyRepository: policyMock}
+
+		assert.NoError(t, controller.Remove(uuid.New(), uuid.New()))
+	})
+}
@@ -15,11 +15,14 @@
 package repositories
 
 import (
+	"fmt"
+
 	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	repositoryAccount "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
 	repositoryAccountCompany "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_company"
 	repoAccountRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_repository"
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	customRoleRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/custom_role"
 	relationalRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
 	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/account/dto"
@@ -29,8 +32,12 @@ import (
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	emailEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/messages"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/queues"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	notificationService "github.com/ZupIT/horusec/development-kit/pkg/services/notification"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	"github.com/ZupIT/horusec/horusec-account/config/app"
 	repositoriesUseCases "github.com/ZupIT/horusec/horusec-account/internal/usecases/repositories"
 	"github.com/google/uuid"
@@ -45,8 +52,8 @@ type IController interface {
 	List(accountID uuid.UUID, companyID uuid.UUID,
 		permissions []string) (repositories *[]accountEntities.RepositoryResponse, err error)
 	CreateAccountRepository(accountRepository *roles.AccountRepository) error
-	UpdateAccountRepository(companyID uuid.UUID, accountRepository *roles.AccountRepository) error
-	InviteUser(inviteUser *dto.InviteUser) error
+	UpdateAccountRepository(accountID, companyID uuid.UUID, accountRepository *roles.AccountRepository) error
+	InviteUser(accountID uuid.UUID, inviteUser *dto.InviteUser) error
 	Delete(repositoryID uuid.UUID) error
 	GetAllAccountsInRepository(repositoryID uuid.UUID) (*[]roles.AccountRole, error)
 	RemoveUser(removeUser *dto.RemoveUser) error
@@ -60,9 +67,11 @@ type Controller struct {
 	accountRepository        repositoryAccount.IAccount
 	accountCompanyRepository repositoryAccountCompany.IAccountCompany
 	company                  company.ICompanyRepository
+	customRoleRepository     customRoleRepository.IRepository
 	broker                   brokerLib.IBroker
 	appConfig                app.IAppConfig
 	repositoriesUseCases     repositoriesUseCases.IRepository
+	notificationService      notificationService.IService
 }
 
 func NewController(databaseWrite SQL.InterfaceWrite, databaseRead SQL.InterfaceRead,
@@ -73,17 +82,19 @@ func NewController(databaseWrite SQL.InterfaceWrite, databaseRead SQL.InterfaceR
 		repository:               relationalRepository.NewRepository(databaseRead, databaseWrite),
 		accountRepositoryRepo:    repoAccountRepository.NewAccountRepositoryRepository(databaseRead, databaseWrite),
 		accountRepository:        repositoryAccount.NewAccountRepository(databaseRead, databaseWrite),
+		customRoleRepository:     customRoleRepository.NewCustomRoleRepository(databaseRead, databaseWrite),
 		accountCompanyRepository: repositoryAccountCompany.NewAccountCompanyRepository(databaseRead, databaseWrite),
 		company:                  company.NewCompanyRepository(databaseRead, databaseWrite),
 		broker:                   broker,
 		appConfig:                appConfig,
 		repositoriesUseCases:     repositoriesUseCases.NewRepositoryUseCases(),
+		notificationService:      notificationService.NewNotificationService(databaseRead, broker),
 	}
 }
 
 func (c *Controller) Create(accountID uuid.UUID, repository *accountEntities.Repository,
 	permissions []string) (*accountEntities.Repository, error) {
-	if c.appConfig.GetAuthType() == authEnums.Ldap &&
+	if c.appConfig.GetAuthType().IsGroupBased() &&
 		c.repositoriesUseCases.IsInvalidLdapGroup(repository.AuthzAdmin, permissions) {
 		return nil, errors.ErrorInvalidLdapGroup
 	}
@@ -111,7 +122,7 @@ func (c *Controller) createRepositoryWithTransaction(accountID uuid.UUID,
 
 func (c *Controller) Update(repositoryID uuid.UUID, repositoryEntity *accountEntities.Repository,
 	permissions []string) (*accountEntities.Repository, error) {
-	if c.appConfig.GetAuthType() == authEnums.Ldap &&
+	if c.appConfig.GetAuthType().IsGroupBased() &&
 		c.repositoriesUseCases.IsInvalidLdapGroup(repositoryEntity.AuthzAdmin, permissions) {
 		return nil, errors.ErrorInvalidLdapGroup
 	}
@@ -135,21 +146,109 @@ func (c *Controller) Get(repositoryID, accountID uuid.UUID) (*accountEntities.Re
 
 func (c *Controller) List(accountID, companyID uuid.UUID,
 	permissions []string) (repositories *[]accountEntities.RepositoryResponse, err error) {
-	if c.appConfig.GetAuthType() == authEnums.Ldap {
+	if c.appConfig.GetAuthType().IsGroupBased() {
 		return c.repository.ListByLdapPermissions(companyID, permissions)
 	}
 
 	return c.repository.List(accountID, companyID)
 }
 
-func (c *Controller) UpdateAccountRepository(companyID uuid.UUID, accountRepository *roles.AccountRepository) error {
+// UpdateAccountRepository receives the account who made the request, members who manage the repository members by a
+// custom role can not grant more permissions than they have
+func (c *Controller) UpdateAccountRepository(accountID, companyID uuid.UUID,
+	accountRepository *roles.AccountRepository) error {
 	if c.isUserNotInCompany(companyID, accountRepository.AccountID) {
 		return errors.ErrorUserNotMemberOfCompany
 	}
 
+	if c.isCustomRoleNotInCompany(companyID, accountRepository) {
+		return errors.ErrorCustomRoleNotFound
+	}
+
+	if err := c.checkRoleGrant(accountID, companyID, accountRepository); err != nil {
+		return err
+	}
+
 	return c.accountRepositoryRepo.UpdateAccountRepository(accountRepository)
 }
 
+// checkRoleGrant allows company and repository admins to grant any role. The other members can not grant the admin
+// role, change the role of an admin or grant a role with permissions they do not have, themselves included.
+func (c *Controller) checkRoleGrant(accountID, companyID uuid.UUID, grant *roles.AccountRepository) error {
+	grantor, err := c.accountRepositoryRepo.GetAccountRepository(accountID, grant.RepositoryID)
+	if c.isCompanyAdmin(accountID, companyID) || (err == nil && !grantor.IsNotAdmin()) {
+		return nil
+	}
+
+	if err != nil || !grant.IsNotAdmin() || c.isRepositoryAdmin(grant.AccountID, grant.RepositoryID) {
+		return errors.ErrorRoleGrantNotAllowed
+	}
+
+	return c.checkGrantedPermissions(companyID, grantor, grant)
+}
+
+func (c *Controller) checkGrantedPermissions(companyID uuid.UUID, grantor, grant *roles.AccountRepository) error {
+	granted, err := c.getPermissions(companyID, grant)
+	if err != nil {
+		return err
+	}
+
+	owned, err := c.getPermissions(companyID, grantor)
+	if err != nil {
+		return err
+	}
+
+	for permission := range granted {
+		if !owned[permission] {
+			return errors.ErrorRoleGrantNotAllowed
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) getPermissions(companyID uuid.UUID,
+	accountRepository *roles.AccountRepository) (map[authEnums.Permission]bool, error) {
+	permissions := map[authEnums.Permission]bool{}
+	for _, permission := range accountRepository.GetRolePermissions() {
+		permissions[permission] = true
+	}
+
+	if !accountRepository.HasCustomRole() {
+		return permissions, nil
+	}
+
+	customRole, err := c.customRoleRepository.Get(*accountRepository.CustomRoleID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, permission := range customRole.Permissions {
+		permissions[authEnums.Permission(permission)] = true
+	}
+
+	return permissions, nil
+}
+
+func (c *Controller) isCompanyAdmin(accountID, companyID uuid.UUID) bool {
+	accountCompany, err := c.accountCompanyRepository.GetAccountCompany(accountID, companyID)
+	return err == nil && accountCompany.Role == accountEnum.Admin
+}
+
+func (c *Controller) isRepositoryAdmin(accountID, repositoryID uuid.UUID) bool {
+	accountRepository, err := c.accountRepositoryRepo.GetAccountRepository(accountID, repositoryID)
+	return err == nil && !accountRepository.IsNotAdmin()
+}
+
+func (c *Controller) isCustomRoleNotInCompany(companyID uuid.UUID, accountRepository *roles.AccountRepository) bool {
+	if !accountRepository.HasCustomRole() {
+		return false
+	}
+
+	_, err := c.customRoleRepository.Get(*accountRepository.CustomRoleID, companyID)
+	return err != nil
+}
+
 func (c *Controller) CreateAccountRepository(accountRepository *roles.AccountRepository) error {
 	if c.isUserNotInCompany(accountRepository.CompanyID, accountRepository.AccountID) {
 		return errors.ErrorUserNotMemberOfCompany
@@ -158,7 +257,7 @@ func (c *Controller) CreateAccountRepository(accountRepository *roles.AccountRep
 	return c.accountRepositoryRepo.Create(accountRepository, nil)
 }
 
-func (c *Controller) InviteUser(inviteUser *dto.InviteUser) error {
+func (c *Controller) InviteUser(accountID uuid.UUID, inviteUser *dto.InviteUser) error {
 	account, err := c.accountRepository.GetByEmail(inviteUser.Email)
 	if err != nil {
 		return err
@@ -169,10 +268,20 @@ func (c *Controller) InviteUser(inviteUser *dto.InviteUser) error {
 		return err
 	}
 
-	if err := c.CreateAccountRepository(inviteUser.ToAccountRepository(account.AccountID)); err != nil {
+	accountRepository := inviteUser.ToAccountRepository(account.AccountID)
+	if err := c.checkRoleGrant(accountID, inviteUser.CompanyID, accountRepository); err != nil {
+		return err
+	}
+
+	if err := c.CreateAccountRepository(accountRepository); err != nil {
 		return err
 	}
-	return c.sendInviteUserEmail(account.Email, account.Username, response.Name)
+	if err := c.sendInviteUserEmail(account.Email, account.Username, response.Name); err != nil {
+		return err
+	}
+
+	c.sendInviteUserChat(inviteUser, account.Username, response)
+	return nil
 }
 
 func (c *Controller) sendInviteUserEmail(email, username, repositoryName string) error {
@@ -190,6 +299,25 @@ func (c *Controller) sendInviteUserEmail(email, username, repositoryName string)
 	return c.broker.Publish(queues.HorusecEmail.ToString(), "", "", emailMessage.ToBytes())
 }
 
+// sendInviteUserChat does not fail the invite, chat delivery is best-effort and the member is already saved
+func (c *Controller) sendInviteUserChat(inviteUser *dto.InviteUser, username string,
+	repository *accountEntities.Repository) {
+	if c.appConfig.IsDisabledBroker() {
+		return
+	}
+
+	chatMessage := &messages.ChatMessage{
+		Title: "[Horusec] Repository invite",
+		Text:  fmt.Sprintf("%s was invited to the repository %s", username, repository.Name),
+		Link:  env.GetHorusecManagerURL(),
+	}
+
+	if err := c.notificationService.Dispatch(repository.CompanyID, repository.RepositoryID,
+		notificationEnum.UserInvited, chatMessage.AddField("Role", string(inviteUser.Role))); err != nil {
+		logger.LogError(errors.ErrorPublishChatNotification, err)
+	}
+}
+
 func (c *Controller) isUserNotInCompany(companyID, accountID uuid.UUID) bool {
 	account, err := c.accountCompanyRepository.GetAccountCompany(accountID, companyID)
 	if err != nil || account == nil {
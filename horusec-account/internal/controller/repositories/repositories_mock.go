@@ -52,12 +52,12 @@ func (m *Mock) CreateAccountRepository(_ *roles.AccountRepository) error {
 	return mockUtils.ReturnNilOrError(args, 0)
 }
 
-func (m *Mock) UpdateAccountRepository(_ uuid.UUID, _ *roles.AccountRepository) error {
+func (m *Mock) UpdateAccountRepository(_, _ uuid.UUID, _ *roles.AccountRepository) error {
 	args := m.MethodCalled("UpdateAccountRepository")
 	return mockUtils.ReturnNilOrError(args, 0)
 }
 
-func (m *Mock) InviteUser(_ *dto.InviteUser) error {
+func (m *Mock) InviteUser(_ uuid.UUID, _ *dto.InviteUser) error {
 	args := m.MethodCalled("InviteUser")
 	return mockUtils.ReturnNilOrError(args, 0)
 }
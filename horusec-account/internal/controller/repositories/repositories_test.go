@@ -21,7 +21,10 @@ import (
 	"github.com/lib/pq"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	repositoryAccount "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
 	repositoryAccountCompany "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_company"
+	repoAccountRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_repository"
+	customRoleRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/custom_role"
 	repositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
 	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/account/dto"
@@ -31,6 +34,7 @@ import (
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	notificationService "github.com/ZupIT/horusec/development-kit/pkg/services/notification"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
 	"github.com/ZupIT/horusec/horusec-account/config/app"
 	"github.com/google/uuid"
@@ -56,8 +60,8 @@ func TestMock(t *testing.T) {
 		_, _ = mock.Get(uuid.New(), uuid.New())
 		_, _ = mock.List(uuid.New(), uuid.New(), []string{})
 		_ = mock.CreateAccountRepository(&roles.AccountRepository{})
-		_ = mock.UpdateAccountRepository(uuid.New(), &roles.AccountRepository{})
-		_ = mock.InviteUser(&dto.InviteUser{})
+		_ = mock.UpdateAccountRepository(uuid.New(), uuid.New(), &roles.AccountRepository{})
+		_ = mock.InviteUser(uuid.New(), &dto.InviteUser{})
 		_ = mock.Delete(uuid.New())
 		_, _ = mock.GetAllAccountsInRepository(uuid.New())
 		_ = mock.RemoveUser(&dto.RemoveUser{})
@@ -279,7 +283,7 @@ func TestUpdateAccountRepository(t *testing.T) {
 
 		controller := NewController(mockWrite, mockRead, brokerMock, &app.Config{})
 
-		err := controller.UpdateAccountRepository(uuid.UUID{}, accountRepository)
+		err := controller.UpdateAccountRepository(uuid.UUID{}, uuid.UUID{}, accountRepository)
 		assert.NoError(t, err)
 	})
 
@@ -296,10 +300,49 @@ func TestUpdateAccountRepository(t *testing.T) {
 
 		controller := NewController(mockWrite, mockRead, brokerMock, &app.Config{})
 
-		err := controller.UpdateAccountRepository(uuid.UUID{}, accountRepository)
+		err := controller.UpdateAccountRepository(uuid.UUID{}, uuid.UUID{}, accountRepository)
 		assert.Error(t, err)
 		assert.Equal(t, errorsEnums.ErrorUserNotMemberOfCompany, err)
 	})
+
+	t.Run("should return error when custom role is not of the company", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		customRoleID := uuid.New()
+		accountRepository := &roles.AccountRepository{CustomRoleID: &customRoleID}
+		customRoleMock := &customRoleRepository.Mock{}
+		customRoleMock.On("Get").Return(&roles.CustomRole{}, errorsEnums.ErrNotFoundRecords)
+
+		resp := &response.Response{}
+		mockRead.On("Find").Return(resp.SetData(accountRepository))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+
+		controller := NewController(mockWrite, mockRead, &broker.Mock{}, &app.Config{}).(*Controller)
+		controller.customRoleRepository = customRoleMock
+
+		err := controller.UpdateAccountRepository(uuid.UUID{}, uuid.UUID{}, accountRepository)
+		assert.Equal(t, errorsEnums.ErrorCustomRoleNotFound, err)
+		mockWrite.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("should update account repository with custom role of the company", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		customRoleID := uuid.New()
+		accountRepository := &roles.AccountRepository{CustomRoleID: &customRoleID}
+		customRoleMock := &customRoleRepository.Mock{}
+		customRoleMock.On("Get").Return(&roles.CustomRole{}, nil)
+
+		resp := &response.Response{}
+		mockRead.On("Find").Return(resp.SetData(accountRepository))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockWrite.On("Update").Return(resp.SetData(accountRepository))
+
+		controller := NewController(mockWrite, mockRead, &broker.Mock{}, &app.Config{}).(*Controller)
+		controller.customRoleRepository = customRoleMock
+
+		assert.NoError(t, controller.UpdateAccountRepository(uuid.UUID{}, uuid.UUID{}, accountRepository))
+	})
 }
 
 func TestCreateAccountRepository(t *testing.T) {
@@ -418,6 +461,130 @@ func TestList(t *testing.T) {
 	})
 }
 
+func newRoleGrantController(companyRole accountEnums.Role, grantor, target *roles.AccountRepository,
+	grantorErr error, customRoles ...*roles.CustomRole) (*Controller, *repoAccountRepository.Mock) {
+	accountCompanyMock := &repositoryAccountCompany.Mock{}
+	accountCompanyMock.On("GetAccountCompany").Return(&roles.AccountCompany{Role: companyRole}, nil)
+	accountRepositoryMock := &repoAccountRepository.Mock{}
+	accountRepositoryMock.On("GetAccountRepository").Once().Return(grantor, grantorErr)
+	accountRepositoryMock.On("GetAccountRepository").Return(target, nil)
+	accountRepositoryMock.On("UpdateAccountRepository").Return(nil)
+	customRoleMock := &customRoleRepository.Mock{}
+	for _, customRole := range customRoles {
+		customRoleMock.On("Get").Once().Return(customRole, nil)
+	}
+
+	controller := NewController(&relational.MockWrite{}, &relational.MockRead{}, &broker.Mock{},
+		&app.Config{}).(*Controller)
+	controller.accountCompanyRepository = accountCompanyMock
+	controller.accountRepositoryRepo = accountRepositoryMock
+	controller.customRoleRepository = customRoleMock
+	return controller, accountRepositoryMock
+}
+
+func TestUpdateAccountRepositoryRoleGrant(t *testing.T) {
+	accountID := uuid.New()
+	manageMembersID := uuid.New()
+	manageMembers := &roles.CustomRole{CustomRoleID: manageMembersID,
+		Permissions: pq.StringArray{authEnums.ManageRepositoryMembers.ToString()}}
+	manageAllID := uuid.New()
+	manageAll := &roles.CustomRole{CustomRoleID: manageAllID, Permissions: pq.StringArray{
+		authEnums.ManageRepositoryMembers.ToString(), authEnums.ManageRepository.ToString()}}
+	grantor := &roles.AccountRepository{AccountID: accountID, Role: accountEnums.Member, CustomRoleID: &manageMembersID}
+
+	t.Run("should return error when member escalates itself to admin", func(t *testing.T) {
+		grant := &roles.AccountRepository{AccountID: accountID, Role: accountEnums.Admin}
+		controller, accountRepositoryMock := newRoleGrantController(accountEnums.Member, grantor, grantor, nil)
+
+		err := controller.UpdateAccountRepository(accountID, uuid.New(), grant)
+
+		assert.Equal(t, errorsEnums.ErrorRoleGrantNotAllowed, err)
+		accountRepositoryMock.AssertNotCalled(t, "UpdateAccountRepository")
+	})
+
+	t.Run("should return error when member gives itself a custom role with more permissions", func(t *testing.T) {
+		grant := &roles.AccountRepository{AccountID: accountID, Role: accountEnums.Member, CustomRoleID: &manageAllID}
+		controller, accountRepositoryMock := newRoleGrantController(accountEnums.Member, grantor, grantor, nil,
+			manageAll, manageAll, manageMembers)
+
+		err := controller.UpdateAccountRepository(accountID, uuid.New(), grant)
+
+		assert.Equal(t, errorsEnums.ErrorRoleGrantNotAllowed, err)
+		accountRepositoryMock.AssertNotCalled(t, "UpdateAccountRepository")
+	})
+
+	t.Run("should return error when member escalates another member to admin", func(t *testing.T) {
+		target := &roles.AccountRepository{AccountID: uuid.New(), Role: accountEnums.Member}
+		grant := &roles.AccountRepository{AccountID: target.AccountID, Role: accountEnums.Admin}
+		controller, accountRepositoryMock := newRoleGrantController(accountEnums.Member, grantor, target, nil)
+
+		err := controller.UpdateAccountRepository(accountID, uuid.New(), grant)
+
+		assert.Equal(t, errorsEnums.ErrorRoleGrantNotAllowed, err)
+		accountRepositoryMock.AssertNotCalled(t, "UpdateAccountRepository")
+	})
+
+	t.Run("should return error when member gives another member a custom role with more permissions",
+		func(t *testing.T) {
+			target := &roles.AccountRepository{AccountID: uuid.New(), Role: accountEnums.Member}
+			grant := &roles.AccountRepository{AccountID: target.AccountID, Role: accountEnums.Supervisor,
+				CustomRoleID: &manageMembersID}
+			controller, accountRepositoryMock := newRoleGrantController(accountEnums.Member, grantor, target, nil,
+				manageMembers, manageMembers, manageMembers)
+
+			err := controller.UpdateAccountRepository(accountID, uuid.New(), grant)
+
+			assert.Equal(t, errorsEnums.ErrorRoleGrantNotAllowed, err)
+			accountRepositoryMock.AssertNotCalled(t, "UpdateAccountRepository")
+		})
+
+	t.Run("should return error when member changes the role of an admin", func(t *testing.T) {
+		target := &roles.AccountRepository{AccountID: uuid.New(), Role: accountEnums.Admin}
+		grant := &roles.AccountRepository{AccountID: target.AccountID, Role: accountEnums.Member}
+		controller, accountRepositoryMock := newRoleGrantController(accountEnums.Member, grantor, target, nil)
+
+		err := controller.UpdateAccountRepository(accountID, uuid.New(), grant)
+
+		assert.Equal(t, errorsEnums.ErrorRoleGrantNotAllowed, err)
+		accountRepositoryMock.AssertNotCalled(t, "UpdateAccountRepository")
+	})
+
+	t.Run("should update when member grants only permissions it has", func(t *testing.T) {
+		target := &roles.AccountRepository{AccountID: uuid.New(), Role: accountEnums.Member}
+		grant := &roles.AccountRepository{AccountID: target.AccountID, Role: accountEnums.Member,
+			CustomRoleID: &manageMembersID}
+		controller, accountRepositoryMock := newRoleGrantController(accountEnums.Member, grantor, target, nil,
+			manageMembers, manageMembers, manageMembers)
+
+		assert.NoError(t, controller.UpdateAccountRepository(accountID, uuid.New(), grant))
+		accountRepositoryMock.AssertCalled(t, "UpdateAccountRepository")
+	})
+
+	t.Run("should update when repository admin grants admin", func(t *testing.T) {
+		target := &roles.AccountRepository{AccountID: uuid.New(), Role: accountEnums.Member}
+		grant := &roles.AccountRepository{AccountID: target.AccountID, Role: accountEnums.Admin}
+		controller, _ := newRoleGrantController(accountEnums.Member,
+			&roles.AccountRepository{AccountID: accountID, Role: accountEnums.Admin}, target, nil)
+
+		assert.NoError(t, controller.UpdateAccountRepository(accountID, uuid.New(), grant))
+	})
+
+	t.Run("should update when company admin outside of the repository grants admin", func(t *testing.T) {
+		target := &roles.AccountRepository{AccountID: uuid.New(), Role: accountEnums.Member}
+		grant := &roles.AccountRepository{AccountID: target.AccountID, Role: accountEnums.Admin}
+		controller, _ := newRoleGrantController(accountEnums.Admin, &roles.AccountRepository{}, target,
+			errorsEnums.ErrNotFoundRecords)
+
+		assert.NoError(t, controller.UpdateAccountRepository(accountID, uuid.New(), grant))
+	})
+}
+
+func newCompanyAdminMock() *repositoryAccountCompany.Mock {
+	accountCompanyMock := &repositoryAccountCompany.Mock{}
+	accountCompanyMock.On("GetAccountCompany").Return(&roles.AccountCompany{Role: accountEnums.Admin}, nil)
+	return accountCompanyMock
+}
+
 func TestInviteUser(t *testing.T) {
 	inviteUser := &dto.InviteUser{
 		Role:  "admin",
@@ -449,12 +616,37 @@ func TestInviteUser(t *testing.T) {
 		mockWrite.On("Create").Return(respRepository)
 		brokerMock.On("Publish").Return(nil)
 
-		controller := NewController(mockWrite, mockRead, brokerMock, &app.Config{})
+		controller := NewController(mockWrite, mockRead, brokerMock, &app.Config{}).(*Controller)
+		controller.accountCompanyRepository = newCompanyAdminMock()
 
-		err := controller.InviteUser(inviteUser)
+		err := controller.InviteUser(uuid.New(), inviteUser)
 		assert.NoError(t, err)
 	})
 
+	t.Run("should not return error when dispatch chat notification fails", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockRead := &relational.MockRead{}
+		brokerMock := &broker.Mock{}
+		notificationMock := &notificationService.Mock{}
+
+		respRepository := &response.Response{}
+		respAccount := &response.Response{}
+		mockRead.On("Find").Once().Return(respAccount.SetData(account))
+		mockRead.On("Find").Return(respRepository.SetData(repository))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockWrite.On("Create").Return(respRepository)
+		brokerMock.On("Publish").Return(nil)
+		notificationMock.On("Dispatch").Return(errors.New("test"))
+
+		controller := NewController(mockWrite, mockRead, brokerMock, &app.Config{}).(*Controller)
+		controller.notificationService = notificationMock
+		controller.accountCompanyRepository = newCompanyAdminMock()
+
+		err := controller.InviteUser(uuid.New(), inviteUser)
+		assert.NoError(t, err)
+		notificationMock.AssertCalled(t, "Dispatch")
+	})
+
 	t.Run("should return error creating account repository", func(t *testing.T) {
 		mockWrite := &relational.MockWrite{}
 		mockRead := &relational.MockRead{}
@@ -468,9 +660,10 @@ func TestInviteUser(t *testing.T) {
 		mockRead.On("SetFilter").Return(&gorm.DB{})
 		mockWrite.On("Create").Return(respWithError.SetError(errors.New("test")))
 
-		controller := NewController(mockWrite, mockRead, brokerMock, &app.Config{})
+		controller := NewController(mockWrite, mockRead, brokerMock, &app.Config{}).(*Controller)
+		controller.accountCompanyRepository = newCompanyAdminMock()
 
-		err := controller.InviteUser(inviteUser)
+		err := controller.InviteUser(uuid.New(), inviteUser)
 		assert.Error(t, err)
 		assert.Equal(t, errors.New("test"), err)
 	})
@@ -488,7 +681,7 @@ func TestInviteUser(t *testing.T) {
 
 		controller := NewController(mockWrite, mockRead, brokerMock, &app.Config{})
 
-		err := controller.InviteUser(inviteUser)
+		err := controller.InviteUser(uuid.New(), inviteUser)
 		assert.Error(t, err)
 		assert.Equal(t, errors.New("test"), err)
 	})
@@ -504,11 +697,32 @@ func TestInviteUser(t *testing.T) {
 
 		controller := NewController(mockWrite, mockRead, brokerMock, &app.Config{})
 
-		err := controller.InviteUser(inviteUser)
+		err := controller.InviteUser(uuid.New(), inviteUser)
 		assert.Error(t, err)
 		assert.Equal(t, errors.New("test"), err)
 	})
 
+	t.Run("should return error when member without admin role invites an admin", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockRead := &relational.MockRead{}
+
+		respRepository := &response.Response{}
+		respAccount := &response.Response{}
+		mockRead.On("Find").Once().Return(respAccount.SetData(account))
+		mockRead.On("Find").Return(respRepository.SetData(repository))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+
+		controller, accountRepositoryMock := newRoleGrantController(accountEnums.Member,
+			&roles.AccountRepository{Role: accountEnums.Member}, &roles.AccountRepository{}, nil)
+		controller.accountRepository = repositoryAccount.NewAccountRepository(mockRead, mockWrite)
+		controller.repository = repositoryRepo.NewRepository(mockRead, mockWrite)
+		accountRepositoryMock.On("Create").Return(nil)
+
+		err := controller.InviteUser(uuid.New(), inviteUser)
+		assert.Equal(t, errorsEnums.ErrorRoleGrantNotAllowed, err)
+		accountRepositoryMock.AssertNotCalled(t, "Create")
+	})
+
 	t.Run("should successfully invite user without email", func(t *testing.T) {
 		mockWrite := &relational.MockWrite{}
 		mockRead := &relational.MockRead{}
@@ -523,9 +737,10 @@ func TestInviteUser(t *testing.T) {
 
 		appConfig := &app.Config{ConfigAuth: authEntities.ConfigAuth{DisabledBroker: true}}
 
-		controller := NewController(mockWrite, mockRead, brokerMock, appConfig)
+		controller := NewController(mockWrite, mockRead, brokerMock, appConfig).(*Controller)
+		controller.accountCompanyRepository = newCompanyAdminMock()
 
-		err := controller.InviteUser(inviteUser)
+		err := controller.InviteUser(uuid.New(), inviteUser)
 		assert.NoError(t, err)
 	})
 }
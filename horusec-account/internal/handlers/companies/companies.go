@@ -25,6 +25,7 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	auditService "github.com/ZupIT/horusec/development-kit/pkg/services/audit"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
 	authGrpc "github.com/ZupIT/horusec/development-kit/pkg/services/grpc/auth"
 	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
@@ -77,6 +78,7 @@ func (h *Handler) Create(w netHttp.ResponseWriter, r *netHttp.Request) {
 		return
 	}
 
+	auditService.GetResource(r.Context()).SetCompanyID(newRepo.CompanyID).SetResourceID(newRepo.CompanyID.String())
 	httpUtil.StatusCreated(w, newRepo)
 }
 
@@ -338,6 +340,8 @@ func (h *Handler) InviteUser(w netHttp.ResponseWriter, r *netHttp.Request) {
 		return
 	}
 
+	auditService.GetResource(r.Context()).SetResourceID(inviteUser.Email).SetDetails(string(inviteUser.Role))
+
 	httpUtil.StatusNoContent(w)
 }
 
@@ -425,6 +429,34 @@ func (h *Handler) RemoveUser(w netHttp.ResponseWriter, r *netHttp.Request) {
 	httpUtil.StatusNoContent(w)
 }
 
+// @Tags Companies
+// @Description reset the two factor authentication of a company member!
+// @ID reset-two-factor
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the company"
+// @Param accountID path string true "accountID of the account"
+// @Success 204 {object} http.Response{content=string} "NO CONTENT"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 404 {object} http.Response{content=string} "NOT FOUND"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/companies/{companyID}/roles/{accountID}/two-factor [delete]
+// @Security ApiKeyAuth
+func (h *Handler) ResetTwoFactor(w netHttp.ResponseWriter, r *netHttp.Request) {
+	member, err := h.getRemoveUserRequestData(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	if err := h.companyController.ResetTwoFactor(member.CompanyID, member.AccountID); err != nil {
+		h.checkDefaultErrors(err, w)
+		return
+	}
+
+	httpUtil.StatusNoContent(w)
+}
+
 func (h *Handler) getRemoveUserRequestData(r *netHttp.Request) (*dto.RemoveUser, error) {
 	removeUser := &dto.RemoveUser{}
 	accountID, err := uuid.Parse(chi.URLParam(r, "accountID"))
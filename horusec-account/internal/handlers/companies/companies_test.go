@@ -34,6 +34,7 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	auditService "github.com/ZupIT/horusec/development-kit/pkg/services/audit"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/broker"
 	authGrpc "github.com/ZupIT/horusec/development-kit/pkg/services/grpc/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/jwt"
@@ -68,8 +69,9 @@ func TestCreateCompany(t *testing.T) {
 			Name: "test",
 		}
 
+		companyID := uuid.New()
 		resp := &response.Response{}
-		resp.SetData(company)
+		resp.SetData(&accountEntities.Company{CompanyID: companyID, Name: "test"})
 		mockTx.On("Create").Return(resp)
 		mockTx.On("CommitTransaction").Return(&response.Response{})
 
@@ -84,9 +86,12 @@ func TestCreateCompany(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		r = r.WithContext(context.WithValue(r.Context(), authEnums.AccountData, &authGrpc.GetAccountDataResponse{AccountID: uuid.New().String(), Permissions: []string{}}))
-		handler.Create(w, r)
+		auditCtx, resource := auditService.NewResourceContext(r.Context())
+		handler.Create(w, r.WithContext(auditCtx))
 
 		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, companyID, resource.CompanyID)
+		assert.Equal(t, companyID.String(), resource.ResourceID)
 	})
 
 	t.Run("should return status code 200 when create a company successfully with application admin", func(t *testing.T) {
@@ -687,10 +692,13 @@ func TestInviteUser(t *testing.T) {
 		ctx := chi.NewRouteContext()
 		ctx.URLParams.Add("companyID", uuid.New().String())
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+		auditCtx, resource := auditService.NewResourceContext(r.Context())
 
-		handler.InviteUser(w, r)
+		handler.InviteUser(w, r.WithContext(auditCtx))
 
 		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "test@test.com", resource.ResourceID)
+		assert.Equal(t, "admin", resource.Details)
 	})
 
 	t.Run("should return status 500 when something unexpected happened", func(t *testing.T) {
@@ -1083,3 +1091,55 @@ func TestRemoveUser(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 }
+
+func TestResetTwoFactor(t *testing.T) {
+	newRequest := func(companyID, accountID string) *http.Request {
+		r, _ := http.NewRequest(http.MethodDelete, "api/companies/", nil)
+		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("companyID", companyID)
+		ctx.URLParams.Add("accountID", accountID)
+		return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+	}
+
+	t.Run("should return 204 when successfully reset two factor", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockRead := &relational.MockRead{}
+
+		resp := &response.Response{}
+		mockWrite.On("Update").Return(resp)
+		mockRead.On("Find").Return(resp.SetData(authEntities.Account{IsTwoFactorEnabled: true}))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+
+		handler := NewHandler(mockWrite, mockRead, &broker.Mock{}, &app.Config{})
+		w := httptest.NewRecorder()
+
+		handler.ResetTwoFactor(w, newRequest(uuid.New().String(), uuid.New().String()))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("should return 404 when account is not a company member", func(t *testing.T) {
+		mockWrite := &relational.MockWrite{}
+		mockRead := &relational.MockRead{}
+
+		resp := &response.Response{}
+		mockRead.On("Find").Return(resp.SetError(errorsEnum.ErrNotFoundRecords))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+
+		handler := NewHandler(mockWrite, mockRead, &broker.Mock{}, &app.Config{})
+		w := httptest.NewRecorder()
+
+		handler.ResetTwoFactor(w, newRequest(uuid.New().String(), uuid.New().String()))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should return 400 when invalid account id", func(t *testing.T) {
+		handler := NewHandler(&relational.MockWrite{}, &relational.MockRead{}, &broker.Mock{}, &app.Config{})
+		w := httptest.NewRecorder()
+
+		handler.ResetTwoFactor(w, newRequest(uuid.New().String(), "invalid"))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
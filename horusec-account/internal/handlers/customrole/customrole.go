@@ -0,0 +1,200 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	netHTTP "net/http"
+
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	_ "github.com/ZupIT/horusec/development-kit/pkg/entities/http" // [swagger-import]
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	auditService "github.com/ZupIT/horusec/development-kit/pkg/services/audit"
+	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
+	customRoleController "github.com/ZupIT/horusec/horusec-account/internal/controller/customrole"
+	customRoleUseCases "github.com/ZupIT/horusec/horusec-account/internal/usecases/customrole"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	customRoleController customRoleController.IController
+	customRoleUseCases   customRoleUseCases.ICustomRole
+}
+
+func NewHandler(databaseWrite SQL.InterfaceWrite, databaseRead SQL.InterfaceRead) *Handler {
+	return &Handler{
+		customRoleController: customRoleController.NewController(databaseWrite, databaseRead),
+		customRoleUseCases:   customRoleUseCases.NewCustomRoleUseCases(),
+	}
+}
+
+// @Tags Custom Roles
+// @Description create a custom role in the company!
+// @ID create-custom-role
+// @Accept  json
+// @Produce  json
+// @Param CustomRole body roles.CustomRole true "custom role info and its permissions"
+// @Param companyID path string true "companyID of the custom role"
+// @Success 201 {object} http.Response{content=roles.CustomRole} "CREATED"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 409 {object} http.Response{content=string} "CONFLICT"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/companies/{companyID}/custom-roles [post]
+// @Security ApiKeyAuth
+func (h *Handler) Create(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, err := uuid.Parse(chi.URLParam(r, "companyID"))
+	if err != nil {
+		httpUtil.StatusBadRequest(w, errorsEnum.ErrorInvalidCompanyID)
+		return
+	}
+
+	customRole, err := h.customRoleUseCases.NewCustomRoleFromReadCloser(r.Body)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	response, err := h.customRoleController.Create(companyID, customRole)
+	if err != nil {
+		h.checkErrors(w, err)
+		return
+	}
+
+	auditService.GetResource(r.Context()).SetResourceID(response.CustomRoleID.String())
+	httpUtil.StatusCreated(w, response)
+}
+
+// @Tags Custom Roles
+// @Description get all custom roles of the company!
+// @ID get-custom-roles
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the custom roles"
+// @Success 200 {object} http.Response{content=[]roles.CustomRole} "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/companies/{companyID}/custom-roles [get]
+// @Security ApiKeyAuth
+func (h *Handler) ListAll(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, err := uuid.Parse(chi.URLParam(r, "companyID"))
+	if err != nil {
+		httpUtil.StatusBadRequest(w, errorsEnum.ErrorInvalidCompanyID)
+		return
+	}
+
+	response, err := h.customRoleController.ListAll(companyID)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, response)
+}
+
+// @Tags Custom Roles
+// @Description update a custom role of the company!
+// @ID update-custom-role
+// @Accept  json
+// @Produce  json
+// @Param CustomRole body roles.CustomRole true "custom role info and its permissions"
+// @Param companyID path string true "companyID of the custom role"
+// @Param customRoleID path string true "customRoleID of the custom role"
+// @Success 200 {object} http.Response{content=roles.CustomRole} "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 404 {object} http.Response{content=string} "NOT FOUND"
+// @Failure 409 {object} http.Response{content=string} "CONFLICT"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/companies/{companyID}/custom-roles/{customRoleID} [put]
+// @Security ApiKeyAuth
+func (h *Handler) Update(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, customRoleID, err := h.getIDs(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	customRole, err := h.customRoleUseCases.NewCustomRoleFromReadCloser(r.Body)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	h.executeUpdateController(w, companyID, customRoleID, customRole)
+}
+
+func (h *Handler) executeUpdateController(w netHTTP.ResponseWriter, companyID, customRoleID uuid.UUID,
+	customRole *roles.CustomRole) {
+	response, err := h.customRoleController.Update(companyID, customRoleID, customRole)
+	if err != nil {
+		h.checkErrors(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, response)
+}
+
+// @Tags Custom Roles
+// @Description delete a custom role of the company, repository members with it keep only their built-in role!
+// @ID delete-custom-role
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the custom role"
+// @Param customRoleID path string true "customRoleID of the custom role"
+// @Success 204
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 404 {object} http.Response{content=string} "NOT FOUND"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/companies/{companyID}/custom-roles/{customRoleID} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) Remove(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, customRoleID, err := h.getIDs(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	if err := h.customRoleController.Remove(companyID, customRoleID); err != nil {
+		h.checkErrors(w, err)
+		return
+	}
+
+	httpUtil.StatusNoContent(w)
+}
+
+func (h *Handler) getIDs(r *netHTTP.Request) (companyID, customRoleID uuid.UUID, err error) {
+	companyID, err = uuid.Parse(chi.URLParam(r, "companyID"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errorsEnum.ErrorInvalidCompanyID
+	}
+
+	customRoleID, err = uuid.Parse(chi.URLParam(r, "customRoleID"))
+	if err != nil || customRoleID == uuid.Nil {
+		return uuid.Nil, uuid.Nil, errorsEnum.ErrorInvalidCustomRoleID
+	}
+
+	return companyID, customRoleID, nil
+}
+
+func (h *Handler) checkErrors(w netHTTP.ResponseWriter, err error) {
+	switch err {
+	case errorsEnum.ErrNotFoundRecords:
+		httpUtil.StatusNotFound(w, errorsEnum.ErrorCustomRoleNotFound)
+	case errorsEnum.ErrorCustomRoleNameAlreadyInUse:
+		httpUtil.StatusConflict(w, err)
+	default:
+		httpUtil.StatusInternalServerError(w, err)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	customRoleController "github.com/ZupIT/horusec/horusec-account/internal/controller/customrole"
+	customRoleUseCases "github.com/ZupIT/horusec/horusec-account/internal/usecases/customrole"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const validBody = `{"name": "triager", "permissions": ["vulnerabilities:manage"]}`
+
+func newRequest(method string, body []byte, params map[string]string) *http.Request {
+	r, _ := http.NewRequest(method, "account/companies/custom-roles", bytes.NewReader(body))
+	ctx := chi.NewRouteContext()
+	for key, value := range params {
+		ctx.URLParams.Add(key, value)
+	}
+
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+}
+
+func newHandlerWithMock(mockController *customRoleController.Mock) *Handler {
+	return &Handler{
+		customRoleController: mockController,
+		customRoleUseCases:   customRoleUseCases.NewCustomRoleUseCases(),
+	}
+}
+
+func TestNewHandler(t *testing.T) {
+	assert.NotEmpty(t, NewHandler(&relational.MockWrite{}, &relational.MockRead{}))
+}
+
+func TestHandler_Create(t *testing.T) {
+	params := map[string]string{"companyID": uuid.New().String()}
+
+	t.Run("should return status created when everything it is ok", func(t *testing.T) {
+		mockController := &customRoleController.Mock{}
+		mockController.On("Create").Return(&roles.CustomRole{}, nil)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Create(w, newRequest(http.MethodPost, []byte(validBody), params))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+	t.Run("should return status bad request when permission is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&customRoleController.Mock{})
+
+		w := httptest.NewRecorder()
+		handler.Create(w, newRequest(http.MethodPost, []byte(`{"name": "test", "permissions": ["test"]}`), params))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status bad request when company id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&customRoleController.Mock{})
+
+		w := httptest.NewRecorder()
+		handler.Create(w, newRequest(http.MethodPost, []byte(validBody), map[string]string{"companyID": "invalid"}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status conflict when name already in use", func(t *testing.T) {
+		mockController := &customRoleController.Mock{}
+		mockController.On("Create").Return(&roles.CustomRole{}, errorsEnum.ErrorCustomRoleNameAlreadyInUse)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Create(w, newRequest(http.MethodPost, []byte(validBody), params))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+	t.Run("should return status internal server error when create fails", func(t *testing.T) {
+		mockController := &customRoleController.Mock{}
+		mockController.On("Create").Return(&roles.CustomRole{}, errors.New("test"))
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Create(w, newRequest(http.MethodPost, []byte(validBody), params))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_ListAll(t *testing.T) {
+	t.Run("should return status ok when everything it is ok", func(t *testing.T) {
+		mockController := &customRoleController.Mock{}
+		mockController.On("ListAll").Return(&[]roles.CustomRole{}, nil)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.ListAll(w, newRequest(http.MethodGet, nil, map[string]string{"companyID": uuid.New().String()}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+	t.Run("should return status bad request when company id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&customRoleController.Mock{})
+
+		w := httptest.NewRecorder()
+		handler.ListAll(w, newRequest(http.MethodGet, nil, map[string]string{"companyID": "invalid"}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status internal server error when list fails", func(t *testing.T) {
+		mockController := &customRoleController.Mock{}
+		mockController.On("ListAll").Return(&[]roles.CustomRole{}, errors.New("test"))
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.ListAll(w, newRequest(http.MethodGet, nil, map[string]string{"companyID": uuid.New().String()}))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_Update(t *testing.T) {
+	params := map[string]string{"companyID": uuid.New().String(), "customRoleID": uuid.New().String()}
+
+	t.Run("should return status ok when everything it is ok", func(t *testing.T) {
+		mockController := &customRoleController.Mock{}
+		mockController.On("Update").Return(&roles.CustomRole{}, nil)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Update(w, newRequest(http.MethodPut, []byte(validBody), params))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+	t.Run("should return status bad request when custom role id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&customRoleController.Mock{})
+
+		w := httptest.NewRecorder()
+		handler.Update(w, newRequest(http.MethodPut, []byte(validBody),
+			map[string]string{"companyID": uuid.New().String(), "customRoleID": "invalid"}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status bad request when body is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&customRoleController.Mock{})
+
+		w := httptest.NewRecorder()
+		handler.Update(w, newRequest(http.MethodPut, []byte("invalid"), params))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status not found when custom role is not of the company", func(t *testing.T) {
+		mockController := &customRoleController.Mock{}
+		mockController.On("Update").Return(&roles.CustomRole{}, errorsEnum.ErrNotFoundRecords)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Update(w, newRequest(http.MethodPut, []byte(validBody), params))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_Remove(t *testing.T) {
+	params := map[string]string{"companyID": uuid.New().String(), "customRoleID": uuid.New().String()}
+
+	t.Run("should return status no content when everything it is ok", func(t *testing.T) {
+		mockController := &customRoleController.Mock{}
+		mockController.On("Remove").Return(nil)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Remove(w, newRequest(http.MethodDelete, nil, params))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+	t.Run("should return status bad request when company id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&customRoleController.Mock{})
+
+		w := httptest.NewRecorder()
+		handler.Remove(w, newRequest(http.MethodDelete, nil,
+			map[string]string{"companyID": "invalid", "customRoleID": uuid.New().String()}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status not found when custom role is not of the company", func(t *testing.T) {
+		mockController := &customRoleController.Mock{}
+		mockController.On("Remove").Return(errorsEnum.ErrNotFoundRecords)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Remove(w, newRequest(http.MethodDelete, nil, params))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	netHTTP "net/http"
+
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	_ "github.com/ZupIT/horusec/development-kit/pkg/entities/http" // [swagger-import]
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/notification"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
+	notificationController "github.com/ZupIT/horusec/horusec-account/internal/controller/notification"
+	notificationUseCases "github.com/ZupIT/horusec/horusec-account/internal/usecases/notification"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	notificationController notificationController.IController
+	notificationUseCases   notificationUseCases.INotification
+}
+
+func NewHandler(databaseWrite SQL.InterfaceWrite, databaseRead SQL.InterfaceRead) *Handler {
+	return &Handler{
+		notificationController: notificationController.NewController(databaseWrite, databaseRead),
+		notificationUseCases:   notificationUseCases.NewNotificationUseCases(),
+	}
+}
+
+func (h *Handler) Options(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	httpUtil.StatusNoContent(w)
+}
+
+// @Tags Notifications
+// @Description create chat notification!
+// @ID create-notification
+// @Accept  json
+// @Produce  json
+// @Param Notification body notification.Notification true "notification info, channels allowed are slack, teams and chat"
+// @Param companyID path string true "companyID of the notification"
+// @Success 201 {object} http.Response{content=string} "CREATED"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/notifications/{companyID} [post]
+// @Security ApiKeyAuth
+func (h *Handler) Create(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	notificationEntity, err := h.getNotificationEntity(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	response, err := h.notificationController.Create(notificationEntity)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusCreated(w, response)
+}
+
+// @Tags Notifications
+// @Description get all chat notifications of company!
+// @ID get-notifications
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the notification"
+// @Success 200 {object} http.Response{content=[]notification.Notification} "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/notifications/{companyID} [get]
+// @Security ApiKeyAuth
+func (h *Handler) ListAll(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, err := uuid.Parse(chi.URLParam(r, "companyID"))
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	response, err := h.notificationController.ListAll(companyID)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, response)
+}
+
+// @Tags Notifications
+// @Description update chat notification!
+// @ID update-notification
+// @Accept  json
+// @Produce  json
+// @Param Notification body notification.Notification true "notification info, channels allowed are slack, teams and chat"
+// @Param companyID path string true "companyID of the notification"
+// @Param notificationID path string true "notificationID of the notification"
+// @Success 204
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 404 {object} http.Response{content=string} "NOT FOUND"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/notifications/{companyID}/{notificationID} [put]
+// @Security ApiKeyAuth
+func (h *Handler) Update(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	notificationID, err := uuid.Parse(chi.URLParam(r, "notificationID"))
+	if err != nil || notificationID == uuid.Nil {
+		httpUtil.StatusBadRequest(w, errorsEnum.ErrorInvalidNotificationID)
+		return
+	}
+
+	notificationEntity, err := h.getNotificationEntity(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	h.executeUpdateController(notificationEntity.SetNotificationID(notificationID), w)
+}
+
+func (h *Handler) executeUpdateController(notificationEntity *notification.Notification, w netHTTP.ResponseWriter) {
+	if err := h.notificationController.Update(notificationEntity); err != nil {
+		if err == errorsEnum.ErrNotFoundRecords {
+			httpUtil.StatusNotFound(w, err)
+		} else {
+			httpUtil.StatusInternalServerError(w, err)
+		}
+		return
+	}
+
+	httpUtil.StatusNoContent(w)
+}
+
+// @Tags Notifications
+// @Description delete chat notification!
+// @ID delete-notification
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the notification"
+// @Param notificationID path string true "notificationID of the notification"
+// @Success 204
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 404 {object} http.Response{content=string} "NOT FOUND"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/notifications/{companyID}/{notificationID} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) Remove(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, err := uuid.Parse(chi.URLParam(r, "companyID"))
+	if err != nil {
+		httpUtil.StatusBadRequest(w, errorsEnum.ErrorInvalidCompanyID)
+		return
+	}
+
+	notificationID, err := uuid.Parse(chi.URLParam(r, "notificationID"))
+	if err != nil || notificationID == uuid.Nil {
+		httpUtil.StatusBadRequest(w, errorsEnum.ErrorInvalidNotificationID)
+		return
+	}
+
+	h.executeRemoveController(companyID, notificationID, w)
+}
+
+func (h *Handler) executeRemoveController(companyID, notificationID uuid.UUID, w netHTTP.ResponseWriter) {
+	if err := h.notificationController.Remove(companyID, notificationID); err != nil {
+		if err == errorsEnum.ErrNotFoundRecords {
+			httpUtil.StatusNotFound(w, err)
+		} else {
+			httpUtil.StatusInternalServerError(w, err)
+		}
+		return
+	}
+
+	httpUtil.StatusNoContent(w)
+}
+
+func (h *Handler) getNotificationEntity(r *netHTTP.Request) (*notification.Notification, error) {
+	notificationEntity, err := h.notificationUseCases.NewNotificationFromReadCloser(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	notificationEntity, err = notificationEntity.SetCompanyID(chi.URLParam(r, "companyID"))
+	if err != nil {
+		return nil, err
+	}
+
+	return notificationEntity, notificationEntity.Validate()
+}
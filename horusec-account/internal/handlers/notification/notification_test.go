@@ -0,0 +1,307 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/notification"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	notificationController "github.com/ZupIT/horusec/horusec-account/internal/controller/notification"
+	notificationUseCases "github.com/ZupIT/horusec/horusec-account/internal/usecases/notification"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func getValidBody() *notification.Notification {
+	return &notification.Notification{
+		Description: "alerts",
+		Channel:     notificationEnum.Slack,
+		URL:         "https://hooks.slack.com/services/test",
+		Events:      []string{notificationEnum.AnalysisFinished.ToString()},
+	}
+}
+
+func newRequest(method string, body []byte, params map[string]string) *http.Request {
+	r, _ := http.NewRequest(method, "api/notifications", bytes.NewReader(body))
+	ctx := chi.NewRouteContext()
+	for key, value := range params {
+		ctx.URLParams.Add(key, value)
+	}
+
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+}
+
+func newHandlerWithMock(mockController *notificationController.Mock) *Handler {
+	return &Handler{
+		notificationController: mockController,
+		notificationUseCases:   notificationUseCases.NewNotificationUseCases(),
+	}
+}
+
+func TestNewHandler(t *testing.T) {
+	assert.NotEmpty(t, NewHandler(&relational.MockWrite{}, &relational.MockRead{}))
+}
+
+func TestHandler_Options(t *testing.T) {
+	t.Run("should return status no content when options", func(t *testing.T) {
+		handler := NewHandler(&relational.MockWrite{}, &relational.MockRead{})
+
+		r, _ := http.NewRequest(http.MethodOptions, "api/notifications", nil)
+		w := httptest.NewRecorder()
+
+		handler.Options(w, r)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
+func TestHandler_Create(t *testing.T) {
+	t.Run("should return status created when everything it is ok", func(t *testing.T) {
+		mockController := &notificationController.Mock{}
+		mockController.On("Create").Return(uuid.New(), nil)
+		handler := newHandlerWithMock(mockController)
+
+		r := newRequest(http.MethodPost, getValidBody().ToBytes(), map[string]string{"companyID": uuid.New().String()})
+		w := httptest.NewRecorder()
+
+		handler.Create(w, r)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+	t.Run("should return status bad request when channel is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&notificationController.Mock{})
+		body := getValidBody()
+		body.Channel = "discord"
+
+		r := newRequest(http.MethodPost, body.ToBytes(), map[string]string{"companyID": uuid.New().String()})
+		w := httptest.NewRecorder()
+
+		handler.Create(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status bad request when event is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&notificationController.Mock{})
+		body := getValidBody()
+		body.Events = []string{"unknown-event"}
+
+		r := newRequest(http.MethodPost, body.ToBytes(), map[string]string{"companyID": uuid.New().String()})
+		w := httptest.NewRecorder()
+
+		handler.Create(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status bad request when company id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&notificationController.Mock{})
+
+		r := newRequest(http.MethodPost, getValidBody().ToBytes(), map[string]string{"companyID": "invalid"})
+		w := httptest.NewRecorder()
+
+		handler.Create(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status bad request when body is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&notificationController.Mock{})
+
+		r := newRequest(http.MethodPost, []byte("invalid"), map[string]string{"companyID": uuid.New().String()})
+		w := httptest.NewRecorder()
+
+		handler.Create(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status internal server error when create fails", func(t *testing.T) {
+		mockController := &notificationController.Mock{}
+		mockController.On("Create").Return(uuid.Nil, errors.New("test"))
+		handler := newHandlerWithMock(mockController)
+
+		r := newRequest(http.MethodPost, getValidBody().ToBytes(), map[string]string{"companyID": uuid.New().String()})
+		w := httptest.NewRecorder()
+
+		handler.Create(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_ListAll(t *testing.T) {
+	t.Run("should return status ok when everything it is ok", func(t *testing.T) {
+		mockController := &notificationController.Mock{}
+		mockController.On("ListAll").Return(&[]notification.Notification{}, nil)
+		handler := newHandlerWithMock(mockController)
+
+		r := newRequest(http.MethodGet, nil, map[string]string{"companyID": uuid.New().String()})
+		w := httptest.NewRecorder()
+
+		handler.ListAll(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+	t.Run("should return status bad request when company id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&notificationController.Mock{})
+
+		r := newRequest(http.MethodGet, nil, map[string]string{"companyID": "invalid"})
+		w := httptest.NewRecorder()
+
+		handler.ListAll(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status internal server error when list fails", func(t *testing.T) {
+		mockController := &notificationController.Mock{}
+		mockController.On("ListAll").Return(&[]notification.Notification{}, errors.New("test"))
+		handler := newHandlerWithMock(mockController)
+
+		r := newRequest(http.MethodGet, nil, map[string]string{"companyID": uuid.New().String()})
+		w := httptest.NewRecorder()
+
+		handler.ListAll(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_Update(t *testing.T) {
+	params := map[string]string{"companyID": uuid.New().String(), "notificationID": uuid.New().String()}
+
+	t.Run("should return status no content when everything it is ok", func(t *testing.T) {
+		mockController := &notificationController.Mock{}
+		mockController.On("Update").Return(nil)
+		handler := newHandlerWithMock(mockController)
+
+		r := newRequest(http.MethodPut, getValidBody().ToBytes(), params)
+		w := httptest.NewRecorder()
+
+		handler.Update(w, r)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+	t.Run("should return status bad request when notification id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&notificationController.Mock{})
+
+		r := newRequest(http.MethodPut, getValidBody().ToBytes(), map[string]string{"companyID": uuid.New().String()})
+		w := httptest.NewRecorder()
+
+		handler.Update(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status bad request when body is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&notificationController.Mock{})
+
+		r := newRequest(http.MethodPut, []byte("invalid"), params)
+		w := httptest.NewRecorder()
+
+		handler.Update(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status not found when notification not exists", func(t *testing.T) {
+		mockController := &notificationController.Mock{}
+		mockController.On("Update").Return(errorsEnum.ErrNotFoundRecords)
+		handler := newHandlerWithMock(mockController)
+
+		r := newRequest(http.MethodPut, getValidBody().ToBytes(), params)
+		w := httptest.NewRecorder()
+
+		handler.Update(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+	t.Run("should return status internal server error when update fails", func(t *testing.T) {
+		mockController := &notificationController.Mock{}
+		mockController.On("Update").Return(errors.New("test"))
+		handler := newHandlerWithMock(mockController)
+
+		r := newRequest(http.MethodPut, getValidBody().ToBytes(), params)
+		w := httptest.NewRecorder()
+
+		handler.Update(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_Remove(t *testing.T) {
+	params := map[string]string{"companyID": uuid.New().String(), "notificationID": uuid.New().String()}
+
+	t.Run("should return status no content when everything it is ok", func(t *testing.T) {
+		mockController := &notificationController.Mock{}
+		mockController.On("Remove").Return(nil)
+		handler := newHandlerWithMock(mockController)
+
+		r := newRequest(http.MethodDelete, nil, params)
+		w := httptest.NewRecorder()
+
+		handler.Remove(w, r)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+	t.Run("should return status bad request when company id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&notificationController.Mock{})
+
+		r := newRequest(http.MethodDelete, nil, map[string]string{"companyID": "invalid"})
+		w := httptest.NewRecorder()
+
+		handler.Remove(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status bad request when notification id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&notificationController.Mock{})
+
+		r := newRequest(http.MethodDelete, nil, map[string]string{"companyID": uuid.New().String()})
+		w := httptest.NewRecorder()
+
+		handler.Remove(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status not found when notification not exists", func(t *testing.T) {
+		mockController := &notificationController.Mock{}
+		mockController.On("Remove").Return(errorsEnum.ErrNotFoundRecords)
+		handler := newHandlerWithMock(mockController)
+
+		r := newRequest(http.MethodDelete, nil, params)
+		w := httptest.NewRecorder()
+
+		handler.Remove(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+	t.Run("should return status internal server error when remove fails", func(t *testing.T) {
+		mockController := &notificationController.Mock{}
+		mockController.On("Remove").Return(errors.New("test"))
+		handler := newHandlerWithMock(mockController)
+
+		r := newRequest(http.MethodDelete, nil, params)
+		w := httptest.NewRecorder()
+
+		handler.Remove(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	netHTTP "net/http"
+
+	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	_ "github.com/ZupIT/horusec/development-kit/pkg/entities/http" // [swagger-import]
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	auditService "github.com/ZupIT/horusec/development-kit/pkg/services/audit"
+	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
+	policyController "github.com/ZupIT/horusec/horusec-account/internal/controller/policy"
+	policyUseCases "github.com/ZupIT/horusec/horusec-account/internal/usecases/policy"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	policyController policyController.IController
+	policyUseCases   policyUseCases.IPolicy
+}
+
+func NewHandler(databaseWrite SQL.InterfaceWrite, databaseRead SQL.InterfaceRead) *Handler {
+	return &Handler{
+		policyController: policyController.NewController(databaseWrite, databaseRead),
+		policyUseCases:   policyUseCases.NewPolicyUseCases(),
+	}
+}
+
+// @Tags Policies
+// @Description create a policy gate in the company, without repositoryID it applies to all repositories!
+// @ID create-policy
+// @Accept  json
+// @Produce  json
+// @Param Policy body account.Policy true "policy rules"
+// @Param companyID path string true "companyID of the policy"
+// @Success 201 {object} http.Response{content=account.Policy} "CREATED"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 404 {object} http.Response{content=string} "NOT FOUND"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/companies/{companyID}/policies [post]
+// @Security ApiKeyAuth
+func (h *Handler) Create(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, err := uuid.Parse(chi.URLParam(r, "companyID"))
+	if err != nil {
+		httpUtil.StatusBadRequest(w, errorsEnum.ErrorInvalidCompanyID)
+		return
+	}
+
+	policy, err := h.policyUseCases.NewPolicyFromReadCloser(r.Body)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	response, err := h.policyController.Create(companyID, policy)
+	if err != nil {
+		h.checkErrors(w, err)
+		return
+	}
+
+	auditService.GetResource(r.Context()).SetResourceID(response.PolicyID.String())
+	httpUtil.StatusCreated(w, response)
+}
+
+// @Tags Policies
+// @Description get all policies of the company!
+// @ID get-policies
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the policies"
+// @Success 200 {object} http.Response{content=[]account.Policy} "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/companies/{companyID}/policies [get]
+// @Security ApiKeyAuth
+func (h *Handler) ListAll(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, err := uuid.Parse(chi.URLParam(r, "companyID"))
+	if err != nil {
+		httpUtil.StatusBadRequest(w, errorsEnum.ErrorInvalidCompanyID)
+		return
+	}
+
+	response, err := h.policyController.ListAll(companyID)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, response)
+}
+
+// @Tags Policies
+// @Description update a policy of the company!
+// @ID update-policy
+// @Accept  json
+// @Produce  json
+// @Param Policy body account.Policy true "policy rules"
+// @Param companyID path string true "companyID of the policy"
+// @Param policyID path string true "policyID of the policy"
+// @Success 200 {object} http.Response{content=account.Policy} "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 404 {object} http.Response{content=string} "NOT FOUND"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/companies/{companyID}/policies/{policyID} [put]
+// @Security ApiKeyAuth
+func (h *Handler) Update(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, policyID, err := h.getIDs(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	policy, err := h.policyUseCases.NewPolicyFromReadCloser(r.Body)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	h.executeUpdateController(w, companyID, policyID, policy)
+}
+
+func (h *Handler) executeUpdateController(w netHTTP.ResponseWriter, companyID, policyID uuid.UUID,
+	policy *accountEntities.Policy) {
+	response, err := h.policyController.Update(companyID, policyID, policy)
+	if err != nil {
+		h.checkErrors(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, response)
+}
+
+// @Tags Policies
+// @Description delete a policy of the company!
+// @ID delete-policy
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the policy"
+// @Param policyID path string true "policyID of the policy"
+// @Success 204
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 404 {object} http.Response{content=string} "NOT FOUND"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /account/companies/{companyID}/policies/{policyID} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) Remove(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, policyID, err := h.getIDs(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	if err := h.policyController.Remove(companyID, policyID); err != nil {
+		h.checkErrors(w, err)
+		return
+	}
+
+	httpUtil.StatusNoContent(w)
+}
+
+func (h *Handler) getIDs(r *netHTTP.Request) (companyID, policyID uuid.UUID, err error) {
+	companyID, err = uuid.Parse(chi.URLParam(r, "companyID"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errorsEnum.ErrorInvalidCompanyID
+	}
+
+	policyID, err = uuid.Parse(chi.URLParam(r, "policyID"))
+	if err != nil || policyID == uuid.Nil {
+		return uuid.Nil, uuid.Nil, errorsEnum.ErrorInvalidPolicyID
+	}
+
+	return companyID, policyID, nil
+}
+
+func (h *Handler) checkErrors(w netHTTP.ResponseWriter, err error) {
+	switch err {
+	case errorsEnum.ErrNotFoundRecords:
+		httpUtil.StatusNotFound(w, errorsEnum.ErrorPolicyNotFound)
+	case errorsEnum.ErrorPolicyRepositoryNotFound:
+		httpUtil.StatusNotFound(w, err)
+	default:
+		httpUtil.StatusInternalServerError(w, err)
+	}
+}
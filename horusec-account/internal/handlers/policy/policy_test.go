@@ -0,0 +1,214 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	policyController "github.com/ZupIT/horusec/horusec-account/internal/controller/policy"
+	policyUseCases "github.com/ZupIT/horusec/horusec-account/internal/usecases/policy"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const validBody = `{"name": "gate", "maxHighVulnerabilities": 0, "requiredTools": ["GoSec"]}`
+
+func newRequest(method string, body []byte, params map[string]string) *http.Request {
+	r, _ := http.NewRequest(method, "account/companies/policies", bytes.NewReader(body))
+	ctx := chi.NewRouteContext()
+	for key, value := range params {
+		ctx.URLParams.Add(key, value)
+	}
+
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+}
+
+func newHandlerWithMock(mockController *policyController.Mock) *Handler {
+	return &Handler{
+		policyController: mockController,
+		policyUseCases:   policyUseCases.NewPolicyUseCases(),
+	}
+}
+
+func TestNewHandler(t *testing.T) {
+	assert.NotEmpty(t, NewHandler(&relational.MockWrite{}, &relational.MockRead{}))
+}
+
+func TestHandler_Create(t *testing.T) {
+	params := map[string]string{"companyID": uuid.New().String()}
+
+	t.Run("should return status created when everything it is ok", func(t *testing.T) {
+		mockController := &policyController.Mock{}
+		mockController.On("Create").Return(&accountEntities.Policy{}, nil)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Create(w, newRequest(http.MethodPost, []byte(validBody), params))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+	t.Run("should return status bad request when tool is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&policyController.Mock{})
+
+		w := httptest.NewRecorder()
+		handler.Create(w, newRequest(http.MethodPost, []byte(`{"name": "gate", "requiredTools": ["test"]}`), params))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status bad request when company id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&policyController.Mock{})
+
+		w := httptest.NewRecorder()
+		handler.Create(w, newRequest(http.MethodPost, []byte(validBody), map[string]string{"companyID": "invalid"}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status not found when repository is not of the company", func(t *testing.T) {
+		mockController := &policyController.Mock{}
+		mockController.On("Create").Return(&accountEntities.Policy{}, errorsEnum.ErrorPolicyRepositoryNotFound)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Create(w, newRequest(http.MethodPost, []byte(validBody), params))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+	t.Run("should return status internal server error when create fails", func(t *testing.T) {
+		mockController := &policyController.Mock{}
+		mockController.On("Create").Return(&accountEntities.Policy{}, errors.New("test"))
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Create(w, newRequest(http.MethodPost, []byte(validBody), params))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_ListAll(t *testing.T) {
+	t.Run("should return status ok when everything it is ok", func(t *testing.T) {
+		mockController := &policyController.Mock{}
+		mockController.On("ListAll").Return(&[]accountEntities.Policy{}, nil)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.ListAll(w, newRequest(http.MethodGet, nil, map[string]string{"companyID": uuid.New().String()}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+	t.Run("should return status bad request when company id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&policyController.Mock{})
+
+		w := httptest.NewRecorder()
+		handler.ListAll(w, newRequest(http.MethodGet, nil, map[string]string{"companyID": "invalid"}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status internal server error when list fails", func(t *testing.T) {
+		mockController := &policyController.Mock{}
+		mockController.On("ListAll").Return(&[]accountEntities.Policy{}, errors.New("test"))
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.ListAll(w, newRequest(http.MethodGet, nil, map[string]string{"companyID": uuid.New().String()}))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_Update(t *testing.T) {
+	params := map[string]string{"companyID": uuid.New().String(), "policyID": uuid.New().String()}
+
+	t.Run("should return status ok when everything it is ok", func(t *testing.T) {
+		mockController := &policyController.Mock{}
+		mockController.On("Update").Return(&accountEntities.Policy{}, nil)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Update(w, newRequest(http.MethodPut, []byte(validBody), params))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+	t.Run("should return status bad request when policy id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&policyController.Mock{})
+
+		w := httptest.NewRecorder()
+		handler.Update(w, newRequest(http.MethodPut, []byte(validBody),
+			map[string]string{"companyID": uuid.New().String(), "policyID": "invalid"}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status bad request when body is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&policyController.Mock{})
+
+		w := httptest.NewRecorder()
+		handler.Update(w, newRequest(http.MethodPut, []byte(`{"name": ""}`), params))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status not found when policy does not exist", func(t *testing.T) {
+		mockController := &policyController.Mock{}
+		mockController.On("Update").Return(&accountEntities.Policy{}, errorsEnum.ErrNotFoundRecords)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Update(w, newRequest(http.MethodPut, []byte(validBody), params))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_Remove(t *testing.T) {
+	params := map[string]string{"companyID": uuid.New().String(), "policyID": uuid.New().String()}
+
+	t.Run("should return status no content when everything it is ok", func(t *testing.T) {
+		mockController := &policyController.Mock{}
+		mockController.On("Remove").Return(nil)
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Remove(w, newRequest(http.MethodDelete, nil, params))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+	t.Run("should return status bad request when company id is invalid", func(t *testing.T) {
+		handler := newHandlerWithMock(&policyController.Mock{})
+
+		w := httptest.NewRecorder()
+		handler.Remove(w, newRequest(http.MethodDelete, nil,
+			map[string]string{"companyID": "invalid", "policyID": uuid.New().String()}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("should return status internal server error when remove fails", func(t *testing.T) {
+		mockController := &policyController.Mock{}
+		mockController.On("Remove").Return(errors.New("test"))
+		handler := newHandlerWithMock(mockController)
+
+		w := httptest.NewRecorder()
+		handler.Remove(w, newRequest(http.MethodDelete, nil, params))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	auditService "github.com/ZupIT/horusec/development-kit/pkg/services/audit"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
 	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
 	"github.com/ZupIT/horusec/horusec-account/config/app"
@@ -76,6 +77,8 @@ func (h *Handler) Create(w netHttp.ResponseWriter, r *netHttp.Request) {
 		return
 	}
 
+	auditService.GetResource(r.Context()).SetRepositoryID(response.RepositoryID).
+		SetResourceID(response.RepositoryID.String())
 	httpUtil.StatusCreated(w, response)
 }
 
@@ -175,11 +178,16 @@ func (h *Handler) checkDefaultErrors(err error, w netHttp.ResponseWriter) {
 		return
 	}
 
-	if err == errorsEnum.ErrorInvalidLdapGroup {
+	if err == errorsEnum.ErrorInvalidLdapGroup || err == errorsEnum.ErrorCustomRoleNotFound {
 		httpUtil.StatusBadRequest(w, err)
 		return
 	}
 
+	if err == errorsEnum.ErrorRoleGrantNotAllowed {
+		httpUtil.StatusForbidden(w, err)
+		return
+	}
+
 	if err.Error() == errorsEnum.ErrorAlreadyExistingRepositoryID {
 		httpUtil.StatusConflict(w, errorsEnum.ErrorUserAlreadyInThisRepository)
 		return
@@ -269,6 +277,7 @@ func (h *Handler) Delete(w netHttp.ResponseWriter, r *netHttp.Request) {
 // @Param accountID path string true "accountID of the repository"
 // @Success 204 {object} http.Response{content=string} "NO CONTENT"
 // @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 403 {object} http.Response{content=string} "FORBIDDEN"
 // @Failure 404 {object} http.Response{content=string} "NOT FOUND"
 // @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
 // @Router /account/companies/{companyID}/repositories/{repositoryID}/roles/{accountID} [patch]
@@ -280,8 +289,9 @@ func (h *Handler) UpdateAccountRepository(w netHttp.ResponseWriter, r *netHttp.R
 		return
 	}
 
+	accountID, _ := h.getAccountData(r)
 	companyID, _ := uuid.Parse(chi.URLParam(r, "companyID"))
-	if err := h.controller.UpdateAccountRepository(companyID, accountRepository); err != nil {
+	if err := h.controller.UpdateAccountRepository(accountID, companyID, accountRepository); err != nil {
 		h.checkDefaultErrors(err, w)
 		return
 	}
@@ -323,6 +333,7 @@ func (h *Handler) getAccountRepositoryRequestID(
 // @Param repositoryID path string true "repositoryID of the repository"
 // @Success 204 {object} http.Response{content=string} "NO CONTENT"
 // @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 403 {object} http.Response{content=string} "FORBIDDEN"
 // @Failure 404 {object} http.Response{content=string} "NOT FOUND"
 // @Failure 409 {object} http.Response{content=string} "CONFLICT"
 // @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
@@ -335,12 +346,15 @@ func (h *Handler) InviteUser(w netHttp.ResponseWriter, r *netHttp.Request) {
 		return
 	}
 
-	err = h.controller.InviteUser(inviteUser)
+	accountID, _ := h.getAccountData(r)
+	err = h.controller.InviteUser(accountID, inviteUser)
 	if err != nil {
 		h.checkDefaultErrors(err, w)
 		return
 	}
 
+	auditService.GetResource(r.Context()).SetResourceID(inviteUser.Email).SetDetails(string(inviteUser.Role))
+
 	httpUtil.StatusNoContent(w)
 }
 
@@ -440,7 +454,7 @@ func (h *Handler) getAccountData(r *netHttp.Request) (uuid.UUID, []string) {
 
 	accountData := r.Context().Value(authEnums.AccountData)
 	bytes, _ := json.Marshal(accountData)
-	_ = json.Unmarshal(bytes, &response)
+	_ = json.Unmarshal(bytes, response)
 	accountID, _ := uuid.Parse(response.AccountID)
 
 	return accountID, response.Permissions
@@ -38,6 +38,7 @@ import (
 	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
 	accountEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/account"
 	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	auditService "github.com/ZupIT/horusec/development-kit/pkg/services/audit"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/broker"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/jwt"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
@@ -93,10 +94,13 @@ func TestCreate(t *testing.T) {
 		ctx.URLParams.Add("companyID", uuid.New().String())
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
 		r = r.WithContext(context.WithValue(r.Context(), authEnums.AccountData, &authGrpc.GetAccountDataResponse{AccountID: uuid.New().String(), Permissions: []string{}}))
+		auditCtx, resource := auditService.NewResourceContext(r.Context())
 
-		handler.Create(w, r)
+		handler.Create(w, r.WithContext(auditCtx))
 
 		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.NotEqual(t, uuid.Nil, resource.RepositoryID)
+		assert.Equal(t, resource.RepositoryID.String(), resource.ResourceID)
 	})
 
 	t.Run("should return internal server error when something went wrong", func(t *testing.T) {
@@ -432,22 +436,20 @@ func TestGet(t *testing.T) {
 
 func TestUpdateAccountRepository(t *testing.T) {
 	t.Run("should return status no content when everything its ok", func(t *testing.T) {
-		mockRead := &relational.MockRead{}
-		mockWrite := &relational.MockWrite{}
-		brokerMock := &broker.Mock{}
-
 		acBytes, _ := json.Marshal(roles.AccountRepository{
 			RepositoryID: uuid.New(),
 			AccountID:    uuid.New(),
 			Role:         accountEnums.Admin,
 		})
 
-		resp := &response.Response{}
-		mockWrite.On("Update").Return(resp)
-		mockRead.On("Find").Return(resp.SetData(acBytes))
-		mockRead.On("SetFilter").Return(&gorm.DB{})
+		controllerMock := &repositoriesController.Mock{}
+		controllerMock.On("UpdateAccountRepository").Return(nil)
+
+		handler := Handler{
+			controller: controllerMock,
+			useCases:   repositoriesUseCases.NewRepositoryUseCases(),
+		}
 
-		handler := NewRepositoryHandler(mockWrite, mockRead, brokerMock, &app.Config{})
 		r, _ := http.NewRequest(http.MethodPost, "api/repository", bytes.NewReader(acBytes))
 		w := httptest.NewRecorder()
 		ctx := chi.NewRouteContext()
@@ -463,6 +465,30 @@ func TestUpdateAccountRepository(t *testing.T) {
 		assert.Equal(t, http.StatusNoContent, w.Code)
 	})
 
+	t.Run("should return forbidden when role grant is not allowed", func(t *testing.T) {
+		controllerMock := &repositoriesController.Mock{}
+		controllerMock.On("UpdateAccountRepository").Return(errorsEnum.ErrorRoleGrantNotAllowed)
+
+		handler := Handler{
+			controller: controllerMock,
+			useCases:   repositoriesUseCases.NewRepositoryUseCases(),
+		}
+
+		acBytes, _ := json.Marshal(roles.AccountRepository{Role: accountEnums.Admin})
+		r, _ := http.NewRequest(http.MethodPatch, "api/repository", bytes.NewReader(acBytes))
+		w := httptest.NewRecorder()
+		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("repositoryID", uuid.New().String())
+		ctx.URLParams.Add("companyID", uuid.New().String())
+		ctx.URLParams.Add("accountID", uuid.New().String())
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+		setAuthorizationHeader(r)
+
+		handler.UpdateAccountRepository(w, r)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
 	t.Run("should return internal server error when something went wrong", func(t *testing.T) {
 		mockRead := &relational.MockRead{}
 		mockWrite := &relational.MockWrite{}
@@ -495,24 +521,20 @@ func TestUpdateAccountRepository(t *testing.T) {
 	})
 
 	t.Run("should return not found when no records were found", func(t *testing.T) {
-		mockRead := &relational.MockRead{}
-		mockWrite := &relational.MockWrite{}
-		brokerMock := &broker.Mock{}
-
 		acBytes, _ := json.Marshal(roles.AccountRepository{
 			RepositoryID: uuid.New(),
 			AccountID:    uuid.New(),
 			Role:         accountEnums.Admin,
 		})
 
-		resp := &response.Response{}
-		respWithError := &response.Response{}
-		mockWrite.On("Update").Return(resp)
-		mockRead.On("Find").Once().Return(resp.SetData(&authEntities.Account{}))
-		mockRead.On("Find").Return(respWithError.SetError(errorsEnum.ErrNotFoundRecords))
-		mockRead.On("SetFilter").Return(&gorm.DB{})
+		controllerMock := &repositoriesController.Mock{}
+		controllerMock.On("UpdateAccountRepository").Return(errorsEnum.ErrNotFoundRecords)
+
+		handler := Handler{
+			controller: controllerMock,
+			useCases:   repositoriesUseCases.NewRepositoryUseCases(),
+		}
 
-		handler := NewRepositoryHandler(mockWrite, mockRead, brokerMock, &app.Config{})
 		r, _ := http.NewRequest(http.MethodPost, "api/repository", bytes.NewReader(acBytes))
 		w := httptest.NewRecorder()
 		ctx := chi.NewRouteContext()
@@ -635,32 +657,14 @@ func TestInviteUser(t *testing.T) {
 
 	inviteUserBytes, _ := json.Marshal(inviteUser)
 
-	repository := &accountEntities.Repository{
-		CompanyID:    uuid.New(),
-		RepositoryID: uuid.New(),
-		Name:         "test",
-	}
-
-	account := &authEntities.Account{
-		AccountID: uuid.New(),
-		Email:     "test@test.com",
-		Username:  "test",
-	}
-
 	t.Run("should return status 204 when everything it is ok", func(t *testing.T) {
-		mockRead := &relational.MockRead{}
-		mockWrite := &relational.MockWrite{}
-		brokerMock := &broker.Mock{}
-
-		respRepository := &response.Response{}
-		respAccount := &response.Response{}
-		mockRead.On("Find").Once().Return(respAccount.SetData(account))
-		mockRead.On("Find").Return(respRepository.SetData(repository))
-		mockRead.On("SetFilter").Return(&gorm.DB{})
-		mockWrite.On("Create").Return(respRepository)
-		brokerMock.On("Publish").Return(nil)
+		controllerMock := &repositoriesController.Mock{}
+		controllerMock.On("InviteUser").Return(nil)
 
-		handler := NewRepositoryHandler(mockWrite, mockRead, brokerMock, &app.Config{})
+		handler := Handler{
+			controller: controllerMock,
+			useCases:   repositoriesUseCases.NewRepositoryUseCases(),
+		}
 
 		r, _ := http.NewRequest(http.MethodPost, "api/repository", bytes.NewReader(inviteUserBytes))
 		w := httptest.NewRecorder()
@@ -669,27 +673,23 @@ func TestInviteUser(t *testing.T) {
 		ctx.URLParams.Add("repositoryID", uuid.New().String())
 		ctx.URLParams.Add("companyID", uuid.New().String())
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+		auditCtx, resource := auditService.NewResourceContext(r.Context())
 
-		handler.InviteUser(w, r)
+		handler.InviteUser(w, r.WithContext(auditCtx))
 
 		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "test@test.com", resource.ResourceID)
+		assert.Equal(t, "admin", resource.Details)
 	})
 
 	t.Run("should return status 409 when user already in repository", func(t *testing.T) {
-		mockRead := &relational.MockRead{}
-		mockWrite := &relational.MockWrite{}
-		brokerMock := &broker.Mock{}
-
-		respRepository := &response.Response{}
-		respAccount := &response.Response{}
-		respWithError := &response.Response{}
-		mockRead.On("Find").Once().Return(respAccount.SetData(account))
-		mockRead.On("Find").Return(respRepository.SetData(repository))
-		mockRead.On("SetFilter").Return(&gorm.DB{})
-		mockWrite.On("Create").Return(
-			respWithError.SetError(errors.New(errorsEnum.ErrorAlreadyExistingRepositoryID)))
+		controllerMock := &repositoriesController.Mock{}
+		controllerMock.On("InviteUser").Return(errors.New(errorsEnum.ErrorAlreadyExistingRepositoryID))
 
-		handler := NewRepositoryHandler(mockWrite, mockRead, brokerMock, &app.Config{})
+		handler := Handler{
+			controller: controllerMock,
+			useCases:   repositoriesUseCases.NewRepositoryUseCases(),
+		}
 
 		r, _ := http.NewRequest(http.MethodPost, "api/repository", bytes.NewReader(inviteUserBytes))
 		w := httptest.NewRecorder()
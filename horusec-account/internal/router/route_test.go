@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/http/server"
+	"github.com/ZupIT/horusec/horusec-account/config/app"
 	"github.com/go-chi/cors"
 	"github.com/stretchr/testify/assert"
 )
@@ -34,7 +35,7 @@ func TestGetRouter(t *testing.T) {
 		router := NewRouter(server.NewServerConfig("8000", &cors.Options{}))
 		assert.NotNil(t, router)
 
-		mux := router.GetRouter(nil, nil, nil, nil, nil)
+		mux := router.GetRouter(nil, nil, nil, &app.Config{}, nil)
 		assert.NotNil(t, mux)
 	})
 }
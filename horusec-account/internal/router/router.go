@@ -17,12 +17,17 @@ package router
 
 import (
 	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/middlewares"
 	serverConfig "github.com/ZupIT/horusec/development-kit/pkg/utils/http/server"
 	"github.com/ZupIT/horusec/horusec-account/config/app"
 	company "github.com/ZupIT/horusec/horusec-account/internal/handlers/companies"
+	"github.com/ZupIT/horusec/horusec-account/internal/handlers/customrole"
 	"github.com/ZupIT/horusec/horusec-account/internal/handlers/health"
+	"github.com/ZupIT/horusec/horusec-account/internal/handlers/notification"
+	"github.com/ZupIT/horusec/horusec-account/internal/handlers/policy"
 	"github.com/ZupIT/horusec/horusec-account/internal/handlers/repositories"
 	"github.com/ZupIT/horusec/horusec-account/internal/handlers/webhook"
 	"github.com/ZupIT/horusec/horusec-account/internal/router/routes"
@@ -46,13 +51,13 @@ func NewRouter(config *serverConfig.Server) *Router {
 
 func (r *Router) GetRouter(broker brokerLib.IBroker, databaseRead SQL.InterfaceRead, databaseWrite SQL.InterfaceWrite,
 	appConfig app.IAppConfig, grpcCon *grpc.ClientConn) *chi.Mux {
-	r.setMiddleware()
+	r.setMiddleware(appConfig)
 	r.setAPIRoutes(broker, databaseRead, databaseWrite, appConfig, grpcCon)
 	return r.router
 }
 
-func (r *Router) setMiddleware() {
-	r.EnableRealIP()
+func (r *Router) setMiddleware(appConfig app.IAppConfig) {
+	r.EnableRealIP(appConfig)
 	r.EnableLogger()
 	r.EnableRecover()
 	r.EnableTimeout()
@@ -67,10 +72,11 @@ func (r *Router) setAPIRoutes(broker brokerLib.IBroker, databaseRead SQL.Interfa
 	r.RouterHealth(broker, databaseRead, databaseWrite, appConfig, grpcCon)
 	r.RouterCompany(broker, databaseRead, databaseWrite, appConfig, grpcCon)
 	r.RouterWebhook(databaseRead, databaseWrite, grpcCon)
+	r.RouterNotification(databaseRead, databaseWrite, grpcCon)
 }
 
-func (r *Router) EnableRealIP() *Router {
-	r.router.Use(middleware.RealIP)
+func (r *Router) EnableRealIP(appConfig app.IAppConfig) *Router {
+	r.router.Use(middlewares.NewRealIPMiddleware(appConfig.GetTrustedProxies()).RealIP)
 	return r
 }
 
@@ -113,16 +119,28 @@ func (r *Router) RouterCompany(broker brokerLib.IBroker, databaseRead SQL.Interf
 	databaseWrite SQL.InterfaceWrite, appConfig app.IAppConfig, grpcCon *grpc.ClientConn) *Router {
 	handler := company.NewHandler(databaseWrite, databaseRead, broker, appConfig)
 	authzMiddleware := middlewares.NewHorusAuthzMiddleware(grpcCon)
+	audit := middlewares.NewAuditMiddleware(databaseRead, databaseWrite, broker).Audit
 	r.router.Route(routes.CompanyHandler, func(router chi.Router) {
-		router.With(authzMiddleware.IsApplicationAdmin).Post("/", handler.Create)
+		router.With(authzMiddleware.IsApplicationAdmin, audit(auditEnums.CompanyCreate, "")).Post("/", handler.Create)
 		router.With(authzMiddleware.SetContextAccountID).Get("/", handler.List)
 		router.With(authzMiddleware.IsCompanyMember).Get("/{companyID}", handler.Get)
 		router.With(authzMiddleware.IsCompanyAdmin).Get("/{companyID}/roles", handler.GetAccounts)
-		router.With(authzMiddleware.IsCompanyAdmin).Patch("/{companyID}", handler.Update)
-		router.With(authzMiddleware.IsCompanyAdmin).Patch("/{companyID}/roles/{accountID}", handler.UpdateAccountCompany)
-		router.With(authzMiddleware.IsCompanyAdmin).Post("/{companyID}/roles", handler.InviteUser)
-		router.With(authzMiddleware.IsCompanyAdmin).Delete("/{companyID}", handler.Delete)
-		router.With(authzMiddleware.IsCompanyAdmin).Delete("/{companyID}/roles/{accountID}", handler.RemoveUser)
+		router.With(authzMiddleware.IsCompanyAdmin, audit(auditEnums.CompanyUpdate, "")).
+			Patch("/{companyID}", handler.Update)
+		router.With(authzMiddleware.IsCompanyAdmin, audit(auditEnums.CompanyRoleUpdate, "accountID")).
+			Patch("/{companyID}/roles/{accountID}", handler.UpdateAccountCompany)
+		router.With(authzMiddleware.IsCompanyAdmin, audit(auditEnums.CompanyRoleAdd, "")).
+			Post("/{companyID}/roles", handler.InviteUser)
+		router.With(authzMiddleware.IsCompanyAdmin, audit(auditEnums.CompanyDelete, "")).
+			Delete("/{companyID}", handler.Delete)
+		router.With(authzMiddleware.IsCompanyAdmin, audit(auditEnums.CompanyRoleRemove, "accountID")).
+			Delete("/{companyID}/roles/{accountID}", handler.RemoveUser)
+		router.With(authzMiddleware.IsCompanyAdmin).Delete(
+			"/{companyID}/roles/{accountID}/two-factor", handler.ResetTwoFactor)
+		router.Route("/{companyID}/custom-roles",
+			r.routerCompanyCustomRoles(databaseRead, databaseWrite, broker, grpcCon))
+		router.Route("/{companyID}/policies",
+			r.routerCompanyPolicies(databaseRead, databaseWrite, broker, grpcCon))
 		router.Route("/{companyID}/repositories",
 			r.routerCompanyRepositories(databaseRead, databaseWrite, broker, appConfig, grpcCon))
 	})
@@ -143,23 +161,73 @@ func (r *Router) RouterWebhook(databaseRead SQL.InterfaceRead,
 	return r
 }
 
+func (r *Router) RouterNotification(databaseRead SQL.InterfaceRead,
+	databaseWrite SQL.InterfaceWrite, grpcCon *grpc.ClientConn) *Router {
+	handler := notification.NewHandler(databaseWrite, databaseRead)
+	authzMiddleware := middlewares.NewHorusAuthzMiddleware(grpcCon)
+	r.router.Route(routes.NotificationHandler, func(router chi.Router) {
+		router.Options("/", handler.Options)
+		router.With(authzMiddleware.IsCompanyAdmin).Post("/{companyID}", handler.Create)
+		router.With(authzMiddleware.IsCompanyAdmin).Get("/{companyID}", handler.ListAll)
+		router.With(authzMiddleware.IsCompanyAdmin).Put("/{companyID}/{notificationID}", handler.Update)
+		router.With(authzMiddleware.IsCompanyAdmin).Delete("/{companyID}/{notificationID}", handler.Remove)
+	})
+	return r
+}
+
+func (r *Router) routerCompanyCustomRoles(databaseRead SQL.InterfaceRead, databaseWrite SQL.InterfaceWrite,
+	broker brokerLib.IBroker, grpcCon *grpc.ClientConn) func(router chi.Router) {
+	handler := customrole.NewHandler(databaseWrite, databaseRead)
+	authzMiddleware := middlewares.NewHorusAuthzMiddleware(grpcCon)
+	audit := middlewares.NewAuditMiddleware(databaseRead, databaseWrite, broker).Audit
+	return func(router chi.Router) {
+		router.Use(authzMiddleware.IsCompanyAdmin)
+		router.Get("/", handler.ListAll)
+		router.With(audit(auditEnums.CustomRoleCreate, "")).Post("/", handler.Create)
+		router.With(audit(auditEnums.CustomRoleUpdate, "customRoleID")).Put("/{customRoleID}", handler.Update)
+		router.With(audit(auditEnums.CustomRoleDelete, "customRoleID")).Delete("/{customRoleID}", handler.Remove)
+	}
+}
+
+func (r *Router) routerCompanyPolicies(databaseRead SQL.InterfaceRead, databaseWrite SQL.InterfaceWrite,
+	broker brokerLib.IBroker, grpcCon *grpc.ClientConn) func(router chi.Router) {
+	handler := policy.NewHandler(databaseWrite, databaseRead)
+	authzMiddleware := middlewares.NewHorusAuthzMiddleware(grpcCon)
+	audit := middlewares.NewAuditMiddleware(databaseRead, databaseWrite, broker).Audit
+	return func(router chi.Router) {
+		router.Use(authzMiddleware.IsCompanyAdmin)
+		router.Get("/", handler.ListAll)
+		router.With(audit(auditEnums.PolicyCreate, "")).Post("/", handler.Create)
+		router.With(audit(auditEnums.PolicyUpdate, "policyID")).Put("/{policyID}", handler.Update)
+		router.With(audit(auditEnums.PolicyDelete, "policyID")).Delete("/{policyID}", handler.Remove)
+	}
+}
+
 func (r *Router) routerCompanyRepositories(databaseRead SQL.InterfaceRead,
 	databaseWrite SQL.InterfaceWrite, broker brokerLib.IBroker,
 	appConfig app.IAppConfig, grpcCon *grpc.ClientConn) func(router chi.Router) {
 	handler := repositories.NewRepositoryHandler(databaseWrite, databaseRead, broker, appConfig)
-	authzMiddleware := middlewares.NewHorusAuthzMiddleware(grpcCon)
+	authzMiddleware := middlewares.NewHorusAuthzMiddlewareWithScope(grpcCon, authEnums.ManageRepositories)
+	audit := middlewares.NewAuditMiddleware(databaseRead, databaseWrite, broker).Audit
 	return func(router chi.Router) {
 		router.Use(authzMiddleware.IsCompanyMember)
 		router.With(authzMiddleware.SetContextAccountID).Get("/", handler.List)
-		router.With(authzMiddleware.IsCompanyAdmin).Post("/", handler.Create)
+		router.With(authzMiddleware.IsCompanyAdmin, audit(auditEnums.RepositoryCreate, "")).Post("/", handler.Create)
 		router.With(authzMiddleware.IsRepositoryMember).Get("/{repositoryID}", handler.Get)
-		router.With(authzMiddleware.IsRepositoryAdmin).Patch("/{repositoryID}", handler.Update)
-		router.With(authzMiddleware.IsRepositoryAdmin).Delete("/{repositoryID}", handler.Delete)
-		router.With(authzMiddleware.IsRepositoryAdmin).Patch(
-			"/{repositoryID}/roles/{accountID}", handler.UpdateAccountRepository)
-		router.With(authzMiddleware.IsRepositoryAdmin).Post("/{repositoryID}/roles", handler.InviteUser)
-		router.With(authzMiddleware.IsRepositoryAdmin).Get("/{repositoryID}/roles", handler.GetAccounts)
-		router.With(authzMiddleware.IsRepositoryAdmin).Delete("/{repositoryID}/roles/{accountID}", handler.RemoveUser)
+		router.With(authzMiddleware.HasPermission(authEnums.ManageRepository), audit(auditEnums.RepositoryUpdate, "")).
+			Patch("/{repositoryID}", handler.Update)
+		router.With(authzMiddleware.HasPermission(authEnums.ManageRepository), audit(auditEnums.RepositoryDelete, "")).
+			Delete("/{repositoryID}", handler.Delete)
+		router.With(authzMiddleware.HasPermission(authEnums.ManageRepositoryMembers),
+			audit(auditEnums.RepositoryRoleUpdate, "accountID")).
+			Patch("/{repositoryID}/roles/{accountID}", handler.UpdateAccountRepository)
+		router.With(authzMiddleware.HasPermission(authEnums.ManageRepositoryMembers),
+			audit(auditEnums.RepositoryRoleAdd, "")).Post("/{repositoryID}/roles", handler.InviteUser)
+		router.With(authzMiddleware.HasPermission(authEnums.ManageRepositoryMembers)).
+			Get("/{repositoryID}/roles", handler.GetAccounts)
+		router.With(authzMiddleware.HasPermission(authEnums.ManageRepositoryMembers),
+			audit(auditEnums.RepositoryRoleRemove, "accountID")).
+			Delete("/{repositoryID}/roles/{accountID}", handler.RemoveUser)
 	}
 }
 
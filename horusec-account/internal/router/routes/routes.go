@@ -15,7 +15,8 @@
 package routes
 
 const (
-	HealthHandler  = "/account/health"
-	WebhookHandler = "/account/webhook"
-	CompanyHandler = "/account/companies"
+	HealthHandler       = "/account/health"
+	WebhookHandler      = "/account/webhook"
+	CompanyHandler      = "/account/companies"
+	NotificationHandler = "/account/notifications"
 )
@@ -0,0 +1,43 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+)
+
+type ICustomRole interface {
+	NewCustomRoleFromReadCloser(body io.ReadCloser) (*roles.CustomRole, error)
+}
+
+type CustomRole struct {
+}
+
+func NewCustomRoleUseCases() ICustomRole {
+	return &CustomRole{}
+}
+
+func (c *CustomRole) NewCustomRoleFromReadCloser(body io.ReadCloser) (customRole *roles.CustomRole, err error) {
+	err = json.NewDecoder(body).Decode(&customRole)
+	_ = body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return customRole, customRole.Validate()
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomRole_NewCustomRoleFromReadCloser(t *testing.T) {
+	t.Run("should parse read closer to custom role with success", func(t *testing.T) {
+		readCloser := ioutil.NopCloser(strings.NewReader(
+			`{"name": "triager", "permissions": ["vulnerabilities:manage"]}`))
+
+		useCases := NewCustomRoleUseCases()
+		result, err := useCases.NewCustomRoleFromReadCloser(readCloser)
+		assert.NoError(t, err)
+		assert.Equal(t, "triager", result.Name)
+	})
+	t.Run("should return error when permission is invalid", func(t *testing.T) {
+		readCloser := ioutil.NopCloser(strings.NewReader(`{"name": "triager", "permissions": ["unknown"]}`))
+
+		useCases := NewCustomRoleUseCases()
+		_, err := useCases.NewCustomRoleFromReadCloser(readCloser)
+		assert.Error(t, err)
+	})
+	t.Run("should parse read closer to custom role with error", func(t *testing.T) {
+		readCloser := ioutil.NopCloser(strings.NewReader("wrong data type"))
+
+		useCases := NewCustomRoleUseCases()
+		result, err := useCases.NewCustomRoleFromReadCloser(readCloser)
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
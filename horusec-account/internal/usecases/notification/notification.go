@@ -0,0 +1,44 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/notification"
+)
+
+type INotification interface {
+	NewNotificationFromReadCloser(body io.ReadCloser) (*notification.Notification, error)
+}
+
+type Notification struct {
+}
+
+func NewNotificationUseCases() INotification {
+	return &Notification{}
+}
+
+func (n *Notification) NewNotificationFromReadCloser(
+	body io.ReadCloser) (notificationData *notification.Notification, err error) {
+	err = json.NewDecoder(body).Decode(&notificationData)
+	_ = body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return notificationData, nil
+}
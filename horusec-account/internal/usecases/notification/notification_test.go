@@ -0,0 +1,49 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/notification"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotification_NewNotificationFromReadCloser(t *testing.T) {
+	t.Run("should parse read closer to notification with success", func(t *testing.T) {
+		n := &notification.Notification{
+			URL:     "http://example.com",
+			Channel: notificationEnum.Slack,
+			Events:  []string{notificationEnum.AnalysisFinished.ToString()},
+		}
+		readCloser := ioutil.NopCloser(strings.NewReader(string(n.ToBytes())))
+
+		useCases := NewNotificationUseCases()
+		result, err := useCases.NewNotificationFromReadCloser(readCloser)
+		assert.NoError(t, err)
+		assert.Equal(t, notificationEnum.Slack, result.Channel)
+	})
+	t.Run("should parse read closer to notification with error", func(t *testing.T) {
+		readCloser := ioutil.NopCloser(strings.NewReader("wrong data type"))
+
+		useCases := NewNotificationUseCases()
+		result, err := useCases.NewNotificationFromReadCloser(readCloser)
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_NewPolicyFromReadCloser(t *testing.T) {
+	t.Run("should parse read closer to policy with success", func(t *testing.T) {
+		readCloser := ioutil.NopCloser(strings.NewReader(`{"name": "no high", "maxHighVulnerabilities": 0,
+			"requiredTools": ["GoSec"], "requiredLanguages": ["Go"], "maxRiskAcceptAgeInDays": 90}`))
+
+		useCases := NewPolicyUseCases()
+		result, err := useCases.NewPolicyFromReadCloser(readCloser)
+		assert.NoError(t, err)
+		assert.Equal(t, "no high", result.Name)
+		assert.Equal(t, 0, *result.MaxHighVulnerabilities)
+		assert.Equal(t, 90, result.MaxRiskAcceptAgeInDays)
+	})
+	t.Run("should return error when tool is invalid", func(t *testing.T) {
+		readCloser := ioutil.NopCloser(strings.NewReader(`{"name": "test", "requiredTools": ["unknown"]}`))
+
+		useCases := NewPolicyUseCases()
+		_, err := useCases.NewPolicyFromReadCloser(readCloser)
+		assert.Error(t, err)
+	})
+	t.Run("should parse read closer to policy with error", func(t *testing.T) {
+		readCloser := ioutil.NopCloser(strings.NewReader("wrong data type"))
+
+		useCases := NewPolicyUseCases()
+		result, err := useCases.NewPolicyFromReadCloser(readCloser)
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/adapter"
 	serverUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http/server"
+	"github.com/ZupIT/horusec/horusec-analytic/config/app"
 	"github.com/ZupIT/horusec/horusec-analytic/config/cors"
 	"github.com/ZupIT/horusec/horusec-analytic/config/swagger"
 	"github.com/ZupIT/horusec/horusec-analytic/internal/router"
@@ -40,9 +41,10 @@ import (
 // @name X-Horusec-Authorization
 func main() {
 	postgresRead := adapter.NewRepositoryRead()
+	appConfig := app.SetupApp()
 
 	server := serverUtil.NewServerConfig("8005", cors.NewCorsConfig()).Timeout(10)
-	chiRouter := router.NewRouter(server).GetRouter(postgresRead, grpc.SetupGrpcConnection())
+	chiRouter := router.NewRouter(server).GetRouter(postgresRead, appConfig, grpc.SetupGrpcConnection())
 
 	log.Println("service running on port", server.GetPort())
 	swagger.SetupSwagger(chiRouter, "8005")
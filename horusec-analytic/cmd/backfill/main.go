@@ -0,0 +1,47 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/adapter"
+	"github.com/ZupIT/horusec/horusec-analytic/internal/controllers/snapshot"
+)
+
+// Refreshes the daily snapshots read by the dashboard with the analysis saved before they existed
+func main() {
+	initialDate := flag.String("initial-date", "", "refresh only the analysis finished since this date (YYYY-MM-DD)")
+	flag.Parse()
+
+	date := time.Time{}
+	if *initialDate != "" {
+		parsed, err := time.Parse("2006-01-02", *initialDate)
+		if err != nil {
+			log.Fatal(err)
+		}
+		date = parsed
+	}
+
+	controller := snapshot.NewSnapshotController(adapter.NewRepositoryRead(), adapter.NewRepositoryWrite())
+	total, err := controller.Backfill(date)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("daily snapshots refreshed with", total, "analysis")
+}
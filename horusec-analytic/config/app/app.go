@@ -0,0 +1,116 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	dashboardEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/confidence"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
+)
+
+const (
+	ScoreWeightHighEnv             = "HORUSEC_SCORE_WEIGHT_HIGH"
+	ScoreWeightMediumEnv           = "HORUSEC_SCORE_WEIGHT_MEDIUM"
+	ScoreWeightLowEnv              = "HORUSEC_SCORE_WEIGHT_LOW"
+	ScoreWeightAuditEnv            = "HORUSEC_SCORE_WEIGHT_AUDIT"
+	ScoreWeightInfoEnv             = "HORUSEC_SCORE_WEIGHT_INFO"
+	ScoreWeightNoSecEnv            = "HORUSEC_SCORE_WEIGHT_NOSEC"
+	ScoreConfidenceHighEnv         = "HORUSEC_SCORE_CONFIDENCE_HIGH"
+	ScoreConfidenceMediumEnv       = "HORUSEC_SCORE_CONFIDENCE_MEDIUM"
+	ScoreConfidenceLowEnv          = "HORUSEC_SCORE_CONFIDENCE_LOW"
+	ScoreRiskAcceptedWeightEnv     = "HORUSEC_SCORE_RISK_ACCEPTED_WEIGHT"
+	ScoreAgeDaysToDoubleEnv        = "HORUSEC_SCORE_AGE_DAYS_TO_DOUBLE"
+	ScoreMaxAgeMultiplierEnv       = "HORUSEC_SCORE_MAX_AGE_MULTIPLIER"
+	ScoreScaleEnv                  = "HORUSEC_SCORE_SCALE"
+	DailySnapshotsEnv              = "HORUSEC_DASHBOARD_DAILY_SNAPSHOTS"
+	GraphqlMaxDepthEnv             = "HORUSEC_GRAPHQL_MAX_DEPTH"
+	GraphqlMaxComplexityEnv        = "HORUSEC_GRAPHQL_MAX_COMPLEXITY"
+	DefaultScoreWeightHigh         = 10
+	DefaultScoreWeightMedium       = 5
+	DefaultScoreWeightLow          = 2
+	DefaultScoreWeightAudit        = 1
+	DefaultScoreConfidenceHigh     = 100
+	DefaultScoreConfidenceMedium   = 70
+	DefaultScoreConfidenceLow      = 40
+	DefaultScoreRiskAcceptedWeight = 25
+	DefaultScoreAgeDaysToDouble    = 90
+	DefaultScoreMaxAgeMultiplier   = 3
+	DefaultScoreScale              = 100
+	DefaultGraphqlMaxDepth         = 10
+	DefaultGraphqlMaxComplexity    = 5000
+)
+
+type Config struct {
+	ScoreModel           *dashboardEntities.ScoreModel
+	DailySnapshots       bool
+	GraphqlMaxDepth      int
+	GraphqlMaxComplexity int
+}
+
+type IAppConfig interface {
+	GetScoreModel() *dashboardEntities.ScoreModel
+	IsDailySnapshotsEnabled() bool
+	GetGraphqlMaxDepth() int
+	GetGraphqlMaxComplexity() int
+}
+
+func SetupApp() IAppConfig {
+	return &Config{
+		ScoreModel: &dashboardEntities.ScoreModel{
+			SeverityWeights: map[severity.Severity]int{
+				severity.High:   env.GetEnvOrDefaultInt(ScoreWeightHighEnv, DefaultScoreWeightHigh),
+				severity.Medium: env.GetEnvOrDefaultInt(ScoreWeightMediumEnv, DefaultScoreWeightMedium),
+				severity.Low:    env.GetEnvOrDefaultInt(ScoreWeightLowEnv, DefaultScoreWeightLow),
+				severity.Audit:  env.GetEnvOrDefaultInt(ScoreWeightAuditEnv, DefaultScoreWeightAudit),
+				severity.Info:   env.GetEnvOrDefaultInt(ScoreWeightInfoEnv, 0),
+				severity.NoSec:  env.GetEnvOrDefaultInt(ScoreWeightNoSecEnv, 0),
+			},
+			ConfidenceWeights: map[string]int{
+				confidence.High.ToString():   env.GetEnvOrDefaultInt(ScoreConfidenceHighEnv, DefaultScoreConfidenceHigh),
+				confidence.Medium.ToString(): env.GetEnvOrDefaultInt(ScoreConfidenceMediumEnv, DefaultScoreConfidenceMedium),
+				confidence.Low.ToString():    env.GetEnvOrDefaultInt(ScoreConfidenceLowEnv, DefaultScoreConfidenceLow),
+			},
+			RiskAcceptedWeight: env.GetEnvOrDefaultInt(ScoreRiskAcceptedWeightEnv, DefaultScoreRiskAcceptedWeight),
+			AgeDaysToDouble:    env.GetEnvOrDefaultInt(ScoreAgeDaysToDoubleEnv, DefaultScoreAgeDaysToDouble),
+			MaxAgeMultiplier:   env.GetEnvOrDefaultInt(ScoreMaxAgeMultiplierEnv, DefaultScoreMaxAgeMultiplier),
+			Scale:              env.GetEnvOrDefaultInt(ScoreScaleEnv, DefaultScoreScale),
+		},
+		DailySnapshots:       env.GetEnvOrDefaultBool(DailySnapshotsEnv, true),
+		GraphqlMaxDepth:      env.GetEnvOrDefaultInt(GraphqlMaxDepthEnv, DefaultGraphqlMaxDepth),
+		GraphqlMaxComplexity: env.GetEnvOrDefaultInt(GraphqlMaxComplexityEnv, DefaultGraphqlMaxComplexity),
+	}
+}
+
+func (a *Config) GetScoreModel() *dashboardEntities.ScoreModel {
+	return a.ScoreModel
+}
+
+// IsDailySnapshotsEnabled returns if the dashboard totals are read from the daily snapshots, with the latest state of
+// each repository in the period, instead of the distinct vulnerabilities of all analysis in the period
+func (a *Config) IsDailySnapshotsEnabled() bool {
+	return a.DailySnapshots
+}
+
+// GetGraphqlMaxDepth returns how many levels of nested fields a graphql query can have, 0 disables the limit
+func (a *Config) GetGraphqlMaxDepth() int {
+	return a.GraphqlMaxDepth
+}
+
+// GetGraphqlMaxComplexity returns the max cost of a graphql query, each field costs one and the fields inside a page
+// cost once for each item of the page, 0 disables the limit
+func (a *Config) GetGraphqlMaxComplexity() int {
+	return a.GraphqlMaxComplexity
+}
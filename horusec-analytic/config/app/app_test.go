@@ -0,0 +1,86 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupApp(t *testing.T) {
+	t.Run("should successfully create an application configuration struct", func(t *testing.T) {
+		assert.NotNil(t, SetupApp())
+	})
+}
+
+func TestIsDailySnapshotsEnabled(t *testing.T) {
+	t.Run("should return enabled by default", func(t *testing.T) {
+		assert.True(t, SetupApp().IsDailySnapshotsEnabled())
+	})
+
+	t.Run("should return disabled from env", func(t *testing.T) {
+		_ = os.Setenv(DailySnapshotsEnv, "false")
+		defer os.Unsetenv(DailySnapshotsEnv)
+
+		assert.False(t, SetupApp().IsDailySnapshotsEnabled())
+	})
+}
+
+func TestGetScoreModel(t *testing.T) {
+	t.Run("should return the default score model", func(t *testing.T) {
+		model := SetupApp().GetScoreModel()
+
+		assert.Equal(t, DefaultScoreWeightHigh, model.SeverityWeights[severity.High])
+		assert.Equal(t, 0, model.SeverityWeights[severity.Info])
+		assert.Equal(t, DefaultScoreConfidenceLow, model.ConfidenceWeights["LOW"])
+		assert.Equal(t, DefaultScoreScale, model.Scale)
+	})
+
+	t.Run("should return the score model from env", func(t *testing.T) {
+		_ = os.Setenv(ScoreWeightHighEnv, "20")
+		_ = os.Setenv(ScoreAgeDaysToDoubleEnv, "0")
+		defer os.Unsetenv(ScoreWeightHighEnv)
+		defer os.Unsetenv(ScoreAgeDaysToDoubleEnv)
+
+		model := SetupApp().GetScoreModel()
+
+		assert.Equal(t, 20, model.SeverityWeights[severity.High])
+		assert.Equal(t, 0, model.AgeDaysToDouble)
+	})
+}
+
+func TestGetGraphqlLimits(t *testing.T) {
+	t.Run("should return the default graphql limits", func(t *testing.T) {
+		config := SetupApp()
+
+		assert.Equal(t, DefaultGraphqlMaxDepth, config.GetGraphqlMaxDepth())
+		assert.Equal(t, DefaultGraphqlMaxComplexity, config.GetGraphqlMaxComplexity())
+	})
+
+	t.Run("should return the graphql limits from env", func(t *testing.T) {
+		_ = os.Setenv(GraphqlMaxDepthEnv, "5")
+		_ = os.Setenv(GraphqlMaxComplexityEnv, "0")
+		defer os.Unsetenv(GraphqlMaxDepthEnv)
+		defer os.Unsetenv(GraphqlMaxComplexityEnv)
+
+		config := SetupApp()
+
+		assert.Equal(t, 5, config.GetGraphqlMaxDepth())
+		assert.Equal(t, 0, config.GetGraphqlMaxComplexity())
+	})
+}
@@ -19,9 +19,11 @@ import (
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	analysisRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/analysis"
+	snapshotRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/snapshot"
 	dashboardEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-analytic/config/app"
 	dashboardUseCases "github.com/ZupIT/horusec/horusec-analytic/internal/usecases/dashboard"
 	"github.com/google/uuid"
 	"github.com/graphql-go/graphql"
@@ -43,20 +45,47 @@ type IController interface {
 		initialDate, finalDate time.Time) ([]dashboardEntities.VulnByTime, error)
 	GetVulnByRepository(companyID, repositoryID uuid.UUID,
 		initialDate, finalDate time.Time) ([]dashboardEntities.VulnByRepository, error)
+	GetVulnBySecurityTool(companyID, repositoryID uuid.UUID,
+		initialDate, finalDate time.Time) ([]dashboardEntities.VulnBySecurityTool, error)
+	GetVulnByRule(companyID, repositoryID uuid.UUID,
+		initialDate, finalDate time.Time) ([]dashboardEntities.VulnByRule, error)
+	GetVulnLifecycle(companyID, repositoryID uuid.UUID,
+		initialDate, finalDate time.Time) ([]dashboardEntities.VulnLifecycle, error)
+	GetTimeToFix(companyID, repositoryID uuid.UUID,
+		initialDate, finalDate time.Time) ([]dashboardEntities.TimeToFix, error)
+	GetVulnAging(companyID, repositoryID uuid.UUID,
+		initialDate, finalDate time.Time) ([]dashboardEntities.VulnAging, error)
+	GetScoreHistory(companyID, repositoryID uuid.UUID,
+		initialDate, finalDate time.Time) ([]dashboardEntities.SecurityScore, error)
+	GetRepositoryScores(companyID, repositoryID uuid.UUID,
+		initialDate, finalDate time.Time) ([]dashboardEntities.RepositoryScore, error)
 }
 
 type Controller struct {
 	useCases   dashboardUseCases.IUseCases
 	repository analysisRepository.IAnalysisRepository
+	totals     snapshotRepository.ITotalsRepository
+	appConfig  app.IAppConfig
 }
 
-func NewDashboardController(postgresRead relational.InterfaceRead) IController {
+func NewDashboardController(postgresRead relational.InterfaceRead, appConfig app.IAppConfig) IController {
 	return &Controller{
 		useCases:   dashboardUseCases.NewDashboardUseCases(),
 		repository: analysisRepository.NewAnalysisRepository(postgresRead, nil),
+		totals:     newTotalsRepository(postgresRead, appConfig),
+		appConfig:  appConfig,
 	}
 }
 
+func newTotalsRepository(postgresRead relational.InterfaceRead,
+	appConfig app.IAppConfig) snapshotRepository.ITotalsRepository {
+	if appConfig.IsDailySnapshotsEnabled() {
+		return snapshotRepository.NewSnapshotRepository(postgresRead, nil)
+	}
+
+	return analysisRepository.NewAnalysisRepository(postgresRead, nil)
+}
+
 func (c *Controller) GetVulnerabilitiesByAuthor(query string, page, size int) (*graphql.Result, error) {
 	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: graphql.NewObject(c.createQueryType(page, size))})
 	logger.LogError(errors.ErrorGraphqlSchema, err)
@@ -112,7 +141,7 @@ func (c *Controller) GetTotalDevelopers(companyID, repositoryID uuid.UUID, initi
 
 func (c *Controller) GetTotalRepositories(companyID, repositoryID uuid.UUID, initialDate,
 	finalDate time.Time) (int, error) {
-	result, err := c.repository.GetRepositoryCount(companyID, repositoryID, initialDate, finalDate)
+	result, err := c.totals.GetRepositoryCount(companyID, repositoryID, initialDate, finalDate)
 
 	logger.LogError("{GetTotalRepositories} something went wrong ->", err)
 
@@ -121,7 +150,7 @@ func (c *Controller) GetTotalRepositories(companyID, repositoryID uuid.UUID, ini
 
 func (c *Controller) GetVulnBySeverity(companyID, repositoryID uuid.UUID, initialDate,
 	finalDate time.Time) ([]dashboardEntities.VulnBySeverity, error) {
-	result, err := c.repository.GetVulnBySeverity(companyID, repositoryID, initialDate, finalDate)
+	result, err := c.totals.GetVulnBySeverity(companyID, repositoryID, initialDate, finalDate)
 
 	logger.LogError("{GetVulnBySeverity} something went wrong ->", err)
 
@@ -139,7 +168,7 @@ func (c *Controller) GetVulnByDeveloper(companyID, repositoryID uuid.UUID,
 
 func (c *Controller) GetVulnByLanguage(companyID, repositoryID uuid.UUID,
 	initialDate, finalDate time.Time) ([]dashboardEntities.VulnByLanguage, error) {
-	result, err := c.repository.GetVulnByLanguage(companyID, repositoryID, initialDate, finalDate)
+	result, err := c.totals.GetVulnByLanguage(companyID, repositoryID, initialDate, finalDate)
 
 	logger.LogError("{GetVulnByLanguage} something went wrong ->", err)
 
@@ -148,7 +177,7 @@ func (c *Controller) GetVulnByLanguage(companyID, repositoryID uuid.UUID,
 
 func (c *Controller) GetVulnByTime(companyID, repositoryID uuid.UUID,
 	initialDate, finalDate time.Time) ([]dashboardEntities.VulnByTime, error) {
-	result, err := c.repository.GetVulnByTime(companyID, repositoryID, initialDate, finalDate)
+	result, err := c.totals.GetVulnByTime(companyID, repositoryID, initialDate, finalDate)
 
 	logger.LogError("{GetVulnByTime} something went wrong ->", err)
 
@@ -157,9 +186,130 @@ func (c *Controller) GetVulnByTime(companyID, repositoryID uuid.UUID,
 
 func (c *Controller) GetVulnByRepository(companyID, repositoryID uuid.UUID,
 	initialDate, finalDate time.Time) ([]dashboardEntities.VulnByRepository, error) {
-	result, err := c.repository.GetVulnByRepository(companyID, repositoryID, initialDate, finalDate)
+	result, err := c.totals.GetVulnByRepository(companyID, repositoryID, initialDate, finalDate)
 
 	logger.LogError("{GetVulnByRepository} something went wrong ->", err)
 
 	return result, err
 }
+
+func (c *Controller) GetVulnBySecurityTool(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.VulnBySecurityTool, error) {
+	result, err := c.repository.GetVulnBySecurityTool(companyID, repositoryID, initialDate, finalDate)
+
+	logger.LogError("{GetVulnBySecurityTool} something went wrong ->", err)
+
+	return result, err
+}
+
+func (c *Controller) GetVulnByRule(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.VulnByRule, error) {
+	result, err := c.repository.GetVulnByRule(companyID, repositoryID, initialDate, finalDate)
+
+	logger.LogError("{GetVulnByRule} something went wrong ->", err)
+
+	return result, err
+}
+
+func (c *Controller) GetVulnLifecycle(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.VulnLifecycle, error) {
+	result, err := c.getVulnLifecycles(companyID, repositoryID, initialDate, finalDate)
+
+	logger.LogError("{GetVulnLifecycle} something went wrong ->", err)
+
+	return result, err
+}
+
+func (c *Controller) GetTimeToFix(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.TimeToFix, error) {
+	lifecycles, err := c.getVulnLifecycles(companyID, repositoryID, initialDate, finalDate)
+	if err != nil {
+		logger.LogError("{GetTimeToFix} something went wrong ->", err)
+		return nil, err
+	}
+
+	return dashboardEntities.NewTimeToFixBySeverity(lifecycles), nil
+}
+
+func (c *Controller) GetVulnAging(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.VulnAging, error) {
+	lifecycles, err := c.getVulnLifecycles(companyID, repositoryID, initialDate, finalDate)
+	if err != nil {
+		logger.LogError("{GetVulnAging} something went wrong ->", err)
+		return nil, err
+	}
+
+	return dashboardEntities.NewVulnAging(lifecycles, time.Now()), nil
+}
+
+// GetScoreHistory returns the security score at the start of each month of the period and at the final date
+func (c *Controller) GetScoreHistory(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.SecurityScore, error) {
+	finalDate = c.getScoreFinalDate(finalDate)
+	lifecycles, analysisDates, err := c.getScoreLifecycles(companyID, repositoryID, finalDate)
+	if err != nil {
+		logger.LogError("{GetScoreHistory} something went wrong ->", err)
+		return nil, err
+	}
+
+	if initialDate.IsZero() && len(analysisDates) > 0 {
+		initialDate = analysisDates[0].FinishedAt
+	}
+
+	return dashboardEntities.NewScoreHistory(c.appConfig.GetScoreModel(), lifecycles, analysisDates,
+		dashboardEntities.GetScoreTimes(initialDate, finalDate)), nil
+}
+
+// GetRepositoryScores returns the ranking of the repositories by the security score at the final date
+func (c *Controller) GetRepositoryScores(companyID, repositoryID uuid.UUID,
+	_, finalDate time.Time) ([]dashboardEntities.RepositoryScore, error) {
+	finalDate = c.getScoreFinalDate(finalDate)
+	lifecycles, analysisDates, err := c.getScoreLifecycles(companyID, repositoryID, finalDate)
+	if err != nil {
+		logger.LogError("{GetRepositoryScores} something went wrong ->", err)
+		return nil, err
+	}
+
+	return dashboardEntities.NewRepositoryScores(c.appConfig.GetScoreModel(), lifecycles, analysisDates,
+		finalDate), nil
+}
+
+func (c *Controller) getScoreFinalDate(finalDate time.Time) time.Time {
+	if finalDate.IsZero() {
+		return time.Now()
+	}
+
+	return finalDate
+}
+
+// getScoreLifecycles reads all analysis until the final date, the vulnerabilities open in the period can be found
+// before it
+func (c *Controller) getScoreLifecycles(companyID, repositoryID uuid.UUID,
+	finalDate time.Time) ([]dashboardEntities.VulnLifecycle, []dashboardEntities.AnalysisDate, error) {
+	occurrences, err := c.repository.GetVulnOccurrences(companyID, repositoryID, time.Time{}, finalDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	analysisDates, err := c.repository.GetAnalysisDates(companyID, repositoryID, time.Time{}, finalDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dashboardEntities.NewVulnLifecycles(occurrences, analysisDates), analysisDates, nil
+}
+
+func (c *Controller) getVulnLifecycles(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.VulnLifecycle, error) {
+	occurrences, err := c.repository.GetVulnOccurrences(companyID, repositoryID, initialDate, finalDate)
+	if err != nil {
+		return nil, err
+	}
+
+	analysisDates, err := c.repository.GetAnalysisDates(companyID, repositoryID, initialDate, finalDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return dashboardEntities.NewVulnLifecycles(occurrences, analysisDates), nil
+}
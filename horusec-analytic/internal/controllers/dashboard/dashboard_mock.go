@@ -72,3 +72,45 @@ func (m *Mock) GetVulnByRepository(companyID, repositoryID uuid.UUID,
 	args := m.MethodCalled("GetVulnByRepository")
 	return args.Get(0).([]dashboardEntities.VulnByRepository), mockUtils.ReturnNilOrError(args, 1)
 }
+
+func (m *Mock) GetVulnBySecurityTool(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.VulnBySecurityTool, error) {
+	args := m.MethodCalled("GetVulnBySecurityTool")
+	return args.Get(0).([]dashboardEntities.VulnBySecurityTool), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetVulnByRule(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.VulnByRule, error) {
+	args := m.MethodCalled("GetVulnByRule")
+	return args.Get(0).([]dashboardEntities.VulnByRule), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetVulnLifecycle(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.VulnLifecycle, error) {
+	args := m.MethodCalled("GetVulnLifecycle")
+	return args.Get(0).([]dashboardEntities.VulnLifecycle), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetTimeToFix(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.TimeToFix, error) {
+	args := m.MethodCalled("GetTimeToFix")
+	return args.Get(0).([]dashboardEntities.TimeToFix), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetVulnAging(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.VulnAging, error) {
+	args := m.MethodCalled("GetVulnAging")
+	return args.Get(0).([]dashboardEntities.VulnAging), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetScoreHistory(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.SecurityScore, error) {
+	args := m.MethodCalled("GetScoreHistory")
+	return args.Get(0).([]dashboardEntities.SecurityScore), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetRepositoryScores(companyID, repositoryID uuid.UUID,
+	initialDate, finalDate time.Time) ([]dashboardEntities.RepositoryScore, error) {
+	args := m.MethodCalled("GetRepositoryScores")
+	return args.Get(0).([]dashboardEntities.RepositoryScore), mockUtils.ReturnNilOrError(args, 1)
+}
@@ -15,11 +15,14 @@
 package dashboard
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/analysis"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/snapshot"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
+	"github.com/ZupIT/horusec/horusec-analytic/config/app"
 	dashboardUseCases "github.com/ZupIT/horusec/horusec-analytic/internal/usecases/dashboard"
 	"github.com/google/uuid"
 	"github.com/graphql-go/graphql"
@@ -132,13 +135,13 @@ func TestGetTotalDevelopers(t *testing.T) {
 
 func TestGetTotalRepositories(t *testing.T) {
 	t.Run("Should success get data with no errors", func(t *testing.T) {
-		analysisMock := &analysis.Mock{}
+		snapshotMock := &snapshot.Mock{}
 
-		analysisMock.On("GetRepositoryCount").Return(3, nil)
+		snapshotMock.On("GetRepositoryCount").Return(3, nil)
 
 		controller := Controller{
-			useCases:   dashboardUseCases.NewDashboardUseCases(),
-			repository: analysisMock,
+			useCases: dashboardUseCases.NewDashboardUseCases(),
+			totals:   snapshotMock,
 		}
 
 		result, err := controller.GetTotalRepositories(uuid.Nil, uuid.Nil, time.Now(), time.Now())
@@ -151,13 +154,13 @@ func TestGetTotalRepositories(t *testing.T) {
 
 func TestGetVulnBySeverity(t *testing.T) {
 	t.Run("Should success get data with no errors", func(t *testing.T) {
-		analysisMock := &analysis.Mock{}
+		snapshotMock := &snapshot.Mock{}
 
-		analysisMock.On("GetVulnBySeverity").Return([]dashboard.VulnBySeverity{{Severity: "LOW"}}, nil)
+		snapshotMock.On("GetVulnBySeverity").Return([]dashboard.VulnBySeverity{{Severity: "LOW"}}, nil)
 
 		controller := Controller{
-			useCases:   dashboardUseCases.NewDashboardUseCases(),
-			repository: analysisMock,
+			useCases: dashboardUseCases.NewDashboardUseCases(),
+			totals:   snapshotMock,
 		}
 
 		result, err := controller.GetVulnBySeverity(uuid.Nil, uuid.Nil, time.Now(), time.Now())
@@ -189,13 +192,13 @@ func TestGetVulnByDeveloper(t *testing.T) {
 
 func TestGetVulnByLanguage(t *testing.T) {
 	t.Run("Should success get data with no errors", func(t *testing.T) {
-		analysisMock := &analysis.Mock{}
+		snapshotMock := &snapshot.Mock{}
 
-		analysisMock.On("GetVulnByLanguage").Return([]dashboard.VulnByLanguage{{Language: "test"}}, nil)
+		snapshotMock.On("GetVulnByLanguage").Return([]dashboard.VulnByLanguage{{Language: "test"}}, nil)
 
 		controller := Controller{
-			useCases:   dashboardUseCases.NewDashboardUseCases(),
-			repository: analysisMock,
+			useCases: dashboardUseCases.NewDashboardUseCases(),
+			totals:   snapshotMock,
 		}
 
 		result, err := controller.GetVulnByLanguage(uuid.Nil, uuid.Nil, time.Now(), time.Now())
@@ -208,13 +211,13 @@ func TestGetVulnByLanguage(t *testing.T) {
 
 func TestGetVulnByTime(t *testing.T) {
 	t.Run("Should success get data with no errors", func(t *testing.T) {
-		analysisMock := &analysis.Mock{}
+		snapshotMock := &snapshot.Mock{}
 
-		analysisMock.On("GetVulnByTime").Return([]dashboard.VulnByTime{{Time: time.Time{}}}, nil)
+		snapshotMock.On("GetVulnByTime").Return([]dashboard.VulnByTime{{Time: time.Time{}}}, nil)
 
 		controller := Controller{
-			useCases:   dashboardUseCases.NewDashboardUseCases(),
-			repository: analysisMock,
+			useCases: dashboardUseCases.NewDashboardUseCases(),
+			totals:   snapshotMock,
 		}
 
 		result, err := controller.GetVulnByTime(uuid.Nil, uuid.Nil, time.Now(), time.Now())
@@ -226,25 +229,187 @@ func TestGetVulnByTime(t *testing.T) {
 }
 
 func TestGetVulnByRepository(t *testing.T) {
+	t.Run("Should success get data with no errors", func(t *testing.T) {
+		snapshotMock := &snapshot.Mock{}
+
+		snapshotMock.On("GetVulnByRepository").Return([]dashboard.VulnByRepository{{Repository: "test"}}, nil)
+
+		controller := Controller{
+			useCases: dashboardUseCases.NewDashboardUseCases(),
+			totals:   snapshotMock,
+		}
+
+		result, err := controller.GetVulnByRepository(uuid.Nil, uuid.Nil, time.Now(), time.Now())
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result)
+	})
+}
+
+func TestGetVulnBySecurityTool(t *testing.T) {
 	t.Run("Should success get data with no errors", func(t *testing.T) {
 		analysisMock := &analysis.Mock{}
 
-		analysisMock.On("GetVulnByRepository").Return([]dashboard.VulnByRepository{{Repository: "test"}}, nil)
+		analysisMock.On("GetVulnBySecurityTool").Return([]dashboard.VulnBySecurityTool{{SecurityTool: "test"}}, nil)
 
 		controller := Controller{
 			useCases:   dashboardUseCases.NewDashboardUseCases(),
 			repository: analysisMock,
 		}
 
-		result, err := controller.GetVulnByRepository(uuid.Nil, uuid.Nil, time.Now(), time.Now())
+		result, err := controller.GetVulnBySecurityTool(uuid.Nil, uuid.Nil, time.Now(), time.Now())
 
 		assert.NoError(t, err)
-		assert.NotEmpty(t, result)
+		assert.Len(t, result, 1)
+	})
+}
+
+func TestGetVulnByRule(t *testing.T) {
+	t.Run("Should success get data with no errors", func(t *testing.T) {
+		analysisMock := &analysis.Mock{}
+
+		analysisMock.On("GetVulnByRule").Return([]dashboard.VulnByRule{{RuleID: "test"}}, nil)
+
+		controller := Controller{
+			useCases:   dashboardUseCases.NewDashboardUseCases(),
+			repository: analysisMock,
+		}
+
+		result, err := controller.GetVulnByRule(uuid.Nil, uuid.Nil, time.Now(), time.Now())
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+}
+
+func TestGetVulnLifecycle(t *testing.T) {
+	repositoryID := uuid.New()
+	now := time.Now()
+	occurrences := []dashboard.VulnOccurrence{
+		{RepositoryID: repositoryID, VulnHash: "1", Severity: "HIGH", FirstSeen: now.AddDate(0, 0, -10),
+			LastSeen: now.AddDate(0, 0, -10)},
+		{RepositoryID: repositoryID, VulnHash: "2", Severity: "LOW", FirstSeen: now.AddDate(0, 0, -40),
+			LastSeen: now.AddDate(0, 0, -1)},
+	}
+	analysisDates := []dashboard.AnalysisDate{
+		{RepositoryID: repositoryID, FinishedAt: now.AddDate(0, 0, -10)},
+		{RepositoryID: repositoryID, FinishedAt: now.AddDate(0, 0, -1)},
+	}
+
+	t.Run("Should success get lifecycle, time to fix and aging", func(t *testing.T) {
+		analysisMock := &analysis.Mock{}
+		analysisMock.On("GetVulnOccurrences").Return(occurrences, nil)
+		analysisMock.On("GetAnalysisDates").Return(analysisDates, nil)
+
+		controller := Controller{repository: analysisMock}
+
+		lifecycles, err := controller.GetVulnLifecycle(uuid.Nil, repositoryID, time.Time{}, time.Time{})
+		assert.NoError(t, err)
+		assert.Len(t, lifecycles, 2)
+
+		timeToFix, err := controller.GetTimeToFix(uuid.Nil, repositoryID, time.Time{}, time.Time{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, timeToFix[0].Fixed)
+		assert.Equal(t, 1, timeToFix[1].Open)
+
+		aging, err := controller.GetVulnAging(uuid.Nil, repositoryID, time.Time{}, time.Time{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, aging[2].Low)
+	})
+
+	t.Run("Should return error when get occurrences fails", func(t *testing.T) {
+		analysisMock := &analysis.Mock{}
+		analysisMock.On("GetVulnOccurrences").Return([]dashboard.VulnOccurrence{}, errors.New("test"))
+
+		controller := Controller{repository: analysisMock}
+
+		_, err := controller.GetTimeToFix(uuid.Nil, repositoryID, time.Time{}, time.Time{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error when get analysis dates fails", func(t *testing.T) {
+		analysisMock := &analysis.Mock{}
+		analysisMock.On("GetVulnOccurrences").Return(occurrences, nil)
+		analysisMock.On("GetAnalysisDates").Return([]dashboard.AnalysisDate{}, errors.New("test"))
+
+		controller := Controller{repository: analysisMock}
+
+		_, err := controller.GetVulnAging(uuid.Nil, repositoryID, time.Time{}, time.Time{})
+		assert.Error(t, err)
+	})
+}
+
+func TestGetSecurityScore(t *testing.T) {
+	repositoryID := uuid.New()
+	now := time.Now()
+	occurrences := []dashboard.VulnOccurrence{
+		{RepositoryID: repositoryID, VulnHash: "1", Severity: "HIGH", FirstSeen: now.AddDate(0, 0, -10),
+			LastSeen: now.AddDate(0, 0, -1)},
+		{RepositoryID: repositoryID, VulnHash: "2", Severity: "LOW", FirstSeen: now.AddDate(0, 0, -40),
+			LastSeen: now.AddDate(0, 0, -10)},
+	}
+	analysisDates := []dashboard.AnalysisDate{
+		{RepositoryID: repositoryID, RepositoryName: "test", FinishedAt: now.AddDate(0, 0, -40)},
+		{RepositoryID: repositoryID, RepositoryName: "test", FinishedAt: now.AddDate(0, 0, -10)},
+		{RepositoryID: repositoryID, RepositoryName: "test", FinishedAt: now.AddDate(0, 0, -1)},
+	}
+
+	t.Run("Should success get score history and repository ranking", func(t *testing.T) {
+		analysisMock := &analysis.Mock{}
+		analysisMock.On("GetVulnOccurrences").Return(occurrences, nil)
+		analysisMock.On("GetAnalysisDates").Return(analysisDates, nil)
+
+		controller := Controller{repository: analysisMock, appConfig: app.SetupApp()}
+
+		history, err := controller.GetScoreHistory(uuid.Nil, repositoryID, time.Time{}, now)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, history)
+		assert.Equal(t, now, history[len(history)-1].Time)
+		assert.Less(t, history[len(history)-1].Score, float64(100))
+
+		ranking, err := controller.GetRepositoryScores(uuid.Nil, repositoryID, time.Time{}, time.Time{})
+		assert.NoError(t, err)
+		assert.Len(t, ranking, 1)
+		assert.Equal(t, "test", ranking[0].RepositoryName)
+		assert.Equal(t, 1, ranking[0].OpenVulnerabilities)
+	})
+
+	t.Run("Should return error when get occurrences fails", func(t *testing.T) {
+		analysisMock := &analysis.Mock{}
+		analysisMock.On("GetVulnOccurrences").Return([]dashboard.VulnOccurrence{}, errors.New("test"))
+
+		controller := Controller{repository: analysisMock, appConfig: app.SetupApp()}
+
+		_, err := controller.GetScoreHistory(uuid.Nil, repositoryID, time.Time{}, time.Time{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error when get analysis dates fails", func(t *testing.T) {
+		analysisMock := &analysis.Mock{}
+		analysisMock.On("GetVulnOccurrences").Return(occurrences, nil)
+		analysisMock.On("GetAnalysisDates").Return([]dashboard.AnalysisDate{}, errors.New("test"))
+
+		controller := Controller{repository: analysisMock, appConfig: app.SetupApp()}
+
+		_, err := controller.GetRepositoryScores(uuid.Nil, repositoryID, time.Time{}, time.Time{})
+		assert.Error(t, err)
 	})
 }
 
 func TestNewDashboardController(t *testing.T) {
 	t.Run("Should return a new controller", func(t *testing.T) {
-		assert.NotEmpty(t, NewDashboardController(nil))
+		assert.NotEmpty(t, NewDashboardController(nil, app.SetupApp()))
+	})
+
+	t.Run("Should read the totals of the daily snapshots by default", func(t *testing.T) {
+		controller := NewDashboardController(nil, app.SetupApp()).(*Controller)
+
+		assert.IsType(t, &snapshot.Repository{}, controller.totals)
+	})
+
+	t.Run("Should read the totals of the analysis when snapshots are disabled", func(t *testing.T) {
+		controller := NewDashboardController(nil, &app.Config{DailySnapshots: false}).(*Controller)
+
+		assert.IsType(t, &analysis.Repository{}, controller.totals)
 	})
 }
@@ -0,0 +1,123 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	cursorPrefix    = "cursor:"
+)
+
+type connection struct {
+	TotalCount int      `json:"totalCount"`
+	Edges      []edge   `json:"edges"`
+	PageInfo   pageInfo `json:"pageInfo"`
+}
+
+type edge struct {
+	Cursor string      `json:"cursor"`
+	Node   interface{} `json:"node"`
+}
+
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// newConnection creates the page of nodes starting at the offset, the cursor of each node is its offset
+func newConnection(nodes []interface{}, offset, totalCount int) *connection {
+	page := &connection{TotalCount: totalCount, Edges: []edge{}}
+	for index, node := range nodes {
+		page.Edges = append(page.Edges, edge{Cursor: encodeCursor(offset + index), Node: node})
+	}
+
+	if len(page.Edges) > 0 {
+		page.PageInfo.EndCursor = page.Edges[len(page.Edges)-1].Cursor
+	}
+
+	page.PageInfo.HasNextPage = offset+len(nodes) < totalCount
+	return page
+}
+
+func encodeCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), cursorPrefix) {
+		return 0, errors.ErrorInvalidGraphqlCursor
+	}
+
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(decoded), cursorPrefix))
+	if err != nil || offset < 0 {
+		return 0, errors.ErrorInvalidGraphqlCursor
+	}
+
+	return offset, nil
+}
+
+// getPagination returns the offset and size of the page from the first and after arguments
+func getPagination(args map[string]interface{}) (offset, size int, err error) {
+	size = defaultPageSize
+	if first, ok := args["first"].(int); ok {
+		if first < 1 || first > maxPageSize {
+			return 0, 0, errors.ErrorInvalidGraphqlPageSize
+		}
+
+		size = first
+	}
+
+	if after, ok := args["after"].(string); ok && after != "" {
+		offset, err = decodeCursor(after)
+		return offset + 1, size, err
+	}
+
+	return 0, size, nil
+}
+
+func newConnectionType(name string, nodeType *graphql.Object) *graphql.Object {
+	edgeType := graphql.NewObject(graphql.ObjectConfig{Name: name + "Edge", Fields: graphql.Fields{
+		"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"node":   &graphql.Field{Type: nodeType},
+	}})
+
+	return graphql.NewObject(graphql.ObjectConfig{Name: name + "Connection", Fields: graphql.Fields{
+		"totalCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"edges":      &graphql.Field{Type: graphql.NewList(edgeType)},
+		"pageInfo":   &graphql.Field{Type: pageInfoType},
+	}})
+}
+
+var pageInfoType = graphql.NewObject(graphql.ObjectConfig{Name: "PageInfo", Fields: graphql.Fields{
+	"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+	"endCursor":   &graphql.Field{Type: graphql.String},
+}})
+
+func paginationArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+		"after": &graphql.ArgumentConfig{Type: graphql.String},
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConnection(t *testing.T) {
+	t.Run("should create connection with cursors of the offset", func(t *testing.T) {
+		page := newConnection([]interface{}{"first", "second"}, 2, 5)
+
+		assert.Equal(t, 5, page.TotalCount)
+		assert.Equal(t, encodeCursor(2), page.Edges[0].Cursor)
+		assert.Equal(t, encodeCursor(3), page.PageInfo.EndCursor)
+		assert.True(t, page.PageInfo.HasNextPage)
+	})
+
+	t.Run("should create empty last page", func(t *testing.T) {
+		page := newConnection(nil, 0, 0)
+
+		assert.Empty(t, page.Edges)
+		assert.Empty(t, page.PageInfo.EndCursor)
+		assert.False(t, page.PageInfo.HasNextPage)
+	})
+}
+
+func TestGetPagination(t *testing.T) {
+	t.Run("should return default page size", func(t *testing.T) {
+		offset, size, err := getPagination(map[string]interface{}{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, offset)
+		assert.Equal(t, defaultPageSize, size)
+	})
+
+	t.Run("should return page after the cursor", func(t *testing.T) {
+		offset, size, err := getPagination(map[string]interface{}{"first": 10, "after": encodeCursor(9)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, offset)
+		assert.Equal(t, 10, size)
+	})
+
+	t.Run("should return error when page size is invalid", func(t *testing.T) {
+		_, _, err := getPagination(map[string]interface{}{"first": maxPageSize + 1})
+
+		assert.Equal(t, errors.ErrorInvalidGraphqlPageSize, err)
+	})
+
+	t.Run("should return error when cursor is invalid", func(t *testing.T) {
+		_, _, err := getPagination(map[string]interface{}{"after": "invalid"})
+		assert.Equal(t, errors.ErrorInvalidGraphqlCursor, err)
+
+		_, _, err = getPagination(map[string]interface{}{"after": encodeCursor(-1)})
+		assert.Equal(t, errors.ErrorInvalidGraphqlCursor, err)
+	})
+}
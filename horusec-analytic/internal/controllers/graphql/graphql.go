@@ -0,0 +1,94 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	analysisRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/analysis"
+	companyRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	repositoryRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	dashboardEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-analytic/config/app"
+	"github.com/ZupIT/horusec/horusec-analytic/internal/controllers/dashboard"
+	"github.com/ZupIT/horusec/horusec-analytic/internal/services/authz"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"google.golang.org/grpc"
+)
+
+type ctxKey string
+
+const (
+	tokenCtxKey      ctxKey = "token"
+	authzCacheCtxKey ctxKey = "authzCache"
+)
+
+type IController interface {
+	Execute(token string, request *dashboardEntities.GraphQLRequest) *graphql.Result
+}
+
+type Controller struct {
+	schema               graphql.Schema
+	dashboard            dashboard.IController
+	analysisRepository   analysisRepository.IAnalysisRepository
+	companyRepository    companyRepository.ICompanyRepository
+	repositoryRepository repositoryRepository.IRepository
+	authz                authz.IService
+	maxDepth             int
+	maxComplexity        int
+}
+
+func NewGraphQLController(postgresRead relational.InterfaceRead, appConfig app.IAppConfig,
+	grpcCon grpc.ClientConnInterface) IController {
+	controller := &Controller{
+		dashboard:            dashboard.NewDashboardController(postgresRead, appConfig),
+		analysisRepository:   analysisRepository.NewAnalysisRepository(postgresRead, nil),
+		companyRepository:    companyRepository.NewCompanyRepository(postgresRead, nil),
+		repositoryRepository: repositoryRepository.NewRepository(postgresRead, nil),
+		authz:                authz.NewAuthzService(grpcCon),
+		maxDepth:             appConfig.GetGraphqlMaxDepth(),
+		maxComplexity:        appConfig.GetGraphqlMaxComplexity(),
+	}
+
+	controller.setSchema()
+	return controller
+}
+
+func (c *Controller) setSchema() {
+	schema, err := c.newSchema()
+	logger.LogError(errors.ErrorGraphqlSchema, err)
+	c.schema = schema
+}
+
+// Execute runs the query with the token of the request, the fields are authorized by the resolvers and the results
+// of the authorization are kept only for this request
+func (c *Controller) Execute(token string, request *dashboardEntities.GraphQLRequest) *graphql.Result {
+	if err := c.checkLimits(request); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	ctx := context.WithValue(context.Background(), tokenCtxKey, token)
+	return graphql.Do(graphql.Params{
+		Schema:         c.schema,
+		RequestString:  request.Query,
+		VariableValues: request.Variables,
+		OperationName:  request.OperationName,
+		Context:        context.WithValue(ctx, authzCacheCtxKey, newAuthzCache()),
+	})
+}
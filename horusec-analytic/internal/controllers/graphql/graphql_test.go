@@ -0,0 +1,297 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	analysisRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/analysis"
+	companyRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	repositoryRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	dashboardEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/horusec-analytic/config/app"
+	"github.com/ZupIT/horusec/horusec-analytic/internal/controllers/dashboard"
+	"github.com/ZupIT/horusec/horusec-analytic/internal/services/authz"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func newTestController(authorized bool) (*Controller, *analysisRepository.Mock, *dashboard.Mock) {
+	authzMock := &authz.Mock{}
+	authzMock.On("IsAuthorized").Return(authorized)
+	analysisMock := &analysisRepository.Mock{}
+	dashboardMock := &dashboard.Mock{}
+	companyMock := &companyRepository.Mock{}
+	companyMock.On("GetByID").Return(&accountEntities.Company{CompanyID: uuid.New(), Name: "test"}, nil)
+	repositoryMock := &repositoryRepository.Mock{}
+	repositoryMock.On("ListAllInCompany").Return(&[]accountEntities.Repository{{Name: "first"}, {Name: "second"}}, nil)
+
+	controller := &Controller{
+		dashboard:            dashboardMock,
+		analysisRepository:   analysisMock,
+		companyRepository:    companyMock,
+		repositoryRepository: repositoryMock,
+		authz:                authzMock,
+		maxDepth:             app.DefaultGraphqlMaxDepth,
+		maxComplexity:        app.DefaultGraphqlMaxComplexity,
+	}
+
+	controller.setSchema()
+	return controller, analysisMock, dashboardMock
+}
+
+func execute(controller *Controller, query string) map[string]interface{} {
+	result := controller.Execute("token", &dashboardEntities.GraphQLRequest{Query: query,
+		Variables: map[string]interface{}{"companyID": uuid.New().String()}})
+	if result.HasErrors() {
+		return map[string]interface{}{"errors": result.Errors}
+	}
+
+	return result.Data.(map[string]interface{})
+}
+
+func TestNewGraphQLController(t *testing.T) {
+	t.Run("should create a new controller with a valid schema", func(t *testing.T) {
+		controller := NewGraphQLController(&relational.MockRead{}, app.SetupApp(), &grpc.ClientConn{})
+
+		assert.NotNil(t, controller)
+		assert.NotNil(t, controller.(*Controller).schema.QueryType())
+	})
+}
+
+func TestExecute(t *testing.T) {
+	t.Run("should return company with paginated repositories", func(t *testing.T) {
+		controller, _, _ := newTestController(true)
+
+		data := execute(controller, `query($companyID: ID!) { company(companyID: $companyID) { name
+			repositories(first: 1) { totalCount edges { cursor node { name } } pageInfo { hasNextPage endCursor } } } }`)
+
+		repositories := data["company"].(map[string]interface{})["repositories"].(map[string]interface{})
+		assert.Equal(t, 2, repositories["totalCount"])
+		assert.Len(t, repositories["edges"], 1)
+		assert.Equal(t, true, repositories["pageInfo"].(map[string]interface{})["hasNextPage"])
+	})
+
+	t.Run("should return analytics of the company", func(t *testing.T) {
+		controller, _, dashboardMock := newTestController(true)
+		dashboardMock.On("GetTotalDevelopers").Return(3, nil)
+		dashboardMock.On("GetVulnBySeverity").Return([]dashboardEntities.VulnBySeverity{
+			{Severity: "HIGH", Total: 2}}, nil)
+		dashboardMock.On("GetVulnByTime").Return([]dashboardEntities.VulnByTime{{Time: time.Now(), Total: 2}}, nil)
+		dashboardMock.On("GetVulnByRule").Return([]dashboardEntities.VulnByRule{{RuleID: "G101",
+			FalsePositiveRate: 50}}, nil)
+		dashboardMock.On("GetScoreHistory").Return([]dashboardEntities.SecurityScore{{Time: time.Now(),
+			Score: 75.5}}, nil)
+		dashboardMock.On("GetRepositoryScores").Return([]dashboardEntities.RepositoryScore{{
+			RepositoryID: uuid.New(), RepositoryName: "test", Score: 50}}, nil)
+
+		data := execute(controller, `query($companyID: ID!) { company(companyID: $companyID) {
+			analytics(initialDate: "2021-01-01T00:00:00Z") { totalDevelopers vulnBySeverity { severity total }
+			vulnByTime { time total } vulnByRule { ruleID falsePositiveRate } scoreHistory { time score }
+			repositoryRanking { repositoryID repositoryName score } } } }`)
+
+		analytics := data["company"].(map[string]interface{})["analytics"].(map[string]interface{})
+		assert.Equal(t, 3, analytics["totalDevelopers"])
+		assert.Len(t, analytics["vulnBySeverity"], 1)
+		assert.Len(t, analytics["vulnByTime"], 1)
+		assert.Equal(t, float64(50), analytics["vulnByRule"].([]interface{})[0].(map[string]interface{})["falsePositiveRate"])
+		assert.Equal(t, 75.5, analytics["scoreHistory"].([]interface{})[0].(map[string]interface{})["score"])
+		assert.Equal(t, "test",
+			analytics["repositoryRanking"].([]interface{})[0].(map[string]interface{})["repositoryName"])
+	})
+
+	t.Run("should return analyses with their vulnerabilities", func(t *testing.T) {
+		controller, analysisMock, _ := newTestController(true)
+		analysisMock.On("ListAnalysis").Return([]horusec.Analysis{{ID: uuid.New(), Branch: "main"}}, 1, nil)
+		analysisMock.On("ListVulnerabilities").Return([]dashboardEntities.VulnDetails{{RepositoryName: "test",
+			Vulnerability: horusec.Vulnerability{VulnerabilityID: uuid.New(), Severity: "HIGH"}}}, 1, nil)
+
+		data := execute(controller, `query($companyID: ID!) { company(companyID: $companyID) {
+			analyses(status: "success") { edges { node { branch
+			vulnerabilities(severity: "HIGH") { edges { node { severity repositoryName } } } } } } } }`)
+
+		analyses := data["company"].(map[string]interface{})["analyses"].(map[string]interface{})
+		analysis := analyses["edges"].([]interface{})[0].(map[string]interface{})["node"].(map[string]interface{})
+		vulnerabilities := analysis["vulnerabilities"].(map[string]interface{})["edges"].([]interface{})
+		vulnerability := vulnerabilities[0].(map[string]interface{})["node"].(map[string]interface{})
+		assert.Equal(t, "main", analysis["branch"])
+		assert.Equal(t, "HIGH", vulnerability["severity"])
+		assert.Equal(t, "test", vulnerability["repositoryName"])
+	})
+
+	t.Run("should return error when the token is not authorized", func(t *testing.T) {
+		controller, _, _ := newTestController(false)
+
+		result := controller.Execute("token", &dashboardEntities.GraphQLRequest{
+			Query: `{ company(companyID: "` + uuid.New().String() + `") { name } }`})
+
+		assert.True(t, result.HasErrors())
+	})
+
+	t.Run("should return error when the cursor is invalid", func(t *testing.T) {
+		controller, _, _ := newTestController(true)
+
+		data := execute(controller, `query($companyID: ID!) { company(companyID: $companyID) {
+			repositories(after: "invalid") { totalCount } } }`)
+
+		assert.NotNil(t, data["errors"])
+	})
+
+	t.Run("should return error when list fails", func(t *testing.T) {
+		controller, analysisMock, _ := newTestController(true)
+		analysisMock.On("ListAnalysis").Return([]horusec.Analysis{}, 0, errors.New("test"))
+
+		data := execute(controller, `query($companyID: ID!) { company(companyID: $companyID) {
+			analyses { totalCount } } }`)
+
+		assert.NotNil(t, data["errors"])
+	})
+
+	t.Run("should authorize each scope only once in the request", func(t *testing.T) {
+		controller, _, dashboardMock := newTestController(true)
+		dashboardMock.On("GetTotalDevelopers").Return(3, nil)
+		dashboardMock.On("GetTotalRepositories").Return(2, nil)
+
+		data := execute(controller, `query($companyID: ID!) { company(companyID: $companyID) {
+			developers: analytics { totalDevelopers } repositories: analytics { totalRepositories } } }`)
+
+		assert.Nil(t, data["errors"])
+		controller.authz.(*authz.Mock).AssertNumberOfCalls(t, "IsAuthorized", 2)
+	})
+}
+
+func TestCheckLimits(t *testing.T) {
+	t.Run("should return error without resolving fields when the query is too deep", func(t *testing.T) {
+		controller, _, _ := newTestController(true)
+		controller.maxDepth = 3
+
+		result := controller.Execute("token", &dashboardEntities.GraphQLRequest{
+			Query: `{ company(companyID: "test") { repositories { edges { cursor } } } }`})
+
+		assert.Equal(t, errorsEnum.ErrorGraphqlMaxDepth.Error(), result.Errors[0].Message)
+		controller.authz.(*authz.Mock).AssertNotCalled(t, "IsAuthorized")
+	})
+
+	t.Run("should count the depth of fields inside fragments", func(t *testing.T) {
+		controller, _, _ := newTestController(true)
+		controller.maxDepth = 3
+
+		err := controller.checkLimits(&dashboardEntities.GraphQLRequest{Query: `{ company(companyID: "test") {
+			...repositories } } fragment repositories on Company { repositories { ... on RepositoryConnection {
+			edges { cursor } } } }`})
+
+		assert.Equal(t, errorsEnum.ErrorGraphqlMaxDepth, err)
+	})
+
+	t.Run("should multiply the cost of the fields inside pages by the page size", func(t *testing.T) {
+		controller, _, _ := newTestController(true)
+		query := `query($size: Int) { company(companyID: "test") { repositories(first: $size) { edges { node {
+			analyses(first: 100) { totalCount } } } } } }`
+
+		err := controller.checkLimits(&dashboardEntities.GraphQLRequest{Query: query,
+			Variables: map[string]interface{}{"size": float64(100)}})
+		assert.Equal(t, errorsEnum.ErrorGraphqlMaxComplexity, err)
+
+		err = controller.checkLimits(&dashboardEntities.GraphQLRequest{Query: query,
+			Variables: map[string]interface{}{"size": float64(10)}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when aliases repeat the fields past the max complexity", func(t *testing.T) {
+		controller, _, _ := newTestController(true)
+		controller.maxComplexity = 10
+
+		err := controller.checkLimits(&dashboardEntities.GraphQLRequest{Query: `{ company(companyID: "test") {
+			a: name b: name c: name d: name e: name f: name g: name h: name i: name j: name } }`})
+
+		assert.Equal(t, errorsEnum.ErrorGraphqlMaxComplexity, err)
+	})
+
+	t.Run("should not check limits when they are disabled", func(t *testing.T) {
+		controller, _, _ := newTestController(true)
+		controller.maxDepth, controller.maxComplexity = 0, 0
+
+		err := controller.checkLimits(&dashboardEntities.GraphQLRequest{Query: `{ company(companyID: "test") {
+			repositories(first: 100) { edges { node { analyses(first: 100) { edges { node {
+			vulnerabilities(first: 100) { totalCount } } } } } } } } }`})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should leave fragment cycles and syntax errors to the query validation", func(t *testing.T) {
+		controller, _, _ := newTestController(true)
+
+		assert.NoError(t, controller.checkLimits(&dashboardEntities.GraphQLRequest{Query: `{ company(companyID: "test")
+			{ ...company } } fragment company on Company { name ...company }`}))
+		assert.NoError(t, controller.checkLimits(&dashboardEntities.GraphQLRequest{Query: `{ company(`}))
+	})
+
+	t.Run("should check only the requested operation", func(t *testing.T) {
+		controller, _, _ := newTestController(true)
+		controller.maxDepth = 2
+
+		err := controller.checkLimits(&dashboardEntities.GraphQLRequest{OperationName: "company", Query: `
+			query company { company(companyID: "test") { name } }
+			query repositories { company(companyID: "test") { repositories { totalCount } } }`})
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestResolveRepository(t *testing.T) {
+	t.Run("should return error when the repository is not of the company", func(t *testing.T) {
+		controller, _, _ := newTestController(true)
+		repositoryMock := &repositoryRepository.Mock{}
+		repositoryMock.On("Get").Return(&accountEntities.Repository{CompanyID: uuid.New()}, nil)
+		controller.repositoryRepository = repositoryMock
+
+		result := controller.Execute("token", &dashboardEntities.GraphQLRequest{
+			Query: `{ repository(companyID: "` + uuid.New().String() + `", repositoryID: "` +
+				uuid.New().String() + `") { name } }`})
+
+		assert.True(t, result.HasErrors())
+	})
+
+	t.Run("should return repository with its analytics", func(t *testing.T) {
+		controller, _, dashboardMock := newTestController(true)
+		companyID := uuid.New()
+		repositoryMock := &repositoryRepository.Mock{}
+		repositoryMock.On("Get").Return(&accountEntities.Repository{CompanyID: companyID, Name: "test"}, nil)
+		controller.repositoryRepository = repositoryMock
+		dashboardMock.On("GetTotalRepositories").Return(1, nil)
+
+		result := controller.Execute("token", &dashboardEntities.GraphQLRequest{
+			Query: `{ repository(companyID: "` + companyID.String() + `", repositoryID: "` +
+				uuid.New().String() + `") { name analytics { totalRepositories } } }`})
+
+		assert.False(t, result.HasErrors())
+		dashboardMock.AssertCalled(t, "GetTotalRepositories")
+	})
+}
+
+func TestGetScope(t *testing.T) {
+	t.Run("should return empty scope when source is unknown", func(t *testing.T) {
+		controller, _, _ := newTestController(true)
+
+		assert.Empty(t, controller.getScope("test").role)
+	})
+}
@@ -0,0 +1,204 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"strconv"
+
+	dashboardEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// queryCost walks the selections of a query with the types of the schema, each field costs one and the fields inside
+// a page cost once for each item of the page, so a query can not ask many pages of pages to the database
+type queryCost struct {
+	schema          graphql.Schema
+	variables       map[string]interface{}
+	fragments       map[string]*ast.FragmentDefinition
+	activeFragments map[string]bool
+	maxDepth        int
+	maxComplexity   int
+}
+
+// checkLimits returns an error when an operation of the query exceeds the max depth or complexity, syntax errors are
+// left to the execution that returns them with their location
+func (c *Controller) checkLimits(request *dashboardEntities.GraphQLRequest) error {
+	document, err := parser.Parse(parser.ParseParams{Source: request.Query})
+	if err != nil {
+		return nil
+	}
+
+	cost := &queryCost{schema: c.schema, variables: request.Variables, maxDepth: c.maxDepth,
+		maxComplexity: c.maxComplexity, fragments: map[string]*ast.FragmentDefinition{},
+		activeFragments: map[string]bool{}}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			cost.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok || !isRequestedOperation(operation, request.OperationName) {
+			continue
+		}
+
+		if _, err := cost.getSelectionSetCost(operation.SelectionSet, c.schema.QueryType(), 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isRequestedOperation(operation *ast.OperationDefinition, operationName string) bool {
+	return operationName == "" || operation.Name == nil || operation.Name.Value == operationName
+}
+
+func (q *queryCost) getSelectionSetCost(selectionSet *ast.SelectionSet, parent *graphql.Object,
+	depth int) (total int, err error) {
+	if selectionSet == nil {
+		return 0, nil
+	}
+
+	for _, selection := range selectionSet.Selections {
+		selectionCost, err := q.getSelectionCost(selection, parent, depth)
+		if err != nil {
+			return 0, err
+		}
+
+		total += selectionCost
+		if q.maxComplexity > 0 && total > q.maxComplexity {
+			return 0, errors.ErrorGraphqlMaxComplexity
+		}
+	}
+
+	return total, nil
+}
+
+func (q *queryCost) getSelectionCost(selection ast.Selection, parent *graphql.Object, depth int) (int, error) {
+	switch value := selection.(type) {
+	case *ast.Field:
+		return q.getFieldCost(value, parent, depth)
+	case *ast.InlineFragment:
+		return q.getSelectionSetCost(value.SelectionSet, q.getTypeCondition(value.TypeCondition, parent), depth)
+	case *ast.FragmentSpread:
+		return q.getFragmentSpreadCost(value, parent, depth)
+	default:
+		return 0, nil
+	}
+}
+
+func (q *queryCost) getFieldCost(field *ast.Field, parent *graphql.Object, depth int) (int, error) {
+	if q.maxDepth > 0 && depth > q.maxDepth {
+		return 0, errors.ErrorGraphqlMaxDepth
+	}
+
+	definition := q.getFieldDefinition(field, parent)
+	if field.SelectionSet == nil || definition == nil {
+		return 1, nil
+	}
+
+	fieldType, _ := graphql.GetNamed(definition.Type).(*graphql.Object)
+	childrenCost, err := q.getSelectionSetCost(field.SelectionSet, fieldType, depth+1)
+	if err != nil {
+		return 0, err
+	}
+
+	return 1 + childrenCost*q.getPageSize(field, definition), nil
+}
+
+// getFragmentSpreadCost ignores the fragments that spread themselves, the validation of the query returns the error
+func (q *queryCost) getFragmentSpreadCost(spread *ast.FragmentSpread, parent *graphql.Object, depth int) (int, error) {
+	fragment, ok := q.fragments[spread.Name.Value]
+	if !ok || q.activeFragments[spread.Name.Value] {
+		return 0, nil
+	}
+
+	q.activeFragments[spread.Name.Value] = true
+	defer delete(q.activeFragments, spread.Name.Value)
+
+	return q.getSelectionSetCost(fragment.SelectionSet, q.getTypeCondition(fragment.TypeCondition, parent), depth)
+}
+
+func (q *queryCost) getFieldDefinition(field *ast.Field, parent *graphql.Object) *graphql.FieldDefinition {
+	if parent == nil {
+		return nil
+	}
+
+	return parent.Fields()[field.Name.Value]
+}
+
+func (q *queryCost) getTypeCondition(typeCondition *ast.Named, parent *graphql.Object) *graphql.Object {
+	if typeCondition == nil {
+		return parent
+	}
+
+	object, _ := q.schema.Type(typeCondition.Name.Value).(*graphql.Object)
+	return object
+}
+
+// getPageSize returns the first argument of the fields with pagination, or its default, and one for other fields
+func (q *queryCost) getPageSize(field *ast.Field, definition *graphql.FieldDefinition) int {
+	for _, argument := range definition.Args {
+		if argument.Name() == "first" {
+			return limitPageSize(q.getIntArgument(field, "first", argument.DefaultValue))
+		}
+	}
+
+	return 1
+}
+
+func (q *queryCost) getIntArgument(field *ast.Field, name string, defaultValue interface{}) interface{} {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != name {
+			continue
+		}
+
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			size, _ := strconv.Atoi(value.Value)
+			return size
+		case *ast.Variable:
+			return q.variables[value.Name.Value]
+		}
+	}
+
+	return defaultValue
+}
+
+// limitPageSize keeps the size between one and the max page size, the resolvers return the error of invalid sizes
+func limitPageSize(value interface{}) int {
+	size := defaultPageSize
+	switch number := value.(type) {
+	case int:
+		size = number
+	case float64:
+		size = int(number)
+	}
+
+	if size < 1 {
+		return 1
+	}
+
+	if size > maxPageSize {
+		return maxPageSize
+	}
+
+	return size
+}
@@ -0,0 +1,337 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	dashboardEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+// scope is the company or repository of the source of a field and the role needed to read its data
+type scope struct {
+	companyID    uuid.UUID
+	repositoryID uuid.UUID
+	analysisID   uuid.UUID
+	role         authEnums.HorusecRoles
+}
+
+// authzCache keeps the authorization of each scope during a request, the items of a page check the same scope
+type authzCache struct {
+	mutex   sync.Mutex
+	results map[scope]bool
+}
+
+func newAuthzCache() *authzCache {
+	return &authzCache{results: map[scope]bool{}}
+}
+
+type analytics struct {
+	companyID    uuid.UUID
+	repositoryID uuid.UUID
+	initialDate  time.Time
+	finalDate    time.Time
+}
+
+// vulnerabilityNode resolves the fields of the vulnerability before the fields of its analysis, the default resolver
+// does not read embedded structs
+type vulnerabilityNode struct {
+	details dashboardEntities.VulnDetails
+}
+
+func (v *vulnerabilityNode) Resolve(p graphql.ResolveParams) (interface{}, error) {
+	p.Source = &v.details.Vulnerability
+	if value, err := graphql.DefaultResolveFn(p); value != nil || err != nil {
+		return value, err
+	}
+
+	p.Source = &v.details
+	return graphql.DefaultResolveFn(p)
+}
+
+func (c *Controller) resolveCompany(p graphql.ResolveParams) (interface{}, error) {
+	companyID, err := uuid.Parse(p.Args["companyID"].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.authorize(p, &scope{companyID: companyID, role: authEnums.CompanyMember}); err != nil {
+		return nil, err
+	}
+
+	return c.companyRepository.GetByID(companyID)
+}
+
+func (c *Controller) resolveRepository(p graphql.ResolveParams) (interface{}, error) {
+	companyID, err := uuid.Parse(p.Args["companyID"].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	repositoryID, err := uuid.Parse(p.Args["repositoryID"].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.authorize(p, &scope{companyID: companyID, repositoryID: repositoryID, role: authEnums.RepositoryMember})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getRepository(companyID, repositoryID)
+}
+
+func (c *Controller) getRepository(companyID, repositoryID uuid.UUID) (*accountEntities.Repository, error) {
+	repository, err := c.repositoryRepository.Get(repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if repository.CompanyID != companyID {
+		return nil, errors.ErrNotFoundRecords
+	}
+
+	return repository, nil
+}
+
+func (c *Controller) resolveCompanyRepositories(p graphql.ResolveParams) (interface{}, error) {
+	fieldScope := c.getScope(p.Source)
+	if err := c.authorize(p, fieldScope); err != nil {
+		return nil, err
+	}
+
+	offset, size, err := getPagination(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	repositories, err := c.repositoryRepository.ListAllInCompany(fieldScope.companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []interface{}
+	for index := offset; index < len(*repositories) && index < offset+size; index++ {
+		nodes = append(nodes, &(*repositories)[index])
+	}
+
+	return newConnection(nodes, offset, len(*repositories)), nil
+}
+
+func (c *Controller) resolveAnalytics(p graphql.ResolveParams) (interface{}, error) {
+	fieldScope := c.getScope(p.Source)
+	if err := c.authorize(p, fieldScope); err != nil {
+		return nil, err
+	}
+
+	initialDate, finalDate := c.getDates(p.Args)
+	if fieldScope.repositoryID != uuid.Nil {
+		return &analytics{repositoryID: fieldScope.repositoryID, initialDate: initialDate, finalDate: finalDate}, nil
+	}
+
+	return &analytics{companyID: fieldScope.companyID, initialDate: initialDate, finalDate: finalDate}, nil
+}
+
+func (c *Controller) resolveAnalyses(p graphql.ResolveParams) (interface{}, error) {
+	filter, err := c.getFilter(p)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis, totalCount, err := c.analysisRepository.ListAnalysis(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []interface{}
+	for index := range analysis {
+		nodes = append(nodes, &analysis[index])
+	}
+
+	return newConnection(nodes, filter.Offset, totalCount), nil
+}
+
+func (c *Controller) resolveVulnerabilities(p graphql.ResolveParams) (interface{}, error) {
+	filter, err := c.getFilter(p)
+	if err != nil {
+		return nil, err
+	}
+
+	vulnDetails, totalCount, err := c.analysisRepository.ListVulnerabilities(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []interface{}
+	for index := range vulnDetails {
+		nodes = append(nodes, &vulnerabilityNode{details: vulnDetails[index]})
+	}
+
+	return newConnection(nodes, filter.Offset, totalCount), nil
+}
+
+func (c *Controller) getFilter(p graphql.ResolveParams) (*dashboardEntities.Filter, error) {
+	fieldScope := c.getScope(p.Source)
+	if err := c.authorize(p, fieldScope); err != nil {
+		return nil, err
+	}
+
+	offset, size, err := getPagination(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &dashboardEntities.Filter{CompanyID: fieldScope.companyID, RepositoryID: fieldScope.repositoryID,
+		AnalysisID: fieldScope.analysisID, Offset: offset, Size: size}
+	filter.InitialDate, _ = p.Args["initialDate"].(time.Time)
+	filter.FinalDate, _ = p.Args["finalDate"].(time.Time)
+	filter.Status, _ = p.Args["status"].(string)
+	filter.Branch, _ = p.Args["branch"].(string)
+	filter.Severity, _ = p.Args["severity"].(string)
+	filter.Type, _ = p.Args["type"].(string)
+	filter.Language, _ = p.Args["language"].(string)
+	filter.SecurityTool, _ = p.Args["securityTool"].(string)
+
+	return filter, nil
+}
+
+func (c *Controller) getDates(args map[string]interface{}) (initialDate, finalDate time.Time) {
+	initialDate, _ = args["initialDate"].(time.Time)
+	finalDate, _ = args["finalDate"].(time.Time)
+	if finalDate.IsZero() {
+		finalDate = time.Now()
+	}
+
+	return initialDate, finalDate
+}
+
+// getScope returns the scope of the source, the data of a company can only be read by its admins
+func (c *Controller) getScope(source interface{}) *scope {
+	switch value := source.(type) {
+	case *accountEntities.Company:
+		return &scope{companyID: value.CompanyID, role: authEnums.CompanyAdmin}
+	case *accountEntities.Repository:
+		return &scope{companyID: value.CompanyID, repositoryID: value.RepositoryID, role: authEnums.RepositoryMember}
+	case *horusec.Analysis:
+		return &scope{companyID: value.CompanyID, repositoryID: value.RepositoryID, analysisID: value.ID,
+			role: authEnums.RepositoryMember}
+	default:
+		return &scope{}
+	}
+}
+
+func (c *Controller) authorize(p graphql.ResolveParams, fieldScope *scope) error {
+	if fieldScope.role == "" || !c.isAuthorized(p.Context, fieldScope) {
+		return errors.ErrorUnauthorized
+	}
+
+	return nil
+}
+
+// isAuthorized asks the auth service only once for each role, company and repository of the request, the analysis
+// does not change the authorization
+func (c *Controller) isAuthorized(ctx context.Context, fieldScope *scope) bool {
+	token, _ := ctx.Value(tokenCtxKey).(string)
+	cache, ok := ctx.Value(authzCacheCtxKey).(*authzCache)
+	if !ok {
+		return c.authz.IsAuthorized(token, fieldScope.role, fieldScope.companyID, fieldScope.repositoryID)
+	}
+
+	key := scope{companyID: fieldScope.companyID, repositoryID: fieldScope.repositoryID, role: fieldScope.role}
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if authorized, ok := cache.results[key]; ok {
+		return authorized
+	}
+
+	cache.results[key] = c.authz.IsAuthorized(token, fieldScope.role, fieldScope.companyID, fieldScope.repositoryID)
+	return cache.results[key]
+}
+
+func (c *Controller) resolveTotalDevelopers(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetTotalDevelopers(source.companyID, source.repositoryID, source.initialDate, source.finalDate)
+}
+
+func (c *Controller) resolveTotalRepositories(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetTotalRepositories(source.companyID, source.repositoryID, source.initialDate,
+		source.finalDate)
+}
+
+func (c *Controller) resolveVulnBySeverity(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetVulnBySeverity(source.companyID, source.repositoryID, source.initialDate, source.finalDate)
+}
+
+func (c *Controller) resolveVulnByLanguage(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetVulnByLanguage(source.companyID, source.repositoryID, source.initialDate, source.finalDate)
+}
+
+func (c *Controller) resolveVulnByDeveloper(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetVulnByDeveloper(source.companyID, source.repositoryID, source.initialDate, source.finalDate)
+}
+
+func (c *Controller) resolveVulnByRepository(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetVulnByRepository(source.companyID, source.repositoryID, source.initialDate,
+		source.finalDate)
+}
+
+func (c *Controller) resolveVulnByTime(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetVulnByTime(source.companyID, source.repositoryID, source.initialDate, source.finalDate)
+}
+
+func (c *Controller) resolveVulnBySecurityTool(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetVulnBySecurityTool(source.companyID, source.repositoryID, source.initialDate,
+		source.finalDate)
+}
+
+func (c *Controller) resolveVulnByRule(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetVulnByRule(source.companyID, source.repositoryID, source.initialDate, source.finalDate)
+}
+
+func (c *Controller) resolveTimeToFix(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetTimeToFix(source.companyID, source.repositoryID, source.initialDate, source.finalDate)
+}
+
+func (c *Controller) resolveVulnAging(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetVulnAging(source.companyID, source.repositoryID, source.initialDate, source.finalDate)
+}
+
+func (c *Controller) resolveScoreHistory(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetScoreHistory(source.companyID, source.repositoryID, source.initialDate, source.finalDate)
+}
+
+func (c *Controller) resolveRanking(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(*analytics)
+	return c.dashboard.GetRepositoryScores(source.companyID, source.repositoryID, source.initialDate, source.finalDate)
+}
@@ -0,0 +1,251 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+func (c *Controller) newSchema() (graphql.Schema, error) {
+	vulnerabilityConnection := newConnectionType("Vulnerability", newVulnerabilityType())
+	analysisConnection := newConnectionType("Analysis", c.newAnalysisType(vulnerabilityConnection))
+	analyticsType := c.newAnalyticsType()
+	repositoryType := c.newRepositoryType(analyticsType, analysisConnection, vulnerabilityConnection)
+	companyType := c.newCompanyType(newConnectionType("Repository", repositoryType), analyticsType,
+		analysisConnection, vulnerabilityConnection)
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"company": &graphql.Field{
+				Type:    companyType,
+				Args:    graphql.FieldConfigArgument{"companyID": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: c.resolveCompany,
+			},
+			"repository": &graphql.Field{
+				Type: repositoryType,
+				Args: graphql.FieldConfigArgument{
+					"companyID":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"repositoryID": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: c.resolveRepository,
+			},
+		},
+	})})
+}
+
+func (c *Controller) newCompanyType(repositoryConnection, analyticsType, analysisConnection,
+	vulnerabilityConnection *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{Name: "Company", Fields: graphql.Fields{
+		"companyID":   &graphql.Field{Type: graphql.ID},
+		"name":        &graphql.Field{Type: graphql.String},
+		"description": &graphql.Field{Type: graphql.String},
+		"createdAt":   &graphql.Field{Type: graphql.DateTime},
+		"repositories": &graphql.Field{
+			Type:    repositoryConnection,
+			Args:    paginationArgs(),
+			Resolve: c.resolveCompanyRepositories,
+		},
+		"analytics":       &graphql.Field{Type: analyticsType, Args: dateArgs(), Resolve: c.resolveAnalytics},
+		"analyses":        &graphql.Field{Type: analysisConnection, Args: analysisArgs(), Resolve: c.resolveAnalyses},
+		"vulnerabilities": c.newVulnerabilitiesField(vulnerabilityConnection),
+	}})
+}
+
+func (c *Controller) newRepositoryType(analyticsType, analysisConnection,
+	vulnerabilityConnection *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{Name: "Repository", Fields: graphql.Fields{
+		"repositoryID":    &graphql.Field{Type: graphql.ID},
+		"companyID":       &graphql.Field{Type: graphql.ID},
+		"name":            &graphql.Field{Type: graphql.String},
+		"description":     &graphql.Field{Type: graphql.String},
+		"createdAt":       &graphql.Field{Type: graphql.DateTime},
+		"analytics":       &graphql.Field{Type: analyticsType, Args: dateArgs(), Resolve: c.resolveAnalytics},
+		"analyses":        &graphql.Field{Type: analysisConnection, Args: analysisArgs(), Resolve: c.resolveAnalyses},
+		"vulnerabilities": c.newVulnerabilitiesField(vulnerabilityConnection),
+	}})
+}
+
+func (c *Controller) newAnalysisType(vulnerabilityConnection *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{Name: "Analysis", Fields: graphql.Fields{
+		"id":              &graphql.Field{Type: graphql.ID},
+		"repositoryID":    &graphql.Field{Type: graphql.ID},
+		"repositoryName":  &graphql.Field{Type: graphql.String},
+		"companyID":       &graphql.Field{Type: graphql.ID},
+		"companyName":     &graphql.Field{Type: graphql.String},
+		"branch":          &graphql.Field{Type: graphql.String},
+		"commit":          &graphql.Field{Type: graphql.String},
+		"status":          &graphql.Field{Type: graphql.String},
+		"errors":          &graphql.Field{Type: graphql.String},
+		"createdAt":       &graphql.Field{Type: graphql.DateTime},
+		"finishedAt":      &graphql.Field{Type: graphql.DateTime},
+		"vulnerabilities": c.newVulnerabilitiesField(vulnerabilityConnection),
+	}})
+}
+
+func (c *Controller) newVulnerabilitiesField(vulnerabilityConnection *graphql.Object) *graphql.Field {
+	return &graphql.Field{Type: vulnerabilityConnection, Args: vulnerabilityArgs(), Resolve: c.resolveVulnerabilities}
+}
+
+func newVulnerabilityType() *graphql.Object {
+	fields := graphql.Fields{
+		"vulnerabilityID":   &graphql.Field{Type: graphql.ID},
+		"repositoryID":      &graphql.Field{Type: graphql.ID},
+		"companyID":         &graphql.Field{Type: graphql.ID},
+		"finishedAt":        &graphql.Field{Type: graphql.DateTime},
+		"riskAcceptedUntil": &graphql.Field{Type: graphql.DateTime},
+	}
+
+	for _, name := range []string{"repositoryName", "companyName", "line", "column", "confidence", "file", "code",
+		"details", "ruleID", "securityTool", "language", "severity", "vulnHash", "type", "commitAuthor", "commitEmail",
+		"commitHash", "commitMessage", "commitDate"} {
+		fields[name] = &graphql.Field{Type: graphql.String}
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{Name: "Vulnerability", Fields: fields})
+}
+
+func (c *Controller) newAnalyticsType() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{Name: "Analytics", Fields: graphql.Fields{
+		"totalDevelopers":   &graphql.Field{Type: graphql.Int, Resolve: c.resolveTotalDevelopers},
+		"totalRepositories": &graphql.Field{Type: graphql.Int, Resolve: c.resolveTotalRepositories},
+		"vulnBySeverity": &graphql.Field{
+			Type: graphql.NewList(graphql.NewObject(graphql.ObjectConfig{Name: "VulnBySeverity",
+				Fields: graphql.Fields{
+					"severity": &graphql.Field{Type: graphql.String},
+					"total":    &graphql.Field{Type: graphql.Int},
+				}})),
+			Resolve: c.resolveVulnBySeverity,
+		},
+		"vulnByLanguage": &graphql.Field{
+			Type:    graphql.NewList(newTotalsBySeverityType("VulnByLanguage", "language", graphql.String)),
+			Resolve: c.resolveVulnByLanguage,
+		},
+		"vulnByDeveloper": &graphql.Field{
+			Type:    graphql.NewList(newTotalsBySeverityType("VulnByDeveloper", "developer", graphql.String)),
+			Resolve: c.resolveVulnByDeveloper,
+		},
+		"vulnByRepository": &graphql.Field{
+			Type:    graphql.NewList(newTotalsBySeverityType("VulnByRepository", "repository", graphql.String)),
+			Resolve: c.resolveVulnByRepository,
+		},
+		"vulnByTime": &graphql.Field{
+			Type:    graphql.NewList(newTotalsBySeverityType("VulnByTime", "time", graphql.DateTime)),
+			Resolve: c.resolveVulnByTime,
+		},
+		"vulnBySecurityTool": &graphql.Field{
+			Type:    graphql.NewList(newFalsePositiveRateType("VulnBySecurityTool", "securityTool")),
+			Resolve: c.resolveVulnBySecurityTool,
+		},
+		"vulnByRule": &graphql.Field{
+			Type:    graphql.NewList(newFalsePositiveRateType("VulnByRule", "securityTool", "ruleID")),
+			Resolve: c.resolveVulnByRule,
+		},
+		"timeToFix": &graphql.Field{Type: graphql.NewList(newTimeToFixType()), Resolve: c.resolveTimeToFix},
+		"vulnAging": &graphql.Field{
+			Type:    graphql.NewList(newTotalsBySeverityType("VulnAging", "range", graphql.String)),
+			Resolve: c.resolveVulnAging,
+		},
+		"scoreHistory":      &graphql.Field{Type: graphql.NewList(newSecurityScoreType()), Resolve: c.resolveScoreHistory},
+		"repositoryRanking": &graphql.Field{Type: graphql.NewList(newRepositoryScoreType()), Resolve: c.resolveRanking},
+	}})
+}
+
+func newTotalsBySeverityType(name, keyField string, keyType graphql.Output) *graphql.Object {
+	fields := graphql.Fields{keyField: &graphql.Field{Type: keyType}}
+	for _, total := range []string{"total", "low", "medium", "high", "audit", "info", "noSec"} {
+		fields[total] = &graphql.Field{Type: graphql.Int}
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{Name: name, Fields: fields})
+}
+
+func newFalsePositiveRateType(name string, keyFields ...string) *graphql.Object {
+	fields := graphql.Fields{
+		"total":             &graphql.Field{Type: graphql.Int},
+		"falsePositive":     &graphql.Field{Type: graphql.Int},
+		"riskAccepted":      &graphql.Field{Type: graphql.Int},
+		"falsePositiveRate": &graphql.Field{Type: graphql.Float},
+	}
+
+	for _, keyField := range keyFields {
+		fields[keyField] = &graphql.Field{Type: graphql.String}
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{Name: name, Fields: fields})
+}
+
+func newTimeToFixType() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{Name: "TimeToFix", Fields: graphql.Fields{
+		"severity":    &graphql.Field{Type: graphql.String},
+		"fixed":       &graphql.Field{Type: graphql.Int},
+		"open":        &graphql.Field{Type: graphql.Int},
+		"meanInHours": &graphql.Field{Type: graphql.Float},
+		"p50InHours":  &graphql.Field{Type: graphql.Float},
+		"p90InHours":  &graphql.Field{Type: graphql.Float},
+	}})
+}
+
+func newSecurityScoreType() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{Name: "SecurityScore", Fields: graphql.Fields{
+		"time":                &graphql.Field{Type: graphql.DateTime},
+		"score":               &graphql.Field{Type: graphql.Float},
+		"repositories":        &graphql.Field{Type: graphql.Int},
+		"openVulnerabilities": &graphql.Field{Type: graphql.Int},
+	}})
+}
+
+func newRepositoryScoreType() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{Name: "RepositoryScore", Fields: graphql.Fields{
+		"repositoryID":        &graphql.Field{Type: graphql.ID},
+		"repositoryName":      &graphql.Field{Type: graphql.String},
+		"score":               &graphql.Field{Type: graphql.Float},
+		"openVulnerabilities": &graphql.Field{Type: graphql.Int},
+		"riskAccepted":        &graphql.Field{Type: graphql.Int},
+	}})
+}
+
+func dateArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"initialDate": &graphql.ArgumentConfig{Type: graphql.DateTime},
+		"finalDate":   &graphql.ArgumentConfig{Type: graphql.DateTime},
+	}
+}
+
+func analysisArgs() graphql.FieldConfigArgument {
+	args := mergeArgs(paginationArgs(), dateArgs())
+	for _, name := range []string{"status", "branch"} {
+		args[name] = &graphql.ArgumentConfig{Type: graphql.String}
+	}
+
+	return args
+}
+
+func vulnerabilityArgs() graphql.FieldConfigArgument {
+	args := mergeArgs(paginationArgs(), dateArgs())
+	for _, name := range []string{"severity", "type", "language", "securityTool"} {
+		args[name] = &graphql.ArgumentConfig{Type: graphql.String}
+	}
+
+	return args
+}
+
+func mergeArgs(args, others graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	for name, arg := range others {
+		args[name] = arg
+	}
+
+	return args
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	snapshotRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/snapshot"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+)
+
+const backfillPageSize = 100
+
+type IController interface {
+	Backfill(initialDate time.Time) (int, error)
+}
+
+type Controller struct {
+	repository snapshotRepository.ISnapshotRepository
+}
+
+func NewSnapshotController(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite) IController {
+	return &Controller{
+		repository: snapshotRepository.NewSnapshotRepository(postgresRead, postgresWrite),
+	}
+}
+
+// Backfill refreshes the daily snapshots with the analysis finished since the initial date, newest first so only
+// the latest analysis of each repository by day is written, it returns the total of analysis read
+func (c *Controller) Backfill(initialDate time.Time) (int, error) {
+	total := 0
+	for page := 1; ; page++ {
+		analysis, err := c.repository.ListAnalysisToRefresh(initialDate, page, backfillPageSize)
+		if err != nil {
+			return total, err
+		}
+
+		if err := c.refreshAll(analysis); err != nil {
+			return total, err
+		}
+
+		total += len(analysis)
+		logger.LogInfo("{Backfill} daily snapshots refreshed, total of analysis read:", total)
+		if len(analysis) < backfillPageSize {
+			return total, nil
+		}
+	}
+}
+
+func (c *Controller) refreshAll(analysis []horusec.Analysis) error {
+	for index := range analysis {
+		if err := c.repository.Refresh(&analysis[index]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
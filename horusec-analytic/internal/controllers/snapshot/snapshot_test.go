@@ -0,0 +1,71 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	snapshotRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/snapshot"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSnapshotController(t *testing.T) {
+	t.Run("should create a new controller", func(t *testing.T) {
+		assert.NotNil(t, NewSnapshotController(&relational.MockRead{}, &relational.MockWrite{}))
+	})
+}
+
+func TestBackfill(t *testing.T) {
+	t.Run("should refresh the analysis of all pages", func(t *testing.T) {
+		repositoryMock := &snapshotRepository.Mock{}
+		repositoryMock.On("ListAnalysisToRefresh").Once().Return(make([]horusec.Analysis, backfillPageSize), nil)
+		repositoryMock.On("ListAnalysisToRefresh").Once().Return(make([]horusec.Analysis, 2), nil)
+		repositoryMock.On("Refresh").Return(nil)
+		controller := &Controller{repository: repositoryMock}
+
+		total, err := controller.Backfill(time.Time{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, backfillPageSize+2, total)
+		repositoryMock.AssertNumberOfCalls(t, "ListAnalysisToRefresh", 2)
+		repositoryMock.AssertNumberOfCalls(t, "Refresh", backfillPageSize+2)
+	})
+
+	t.Run("should return error when list analysis fails", func(t *testing.T) {
+		repositoryMock := &snapshotRepository.Mock{}
+		repositoryMock.On("ListAnalysisToRefresh").Return([]horusec.Analysis{}, errors.New("test"))
+		controller := &Controller{repository: repositoryMock}
+
+		_, err := controller.Backfill(time.Time{})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when refresh fails", func(t *testing.T) {
+		repositoryMock := &snapshotRepository.Mock{}
+		repositoryMock.On("ListAnalysisToRefresh").Return(make([]horusec.Analysis, 1), nil)
+		repositoryMock.On("Refresh").Return(errors.New("test"))
+		controller := &Controller{repository: repositoryMock}
+
+		total, err := controller.Backfill(time.Time{})
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, total)
+	})
+}
@@ -27,6 +27,7 @@ import (
 
 	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
+	"github.com/ZupIT/horusec/horusec-analytic/config/app"
 	"github.com/ZupIT/horusec/horusec-analytic/internal/controllers/dashboard"
 	"github.com/graphql-go/graphql"
 )
@@ -35,9 +36,9 @@ type Handler struct {
 	controller dashboard.IController
 }
 
-func NewDashboardHandler(postgresRead relational.InterfaceRead) *Handler {
+func NewDashboardHandler(postgresRead relational.InterfaceRead, appConfig app.IAppConfig) *Handler {
 	return &Handler{
-		controller: dashboard.NewDashboardController(postgresRead),
+		controller: dashboard.NewDashboardController(postgresRead, appConfig),
 	}
 }
 
@@ -501,6 +502,189 @@ func (h *Handler) GetRepositoryVulnBySeverity(w netHTTP.ResponseWriter, r *netHT
 	httpUtil.StatusOK(w, result)
 }
 
+// @Tags Dashboard Company
+// @Description get first and last seen of each vulnerability
+// @ID company-vulnerabilities-lifecycle
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the company"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/vulnerabilities-lifecycle [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetCompanyVulnLifecycle(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, _ := uuid.Parse(chi.URLParam(r, "companyID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetVulnLifecycle(companyID, uuid.Nil, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
+// @Tags Dashboard Company
+// @Description get mean and percentiles of the time to fix vulnerabilities by severity
+// @ID company-time-to-fix
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the company"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/time-to-fix [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetCompanyTimeToFix(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, _ := uuid.Parse(chi.URLParam(r, "companyID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetTimeToFix(companyID, uuid.Nil, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
+// @Tags Dashboard Company
+// @Description get histogram of open vulnerabilities by days since first seen
+// @ID company-vulnerabilities-aging
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the company"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/vulnerabilities-aging [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetCompanyVulnAging(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, _ := uuid.Parse(chi.URLParam(r, "companyID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetVulnAging(companyID, uuid.Nil, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
+// @Tags Dashboard Repository
+// @Description get first and last seen of each vulnerability
+// @ID repository-vulnerabilities-lifecycle
+// @Accept  json
+// @Produce  json
+// @Param repositoryID path string true "repositoryID of the repository"
+// @Param companyID path string true "companyID of the company"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/repositories/{repositoryID}/vulnerabilities-lifecycle [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetRepositoryVulnLifecycle(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	repositoryID, _ := uuid.Parse(chi.URLParam(r, "repositoryID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetVulnLifecycle(uuid.Nil, repositoryID, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
+// @Tags Dashboard Repository
+// @Description get mean and percentiles of the time to fix vulnerabilities by severity
+// @ID repository-time-to-fix
+// @Accept  json
+// @Produce  json
+// @Param repositoryID path string true "repositoryID of the repository"
+// @Param companyID path string true "companyID of the company"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/repositories/{repositoryID}/time-to-fix [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetRepositoryTimeToFix(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	repositoryID, _ := uuid.Parse(chi.URLParam(r, "repositoryID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetTimeToFix(uuid.Nil, repositoryID, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
+// @Tags Dashboard Repository
+// @Description get histogram of open vulnerabilities by days since first seen
+// @ID repository-vulnerabilities-aging
+// @Accept  json
+// @Produce  json
+// @Param repositoryID path string true "repositoryID of the repository"
+// @Param companyID path string true "companyID of the company"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/repositories/{repositoryID}/vulnerabilities-aging [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetRepositoryVulnAging(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	repositoryID, _ := uuid.Parse(chi.URLParam(r, "repositoryID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetVulnAging(uuid.Nil, repositoryID, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
 func getDateRangeFromRequestQuery(r *netHTTP.Request) (*time.Time, *time.Time, error) {
 	initial, err := getDateFromRequestQuery(r, "initialDate")
 	if err != nil {
@@ -547,3 +731,215 @@ func (h *Handler) getErrorFromGraphQlResponse(result *graphql.Result) error {
 
 	return errors.New(errorMsg)
 }
+
+// @Tags Dashboard Company
+// @Description get vulnerabilities and false positive rate by security tool
+// @ID company-vulnerabilities-by-tool
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the company"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/vulnerabilities-by-tool [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetCompanyVulnBySecurityTool(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, _ := uuid.Parse(chi.URLParam(r, "companyID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetVulnBySecurityTool(companyID, uuid.Nil, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
+// @Tags Dashboard Company
+// @Description get the rules with more false positives with their false positive rate
+// @ID company-vulnerabilities-by-rule
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the company"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/vulnerabilities-by-rule [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetCompanyVulnByRule(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, _ := uuid.Parse(chi.URLParam(r, "companyID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetVulnByRule(companyID, uuid.Nil, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
+// @Tags Dashboard Repository
+// @Description get vulnerabilities and false positive rate by security tool
+// @ID repository-vulnerabilities-by-tool
+// @Accept  json
+// @Produce  json
+// @Param repositoryID path string true "repositoryID of the repository"
+// @Param companyID path string true "companyID of the company"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/repositories/{repositoryID}/vulnerabilities-by-tool [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetRepositoryVulnBySecurityTool(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	repositoryID, _ := uuid.Parse(chi.URLParam(r, "repositoryID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetVulnBySecurityTool(uuid.Nil, repositoryID, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
+// @Tags Dashboard Repository
+// @Description get the rules with more false positives with their false positive rate
+// @ID repository-vulnerabilities-by-rule
+// @Accept  json
+// @Produce  json
+// @Param repositoryID path string true "repositoryID of the repository"
+// @Param companyID path string true "companyID of the company"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/repositories/{repositoryID}/vulnerabilities-by-rule [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetRepositoryVulnByRule(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	repositoryID, _ := uuid.Parse(chi.URLParam(r, "repositoryID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetVulnByRule(uuid.Nil, repositoryID, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
+// @Tags Dashboard Company
+// @Description get the company security score at the start of each month of the period and at the final date
+// @ID company-score-history
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the company"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/score-history [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetCompanyScoreHistory(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, _ := uuid.Parse(chi.URLParam(r, "companyID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetScoreHistory(companyID, uuid.Nil, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
+// @Tags Dashboard Company
+// @Description get the ranking of the company repositories by security score at the final date
+// @ID company-score-ranking
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the company"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/score-ranking [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetCompanyRepositoryScores(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	companyID, _ := uuid.Parse(chi.URLParam(r, "companyID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetRepositoryScores(companyID, uuid.Nil, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
+// @Tags Dashboard Repository
+// @Description get the repository security score at the start of each month of the period and at the final date
+// @ID repository-score-history
+// @Accept  json
+// @Produce  json
+// @Param repositoryID path string true "repositoryID of the repository"
+// @Param companyID path string true "companyID of the company"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 500 "INTERNAL SERVER ERROR"
+// @Router /analytic/dashboard/companies/{companyID}/repositories/{repositoryID}/score-history [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetRepositoryScoreHistory(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	repositoryID, _ := uuid.Parse(chi.URLParam(r, "repositoryID"))
+	initialDate, finalDate, err := getDateRangeFromRequestQuery(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	result, err := h.controller.GetScoreHistory(uuid.Nil, repositoryID, *initialDate, *finalDate)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
@@ -840,3 +840,76 @@ func TestGetRepositoryVulnBySeverity(t *testing.T) {
 		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 	})
 }
+
+func TestGetRemediationMetrics(t *testing.T) {
+	companyHandlers := map[string]func(handler *Handler) http.HandlerFunc{
+		"GetVulnLifecycle": func(handler *Handler) http.HandlerFunc { return handler.GetCompanyVulnLifecycle },
+		"GetTimeToFix":     func(handler *Handler) http.HandlerFunc { return handler.GetCompanyTimeToFix },
+		"GetVulnAging":     func(handler *Handler) http.HandlerFunc { return handler.GetCompanyVulnAging },
+		"GetVulnBySecurityTool": func(handler *Handler) http.HandlerFunc {
+			return handler.GetCompanyVulnBySecurityTool
+		},
+		"GetVulnByRule":   func(handler *Handler) http.HandlerFunc { return handler.GetCompanyVulnByRule },
+		"GetScoreHistory": func(handler *Handler) http.HandlerFunc { return handler.GetCompanyScoreHistory },
+		"GetRepositoryScores": func(handler *Handler) http.HandlerFunc {
+			return handler.GetCompanyRepositoryScores
+		},
+	}
+	repositoryHandlers := map[string]func(handler *Handler) http.HandlerFunc{
+		"GetVulnLifecycle": func(handler *Handler) http.HandlerFunc { return handler.GetRepositoryVulnLifecycle },
+		"GetTimeToFix":     func(handler *Handler) http.HandlerFunc { return handler.GetRepositoryTimeToFix },
+		"GetVulnAging":     func(handler *Handler) http.HandlerFunc { return handler.GetRepositoryVulnAging },
+		"GetVulnBySecurityTool": func(handler *Handler) http.HandlerFunc {
+			return handler.GetRepositoryVulnBySecurityTool
+		},
+		"GetVulnByRule":   func(handler *Handler) http.HandlerFunc { return handler.GetRepositoryVulnByRule },
+		"GetScoreHistory": func(handler *Handler) http.HandlerFunc { return handler.GetRepositoryScoreHistory },
+	}
+	results := map[string]interface{}{
+		"GetVulnLifecycle":      []dashboardEntities.VulnLifecycle{},
+		"GetTimeToFix":          []dashboardEntities.TimeToFix{},
+		"GetVulnAging":          []dashboardEntities.VulnAging{},
+		"GetVulnBySecurityTool": []dashboardEntities.VulnBySecurityTool{},
+		"GetVulnByRule":         []dashboardEntities.VulnByRule{},
+		"GetScoreHistory":       []dashboardEntities.SecurityScore{},
+		"GetRepositoryScores":   []dashboardEntities.RepositoryScore{},
+	}
+
+	for _, scope := range []map[string]func(handler *Handler) http.HandlerFunc{companyHandlers, repositoryHandlers} {
+		for method, getHandlerFunc := range scope {
+			t.Run("should return status code 200 when "+method+" success", func(t *testing.T) {
+				controllerMock := &dashboardController.Mock{}
+				controllerMock.On(method).Return(results[method], nil)
+
+				r, _ := http.NewRequest(http.MethodGet,
+					"api/dashboard?finalDate=2006-01-02T15:04:05Z&initialDate=2006-01-02T15:04:05Z", nil)
+				w := httptest.NewRecorder()
+
+				getHandlerFunc(&Handler{controller: controllerMock})(w, r)
+
+				assert.Equal(t, http.StatusOK, w.Code)
+			})
+
+			t.Run("should return status code 500 when "+method+" fails", func(t *testing.T) {
+				controllerMock := &dashboardController.Mock{}
+				controllerMock.On(method).Return(results[method], errors.New("test"))
+
+				r, _ := http.NewRequest(http.MethodGet, "api/dashboard", nil)
+				w := httptest.NewRecorder()
+
+				getHandlerFunc(&Handler{controller: controllerMock})(w, r)
+
+				assert.Equal(t, http.StatusInternalServerError, w.Code)
+			})
+
+			t.Run("should return status code 422 when "+method+" has invalid dates", func(t *testing.T) {
+				r, _ := http.NewRequest(http.MethodGet, "api/dashboard?initialDate=invalid", nil)
+				w := httptest.NewRecorder()
+
+				getHandlerFunc(&Handler{controller: &dashboardController.Mock{}})(w, r)
+
+				assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+			})
+		}
+	}
+}
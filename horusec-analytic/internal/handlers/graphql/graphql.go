@@ -0,0 +1,72 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"encoding/json"
+	netHTTP "net/http"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	dashboardEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
+	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
+	"github.com/ZupIT/horusec/horusec-analytic/config/app"
+	"github.com/ZupIT/horusec/horusec-analytic/internal/controllers/graphql"
+	"google.golang.org/grpc"
+)
+
+type Handler struct {
+	controller graphql.IController
+}
+
+func NewGraphQLHandler(postgresRead relational.InterfaceRead, appConfig app.IAppConfig,
+	grpcCon grpc.ClientConnInterface) *Handler {
+	return &Handler{
+		controller: graphql.NewGraphQLController(postgresRead, appConfig, grpcCon),
+	}
+}
+
+func (h *Handler) Options(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	httpUtil.StatusNoContent(w)
+}
+
+// @Tags GraphQL
+// @Description execute a query on the analytics schema, each field is authorized with the token of the request
+// @ID graphql
+// @Accept  json
+// @Produce  json
+// @Param GraphQLRequest body dashboard.GraphQLRequest true "graphql query, variables and operation name"
+// @Success 200 "OK"
+// @Failure 400 "BAD REQUEST"
+// @Failure 401 "UNAUTHORIZED"
+// @Router /analytic/graphql [post]
+// @Security ApiKeyAuth
+func (h *Handler) Post(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	request, err := h.getGraphQLRequest(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, h.controller.Execute(r.Header.Get("X-Horusec-Authorization"), request))
+}
+
+func (h *Handler) getGraphQLRequest(r *netHTTP.Request) (*dashboardEntities.GraphQLRequest, error) {
+	request := &dashboardEntities.GraphQLRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		return nil, err
+	}
+
+	return request, request.Validate()
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	dashboardEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
+	"github.com/ZupIT/horusec/horusec-analytic/config/app"
+	graphqlController "github.com/ZupIT/horusec/horusec-analytic/internal/controllers/graphql"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestNewGraphQLHandler(t *testing.T) {
+	t.Run("should create a new handler", func(t *testing.T) {
+		assert.NotNil(t, NewGraphQLHandler(&relational.MockRead{}, app.SetupApp(), &grpc.ClientConn{}))
+	})
+}
+
+func TestOptions(t *testing.T) {
+	t.Run("should return status code 204 when options", func(t *testing.T) {
+		handler := &Handler{controller: &graphqlController.Mock{}}
+		r, _ := http.NewRequest(http.MethodOptions, "api/graphql", nil)
+		w := httptest.NewRecorder()
+
+		handler.Options(w, r)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
+func TestPost(t *testing.T) {
+	t.Run("should return status code 200 when execute query", func(t *testing.T) {
+		controllerMock := &graphqlController.Mock{}
+		controllerMock.On("Execute").Return(&graphql.Result{Data: map[string]interface{}{}})
+		handler := &Handler{controller: controllerMock}
+
+		body := (&dashboardEntities.GraphQLRequest{Query: "{ company(companyID: \"test\") { name } }"}).ToBytes()
+		r, _ := http.NewRequest(http.MethodPost, "api/graphql", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.Post(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return status code 400 when query is missing", func(t *testing.T) {
+		handler := &Handler{controller: &graphqlController.Mock{}}
+
+		body := (&dashboardEntities.GraphQLRequest{}).ToBytes()
+		r, _ := http.NewRequest(http.MethodPost, "api/graphql", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.Post(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return status code 400 when body is invalid", func(t *testing.T) {
+		handler := &Handler{controller: &graphqlController.Mock{}}
+
+		r, _ := http.NewRequest(http.MethodPost, "api/graphql", bytes.NewReader([]byte("invalid")))
+		w := httptest.NewRecorder()
+
+		handler.Post(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/http/server"
+	"github.com/ZupIT/horusec/horusec-analytic/config/app"
 	"github.com/go-chi/cors"
 	"github.com/stretchr/testify/assert"
 )
@@ -34,7 +35,7 @@ func TestGetRouter(t *testing.T) {
 		router := NewRouter(server.NewServerConfig("8005", &cors.Options{}))
 		assert.NotNil(t, router)
 
-		mux := router.GetRouter(nil, nil)
+		mux := router.GetRouter(nil, app.SetupApp(), nil)
 		assert.NotNil(t, mux)
 	})
 }
@@ -17,9 +17,12 @@ package router
 
 import (
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/middlewares"
 	configUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http/server"
+	"github.com/ZupIT/horusec/horusec-analytic/config/app"
 	"github.com/ZupIT/horusec/horusec-analytic/internal/handlers/dashboard"
+	"github.com/ZupIT/horusec/horusec-analytic/internal/handlers/graphql"
 	"github.com/ZupIT/horusec/horusec-analytic/internal/handlers/health"
 	"github.com/ZupIT/horusec/horusec-analytic/internal/router/routes"
 	"github.com/go-chi/chi"
@@ -51,10 +54,12 @@ func (r *Router) setMiddleware() {
 	r.RouterMetrics()
 }
 
-func (r *Router) GetRouter(postgresRead relational.InterfaceRead, grpcCon *grpc.ClientConn) *chi.Mux {
+func (r *Router) GetRouter(postgresRead relational.InterfaceRead, appConfig app.IAppConfig,
+	grpcCon *grpc.ClientConn) *chi.Mux {
 	r.setMiddleware()
-	r.RouterCompanyAnalytic(postgresRead, grpcCon)
-	r.RouterRepositoryAnalytic(postgresRead, grpcCon)
+	r.RouterCompanyAnalytic(postgresRead, appConfig, grpcCon)
+	r.RouterRepositoryAnalytic(postgresRead, appConfig, grpcCon)
+	r.RouterGraphQL(postgresRead, appConfig, grpcCon)
 	r.RouterHealth(postgresRead, grpcCon)
 	return r.router
 }
@@ -109,9 +114,10 @@ func (r *Router) RouterHealth(postgresRead relational.InterfaceRead, grpcCon *gr
 	return r
 }
 
-func (r *Router) RouterCompanyAnalytic(postgresRead relational.InterfaceRead, grpcCon *grpc.ClientConn) *Router {
-	handler := dashboard.NewDashboardHandler(postgresRead)
-	authz := middlewares.NewHorusAuthzMiddleware(grpcCon)
+func (r *Router) RouterCompanyAnalytic(postgresRead relational.InterfaceRead, appConfig app.IAppConfig,
+	grpcCon *grpc.ClientConn) *Router {
+	handler := dashboard.NewDashboardHandler(postgresRead, appConfig)
+	authz := middlewares.NewHorusAuthzMiddlewareWithScope(grpcCon, authEnums.ReadAnalytics)
 	r.router.Route(routes.CompanyHandler, func(router chi.Router) {
 		router.With(authz.IsCompanyAdmin).Get("/{companyID}/details", handler.GetVulnDetails)
 		router.With(authz.IsCompanyAdmin).Get("/{companyID}/total-developers", handler.GetCompanyTotalDevelopers)
@@ -122,15 +128,23 @@ func (r *Router) RouterCompanyAnalytic(postgresRead relational.InterfaceRead, gr
 		router.With(authz.IsCompanyAdmin).Get(
 			"/{companyID}/vulnerabilities-by-repository", handler.GetCompanyVulnByRepository)
 		router.With(authz.IsCompanyAdmin).Get("/{companyID}/vulnerabilities-by-time", handler.GetCompanyVulnByTime)
+		router.With(authz.IsCompanyAdmin).Get("/{companyID}/vulnerabilities-lifecycle", handler.GetCompanyVulnLifecycle)
+		router.With(authz.IsCompanyAdmin).Get("/{companyID}/time-to-fix", handler.GetCompanyTimeToFix)
+		router.With(authz.IsCompanyAdmin).Get("/{companyID}/vulnerabilities-aging", handler.GetCompanyVulnAging)
+		router.With(authz.IsCompanyAdmin).Get("/{companyID}/vulnerabilities-by-tool", handler.GetCompanyVulnBySecurityTool)
+		router.With(authz.IsCompanyAdmin).Get("/{companyID}/vulnerabilities-by-rule", handler.GetCompanyVulnByRule)
+		router.With(authz.IsCompanyAdmin).Get("/{companyID}/score-history", handler.GetCompanyScoreHistory)
+		router.With(authz.IsCompanyAdmin).Get("/{companyID}/score-ranking", handler.GetCompanyRepositoryScores)
 		router.Options("/", handler.Options)
 	})
 
 	return r
 }
 
-func (r *Router) RouterRepositoryAnalytic(postgresRead relational.InterfaceRead, grpcCon *grpc.ClientConn) *Router {
-	handler := dashboard.NewDashboardHandler(postgresRead)
-	authz := middlewares.NewHorusAuthzMiddleware(grpcCon)
+func (r *Router) RouterRepositoryAnalytic(postgresRead relational.InterfaceRead, appConfig app.IAppConfig,
+	grpcCon *grpc.ClientConn) *Router {
+	handler := dashboard.NewDashboardHandler(postgresRead, appConfig)
+	authz := middlewares.NewHorusAuthzMiddlewareWithScope(grpcCon, authEnums.ReadAnalytics)
 	r.router.Route(routes.RepositoryHandler, func(router chi.Router) {
 		router.With(authz.IsRepositoryMember).Get("/{repositoryID}/details", handler.GetVulnDetails)
 		router.With(authz.IsRepositoryMember).Get("/{repositoryID}/total-developers", handler.GetRepositoryTotalDevelopers)
@@ -144,7 +158,28 @@ func (r *Router) RouterRepositoryAnalytic(postgresRead relational.InterfaceRead,
 		router.With(authz.IsRepositoryMember).Get(
 			"/{repositoryID}/vulnerabilities-by-repository", handler.GetRepositoryVulnByRepository)
 		router.With(authz.IsRepositoryMember).Get("/{repositoryID}/vulnerabilities-by-time", handler.GetRepositoryVulnByTime)
+		router.With(authz.IsRepositoryMember).Get(
+			"/{repositoryID}/vulnerabilities-lifecycle", handler.GetRepositoryVulnLifecycle)
+		router.With(authz.IsRepositoryMember).Get("/{repositoryID}/time-to-fix", handler.GetRepositoryTimeToFix)
+		router.With(authz.IsRepositoryMember).Get("/{repositoryID}/vulnerabilities-aging", handler.GetRepositoryVulnAging)
+		router.With(authz.IsRepositoryMember).Get(
+			"/{repositoryID}/vulnerabilities-by-tool", handler.GetRepositoryVulnBySecurityTool)
+		router.With(authz.IsRepositoryMember).Get(
+			"/{repositoryID}/vulnerabilities-by-rule", handler.GetRepositoryVulnByRule)
+		router.With(authz.IsRepositoryMember).Get("/{repositoryID}/score-history", handler.GetRepositoryScoreHistory)
 		router.Options("/", handler.Options)
 	})
 	return r
 }
+
+func (r *Router) RouterGraphQL(postgresRead relational.InterfaceRead, appConfig app.IAppConfig,
+	grpcCon *grpc.ClientConn) *Router {
+	handler := graphql.NewGraphQLHandler(postgresRead, appConfig, grpcCon)
+	authz := middlewares.NewHorusAuthzMiddlewareWithScope(grpcCon, authEnums.ReadAnalytics)
+	r.router.Route(routes.GraphQLHandler, func(router chi.Router) {
+		router.With(authz.SetContextAccountID).Post("/", handler.Post)
+		router.Options("/", handler.Options)
+	})
+
+	return r
+}
@@ -16,6 +16,7 @@ package routes
 
 const (
 	HealthHandler     = "/analytic/health"
+	GraphQLHandler    = "/analytic/graphql"
 	CompanyHandler    = "/analytic/dashboard/companies"
 	RepositoryHandler = "/analytic/dashboard/companies/{companyID}/repositories"
 )
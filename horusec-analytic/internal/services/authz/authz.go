@@ -0,0 +1,68 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	authGrpc "github.com/ZupIT/horusec/development-kit/pkg/services/grpc/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+)
+
+type IService interface {
+	IsAuthorized(token string, role authEnums.HorusecRoles, companyID, repositoryID uuid.UUID) bool
+}
+
+type Service struct {
+	grpcClient authGrpc.AuthServiceClient
+	ctx        context.Context
+}
+
+// NewAuthzService authorizes the requests that cannot be checked by the route middlewares, the tokens must have the
+// read analytics scope like the dashboard routes
+func NewAuthzService(grpcCon grpc.ClientConnInterface) IService {
+	return &Service{
+		grpcClient: authGrpc.NewAuthServiceClient(grpcCon),
+		ctx:        context.Background(),
+	}
+}
+
+func (s *Service) IsAuthorized(token string, role authEnums.HorusecRoles, companyID, repositoryID uuid.UUID) bool {
+	response, err := s.grpcClient.IsAuthorized(s.ctx, &authGrpc.IsAuthorizedData{
+		Token:        token,
+		Role:         role.ToString(),
+		CompanyID:    s.getIDString(companyID),
+		RepositoryID: s.getIDString(repositoryID),
+		Scope:        authEnums.ReadAnalytics.ToString(),
+	})
+	if err != nil {
+		logger.LogError(errors.SomethingWentWrongInGrpcRequest, err)
+		return false
+	}
+
+	return response.GetIsAuthorized()
+}
+
+func (s *Service) getIDString(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+
+	return id.String()
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"errors"
+	"testing"
+
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	authGrpc "github.com/ZupIT/horusec/development-kit/pkg/services/grpc/auth"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestNewAuthzService(t *testing.T) {
+	t.Run("should create a new service", func(t *testing.T) {
+		assert.NotNil(t, NewAuthzService(&grpc.ClientConn{}))
+	})
+}
+
+func TestIsAuthorized(t *testing.T) {
+	t.Run("should return true when authorized", func(t *testing.T) {
+		grpcMock := &authGrpc.Mock{}
+		grpcMock.On("IsAuthorized").Return(&authGrpc.IsAuthorizedResponse{IsAuthorized: true}, nil)
+		service := &Service{grpcClient: grpcMock}
+
+		assert.True(t, service.IsAuthorized("token", authEnums.CompanyAdmin, uuid.New(), uuid.Nil))
+	})
+
+	t.Run("should return false when not authorized", func(t *testing.T) {
+		grpcMock := &authGrpc.Mock{}
+		grpcMock.On("IsAuthorized").Return(&authGrpc.IsAuthorizedResponse{IsAuthorized: false}, nil)
+		service := &Service{grpcClient: grpcMock}
+
+		assert.False(t, service.IsAuthorized("token", authEnums.RepositoryMember, uuid.New(), uuid.New()))
+	})
+
+	t.Run("should return false when grpc request fails", func(t *testing.T) {
+		grpcMock := &authGrpc.Mock{}
+		grpcMock.On("IsAuthorized").Return(&authGrpc.IsAuthorizedResponse{}, errors.New("test"))
+		service := &Service{grpcClient: grpcMock}
+
+		assert.False(t, service.IsAuthorized("token", authEnums.CompanyMember, uuid.New(), uuid.Nil))
+	})
+}
@@ -19,16 +19,22 @@ import (
 	"net/http"
 
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
 	"github.com/ZupIT/horusec/horusec-api/config/app"
 	brokerConfig "github.com/ZupIT/horusec/horusec-api/config/broker"
 	"github.com/ZupIT/horusec/horusec-api/config/grpc"
 	"github.com/go-chi/chi"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/adapter"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/lock"
 
 	serverUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http/server"
 	"github.com/ZupIT/horusec/horusec-api/config/cors"
 	"github.com/ZupIT/horusec/horusec-api/config/swagger"
+	"github.com/ZupIT/horusec/horusec-api/internal/controllers/retention"
+	"github.com/ZupIT/horusec/horusec-api/internal/controllers/riskaccept"
+	"github.com/ZupIT/horusec/horusec-api/internal/controllers/tokens/expiration"
+	"github.com/ZupIT/horusec/horusec-api/internal/jobs"
 	"github.com/ZupIT/horusec/horusec-api/internal/router"
 )
 
@@ -44,15 +50,24 @@ import (
 // @in header
 // @name X-Horusec-Authorization
 func main() {
+	defer tracing.SetUpTracing("horusec-api")()
+
 	var broker brokerLib.IBroker
 
 	postgresRead := adapter.NewRepositoryRead()
 	postgresWrite := adapter.NewRepositoryWrite()
 	appConfig := app.SetupApp()
 	if !appConfig.IsDisabledBroker() {
-		broker = brokerConfig.SetUp()
+		broker = brokerConfig.SetUp(postgresRead, postgresWrite)
 	}
 
+	jobs.NewRiskAcceptJob(riskaccept.NewRiskAcceptController(postgresRead, postgresWrite, broker, appConfig),
+		lock.NewLockRepository(postgresWrite), appConfig.GetRiskAcceptJobInterval()).Start()
+	jobs.NewTokenExpirationJob(expiration.NewTokenExpirationController(postgresRead, postgresWrite, broker, appConfig),
+		appConfig.GetTokenExpirationJobInterval()).Start()
+	jobs.NewRetentionJob(retention.NewRetentionController(postgresRead, postgresWrite, appConfig),
+		appConfig.GetAnalysisRetentionJobInterval()).Start()
+
 	server := serverUtil.NewServerConfig("8000", cors.NewCorsConfig()).Timeout(10)
 	chiRouter := router.NewRouter(server).
 		GetRouter(postgresRead, postgresWrite, broker, appConfig, grpc.SetupGrpcConnection())
@@ -15,27 +15,116 @@
 package app
 
 import (
+	"strings"
+	"time"
+
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
 )
 
 const (
-	DisabledBrokerEnv = "HORUSEC_DISABLED_BROKER"
+	DisabledBrokerEnv                 = "HORUSEC_DISABLED_BROKER"
+	RiskAcceptJobIntervalEnv          = "HORUSEC_RISK_ACCEPT_JOB_INTERVAL_IN_MINUTES"
+	RiskAcceptWarningInDaysEnv        = "HORUSEC_RISK_ACCEPT_WARNING_IN_DAYS"
+	TokenExpirationJobIntervalEnv     = "HORUSEC_TOKEN_EXPIRATION_JOB_INTERVAL_IN_MINUTES"
+	TokenExpirationWarningInDaysEnv   = "HORUSEC_TOKEN_EXPIRATION_WARNING_IN_DAYS"
+	AnalysisRetentionJobIntervalEnv   = "HORUSEC_ANALYSIS_RETENTION_JOB_INTERVAL_IN_MINUTES"
+	AnalysisRetentionBatchSizeEnv     = "HORUSEC_ANALYSIS_RETENTION_BATCH_SIZE"
+	DailySnapshotsEnv                 = "HORUSEC_DASHBOARD_DAILY_SNAPSHOTS"
+	TrustedProxiesEnv                 = "HORUSEC_TRUSTED_PROXIES"
+	DefaultRiskAcceptJobInterval      = 60
+	DefaultRiskAcceptWarningDays      = 7
+	DefaultTokenExpirationJobInterval = 60
+	DefaultTokenExpirationWarningDays = 7
+	DefaultAnalysisRetentionInterval  = 1440
+	DefaultAnalysisRetentionBatchSize = 500
 )
 
 type Config struct {
-	DisabledBroker bool
+	DisabledBroker               bool
+	RiskAcceptJobInterval        int
+	RiskAcceptWarningInDays      int
+	TokenExpirationJobInterval   int
+	TokenExpirationWarningInDays int
+	AnalysisRetentionJobInterval int
+	AnalysisRetentionBatchSize   int
+	DailySnapshots               bool
+	TrustedProxies               string
 }
 
 type IAppConfig interface {
 	IsDisabledBroker() bool
+	GetRiskAcceptJobInterval() time.Duration
+	GetRiskAcceptWarningInDays() int
+	GetTokenExpirationJobInterval() time.Duration
+	GetTokenExpirationWarningInDays() int
+	GetAnalysisRetentionJobInterval() time.Duration
+	GetAnalysisRetentionBatchSize() int
+	IsDailySnapshotsEnabled() bool
+	GetTrustedProxies() []string
 }
 
 func SetupApp() IAppConfig {
 	return &Config{
-		DisabledBroker: env.GetEnvOrDefaultBool(DisabledBrokerEnv, false),
+		DisabledBroker:          env.GetEnvOrDefaultBool(DisabledBrokerEnv, false),
+		RiskAcceptJobInterval:   env.GetEnvOrDefaultInt(RiskAcceptJobIntervalEnv, DefaultRiskAcceptJobInterval),
+		RiskAcceptWarningInDays: env.GetEnvOrDefaultInt(RiskAcceptWarningInDaysEnv, DefaultRiskAcceptWarningDays),
+		TokenExpirationJobInterval: env.GetEnvOrDefaultInt(TokenExpirationJobIntervalEnv,
+			DefaultTokenExpirationJobInterval),
+		TokenExpirationWarningInDays: env.GetEnvOrDefaultInt(TokenExpirationWarningInDaysEnv,
+			DefaultTokenExpirationWarningDays),
+		AnalysisRetentionJobInterval: env.GetEnvOrDefaultInt(AnalysisRetentionJobIntervalEnv,
+			DefaultAnalysisRetentionInterval),
+		AnalysisRetentionBatchSize: env.GetEnvOrDefaultInt(AnalysisRetentionBatchSizeEnv,
+			DefaultAnalysisRetentionBatchSize),
+		DailySnapshots: env.GetEnvOrDefaultBool(DailySnapshotsEnv, true),
+		TrustedProxies: env.GetEnvOrDefault(TrustedProxiesEnv, ""),
 	}
 }
 
 func (a *Config) IsDisabledBroker() bool {
 	return a.DisabledBroker
 }
+
+func (a *Config) GetRiskAcceptJobInterval() time.Duration {
+	return time.Duration(a.RiskAcceptJobInterval) * time.Minute
+}
+
+func (a *Config) GetRiskAcceptWarningInDays() int {
+	return a.RiskAcceptWarningInDays
+}
+
+func (a *Config) GetTokenExpirationJobInterval() time.Duration {
+	return time.Duration(a.TokenExpirationJobInterval) * time.Minute
+}
+
+func (a *Config) GetTokenExpirationWarningInDays() int {
+	return a.TokenExpirationWarningInDays
+}
+
+func (a *Config) GetAnalysisRetentionJobInterval() time.Duration {
+	return time.Duration(a.AnalysisRetentionJobInterval) * time.Minute
+}
+
+// GetAnalysisRetentionBatchSize returns the default batch size when the env is not a positive number
+func (a *Config) GetAnalysisRetentionBatchSize() int {
+	if a.AnalysisRetentionBatchSize <= 0 {
+		return DefaultAnalysisRetentionBatchSize
+	}
+
+	return a.AnalysisRetentionBatchSize
+}
+
+// IsDailySnapshotsEnabled returns if horusec-analytic reads the dashboard totals from the daily snapshots, it must
+// have the same value of the env in horusec-analytic because the retention only prunes when it is enabled
+func (a *Config) IsDailySnapshotsEnabled() bool {
+	return a.DailySnapshots
+}
+
+// GetTrustedProxies returns the ips or cidrs allowed to send the client ip in forwarded headers
+func (a *Config) GetTrustedProxies() []string {
+	if strings.TrimSpace(a.TrustedProxies) == "" {
+		return []string{}
+	}
+
+	return strings.Split(a.TrustedProxies, ",")
+}
@@ -17,6 +17,7 @@ package app
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -40,3 +41,97 @@ func TestIsEmailConfirmationRequired(t *testing.T) {
 		assert.True(t, appConfig.IsDisabledBroker())
 	})
 }
+
+func TestGetRiskAcceptJobInterval(t *testing.T) {
+	t.Run("should return default interval in minutes", func(t *testing.T) {
+		appConfig := SetupApp()
+		assert.Equal(t, 60*time.Minute, appConfig.GetRiskAcceptJobInterval())
+	})
+
+	t.Run("should return interval from env", func(t *testing.T) {
+		_ = os.Setenv(RiskAcceptJobIntervalEnv, "5")
+		defer os.Unsetenv(RiskAcceptJobIntervalEnv)
+		appConfig := SetupApp()
+		assert.Equal(t, 5*time.Minute, appConfig.GetRiskAcceptJobInterval())
+	})
+}
+
+func TestGetRiskAcceptWarningInDays(t *testing.T) {
+	t.Run("should return default warning days", func(t *testing.T) {
+		appConfig := SetupApp()
+		assert.Equal(t, 7, appConfig.GetRiskAcceptWarningInDays())
+	})
+}
+
+func TestGetTokenExpirationJobInterval(t *testing.T) {
+	t.Run("should return default interval in minutes", func(t *testing.T) {
+		appConfig := SetupApp()
+		assert.Equal(t, 60*time.Minute, appConfig.GetTokenExpirationJobInterval())
+	})
+
+	t.Run("should return interval from env", func(t *testing.T) {
+		_ = os.Setenv(TokenExpirationJobIntervalEnv, "5")
+		defer os.Unsetenv(TokenExpirationJobIntervalEnv)
+		appConfig := SetupApp()
+		assert.Equal(t, 5*time.Minute, appConfig.GetTokenExpirationJobInterval())
+	})
+}
+
+func TestGetTokenExpirationWarningInDays(t *testing.T) {
+	t.Run("should return default warning days", func(t *testing.T) {
+		appConfig := SetupApp()
+		assert.Equal(t, 7, appConfig.GetTokenExpirationWarningInDays())
+	})
+}
+
+func TestGetAnalysisRetentionJobInterval(t *testing.T) {
+	t.Run("should return default interval of one day", func(t *testing.T) {
+		appConfig := SetupApp()
+		assert.Equal(t, 24*time.Hour, appConfig.GetAnalysisRetentionJobInterval())
+	})
+
+	t.Run("should return interval from env", func(t *testing.T) {
+		_ = os.Setenv(AnalysisRetentionJobIntervalEnv, "5")
+		defer os.Unsetenv(AnalysisRetentionJobIntervalEnv)
+		appConfig := SetupApp()
+		assert.Equal(t, 5*time.Minute, appConfig.GetAnalysisRetentionJobInterval())
+	})
+}
+
+func TestGetAnalysisRetentionBatchSize(t *testing.T) {
+	t.Run("should return default batch size", func(t *testing.T) {
+		appConfig := SetupApp()
+		assert.Equal(t, 500, appConfig.GetAnalysisRetentionBatchSize())
+	})
+
+	t.Run("should return default batch size when env is not positive", func(t *testing.T) {
+		_ = os.Setenv(AnalysisRetentionBatchSizeEnv, "0")
+		defer os.Unsetenv(AnalysisRetentionBatchSizeEnv)
+		appConfig := SetupApp()
+		assert.Equal(t, 500, appConfig.GetAnalysisRetentionBatchSize())
+	})
+}
+
+func TestIsDailySnapshotsEnabled(t *testing.T) {
+	t.Run("should return enabled by default", func(t *testing.T) {
+		assert.True(t, SetupApp().IsDailySnapshotsEnabled())
+	})
+
+	t.Run("should return disabled from env", func(t *testing.T) {
+		_ = os.Setenv(DailySnapshotsEnv, "false")
+		defer os.Unsetenv(DailySnapshotsEnv)
+
+		assert.False(t, SetupApp().IsDailySnapshotsEnabled())
+	})
+}
+
+func TestGetTrustedProxies(t *testing.T) {
+	t.Run("should return no trusted proxies by default", func(t *testing.T) {
+		assert.Empty(t, SetupApp().GetTrustedProxies())
+	})
+
+	t.Run("should split trusted proxies by comma", func(t *testing.T) {
+		appConfig := &Config{TrustedProxies: "10.0.0.0/8,172.16.0.1"}
+		assert.Equal(t, []string{"10.0.0.0/8", "172.16.0.1"}, appConfig.GetTrustedProxies())
+	})
+}
@@ -15,16 +15,27 @@
 package broker
 
 import (
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/queues"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/broker/config"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-api/internal/events/audit"
 )
 
-func SetUp() brokerLib.IBroker {
+func SetUp(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite) brokerLib.IBroker {
 	broker, err := brokerLib.NewBroker(config.NewBrokerConfig())
 	if err != nil {
 		logger.LogPanic("{BROKER_ERROR} failed to connect", err)
 	}
 
+	setUpConsumers(broker, postgresRead, postgresWrite)
 	return broker
 }
+
+func setUpConsumers(broker brokerLib.IBroker, postgresRead relational.InterfaceRead,
+	postgresWrite relational.InterfaceWrite) {
+	auditConsumer := audit.NewConsumer(postgresRead, postgresWrite)
+
+	go broker.Consume(queues.HorusecAudit.ToString(), "", "", auditConsumer.SaveEvent)
+}
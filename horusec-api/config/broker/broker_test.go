@@ -18,6 +18,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,14 +27,14 @@ func TestSetUp(t *testing.T) {
 		_ = os.Setenv("HORUSEC_BROKER_USERNAME", "other_username")
 		_ = os.Setenv("HORUSEC_BROKER_PASSWORD", "other_password")
 		assert.Panics(t, func() {
-			SetUp()
+			SetUp(&relational.MockRead{}, &relational.MockWrite{})
 		})
 	})
 	t.Run("Should not return panics when setup broker", func(t *testing.T) {
 		_ = os.Setenv("HORUSEC_BROKER_USERNAME", "guest")
 		_ = os.Setenv("HORUSEC_BROKER_PASSWORD", "guest")
 		assert.NotPanics(t, func() {
-			SetUp()
+			SetUp(&relational.MockRead{}, &relational.MockWrite{})
 		})
 	})
 }
@@ -15,53 +15,75 @@
 package analysis
 
 import (
+	"context"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	repositoryAnalysis "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/analysis"
 	repositoryCompany "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	repositorySnapshot "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/snapshot"
 	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
 	apiEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/api"
 	horusecEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
 	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/queues"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/metrics"
+	notificationService "github.com/ZupIT/horusec/development-kit/pkg/services/notification"
 	analysisUseCases "github.com/ZupIT/horusec/development-kit/pkg/usecases/analysis"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	"github.com/ZupIT/horusec/horusec-api/config/app"
+	"github.com/ZupIT/horusec/horusec-api/internal/controllers/policy"
 	"github.com/google/uuid"
 )
 
 type IController interface {
-	SaveAnalysis(analysisData *apiEntities.AnalysisData) (uuid.UUID, error)
+	SaveAnalysis(ctx context.Context, analysisData *apiEntities.AnalysisData) (uuid.UUID, error)
 	GetAnalysis(analysisID uuid.UUID) (*horusecEntities.Analysis, error)
+	CompareAnalysis(companyID, repositoryID, baseAnalysisID, headAnalysisID uuid.UUID) (
+		*horusecEntities.AnalysisComparison, error)
+	ListHistory(filter *horusecEntities.HistoryFilter) (*horusecEntities.AnalysisHistory, error)
 }
 
 type Controller struct {
-	postgresWrite    relational.InterfaceWrite
-	useCasesAnalysis analysisUseCases.Interface
-	repoCompany      repositoryCompany.ICompanyRepository
-	repoRepository   repository.IRepository
-	repoAnalysis     repositoryAnalysis.IAnalysisRepository
-	config           app.IAppConfig
-	broker           brokerLib.IBroker
+	postgresWrite       relational.InterfaceWrite
+	useCasesAnalysis    analysisUseCases.Interface
+	repoCompany         repositoryCompany.ICompanyRepository
+	repoRepository      repository.IRepository
+	repoAnalysis        repositoryAnalysis.IAnalysisRepository
+	repoSnapshot        repositorySnapshot.ISnapshotRepository
+	config              app.IAppConfig
+	broker              brokerLib.IBroker
+	notificationService notificationService.IService
+	policy              policy.IController
 }
 
 func NewAnalysisController(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite,
 	broker brokerLib.IBroker, config app.IAppConfig) IController {
 	return &Controller{
-		broker:           broker,
-		config:           config,
-		postgresWrite:    postgresWrite,
-		useCasesAnalysis: analysisUseCases.NewAnalysisUseCases(),
-		repoRepository:   repository.NewRepository(postgresRead, postgresWrite),
-		repoCompany:      repositoryCompany.NewCompanyRepository(postgresRead, postgresWrite),
-		repoAnalysis:     repositoryAnalysis.NewAnalysisRepository(postgresRead, postgresWrite),
+		broker:              broker,
+		config:              config,
+		postgresWrite:       postgresWrite,
+		useCasesAnalysis:    analysisUseCases.NewAnalysisUseCases(),
+		repoRepository:      repository.NewRepository(postgresRead, postgresWrite),
+		repoCompany:         repositoryCompany.NewCompanyRepository(postgresRead, postgresWrite),
+		repoAnalysis:        repositoryAnalysis.NewAnalysisRepository(postgresRead, postgresWrite),
+		repoSnapshot:        repositorySnapshot.NewSnapshotRepository(postgresRead, postgresWrite),
+		notificationService: notificationService.NewNotificationService(postgresRead, broker),
+		policy:              policy.NewPolicyController(postgresRead, postgresWrite),
 	}
 }
 
-func (c *Controller) SaveAnalysis(analysisData *apiEntities.AnalysisData) (uuid.UUID, error) {
+// SaveAnalysis publishes the analysis to the webhook with the trace context of ctx
+func (c *Controller) SaveAnalysis(ctx context.Context, analysisData *apiEntities.AnalysisData) (uuid.UUID, error) {
 	company, err := c.repoCompany.GetByID(analysisData.Analysis.CompanyID)
 	if err != nil {
 		return uuid.Nil, err
@@ -72,7 +94,7 @@ func (c *Controller) SaveAnalysis(analysisData *apiEntities.AnalysisData) (uuid.
 	}
 	c.setDefaultContentToCreate(analysisData.Analysis, company, repo)
 	analysis := c.removeAnalysisVulnerabilityWithHashDuplicate(analysisData.Analysis)
-	return c.createAnalyzeAndVulnerabilities(analysis)
+	return c.createAnalyzeAndVulnerabilities(ctx, analysisData, analysis)
 }
 
 func (c *Controller) getRepositoryOrCreateIfNotExist(
@@ -81,7 +103,7 @@ func (c *Controller) getRepositoryOrCreateIfNotExist(
 	if analysisData.RepositoryName != "" && analysisData.Analysis.RepositoryID == uuid.Nil {
 		repo, err = c.repoRepository.GetByName(analysisData.Analysis.CompanyID, analysisData.RepositoryName)
 		if err == errorsEnums.ErrNotFoundRecords {
-			return c.createRepository(analysisData, company)
+			return c.createRepositoryIfEnabled(analysisData, company)
 		}
 		return repo, err
 	}
@@ -95,7 +117,18 @@ func (c *Controller) setDefaultContentToCreate(analysis *horusecEntities.Analysi
 		SetCompanyName(company.Name).
 		SetRepositoryName(repo.Name).
 		SetRepositoryID(repo.RepositoryID).
-		SetupIDInAnalysisContents()
+		SetExpiredRiskAcceptToVulnerability().
+		SetupIDInAnalysisContents().
+		ClearPolicyVerdict()
+}
+
+func (c *Controller) createRepositoryIfEnabled(
+	analysisData *apiEntities.AnalysisData, company *accountEntities.Company) (*accountEntities.Repository, error) {
+	if analysisData.DisableAutoCreate {
+		return nil, errorsEnums.ErrorTokenAutoCreateDisabled
+	}
+
+	return c.createRepository(analysisData, company)
 }
 
 func (c *Controller) createRepository(
@@ -113,7 +146,8 @@ func (c *Controller) createRepository(
 	return repo, c.repoRepository.Create(repo, nil)
 }
 
-func (c *Controller) createAnalyzeAndVulnerabilities(analysis *horusecEntities.Analysis) (uuid.UUID, error) {
+func (c *Controller) createAnalyzeAndVulnerabilities(ctx context.Context, analysisData *apiEntities.AnalysisData,
+	analysis *horusecEntities.Analysis) (uuid.UUID, error) {
 	conn := c.postgresWrite.StartTransaction()
 	if err := c.repoAnalysis.Create(analysis, conn); err != nil {
 		logger.LogError(
@@ -125,13 +159,70 @@ func (c *Controller) createAnalyzeAndVulnerabilities(analysis *horusecEntities.A
 	if err := conn.CommitTransaction().GetError(); err != nil {
 		return uuid.Nil, err
 	}
-	return analysis.GetID(), c.publishToWebhook(analysis)
+	metrics.ObserveAnalysis(analysis)
+	c.refreshDailySnapshot(analysis)
+	c.evaluatePolicies(analysisData, analysis)
+	if err := c.publishToWebhook(ctx, analysis); err != nil {
+		return uuid.Nil, err
+	}
+	c.publishToChat(analysis)
+	return analysis.GetID(), nil
+}
+
+// refreshDailySnapshot does not fail the analysis, a missing snapshot can be recreated by the analytic backfill
+func (c *Controller) refreshDailySnapshot(analysis *horusecEntities.Analysis) {
+	if err := c.repoSnapshot.Refresh(analysis); err != nil {
+		logger.LogError(errorsEnums.ErrorRefreshDailySnapshot, err)
+	}
+}
+
+// evaluatePolicies does not fail the analysis already saved, without a verdict the CLI fails when policies are enabled
+func (c *Controller) evaluatePolicies(analysisData *apiEntities.AnalysisData, analysis *horusecEntities.Analysis) {
+	if err := c.policy.Evaluate(analysisData, analysis); err != nil {
+		logger.LogError(errorsEnums.ErrSavePolicyVerdict, err)
+	}
 }
 
 func (c *Controller) GetAnalysis(analysisID uuid.UUID) (*horusecEntities.Analysis, error) {
 	return c.repoAnalysis.GetByID(analysisID)
 }
 
+func (c *Controller) ListHistory(filter *horusecEntities.HistoryFilter) (*horusecEntities.AnalysisHistory, error) {
+	return c.repoAnalysis.ListHistory(filter)
+}
+
+// CompareAnalysis returns not found when an analysis is outside of the company or repository of the token, so the
+// existence of analyses from other tenants is not revealed
+func (c *Controller) CompareAnalysis(companyID, repositoryID, baseAnalysisID, headAnalysisID uuid.UUID) (
+	*horusecEntities.AnalysisComparison, error) {
+	base, err := c.getAnalysisToCompare(companyID, repositoryID, baseAnalysisID)
+	if err != nil {
+		return nil, err
+	}
+	head, err := c.getAnalysisToCompare(companyID, repositoryID, headAnalysisID)
+	if err != nil {
+		return nil, err
+	}
+	if base.RepositoryID != head.RepositoryID {
+		return nil, errorsEnums.ErrorAnalysesOfDifferentRepositories
+	}
+
+	return horusecEntities.NewAnalysisComparison(base, head), nil
+}
+
+func (c *Controller) getAnalysisToCompare(companyID, repositoryID, analysisID uuid.UUID) (
+	*horusecEntities.Analysis, error) {
+	analysis, err := c.repoAnalysis.GetByID(analysisID)
+	if err != nil {
+		return nil, err
+	}
+	if analysis.CompanyID != companyID || (repositoryID != uuid.Nil && analysis.RepositoryID != repositoryID) {
+		return nil, errorsEnums.ErrNotFoundRecords
+	}
+
+	return analysis, nil
+}
+
 func (c *Controller) removeAnalysisVulnerabilityWithHashDuplicate(
 	analysis *horusecEntities.Analysis) *horusecEntities.Analysis {
 	newAnalysis := analysis.GetAnalysisWithoutAnalysisVulnerabilities()
@@ -155,9 +246,38 @@ func (c *Controller) hasDuplicatedHash(
 	return false
 }
 
-func (c *Controller) publishToWebhook(analysis *horusecEntities.Analysis) error {
+func (c *Controller) publishToWebhook(ctx context.Context, analysis *horusecEntities.Analysis) error {
 	if !c.config.IsDisabledBroker() {
-		return c.broker.Publish(queues.HorusecWebhookDispatch.ToString(), "", "", analysis.ToBytes())
+		return c.broker.PublishWithContext(ctx, queues.HorusecWebhookDispatch.ToString(), "", "",
+			analysis.ToBytes())
 	}
 	return nil
 }
+
+// publishToChat does not fail the analysis, chat delivery is best-effort and the analysis is already saved
+func (c *Controller) publishToChat(analysis *horusecEntities.Analysis) {
+	if c.config.IsDisabledBroker() {
+		return
+	}
+
+	if err := c.notificationService.Dispatch(analysis.CompanyID, analysis.RepositoryID,
+		notificationEnum.AnalysisFinished, c.newAnalysisChatMessage(analysis)); err != nil {
+		logger.LogError(errorsEnums.ErrorPublishChatNotification, err)
+	}
+}
+
+func (c *Controller) newAnalysisChatMessage(analysis *horusecEntities.Analysis) *messages.ChatMessage {
+	total := analysis.GetTotalVulnerabilitiesBySeverity()[horusec.Vulnerability]
+	chatMessage := &messages.ChatMessage{
+		Title: "[Horusec] Analysis finished",
+		Text: fmt.Sprintf("Analysis of the repository %s finished with status %s",
+			analysis.RepositoryName, analysis.Status),
+		Link: env.GetHorusecManagerURL(),
+	}
+
+	return chatMessage.
+		AddField("Total vulnerabilities", strconv.Itoa(analysis.GetTotalVulnerabilities())).
+		AddField(severity.High.ToString(), strconv.Itoa(total[severity.High])).
+		AddField(severity.Medium.ToString(), strconv.Itoa(total[severity.Medium])).
+		AddField(severity.Low.ToString(), strconv.Itoa(total[severity.Low]))
+}
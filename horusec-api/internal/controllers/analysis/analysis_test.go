@@ -15,17 +15,21 @@
 package analysis
 
 import (
+	"context"
 	"errors"
 	repositoryAnalysis "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/analysis"
 	repositoryCompany "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
 	repositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	repositorySnapshot "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/snapshot"
 	apiEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/api"
 	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	notificationService "github.com/ZupIT/horusec/development-kit/pkg/services/notification"
 	analysisUseCases "github.com/ZupIT/horusec/development-kit/pkg/usecases/analysis"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/test"
 	"github.com/ZupIT/horusec/horusec-api/config/app"
+	"github.com/ZupIT/horusec/horusec-api/internal/controllers/policy"
 	"testing"
 	"time"
 
@@ -58,6 +62,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 	conn.Table("analysis").AutoMigrate(&horusec.Analysis{})
 	conn.Table("analysis_vulnerabilities").AutoMigrate(&horusec.AnalysisVulnerabilities{})
 	conn.Table("vulnerabilities").AutoMigrate(&horusec.Vulnerability{})
+	conn.Table("policies").AutoMigrate(&account.Policy{})
 	conn.LogMode(true)
 
 	t.Run("should send a new analysis without errors", func(t *testing.T) {
@@ -77,6 +82,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 		mockRead.On("Find").Once().Return(respComp.SetData(company))
 		mockRead.On("Find").Return(respRepo.SetData(repository))
 		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("GetConnection").Return(conn)
 		mockWrite.On("StartTransaction").Return(mockWrite)
 		mockWrite.On("CommitTransaction").Return(&response.Response{})
 		mockWrite.On("Create").Return(&response.Response{})
@@ -89,7 +95,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 			Analysis:       analysis,
 			RepositoryName: "test",
 		}
-		id, err := controller.SaveAnalysis(analysisData)
+		id, err := controller.SaveAnalysis(context.Background(), analysisData)
 		assert.NoError(t, err)
 		assert.NotEqual(t, uuid.Nil, id)
 	})
@@ -109,6 +115,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 		mockRead.On("Find").Once().Return(respComp.SetData(company))
 		mockRead.On("Find").Return(respRepo.SetData(repository))
 		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("GetConnection").Return(conn)
 		mockWrite.On("StartTransaction").Return(mockWrite)
 		mockWrite.On("CommitTransaction").Return(&response.Response{})
 		mockWrite.On("Create").Return(&response.Response{})
@@ -121,7 +128,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 			Analysis:       analysis,
 			RepositoryName: "test",
 		}
-		id, err := controller.SaveAnalysis(analysisData)
+		id, err := controller.SaveAnalysis(context.Background(), analysisData)
 		assert.NoError(t, err)
 		assert.NotEqual(t, uuid.Nil, id)
 	})
@@ -142,6 +149,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 		mockRead.On("Find").Once().Return(respComp.SetData(company))
 		mockRead.On("Find").Return(respRepo.SetData(repository))
 		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("GetConnection").Return(conn)
 		mockWrite.On("StartTransaction").Return(mockWrite)
 		mockWrite.On("CommitTransaction").Return(&response.Response{})
 		mockWrite.On("Create").Return(&response.Response{})
@@ -154,7 +162,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 			Analysis:       analysis,
 			RepositoryName: "test",
 		}
-		_, err := controller.SaveAnalysis(analysisData)
+		_, err := controller.SaveAnalysis(context.Background(), analysisData)
 		assert.Error(t, err)
 	})
 	t.Run("should send a new analysis without errors expected remove vulnerabilities hash duplicated", func(t *testing.T) {
@@ -175,19 +183,23 @@ func TestController_SaveAnalysis(t *testing.T) {
 		mockRead.On("Find").Once().Return(respComp.SetData(company))
 		mockRead.On("Find").Return(respRepo.SetData(repository))
 		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("GetConnection").Return(conn)
 		mockWrite.On("StartTransaction").Return(mockWrite)
 		mockWrite.On("CommitTransaction").Return(&response.Response{})
 		mockWrite.On("Create").Return(&response.Response{})
 		mockWrite.On("GetConnection").Return(conn)
 
 		controller := &Controller{
-			broker:           mockBroker,
-			config:           config,
-			postgresWrite:    mockWrite,
-			useCasesAnalysis: analysisUseCases.NewAnalysisUseCases(),
-			repoRepository:   repositoryRepo.NewRepository(mockRead, mockWrite),
-			repoCompany:      repositoryCompany.NewCompanyRepository(mockRead, mockWrite),
-			repoAnalysis:     repositoryAnalysis.NewAnalysisRepository(mockRead, mockWrite),
+			broker:              mockBroker,
+			config:              config,
+			postgresWrite:       mockWrite,
+			useCasesAnalysis:    analysisUseCases.NewAnalysisUseCases(),
+			repoRepository:      repositoryRepo.NewRepository(mockRead, mockWrite),
+			repoCompany:         repositoryCompany.NewCompanyRepository(mockRead, mockWrite),
+			repoAnalysis:        repositoryAnalysis.NewAnalysisRepository(mockRead, mockWrite),
+			repoSnapshot:        repositorySnapshot.NewSnapshotRepository(mockRead, mockWrite),
+			notificationService: notificationService.NewNotificationService(mockRead, mockBroker),
+			policy:              policy.NewPolicyController(mockRead, mockWrite),
 		}
 
 		analysis := test.CreateAnalysisMock()
@@ -199,10 +211,54 @@ func TestController_SaveAnalysis(t *testing.T) {
 			Analysis:       newAnalysis,
 			RepositoryName: "test",
 		}
-		id, err := controller.SaveAnalysis(analysisData)
+		id, err := controller.SaveAnalysis(context.Background(), analysisData)
 		assert.NoError(t, err)
 		assert.NotEqual(t, uuid.Nil, id)
 	})
+	t.Run("should save the analysis when the policies verdict can not be saved", func(t *testing.T) {
+		mockBroker := &broker.Mock{}
+		config := &app.Config{}
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		policyMock := &policy.Mock{}
+
+		respComp := &response.Response{}
+		respRepo := &response.Response{}
+		mockRead.On("Find").Once().Return(respComp.SetData(&account.Company{Name: "test"}))
+		mockRead.On("Find").Return(respRepo.SetData(&account.Repository{Name: "test"}))
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("GetConnection").Return(conn)
+		mockWrite.On("StartTransaction").Return(mockWrite)
+		mockWrite.On("CommitTransaction").Return(&response.Response{})
+		mockWrite.On("Create").Return(&response.Response{})
+		mockWrite.On("GetConnection").Return(conn)
+		mockBroker.On("Publish").Return(nil)
+		policyMock.On("Evaluate").Return(errors.New("test"))
+
+		controller := &Controller{
+			broker:              mockBroker,
+			config:              config,
+			postgresWrite:       mockWrite,
+			useCasesAnalysis:    analysisUseCases.NewAnalysisUseCases(),
+			repoRepository:      repositoryRepo.NewRepository(mockRead, mockWrite),
+			repoCompany:         repositoryCompany.NewCompanyRepository(mockRead, mockWrite),
+			repoAnalysis:        repositoryAnalysis.NewAnalysisRepository(mockRead, mockWrite),
+			repoSnapshot:        repositorySnapshot.NewSnapshotRepository(mockRead, mockWrite),
+			notificationService: notificationService.NewNotificationService(mockRead, mockBroker),
+			policy:              policyMock,
+		}
+
+		analysis := test.CreateAnalysisMock()
+		analysis.SetPolicyVerdict(nil)
+		id, err := controller.SaveAnalysis(context.Background(), &apiEntities.AnalysisData{
+			Analysis:       analysis,
+			RepositoryName: "test",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, analysis.ID, id)
+		assert.Empty(t, analysis.PolicyStatus, "the verdict sent by the client is not trusted")
+		policyMock.AssertCalled(t, "Evaluate")
+	})
 	t.Run("should send a new analysis without errors and create repository", func(t *testing.T) {
 
 		mockBroker := &broker.Mock{}
@@ -220,6 +276,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 		mockRead.On("Find").Once().Return(respComp.SetData(company))
 		mockRead.On("Find").Return(respRepo.SetError(errorsEnums.ErrNotFoundRecords))
 		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("GetConnection").Return(conn)
 		mockWrite.On("StartTransaction").Return(mockWrite)
 		mockWrite.On("CommitTransaction").Return(&response.Response{})
 		mockWrite.On("Create").Return(&response.Response{})
@@ -228,6 +285,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 
 		analysis := &apiEntities.AnalysisData{
 			Analysis: &horusec.Analysis{
+				ID:         uuid.New(),
 				Status:     enumHorusec.Success,
 				CreatedAt:  time.Now(),
 				FinishedAt: time.Now(),
@@ -235,10 +293,32 @@ func TestController_SaveAnalysis(t *testing.T) {
 			},
 			RepositoryName: "test",
 		}
-		id, err := controller.SaveAnalysis(analysis)
+		id, err := controller.SaveAnalysis(context.Background(), analysis)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, id)
 	})
+	t.Run("should return error when repository does not exist and auto create is disabled", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+
+		respComp := &response.Response{}
+		respRepo := &response.Response{}
+		mockRead.On("Find").Once().Return(respComp.SetData(&account.Company{Name: "test"}))
+		mockRead.On("Find").Return(respRepo.SetError(errorsEnums.ErrNotFoundRecords))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+
+		controller := NewAnalysisController(mockRead, mockWrite, &broker.Mock{}, &app.Config{})
+
+		analysis := &apiEntities.AnalysisData{
+			Analysis:          &horusec.Analysis{CompanyID: uuid.New()},
+			RepositoryName:    "test",
+			DisableAutoCreate: true,
+		}
+		_, err := controller.SaveAnalysis(context.Background(), analysis)
+
+		assert.Equal(t, errorsEnums.ErrorTokenAutoCreateDisabled, err)
+		mockWrite.AssertNotCalled(t, "Create")
+	})
 	t.Run("should return error while getting repository", func(t *testing.T) {
 
 		mockBroker := &broker.Mock{}
@@ -254,6 +334,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 		mockRead.On("Find").Once().Return(resp)
 		mockRead.On("Find").Return(respWithError.SetError(errors.New("test")))
 		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("GetConnection").Return(conn)
 
 		controller := NewAnalysisController(mockRead, mockWrite, mockBroker, config)
 
@@ -265,7 +346,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 			},
 			RepositoryName: "",
 		}
-		_, err := controller.SaveAnalysis(analysis)
+		_, err := controller.SaveAnalysis(context.Background(), analysis)
 
 		assert.Error(t, err)
 	})
@@ -282,6 +363,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 		resp := &response.Response{}
 		mockRead.On("Find").Return(resp.SetError(errors.New("test")))
 		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("GetConnection").Return(conn)
 
 		controller := NewAnalysisController(mockRead, mockWrite, mockBroker, config)
 
@@ -293,7 +375,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 			},
 			RepositoryName: "",
 		}
-		_, err := controller.SaveAnalysis(analysis)
+		_, err := controller.SaveAnalysis(context.Background(), analysis)
 
 		assert.Error(t, err)
 	})
@@ -308,6 +390,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 		resp := &response.Response{}
 		mockRead.On("Find").Return(&response.Response{})
 		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("GetConnection").Return(conn)
 		mockWrite.On("StartTransaction").Return(mockWrite)
 		mockWrite.On("RollbackTransaction").Return(&response.Response{})
 		mockWrite.On("Create").Return(resp.SetError(errors.New("some error")))
@@ -322,7 +405,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 			},
 			RepositoryName: "",
 		}
-		_, err := controller.SaveAnalysis(analysis)
+		_, err := controller.SaveAnalysis(context.Background(), analysis)
 		assert.Error(t, err)
 	})
 
@@ -345,6 +428,7 @@ func TestController_SaveAnalysis(t *testing.T) {
 		mockRead.On("Find").Once().Return(respComp.SetData(company))
 		mockRead.On("Find").Return(respRepo.SetData(repository))
 		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("GetConnection").Return(conn)
 		mockWrite.On("StartTransaction").Return(mockWrite)
 		mockWrite.On("CommitTransaction").Return(&response.Response{})
 		mockWrite.On("Create").Return(createResponse.SetError(errors.New("test")))
@@ -378,9 +462,9 @@ func TestController_SaveAnalysis(t *testing.T) {
 			},
 			RepositoryName: "test",
 		}
-		id, err := controller.SaveAnalysis(analysis)
+		id, err := controller.SaveAnalysis(context.Background(), analysis)
 		assert.Error(t, err)
-		assert.NotEmpty(t, id)
+		assert.Equal(t, uuid.Nil, id)
 	})
 }
 
@@ -394,6 +478,7 @@ func TestController_GetAnalysis(t *testing.T) {
 		conn, err := gorm.Open("sqlite3", ":memory:")
 		assert.NoError(t, err)
 		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("GetConnection").Return(conn)
 		mockRead.On("Find").Return(resp.SetData(repo))
 
 		controller := NewAnalysisController(mockRead, mockWrite, nil, nil)
@@ -413,6 +498,7 @@ func TestController_GetAnalysis(t *testing.T) {
 		conn, err := gorm.Open("sqlite3", ":memory:")
 		assert.NoError(t, err)
 		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("GetConnection").Return(conn)
 		mockRead.On("Find").Return(resp.SetError(errors.New("error")))
 
 		controller := NewAnalysisController(mockRead, mockWrite, nil, nil)
@@ -429,6 +515,7 @@ func TestController_GetAnalysis(t *testing.T) {
 		conn, err := gorm.Open("sqlite3", ":memory:")
 		assert.NoError(t, err)
 		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("GetConnection").Return(conn)
 		mockRead.On("Find").Return(resp.SetData(repo).SetError(errorsEnum.ErrNotFoundRecords))
 
 		controller := NewAnalysisController(mockRead, mockWrite, nil, nil)
@@ -438,3 +525,145 @@ func TestController_GetAnalysis(t *testing.T) {
 		assert.Equal(t, errorsEnum.ErrNotFoundRecords, err)
 	})
 }
+
+func TestController_ListHistory(t *testing.T) {
+	t.Run("should list the history of the repository", func(t *testing.T) {
+		analysisMock := &repositoryAnalysis.Mock{}
+		analysisMock.On("ListHistory").Return(&horusec.AnalysisHistory{TotalItems: 1,
+			Data: []horusec.AnalysisSummary{{ID: uuid.New()}}}, nil)
+		controller := &Controller{repoAnalysis: analysisMock}
+
+		history, err := controller.ListHistory(&horusec.HistoryFilter{RepositoryID: uuid.New()})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, history.TotalItems)
+	})
+}
+
+func TestController_CompareAnalysis(t *testing.T) {
+	t.Run("should compare two analyses of the same repository", func(t *testing.T) {
+		base := test.CreateAnalysisMock()
+		head := test.CreateAnalysisMock()
+		head.CompanyID, head.RepositoryID = base.CompanyID, base.RepositoryID
+		head.AnalysisVulnerabilities = head.AnalysisVulnerabilities[1:]
+		analysisMock := &repositoryAnalysis.Mock{}
+		analysisMock.On("GetByID").Return(base, nil).Once()
+		analysisMock.On("GetByID").Return(head, nil).Once()
+		controller := &Controller{repoAnalysis: analysisMock}
+
+		comparison, err := controller.CompareAnalysis(base.CompanyID, uuid.Nil, base.ID, head.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, base.ID, comparison.BaseAnalysisID)
+		assert.Equal(t, head.ID, comparison.HeadAnalysisID)
+		assert.Equal(t, 1, comparison.Fixed.Total)
+		assert.Equal(t, len(head.AnalysisVulnerabilities), comparison.Unchanged.Total)
+	})
+	t.Run("should return not found when analysis is from another company", func(t *testing.T) {
+		analysisMock := &repositoryAnalysis.Mock{}
+		analysisMock.On("GetByID").Return(test.CreateAnalysisMock(), nil)
+		controller := &Controller{repoAnalysis: analysisMock}
+
+		_, err := controller.CompareAnalysis(uuid.New(), uuid.Nil, uuid.New(), uuid.New())
+
+		assert.Equal(t, errorsEnums.ErrNotFoundRecords, err)
+	})
+	t.Run("should return not found when analysis is from another repository of the token", func(t *testing.T) {
+		analysis := test.CreateAnalysisMock()
+		analysisMock := &repositoryAnalysis.Mock{}
+		analysisMock.On("GetByID").Return(analysis, nil)
+		controller := &Controller{repoAnalysis: analysisMock}
+
+		_, err := controller.CompareAnalysis(analysis.CompanyID, uuid.New(), uuid.New(), uuid.New())
+
+		assert.Equal(t, errorsEnums.ErrNotFoundRecords, err)
+	})
+	t.Run("should return error when analyses are from different repositories", func(t *testing.T) {
+		base := test.CreateAnalysisMock()
+		head := test.CreateAnalysisMock()
+		head.CompanyID = base.CompanyID
+		analysisMock := &repositoryAnalysis.Mock{}
+		analysisMock.On("GetByID").Return(base, nil).Once()
+		analysisMock.On("GetByID").Return(head, nil).Once()
+		controller := &Controller{repoAnalysis: analysisMock}
+
+		_, err := controller.CompareAnalysis(base.CompanyID, uuid.Nil, base.ID, head.ID)
+
+		assert.Equal(t, errorsEnums.ErrorAnalysesOfDifferentRepositories, err)
+	})
+	t.Run("should return error when get base analysis fails", func(t *testing.T) {
+		analysisMock := &repositoryAnalysis.Mock{}
+		analysisMock.On("GetByID").Return(&horusec.Analysis{}, errors.New("test"))
+		controller := &Controller{repoAnalysis: analysisMock}
+
+		_, err := controller.CompareAnalysis(uuid.New(), uuid.Nil, uuid.New(), uuid.New())
+
+		assert.Error(t, err)
+	})
+	t.Run("should return error when get head analysis fails", func(t *testing.T) {
+		base := test.CreateAnalysisMock()
+		analysisMock := &repositoryAnalysis.Mock{}
+		analysisMock.On("GetByID").Return(base, nil).Once()
+		analysisMock.On("GetByID").Return(&horusec.Analysis{}, errors.New("test")).Once()
+		controller := &Controller{repoAnalysis: analysisMock}
+
+		_, err := controller.CompareAnalysis(base.CompanyID, uuid.Nil, base.ID, uuid.New())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestController_refreshDailySnapshot(t *testing.T) {
+	t.Run("should refresh the daily snapshot of the analysis", func(t *testing.T) {
+		snapshotMock := &repositorySnapshot.Mock{}
+		snapshotMock.On("Refresh").Return(nil)
+		controller := &Controller{repoSnapshot: snapshotMock}
+
+		controller.refreshDailySnapshot(test.CreateAnalysisMock())
+		snapshotMock.AssertCalled(t, "Refresh")
+	})
+	t.Run("should not panic when refresh fails", func(t *testing.T) {
+		snapshotMock := &repositorySnapshot.Mock{}
+		snapshotMock.On("Refresh").Return(errors.New("test"))
+		controller := &Controller{repoSnapshot: snapshotMock}
+
+		assert.NotPanics(t, func() {
+			controller.refreshDailySnapshot(test.CreateAnalysisMock())
+		})
+	})
+}
+
+func TestController_publishToChat(t *testing.T) {
+	t.Run("should dispatch analysis finished chat message", func(t *testing.T) {
+		notificationMock := &notificationService.Mock{}
+		notificationMock.On("Dispatch").Return(nil)
+		controller := &Controller{config: &app.Config{}, notificationService: notificationMock}
+
+		controller.publishToChat(test.CreateAnalysisMock())
+		notificationMock.AssertCalled(t, "Dispatch")
+	})
+	t.Run("should not panic when dispatch chat message fails", func(t *testing.T) {
+		notificationMock := &notificationService.Mock{}
+		notificationMock.On("Dispatch").Return(errors.New("test"))
+		controller := &Controller{config: &app.Config{}, notificationService: notificationMock}
+
+		assert.NotPanics(t, func() {
+			controller.publishToChat(test.CreateAnalysisMock())
+		})
+	})
+	t.Run("should not dispatch when broker is disabled", func(t *testing.T) {
+		notificationMock := &notificationService.Mock{}
+		controller := &Controller{config: &app.Config{DisabledBroker: true}, notificationService: notificationMock}
+
+		controller.publishToChat(test.CreateAnalysisMock())
+		notificationMock.AssertNotCalled(t, "Dispatch")
+	})
+	t.Run("should set vulnerabilities totals in chat message", func(t *testing.T) {
+		controller := &Controller{}
+
+		chatMessage := controller.newAnalysisChatMessage(test.CreateAnalysisMock())
+
+		assert.Equal(t, "[Horusec] Analysis finished", chatMessage.Title)
+		assert.Len(t, chatMessage.Fields, 4)
+	})
+}
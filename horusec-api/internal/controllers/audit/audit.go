@@ -0,0 +1,44 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	auditRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/audit"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+)
+
+type IController interface {
+	Create(event *audit.Event) error
+	List(filter *audit.Filter) (*audit.Events, error)
+}
+
+type Controller struct {
+	auditRepository auditRepository.IRepository
+}
+
+func NewAuditController(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite) IController {
+	return &Controller{
+		auditRepository: auditRepository.NewAuditRepository(postgresRead, postgresWrite),
+	}
+}
+
+func (c *Controller) Create(event *audit.Event) error {
+	return c.auditRepository.Create(event)
+}
+
+func (c *Controller) List(filter *audit.Filter) (*audit.Events, error) {
+	return c.auditRepository.List(filter)
+}
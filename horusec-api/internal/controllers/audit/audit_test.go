@@ -0,0 +1,76 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	auditRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/audit"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock(t *testing.T) {
+	t.Run("should mock audit controller", func(t *testing.T) {
+		m := &Mock{}
+		m.On("Create").Return(nil)
+		m.On("List").Return(&audit.Events{}, nil)
+
+		assert.NoError(t, m.Create(&audit.Event{}))
+		_, err := m.List(&audit.Filter{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestNewAuditController(t *testing.T) {
+	t.Run("should create a new controller", func(t *testing.T) {
+		assert.NotNil(t, NewAuditController(&relational.MockRead{}, &relational.MockWrite{}))
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("should save event", func(t *testing.T) {
+		repositoryMock := &auditRepository.Mock{}
+		repositoryMock.On("Create").Return(nil)
+		controller := &Controller{auditRepository: repositoryMock}
+
+		assert.NoError(t, controller.Create(audit.NewEvent(auditEnums.CompanyCreate, uuid.New())))
+	})
+
+	t.Run("should return error when failed to save", func(t *testing.T) {
+		repositoryMock := &auditRepository.Mock{}
+		repositoryMock.On("Create").Return(errors.New("test"))
+		controller := &Controller{auditRepository: repositoryMock}
+
+		assert.Error(t, controller.Create(audit.NewEvent(auditEnums.CompanyCreate, uuid.New())))
+	})
+}
+
+func TestList(t *testing.T) {
+	t.Run("should list events", func(t *testing.T) {
+		repositoryMock := &auditRepository.Mock{}
+		repositoryMock.On("List").Return(&audit.Events{TotalItems: 1, Data: []audit.Event{{}}}, nil)
+		controller := &Controller{auditRepository: repositoryMock}
+
+		events, err := controller.List(&audit.Filter{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, events.TotalItems)
+	})
+}
@@ -16,37 +16,76 @@ package management
 
 import (
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	repositorySnapshot "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/snapshot"
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/vulnerability"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
-	horusecEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
-	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	"github.com/google/uuid"
 )
 
 type IController interface {
-	ListVulnManagementData(repositoryID uuid.UUID, page, size int, vulnSeverity severity.Severity,
-		vulnType horusecEnums.VulnerabilityType, vulnHash string) (vulnManagement dto.VulnManagement, err error)
-	UpdateVulnType(vulnerabilityID uuid.UUID, vulnType *dto.UpdateVulnType) (*horusec.Vulnerability, error)
+	ListVulnManagementData(filter *dto.VulnManagementFilter) (vulnManagement dto.VulnManagement, err error)
+	UpdateVulnType(repositoryID, vulnerabilityID uuid.UUID,
+		vulnType *dto.UpdateVulnType) (*horusec.Vulnerability, error)
+	ExportVulnerabilities(filter *dto.VulnExportFilter, handle func(vulnExport *dto.VulnExport) error) error
+	BulkUpdateVulnType(repositoryID uuid.UUID, bulkData *dto.BulkUpdateVulnType) (int, error)
+	ListVulnTypeHistory(repositoryID, vulnerabilityID uuid.UUID) (*[]horusec.VulnerabilityTypeHistory, error)
 }
 
 type Controller struct {
 	managementRepository vulnerability.IRepository
+	repoSnapshot         repositorySnapshot.ISnapshotRepository
 }
 
 func NewManagementController(postgresRead relational.InterfaceRead,
 	postgresWrite relational.InterfaceWrite) IController {
 	return &Controller{
 		managementRepository: vulnerability.NewManagementRepository(postgresRead, postgresWrite),
+		repoSnapshot:         repositorySnapshot.NewSnapshotRepository(postgresRead, postgresWrite),
 	}
 }
 
-func (c *Controller) ListVulnManagementData(repositoryID uuid.UUID, page, size int, vulnSeverity severity.Severity,
-	vulnType horusecEnums.VulnerabilityType, vulnHash string) (vulnManagement dto.VulnManagement, err error) {
-	return c.managementRepository.ListVulnManagementData(repositoryID, page, size, vulnSeverity, vulnType, vulnHash)
+func (c *Controller) ListVulnManagementData(
+	filter *dto.VulnManagementFilter) (vulnManagement dto.VulnManagement, err error) {
+	return c.managementRepository.ListVulnManagementData(filter)
 }
 
-func (c *Controller) UpdateVulnType(vulnerabilityID uuid.UUID,
+func (c *Controller) UpdateVulnType(repositoryID, vulnerabilityID uuid.UUID,
 	updateTypeData *dto.UpdateVulnType) (*horusec.Vulnerability, error) {
-	return c.managementRepository.UpdateVulnType(vulnerabilityID, updateTypeData)
+	vulnerability, err := c.managementRepository.UpdateVulnType(repositoryID, vulnerabilityID, updateTypeData)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logRefreshError(c.repoSnapshot.RefreshByVulnerabilities([]uuid.UUID{vulnerabilityID}))
+	return vulnerability, nil
+}
+
+func (c *Controller) ExportVulnerabilities(filter *dto.VulnExportFilter,
+	handle func(vulnExport *dto.VulnExport) error) error {
+	return c.managementRepository.ExportVulnerabilities(filter, handle)
+}
+
+func (c *Controller) BulkUpdateVulnType(repositoryID uuid.UUID, bulkData *dto.BulkUpdateVulnType) (int, error) {
+	count, err := c.managementRepository.BulkUpdateVulnType(repositoryID, bulkData)
+	if err != nil || count == 0 {
+		return count, err
+	}
+
+	c.logRefreshError(c.repoSnapshot.RefreshByRepository(repositoryID))
+	return count, nil
+}
+
+// logRefreshError does not fail the change of type, the snapshots can be recreated by the analytic backfill
+func (c *Controller) logRefreshError(err error) {
+	if err != nil {
+		logger.LogError(errorsEnums.ErrorRefreshDailySnapshot, err)
+	}
+}
+
+func (c *Controller) ListVulnTypeHistory(repositoryID,
+	vulnerabilityID uuid.UUID) (*[]horusec.VulnerabilityTypeHistory, error) {
+	return c.managementRepository.ListVulnTypeHistory(repositoryID, vulnerabilityID)
 }
@@ -17,8 +17,6 @@ package management
 import (
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
-	horusecEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
-	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
 	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -28,13 +26,29 @@ type Mock struct {
 	mock.Mock
 }
 
-func (m *Mock) ListVulnManagementData(repositoryID uuid.UUID, page, size int, vulnSeverity severity.Severity,
-	vulnType horusecEnums.VulnerabilityType, vulnHash string) (vulnManagement dto.VulnManagement, err error) {
+func (m *Mock) ListVulnManagementData(
+	filter *dto.VulnManagementFilter) (vulnManagement dto.VulnManagement, err error) {
 	args := m.MethodCalled("ListVulnManagementData")
 	return args.Get(0).(dto.VulnManagement), mockUtils.ReturnNilOrError(args, 1)
 }
 
-func (m *Mock) UpdateVulnType(vulnerabilityID uuid.UUID, vulnType *dto.UpdateVulnType) (*horusec.Vulnerability, error) {
+func (m *Mock) UpdateVulnType(repositoryID, vulnerabilityID uuid.UUID,
+	vulnType *dto.UpdateVulnType) (*horusec.Vulnerability, error) {
 	args := m.MethodCalled("UpdateVulnType")
 	return args.Get(0).(*horusec.Vulnerability), mockUtils.ReturnNilOrError(args, 1)
 }
+
+func (m *Mock) ExportVulnerabilities(_ *dto.VulnExportFilter, _ func(vulnExport *dto.VulnExport) error) error {
+	args := m.MethodCalled("ExportVulnerabilities")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) BulkUpdateVulnType(_ uuid.UUID, _ *dto.BulkUpdateVulnType) (int, error) {
+	args := m.MethodCalled("BulkUpdateVulnType")
+	return args.Get(0).(int), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) ListVulnTypeHistory(_, _ uuid.UUID) (*[]horusec.VulnerabilityTypeHistory, error) {
+	args := m.MethodCalled("ListVulnTypeHistory")
+	return args.Get(0).(*[]horusec.VulnerabilityTypeHistory), mockUtils.ReturnNilOrError(args, 1)
+}
@@ -15,13 +15,16 @@
 package management
 
 import (
+	"errors"
+	"testing"
+
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/snapshot"
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/vulnerability"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
-	"testing"
 )
 
 func TestNewManagementController(t *testing.T) {
@@ -50,8 +53,8 @@ func TestListVulnManagementData(t *testing.T) {
 
 		controller := Controller{managementRepository: repositoryMock}
 
-		result, err := controller.ListVulnManagementData(uuid.New(), 1, 10,
-			"", "", "")
+		result, err := controller.ListVulnManagementData(&dto.VulnManagementFilter{
+			VulnFilter: dto.VulnFilter{RepositoryID: uuid.New()}, Page: 1, Size: 10})
 		assert.NoError(t, err)
 		assert.Equal(t, 1, result.TotalItems)
 		assert.Len(t, result.Data, 1)
@@ -62,12 +65,102 @@ func TestUpdateVulnType(t *testing.T) {
 	t.Run("should success update data with no errors", func(t *testing.T) {
 		repositoryMock := &vulnerability.Mock{}
 
+		snapshotMock := &snapshot.Mock{}
+
 		repositoryMock.On("UpdateVulnType").Return(&horusec.Vulnerability{}, nil)
 		repositoryMock.On("GetVulnByID").Return(&horusec.Vulnerability{}, nil)
+		snapshotMock.On("RefreshByVulnerabilities").Return(nil)
+
+		controller := Controller{managementRepository: repositoryMock, repoSnapshot: snapshotMock}
+
+		_, err := controller.UpdateVulnType(uuid.New(), uuid.New(), &dto.UpdateVulnType{})
+		assert.NoError(t, err)
+		snapshotMock.AssertCalled(t, "RefreshByVulnerabilities")
+	})
+
+	t.Run("should not fail when daily snapshots can not be refreshed", func(t *testing.T) {
+		repositoryMock := &vulnerability.Mock{}
+		snapshotMock := &snapshot.Mock{}
+
+		repositoryMock.On("UpdateVulnType").Return(&horusec.Vulnerability{}, nil)
+		snapshotMock.On("RefreshByVulnerabilities").Return(errors.New("test"))
+
+		controller := Controller{managementRepository: repositoryMock, repoSnapshot: snapshotMock}
+
+		_, err := controller.UpdateVulnType(uuid.New(), uuid.New(), &dto.UpdateVulnType{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not refresh daily snapshots when update fails", func(t *testing.T) {
+		repositoryMock := &vulnerability.Mock{}
+		snapshotMock := &snapshot.Mock{}
+
+		repositoryMock.On("UpdateVulnType").Return(&horusec.Vulnerability{}, errors.New("test"))
+
+		controller := Controller{managementRepository: repositoryMock, repoSnapshot: snapshotMock}
+
+		_, err := controller.UpdateVulnType(uuid.New(), uuid.New(), &dto.UpdateVulnType{})
+		assert.Error(t, err)
+		snapshotMock.AssertNotCalled(t, "RefreshByVulnerabilities")
+	})
+}
+
+func TestExportVulnerabilities(t *testing.T) {
+	t.Run("should success export vulnerabilities", func(t *testing.T) {
+		repositoryMock := &vulnerability.Mock{}
+
+		repositoryMock.On("ExportVulnerabilities").Return(nil)
+
+		controller := Controller{managementRepository: repositoryMock}
+
+		err := controller.ExportVulnerabilities(&dto.VulnExportFilter{VulnFilter: dto.VulnFilter{RepositoryID: uuid.New()}},
+			func(vulnExport *dto.VulnExport) error { return nil })
+		assert.NoError(t, err)
+	})
+}
+
+func TestBulkUpdateVulnType(t *testing.T) {
+	t.Run("should success bulk update data with no errors", func(t *testing.T) {
+		repositoryMock := &vulnerability.Mock{}
+
+		snapshotMock := &snapshot.Mock{}
+
+		repositoryMock.On("BulkUpdateVulnType").Return(2, nil)
+		snapshotMock.On("RefreshByRepository").Return(nil)
+
+		controller := Controller{managementRepository: repositoryMock, repoSnapshot: snapshotMock}
+
+		count, err := controller.BulkUpdateVulnType(uuid.New(), &dto.BulkUpdateVulnType{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		snapshotMock.AssertCalled(t, "RefreshByRepository")
+	})
+
+	t.Run("should not refresh daily snapshots when nothing was updated", func(t *testing.T) {
+		repositoryMock := &vulnerability.Mock{}
+		snapshotMock := &snapshot.Mock{}
+
+		repositoryMock.On("BulkUpdateVulnType").Return(0, nil)
+
+		controller := Controller{managementRepository: repositoryMock, repoSnapshot: snapshotMock}
+
+		count, err := controller.BulkUpdateVulnType(uuid.New(), &dto.BulkUpdateVulnType{})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+		snapshotMock.AssertNotCalled(t, "RefreshByRepository")
+	})
+}
+
+func TestListVulnTypeHistory(t *testing.T) {
+	t.Run("should success list vulnerability type history", func(t *testing.T) {
+		repositoryMock := &vulnerability.Mock{}
+
+		repositoryMock.On("ListVulnTypeHistory").Return(&[]horusec.VulnerabilityTypeHistory{{}}, nil)
 
 		controller := Controller{managementRepository: repositoryMock}
 
-		_, err := controller.UpdateVulnType(uuid.New(), &dto.UpdateVulnType{})
+		result, err := controller.ListVulnTypeHistory(uuid.New(), uuid.New())
 		assert.NoError(t, err)
+		assert.Len(t, *result, 1)
 	})
 }
@@ -0,0 +1,114 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	repositoryAnalysis "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/analysis"
+	repositoryPolicy "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/policy"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/vulnerability"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	apiEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	horusecEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/google/uuid"
+)
+
+type IController interface {
+	Evaluate(analysisData *apiEntities.AnalysisData, analysis *horusecEntities.Analysis) error
+}
+
+type Controller struct {
+	repoPolicy           repositoryPolicy.IRepository
+	repoAnalysis         repositoryAnalysis.IAnalysisRepository
+	managementRepository vulnerability.IRepository
+}
+
+func NewPolicyController(postgresRead relational.InterfaceRead,
+	postgresWrite relational.InterfaceWrite) IController {
+	return &Controller{
+		repoPolicy:           repositoryPolicy.NewPolicyRepository(postgresRead, postgresWrite),
+		repoAnalysis:         repositoryAnalysis.NewAnalysisRepository(postgresRead, postgresWrite),
+		managementRepository: vulnerability.NewManagementRepository(postgresRead, postgresWrite),
+	}
+}
+
+// Evaluate sets and saves the verdict of the company and repository policies in the saved analysis. When the
+// policies can not be evaluated the analysis is saved as not evaluated, it returns error only when the verdict can
+// not be saved
+func (c *Controller) Evaluate(analysisData *apiEntities.AnalysisData, analysis *horusecEntities.Analysis) error {
+	policies, err := c.repoPolicy.ListApplicable(analysis.CompanyID, analysis.RepositoryID)
+	if err != nil {
+		return c.saveNotEvaluated(analysis, err)
+	}
+
+	if len(*policies) == 0 {
+		return nil
+	}
+
+	input, err := c.newPolicyInput(analysisData, analysis.ID)
+	if err != nil {
+		return c.saveNotEvaluated(analysis, err)
+	}
+
+	var reasons []string
+	for index := range *policies {
+		reasons = append(reasons, (*policies)[index].Evaluate(input, time.Now())...)
+	}
+
+	return c.repoAnalysis.UpdatePolicyVerdict(analysis.SetPolicyVerdict(reasons))
+}
+
+func (c *Controller) saveNotEvaluated(analysis *horusecEntities.Analysis, err error) error {
+	logger.LogError(errorsEnums.ErrEvaluatePolicies, err)
+	return c.repoAnalysis.UpdatePolicyVerdict(analysis.SetPolicyNotEvaluated(errorsEnums.ErrorPolicyNotEvaluated.Error()))
+}
+
+// newPolicyInput uses the saved analysis, its vulnerabilities have the types changed in horusec-manager
+func (c *Controller) newPolicyInput(analysisData *apiEntities.AnalysisData,
+	analysisID uuid.UUID) (*accountEntities.PolicyInput, error) {
+	saved, err := c.repoAnalysis.GetByID(analysisID)
+	if err != nil {
+		return nil, err
+	}
+
+	riskAcceptedAt, err := c.managementRepository.ListRiskAcceptedDates(c.getRiskAcceptedIDs(saved))
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountEntities.PolicyInput{
+		HighVulnerabilities: saved.GetTotalVulnerabilitiesBySeverity()[horusec.Vulnerability][severity.High],
+		DisabledTools:       analysisData.DisabledTools,
+		Languages:           analysisData.Languages,
+		RiskAcceptedAt:      riskAcceptedAt,
+	}, nil
+}
+
+func (c *Controller) getRiskAcceptedIDs(analysis *horusecEntities.Analysis) (ids []uuid.UUID) {
+	for index := range analysis.AnalysisVulnerabilities {
+		vuln := analysis.AnalysisVulnerabilities[index].Vulnerability
+		if vuln.Type == horusec.RiskAccepted {
+			ids = append(ids, vuln.VulnerabilityID)
+		}
+	}
+
+	return ids
+}
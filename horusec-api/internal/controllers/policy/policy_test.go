@@ -0,0 +1,166 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	repositoryAnalysis "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/analysis"
+	repositoryPolicy "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/policy"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/vulnerability"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	apiEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	horusecEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSavedAnalysis() *horusecEntities.Analysis {
+	newVulnerability := func(vulnSeverity severity.Severity, vulnType horusec.VulnerabilityType) horusecEntities.
+		AnalysisVulnerabilities {
+		return horusecEntities.AnalysisVulnerabilities{Vulnerability: horusecEntities.Vulnerability{
+			VulnerabilityID: uuid.New(), Severity: vulnSeverity, Type: vulnType}}
+	}
+
+	return &horusecEntities.Analysis{ID: uuid.New(), AnalysisVulnerabilities: []horusecEntities.AnalysisVulnerabilities{
+		newVulnerability(severity.High, horusec.Vulnerability),
+		newVulnerability(severity.High, horusec.FalsePositive),
+		newVulnerability(severity.High, horusec.RiskAccepted),
+		newVulnerability(severity.Low, horusec.Vulnerability),
+	}}
+}
+
+func newController(policyMock *repositoryPolicy.Mock, analysisMock *repositoryAnalysis.Mock,
+	managementMock *vulnerability.Mock) *Controller {
+	return &Controller{repoPolicy: policyMock, repoAnalysis: analysisMock, managementRepository: managementMock}
+}
+
+func TestNewPolicyController(t *testing.T) {
+	t.Run("should create a new controller", func(t *testing.T) {
+		assert.NotNil(t, NewPolicyController(&relational.MockRead{}, &relational.MockWrite{}))
+	})
+}
+
+func TestEvaluate(t *testing.T) {
+	maxHigh := 0
+
+	t.Run("should not set a verdict when there are no policies", func(t *testing.T) {
+		policyMock := &repositoryPolicy.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		policyMock.On("ListApplicable").Return(&[]accountEntities.Policy{}, nil)
+
+		analysis := &horusecEntities.Analysis{ID: uuid.New()}
+		assert.NoError(t, newController(policyMock, analysisMock, &vulnerability.Mock{}).
+			Evaluate(&apiEntities.AnalysisData{}, analysis))
+		assert.Empty(t, analysis.PolicyStatus)
+		analysisMock.AssertNotCalled(t, "UpdatePolicyVerdict")
+	})
+
+	t.Run("should pass when the analysis follows the policies", func(t *testing.T) {
+		policyMock := &repositoryPolicy.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		managementMock := &vulnerability.Mock{}
+		policyMock.On("ListApplicable").Return(&[]accountEntities.Policy{
+			{Name: "company", RequiredLanguages: []string{"Go"}, MaxRiskAcceptAgeInDays: 30}}, nil)
+		analysisMock.On("GetByID").Return(newSavedAnalysis(), nil)
+		analysisMock.On("UpdatePolicyVerdict").Return(nil)
+		managementMock.On("ListRiskAcceptedDates").Return([]time.Time{time.Now().AddDate(0, 0, -1)}, nil)
+
+		analysis := &horusecEntities.Analysis{ID: uuid.New()}
+		assert.NoError(t, newController(policyMock, analysisMock, managementMock).
+			Evaluate(&apiEntities.AnalysisData{Languages: []string{"Go"}}, analysis))
+		assert.Equal(t, horusec.PolicyPassed, analysis.PolicyStatus)
+		assert.Empty(t, analysis.PolicyReasons)
+	})
+
+	t.Run("should fail with the reasons of all policies", func(t *testing.T) {
+		policyMock := &repositoryPolicy.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		managementMock := &vulnerability.Mock{}
+		policyMock.On("ListApplicable").Return(&[]accountEntities.Policy{
+			{Name: "company", MaxHighVulnerabilities: &maxHigh},
+			{Name: "repository", RequiredTools: []string{"GoSec"}}}, nil)
+		analysisMock.On("GetByID").Return(newSavedAnalysis(), nil)
+		analysisMock.On("UpdatePolicyVerdict").Return(nil)
+		managementMock.On("ListRiskAcceptedDates").Return([]time.Time{}, nil)
+
+		analysis := &horusecEntities.Analysis{ID: uuid.New()}
+		assert.NoError(t, newController(policyMock, analysisMock, managementMock).
+			Evaluate(&apiEntities.AnalysisData{DisabledTools: []string{"GoSec"}}, analysis))
+		assert.Equal(t, horusec.PolicyFailed, analysis.PolicyStatus)
+		assert.Equal(t, []string{"[company] found 1 HIGH vulnerabilities and the maximum allowed is 0",
+			"[repository] the tool GoSec is required and was disabled"}, []string(analysis.PolicyReasons))
+	})
+
+	t.Run("should save as not evaluated when the policies can not be listed", func(t *testing.T) {
+		policyMock := &repositoryPolicy.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		policyMock.On("ListApplicable").Return(&[]accountEntities.Policy{}, errors.New("test"))
+		analysisMock.On("UpdatePolicyVerdict").Return(nil)
+
+		analysis := &horusecEntities.Analysis{ID: uuid.New()}
+		assert.NoError(t, newController(policyMock, analysisMock, &vulnerability.Mock{}).
+			Evaluate(&apiEntities.AnalysisData{}, analysis))
+		assert.Equal(t, horusec.PolicyNotEvaluated, analysis.PolicyStatus)
+		assert.Equal(t, []string{errorsEnums.ErrorPolicyNotEvaluated.Error()}, []string(analysis.PolicyReasons))
+	})
+
+	t.Run("should save as not evaluated when the saved analysis can not be found", func(t *testing.T) {
+		policyMock := &repositoryPolicy.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		policyMock.On("ListApplicable").Return(&[]accountEntities.Policy{{Name: "company"}}, nil)
+		analysisMock.On("GetByID").Return(&horusecEntities.Analysis{}, errors.New("test"))
+		analysisMock.On("UpdatePolicyVerdict").Return(nil)
+
+		analysis := &horusecEntities.Analysis{ID: uuid.New()}
+		assert.NoError(t, newController(policyMock, analysisMock, &vulnerability.Mock{}).
+			Evaluate(&apiEntities.AnalysisData{}, analysis))
+		assert.Equal(t, horusec.PolicyNotEvaluated, analysis.PolicyStatus)
+	})
+
+	t.Run("should save as not evaluated when the risk accepted dates can not be listed", func(t *testing.T) {
+		policyMock := &repositoryPolicy.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		managementMock := &vulnerability.Mock{}
+		policyMock.On("ListApplicable").Return(&[]accountEntities.Policy{{Name: "company"}}, nil)
+		analysisMock.On("GetByID").Return(newSavedAnalysis(), nil)
+		analysisMock.On("UpdatePolicyVerdict").Return(nil)
+		managementMock.On("ListRiskAcceptedDates").Return([]time.Time{}, errors.New("test"))
+
+		analysis := &horusecEntities.Analysis{ID: uuid.New()}
+		assert.NoError(t, newController(policyMock, analysisMock, managementMock).
+			Evaluate(&apiEntities.AnalysisData{}, analysis))
+		assert.Equal(t, horusec.PolicyNotEvaluated, analysis.PolicyStatus)
+	})
+
+	t.Run("should return error when the verdict can not be saved", func(t *testing.T) {
+		policyMock := &repositoryPolicy.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		managementMock := &vulnerability.Mock{}
+		policyMock.On("ListApplicable").Return(&[]accountEntities.Policy{{Name: "company"}}, nil)
+		analysisMock.On("GetByID").Return(newSavedAnalysis(), nil)
+		analysisMock.On("UpdatePolicyVerdict").Return(errors.New("test"))
+		managementMock.On("ListRiskAcceptedDates").Return([]time.Time{}, nil)
+
+		assert.Error(t, newController(policyMock, analysisMock, managementMock).
+			Evaluate(&apiEntities.AnalysisData{}, &horusecEntities.Analysis{ID: uuid.New()}))
+	})
+}
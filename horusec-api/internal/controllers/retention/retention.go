@@ -0,0 +1,142 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retention
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	repositoryAnalysis "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/analysis"
+	repositoryCompany "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	repositorySnapshot "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/snapshot"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-api/config/app"
+	"github.com/google/uuid"
+)
+
+type IController interface {
+	PruneExpired() error
+}
+
+type Controller struct {
+	repoCompany  repositoryCompany.ICompanyRepository
+	repoAnalysis repositoryAnalysis.IAnalysisRepository
+	repoSnapshot repositorySnapshot.ISnapshotRepository
+	config       app.IAppConfig
+}
+
+func NewRetentionController(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite,
+	config app.IAppConfig) IController {
+	return &Controller{
+		repoCompany:  repositoryCompany.NewCompanyRepository(postgresRead, postgresWrite),
+		repoAnalysis: repositoryAnalysis.NewAnalysisRepository(postgresRead, postgresWrite),
+		repoSnapshot: repositorySnapshot.NewSnapshotRepository(postgresRead, postgresWrite),
+		config:       config,
+	}
+}
+
+// PruneExpired removes the analyses older than the retention of each company and then the vulnerabilities left
+// without analysis, a company that fails is logged so the others are still pruned. Nothing is removed when the
+// dashboard does not read the daily snapshots, because its totals would change
+func (c *Controller) PruneExpired() error {
+	if !c.config.IsDailySnapshotsEnabled() {
+		logger.LogWarnWithLevel(errorsEnums.MsgPruneWithoutDailySnapshots)
+		return nil
+	}
+
+	companies, err := c.repoCompany.ListAll()
+	if err != nil {
+		return err
+	}
+
+	hasRetention := false
+	for index := range *companies {
+		company := (*companies)[index]
+		if company.AnalysisRetentionDays <= 0 {
+			continue
+		}
+
+		hasRetention = true
+		finishedBefore := time.Now().AddDate(0, 0, -company.AnalysisRetentionDays)
+		if err := c.pruneCompany(company.CompanyID, finishedBefore); err != nil {
+			logger.LogError(errorsEnums.ErrPruneExpiredAnalysis, err)
+		}
+	}
+
+	if !hasRetention {
+		return nil
+	}
+
+	return c.pruneOrphanVulnerabilities()
+}
+
+func (c *Controller) pruneCompany(companyID uuid.UUID, finishedBefore time.Time) error {
+	size := c.config.GetAnalysisRetentionBatchSize()
+	for {
+		analysis, err := c.repoAnalysis.ListToPrune(companyID, finishedBefore, size)
+		if err != nil || len(analysis) == 0 {
+			return err
+		}
+
+		if err := c.pruneBatch(analysis); err != nil || len(analysis) < size {
+			return err
+		}
+	}
+}
+
+// pruneBatch keeps the analysis that the daily snapshot of each day was created from and removes the others, a day
+// without snapshot has it created from its first analysis in the batch. The daily analyses keep the history read
+// from the analysis by the developers, time to fix and score analytics
+func (c *Controller) pruneBatch(analysis []horusec.Analysis) error {
+	analysisIDs := make([]uuid.UUID, 0, len(analysis))
+	for index := range analysis {
+		isDaily, err := c.isDailyAnalysis(&analysis[index])
+		if err != nil {
+			return err
+		}
+
+		if !isDaily {
+			analysisIDs = append(analysisIDs, analysis[index].ID)
+		}
+	}
+
+	if len(analysisIDs) == 0 {
+		return nil
+	}
+
+	return c.repoAnalysis.DeleteByIDs(analysisIDs)
+}
+
+// isDailyAnalysis creates the missing snapshot of the day from the analysis, the analyses come newest first
+func (c *Controller) isDailyAnalysis(analysis *horusec.Analysis) (bool, error) {
+	snapshot, err := c.repoSnapshot.GetDaily(analysis)
+	if err != nil || snapshot != nil {
+		return snapshot != nil && snapshot.AnalysisID == analysis.ID, err
+	}
+
+	return true, c.repoSnapshot.Refresh(analysis)
+}
+
+func (c *Controller) pruneOrphanVulnerabilities() error {
+	size := c.config.GetAnalysisRetentionBatchSize()
+	for {
+		removed, err := c.repoAnalysis.DeleteOrphanVulnerabilities(size)
+		if err != nil || removed < int64(size) {
+			return err
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retention
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	repositoryAnalysis "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/analysis"
+	repositoryCompany "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	repositorySnapshot "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/snapshot"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/dashboard"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/ZupIT/horusec/horusec-api/config/app"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCompanies(retentionDays ...int) *[]accountEntities.Company {
+	companies := make([]accountEntities.Company, 0, len(retentionDays))
+	for _, days := range retentionDays {
+		companies = append(companies, accountEntities.Company{CompanyID: uuid.New(), AnalysisRetentionDays: days})
+	}
+
+	return &companies
+}
+
+func newController(companyMock *repositoryCompany.Mock, analysisMock *repositoryAnalysis.Mock,
+	snapshotMock *repositorySnapshot.Mock, batchSize int) *Controller {
+	return &Controller{repoCompany: companyMock, repoAnalysis: analysisMock, repoSnapshot: snapshotMock,
+		config: &app.Config{AnalysisRetentionBatchSize: batchSize, DailySnapshots: true}}
+}
+
+func TestNewRetentionController(t *testing.T) {
+	t.Run("should create a new controller", func(t *testing.T) {
+		controller := NewRetentionController(&relational.MockRead{}, &relational.MockWrite{}, &app.Config{})
+		assert.NotNil(t, controller)
+	})
+}
+
+func TestPruneExpired(t *testing.T) {
+	t.Run("should prune in batches only the companies with retention", func(t *testing.T) {
+		companyMock := &repositoryCompany.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		snapshotMock := &repositorySnapshot.Mock{}
+		companyMock.On("ListAll").Return(newCompanies(0, 30), nil)
+		analysisMock.On("ListToPrune").Return([]horusec.Analysis{{ID: uuid.New()}, {ID: uuid.New()}}, nil).Once()
+		analysisMock.On("ListToPrune").Return([]horusec.Analysis{{ID: uuid.New()}}, nil).Once()
+		analysisMock.On("DeleteByIDs").Return(nil)
+		analysisMock.On("DeleteOrphanVulnerabilities").Return(int64(2), nil).Once()
+		analysisMock.On("DeleteOrphanVulnerabilities").Return(int64(1), nil).Once()
+		snapshotMock.On("GetDaily").Return(&dashboard.DailySnapshot{AnalysisID: uuid.New()}, nil)
+
+		assert.NoError(t, newController(companyMock, analysisMock, snapshotMock, 2).PruneExpired())
+		analysisMock.AssertNumberOfCalls(t, "ListToPrune", 2)
+		analysisMock.AssertNumberOfCalls(t, "DeleteByIDs", 2)
+		analysisMock.AssertNumberOfCalls(t, "DeleteOrphanVulnerabilities", 2)
+		snapshotMock.AssertNotCalled(t, "Refresh")
+	})
+
+	t.Run("should keep the analysis of the daily snapshot and create the missing ones", func(t *testing.T) {
+		companyMock := &repositoryCompany.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		snapshotMock := &repositorySnapshot.Mock{}
+		daily := uuid.New()
+		companyMock.On("ListAll").Return(newCompanies(30), nil)
+		analysisMock.On("ListToPrune").Return([]horusec.Analysis{{ID: daily}, {ID: uuid.New()}, {ID: uuid.New()}}, nil)
+		analysisMock.On("DeleteByIDs").Return(nil)
+		analysisMock.On("DeleteOrphanVulnerabilities").Return(int64(0), nil)
+		snapshotMock.On("GetDaily").Return(&dashboard.DailySnapshot{AnalysisID: daily}, nil).Once()
+		snapshotMock.On("GetDaily").Return((*dashboard.DailySnapshot)(nil), nil).Once()
+		snapshotMock.On("GetDaily").Return(&dashboard.DailySnapshot{AnalysisID: daily}, nil).Once()
+		snapshotMock.On("Refresh").Return(nil)
+
+		assert.NoError(t, newController(companyMock, analysisMock, snapshotMock, 10).PruneExpired())
+		snapshotMock.AssertNumberOfCalls(t, "Refresh", 1)
+		analysisMock.AssertNumberOfCalls(t, "DeleteByIDs", 1)
+	})
+
+	t.Run("should not delete when all analysis are daily", func(t *testing.T) {
+		companyMock := &repositoryCompany.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		snapshotMock := &repositorySnapshot.Mock{}
+		companyMock.On("ListAll").Return(newCompanies(30), nil)
+		analysisMock.On("ListToPrune").Return([]horusec.Analysis{{ID: uuid.New()}}, nil)
+		analysisMock.On("DeleteOrphanVulnerabilities").Return(int64(0), nil)
+		snapshotMock.On("GetDaily").Return((*dashboard.DailySnapshot)(nil), nil)
+		snapshotMock.On("Refresh").Return(nil)
+
+		assert.NoError(t, newController(companyMock, analysisMock, snapshotMock, 10).PruneExpired())
+		analysisMock.AssertNotCalled(t, "DeleteByIDs")
+	})
+
+	t.Run("should not prune when the daily snapshots are disabled", func(t *testing.T) {
+		companyMock := &repositoryCompany.Mock{}
+		controller := newController(companyMock, &repositoryAnalysis.Mock{}, &repositorySnapshot.Mock{}, 10)
+		controller.config = &app.Config{DailySnapshots: false}
+
+		assert.NoError(t, controller.PruneExpired())
+		companyMock.AssertNotCalled(t, "ListAll")
+	})
+
+	t.Run("should not delete the analysis when snapshot fails", func(t *testing.T) {
+		companyMock := &repositoryCompany.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		snapshotMock := &repositorySnapshot.Mock{}
+		companyMock.On("ListAll").Return(newCompanies(30, 30), nil)
+		analysisMock.On("ListToPrune").Return([]horusec.Analysis{{ID: uuid.New()}}, nil)
+		analysisMock.On("DeleteOrphanVulnerabilities").Return(int64(0), nil)
+		snapshotMock.On("GetDaily").Return((*dashboard.DailySnapshot)(nil), nil)
+		snapshotMock.On("Refresh").Return(errors.New("test"))
+
+		assert.NoError(t, newController(companyMock, analysisMock, snapshotMock, 10).PruneExpired())
+		analysisMock.AssertNumberOfCalls(t, "ListToPrune", 2)
+		analysisMock.AssertNotCalled(t, "DeleteByIDs")
+	})
+
+	t.Run("should not prune when no company has retention", func(t *testing.T) {
+		companyMock := &repositoryCompany.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		companyMock.On("ListAll").Return(newCompanies(0), nil)
+
+		assert.NoError(t, newController(companyMock, analysisMock, nil, 10).PruneExpired())
+		analysisMock.AssertNotCalled(t, "ListToPrune")
+		analysisMock.AssertNotCalled(t, "DeleteOrphanVulnerabilities")
+	})
+
+	t.Run("should return error when list companies fails", func(t *testing.T) {
+		companyMock := &repositoryCompany.Mock{}
+		companyMock.On("ListAll").Return(&[]accountEntities.Company{}, errors.New("test"))
+
+		assert.Error(t, newController(companyMock, nil, nil, 10).PruneExpired())
+	})
+
+	t.Run("should return error when delete orphan vulnerabilities fails", func(t *testing.T) {
+		companyMock := &repositoryCompany.Mock{}
+		analysisMock := &repositoryAnalysis.Mock{}
+		companyMock.On("ListAll").Return(newCompanies(30), nil)
+		analysisMock.On("ListToPrune").Return([]horusec.Analysis{}, nil)
+		analysisMock.On("DeleteOrphanVulnerabilities").Return(int64(0), errors.New("test"))
+
+		assert.Error(t, newController(companyMock, analysisMock, nil, 10).PruneExpired())
+	})
+}
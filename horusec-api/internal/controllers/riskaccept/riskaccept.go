@@ -0,0 +1,185 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package riskaccept
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	repositorySnapshot "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/snapshot"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/vulnerability"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	accountEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/account"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	emailEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/messages"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/queues"
+	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	notificationService "github.com/ZupIT/horusec/development-kit/pkg/services/notification"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-api/config/app"
+	"github.com/google/uuid"
+)
+
+type IController interface {
+	RevertExpired(repositoryID uuid.UUID) error
+	WarnExpiring() error
+}
+
+type Controller struct {
+	managementRepository vulnerability.IRepository
+	repoRepository       repository.IRepository
+	repoSnapshot         repositorySnapshot.ISnapshotRepository
+	broker               brokerLib.IBroker
+	config               app.IAppConfig
+	notificationService  notificationService.IService
+}
+
+func NewRiskAcceptController(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite,
+	broker brokerLib.IBroker, config app.IAppConfig) IController {
+	return &Controller{
+		managementRepository: vulnerability.NewManagementRepository(postgresRead, postgresWrite),
+		repoRepository:       repository.NewRepository(postgresRead, postgresWrite),
+		repoSnapshot:         repositorySnapshot.NewSnapshotRepository(postgresRead, postgresWrite),
+		broker:               broker,
+		config:               config,
+		notificationService:  notificationService.NewNotificationService(postgresRead, broker),
+	}
+}
+
+// RevertExpired reverts each repository after its notice, so a failed notice is sent again on the next run instead
+// of being lost. Meanwhile the analysis uploads already handle the expired acceptances as vulnerabilities
+func (c *Controller) RevertExpired(repositoryID uuid.UUID) error {
+	expirations, err := c.managementRepository.ListRiskAcceptExpired(repositoryID)
+	if err != nil {
+		return err
+	}
+
+	for expiredRepositoryID, repositoryExpirations := range dto.GroupRiskAcceptExpirationsByRepository(expirations) {
+		if err := c.revert(expiredRepositoryID, repositoryExpirations); err != nil {
+			logger.LogError(errorsEnums.ErrRevertExpiredRiskAccept, err,
+				map[string]interface{}{"repositoryID": expiredRepositoryID})
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) revert(repositoryID uuid.UUID, expirations []dto.RiskAcceptExpiration) error {
+	if err := c.notify(repositoryID, expirations, notificationEnum.RiskAcceptExpired, emailEnum.RiskAcceptExpired,
+		"[Horusec] Risk acceptance expired"); err != nil {
+		return err
+	}
+
+	if err := c.managementRepository.RevertExpiredRiskAccept(expirations); err != nil {
+		return err
+	}
+
+	c.refreshDailySnapshots(expirations)
+	return nil
+}
+
+// refreshDailySnapshots does not fail the revert, the snapshots can be recreated by the analytic backfill
+func (c *Controller) refreshDailySnapshots(expirations []dto.RiskAcceptExpiration) {
+	if err := c.repoSnapshot.RefreshByVulnerabilities(dto.GetRiskAcceptExpirationIDs(expirations)); err != nil {
+		logger.LogError(errorsEnums.ErrorRefreshDailySnapshot, err)
+	}
+}
+
+func (c *Controller) WarnExpiring() error {
+	expirations, err := c.managementRepository.ListRiskAcceptExpiringUntil(
+		time.Now().AddDate(0, 0, c.config.GetRiskAcceptWarningInDays()))
+	if err != nil {
+		return err
+	}
+
+	for repositoryID, repositoryExpirations := range dto.GroupRiskAcceptExpirationsByRepository(expirations) {
+		if err := c.warn(repositoryID, repositoryExpirations); err != nil {
+			logger.LogError(errorsEnums.ErrWarnExpiringRiskAccept, err,
+				map[string]interface{}{"repositoryID": repositoryID})
+		}
+	}
+
+	return nil
+}
+
+// warn sets the vulnerabilities of each repository as warned after its own notice, so a failure does not block or
+// repeat the others
+func (c *Controller) warn(repositoryID uuid.UUID, expirations []dto.RiskAcceptExpiration) error {
+	if err := c.notify(repositoryID, expirations, notificationEnum.RiskAcceptExpiring,
+		emailEnum.RiskAcceptExpiring, "[Horusec] Risk acceptance expiring"); err != nil {
+		return err
+	}
+
+	return c.managementRepository.SetRiskExpirationWarned(dto.GetRiskAcceptExpirationIDs(expirations))
+}
+
+func (c *Controller) notify(repositoryID uuid.UUID, expirations []dto.RiskAcceptExpiration,
+	event notificationEnum.Event, templateName, subject string) error {
+	if c.config.IsDisabledBroker() {
+		return nil
+	}
+
+	if err := c.sendEmailToAdmins(repositoryID, expirations, templateName, subject); err != nil {
+		return err
+	}
+
+	return c.notificationService.Dispatch(expirations[0].CompanyID, repositoryID, event,
+		c.newChatMessage(expirations, subject))
+}
+
+func (c *Controller) sendEmailToAdmins(repositoryID uuid.UUID, expirations []dto.RiskAcceptExpiration,
+	templateName, subject string) error {
+	accounts, err := c.repoRepository.GetAllAccountsInRepository(repositoryID)
+	if err != nil {
+		return err
+	}
+
+	for _, account := range *accounts {
+		if account.Role != string(accountEnums.Admin) {
+			continue
+		}
+
+		emailMessage := messages.EmailMessage{
+			To:           account.Email,
+			TemplateName: templateName,
+			Subject:      subject,
+			Data: map[string]interface{}{"username": account.Username,
+				"repositoryName": expirations[0].RepositoryName, "vulnerabilities": expirations},
+		}
+
+		if err := c.broker.Publish(queues.HorusecEmail.ToString(), "", "", emailMessage.ToBytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) newChatMessage(expirations []dto.RiskAcceptExpiration, title string) *messages.ChatMessage {
+	chatMessage := &messages.ChatMessage{
+		Title: title,
+		Text: fmt.Sprintf("Risk acceptance of %d vulnerabilities of the repository %s",
+			len(expirations), expirations[0].RepositoryName),
+		Link: env.GetHorusecManagerURL(),
+	}
+
+	return chatMessage.AddField("Total vulnerabilities", strconv.Itoa(len(expirations)))
+}
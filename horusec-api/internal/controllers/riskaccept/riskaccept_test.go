@@ -0,0 +1,195 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package riskaccept
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/snapshot"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/vulnerability"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	notificationService "github.com/ZupIT/horusec/development-kit/pkg/services/notification"
+	"github.com/ZupIT/horusec/horusec-api/config/app"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newExpirations() []dto.RiskAcceptExpiration {
+	repositoryID := uuid.New()
+	return []dto.RiskAcceptExpiration{
+		{VulnerabilityID: uuid.New(), RepositoryID: repositoryID, RepositoryName: "test"},
+		{VulnerabilityID: uuid.New(), RepositoryID: repositoryID, RepositoryName: "test"},
+	}
+}
+
+func newAccounts() *[]roles.AccountRole {
+	return &[]roles.AccountRole{
+		{AccountID: uuid.New(), Email: "admin@horusec.com", Username: "admin", Role: "admin"},
+		{AccountID: uuid.New(), Email: "member@horusec.com", Username: "member", Role: "member"},
+	}
+}
+
+func newSnapshotMock() *snapshot.Mock {
+	snapshotMock := &snapshot.Mock{}
+	snapshotMock.On("RefreshByVulnerabilities").Return(nil)
+	return snapshotMock
+}
+
+func TestNewRiskAcceptController(t *testing.T) {
+	t.Run("should create a new controller", func(t *testing.T) {
+		controller := NewRiskAcceptController(&relational.MockRead{}, &relational.MockWrite{}, nil, &app.Config{})
+		assert.NotNil(t, controller)
+	})
+}
+
+func TestRevertExpired(t *testing.T) {
+	t.Run("should notify repository admins and revert expired", func(t *testing.T) {
+		managementMock := &vulnerability.Mock{}
+		repositoryMock := &repository.Mock{}
+		brokerMock := &broker.Mock{}
+		notificationMock := &notificationService.Mock{}
+
+		managementMock.On("ListRiskAcceptExpired").Return(newExpirations(), nil)
+		managementMock.On("RevertExpiredRiskAccept").Return(nil)
+		repositoryMock.On("GetAllAccountsInRepository").Return(newAccounts(), nil)
+		brokerMock.On("Publish").Return(nil)
+		notificationMock.On("Dispatch").Return(nil)
+
+		controller := &Controller{managementRepository: managementMock, repoRepository: repositoryMock,
+			repoSnapshot: newSnapshotMock(),
+			broker:       brokerMock, config: &app.Config{}, notificationService: notificationMock}
+
+		assert.NoError(t, controller.RevertExpired(uuid.Nil))
+		managementMock.AssertNumberOfCalls(t, "RevertExpiredRiskAccept", 1)
+		controller.repoSnapshot.(*snapshot.Mock).AssertCalled(t, "RefreshByVulnerabilities")
+		brokerMock.AssertNumberOfCalls(t, "Publish", 1)
+		notificationMock.AssertNumberOfCalls(t, "Dispatch", 1)
+	})
+
+	t.Run("should revert without notify when broker is disabled", func(t *testing.T) {
+		managementMock := &vulnerability.Mock{}
+		repositoryMock := &repository.Mock{}
+
+		managementMock.On("ListRiskAcceptExpired").Return(newExpirations(), nil)
+		managementMock.On("RevertExpiredRiskAccept").Return(nil)
+
+		controller := &Controller{managementRepository: managementMock, repoRepository: repositoryMock,
+			repoSnapshot: newSnapshotMock(),
+			config:       &app.Config{DisabledBroker: true}}
+
+		assert.NoError(t, controller.RevertExpired(uuid.New()))
+		repositoryMock.AssertNotCalled(t, "GetAllAccountsInRepository")
+		managementMock.AssertNumberOfCalls(t, "RevertExpiredRiskAccept", 1)
+	})
+
+	t.Run("should return error when failed to list expired", func(t *testing.T) {
+		managementMock := &vulnerability.Mock{}
+		managementMock.On("ListRiskAcceptExpired").Return([]dto.RiskAcceptExpiration{}, errors.New("test"))
+
+		controller := &Controller{managementRepository: managementMock, config: &app.Config{}}
+
+		assert.Equal(t, errors.New("test"), controller.RevertExpired(uuid.Nil))
+	})
+
+	t.Run("should keep reverting other repositories when one fails to notify", func(t *testing.T) {
+		managementMock := &vulnerability.Mock{}
+		repositoryMock := &repository.Mock{}
+		brokerMock := &broker.Mock{}
+		notificationMock := &notificationService.Mock{}
+
+		managementMock.On("ListRiskAcceptExpired").Return(append(newExpirations(), newExpirations()...), nil)
+		managementMock.On("RevertExpiredRiskAccept").Return(nil)
+		repositoryMock.On("GetAllAccountsInRepository").Return(&[]roles.AccountRole{}, errors.New("test")).Once()
+		repositoryMock.On("GetAllAccountsInRepository").Return(newAccounts(), nil)
+		brokerMock.On("Publish").Return(nil)
+		notificationMock.On("Dispatch").Return(nil)
+
+		controller := &Controller{managementRepository: managementMock, repoRepository: repositoryMock,
+			repoSnapshot: newSnapshotMock(),
+			broker:       brokerMock, config: &app.Config{}, notificationService: notificationMock}
+
+		assert.NoError(t, controller.RevertExpired(uuid.Nil))
+		managementMock.AssertNumberOfCalls(t, "RevertExpiredRiskAccept", 1)
+		notificationMock.AssertNumberOfCalls(t, "Dispatch", 1)
+	})
+
+	t.Run("should not refresh the snapshots when failed to revert", func(t *testing.T) {
+		managementMock := &vulnerability.Mock{}
+		managementMock.On("ListRiskAcceptExpired").Return(newExpirations(), nil)
+		managementMock.On("RevertExpiredRiskAccept").Return(errors.New("test"))
+
+		controller := &Controller{managementRepository: managementMock, repoSnapshot: newSnapshotMock(),
+			config: &app.Config{DisabledBroker: true}}
+
+		assert.NoError(t, controller.RevertExpired(uuid.Nil))
+		controller.repoSnapshot.(*snapshot.Mock).AssertNotCalled(t, "RefreshByVulnerabilities")
+	})
+}
+
+func TestWarnExpiring(t *testing.T) {
+	t.Run("should notify expiring and set as warned", func(t *testing.T) {
+		managementMock := &vulnerability.Mock{}
+		repositoryMock := &repository.Mock{}
+		brokerMock := &broker.Mock{}
+		notificationMock := &notificationService.Mock{}
+
+		managementMock.On("ListRiskAcceptExpiringUntil").Return(newExpirations(), nil)
+		managementMock.On("SetRiskExpirationWarned").Return(nil)
+		repositoryMock.On("GetAllAccountsInRepository").Return(newAccounts(), nil)
+		brokerMock.On("Publish").Return(nil)
+		notificationMock.On("Dispatch").Return(nil)
+
+		controller := &Controller{managementRepository: managementMock, repoRepository: repositoryMock,
+			broker: brokerMock, config: &app.Config{RiskAcceptWarningInDays: 7}, notificationService: notificationMock}
+
+		assert.NoError(t, controller.WarnExpiring())
+		managementMock.AssertCalled(t, "SetRiskExpirationWarned")
+	})
+
+	t.Run("should keep warning other repositories when one fails to dispatch", func(t *testing.T) {
+		managementMock := &vulnerability.Mock{}
+		repositoryMock := &repository.Mock{}
+		brokerMock := &broker.Mock{}
+		notificationMock := &notificationService.Mock{}
+
+		managementMock.On("ListRiskAcceptExpiringUntil").Return(append(newExpirations(), newExpirations()...), nil)
+		managementMock.On("SetRiskExpirationWarned").Return(nil)
+		repositoryMock.On("GetAllAccountsInRepository").Return(newAccounts(), nil)
+		brokerMock.On("Publish").Return(nil)
+		notificationMock.On("Dispatch").Return(errors.New("test")).Once()
+		notificationMock.On("Dispatch").Return(nil)
+
+		controller := &Controller{managementRepository: managementMock, repoRepository: repositoryMock,
+			broker: brokerMock, config: &app.Config{}, notificationService: notificationMock}
+
+		assert.NoError(t, controller.WarnExpiring())
+		notificationMock.AssertNumberOfCalls(t, "Dispatch", 2)
+		managementMock.AssertNumberOfCalls(t, "SetRiskExpirationWarned", 1)
+	})
+
+	t.Run("should return error when failed to list expiring", func(t *testing.T) {
+		managementMock := &vulnerability.Mock{}
+		managementMock.On("ListRiskAcceptExpiringUntil").Return([]dto.RiskAcceptExpiration{}, errors.New("test"))
+
+		controller := &Controller{managementRepository: managementMock, config: &app.Config{}}
+
+		assert.Equal(t, errors.New("test"), controller.WarnExpiring())
+	})
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	tokenRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/token"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
+	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	tokenUseCases "github.com/ZupIT/horusec/horusec-api/internal/usecases/tokens"
 	"github.com/google/uuid"
 )
@@ -26,6 +28,7 @@ type IController interface {
 	CreateTokenCompany(*api.Token) (string, error)
 	DeleteTokenCompany(tokenID uuid.UUID) error
 	GetAllTokenCompany(repositoryID uuid.UUID) (*[]api.Token, error)
+	RotateTokenCompany(tokenID, companyID uuid.UUID, rotate *dto.RotateToken) (string, error)
 }
 
 type Controller struct {
@@ -58,3 +61,20 @@ func (c Controller) DeleteTokenCompany(tokenID uuid.UUID) error {
 func (c Controller) GetAllTokenCompany(companyID uuid.UUID) (*[]api.Token, error) {
 	return c.tokenRepository.GetAllOfCompany(companyID)
 }
+
+func (c Controller) RotateTokenCompany(tokenID, companyID uuid.UUID, rotate *dto.RotateToken) (string, error) {
+	token, err := c.tokenRepository.Get(tokenID)
+	if err != nil {
+		return "", err
+	}
+	if token.CompanyID != companyID || token.RepositoryID != nil {
+		return "", EnumErrors.ErrNotFoundRecords
+	}
+
+	token.Rotate(uuid.New(), rotate.GetGracePeriod(), rotate.GetExpiresAt())
+	if err := c.tokenRepository.Rotate(token); err != nil {
+		return "", err
+	}
+
+	return token.GetKey().String(), nil
+}
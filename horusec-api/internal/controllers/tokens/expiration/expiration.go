@@ -0,0 +1,127 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expiration
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	tokenRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/token"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	accountEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/account"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	emailEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/messages"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/queues"
+	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-api/config/app"
+	"github.com/google/uuid"
+)
+
+type IController interface {
+	WarnExpiring() error
+}
+
+type Controller struct {
+	tokenRepository   tokenRepository.IRepository
+	repoRepository    repository.IRepository
+	companyRepository company.ICompanyRepository
+	broker            brokerLib.IBroker
+	config            app.IAppConfig
+}
+
+func NewTokenExpirationController(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite,
+	broker brokerLib.IBroker, config app.IAppConfig) IController {
+	return &Controller{
+		tokenRepository:   tokenRepository.NewTokenRepository(postgresRead, postgresWrite),
+		repoRepository:    repository.NewRepository(postgresRead, postgresWrite),
+		companyRepository: company.NewCompanyRepository(postgresRead, postgresWrite),
+		broker:            broker,
+		config:            config,
+	}
+}
+
+func (c *Controller) WarnExpiring() error {
+	tokens, err := c.tokenRepository.ListExpiringUntil(
+		time.Now().AddDate(0, 0, c.config.GetTokenExpirationWarningInDays()))
+	if err != nil {
+		return err
+	}
+
+	for index := range *tokens {
+		if err := c.warn(&(*tokens)[index]); err != nil {
+			logger.LogError(errorsEnums.ErrWarnExpiringToken, err,
+				map[string]interface{}{"tokenID": (*tokens)[index].TokenID})
+		}
+	}
+
+	return nil
+}
+
+// warn sets each token as warned after its own notification, so a failure does not block or repeat the others
+func (c *Controller) warn(token *api.Token) error {
+	if err := c.notify(token); err != nil {
+		return err
+	}
+
+	return c.tokenRepository.SetExpirationWarned([]uuid.UUID{token.TokenID})
+}
+
+func (c *Controller) notify(token *api.Token) error {
+	if c.config.IsDisabledBroker() {
+		return nil
+	}
+
+	accounts, err := c.getAccounts(token)
+	if err != nil {
+		return err
+	}
+
+	for _, account := range *accounts {
+		if account.Role != string(accountEnums.Admin) {
+			continue
+		}
+
+		if err := c.sendEmail(token, account); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) getAccounts(token *api.Token) (*[]roles.AccountRole, error) {
+	if token.RepositoryID != nil {
+		return c.repoRepository.GetAllAccountsInRepository(*token.RepositoryID)
+	}
+
+	return c.companyRepository.GetAllAccountsInCompany(token.CompanyID)
+}
+
+func (c *Controller) sendEmail(token *api.Token, account roles.AccountRole) error {
+	emailMessage := messages.EmailMessage{
+		To:           account.Email,
+		TemplateName: emailEnum.TokenExpiring,
+		Subject:      "[Horusec] Token expiring",
+		Data: map[string]interface{}{"username": account.Username, "tokenDescription": token.Description,
+			"suffixValue": token.SuffixValue, "expiresAt": token.ExpiresAt.Format("2006-01-02 15:04")},
+	}
+
+	return c.broker.Publish(queues.HorusecEmail.ToString(), "", "", emailMessage.ToBytes())
+}
@@ -0,0 +1,150 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expiration
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	tokenRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/token"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/ZupIT/horusec/horusec-api/config/app"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTokens() *[]api.Token {
+	repositoryID := uuid.New()
+	return &[]api.Token{
+		{TokenID: uuid.New(), CompanyID: uuid.New(), RepositoryID: &repositoryID, Description: "repository"},
+		{TokenID: uuid.New(), CompanyID: uuid.New(), Description: "company"},
+	}
+}
+
+func newAccounts() *[]roles.AccountRole {
+	return &[]roles.AccountRole{
+		{AccountID: uuid.New(), Email: "admin@horusec.com", Username: "admin", Role: "admin"},
+		{AccountID: uuid.New(), Email: "member@horusec.com", Username: "member", Role: "member"},
+	}
+}
+
+func TestNewTokenExpirationController(t *testing.T) {
+	t.Run("should create a new controller", func(t *testing.T) {
+		controller := NewTokenExpirationController(&relational.MockRead{}, &relational.MockWrite{}, nil, &app.Config{})
+		assert.NotNil(t, controller)
+	})
+}
+
+func TestWarnExpiring(t *testing.T) {
+	t.Run("should warn repository and company admins and set tokens as warned", func(t *testing.T) {
+		tokenMock := &tokenRepository.Mock{}
+		repositoryMock := &repository.Mock{}
+		companyMock := &company.Mock{}
+		brokerMock := &broker.Mock{}
+
+		tokenMock.On("ListExpiringUntil").Return(newTokens(), nil)
+		tokenMock.On("SetExpirationWarned").Return(nil)
+		repositoryMock.On("GetAllAccountsInRepository").Return(newAccounts(), nil)
+		companyMock.On("GetAllAccountsInCompany").Return(newAccounts(), nil)
+		brokerMock.On("Publish").Return(nil)
+
+		controller := &Controller{tokenRepository: tokenMock, repoRepository: repositoryMock,
+			companyRepository: companyMock, broker: brokerMock, config: &app.Config{}}
+
+		assert.NoError(t, controller.WarnExpiring())
+		brokerMock.AssertNumberOfCalls(t, "Publish", 2)
+		tokenMock.AssertNumberOfCalls(t, "SetExpirationWarned", 2)
+	})
+
+	t.Run("should set tokens as warned without sending emails when broker is disabled", func(t *testing.T) {
+		tokenMock := &tokenRepository.Mock{}
+		repositoryMock := &repository.Mock{}
+
+		tokenMock.On("ListExpiringUntil").Return(newTokens(), nil)
+		tokenMock.On("SetExpirationWarned").Return(nil)
+
+		controller := &Controller{tokenRepository: tokenMock, repoRepository: repositoryMock,
+			config: &app.Config{DisabledBroker: true}}
+
+		assert.NoError(t, controller.WarnExpiring())
+		repositoryMock.AssertNotCalled(t, "GetAllAccountsInRepository")
+		tokenMock.AssertCalled(t, "SetExpirationWarned")
+	})
+
+	t.Run("should return error when failed to list expiring tokens", func(t *testing.T) {
+		tokenMock := &tokenRepository.Mock{}
+		tokenMock.On("ListExpiringUntil").Return(&[]api.Token{}, errors.New("test"))
+
+		controller := &Controller{tokenRepository: tokenMock, config: &app.Config{}}
+
+		assert.Equal(t, errors.New("test"), controller.WarnExpiring())
+	})
+
+	t.Run("should warn the other tokens when failed to get accounts of one", func(t *testing.T) {
+		tokenMock := &tokenRepository.Mock{}
+		repositoryMock := &repository.Mock{}
+		companyMock := &company.Mock{}
+		brokerMock := &broker.Mock{}
+
+		tokenMock.On("ListExpiringUntil").Return(newTokens(), nil)
+		tokenMock.On("SetExpirationWarned").Return(nil)
+		repositoryMock.On("GetAllAccountsInRepository").Return(&[]roles.AccountRole{}, errors.New("test"))
+		companyMock.On("GetAllAccountsInCompany").Return(newAccounts(), nil)
+		brokerMock.On("Publish").Return(nil)
+
+		controller := &Controller{tokenRepository: tokenMock, repoRepository: repositoryMock,
+			companyRepository: companyMock, broker: brokerMock, config: &app.Config{}}
+
+		assert.NoError(t, controller.WarnExpiring())
+		tokenMock.AssertNumberOfCalls(t, "SetExpirationWarned", 1)
+		brokerMock.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("should not set token as warned when failed to publish email", func(t *testing.T) {
+		tokenMock := &tokenRepository.Mock{}
+		repositoryMock := &repository.Mock{}
+		companyMock := &company.Mock{}
+		brokerMock := &broker.Mock{}
+
+		tokenMock.On("ListExpiringUntil").Return(newTokens(), nil)
+		repositoryMock.On("GetAllAccountsInRepository").Return(newAccounts(), nil)
+		companyMock.On("GetAllAccountsInCompany").Return(newAccounts(), nil)
+		brokerMock.On("Publish").Return(errors.New("test"))
+
+		controller := &Controller{tokenRepository: tokenMock, repoRepository: repositoryMock,
+			companyRepository: companyMock, broker: brokerMock, config: &app.Config{}}
+
+		assert.NoError(t, controller.WarnExpiring())
+		brokerMock.AssertNumberOfCalls(t, "Publish", 2)
+		tokenMock.AssertNotCalled(t, "SetExpirationWarned")
+	})
+
+	t.Run("should continue when failed to set one token as warned", func(t *testing.T) {
+		tokenMock := &tokenRepository.Mock{}
+
+		tokenMock.On("ListExpiringUntil").Return(newTokens(), nil)
+		tokenMock.On("SetExpirationWarned").Return(errors.New("test"))
+
+		controller := &Controller{tokenRepository: tokenMock, config: &app.Config{DisabledBroker: true}}
+
+		assert.NoError(t, controller.WarnExpiring())
+		tokenMock.AssertNumberOfCalls(t, "SetExpirationWarned", 2)
+	})
+}
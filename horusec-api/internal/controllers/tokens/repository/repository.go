@@ -18,6 +18,8 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	tokenRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/token"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
+	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	tokenUseCases "github.com/ZupIT/horusec/horusec-api/internal/usecases/tokens"
 	"github.com/google/uuid"
 )
@@ -26,6 +28,7 @@ type IController interface {
 	CreateTokenRepository(*api.Token) (string, error)
 	DeleteTokenRepository(tokenID uuid.UUID) error
 	GetAllTokenRepository(repositoryID uuid.UUID) (*[]api.Token, error)
+	RotateTokenRepository(tokenID, repositoryID uuid.UUID, rotate *dto.RotateToken) (string, error)
 }
 
 type Controller struct {
@@ -58,3 +61,20 @@ func (c *Controller) DeleteTokenRepository(tokenID uuid.UUID) error {
 func (c *Controller) GetAllTokenRepository(repositoryID uuid.UUID) (*[]api.Token, error) {
 	return c.tokenRepository.GetAllOfRepository(repositoryID)
 }
+
+func (c *Controller) RotateTokenRepository(tokenID, repositoryID uuid.UUID, rotate *dto.RotateToken) (string, error) {
+	token, err := c.tokenRepository.Get(tokenID)
+	if err != nil {
+		return "", err
+	}
+	if token.RepositoryID == nil || *token.RepositoryID != repositoryID {
+		return "", EnumErrors.ErrNotFoundRecords
+	}
+
+	token.Rotate(uuid.New(), rotate.GetGracePeriod(), rotate.GetExpiresAt())
+	if err := c.tokenRepository.Rotate(token); err != nil {
+		return "", err
+	}
+
+	return token.GetKey().String(), nil
+}
@@ -18,8 +18,11 @@ import (
 	"errors"
 	"testing"
 
+	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/sqlite" // Required in gorm usage
 
@@ -153,3 +156,69 @@ func TestGetAllOfRepository(t *testing.T) {
 		mockRead.AssertCalled(t, "Find")
 	})
 }
+
+func TestRotate(t *testing.T) {
+	t.Run("should rotate token and return the new key", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		ownerID := uuid.New()
+		token := &api.Token{TokenID: uuid.New(), RepositoryID: &ownerID, CompanyID: uuid.New()}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(1, nil, token))
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
+
+		controller := NewController(mockRead, mockWrite)
+
+		key, err := controller.RotateTokenRepository(token.TokenID, ownerID, &dto.RotateToken{GracePeriodInHours: 1})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, key)
+		mockWrite.AssertCalled(t, "Update")
+	})
+
+	t.Run("should return not found when token belongs to another owner", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		ownerID := uuid.New()
+		token := &api.Token{TokenID: uuid.New(), CompanyID: uuid.New()}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(1, nil, token))
+
+		controller := NewController(mockRead, mockWrite)
+
+		_, err := controller.RotateTokenRepository(token.TokenID, ownerID, &dto.RotateToken{})
+
+		assert.Equal(t, EnumErrors.ErrNotFoundRecords, err)
+		mockWrite.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("should return error when get token fails", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		controller := NewController(mockRead, mockWrite)
+
+		_, err := controller.RotateTokenRepository(uuid.New(), uuid.New(), &dto.RotateToken{})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when update fails", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		ownerID := uuid.New()
+		token := &api.Token{TokenID: uuid.New(), RepositoryID: &ownerID, CompanyID: uuid.New()}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(1, nil, token))
+		mockWrite.On("Update").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		controller := NewController(mockRead, mockWrite)
+
+		key, err := controller.RotateTokenRepository(token.TokenID, ownerID, &dto.RotateToken{})
+
+		assert.Error(t, err)
+		assert.Empty(t, key)
+	})
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	enumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	brokerPacket "github.com/ZupIT/horusec/development-kit/pkg/services/broker/packet"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	auditController "github.com/ZupIT/horusec/horusec-api/internal/controllers/audit"
+)
+
+type Consumer struct {
+	controller auditController.IController
+}
+
+func NewConsumer(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite) *Consumer {
+	return &Consumer{controller: auditController.NewAuditController(postgresRead, postgresWrite)}
+}
+
+func (c *Consumer) SaveEvent(packet brokerPacket.IPacket) {
+	event, err := audit.NewEventFromBytes(packet.GetBody())
+	if err != nil {
+		logger.LogError(enumErrors.ErrorPersistAuditEvent, err)
+		_ = packet.Ack()
+		return
+	}
+
+	if err := c.controller.Create(event); err != nil {
+		logger.LogError(enumErrors.ErrorPersistAuditEvent, err, map[string]interface{}{
+			"action":    event.Action,
+			"accountID": event.AccountID,
+		})
+	}
+
+	_ = packet.Ack()
+}
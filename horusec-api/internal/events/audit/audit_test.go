@@ -0,0 +1,69 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/broker/packet"
+	auditController "github.com/ZupIT/horusec/horusec-api/internal/controllers/audit"
+	"github.com/google/uuid"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConsumer(t *testing.T) {
+	t.Run("should successful create a new consumer", func(t *testing.T) {
+		assert.NotNil(t, NewConsumer(&relational.MockRead{}, &relational.MockWrite{}))
+	})
+}
+
+func TestSaveEvent(t *testing.T) {
+	t.Run("should save the event received", func(t *testing.T) {
+		controllerMock := &auditController.Mock{}
+		controllerMock.On("Create").Return(nil)
+		consumer := &Consumer{controller: controllerMock}
+
+		event := audit.NewEvent(auditEnums.CompanyDelete, uuid.New())
+		consumer.SaveEvent(packet.NewPacket(&amqp.Delivery{Body: event.ToBytes()}))
+
+		controllerMock.AssertCalled(t, "Create")
+	})
+
+	t.Run("should not panic when failed to save", func(t *testing.T) {
+		controllerMock := &auditController.Mock{}
+		controllerMock.On("Create").Return(errors.New("test"))
+		consumer := &Consumer{controller: controllerMock}
+
+		event := audit.NewEvent(auditEnums.CompanyDelete, uuid.New())
+
+		assert.NotPanics(t, func() {
+			consumer.SaveEvent(packet.NewPacket(&amqp.Delivery{Body: event.ToBytes()}))
+		})
+	})
+
+	t.Run("should not save when body is invalid", func(t *testing.T) {
+		controllerMock := &auditController.Mock{}
+		consumer := &Consumer{controller: controllerMock}
+
+		consumer.SaveEvent(packet.NewPacket(&amqp.Delivery{Body: []byte("invalid")}))
+
+		controllerMock.AssertNotCalled(t, "Create")
+	})
+}
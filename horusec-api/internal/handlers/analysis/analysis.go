@@ -16,10 +16,13 @@ package analysis
 
 import (
 	apiEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	horusecEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
 	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
 	"github.com/ZupIT/horusec/horusec-api/config/app"
 	netHTTP "net/http"
+	"strconv"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	_ "github.com/ZupIT/horusec/development-kit/pkg/entities/http" // [swagger-import]
@@ -39,7 +42,7 @@ type Handler struct {
 
 func NewHandler(
 	postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite,
-	broker brokerLib.IBroker, config app.IAppConfig) httpUtil.Interface {
+	broker brokerLib.IBroker, config app.IAppConfig) *Handler {
 	return &Handler{
 		useCases:           usecasesAnalysis.NewAnalysisUseCases(),
 		analysisController: analysis.NewAnalysisController(postgresRead, postgresWrite, broker, config),
@@ -52,13 +55,14 @@ func (h *Handler) Options(w netHTTP.ResponseWriter, _ *netHTTP.Request) {
 
 // @Tags Analysis
 // @Security ApiKeyAuth
-// @Description Start new analysis
+// @Description Start new analysis, the verdict of the company and repository policies is saved in the analysis
 // @ID start-new-analysis
 // @Accept  json
 // @Produce  json
-// @Param SendNewAnalysis body horusec.Analysis true "send new analysis info"
+// @Param SendNewAnalysis body api.AnalysisData true "send new analysis info"
 // @Success 201 {object} http.Response{content=string} "CREATED"
 // @Success 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Success 403 {object} http.Response{content=string} "FORBIDDEN"
 // @Success 404 {object} http.Response{content=string} "NOT FOUND"
 // @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
 // @Router /api/analysis [post]
@@ -69,7 +73,12 @@ func (h *Handler) Post(w netHTTP.ResponseWriter, r *netHTTP.Request) {
 		return
 	}
 
-	analysisID, err := h.analysisController.SaveAnalysis(analysisData)
+	if err := h.checkTokenConstraints(r, analysisData); err != nil {
+		httpUtil.StatusForbidden(w, err)
+		return
+	}
+
+	analysisID, err := h.analysisController.SaveAnalysis(r.Context(), analysisData)
 	if err != nil {
 		h.checkSaveAnalysisErrors(w, err)
 		return
@@ -84,6 +93,11 @@ func (h *Handler) checkSaveAnalysisErrors(w netHTTP.ResponseWriter, err error) {
 		return
 	}
 
+	if err == errors.ErrorTokenAutoCreateDisabled {
+		httpUtil.StatusForbidden(w, err)
+		return
+	}
+
 	httpUtil.StatusInternalServerError(w, err)
 }
 
@@ -101,6 +115,16 @@ func (h *Handler) getAnalysisBody(r *netHTTP.Request) (*apiEntities.AnalysisData
 	return analysisData, nil
 }
 
+func (h *Handler) checkTokenConstraints(r *netHTTP.Request, analysisData *apiEntities.AnalysisData) error {
+	token, ok := r.Context().Value(middlewares.TokenCtxKey).(*apiEntities.Token)
+	if !ok {
+		return nil
+	}
+
+	analysisData.DisableAutoCreate = token.DisableAutoCreate
+	return token.ValidateAnalysisConstraints(analysisData.RepositoryName, analysisData.Analysis.Branch)
+}
+
 func (h *Handler) getCompanyIDAndRepositoryIDInCxt(r *netHTTP.Request) (uuid.UUID, uuid.UUID, error) {
 	companyIDCtx := r.Context().Value(middlewares.CompanyIDCtxKey)
 	if companyIDCtx == nil {
@@ -143,6 +167,115 @@ func (h *Handler) Get(w netHTTP.ResponseWriter, r *netHTTP.Request) {
 	}
 }
 
+// @Tags Analysis
+// @Security ApiKeyAuth
+// @Description Compare two analyses of the same repository by the hash of the vulnerabilities
+// @ID compare-analysis
+// @Accept  json
+// @Produce  json
+// @Param baseAnalysisID query string true "analysisID of the older analysis"
+// @Param headAnalysisID query string true "analysisID of the newer analysis"
+// @Success 200 {object} http.Response{content=horusec.AnalysisComparison{new=horusec.ComparisonBucket{},fixed=horusec.ComparisonBucket{},unchanged=horusec.ComparisonBucket{}}} "OK"
+// @Success 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Success 403 {object} http.Response{content=string} "FORBIDDEN"
+// @Success 404 {object} http.Response{content=string} "NOT FOUND"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /api/analysis/compare [get]
+func (h *Handler) Compare(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	baseAnalysisID, headAnalysisID, err := h.getAnalysisIDsToCompare(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+	companyID, repositoryID, err := h.getCompanyIDAndRepositoryIDInCxt(r)
+	if err != nil {
+		httpUtil.StatusForbidden(w, err)
+		return
+	}
+
+	comparison, err := h.analysisController.CompareAnalysis(companyID, repositoryID, baseAnalysisID, headAnalysisID)
+	if err != nil {
+		h.checkCompareAnalysisErrors(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, comparison)
+}
+
+func (h *Handler) getAnalysisIDsToCompare(r *netHTTP.Request) (uuid.UUID, uuid.UUID, error) {
+	baseAnalysisID, err := uuid.Parse(r.URL.Query().Get("baseAnalysisID"))
+	if err != nil || baseAnalysisID == uuid.Nil {
+		return uuid.Nil, uuid.Nil, errors.ErrorInvalidAnalysisID
+	}
+	headAnalysisID, err := uuid.Parse(r.URL.Query().Get("headAnalysisID"))
+	if err != nil || headAnalysisID == uuid.Nil {
+		return uuid.Nil, uuid.Nil, errors.ErrorInvalidAnalysisID
+	}
+
+	return baseAnalysisID, headAnalysisID, nil
+}
+
+func (h *Handler) checkCompareAnalysisErrors(w netHTTP.ResponseWriter, err error) {
+	switch err {
+	case errors.ErrNotFoundRecords:
+		httpUtil.StatusNotFound(w, err)
+	case errors.ErrorAnalysesOfDifferentRepositories:
+		httpUtil.StatusBadRequest(w, err)
+	default:
+		httpUtil.StatusInternalServerError(w, err)
+	}
+}
+
+// @Tags Analysis
+// @Security ApiKeyAuth
+// @Description Get the analyses of the repository newest first with the totals of their vulnerabilities
+// @ID get-repository-analysis-history
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the company"
+// @Param repositoryID path string true "repositoryID of the repository"
+// @Param page query string false "page query string"
+// @Param size query string false "size query string, max 100"
+// @Param branch query string false "branch query string"
+// @Param status query string false "status query string, running, success or error"
+// @Success 200 {object} http.Response{content=horusec.AnalysisHistory{data=[]horusec.AnalysisSummary}} "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 401 {object} http.Response{content=string} "UNAUTHORIZED"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /api/companies/{companyID}/repositories/{repositoryID}/analysis [get]
+func (h *Handler) ListByRepository(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	filter, err := h.getHistoryFilter(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	history, err := h.analysisController.ListHistory(filter)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, history)
+}
+
+func (h *Handler) getHistoryFilter(r *netHTTP.Request) (*horusecEntities.HistoryFilter, error) {
+	repositoryID, err := uuid.Parse(chi.URLParam(r, "repositoryID"))
+	if err != nil {
+		return nil, errors.ErrorInvalidRepositoryID
+	}
+
+	filter := &horusecEntities.HistoryFilter{RepositoryID: repositoryID, Branch: r.URL.Query().Get("branch"),
+		Status: horusec.Status(r.URL.Query().Get("status"))}
+	filter.Page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+	filter.Size, _ = strconv.Atoi(r.URL.Query().Get("size"))
+	if err := filter.Validate(); err != nil {
+		return nil, errors.ErrorInvalidAnalysisHistoryFilter
+	}
+
+	return filter, nil
+}
+
 func (h *Handler) Put(w netHTTP.ResponseWriter, _ *netHTTP.Request) {
 	httpUtil.StatusMethodNotAllowed(w, nil)
 }
@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/account"
 	apiEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/api"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/broker"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/test"
@@ -149,6 +150,153 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestListByRepository(t *testing.T) {
+	newListRequest := func(repositoryID, query string) *http.Request {
+		r, _ := http.NewRequest(http.MethodGet, "/api/companies/1/repositories/1/analysis"+query, nil)
+		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("repositoryID", repositoryID)
+		return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+	}
+
+	t.Run("should return 400 when repository id is invalid", func(t *testing.T) {
+		handler := NewHandler(&relational.MockRead{}, &relational.MockWrite{}, nil, nil)
+		w := httptest.NewRecorder()
+
+		handler.ListByRepository(w, newListRequest("invalid", ""))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when filter is invalid", func(t *testing.T) {
+		handler := NewHandler(&relational.MockRead{}, &relational.MockWrite{}, nil, nil)
+
+		for _, query := range []string{"?size=101", "?page=-1", "?status=unknown"} {
+			w := httptest.NewRecorder()
+			handler.ListByRepository(w, newListRequest(uuid.New().String(), query))
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
+	t.Run("should return 500 when failed to list analysis", func(t *testing.T) {
+		brokenConn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(brokenConn)
+
+		handler := NewHandler(mockRead, &relational.MockWrite{}, nil, nil)
+		w := httptest.NewRecorder()
+
+		handler.ListByRepository(w, newListRequest(uuid.New().String(), ""))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("should return 200 with the analysis of the repository", func(t *testing.T) {
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		assert.NoError(t, conn.Table("analysis").AutoMigrate(&horusec.Analysis{}).Error)
+		assert.NoError(t, conn.Table("analysis_vulnerabilities").AutoMigrate(&horusec.AnalysisVulnerabilities{}).Error)
+		assert.NoError(t, conn.Table("vulnerabilities").AutoMigrate(&horusec.Vulnerability{}).Error)
+		analysis := &horusec.Analysis{ID: uuid.New(), RepositoryID: uuid.New(), Branch: "main"}
+		assert.NoError(t, conn.Table("analysis").Create(analysis.GetAnalysisWithoutAnalysisVulnerabilities()).Error)
+		mockRead := &relational.MockRead{}
+		mockRead.On("GetConnection").Return(conn)
+
+		handler := NewHandler(mockRead, &relational.MockWrite{}, nil, nil)
+		w := httptest.NewRecorder()
+
+		handler.ListByRepository(w, newListRequest(analysis.RepositoryID.String(), "?branch=main&page=1&size=10"))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), analysis.ID.String())
+	})
+}
+
+func TestCompare(t *testing.T) {
+	newCompareRequest := func(query string, companyID interface{}) *http.Request {
+		r, _ := http.NewRequest(http.MethodGet, "/api/analysis/compare"+query, nil)
+		if companyID != nil {
+			r = r.WithContext(context.WithValue(r.Context(), middlewares.CompanyIDCtxKey, companyID))
+		}
+		return r
+	}
+	validQuery := "?baseAnalysisID=85d08ec1-7786-4c2d-bf4e-5fee3a010315&headAnalysisID=" +
+		"a6d0a2a4-0e8f-4d49-b0b5-0d37f0d7d3a6"
+
+	t.Run("should return 400 when analysis ids are invalid", func(t *testing.T) {
+		handler := NewHandler(&relational.MockRead{}, &relational.MockWrite{}, nil, nil)
+		w := httptest.NewRecorder()
+
+		handler.Compare(w, newCompareRequest("?baseAnalysisID=invalid", uuid.New()))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when head analysis id is missing", func(t *testing.T) {
+		handler := NewHandler(&relational.MockRead{}, &relational.MockWrite{}, nil, nil)
+		w := httptest.NewRecorder()
+
+		handler.Compare(w, newCompareRequest("?baseAnalysisID="+uuid.New().String(), uuid.New()))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 403 when company is not in context", func(t *testing.T) {
+		handler := NewHandler(&relational.MockRead{}, &relational.MockWrite{}, nil, nil)
+		w := httptest.NewRecorder()
+
+		handler.Compare(w, newCompareRequest(validQuery, nil))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("should return 500 when failed to get analysis", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		handler := NewHandler(mockRead, &relational.MockWrite{}, nil, nil)
+		w := httptest.NewRecorder()
+
+		handler.Compare(w, newCompareRequest(validQuery, uuid.New()))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("should return 404 when analysis is from another company", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(1, nil, test.CreateAnalysisMock()))
+
+		handler := NewHandler(mockRead, &relational.MockWrite{}, nil, nil)
+		w := httptest.NewRecorder()
+
+		handler.Compare(w, newCompareRequest(validQuery, uuid.New()))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should return 200 when everything its ok", func(t *testing.T) {
+		analysis := test.CreateAnalysisMock()
+		mockRead := &relational.MockRead{}
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("Find").Return(response.NewResponse(1, nil, analysis))
+
+		handler := NewHandler(mockRead, &relational.MockWrite{}, nil, nil)
+		w := httptest.NewRecorder()
+
+		handler.Compare(w, newCompareRequest(validQuery, analysis.CompanyID))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
 func TestPut(t *testing.T) {
 	t.Run("should return 405 when not allowed", func(t *testing.T) {
 		mockRead := &relational.MockRead{}
@@ -194,6 +342,7 @@ func TestPost(t *testing.T) {
 		conn.Table("analysis").AutoMigrate(&horusec.Analysis{})
 		conn.Table("analysis_vulnerabilities").AutoMigrate(&horusec.AnalysisVulnerabilities{})
 		conn.Table("vulnerabilities").AutoMigrate(&horusec.Vulnerability{})
+		conn.Table("policies").AutoMigrate(&account.Policy{})
 		conn.LogMode(true)
 		resp := &response.Response{}
 
@@ -204,6 +353,7 @@ func TestPost(t *testing.T) {
 
 		mockRead.On("Find").Return(resp)
 		mockRead.On("SetFilter").Return(conn)
+		mockRead.On("GetConnection").Return(conn)
 
 		analysisData := apiEntities.AnalysisData{
 			Analysis:       test.CreateAnalysisMock(),
@@ -223,6 +373,47 @@ func TestPost(t *testing.T) {
 
 		assert.Equal(t, http.StatusCreated, w.Code)
 	})
+	t.Run("Should return 403 when token does not allow the branch", func(t *testing.T) {
+		analysisData := apiEntities.AnalysisData{
+			Analysis:       test.CreateAnalysisMock().SetGitInfo("feature", "a1b2c3"),
+			RepositoryName: "test",
+		}
+
+		handler := NewHandler(&relational.MockRead{}, &relational.MockWrite{}, mockBroker, config)
+		r, _ := http.NewRequest(http.MethodPost, "api/analysis", bytes.NewReader(analysisData.ToBytes()))
+		ctx := context.WithValue(r.Context(), middlewares.CompanyIDCtxKey, uuid.New())
+		ctx = context.WithValue(ctx, middlewares.TokenCtxKey, &apiEntities.Token{AllowedBranches: []string{"main"}})
+		r = r.WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		handler.Post(w, r)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+	t.Run("Should return 403 when token can not create the repository", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		respCompany := &response.Response{}
+		respRepository := &response.Response{}
+		mockRead.On("Find").Once().Return(respCompany.SetData(&account.Company{Name: "test"}))
+		mockRead.On("Find").Return(respRepository.SetError(errorsEnum.ErrNotFoundRecords))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+
+		analysisData := apiEntities.AnalysisData{
+			Analysis:       test.CreateAnalysisMock(),
+			RepositoryName: "test",
+		}
+
+		handler := NewHandler(mockRead, &relational.MockWrite{}, mockBroker, config)
+		r, _ := http.NewRequest(http.MethodPost, "api/analysis", bytes.NewReader(analysisData.ToBytes()))
+		ctx := context.WithValue(r.Context(), middlewares.CompanyIDCtxKey, uuid.New())
+		ctx = context.WithValue(ctx, middlewares.TokenCtxKey, &apiEntities.Token{DisableAutoCreate: true})
+		r = r.WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		handler.Post(w, r)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
 	t.Run("Should return 400 when body is nil", func(t *testing.T) {
 		mockRead := &relational.MockRead{}
 		mockWrite := &relational.MockWrite{}
@@ -355,6 +546,10 @@ func TestPost(t *testing.T) {
 		mockRead := &relational.MockRead{}
 		mockWrite := &relational.MockWrite{}
 
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		conn.Table("policies").AutoMigrate(&account.Policy{})
+
 		resp := &response.Response{}
 
 		mockWrite.On("StartTransaction").Return(mockWrite)
@@ -363,6 +558,7 @@ func TestPost(t *testing.T) {
 
 		mockRead.On("Find").Return(resp)
 		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("GetConnection").Return(conn)
 
 		analysisData := apiEntities.AnalysisData{
 			Analysis: &horusec.Analysis{
@@ -389,6 +585,9 @@ func TestPost(t *testing.T) {
 		mockRead := &relational.MockRead{}
 		mockWrite := &relational.MockWrite{}
 
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+
 		resp := &response.Response{}
 
 		mockWrite.On("StartTransaction").Return(mockWrite)
@@ -399,6 +598,7 @@ func TestPost(t *testing.T) {
 
 		mockRead.On("Find").Return(resp)
 		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("GetConnection").Return(conn)
 
 		analysis := &apiEntities.AnalysisData{
 			Analysis: &horusec.Analysis{
@@ -426,6 +626,9 @@ func TestPost(t *testing.T) {
 		mockRead := &relational.MockRead{}
 		mockWrite := &relational.MockWrite{}
 
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+
 		resp := &response.Response{}
 		resp1 := &response.Response{}
 
@@ -437,6 +640,7 @@ func TestPost(t *testing.T) {
 
 		mockRead.On("Find").Return(resp)
 		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("GetConnection").Return(conn)
 
 		analysis := &apiEntities.AnalysisData{
 			Analysis: &horusec.Analysis{
@@ -464,6 +668,9 @@ func TestPost(t *testing.T) {
 		mockRead := &relational.MockRead{}
 		mockWrite := &relational.MockWrite{}
 
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+
 		resp := &response.Response{}
 
 		mockWrite.On("StartTransaction").Return(mockWrite)
@@ -472,6 +679,7 @@ func TestPost(t *testing.T) {
 
 		mockRead.On("Find").Return(&response.Response{})
 		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("GetConnection").Return(conn)
 
 		analysis := &apiEntities.AnalysisData{
 			Analysis: &horusec.Analysis{
@@ -500,6 +708,9 @@ func TestPost(t *testing.T) {
 		mockRead := &relational.MockRead{}
 		mockWrite := &relational.MockWrite{}
 
+		conn, err := gorm.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+
 		resp := &response.Response{}
 
 		mockWrite.On("StartTransaction").Return(mockWrite)
@@ -510,6 +721,7 @@ func TestPost(t *testing.T) {
 
 		mockRead.On("Find").Return(resp)
 		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("GetConnection").Return(conn)
 
 		analysis := &apiEntities.AnalysisData{
 			Analysis: &horusec.Analysis{
@@ -0,0 +1,161 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	netHTTP "net/http"
+	"strconv"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
+	auditController "github.com/ZupIT/horusec/horusec-api/internal/controllers/audit"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	auditController auditController.IController
+}
+
+func NewHandler(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite) *Handler {
+	return &Handler{
+		auditController: auditController.NewAuditController(postgresRead, postgresWrite),
+	}
+}
+
+func (h *Handler) Options(w netHTTP.ResponseWriter, _ *netHTTP.Request) {
+	httpUtil.StatusNoContent(w)
+}
+
+// @Tags Audit
+// @Security ApiKeyAuth
+// @Description Get the audit events of all companies, only application admins can access it
+// @ID get-audit-events
+// @Accept  json
+// @Produce  json
+// @Param page query string false "page query string"
+// @Param size query string false "size query string, max 100"
+// @Param action query string false "action query string"
+// @Param companyID query string false "companyID query string"
+// @Param repositoryID query string false "repositoryID query string"
+// @Param accountID query string false "accountID query string"
+// @Param initialDate query string false "initialDate query string, RFC3339"
+// @Param finalDate query string false "finalDate query string, RFC3339"
+// @Success 200 {object} http.Response{content=audit.Events} "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 401 {object} http.Response{content=string} "UNAUTHORIZED"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /api/audit [get]
+func (h *Handler) List(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	filter, err := h.getFilter(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	if filter.CompanyID, err = h.getUUID(r.URL.Query().Get("companyID")); err != nil {
+		httpUtil.StatusBadRequest(w, errors.ErrorInvalidAuditFilter)
+		return
+	}
+
+	h.list(w, filter)
+}
+
+// @Tags Audit
+// @Security ApiKeyAuth
+// @Description Get the audit events of the company, only company admins can access it
+// @ID get-company-audit-events
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the company"
+// @Param page query string false "page query string"
+// @Param size query string false "size query string, max 100"
+// @Param action query string false "action query string"
+// @Param repositoryID query string false "repositoryID query string"
+// @Param accountID query string false "accountID query string"
+// @Param initialDate query string false "initialDate query string, RFC3339"
+// @Param finalDate query string false "finalDate query string, RFC3339"
+// @Success 200 {object} http.Response{content=audit.Events} "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 401 {object} http.Response{content=string} "UNAUTHORIZED"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /api/companies/{companyID}/audit [get]
+func (h *Handler) ListByCompany(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	filter, err := h.getFilter(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	if filter.CompanyID, err = uuid.Parse(chi.URLParam(r, "companyID")); err != nil {
+		httpUtil.StatusBadRequest(w, errors.ErrorInvalidCompanyID)
+		return
+	}
+
+	h.list(w, filter)
+}
+
+func (h *Handler) list(w netHTTP.ResponseWriter, filter *audit.Filter) {
+	events, err := h.auditController.List(filter)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, events)
+}
+
+func (h *Handler) getFilter(r *netHTTP.Request) (filter *audit.Filter, err error) {
+	filter = &audit.Filter{Action: auditEnums.Action(r.URL.Query().Get("action"))}
+	filter.Page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+	filter.Size, _ = strconv.Atoi(r.URL.Query().Get("size"))
+	if filter.RepositoryID, err = h.getUUID(r.URL.Query().Get("repositoryID")); err != nil {
+		return nil, errors.ErrorInvalidAuditFilter
+	}
+
+	if filter.AccountID, err = h.getUUID(r.URL.Query().Get("accountID")); err != nil {
+		return nil, errors.ErrorInvalidAuditFilter
+	}
+
+	if filter.InitialDate, err = h.getDate(r.URL.Query().Get("initialDate")); err != nil {
+		return nil, errors.ErrorInvalidAuditFilter
+	}
+
+	if filter.FinalDate, err = h.getDate(r.URL.Query().Get("finalDate")); err != nil {
+		return nil, errors.ErrorInvalidAuditFilter
+	}
+
+	return filter, filter.Validate()
+}
+
+func (h *Handler) getUUID(value string) (uuid.UUID, error) {
+	if value == "" {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(value)
+}
+
+func (h *Handler) getDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
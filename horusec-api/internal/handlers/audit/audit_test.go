@@ -0,0 +1,177 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	auditController "github.com/ZupIT/horusec/horusec-api/internal/controllers/audit"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHandler(t *testing.T) {
+	t.Run("should create a new handler", func(t *testing.T) {
+		assert.NotNil(t, NewHandler(&relational.MockRead{}, &relational.MockWrite{}))
+	})
+}
+
+func TestOptions(t *testing.T) {
+	t.Run("should return 204 when options", func(t *testing.T) {
+		handler := NewHandler(&relational.MockRead{}, &relational.MockWrite{})
+
+		r, _ := http.NewRequest(http.MethodOptions, "api/audit", nil)
+		w := httptest.NewRecorder()
+
+		handler.Options(w, r)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
+func TestList(t *testing.T) {
+	t.Run("should return 200 when list events", func(t *testing.T) {
+		controllerMock := &auditController.Mock{}
+		controllerMock.On("List").Return(&audit.Events{}, nil)
+		handler := &Handler{auditController: controllerMock}
+
+		r, _ := http.NewRequest(http.MethodGet, "api/audit?page=1&size=10&action=token.create&companyID="+
+			uuid.New().String()+"&initialDate=2021-01-01T00:00:00Z&finalDate=2021-02-01T00:00:00Z", nil)
+		w := httptest.NewRecorder()
+
+		handler.List(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 400 when invalid company id", func(t *testing.T) {
+		handler := &Handler{auditController: &auditController.Mock{}}
+
+		r, _ := http.NewRequest(http.MethodGet, "api/audit?companyID=test", nil)
+		w := httptest.NewRecorder()
+
+		handler.List(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when invalid action", func(t *testing.T) {
+		handler := &Handler{auditController: &auditController.Mock{}}
+
+		r, _ := http.NewRequest(http.MethodGet, "api/audit?action=test", nil)
+		w := httptest.NewRecorder()
+
+		handler.List(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when invalid dates", func(t *testing.T) {
+		handler := &Handler{auditController: &auditController.Mock{}}
+
+		r, _ := http.NewRequest(http.MethodGet, "api/audit?initialDate=test", nil)
+		w := httptest.NewRecorder()
+
+		handler.List(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when final date before initial date", func(t *testing.T) {
+		handler := &Handler{auditController: &auditController.Mock{}}
+
+		r, _ := http.NewRequest(http.MethodGet,
+			"api/audit?initialDate=2021-02-01T00:00:00Z&finalDate=2021-01-01T00:00:00Z", nil)
+		w := httptest.NewRecorder()
+
+		handler.List(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when invalid account id", func(t *testing.T) {
+		handler := &Handler{auditController: &auditController.Mock{}}
+
+		r, _ := http.NewRequest(http.MethodGet, "api/audit?accountID=test", nil)
+		w := httptest.NewRecorder()
+
+		handler.List(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 500 when failed to list", func(t *testing.T) {
+		controllerMock := &auditController.Mock{}
+		controllerMock.On("List").Return(&audit.Events{}, errors.New("test"))
+		handler := &Handler{auditController: controllerMock}
+
+		r, _ := http.NewRequest(http.MethodGet, "api/audit", nil)
+		w := httptest.NewRecorder()
+
+		handler.List(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestListByCompany(t *testing.T) {
+	t.Run("should return 200 when list company events", func(t *testing.T) {
+		controllerMock := &auditController.Mock{}
+		controllerMock.On("List").Return(&audit.Events{}, nil)
+		handler := &Handler{auditController: controllerMock}
+
+		r, _ := http.NewRequest(http.MethodGet, "api/companies/id/audit?repositoryID="+uuid.New().String(), nil)
+		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("companyID", uuid.New().String())
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+		w := httptest.NewRecorder()
+
+		handler.ListByCompany(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 400 when invalid company id", func(t *testing.T) {
+		handler := &Handler{auditController: &auditController.Mock{}}
+
+		r, _ := http.NewRequest(http.MethodGet, "api/companies/id/audit", nil)
+		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("companyID", "test")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+		w := httptest.NewRecorder()
+
+		handler.ListByCompany(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when invalid filter", func(t *testing.T) {
+		handler := &Handler{auditController: &auditController.Mock{}}
+
+		r, _ := http.NewRequest(http.MethodGet, "api/companies/id/audit?size=1000", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListByCompany(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
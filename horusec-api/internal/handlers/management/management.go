@@ -15,18 +15,27 @@
 package management
 
 import (
+	"fmt"
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
-	_ "github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto" // [swagger-import]
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	exportEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/export"
 	horusecEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/languages"
+	managementEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/management"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/tools"
+	authGrpc "github.com/ZupIT/horusec/development-kit/pkg/services/grpc/auth"
 	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	"github.com/ZupIT/horusec/horusec-api/internal/controllers/management"
 	managementUseCases "github.com/ZupIT/horusec/horusec-api/internal/usecases/management"
 	"github.com/go-chi/chi"
 	"github.com/google/uuid"
 	netHTTP "net/http"
 	"strconv"
+	"time"
 )
 
 type Handler struct {
@@ -57,20 +66,26 @@ func (h *Handler) Options(w netHTTP.ResponseWriter, _ *netHTTP.Request) {
 // @Param vulnHash query string false "vulnHash query string"
 // @Param vulnType query string false "vulnType query string"
 // @Param vulnSeverity query string false "vulnSeverity query string"
+// @Param securityTool query string false "securityTool query string"
+// @Param language query string false "language query string"
+// @Param filePrefix query string false "filePrefix query string"
+// @Param commitAuthor query string false "commitAuthor query string, name or email of the author"
+// @Param details query string false "details query string, free text search on details"
+// @Param firstSeenInitialDate query string false "firstSeenInitialDate query string"
+// @Param firstSeenFinalDate query string false "firstSeenFinalDate query string"
+// @Param sortBy query string false "sortBy query string, allowed severity, file and age, default severity"
 // @Success 200 {object} http.Response{content=string} "OK"
 // @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
 // @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
 // @Router /api/companies/{companyID}/repositories/{repositoryID}/management [get]
 func (h *Handler) Get(w netHTTP.ResponseWriter, r *netHTTP.Request) {
-	repositoryID, err := uuid.Parse(chi.URLParam(r, "repositoryID"))
+	filter, err := h.getVulnManagementFilter(r)
 	if err != nil {
 		httpUtil.StatusBadRequest(w, err)
 		return
 	}
 
-	page, size := h.getPageSize(r)
-	result, err := h.managementController.ListVulnManagementData(repositoryID, page, size,
-		h.getVulnSeverity(r), h.getVulnType(r), h.getVulnHash(r))
+	result, err := h.managementController.ListVulnManagementData(filter)
 	if err != nil {
 		httpUtil.StatusInternalServerError(w, err)
 		return
@@ -79,6 +94,43 @@ func (h *Handler) Get(w netHTTP.ResponseWriter, r *netHTTP.Request) {
 	httpUtil.StatusOK(w, result)
 }
 
+func (h *Handler) getVulnManagementFilter(r *netHTTP.Request) (filter *dto.VulnManagementFilter, err error) {
+	filter = &dto.VulnManagementFilter{VulnFilter: h.getVulnFilter(r), SortBy: h.getSortBy(r)}
+	if filter.RepositoryID, err = uuid.Parse(chi.URLParam(r, "repositoryID")); err != nil {
+		return nil, err
+	}
+
+	filter.Page, filter.Size = h.getPageSize(r)
+	if filter.FirstSeenInitialDate, err = h.getDate(r, "firstSeenInitialDate"); err != nil {
+		return nil, err
+	}
+
+	if filter.FirstSeenFinalDate, err = h.getDate(r, "firstSeenFinalDate"); err != nil {
+		return nil, err
+	}
+
+	return filter, filter.Validate()
+}
+
+func (h *Handler) getVulnFilter(r *netHTTP.Request) dto.VulnFilter {
+	companyID, _ := uuid.Parse(chi.URLParam(r, "companyID"))
+	return dto.VulnFilter{
+		CompanyID:    companyID,
+		Severity:     h.getVulnSeverity(r),
+		Type:         h.getVulnType(r),
+		VulnHash:     h.getVulnHash(r),
+		SecurityTool: tools.Tool(r.URL.Query().Get("securityTool")),
+		Language:     languages.Language(r.URL.Query().Get("language")),
+		FilePrefix:   r.URL.Query().Get("filePrefix"),
+		CommitAuthor: r.URL.Query().Get("commitAuthor"),
+		Details:      r.URL.Query().Get("details"),
+	}
+}
+
+func (h *Handler) getSortBy(r *netHTTP.Request) managementEnums.SortBy {
+	return managementEnums.SortBy(r.URL.Query().Get("sortBy"))
+}
+
 func (h *Handler) getPageSize(r *netHTTP.Request) (page, size int) {
 	page, _ = strconv.Atoi(r.URL.Query().Get("page"))
 	size, _ = strconv.Atoi(r.URL.Query().Get("size"))
@@ -112,6 +164,12 @@ func (h *Handler) getVulnHash(r *netHTTP.Request) string {
 // @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
 // @Router /api/companies/{companyID}/repositories/{repositoryID}/management/{vulnerabilityID}/type [put]
 func (h *Handler) UpdateVulnType(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	repositoryID, err := uuid.Parse(chi.URLParam(r, "repositoryID"))
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
 	updateData, err := h.managementUseCases.NewUpdateVulnTypeFromReadCloser(r.Body)
 	vulnerabilityID, _ := uuid.Parse(chi.URLParam(r, "vulnerabilityID"))
 	if err != nil || vulnerabilityID == uuid.Nil {
@@ -119,7 +177,8 @@ func (h *Handler) UpdateVulnType(w netHTTP.ResponseWriter, r *netHTTP.Request) {
 		return
 	}
 
-	result, err := h.managementController.UpdateVulnType(vulnerabilityID, updateData)
+	result, err := h.managementController.UpdateVulnType(repositoryID, vulnerabilityID,
+		updateData.SetAccountID(h.getAccountID(r)))
 	if err != nil {
 		h.checkUpdateErrors(w, err)
 		return
@@ -128,6 +187,87 @@ func (h *Handler) UpdateVulnType(w netHTTP.ResponseWriter, r *netHTTP.Request) {
 	httpUtil.StatusOK(w, result)
 }
 
+// @Tags Management
+// @Security ApiKeyAuth
+// @Description update type of many vulnerabilities, selected by ids or by filter, with a required justification
+// @ID bulk-update-vuln-type
+// @Accept  json
+// @Produce  json
+// @Param BulkUpdateVulnType body dto.BulkUpdateVulnType true "type, justification and selection of vulnerabilities"
+// @Param companyID path string true "companyID of the company"
+// @Param repositoryID path string true "repositoryID of the repository"
+// @Success 200 {object} http.Response{content=int} "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /api/companies/{companyID}/repositories/{repositoryID}/management/type [put]
+func (h *Handler) BulkUpdateVulnType(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	repositoryID, err := uuid.Parse(chi.URLParam(r, "repositoryID"))
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	bulkData, err := h.managementUseCases.NewBulkUpdateVulnTypeFromReadCloser(r.Body)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	count, err := h.managementController.BulkUpdateVulnType(repositoryID, bulkData.SetAccountID(h.getAccountID(r)))
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, count)
+}
+
+// @Tags Management
+// @Security ApiKeyAuth
+// @Description list the history of type changes of a vulnerability
+// @ID list-vuln-type-history
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the company"
+// @Param repositoryID path string true "repositoryID of the repository"
+// @Param vulnerabilityID path string true "vulnerabilityID of the vulnerability"
+// @Success 200 {object} http.Response{content=string} "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 404 {object} http.Response{content=string} "NOT FOUND"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /api/companies/{companyID}/repositories/{repositoryID}/management/{vulnerabilityID}/history [get]
+func (h *Handler) ListVulnTypeHistory(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	repositoryID, err := uuid.Parse(chi.URLParam(r, "repositoryID"))
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	vulnerabilityID, err := uuid.Parse(chi.URLParam(r, "vulnerabilityID"))
+	if err != nil {
+		httpUtil.StatusBadRequest(w, errors.ErrInvalidVulnerabilityID)
+		return
+	}
+
+	result, err := h.managementController.ListVulnTypeHistory(repositoryID, vulnerabilityID)
+	if err != nil {
+		h.checkUpdateErrors(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, result)
+}
+
+func (h *Handler) getAccountID(r *netHTTP.Request) uuid.UUID {
+	accountData, ok := r.Context().Value(authEnums.AccountData).(*authGrpc.GetAccountDataResponse)
+	if !ok {
+		return uuid.Nil
+	}
+
+	accountID, _ := uuid.Parse(accountData.GetAccountID())
+	return accountID
+}
+
 func (h *Handler) checkUpdateErrors(w netHTTP.ResponseWriter, err error) {
 	if err == errors.ErrNotFoundRecords {
 		httpUtil.StatusNotFound(w, errors.ErrVulnerabilityNotFound)
@@ -145,3 +285,143 @@ func (h *Handler) checkInvalidRequestErrors(w netHTTP.ResponseWriter, err error)
 
 	httpUtil.StatusBadRequest(w, err)
 }
+
+// @Tags Management
+// @Security ApiKeyAuth
+// @Description Export all vulnerabilities of repository as csv or ndjson
+// @ID export-repository-vulnerabilities
+// @Produce  text/csv
+// @Produce  application/x-ndjson
+// @Param companyID path string true "companyID of the company"
+// @Param repositoryID path string true "repositoryID of the repository"
+// @Param format query string false "format query string, allowed csv and ndjson, default csv"
+// @Param vulnType query string false "vulnType query string"
+// @Param vulnSeverity query string false "vulnSeverity query string"
+// @Param securityTool query string false "securityTool query string"
+// @Param language query string false "language query string"
+// @Param filePrefix query string false "filePrefix query string"
+// @Param commitAuthor query string false "commitAuthor query string, name or email of the author"
+// @Param details query string false "details query string, free text search on details"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /api/companies/{companyID}/repositories/{repositoryID}/management/export [get]
+func (h *Handler) ExportRepository(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	repositoryID, err := uuid.Parse(chi.URLParam(r, "repositoryID"))
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	h.export(w, r, repositoryID)
+}
+
+// @Tags Management
+// @Security ApiKeyAuth
+// @Description Export all vulnerabilities of company as csv or ndjson
+// @ID export-company-vulnerabilities
+// @Produce  text/csv
+// @Produce  application/x-ndjson
+// @Param companyID path string true "companyID of the company"
+// @Param format query string false "format query string, allowed csv and ndjson, default csv"
+// @Param vulnType query string false "vulnType query string"
+// @Param vulnSeverity query string false "vulnSeverity query string"
+// @Param securityTool query string false "securityTool query string"
+// @Param language query string false "language query string"
+// @Param filePrefix query string false "filePrefix query string"
+// @Param commitAuthor query string false "commitAuthor query string, name or email of the author"
+// @Param details query string false "details query string, free text search on details"
+// @Param initialDate query string false "initialDate query string"
+// @Param finalDate query string false "finalDate query string"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /api/companies/{companyID}/management/export [get]
+func (h *Handler) ExportCompany(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	if _, err := uuid.Parse(chi.URLParam(r, "companyID")); err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	h.export(w, r, uuid.Nil)
+}
+
+func (h *Handler) export(w netHTTP.ResponseWriter, r *netHTTP.Request, repositoryID uuid.UUID) {
+	filter, err := h.getExportFilter(r, repositoryID)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", filter.Format.GetContentType())
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=vulnerabilities.%s", filter.Format.ToString()))
+
+	exportWriter := h.managementUseCases.NewExportWriter(filter.Format, w)
+	h.finishExport(w, exportWriter, h.managementController.ExportVulnerabilities(filter, exportWriter.Write))
+}
+
+// finishExport returns 500 while nothing reached the client, after that the status is already sent so the stream is
+// aborted to the client not take a truncated file as complete
+func (h *Handler) finishExport(w netHTTP.ResponseWriter, exportWriter managementUseCases.IExportWriter, err error) {
+	if err == nil {
+		err = exportWriter.Flush()
+	}
+
+	if err != nil && !exportWriter.HasWritten() {
+		w.Header().Del("Content-Disposition")
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	if err != nil {
+		logger.LogError(errors.ErrExportVulnerabilities, err)
+		h.abortExport(w)
+	}
+}
+
+func (h *Handler) abortExport(w netHTTP.ResponseWriter) {
+	if hijacker, ok := w.(netHTTP.Hijacker); ok {
+		if conn, _, err := hijacker.Hijack(); err == nil {
+			_ = conn.Close()
+			return
+		}
+	}
+
+	panic(netHTTP.ErrAbortHandler)
+}
+
+func (h *Handler) getExportFilter(r *netHTTP.Request,
+	repositoryID uuid.UUID) (filter *dto.VulnExportFilter, err error) {
+	filter = &dto.VulnExportFilter{VulnFilter: h.getVulnFilter(r), Format: h.getExportFormat(r)}
+	filter.RepositoryID = repositoryID
+	if filter.InitialDate, err = h.getDate(r, "initialDate"); err != nil {
+		return nil, err
+	}
+
+	if filter.FinalDate, err = h.getDate(r, "finalDate"); err != nil {
+		return nil, err
+	}
+
+	return filter, filter.Validate()
+}
+
+func (h *Handler) getExportFormat(r *netHTTP.Request) exportEnums.Format {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		return exportEnums.CSV
+	}
+
+	return exportEnums.GetFormatByString(format)
+}
+
+func (h *Handler) getDate(r *netHTTP.Request, queryStrKey string) (time.Time, error) {
+	date := r.URL.Query().Get(queryStrKey)
+	if date != "" {
+		return time.Parse(time.RFC3339, date)
+	}
+
+	return time.Time{}, nil
+}
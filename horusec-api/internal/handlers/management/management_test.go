@@ -22,11 +22,15 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	exportEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/export"
 	horusecEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	authGrpc "github.com/ZupIT/horusec/development-kit/pkg/services/grpc/auth"
 	"github.com/ZupIT/horusec/horusec-api/internal/controllers/management"
 	managementUseCases "github.com/ZupIT/horusec/horusec-api/internal/usecases/management"
 	"github.com/go-chi/chi"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
@@ -107,6 +111,52 @@ func TestGet(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
+
+	t.Run("should return 200 when filter with all query parameters", func(t *testing.T) {
+		controllerMock := &management.Mock{}
+
+		controllerMock.On("ListVulnManagementData").Return(dto.VulnManagement{}, nil)
+
+		handler := Handler{managementController: controllerMock}
+		w := httptest.NewRecorder()
+
+		handler.Get(w, newExportRequest("api/management?page=1&size=10&vulnSeverity=HIGH&securityTool=GoSec"+
+			"&language=Go&filePrefix=internal/&commitAuthor=horus&details=sql&sortBy=age"+
+			"&firstSeenInitialDate=2020-07-19T00:00:00Z&firstSeenFinalDate=2020-07-21T00:00:00Z",
+			map[string]string{"repositoryID": "85d08ec1-7786-4c2d-bf4e-5fee3a010315"}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 400 when invalid sort by", func(t *testing.T) {
+		handler := Handler{managementController: &management.Mock{}}
+		w := httptest.NewRecorder()
+
+		handler.Get(w, newExportRequest("api/management?sortBy=line",
+			map[string]string{"repositoryID": "85d08ec1-7786-4c2d-bf4e-5fee3a010315"}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when invalid first seen date", func(t *testing.T) {
+		handler := Handler{managementController: &management.Mock{}}
+		w := httptest.NewRecorder()
+
+		handler.Get(w, newExportRequest("api/management?firstSeenInitialDate=invalid",
+			map[string]string{"repositoryID": "85d08ec1-7786-4c2d-bf4e-5fee3a010315"}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when invalid first seen final date", func(t *testing.T) {
+		handler := Handler{managementController: &management.Mock{}}
+		w := httptest.NewRecorder()
+
+		handler.Get(w, newExportRequest("api/management?firstSeenFinalDate=invalid",
+			map[string]string{"repositoryID": "85d08ec1-7786-4c2d-bf4e-5fee3a010315"}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 func TestUpdateType(t *testing.T) {
@@ -128,6 +178,7 @@ func TestUpdateType(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("repositoryID", "a9f2c1d4-5b6e-4f70-8a91-b2c3d4e5f601")
 		ctx.URLParams.Add("vulnerabilityID", "85d08ec1-7786-4c2d-bf4e-5fee3a010315")
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
 
@@ -154,6 +205,7 @@ func TestUpdateType(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("repositoryID", "a9f2c1d4-5b6e-4f70-8a91-b2c3d4e5f601")
 		ctx.URLParams.Add("vulnerabilityID", "85d08ec1-7786-4c2d-bf4e-5fee3a010315")
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
 
@@ -180,6 +232,7 @@ func TestUpdateType(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("repositoryID", "a9f2c1d4-5b6e-4f70-8a91-b2c3d4e5f601")
 		ctx.URLParams.Add("vulnerabilityID", "85d08ec1-7786-4c2d-bf4e-5fee3a010315")
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
 
@@ -200,6 +253,7 @@ func TestUpdateType(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("repositoryID", "a9f2c1d4-5b6e-4f70-8a91-b2c3d4e5f601")
 		ctx.URLParams.Add("vulnerabilityID", "85d08ec1-7786-4c2d-bf4e-5fee3a010315")
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
 
@@ -226,6 +280,7 @@ func TestUpdateType(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("repositoryID", "a9f2c1d4-5b6e-4f70-8a91-b2c3d4e5f601")
 		ctx.URLParams.Add("vulnerabilityID", "test")
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
 
@@ -233,4 +288,302 @@ func TestUpdateType(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
+
+	t.Run("should return 400 when invalid repository id", func(t *testing.T) {
+		controllerMock := &management.Mock{}
+
+		handler := Handler{managementController: controllerMock,
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+
+		data := &dto.UpdateVulnType{
+			Type: horusecEnum.RiskAccepted,
+		}
+
+		dataBytes, _ := json.Marshal(data)
+
+		r, _ := http.NewRequest(http.MethodPut, "api/management", bytes.NewReader(dataBytes))
+		w := httptest.NewRecorder()
+
+		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("repositoryID", "test")
+		ctx.URLParams.Add("vulnerabilityID", "85d08ec1-7786-4c2d-bf4e-5fee3a010315")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+
+		handler.UpdateVulnType(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		controllerMock.AssertNotCalled(t, "UpdateVulnType")
+	})
+}
+
+func TestBulkUpdateVulnType(t *testing.T) {
+	newBulkRequest := func(body []byte, repositoryID string) *http.Request {
+		r, _ := http.NewRequest(http.MethodPut, "api/management/type", bytes.NewReader(body))
+		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("repositoryID", repositoryID)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+		return r.WithContext(context.WithValue(r.Context(), authEnums.AccountData,
+			&authGrpc.GetAccountDataResponse{AccountID: uuid.New().String()}))
+	}
+
+	validBody, _ := json.Marshal(&dto.BulkUpdateVulnType{VulnerabilityIDs: []uuid.UUID{uuid.New()},
+		Type: horusecEnum.FalsePositive, Comment: "test"})
+
+	t.Run("should return 200 when everything its ok", func(t *testing.T) {
+		controllerMock := &management.Mock{}
+		controllerMock.On("BulkUpdateVulnType").Return(1, nil)
+
+		handler := Handler{managementController: controllerMock,
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.BulkUpdateVulnType(w, newBulkRequest(validBody, uuid.New().String()))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 500 when something went wrong", func(t *testing.T) {
+		controllerMock := &management.Mock{}
+		controllerMock.On("BulkUpdateVulnType").Return(0, errors.New("test"))
+
+		handler := Handler{managementController: controllerMock,
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.BulkUpdateVulnType(w, newBulkRequest(validBody, uuid.New().String()))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("should return 400 when missing justification", func(t *testing.T) {
+		body, _ := json.Marshal(&dto.BulkUpdateVulnType{VulnerabilityIDs: []uuid.UUID{uuid.New()},
+			Type: horusecEnum.FalsePositive})
+
+		handler := Handler{managementController: &management.Mock{},
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.BulkUpdateVulnType(w, newBulkRequest(body, uuid.New().String()))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when invalid repository id", func(t *testing.T) {
+		handler := Handler{managementController: &management.Mock{},
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.BulkUpdateVulnType(w, newBulkRequest(validBody, "test"))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestListVulnTypeHistory(t *testing.T) {
+	t.Run("should return 200 when everything its ok", func(t *testing.T) {
+		controllerMock := &management.Mock{}
+		controllerMock.On("ListVulnTypeHistory").Return(&[]horusec.VulnerabilityTypeHistory{}, nil)
+
+		handler := Handler{managementController: controllerMock}
+		w := httptest.NewRecorder()
+
+		handler.ListVulnTypeHistory(w, newExportRequest("api/management/history",
+			map[string]string{"repositoryID": uuid.New().String(), "vulnerabilityID": uuid.New().String()}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 500 when something went wrong", func(t *testing.T) {
+		controllerMock := &management.Mock{}
+		controllerMock.On("ListVulnTypeHistory").Return(&[]horusec.VulnerabilityTypeHistory{}, errors.New("test"))
+
+		handler := Handler{managementController: controllerMock}
+		w := httptest.NewRecorder()
+
+		handler.ListVulnTypeHistory(w, newExportRequest("api/management/history",
+			map[string]string{"repositoryID": uuid.New().String(), "vulnerabilityID": uuid.New().String()}))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("should return 400 when invalid vulnerability id", func(t *testing.T) {
+		handler := Handler{managementController: &management.Mock{}}
+		w := httptest.NewRecorder()
+
+		handler.ListVulnTypeHistory(w, newExportRequest("api/management/history",
+			map[string]string{"repositoryID": uuid.New().String(), "vulnerabilityID": "test"}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 404 when vulnerability is not of the repository", func(t *testing.T) {
+		controllerMock := &management.Mock{}
+		controllerMock.On("ListVulnTypeHistory").Return(&[]horusec.VulnerabilityTypeHistory{},
+			errorsEnum.ErrNotFoundRecords)
+
+		handler := Handler{managementController: controllerMock}
+		w := httptest.NewRecorder()
+
+		handler.ListVulnTypeHistory(w, newExportRequest("api/management/history",
+			map[string]string{"repositoryID": uuid.New().String(), "vulnerabilityID": uuid.New().String()}))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func newExportRequest(url string, params map[string]string) *http.Request {
+	r, _ := http.NewRequest(http.MethodGet, url, nil)
+	ctx := chi.NewRouteContext()
+	for key, value := range params {
+		ctx.URLParams.Add(key, value)
+	}
+
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+}
+
+func TestExportRepository(t *testing.T) {
+	params := map[string]string{
+		"companyID":    "6f2f2b89-3b69-4d5a-8c4d-1f2a3b4c5d6e",
+		"repositoryID": "85d08ec1-7786-4c2d-bf4e-5fee3a010315",
+	}
+
+	t.Run("should return 200 and csv header when everything its ok", func(t *testing.T) {
+		controllerMock := &management.Mock{}
+		controllerMock.On("ExportVulnerabilities").Return(nil)
+
+		handler := Handler{managementController: controllerMock,
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.ExportRepository(w, newExportRequest("api/management/export?vulnSeverity=HIGH", params))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "repositoryID,repositoryName,vulnerabilityID")
+	})
+
+	t.Run("should return 200 with ndjson content type", func(t *testing.T) {
+		controllerMock := &management.Mock{}
+		controllerMock.On("ExportVulnerabilities").Return(nil)
+
+		handler := Handler{managementController: controllerMock,
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.ExportRepository(w, newExportRequest("api/management/export?format=ndjson", params))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("should return 400 when invalid format", func(t *testing.T) {
+		handler := Handler{managementController: &management.Mock{},
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.ExportRepository(w, newExportRequest("api/management/export?format=xlsx", params))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when invalid date", func(t *testing.T) {
+		handler := Handler{managementController: &management.Mock{},
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.ExportRepository(w, newExportRequest("api/management/export?initialDate=invalid", params))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when invalid final date", func(t *testing.T) {
+		handler := Handler{managementController: &management.Mock{},
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.ExportRepository(w, newExportRequest("api/management/export?finalDate=invalid", params))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when invalid repository id", func(t *testing.T) {
+		handler := Handler{managementController: &management.Mock{},
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.ExportRepository(w, newExportRequest("api/management/export", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 500 when something went wrong before write", func(t *testing.T) {
+		controllerMock := &management.Mock{}
+		controllerMock.On("ExportVulnerabilities").Return(errors.New("test"))
+
+		handler := Handler{managementController: controllerMock,
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.ExportRepository(w, newExportRequest("api/management/export", params))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Disposition"))
+	})
+}
+
+func TestFinishExport(t *testing.T) {
+	t.Run("should abort the stream when something went wrong after write", func(t *testing.T) {
+		handler := Handler{managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+		exportWriter := handler.managementUseCases.NewExportWriter(exportEnums.NDJSON, w)
+		assert.NoError(t, exportWriter.Write(&dto.VulnExport{}))
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			handler.finishExport(w, exportWriter, errors.New("test"))
+		})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 500 when flush fails before write", func(t *testing.T) {
+		handler := Handler{managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+		exportWriter := handler.managementUseCases.NewExportWriter(exportEnums.CSV, &failWriter{})
+
+		handler.finishExport(w, exportWriter, nil)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+type failWriter struct{}
+
+func (f *failWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("test")
+}
+
+func TestExportCompany(t *testing.T) {
+	t.Run("should return 200 when everything its ok", func(t *testing.T) {
+		controllerMock := &management.Mock{}
+		controllerMock.On("ExportVulnerabilities").Return(nil)
+
+		handler := Handler{managementController: controllerMock,
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.ExportCompany(w, newExportRequest("api/management/export?initialDate=2020-07-19T00:00:00Z"+
+			"&finalDate=2020-07-21T00:00:00Z", map[string]string{"companyID": "6f2f2b89-3b69-4d5a-8c4d-1f2a3b4c5d6e"}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "vulnerabilities.csv")
+	})
+
+	t.Run("should return 400 when invalid company id", func(t *testing.T) {
+		handler := Handler{managementController: &management.Mock{},
+			managementUseCases: managementUseCases.NewManagementUseCases()}
+		w := httptest.NewRecorder()
+
+		handler.ExportCompany(w, newExportRequest("api/management/export", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 
 	_ "github.com/ZupIT/horusec/development-kit/pkg/entities/api" // [swagger-import]
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
 	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	tokensController "github.com/ZupIT/horusec/horusec-api/internal/controllers/tokens/company"
 	tokenUseCases "github.com/ZupIT/horusec/horusec-api/internal/usecases/tokens"
@@ -127,3 +128,61 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 
 	httpUtil.StatusOK(w, tokens)
 }
+
+// @Tags Tokens
+// @Security ApiKeyAuth
+// @Description Rotate a company token keeping the current value valid during the grace period
+// @ID company-rotate-token
+// @Accept  json
+// @Produce  json
+// @Param companyID path string true "companyID of the company"
+// @Param tokenID path string true "ID of the token"
+// @Param RotateToken body dto.RotateToken false "rotate info"
+// @Success 200 {object} http.Response{content=string} "OK"
+// @Success 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Success 401 {object} http.Response{content=string} "UNAUTHORIZED"
+// @Success 404 {object} http.Response{content=string} "NOT FOUND"
+// @Success 422 {object} http.Response{content=string} "UNPROCESSABLE ENTITY"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /api/companies/{companyID}/tokens/{tokenID}/rotate [post]
+func (h *Handler) Rotate(w http.ResponseWriter, r *http.Request) {
+	tokenID, companyID, err := h.getTokenIDAndCompanyID(r)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+	rotate, err := h.tokenUseCases.ValidateRotateToken(r)
+	if err != nil {
+		httpUtil.StatusUnprocessableEntity(w, err)
+		return
+	}
+
+	h.rotate(w, tokenID, companyID, rotate)
+}
+
+func (h *Handler) rotate(w http.ResponseWriter, tokenID, companyID uuid.UUID, rotate *dto.RotateToken) {
+	tokenKey, err := h.controller.RotateTokenCompany(tokenID, companyID, rotate)
+	if err != nil {
+		if err == EnumErrors.ErrNotFoundRecords {
+			httpUtil.StatusNotFound(w, err)
+			return
+		}
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, tokenKey)
+}
+
+func (h *Handler) getTokenIDAndCompanyID(r *http.Request) (tokenID, companyID uuid.UUID, err error) {
+	tokenID, err = uuid.Parse(chi.URLParam(r, "tokenID"))
+	if err != nil || tokenID == uuid.Nil {
+		return uuid.Nil, uuid.Nil, EnumErrors.ErrorInvalidTokenID
+	}
+	companyID, err = uuid.Parse(chi.URLParam(r, "companyID"))
+	if err != nil || companyID == uuid.Nil {
+		return uuid.Nil, uuid.Nil, EnumErrors.ErrorInvalidCompanyID
+	}
+
+	return tokenID, companyID, nil
+}
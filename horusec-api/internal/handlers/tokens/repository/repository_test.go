@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
@@ -441,3 +442,72 @@ func TestOptions(t *testing.T) {
 		assert.Equal(t, http.StatusNoContent, w.Code)
 	})
 }
+
+func TestRotate(t *testing.T) {
+	newRequest := func(tokenID, ownerID, body string) *http.Request {
+		r, _ := http.NewRequest(http.MethodPost, "api/tokens/rotate", strings.NewReader(body))
+		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("tokenID", tokenID)
+		ctx.URLParams.Add("repositoryID", ownerID)
+		return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+	}
+
+	t.Run("should return status 200 when successfully rotate a token", func(t *testing.T) {
+		ownerID := uuid.New()
+		token := &api.Token{TokenID: uuid.New(), RepositoryID: &ownerID, CompanyID: uuid.New()}
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(1, nil, token))
+		mockWrite.On("Update").Return(response.NewResponse(1, nil, nil))
+
+		w := httptest.NewRecorder()
+		NewHandler(mockRead, mockWrite).Rotate(w, newRequest(token.TokenID.String(), ownerID.String(), `{"gracePeriodInHours": 24}`))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return status 400 when tokenID is invalid", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		NewHandler(&relational.MockRead{}, &relational.MockWrite{}).Rotate(w, newRequest("invalid", uuid.New().String(), ""))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return status 400 when repositoryID is invalid", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		NewHandler(&relational.MockRead{}, &relational.MockWrite{}).Rotate(w, newRequest(uuid.New().String(), "invalid", ""))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return status 422 when grace period is invalid", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		NewHandler(&relational.MockRead{}, &relational.MockWrite{}).Rotate(w,
+			newRequest(uuid.New().String(), uuid.New().String(), `{"gracePeriodInHours": -1}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("should return status 404 when token does not exist", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil))
+
+		w := httptest.NewRecorder()
+		NewHandler(mockRead, &relational.MockWrite{}).Rotate(w, newRequest(uuid.New().String(), uuid.New().String(), ""))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should return status 500 when something went wrong", func(t *testing.T) {
+		mockRead := &relational.MockRead{}
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		mockRead.On("Find").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		w := httptest.NewRecorder()
+		NewHandler(mockRead, &relational.MockWrite{}).Rotate(w, newRequest(uuid.New().String(), uuid.New().String(), ""))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
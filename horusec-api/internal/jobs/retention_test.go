@@ -0,0 +1,74 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobs
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/horusec-api/internal/controllers/retention"
+	"github.com/stretchr/testify/assert"
+)
+
+type pruneCountController struct {
+	count int32
+}
+
+func (c *pruneCountController) PruneExpired() error {
+	atomic.AddInt32(&c.count, 1)
+	return nil
+}
+
+func TestRetentionJobRun(t *testing.T) {
+	t.Run("should prune expired analysis", func(t *testing.T) {
+		controllerMock := &retention.Mock{}
+		controllerMock.On("PruneExpired").Return(nil)
+
+		NewRetentionJob(controllerMock, time.Minute).Run()
+
+		controllerMock.AssertCalled(t, "PruneExpired")
+	})
+
+	t.Run("should not panic when prune fails", func(t *testing.T) {
+		controllerMock := &retention.Mock{}
+		controllerMock.On("PruneExpired").Return(errors.New("test"))
+
+		assert.NotPanics(t, func() {
+			NewRetentionJob(controllerMock, time.Minute).Run()
+		})
+	})
+}
+
+func TestRetentionJobStart(t *testing.T) {
+	t.Run("should run job periodically", func(t *testing.T) {
+		controller := &pruneCountController{}
+
+		NewRetentionJob(controller, time.Millisecond).Start()
+
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&controller.count) >= 2
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("should not start when interval is zero", func(t *testing.T) {
+		controllerMock := &retention.Mock{}
+
+		NewRetentionJob(controllerMock, 0).Start()
+
+		controllerMock.AssertNotCalled(t, "PruneExpired")
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobs
+
+import (
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/lock"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-api/internal/controllers/riskaccept"
+	"github.com/google/uuid"
+)
+
+type IJob interface {
+	Start()
+	Run()
+}
+
+const riskAcceptJobLock = "horusec-api-risk-accept-job"
+
+type RiskAcceptJob struct {
+	controller riskaccept.IController
+	repoLock   lock.IRepository
+	interval   time.Duration
+}
+
+func NewRiskAcceptJob(controller riskaccept.IController, repoLock lock.IRepository, interval time.Duration) IJob {
+	return &RiskAcceptJob{
+		controller: controller,
+		repoLock:   repoLock,
+		interval:   interval,
+	}
+}
+
+func (j *RiskAcceptJob) Start() {
+	if j.interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.Run()
+			<-ticker.C
+		}
+	}()
+}
+
+// Run is skipped when another replica holds the lock, so the warnings are not sent more than once
+func (j *RiskAcceptJob) Run() {
+	if _, err := j.repoLock.RunLocked(riskAcceptJobLock, j.run); err != nil {
+		logger.LogError(errorsEnums.ErrRunRiskAcceptJob, err)
+	}
+}
+
+func (j *RiskAcceptJob) run() {
+	if err := j.controller.RevertExpired(uuid.Nil); err != nil {
+		logger.LogError(errorsEnums.ErrRevertExpiredRiskAccept, err)
+	}
+
+	if err := j.controller.WarnExpiring(); err != nil {
+		logger.LogError(errorsEnums.ErrWarnExpiringRiskAccept, err)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobs
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/lock"
+	"github.com/ZupIT/horusec/horusec-api/internal/controllers/riskaccept"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type countController struct {
+	count     int32
+	warnCount int32
+}
+
+func (c *countController) RevertExpired(_ uuid.UUID) error {
+	atomic.AddInt32(&c.count, 1)
+	return nil
+}
+
+func (c *countController) WarnExpiring() error {
+	atomic.AddInt32(&c.warnCount, 1)
+	return nil
+}
+
+func TestRiskAcceptJobRun(t *testing.T) {
+	t.Run("should revert expired and warn expiring risk accept", func(t *testing.T) {
+		lockMock := &lock.Mock{}
+		lockMock.On("RunLocked").Return(true, nil)
+		controllerMock := &riskaccept.Mock{}
+		controllerMock.On("RevertExpired").Return(nil)
+		controllerMock.On("WarnExpiring").Return(nil)
+
+		NewRiskAcceptJob(controllerMock, lockMock, time.Minute).Run()
+
+		controllerMock.AssertCalled(t, "RevertExpired")
+		controllerMock.AssertCalled(t, "WarnExpiring")
+	})
+
+	t.Run("should warn expiring even when revert fails", func(t *testing.T) {
+		lockMock := &lock.Mock{}
+		lockMock.On("RunLocked").Return(true, nil)
+		controllerMock := &riskaccept.Mock{}
+		controllerMock.On("RevertExpired").Return(errors.New("test"))
+		controllerMock.On("WarnExpiring").Return(errors.New("test"))
+
+		NewRiskAcceptJob(controllerMock, lockMock, time.Minute).Run()
+
+		controllerMock.AssertCalled(t, "WarnExpiring")
+	})
+
+	t.Run("should not run when the lock is held by another replica", func(t *testing.T) {
+		lockMock := &lock.Mock{}
+		lockMock.On("RunLocked").Return(false, nil)
+		controllerMock := &riskaccept.Mock{}
+
+		NewRiskAcceptJob(controllerMock, lockMock, time.Minute).Run()
+
+		controllerMock.AssertNotCalled(t, "RevertExpired")
+		controllerMock.AssertNotCalled(t, "WarnExpiring")
+	})
+
+	t.Run("should not run when the lock can not be acquired", func(t *testing.T) {
+		lockMock := &lock.Mock{}
+		lockMock.On("RunLocked").Return(false, errors.New("test"))
+		controllerMock := &riskaccept.Mock{}
+
+		NewRiskAcceptJob(controllerMock, lockMock, time.Minute).Run()
+
+		controllerMock.AssertNotCalled(t, "RevertExpired")
+	})
+}
+
+func TestRiskAcceptJobStart(t *testing.T) {
+	t.Run("should run job periodically", func(t *testing.T) {
+		lockMock := &lock.Mock{}
+		lockMock.On("RunLocked").Return(true, nil)
+		controller := &countController{}
+
+		NewRiskAcceptJob(controller, lockMock, time.Millisecond).Start()
+
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&controller.count) >= 2
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("should not start when interval is zero", func(t *testing.T) {
+		controllerMock := &riskaccept.Mock{}
+
+		NewRiskAcceptJob(controllerMock, &lock.Mock{}, 0).Start()
+
+		controllerMock.AssertNotCalled(t, "RevertExpired")
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobs
+
+import (
+	"time"
+
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-api/internal/controllers/tokens/expiration"
+)
+
+type TokenExpirationJob struct {
+	controller expiration.IController
+	interval   time.Duration
+}
+
+func NewTokenExpirationJob(controller expiration.IController, interval time.Duration) IJob {
+	return &TokenExpirationJob{
+		controller: controller,
+		interval:   interval,
+	}
+}
+
+func (j *TokenExpirationJob) Start() {
+	if j.interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.Run()
+			<-ticker.C
+		}
+	}()
+}
+
+func (j *TokenExpirationJob) Run() {
+	if err := j.controller.WarnExpiring(); err != nil {
+		logger.LogError(errorsEnums.ErrWarnExpiringToken, err)
+	}
+}
@@ -17,11 +17,15 @@ package router
 
 import (
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/middlewares"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
 	serverConfig "github.com/ZupIT/horusec/development-kit/pkg/utils/http/server"
 	"github.com/ZupIT/horusec/horusec-api/config/app"
 	"github.com/ZupIT/horusec/horusec-api/internal/handlers/analysis"
+	"github.com/ZupIT/horusec/horusec-api/internal/handlers/audit"
 	"github.com/ZupIT/horusec/horusec-api/internal/handlers/health"
 	"github.com/ZupIT/horusec/horusec-api/internal/handlers/management"
 	tokensCompany "github.com/ZupIT/horusec/horusec-api/internal/handlers/tokens/company"
@@ -45,30 +49,33 @@ func NewRouter(config *serverConfig.Server) *Router {
 	}
 }
 
-func (r *Router) setMiddleware() {
-	r.EnableRealIP()
+func (r *Router) setMiddleware(config app.IAppConfig) {
+	r.EnableRealIP(config)
 	r.EnableLogger()
 	r.EnableRecover()
 	r.EnableTimeout()
 	r.EnableCompress()
 	r.EnableRequestID()
 	r.EnableCORS()
+	r.EnableTracing()
 	r.RouterMetrics()
 }
 
 func (r *Router) GetRouter(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite,
 	broker brokerLib.IBroker, config app.IAppConfig, grpcCon *grpc.ClientConn) *chi.Mux {
-	r.setMiddleware()
+	r.setMiddleware(config)
 	r.RouterHealth(postgresRead, postgresWrite, broker, config, grpcCon)
 	r.RouterAnalysis(postgresRead, postgresWrite, broker, config)
-	r.RouterTokensRepository(postgresRead, postgresWrite, grpcCon)
-	r.RouterTokensCompany(postgresRead, postgresWrite, grpcCon)
-	r.RouterManagement(postgresRead, postgresWrite, grpcCon)
+	r.RouterRepositoryAnalysis(postgresRead, postgresWrite, broker, config, grpcCon)
+	r.RouterTokensRepository(postgresRead, postgresWrite, broker, grpcCon)
+	r.RouterTokensCompany(postgresRead, postgresWrite, broker, grpcCon)
+	r.RouterManagement(postgresRead, postgresWrite, broker, grpcCon)
+	r.RouterAudit(postgresRead, postgresWrite, grpcCon)
 	return r.router
 }
 
-func (r *Router) EnableRealIP() *Router {
-	r.router.Use(middleware.RealIP)
+func (r *Router) EnableRealIP(config app.IAppConfig) *Router {
+	r.router.Use(middlewares.NewRealIPMiddleware(config.GetTrustedProxies()).RealIP)
 	return r
 }
 
@@ -102,6 +109,11 @@ func (r *Router) EnableCORS() *Router {
 	return r
 }
 
+func (r *Router) EnableTracing() *Router {
+	r.router.Use(tracing.Middleware)
+	return r
+}
+
 func (r *Router) RouterMetrics() *Router {
 	r.router.Handle("/metrics", promhttp.Handler())
 	return r
@@ -120,9 +132,10 @@ func (r *Router) RouterHealth(postgresRead relational.InterfaceRead, postgresWri
 
 func (r *Router) RouterAnalysis(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite, broker brokerLib.IBroker, config app.IAppConfig) *Router {
 	handler := analysis.NewHandler(postgresRead, postgresWrite, broker, config)
-	tokenMiddleware := middlewares.NewTokenAuthz(postgresRead)
+	tokenMiddleware := middlewares.NewTokenAuthz(postgresRead, postgresWrite)
 	r.router.Route(routes.AnalysisHandler, func(router chi.Router) {
 		router.Use(tokenMiddleware.IsAuthorized)
+		router.Get("/compare", handler.Compare)
 		router.Get("/{analysisID}", handler.Get)
 		router.Post("/", handler.Post)
 		router.Options("/", handler.Options)
@@ -131,42 +144,94 @@ func (r *Router) RouterAnalysis(postgresRead relational.InterfaceRead, postgresW
 	return r
 }
 
-func (r *Router) RouterTokensRepository(
-	postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite, grpcCon *grpc.ClientConn) *Router {
+func (r *Router) RouterRepositoryAnalysis(postgresRead relational.InterfaceRead,
+	postgresWrite relational.InterfaceWrite, broker brokerLib.IBroker, config app.IAppConfig,
+	grpcCon *grpc.ClientConn) *Router {
+	handler := analysis.NewHandler(postgresRead, postgresWrite, broker, config)
+	authzMiddleware := middlewares.NewHorusAuthzMiddleware(grpcCon)
+	r.router.Route(routes.RepositoryAnalysisHandler, func(router chi.Router) {
+		router.With(authzMiddleware.IsRepositoryMember).Get("/", handler.ListByRepository)
+		router.Options("/", handler.Options)
+	})
+
+	return r
+}
+
+func (r *Router) RouterTokensRepository(postgresRead relational.InterfaceRead,
+	postgresWrite relational.InterfaceWrite, broker brokerLib.IBroker, grpcCon *grpc.ClientConn) *Router {
 	handler := tokensRepository.NewHandler(postgresRead, postgresWrite)
-	authMiddleware := middlewares.NewHorusAuthzMiddleware(grpcCon)
+	manageTokens := middlewares.NewHorusAuthzMiddleware(grpcCon).HasPermission(authEnums.ManageRepositoryTokens)
+	auditMiddleware := middlewares.NewAuditMiddleware(postgresRead, postgresWrite, broker)
 	r.router.Route(routes.TokensRepositoryHandler, func(router chi.Router) {
-		router.With(authMiddleware.IsRepositoryAdmin).Post("/", handler.Post)
-		router.With(authMiddleware.IsRepositoryAdmin).Get("/", handler.Get)
-		router.With(authMiddleware.IsRepositoryAdmin).Delete("/{tokenID}", handler.Delete)
+		router.With(manageTokens, auditMiddleware.Audit(auditEnums.TokenCreate, "")).
+			Post("/", handler.Post)
+		router.With(manageTokens).Get("/", handler.Get)
+		router.With(manageTokens, auditMiddleware.Audit(auditEnums.TokenDelete, "tokenID")).
+			Delete("/{tokenID}", handler.Delete)
+		router.With(manageTokens, auditMiddleware.Audit(auditEnums.TokenRotate, "tokenID")).
+			Post("/{tokenID}/rotate", handler.Rotate)
 		router.Options("/", handler.Options)
 	})
 
 	return r
 }
 
-func (r *Router) RouterTokensCompany(
-	postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite, grpcCon *grpc.ClientConn) *Router {
+func (r *Router) RouterTokensCompany(postgresRead relational.InterfaceRead,
+	postgresWrite relational.InterfaceWrite, broker brokerLib.IBroker, grpcCon *grpc.ClientConn) *Router {
 	handler := tokensCompany.NewHandler(postgresRead, postgresWrite)
 	companyMiddleware := middlewares.NewHorusAuthzMiddleware(grpcCon)
+	auditMiddleware := middlewares.NewAuditMiddleware(postgresRead, postgresWrite, broker)
 	r.router.Route(routes.TokensCompanyHandler, func(router chi.Router) {
-		router.With(companyMiddleware.IsCompanyAdmin).Post("/", handler.Post)
+		router.With(companyMiddleware.IsCompanyAdmin, auditMiddleware.Audit(auditEnums.TokenCreate, "")).
+			Post("/", handler.Post)
 		router.With(companyMiddleware.IsCompanyAdmin).Get("/", handler.Get)
-		router.With(companyMiddleware.IsCompanyAdmin).Delete("/{tokenID}", handler.Delete)
+		router.With(companyMiddleware.IsCompanyAdmin, auditMiddleware.Audit(auditEnums.TokenDelete, "tokenID")).
+			Delete("/{tokenID}", handler.Delete)
+		router.With(companyMiddleware.IsCompanyAdmin, auditMiddleware.Audit(auditEnums.TokenRotate, "tokenID")).
+			Post("/{tokenID}/rotate", handler.Rotate)
 		router.Options("/", handler.Options)
 	})
 
 	return r
 }
 
-func (r *Router) RouterManagement(
-	postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite, grpcCon *grpc.ClientConn) *Router {
-	repositoryMiddleware := middlewares.NewHorusAuthzMiddleware(grpcCon)
+func (r *Router) RouterManagement(postgresRead relational.InterfaceRead,
+	postgresWrite relational.InterfaceWrite, broker brokerLib.IBroker, grpcCon *grpc.ClientConn) *Router {
+	repositoryMiddleware := middlewares.NewHorusAuthzMiddlewareWithScope(grpcCon, authEnums.ManageVulnerabilities)
+	auditMiddleware := middlewares.NewAuditMiddleware(postgresRead, postgresWrite, broker)
 	handler := management.NewHandler(postgresRead, postgresWrite)
 	r.router.Route(routes.ManagementHandler, func(router chi.Router) {
 		router.With(repositoryMiddleware.IsRepositoryMember).Get("/", handler.Get)
-		router.With(repositoryMiddleware.IsRepositorySupervisor).Put("/{vulnerabilityID}/type",
+		router.With(repositoryMiddleware.IsRepositoryMember).Get("/export", handler.ExportRepository)
+		router.With(repositoryMiddleware.HasPermission(authEnums.ManageVulnerabilityTypes),
+			auditMiddleware.Audit(auditEnums.VulnerabilityTypeUpdate, "")).Put("/type", handler.BulkUpdateVulnType)
+		router.With(repositoryMiddleware.HasPermission(authEnums.ManageVulnerabilityTypes),
+			auditMiddleware.Audit(auditEnums.VulnerabilityTypeUpdate, "vulnerabilityID")).Put("/{vulnerabilityID}/type",
 			handler.UpdateVulnType)
+		router.With(repositoryMiddleware.IsRepositoryMember).Get("/{vulnerabilityID}/history",
+			handler.ListVulnTypeHistory)
+		router.Options("/", handler.Options)
+	})
+
+	r.router.Route(routes.CompanyManagementHandler, func(router chi.Router) {
+		router.With(repositoryMiddleware.IsCompanyAdmin).Get("/export", handler.ExportCompany)
+		router.Options("/", handler.Options)
+	})
+
+	return r
+}
+
+func (r *Router) RouterAudit(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite,
+	grpcCon *grpc.ClientConn) *Router {
+	authMiddleware := middlewares.NewHorusAuthzMiddleware(grpcCon)
+	handler := audit.NewHandler(postgresRead, postgresWrite)
+	r.router.Route(routes.AuditHandler, func(router chi.Router) {
+		router.With(authMiddleware.IsStrictApplicationAdmin).Get("/", handler.List)
+		router.Options("/", handler.Options)
+	})
+
+	r.router.Route(routes.CompanyAuditHandler, func(router chi.Router) {
+		router.With(authMiddleware.IsCompanyAdmin).Get("/", handler.ListByCompany)
 		router.Options("/", handler.Options)
 	})
 
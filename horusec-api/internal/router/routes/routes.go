@@ -15,9 +15,13 @@
 package routes
 
 const (
-	AnalysisHandler         = "/api/analysis"
-	TokensRepositoryHandler = "/api/companies/{companyID}/repositories/{repositoryID}/tokens" // nolint
-	TokensCompanyHandler    = "/api/companies/{companyID}/tokens"                             // nolint
-	HealthHandler           = "/api/health"
-	ManagementHandler       = "/api/companies/{companyID}/repositories/{repositoryID}/management"
+	AnalysisHandler           = "/api/analysis"
+	RepositoryAnalysisHandler = "/api/companies/{companyID}/repositories/{repositoryID}/analysis"
+	TokensRepositoryHandler   = "/api/companies/{companyID}/repositories/{repositoryID}/tokens" // nolint
+	TokensCompanyHandler      = "/api/companies/{companyID}/tokens"                             // nolint
+	HealthHandler             = "/api/health"
+	ManagementHandler         = "/api/companies/{companyID}/repositories/{repositoryID}/management"
+	CompanyManagementHandler  = "/api/companies/{companyID}/management"
+	AuditHandler              = "/api/audit"
+	CompanyAuditHandler       = "/api/companies/{companyID}/audit"
 )
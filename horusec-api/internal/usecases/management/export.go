@@ -0,0 +1,109 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package management
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
+)
+
+// IExportWriter streams the vulnerabilities to the response, HasWritten returns true only after bytes reached the
+// response, from then on the status is already sent and an error can not be returned to the client
+type IExportWriter interface {
+	Write(vulnExport *dto.VulnExport) error
+	Flush() error
+	HasWritten() bool
+}
+
+// responseWriter marks as written only when the buffered bytes are flushed to the response
+type responseWriter struct {
+	writer  io.Writer
+	written bool
+}
+
+type csvExportWriter struct {
+	response      *responseWriter
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+type ndjsonExportWriter struct {
+	response *responseWriter
+	encoder  *json.Encoder
+}
+
+func (r *responseWriter) Write(data []byte) (int, error) {
+	n, err := r.writer.Write(data)
+	if n > 0 {
+		r.written = true
+	}
+
+	return n, err
+}
+
+func newCSVExportWriter(writer io.Writer) IExportWriter {
+	response := &responseWriter{writer: writer}
+	return &csvExportWriter{response: response, writer: csv.NewWriter(response)}
+}
+
+func (c *csvExportWriter) Write(vulnExport *dto.VulnExport) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	return c.writer.Write(vulnExport.ToCSVRecord())
+}
+
+func (c *csvExportWriter) Flush() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func (c *csvExportWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+
+	c.headerWritten = true
+	return c.writer.Write((&dto.VulnExport{}).GetCSVHeader())
+}
+
+func (c *csvExportWriter) HasWritten() bool {
+	return c.response.written
+}
+
+func newNDJSONExportWriter(writer io.Writer) IExportWriter {
+	response := &responseWriter{writer: writer}
+	return &ndjsonExportWriter{response: response, encoder: json.NewEncoder(response)}
+}
+
+func (n *ndjsonExportWriter) Write(vulnExport *dto.VulnExport) error {
+	return n.encoder.Encode(vulnExport)
+}
+
+func (n *ndjsonExportWriter) Flush() error {
+	return nil
+}
+
+func (n *ndjsonExportWriter) HasWritten() bool {
+	return n.response.written
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package management
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	exportEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/export"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func getVulnExport() *dto.VulnExport {
+	return &dto.VulnExport{
+		RepositoryID:   uuid.New(),
+		RepositoryName: "test",
+		Vulnerability: horusec.Vulnerability{
+			VulnerabilityID: uuid.New(),
+			Severity:        severity.High,
+			Details:         "details, with comma",
+		},
+	}
+}
+
+func TestNewExportWriterCSV(t *testing.T) {
+	t.Run("should write header and records as csv", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		writer := NewManagementUseCases().NewExportWriter(exportEnums.CSV, buffer)
+
+		assert.False(t, writer.HasWritten())
+		assert.NoError(t, writer.Write(getVulnExport()))
+		assert.NoError(t, writer.Write(getVulnExport()))
+		assert.False(t, writer.HasWritten())
+		assert.NoError(t, writer.Flush())
+		assert.True(t, writer.HasWritten())
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		assert.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[0], "repositoryID,repositoryName,vulnerabilityID"))
+		assert.Contains(t, lines[1], "\"details, with comma\"")
+	})
+
+	t.Run("should write only header when there is no vulnerabilities", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		writer := NewManagementUseCases().NewExportWriter(exportEnums.CSV, buffer)
+
+		assert.NoError(t, writer.Flush())
+		assert.Len(t, strings.Split(strings.TrimSpace(buffer.String()), "\n"), 1)
+	})
+
+	t.Run("should not be written when the flush to the response fails", func(t *testing.T) {
+		writer := NewManagementUseCases().NewExportWriter(exportEnums.CSV, &failWriter{})
+
+		assert.NoError(t, writer.Write(getVulnExport()))
+		assert.Error(t, writer.Flush())
+		assert.False(t, writer.HasWritten())
+	})
+}
+
+type failWriter struct{}
+
+func (f *failWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("test")
+}
+
+func TestNewExportWriterNDJSON(t *testing.T) {
+	t.Run("should write one json object per line", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		writer := NewManagementUseCases().NewExportWriter(exportEnums.NDJSON, buffer)
+
+		assert.False(t, writer.HasWritten())
+		assert.NoError(t, writer.Write(getVulnExport()))
+		assert.NoError(t, writer.Write(getVulnExport()))
+		assert.NoError(t, writer.Flush())
+		assert.True(t, writer.HasWritten())
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		assert.Len(t, lines, 2)
+
+		vulnExport := &dto.VulnExport{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), vulnExport))
+		assert.Equal(t, "test", vulnExport.RepositoryName)
+		assert.Equal(t, severity.High, vulnExport.Severity)
+	})
+}
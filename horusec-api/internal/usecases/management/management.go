@@ -17,11 +17,14 @@ package management
 import (
 	"encoding/json"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
+	exportEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/export"
 	"io"
 )
 
 type IUseCases interface {
 	NewUpdateVulnTypeFromReadCloser(body io.ReadCloser) (updateData *dto.UpdateVulnType, err error)
+	NewBulkUpdateVulnTypeFromReadCloser(body io.ReadCloser) (bulkData *dto.BulkUpdateVulnType, err error)
+	NewExportWriter(format exportEnums.Format, writer io.Writer) IExportWriter
 }
 
 type UseCases struct {
@@ -40,3 +43,22 @@ func (u *UseCases) NewUpdateVulnTypeFromReadCloser(body io.ReadCloser) (updateDa
 
 	return updateData, updateData.Validate()
 }
+
+func (u *UseCases) NewBulkUpdateVulnTypeFromReadCloser(
+	body io.ReadCloser) (bulkData *dto.BulkUpdateVulnType, err error) {
+	err = json.NewDecoder(body).Decode(&bulkData)
+	_ = body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return bulkData, bulkData.Validate()
+}
+
+func (u *UseCases) NewExportWriter(format exportEnums.Format, writer io.Writer) IExportWriter {
+	if format == exportEnums.NDJSON {
+		return newNDJSONExportWriter(writer)
+	}
+
+	return newCSVExportWriter(writer)
+}
@@ -43,3 +43,43 @@ func TestNewUpdateVulnTypeFromReadCloser(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestNewBulkUpdateVulnTypeFromReadCloser(t *testing.T) {
+	t.Run("should success parse read closer to bulk update data", func(t *testing.T) {
+		bytes, _ := json.Marshal(&dto.BulkUpdateVulnType{Filter: &dto.VulnFilter{FilePrefix: "src/"},
+			Type: horusecEnum.FalsePositive, Comment: "test"})
+		readCloser := ioutil.NopCloser(strings.NewReader(string(bytes)))
+
+		useCases := NewManagementUseCases()
+		data, err := useCases.NewBulkUpdateVulnTypeFromReadCloser(readCloser)
+		assert.NoError(t, err)
+		assert.NotNil(t, data.Filter)
+	})
+
+	t.Run("should return error when filter and vulnerabilities are empty", func(t *testing.T) {
+		bytes, _ := json.Marshal(&dto.BulkUpdateVulnType{Filter: &dto.VulnFilter{}, Type: horusecEnum.FalsePositive,
+			Comment: "test"})
+		readCloser := ioutil.NopCloser(strings.NewReader(string(bytes)))
+
+		useCases := NewManagementUseCases()
+		_, err := useCases.NewBulkUpdateVulnTypeFromReadCloser(readCloser)
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when missing comment", func(t *testing.T) {
+		bytes, _ := json.Marshal(&dto.BulkUpdateVulnType{Filter: &dto.VulnFilter{}, Type: horusecEnum.FalsePositive})
+		readCloser := ioutil.NopCloser(strings.NewReader(string(bytes)))
+
+		useCases := NewManagementUseCases()
+		_, err := useCases.NewBulkUpdateVulnTypeFromReadCloser(readCloser)
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when invalid read closer", func(t *testing.T) {
+		readCloser := ioutil.NopCloser(strings.NewReader(""))
+
+		useCases := NewManagementUseCases()
+		_, err := useCases.NewBulkUpdateVulnTypeFromReadCloser(readCloser)
+		assert.Error(t, err)
+	})
+}
@@ -23,11 +23,13 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
 )
 
 type ITokenUseCases interface {
 	ValidateTokenRepository(r *http.Request) (token *api.Token, err error)
 	ValidateTokenCompany(r *http.Request) (token *api.Token, err error)
+	ValidateRotateToken(r *http.Request) (rotate *dto.RotateToken, err error)
 }
 
 type TokenUseCases struct {
@@ -74,3 +76,14 @@ func (u *TokenUseCases) ValidateTokenCompany(r *http.Request) (token *api.Token,
 	token.RepositoryID = nil
 	return token, token.Validate(false)
 }
+
+func (u *TokenUseCases) ValidateRotateToken(r *http.Request) (rotate *dto.RotateToken, err error) {
+	rotate = &dto.RotateToken{}
+	if r.ContentLength != 0 {
+		if err = json.NewDecoder(r.Body).Decode(rotate); err != nil {
+			return nil, err
+		}
+	}
+
+	return rotate, rotate.Validate()
+}
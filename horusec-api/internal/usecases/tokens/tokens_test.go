@@ -21,8 +21,10 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api/dto"
 	"github.com/go-chi/chi"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -158,3 +160,39 @@ func TestValidateTokenCompany(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestValidateRotateToken(t *testing.T) {
+	t.Run("should return rotate data from request body", func(t *testing.T) {
+		expiresAt := time.Now().AddDate(0, 1, 0)
+		bytes, _ := json.Marshal(dto.RotateToken{GracePeriodInHours: 24, ExpiresAt: &expiresAt})
+		r, _ := http.NewRequest(http.MethodPost, "api/tokens", strings.NewReader(string(bytes)))
+
+		rotate, err := NewTokenUseCases().ValidateRotateToken(r)
+		assert.NoError(t, err)
+		assert.Equal(t, 24*time.Hour, rotate.GetGracePeriod())
+	})
+
+	t.Run("should return default rotate data when body is empty", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodPost, "api/tokens", nil)
+
+		rotate, err := NewTokenUseCases().ValidateRotateToken(r)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), rotate.GetGracePeriod())
+		assert.True(t, rotate.GetExpiresAt().IsZero())
+	})
+
+	t.Run("should return error when grace period is too long", func(t *testing.T) {
+		bytes, _ := json.Marshal(dto.RotateToken{GracePeriodInHours: api.TokenMaxGracePeriodInHours + 1})
+		r, _ := http.NewRequest(http.MethodPost, "api/tokens", strings.NewReader(string(bytes)))
+
+		_, err := NewTokenUseCases().ValidateRotateToken(r)
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when invalid body", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodPost, "api/tokens", strings.NewReader("invalid"))
+
+		_, err := NewTokenUseCases().ValidateRotateToken(r)
+		assert.Error(t, err)
+	})
+}
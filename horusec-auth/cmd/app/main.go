@@ -22,12 +22,15 @@ import (
 	"net/http"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/adapter"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	serverUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http/server"
 	adminConfig "github.com/ZupIT/horusec/horusec-auth/config/admin"
 	"github.com/ZupIT/horusec/horusec-auth/config/app"
 	"github.com/ZupIT/horusec/horusec-auth/config/cors"
 	grpcConfig "github.com/ZupIT/horusec/horusec-auth/config/grpc"
 	"github.com/ZupIT/horusec/horusec-auth/config/swagger"
+	"github.com/ZupIT/horusec/horusec-auth/internal/controller/ldapsync"
+	"github.com/ZupIT/horusec/horusec-auth/internal/jobs"
 	"github.com/ZupIT/horusec/horusec-auth/internal/router"
 )
 
@@ -56,6 +59,9 @@ func main() {
 	cacheRepository := cache.NewCacheRepository(postgresRead, postgresWrite)
 
 	adminConfig.CreateApplicationAdmin(appConfig, postgresRead, postgresWrite)
+	if appConfig.GetAuthType() == authEnums.Ldap {
+		jobs.NewLdapSyncJob(ldapsync.NewController(postgresRead, postgresWrite), appConfig.GetLdapSyncJobInterval()).Start()
+	}
 
 	server := serverUtil.NewServerConfig("8006", cors.NewCorsConfig()).Timeout(10)
 	chiRouter := router.NewRouter(server).GetRouter(postgresRead, postgresWrite, broker, cacheRepository, appConfig)
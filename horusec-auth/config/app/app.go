@@ -16,6 +16,9 @@ package app
 
 import (
 	"encoding/json"
+	"strings"
+	"time"
+
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
@@ -27,6 +30,14 @@ const (
 	EnvAuthType                  = "HORUSEC_AUTH_TYPE"
 	EnvHorusecAPIURL             = "HORUSEC_API_URL"
 	DisabledBrokerEnv            = "HORUSEC_DISABLED_BROKER"
+	EnvLdapSyncJobInterval       = "HORUSEC_LDAP_SYNC_JOB_INTERVAL_IN_MINUTES"
+	DefaultLdapSyncJobInterval   = 60
+	EnvLoginMaxAttemptsAccount   = "HORUSEC_LOGIN_MAX_ATTEMPTS_PER_ACCOUNT"
+	EnvLoginMaxAttemptsIP        = "HORUSEC_LOGIN_MAX_ATTEMPTS_PER_IP"
+	EnvLoginLockoutInMinutes     = "HORUSEC_LOGIN_LOCKOUT_IN_MINUTES"
+	EnvLoginDelayInSeconds       = "HORUSEC_LOGIN_DELAY_IN_SECONDS"
+	EnvScimToken                 = "HORUSEC_SCIM_TOKEN"
+	EnvTrustedProxies            = "HORUSEC_TRUSTED_PROXIES"
 )
 
 type Config struct {
@@ -35,6 +46,13 @@ type Config struct {
 	ApplicationAdminData   string
 	AuthType               authEnums.AuthorizationType
 	DisabledBroker         bool
+	LdapSyncJobInterval    int
+	LoginMaxAttemptsPerAcc int
+	LoginMaxAttemptsPerIP  int
+	LoginLockoutInMinutes  int
+	LoginDelayInSeconds    int
+	ScimToken              string
+	TrustedProxies         string
 }
 
 func NewConfig() *Config {
@@ -44,7 +62,14 @@ func NewConfig() *Config {
 		EnableApplicationAdmin: env.GetEnvOrDefaultBool(EnvEnableApplicationAdminEnv, false),
 		ApplicationAdminData: env.GetEnvOrDefault(EnvApplicationAdminDataEnv,
 			"{\"username\": \"horusec-admin\", \"email\":\"horusec-admin@example.com\", \"password\":\"Devpass0*\"}"),
-		DisabledBroker: env.GetEnvOrDefaultBool(DisabledBrokerEnv, false),
+		DisabledBroker:         env.GetEnvOrDefaultBool(DisabledBrokerEnv, false),
+		LdapSyncJobInterval:    env.GetEnvOrDefaultInt(EnvLdapSyncJobInterval, DefaultLdapSyncJobInterval),
+		LoginMaxAttemptsPerAcc: env.GetEnvOrDefaultInt(EnvLoginMaxAttemptsAccount, 5),
+		LoginMaxAttemptsPerIP:  env.GetEnvOrDefaultInt(EnvLoginMaxAttemptsIP, 20),
+		LoginLockoutInMinutes:  env.GetEnvOrDefaultInt(EnvLoginLockoutInMinutes, 15),
+		LoginDelayInSeconds:    env.GetEnvOrDefaultInt(EnvLoginDelayInSeconds, 1),
+		ScimToken:              env.GetEnvOrDefault(EnvScimToken, ""),
+		TrustedProxies:         env.GetEnvOrDefault(EnvTrustedProxies, ""),
 	}
 }
 
@@ -67,3 +92,37 @@ func (a *Config) GetHorusecAPIURL() string {
 func (a *Config) IsDisabledBroker() bool {
 	return a.DisabledBroker
 }
+
+func (a *Config) GetLdapSyncJobInterval() time.Duration {
+	return time.Duration(a.LdapSyncJobInterval) * time.Minute
+}
+
+func (a *Config) GetLoginMaxAttemptsPerAccount() int {
+	return a.LoginMaxAttemptsPerAcc
+}
+
+func (a *Config) GetLoginMaxAttemptsPerIP() int {
+	return a.LoginMaxAttemptsPerIP
+}
+
+func (a *Config) GetLoginLockout() time.Duration {
+	return time.Duration(a.LoginLockoutInMinutes) * time.Minute
+}
+
+func (a *Config) GetLoginDelay() time.Duration {
+	return time.Duration(a.LoginDelayInSeconds) * time.Second
+}
+
+// GetScimToken returns the bearer token of the identity provider, scim provisioning is disabled when it is empty
+func (a *Config) GetScimToken() string {
+	return a.ScimToken
+}
+
+// GetTrustedProxies returns the ips or cidrs allowed to send the client ip in forwarded headers
+func (a *Config) GetTrustedProxies() []string {
+	if strings.TrimSpace(a.TrustedProxies) == "" {
+		return []string{}
+	}
+
+	return strings.Split(a.TrustedProxies, ",")
+}
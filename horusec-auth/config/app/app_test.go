@@ -18,6 +18,7 @@ import (
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestConfig_GetEnableApplicationAdmin(t *testing.T) {
@@ -50,3 +51,39 @@ func TestConfig_GetAuthType(t *testing.T) {
 		assert.Equal(t, authEnums.Horusec, appConfig.GetAuthType())
 	})
 }
+
+func TestConfig_GetLdapSyncJobInterval(t *testing.T) {
+	t.Run("Should return ldap sync job interval default", func(t *testing.T) {
+		appConfig := NewConfig()
+		assert.Equal(t, 60*time.Minute, appConfig.GetLdapSyncJobInterval())
+	})
+}
+
+func TestConfig_GetLoginProtection(t *testing.T) {
+	t.Run("Should return login protection defaults", func(t *testing.T) {
+		appConfig := NewConfig()
+		assert.Equal(t, 5, appConfig.GetLoginMaxAttemptsPerAccount())
+		assert.Equal(t, 20, appConfig.GetLoginMaxAttemptsPerIP())
+		assert.Equal(t, 15*time.Minute, appConfig.GetLoginLockout())
+		assert.Equal(t, time.Second, appConfig.GetLoginDelay())
+	})
+}
+
+func TestConfig_GetScimToken(t *testing.T) {
+	t.Run("Should return empty scim token by default", func(t *testing.T) {
+		appConfig := NewConfig()
+		assert.Empty(t, appConfig.GetScimToken())
+	})
+}
+
+func TestConfig_GetTrustedProxies(t *testing.T) {
+	t.Run("Should return no trusted proxies by default", func(t *testing.T) {
+		appConfig := NewConfig()
+		assert.Empty(t, appConfig.GetTrustedProxies())
+	})
+
+	t.Run("Should split trusted proxies by comma", func(t *testing.T) {
+		appConfig := &Config{TrustedProxies: "10.0.0.0/8,172.16.0.1"}
+		assert.Equal(t, []string{"10.0.0.0/8", "172.16.0.1"}, appConfig.GetTrustedProxies())
+	})
+}
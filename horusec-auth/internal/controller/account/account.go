@@ -20,24 +20,29 @@ import (
 
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/crypto"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/totp"
 
 	SQL "github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	repositoryAccount "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
 	repoAccountRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_repository"
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/cache"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
 	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
 	entityCache "github.com/ZupIT/horusec/development-kit/pkg/entities/cache"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	emailEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/messages"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/queues"
+	auditService "github.com/ZupIT/horusec/development-kit/pkg/services/audit"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/jwt"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/keycloak"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
 	"github.com/ZupIT/horusec/horusec-auth/config/app"
+	personalTokenService "github.com/ZupIT/horusec/horusec-auth/internal/services/personal_token"
 	authUseCases "github.com/ZupIT/horusec/horusec-auth/internal/usecases/auth"
 	"github.com/google/uuid"
 )
@@ -57,6 +62,13 @@ type IAccount interface {
 	GetAccountIDByEmail(email string) (uuid.UUID, error)
 	GetAccountID(token string) (uuid.UUID, error)
 	UpdateAccount(account *authEntities.Account) error
+	EnrollTwoFactor(accountID uuid.UUID) (*dto.TwoFactorEnrollment, error)
+	EnableTwoFactor(accountID uuid.UUID, code string) (*dto.TwoFactorRecoveryCodes, error)
+	DisableTwoFactor(accountID uuid.UUID, code string) error
+	CreatePersonalToken(accountID uuid.UUID, token string,
+		personalToken *authEntities.PersonalToken) (*dto.PersonalTokenCreated, error)
+	ListPersonalTokens(accountID uuid.UUID) (*[]authEntities.PersonalToken, error)
+	RevokePersonalToken(personalTokenID, accountID uuid.UUID) error
 }
 
 type Account struct {
@@ -70,6 +82,8 @@ type Account struct {
 	appConfig             *app.Config
 	authUseCases          authUseCases.IUseCases
 	keycloak              keycloak.IService
+	personalTokenService  personalTokenService.IService
+	auditService          auditService.IService
 }
 
 func NewAccountController(broker brokerLib.IBroker, databaseRead SQL.InterfaceRead,
@@ -85,6 +99,8 @@ func NewAccountController(broker brokerLib.IBroker, databaseRead SQL.InterfaceRe
 		appConfig:             appConfig,
 		authUseCases:          authUseCases.NewAuthUseCases(),
 		keycloak:              keycloak.NewKeycloakService(),
+		personalTokenService:  personalTokenService.NewService(databaseRead, databaseWrite, appConfig.GetAuthType()),
+		auditService:          auditService.NewAuditService(databaseRead, databaseWrite, broker),
 	}
 }
 
@@ -243,6 +259,11 @@ func (a *Account) RenewToken(refreshToken, accessToken string) (*dto.LoginRespon
 		return nil, err
 	}
 
+	if err := account.IsAccountEnabled(); err != nil {
+		_ = a.cacheRepository.Del(accountID.String())
+		return nil, err
+	}
+
 	err = a.getAndValidateRefreshToken(refreshToken, accessToken, account.AccountID)
 	if err != nil {
 		return nil, err
@@ -342,7 +363,7 @@ func (a *Account) GetAccountID(token string) (uuid.UUID, error) {
 		return jwt.GetAccountIDByJWTToken(token)
 	case authEnums.Keycloak:
 		return a.keycloak.GetAccountIDByJWTToken(token)
-	case authEnums.Ldap:
+	case authEnums.Ldap, authEnums.OIDC:
 		return jwt.GetAccountIDByJWTToken(token)
 	}
 
@@ -387,3 +408,120 @@ func (a *Account) checkIfPasswordHashIsEqualNewPassword(passwordHash, newPasswor
 	}
 	return nil
 }
+
+// EnrollTwoFactor creates a new secret for the account, it only protects the login after confirmed by EnableTwoFactor.
+func (a *Account) EnrollTwoFactor(accountID uuid.UUID) (*dto.TwoFactorEnrollment, error) {
+	account, err := a.getHorusecAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.IsTwoFactorEnabled {
+		return nil, errors.ErrorTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorEnrollment{Secret: secret, ProvisioningURI: totp.GetProvisioningURI(account.Email, secret)},
+		a.accountRepository.UpdateTwoFactor(account.SetTwoFactorSecret(secret))
+}
+
+func (a *Account) EnableTwoFactor(accountID uuid.UUID, code string) (*dto.TwoFactorRecoveryCodes, error) {
+	account, err := a.getHorusecAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.checkTwoFactorEnrollment(account, code); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := totp.NewRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorRecoveryCodes{RecoveryCodes: recoveryCodes},
+		a.accountRepository.UpdateTwoFactor(account.EnableTwoFactor(recoveryCodes))
+}
+
+func (a *Account) checkTwoFactorEnrollment(account *authEntities.Account, code string) error {
+	if account.IsTwoFactorEnabled {
+		return errors.ErrorTwoFactorAlreadyEnabled
+	}
+
+	if account.TwoFactorSecret == "" {
+		return errors.ErrorTwoFactorNotEnrolled
+	}
+
+	if !account.UseTotpCode(code) {
+		return errors.ErrorInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+func (a *Account) DisableTwoFactor(accountID uuid.UUID, code string) error {
+	account, err := a.getHorusecAccount(accountID)
+	if err != nil {
+		return err
+	}
+
+	if !account.IsTwoFactorEnabled {
+		return errors.ErrorTwoFactorNotEnabled
+	}
+
+	if !account.UseTotpCode(code) && !account.UseRecoveryCode(code) {
+		return errors.ErrorInvalidTwoFactorCode
+	}
+
+	return a.accountRepository.UpdateTwoFactor(account.ResetTwoFactor())
+}
+
+func (a *Account) getHorusecAccount(accountID uuid.UUID) (*authEntities.Account, error) {
+	if a.appConfig.GetAuthType() != authEnums.Horusec {
+		return nil, errors.ErrorInvalidAuthType
+	}
+
+	return a.accountRepository.GetByAccountID(accountID)
+}
+
+// CreatePersonalToken keeps the groups of the creator token for ldap and oidc, where the roles come from the groups
+func (a *Account) CreatePersonalToken(accountID uuid.UUID, token string,
+	personalToken *authEntities.PersonalToken) (*dto.PersonalTokenCreated, error) {
+	var permissions []string
+	if authType := a.appConfig.GetAuthType(); authType == authEnums.Ldap || authType == authEnums.OIDC {
+		claims, err := jwt.DecodeToken(token)
+		if err != nil {
+			return nil, errors.ErrorUnauthorized
+		}
+
+		permissions = claims.Permissions
+	}
+
+	created, err := a.personalTokenService.Create(accountID, permissions, personalToken)
+	if err != nil {
+		return nil, err
+	}
+
+	a.auditService.Publish(audit.NewEvent(auditEnums.PersonalTokenCreate, accountID).
+		SetResourceID(created.PersonalTokenID.String()))
+	return created, nil
+}
+
+func (a *Account) ListPersonalTokens(accountID uuid.UUID) (*[]authEntities.PersonalToken, error) {
+	return a.personalTokenService.List(accountID)
+}
+
+func (a *Account) RevokePersonalToken(personalTokenID, accountID uuid.UUID) error {
+	if err := a.personalTokenService.Revoke(personalTokenID, accountID); err != nil {
+		return err
+	}
+
+	a.auditService.Publish(audit.NewEvent(auditEnums.PersonalTokenRevoke, accountID).
+		SetResourceID(personalTokenID.String()))
+	return nil
+}
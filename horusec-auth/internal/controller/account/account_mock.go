@@ -102,3 +102,34 @@ func (m *Mock) UpdateAccount(account *authEntities.Account) error {
 	args := m.MethodCalled("UpdateAccount", account)
 	return mockUtils.ReturnNilOrError(args, 0)
 }
+
+func (m *Mock) EnrollTwoFactor(_ uuid.UUID) (*dto.TwoFactorEnrollment, error) {
+	args := m.MethodCalled("EnrollTwoFactor")
+	return args.Get(0).(*dto.TwoFactorEnrollment), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) EnableTwoFactor(_ uuid.UUID, _ string) (*dto.TwoFactorRecoveryCodes, error) {
+	args := m.MethodCalled("EnableTwoFactor")
+	return args.Get(0).(*dto.TwoFactorRecoveryCodes), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) DisableTwoFactor(_ uuid.UUID, _ string) error {
+	args := m.MethodCalled("DisableTwoFactor")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) CreatePersonalToken(_ uuid.UUID, _ string,
+	_ *authEntities.PersonalToken) (*dto.PersonalTokenCreated, error) {
+	args := m.MethodCalled("CreatePersonalToken")
+	return args.Get(0).(*dto.PersonalTokenCreated), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) ListPersonalTokens(_ uuid.UUID) (*[]authEntities.PersonalToken, error) {
+	args := m.MethodCalled("ListPersonalTokens")
+	return args.Get(0).(*[]authEntities.PersonalToken), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) RevokePersonalToken(_, _ uuid.UUID) error {
+	args := m.MethodCalled("RevokePersonalToken")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
@@ -29,12 +29,15 @@ import (
 	entityCache "github.com/ZupIT/horusec/development-kit/pkg/entities/cache"
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	auditService "github.com/ZupIT/horusec/development-kit/pkg/services/audit"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/broker"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/jwt"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/keycloak"
 	keycloakService "github.com/ZupIT/horusec/development-kit/pkg/services/keycloak"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/totp"
 	"github.com/ZupIT/horusec/horusec-auth/config/app"
+	personalTokenService "github.com/ZupIT/horusec/horusec-auth/internal/services/personal_token"
 	authUseCases "github.com/ZupIT/horusec/horusec-auth/internal/usecases/auth"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
@@ -56,6 +59,12 @@ func TestMock(t *testing.T) {
 	controllerMock.On("VerifyAlreadyInUse").Return(nil)
 	controllerMock.On("DeleteAccount").Return(nil)
 	controllerMock.On("GetAccountIDByEmail").Return(uuid.New(), nil)
+	controllerMock.On("EnrollTwoFactor").Return(&dto.TwoFactorEnrollment{}, nil)
+	controllerMock.On("EnableTwoFactor").Return(&dto.TwoFactorRecoveryCodes{}, nil)
+	controllerMock.On("DisableTwoFactor").Return(nil)
+	controllerMock.On("CreatePersonalToken").Return(&dto.PersonalTokenCreated{}, nil)
+	controllerMock.On("ListPersonalTokens").Return(&[]authEntities.PersonalToken{}, nil)
+	controllerMock.On("RevokePersonalToken").Return(nil)
 
 	_ = controllerMock.CreateAccount(&authEntities.Account{})
 	_, _ = controllerMock.Login(&dto.LoginData{})
@@ -69,6 +78,12 @@ func TestMock(t *testing.T) {
 	_ = controllerMock.VerifyAlreadyInUse(&dto.ValidateUnique{})
 	_ = controllerMock.DeleteAccount(uuid.New())
 	_, _ = controllerMock.GetAccountIDByEmail(uuid.New().String())
+	_, _ = controllerMock.EnrollTwoFactor(uuid.New())
+	_, _ = controllerMock.EnableTwoFactor(uuid.New(), "")
+	_ = controllerMock.DisableTwoFactor(uuid.New(), "")
+	_, _ = controllerMock.CreatePersonalToken(uuid.New(), "", &authEntities.PersonalToken{})
+	_, _ = controllerMock.ListPersonalTokens(uuid.New())
+	_ = controllerMock.RevokePersonalToken(uuid.New(), uuid.New())
 }
 func TestNewAccountController(t *testing.T) {
 	t.Run("should create a new controller", func(t *testing.T) {
@@ -508,6 +523,28 @@ func TestRenewToken(t *testing.T) {
 		assert.NotEmpty(t, renewResponse)
 	})
 
+	t.Run("should return error and drop refresh token when account is disabled", func(t *testing.T) {
+		brokerMock := &broker.Mock{}
+		mockRead := &relational.MockRead{}
+		mockWrite := &relational.MockWrite{}
+		cacheRepositoryMock := &cache.Mock{}
+
+		resp := &response.Response{}
+		mockRead.On("Find").Return(resp.SetData(&authEntities.Account{IsDisabled: true}))
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+		cacheRepositoryMock.On("Get").Return(&entityCache.Cache{Value: []byte("test")}, nil)
+		cacheRepositoryMock.On("Del").Return(nil)
+		cacheRepositoryMock.On("Set").Return(nil)
+
+		controller := NewAccountController(brokerMock, mockRead, mockWrite, cacheRepositoryMock, app.NewConfig())
+
+		renewResponse, err := controller.RenewToken("test", token)
+		assert.Equal(t, errorsEnum.ErrorAccountDisabled, err)
+		assert.Nil(t, renewResponse)
+		cacheRepositoryMock.AssertCalled(t, "Del")
+		cacheRepositoryMock.AssertNotCalled(t, "Set")
+	})
+
 	t.Run("should return error while refreshing token", func(t *testing.T) {
 		brokerMock := &broker.Mock{}
 		mockRead := &relational.MockRead{}
@@ -1106,3 +1143,144 @@ func TestUpdateAccount(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestTwoFactor(t *testing.T) {
+	secret, _ := totp.NewSecret()
+	newController := func(account *authEntities.Account, authType authEnums.AuthorizationType) *Account {
+		accountMock := &repositoryAccount.Mock{}
+		accountMock.On("GetByAccountID").Return(account, nil)
+		accountMock.On("UpdateTwoFactor").Return(nil)
+
+		return &Account{accountRepository: accountMock, appConfig: &app.Config{AuthType: authType}}
+	}
+
+	t.Run("should enroll returning the secret and provisioning uri", func(t *testing.T) {
+		account := &authEntities.Account{Email: "test@test.com"}
+
+		enrollment, err := newController(account, authEnums.Horusec).EnrollTwoFactor(uuid.New())
+
+		assert.NoError(t, err)
+		assert.Equal(t, account.TwoFactorSecret, enrollment.Secret)
+		assert.Contains(t, enrollment.ProvisioningURI, "otpauth://totp/Horusec:test@test.com")
+		assert.False(t, account.IsTwoFactorEnabled)
+	})
+
+	t.Run("should return error when enroll already enabled account", func(t *testing.T) {
+		account := &authEntities.Account{IsTwoFactorEnabled: true}
+
+		_, err := newController(account, authEnums.Horusec).EnrollTwoFactor(uuid.New())
+
+		assert.Equal(t, errorsEnum.ErrorTwoFactorAlreadyEnabled, err)
+	})
+
+	t.Run("should return error when auth type is not horusec", func(t *testing.T) {
+		_, err := newController(&authEntities.Account{}, authEnums.Ldap).EnrollTwoFactor(uuid.New())
+
+		assert.Equal(t, errorsEnum.ErrorInvalidAuthType, err)
+	})
+
+	t.Run("should enable with valid code returning recovery codes", func(t *testing.T) {
+		account := (&authEntities.Account{}).SetTwoFactorSecret(secret)
+		code, _ := totp.GenerateCode(secret, time.Now())
+
+		recoveryCodes, err := newController(account, authEnums.Horusec).EnableTwoFactor(uuid.New(), code)
+
+		assert.NoError(t, err)
+		assert.Len(t, recoveryCodes.RecoveryCodes, totp.RecoveryCodesCount)
+		assert.True(t, account.IsTwoFactorEnabled)
+		assert.True(t, account.UseRecoveryCode(recoveryCodes.RecoveryCodes[0]))
+	})
+
+	t.Run("should return error when enable without enrollment or with invalid code", func(t *testing.T) {
+		_, err := newController(&authEntities.Account{}, authEnums.Horusec).EnableTwoFactor(uuid.New(), "123456")
+		assert.Equal(t, errorsEnum.ErrorTwoFactorNotEnrolled, err)
+
+		account := (&authEntities.Account{}).SetTwoFactorSecret(secret)
+		_, err = newController(account, authEnums.Horusec).EnableTwoFactor(uuid.New(), "000000a")
+		assert.Equal(t, errorsEnum.ErrorInvalidTwoFactorCode, err)
+
+		account = (&authEntities.Account{}).SetTwoFactorSecret(secret).EnableTwoFactor(nil)
+		_, err = newController(account, authEnums.Horusec).EnableTwoFactor(uuid.New(), "123456")
+		assert.Equal(t, errorsEnum.ErrorTwoFactorAlreadyEnabled, err)
+	})
+
+	t.Run("should disable with recovery code", func(t *testing.T) {
+		account := (&authEntities.Account{}).SetTwoFactorSecret(secret).EnableTwoFactor([]string{"aaaaa-bbbbb"})
+
+		err := newController(account, authEnums.Horusec).DisableTwoFactor(uuid.New(), "aaaaa-bbbbb")
+
+		assert.NoError(t, err)
+		assert.False(t, account.IsTwoFactorEnabled)
+		assert.Empty(t, account.TwoFactorSecret)
+	})
+
+	t.Run("should return error when disable with invalid code or not enabled", func(t *testing.T) {
+		account := (&authEntities.Account{}).SetTwoFactorSecret(secret).EnableTwoFactor([]string{"aaaaa-bbbbb"})
+		err := newController(account, authEnums.Horusec).DisableTwoFactor(uuid.New(), "ccccc-ddddd")
+		assert.Equal(t, errorsEnum.ErrorInvalidTwoFactorCode, err)
+
+		err = newController(&authEntities.Account{}, authEnums.Horusec).DisableTwoFactor(uuid.New(), "123456")
+		assert.Equal(t, errorsEnum.ErrorTwoFactorNotEnabled, err)
+	})
+}
+
+func newAuditMock() *auditService.Mock {
+	auditMock := &auditService.Mock{}
+	auditMock.On("Publish")
+	return auditMock
+}
+
+func TestPersonalTokens(t *testing.T) {
+	t.Run("should create personal token with the groups of the creator when ldap", func(t *testing.T) {
+		account := &authEntities.Account{AccountID: uuid.New(), Email: "test@test.com", Username: "test"}
+		token, _, _ := jwt.CreateToken(account, []string{"group"})
+		serviceMock := &personalTokenService.Mock{}
+		serviceMock.On("Create").Return(&dto.PersonalTokenCreated{Token: "hpat_test"}, nil)
+		controller := &Account{personalTokenService: serviceMock, auditService: newAuditMock(),
+			appConfig: &app.Config{AuthType: authEnums.Ldap}}
+
+		result, err := controller.CreatePersonalToken(account.AccountID, token, &authEntities.PersonalToken{})
+		assert.NoError(t, err)
+		assert.Equal(t, "hpat_test", result.Token)
+	})
+
+	t.Run("should return unauthorized when invalid token of ldap creator", func(t *testing.T) {
+		controller := &Account{personalTokenService: &personalTokenService.Mock{},
+			appConfig: &app.Config{AuthType: authEnums.OIDC}}
+
+		_, err := controller.CreatePersonalToken(uuid.New(), "invalid", &authEntities.PersonalToken{})
+		assert.Equal(t, errorsEnum.ErrorUnauthorized, err)
+	})
+
+	t.Run("should create personal token without groups when horusec", func(t *testing.T) {
+		serviceMock := &personalTokenService.Mock{}
+		serviceMock.On("Create").Return(&dto.PersonalTokenCreated{}, nil)
+		controller := &Account{personalTokenService: serviceMock, auditService: newAuditMock(),
+			appConfig: &app.Config{AuthType: authEnums.Horusec}}
+
+		_, err := controller.CreatePersonalToken(uuid.New(), "", &authEntities.PersonalToken{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should list and revoke personal tokens", func(t *testing.T) {
+		serviceMock := &personalTokenService.Mock{}
+		serviceMock.On("List").Return(&[]authEntities.PersonalToken{{}}, nil)
+		serviceMock.On("Revoke").Return(errorsEnum.ErrNotFoundRecords)
+		controller := &Account{personalTokenService: serviceMock}
+
+		result, err := controller.ListPersonalTokens(uuid.New())
+		assert.NoError(t, err)
+		assert.Len(t, *result, 1)
+		assert.Equal(t, errorsEnum.ErrNotFoundRecords, controller.RevokePersonalToken(uuid.New(), uuid.New()))
+	})
+
+	t.Run("should audit the revoked personal token", func(t *testing.T) {
+		serviceMock := &personalTokenService.Mock{}
+		serviceMock.On("Revoke").Return(nil)
+		auditMock := newAuditMock()
+		controller := &Account{personalTokenService: serviceMock, auditService: auditMock}
+
+		assert.NoError(t, controller.RevokePersonalToken(uuid.New(), uuid.New()))
+		auditMock.AssertCalled(t, "Publish")
+	})
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	accountDTO "github.com/ZupIT/horusec/development-kit/pkg/entities/account/dto"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
@@ -29,9 +30,12 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	"github.com/ZupIT/horusec/horusec-auth/config/app"
 	"github.com/ZupIT/horusec/horusec-auth/internal/services"
+	customRoleService "github.com/ZupIT/horusec/horusec-auth/internal/services/custom_role"
 	horusecService "github.com/ZupIT/horusec/horusec-auth/internal/services/horusec"
 	keycloakService "github.com/ZupIT/horusec/horusec-auth/internal/services/keycloak"
 	"github.com/ZupIT/horusec/horusec-auth/internal/services/ldap"
+	oidcService "github.com/ZupIT/horusec/horusec-auth/internal/services/oidc"
+	personalTokenService "github.com/ZupIT/horusec/horusec-auth/internal/services/personal_token"
 	"github.com/google/uuid"
 )
 
@@ -40,6 +44,7 @@ type IController interface {
 	IsAuthorized(_ context.Context, data *authGrpc.IsAuthorizedData) (*authGrpc.IsAuthorizedResponse, error)
 	GetAuthConfig(_ context.Context, data *authGrpc.GetAuthConfigData) (*authGrpc.GetAuthConfigResponse, error)
 	GetAccountID(_ context.Context, data *authGrpc.GetAccountData) (*authGrpc.GetAccountDataResponse, error)
+	GetOIDCAuthorizationURL() (*dto.OIDCAuthorization, error)
 }
 
 type Controller struct {
@@ -47,6 +52,9 @@ type Controller struct {
 	horusAuthService    services.IAuthService
 	keycloakAuthService services.IAuthService
 	ldapAuthService     services.IAuthService
+	oidcAuthService     oidcService.IService
+	personalToken       personalTokenService.IService
+	customRole          customRoleService.IService
 	keycloak            keycloak.IService
 	appConfig           *app.Config
 }
@@ -58,6 +66,9 @@ func NewAuthController(
 		horusAuthService:    horusecService.NewHorusAuthService(postgresRead, postgresWrite),
 		ldapAuthService:     ldap.NewService(postgresRead, postgresWrite),
 		keycloakAuthService: keycloakService.NewKeycloakAuthService(postgresRead),
+		oidcAuthService:     oidcService.NewService(postgresRead, postgresWrite),
+		personalToken:       personalTokenService.NewService(postgresRead, postgresWrite, appConfig.GetAuthType()),
+		customRole:          customRoleService.NewService(postgresRead, postgresWrite),
 		keycloak:            keycloak.NewKeycloakService(),
 	}
 }
@@ -70,6 +81,8 @@ func (c *Controller) AuthByType(credentials *dto.Credentials) (interface{}, erro
 		return c.keycloakAuthService.Authenticate(credentials)
 	case authEnums.Ldap:
 		return c.ldapAuthService.Authenticate(credentials)
+	case authEnums.OIDC:
+		return c.oidcAuthService.Authenticate(credentials)
 	}
 
 	return nil, errors.ErrorUnauthorized
@@ -78,16 +91,96 @@ func (c *Controller) AuthByType(credentials *dto.Credentials) (interface{}, erro
 func (c *Controller) IsAuthorized(_ context.Context,
 	data *authGrpc.IsAuthorizedData) (*authGrpc.IsAuthorizedResponse, error) {
 	c.logGrpcRequest("IsAuthorized")
+	authorizationData, err := c.getAuthorizationData(data)
+	if err != nil {
+		return c.setIsAuthorizedResponse(false, err)
+	}
+
+	authService := c.getIsAuthorizedService(data.Token)
+	if authService == nil {
+		return c.setIsAuthorizedResponse(false, errors.ErrorUnauthorized)
+	}
+
+	if authorizationData.Permission != "" {
+		return c.setIsAuthorizedResponse(c.isAuthorizedByPermission(authService, authorizationData, data.Token))
+	}
+
+	return c.setIsAuthorizedResponse(authService.IsAuthorized(authorizationData))
+}
+
+// getAuthorizationData exchanges personal tokens by the account token generated from them
+func (c *Controller) getAuthorizationData(data *authGrpc.IsAuthorizedData) (*dto.AuthorizationData, error) {
+	authorizationData := c.parseToAuthorizationData(data)
+	if !authEntities.IsPersonalToken(data.Token) {
+		return authorizationData, nil
+	}
+
+	_, accountToken, err := c.personalToken.Exchange(data.Token, authEnums.Scope(data.Scope))
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationData.Token = accountToken
+	return authorizationData, nil
+}
+
+// getIsAuthorizedService returns the service to check the roles, keycloak accounts using personal tokens are checked
+// by the horusec service because the generated token is not issued by keycloak
+func (c *Controller) getIsAuthorizedService(token string) services.IAuthService {
 	switch c.getAuthorizationType() {
 	case authEnums.Horusec:
-		return c.setIsAuthorizedResponse(c.horusAuthService.IsAuthorized(c.parseToAuthorizationData(data)))
+		return c.horusAuthService
 	case authEnums.Keycloak:
-		return c.setIsAuthorizedResponse(c.keycloakAuthService.IsAuthorized(c.parseToAuthorizationData(data)))
+		if authEntities.IsPersonalToken(token) {
+			return c.horusAuthService
+		}
+
+		return c.keycloakAuthService
 	case authEnums.Ldap:
-		return c.setIsAuthorizedResponse(c.ldapAuthService.IsAuthorized(c.parseToAuthorizationData(data)))
+		return c.ldapAuthService
+	case authEnums.OIDC:
+		return c.oidcAuthService
 	}
 
-	return c.setIsAuthorizedResponse(false, errors.ErrorUnauthorized)
+	return nil
+}
+
+// isAuthorizedByPermission checks the built-in roles containing the permission and then the custom role of the
+// repository member, custom roles are only available when horusec manages the accounts roles
+func (c *Controller) isAuthorizedByPermission(authService services.IAuthService,
+	authorizationData *dto.AuthorizationData, token string) (bool, error) {
+	for _, role := range authorizationData.Permission.Roles() {
+		authorizationData.Role = role
+		if isAuthorized, _ := authService.IsAuthorized(authorizationData); isAuthorized {
+			return true, nil
+		}
+	}
+
+	authType := c.getAuthorizationType()
+	if authType != authEnums.Horusec && authType != authEnums.Keycloak {
+		return false, errors.ErrorUnauthorized
+	}
+
+	authorizationData.Role = authEnums.RepositoryMember
+	if isAuthorized, err := authService.IsAuthorized(authorizationData); !isAuthorized {
+		return false, err
+	}
+
+	return c.isAuthorizedByCustomRole(authorizationData, token)
+}
+
+func (c *Controller) isAuthorizedByCustomRole(authorizationData *dto.AuthorizationData, token string) (bool, error) {
+	getAccountID := jwt.GetAccountIDByJWTToken
+	if c.getAuthorizationType() == authEnums.Keycloak && !authEntities.IsPersonalToken(token) {
+		getAccountID = c.keycloak.GetAccountIDByJWTToken
+	}
+
+	accountID, err := getAccountID(authorizationData.Token)
+	if err != nil {
+		return false, err
+	}
+
+	return c.customRole.HasPermission(accountID, authorizationData.RepositoryID, authorizationData.Permission)
 }
 
 func (c *Controller) parseToAuthorizationData(data *authGrpc.IsAuthorizedData) *dto.AuthorizationData {
@@ -99,6 +192,7 @@ func (c *Controller) parseToAuthorizationData(data *authGrpc.IsAuthorizedData) *
 		Role:         authEnums.HorusecRoles(data.Role),
 		CompanyID:    companyID,
 		RepositoryID: repositoryID,
+		Permission:   authEnums.Permission(data.Permission),
 	}
 }
 
@@ -136,18 +230,36 @@ func (c *Controller) getAuthorizationType() authEnums.AuthorizationType {
 func (c *Controller) GetAccountID(_ context.Context,
 	data *authGrpc.GetAccountData) (*authGrpc.GetAccountDataResponse, error) {
 	c.logGrpcRequest("GetAccountID")
+	if authEntities.IsPersonalToken(data.Token) {
+		return c.getAccountIDByPersonalToken(data)
+	}
+
 	switch c.getAuthorizationType() {
 	case authEnums.Horusec:
 		return c.setGetAccountIDResponse(jwt.GetAccountIDByJWTToken(data.Token))
 	case authEnums.Keycloak:
 		return c.setGetAccountIDResponse(c.keycloak.GetAccountIDByJWTToken(data.Token))
-	case authEnums.Ldap:
+	case authEnums.Ldap, authEnums.OIDC:
 		return c.setGetAccountIDResponseLdap(jwt.DecodeToken(data.Token))
 	}
 
 	return c.setGetAccountIDResponse(uuid.Nil, errors.ErrorUnauthorized)
 }
 
+func (c *Controller) getAccountIDByPersonalToken(
+	data *authGrpc.GetAccountData) (*authGrpc.GetAccountDataResponse, error) {
+	personalToken, _, err := c.personalToken.Exchange(data.Token, authEnums.Scope(data.Scope))
+	if err != nil {
+		logger.LogError(errors.ErrorFailedToGetAccountID, err)
+		return &authGrpc.GetAccountDataResponse{}, err
+	}
+
+	return &authGrpc.GetAccountDataResponse{
+		AccountID:   personalToken.AccountID.String(),
+		Permissions: personalToken.Permissions,
+	}, nil
+}
+
 func (c *Controller) setGetAccountIDResponse(accountID uuid.UUID, err error) (*authGrpc.GetAccountDataResponse, error) {
 	if err != nil {
 		logger.LogError(errors.ErrorFailedToGetAccountID, err)
@@ -172,6 +284,14 @@ func (c *Controller) setGetAccountIDResponseLdap(claimsJTW *accountDTO.ClaimsJWT
 	}, nil
 }
 
+func (c *Controller) GetOIDCAuthorizationURL() (*dto.OIDCAuthorization, error) {
+	if c.getAuthorizationType() != authEnums.OIDC {
+		return nil, errors.ErrorInvalidAuthType
+	}
+
+	return c.oidcAuthService.GetAuthorizationURL()
+}
+
 func (c *Controller) logGrpcRequest(method string) {
 	logger.LogInfo(fmt.Sprintf("{AUTH_GRPC} Received request for: %s", method))
 }
@@ -46,3 +46,8 @@ func (m *MockAuthController) GetAccountID(_ context.Context, _ *authGrpc.GetAcco
 	args := m.MethodCalled("GetAccountID")
 	return args.Get(0).(*authGrpc.GetAccountDataResponse), mockUtils.ReturnNilOrError(args, 1)
 }
+
+func (m *MockAuthController) GetOIDCAuthorizationURL() (*dto.OIDCAuthorization, error) {
+	args := m.MethodCalled("GetOIDCAuthorizationURL")
+	return args.Get(0).(*dto.OIDCAuthorization), mockUtils.ReturnNilOrError(args, 1)
+}
@@ -30,6 +30,9 @@ import (
 	keycloakService "github.com/ZupIT/horusec/development-kit/pkg/services/keycloak"
 	"github.com/ZupIT/horusec/horusec-auth/config/app"
 	"github.com/ZupIT/horusec/horusec-auth/internal/services"
+	customRoleService "github.com/ZupIT/horusec/horusec-auth/internal/services/custom_role"
+	oidcService "github.com/ZupIT/horusec/horusec-auth/internal/services/oidc"
+	personalTokenService "github.com/ZupIT/horusec/horusec-auth/internal/services/personal_token"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -113,9 +116,41 @@ func TestAuthByType(t *testing.T) {
 		assert.Equal(t, errorsEnum.ErrorUnauthorized, err)
 		assert.Nil(t, result)
 	})
+
+	t.Run("should authenticate with oidc and return no errors", func(t *testing.T) {
+		mockService := &oidcService.Mock{}
+
+		mockService.On("Authenticate").Return(&dto.OIDCAuthResponse{}, nil)
+
+		controller := Controller{
+			appConfig:       &app.Config{AuthType: authEnums.OIDC},
+			oidcAuthService: mockService,
+		}
+
+		result, err := controller.AuthByType(&dto.Credentials{Code: "code", State: "state"})
+
+		assert.NotNil(t, result)
+		assert.NoError(t, err)
+	})
 }
 
 func TestAuthorizeByType(t *testing.T) {
+	t.Run("should authorize with oidc and return no errors", func(t *testing.T) {
+		mockService := &oidcService.Mock{}
+
+		mockService.On("IsAuthorized").Return(true, nil)
+
+		controller := Controller{
+			appConfig:       &app.Config{AuthType: authEnums.OIDC},
+			oidcAuthService: mockService,
+		}
+
+		result, err := controller.IsAuthorized(nil, &authGrpc.IsAuthorizedData{Token: "test", Role: "test"})
+
+		assert.NoError(t, err)
+		assert.True(t, result.GetIsAuthorized())
+	})
+
 	t.Run("should authenticate with horusec and return no errors", func(t *testing.T) {
 		mockService := &services.MockAuthService{}
 
@@ -209,6 +244,265 @@ func TestAuthorizeByType(t *testing.T) {
 	})
 }
 
+func TestIsAuthorizedByPersonalToken(t *testing.T) {
+	t.Run("should authorize keycloak personal token with horusec service", func(t *testing.T) {
+		personalTokenMock := &personalTokenService.Mock{}
+		personalTokenMock.On("Exchange").Return(&authEntities.PersonalToken{}, "account token", nil)
+		mockService := &services.MockAuthService{}
+		mockService.On("IsAuthorized").Return(true, nil)
+
+		controller := Controller{
+			appConfig:        &app.Config{AuthType: authEnums.Keycloak},
+			horusAuthService: mockService,
+			personalToken:    personalTokenMock,
+		}
+
+		result, err := controller.IsAuthorized(nil, &authGrpc.IsAuthorizedData{
+			Token: "hpat_test",
+			Role:  authEnums.CompanyMember.ToString(),
+			Scope: authEnums.ReadAnalytics.ToString(),
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, result.GetIsAuthorized())
+	})
+
+	t.Run("should authorize ldap personal token with ldap service", func(t *testing.T) {
+		personalTokenMock := &personalTokenService.Mock{}
+		personalTokenMock.On("Exchange").Return(&authEntities.PersonalToken{}, "account token", nil)
+		mockService := &services.MockAuthService{}
+		mockService.On("IsAuthorized").Return(true, nil)
+
+		controller := Controller{
+			appConfig:       &app.Config{AuthType: authEnums.Ldap},
+			ldapAuthService: mockService,
+			personalToken:   personalTokenMock,
+		}
+
+		result, err := controller.IsAuthorized(nil, &authGrpc.IsAuthorizedData{Token: "hpat_test"})
+
+		assert.NoError(t, err)
+		assert.True(t, result.GetIsAuthorized())
+	})
+
+	t.Run("should authorize oidc personal token with oidc service", func(t *testing.T) {
+		personalTokenMock := &personalTokenService.Mock{}
+		personalTokenMock.On("Exchange").Return(&authEntities.PersonalToken{}, "account token", nil)
+		mockService := &oidcService.Mock{}
+		mockService.On("IsAuthorized").Return(true, nil)
+
+		controller := Controller{
+			appConfig:       &app.Config{AuthType: authEnums.OIDC},
+			oidcAuthService: mockService,
+			personalToken:   personalTokenMock,
+		}
+
+		result, err := controller.IsAuthorized(nil, &authGrpc.IsAuthorizedData{Token: "hpat_test"})
+
+		assert.NoError(t, err)
+		assert.True(t, result.GetIsAuthorized())
+	})
+
+	t.Run("should return error when personal token does not have the scope", func(t *testing.T) {
+		personalTokenMock := &personalTokenService.Mock{}
+		personalTokenMock.On("Exchange").Return(&authEntities.PersonalToken{}, "",
+			errorsEnum.ErrorPersonalTokenScopeNotAllowed)
+
+		controller := Controller{
+			appConfig:     &app.Config{AuthType: authEnums.Horusec},
+			personalToken: personalTokenMock,
+		}
+
+		_, err := controller.IsAuthorized(nil, &authGrpc.IsAuthorizedData{Token: "hpat_test"})
+
+		assert.Equal(t, errorsEnum.ErrorPersonalTokenScopeNotAllowed, err)
+	})
+
+	t.Run("should return unauthorized when invalid auth type", func(t *testing.T) {
+		personalTokenMock := &personalTokenService.Mock{}
+		personalTokenMock.On("Exchange").Return(&authEntities.PersonalToken{}, "account token", nil)
+
+		controller := Controller{
+			appConfig:     &app.Config{AuthType: "test"},
+			personalToken: personalTokenMock,
+		}
+
+		_, err := controller.IsAuthorized(nil, &authGrpc.IsAuthorizedData{Token: "hpat_test"})
+
+		assert.Equal(t, errorsEnum.ErrorUnauthorized, err)
+	})
+}
+
+func TestIsAuthorizedByPermission(t *testing.T) {
+	token, _, _ := jwt.CreateToken(&authEntities.Account{AccountID: uuid.New(), Email: "test@test.com",
+		Username: "test"}, nil)
+
+	t.Run("should authorize when a built-in role has the permission", func(t *testing.T) {
+		mockService := &services.MockAuthService{}
+		mockService.On("IsAuthorized").Return(true, nil)
+		customRoleMock := &customRoleService.Mock{}
+
+		controller := Controller{
+			appConfig:        &app.Config{AuthType: authEnums.Horusec},
+			horusAuthService: mockService,
+			customRole:       customRoleMock,
+		}
+
+		result, err := controller.IsAuthorized(nil, &authGrpc.IsAuthorizedData{
+			Token:      token,
+			Permission: authEnums.ManageVulnerabilityTypes.ToString(),
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, result.GetIsAuthorized())
+		customRoleMock.AssertNotCalled(t, "HasPermission")
+	})
+
+	t.Run("should authorize repository member with custom role containing the permission", func(t *testing.T) {
+		mockService := &services.MockAuthService{}
+		mockService.On("IsAuthorized").Return(false, errors.New("test")).Times(3)
+		mockService.On("IsAuthorized").Return(true, nil)
+		customRoleMock := &customRoleService.Mock{}
+		customRoleMock.On("HasPermission").Return(true, nil)
+
+		controller := Controller{
+			appConfig:        &app.Config{AuthType: authEnums.Horusec},
+			horusAuthService: mockService,
+			customRole:       customRoleMock,
+		}
+
+		result, err := controller.IsAuthorized(nil, &authGrpc.IsAuthorizedData{
+			Token:      token,
+			Permission: authEnums.ManageVulnerabilityTypes.ToString(),
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, result.GetIsAuthorized())
+	})
+
+	t.Run("should not authorize when custom role does not contain the permission", func(t *testing.T) {
+		mockService := &services.MockAuthService{}
+		mockService.On("IsAuthorized").Return(false, errors.New("test")).Times(2)
+		mockService.On("IsAuthorized").Return(true, nil)
+		customRoleMock := &customRoleService.Mock{}
+		customRoleMock.On("HasPermission").Return(false, nil)
+
+		controller := Controller{
+			appConfig:        &app.Config{AuthType: authEnums.Horusec},
+			horusAuthService: mockService,
+			customRole:       customRoleMock,
+		}
+
+		result, err := controller.IsAuthorized(nil, &authGrpc.IsAuthorizedData{
+			Token:      token,
+			Permission: authEnums.ManageRepositoryTokens.ToString(),
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, result.GetIsAuthorized())
+	})
+
+	t.Run("should return error when account is not repository member", func(t *testing.T) {
+		mockService := &services.MockAuthService{}
+		mockService.On("IsAuthorized").Return(false, errorsEnum.ErrorUnauthorizedRepositoryMember)
+		customRoleMock := &customRoleService.Mock{}
+
+		controller := Controller{
+			appConfig:        &app.Config{AuthType: authEnums.Horusec},
+			horusAuthService: mockService,
+			customRole:       customRoleMock,
+		}
+
+		_, err := controller.IsAuthorized(nil, &authGrpc.IsAuthorizedData{
+			Token:      token,
+			Permission: authEnums.ManageRepository.ToString(),
+		})
+
+		assert.Equal(t, errorsEnum.ErrorUnauthorizedRepositoryMember, err)
+		customRoleMock.AssertNotCalled(t, "HasPermission")
+	})
+
+	t.Run("should not check custom roles when ldap", func(t *testing.T) {
+		mockService := &services.MockAuthService{}
+		mockService.On("IsAuthorized").Return(false, errors.New("test"))
+		customRoleMock := &customRoleService.Mock{}
+
+		controller := Controller{
+			appConfig:       &app.Config{AuthType: authEnums.Ldap},
+			ldapAuthService: mockService,
+			customRole:      customRoleMock,
+		}
+
+		_, err := controller.IsAuthorized(nil, &authGrpc.IsAuthorizedData{
+			Token:      token,
+			Permission: authEnums.ManageRepository.ToString(),
+		})
+
+		assert.Equal(t, errorsEnum.ErrorUnauthorized, err)
+		customRoleMock.AssertNotCalled(t, "HasPermission")
+	})
+
+	t.Run("should check custom role of keycloak personal token with horusec token", func(t *testing.T) {
+		personalTokenMock := &personalTokenService.Mock{}
+		personalTokenMock.On("Exchange").Return(&authEntities.PersonalToken{}, token, nil)
+		mockService := &services.MockAuthService{}
+		mockService.On("IsAuthorized").Return(false, errors.New("test")).Times(3)
+		mockService.On("IsAuthorized").Return(true, nil)
+		customRoleMock := &customRoleService.Mock{}
+		customRoleMock.On("HasPermission").Return(true, nil)
+
+		controller := Controller{
+			appConfig:        &app.Config{AuthType: authEnums.Keycloak},
+			horusAuthService: mockService,
+			personalToken:    personalTokenMock,
+			customRole:       customRoleMock,
+		}
+
+		result, err := controller.IsAuthorized(nil, &authGrpc.IsAuthorizedData{
+			Token:      "hpat_test",
+			Permission: authEnums.ManageVulnerabilityTypes.ToString(),
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, result.GetIsAuthorized())
+	})
+}
+
+func TestGetAccountIDByPersonalToken(t *testing.T) {
+	t.Run("should return account id and permissions of the personal token", func(t *testing.T) {
+		personalToken := &authEntities.PersonalToken{AccountID: uuid.New(), Permissions: []string{"group"}}
+		personalTokenMock := &personalTokenService.Mock{}
+		personalTokenMock.On("Exchange").Return(personalToken, "account token", nil)
+
+		controller := Controller{
+			appConfig:     &app.Config{AuthType: authEnums.Ldap},
+			personalToken: personalTokenMock,
+		}
+
+		response, err := controller.GetAccountID(nil, &authGrpc.GetAccountData{Token: "hpat_test"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, personalToken.AccountID.String(), response.GetAccountID())
+		assert.Equal(t, []string{"group"}, response.GetPermissions())
+	})
+
+	t.Run("should return error when personal token is expired", func(t *testing.T) {
+		personalTokenMock := &personalTokenService.Mock{}
+		personalTokenMock.On("Exchange").Return(&authEntities.PersonalToken{}, "",
+			errorsEnum.ErrorPersonalTokenExpired)
+
+		controller := Controller{
+			appConfig:     &app.Config{AuthType: authEnums.Horusec},
+			personalToken: personalTokenMock,
+		}
+
+		response, err := controller.GetAccountID(nil, &authGrpc.GetAccountData{Token: "hpat_test"})
+
+		assert.Error(t, err)
+		assert.Empty(t, response.GetAccountID())
+	})
+}
+
 func TestController_GetAuthTypes(t *testing.T) {
 	t.Run("Should return default authentication type", func(t *testing.T) {
 		mockService := &services.MockAuthService{}
@@ -309,6 +603,26 @@ func TestGetAccountIDByAuthType(t *testing.T) {
 		assert.NotEmpty(t, response.GetAccountID())
 	})
 
+	t.Run("should return account id and permissions when oidc", func(t *testing.T) {
+		account := &authEntities.Account{
+			AccountID: uuid.New(),
+			Email:     "test@test.com",
+			Username:  "test",
+		}
+
+		token, _, _ := jwt.CreateToken(account, []string{"developers"})
+
+		controller := Controller{
+			appConfig: &app.Config{AuthType: authEnums.OIDC},
+		}
+
+		response, err := controller.GetAccountID(nil, &authGrpc.GetAccountData{Token: token})
+
+		assert.NoError(t, err)
+		assert.Equal(t, account.AccountID.String(), response.GetAccountID())
+		assert.Equal(t, []string{"developers"}, response.GetPermissions())
+	})
+
 	t.Run("should return error when invalid auth type", func(t *testing.T) {
 		mockService := &services.MockAuthService{}
 
@@ -324,3 +638,32 @@ func TestGetAccountIDByAuthType(t *testing.T) {
 		assert.Empty(t, response.GetAccountID())
 	})
 }
+
+func TestGetOIDCAuthorizationURL(t *testing.T) {
+	t.Run("should return authorization url when oidc", func(t *testing.T) {
+		mockService := &oidcService.Mock{}
+
+		mockService.On("GetAuthorizationURL").Return(&dto.OIDCAuthorization{State: "state"}, nil)
+
+		controller := Controller{
+			appConfig:       &app.Config{AuthType: authEnums.OIDC},
+			oidcAuthService: mockService,
+		}
+
+		result, err := controller.GetOIDCAuthorizationURL()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "state", result.State)
+	})
+
+	t.Run("should return error when auth type is not oidc", func(t *testing.T) {
+		controller := Controller{
+			appConfig: &app.Config{AuthType: authEnums.Horusec},
+		}
+
+		result, err := controller.GetOIDCAuthorizationURL()
+
+		assert.Equal(t, errorsEnum.ErrorInvalidAuthType, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,353 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldapsync
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
+	accountCompanyRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_company"
+	accountRepositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_repository"
+	companyRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	repositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	accountEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/account"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	ldapService "github.com/ZupIT/horusec/development-kit/pkg/services/ldap"
+	"github.com/google/uuid"
+)
+
+type IController interface {
+	Sync(dryRun bool) (*dto.LdapSyncReport, error)
+}
+
+type Controller struct {
+	client                ldapService.ILDAPService
+	accountRepo           accountRepo.IAccount
+	companyRepo           companyRepo.ICompanyRepository
+	repositoryRepo        repositoryRepo.IRepository
+	accountCompanyRepo    accountCompanyRepo.IAccountCompany
+	accountRepositoryRepo accountRepositoryRepo.IAccountRepository
+	mutex                 sync.Mutex
+}
+
+// syncRun keeps the state of one execution, so each ldap group and account is searched only once
+type syncRun struct {
+	report   *dto.LdapSyncReport
+	members  map[string][]map[string]string
+	accounts map[string]*authEntities.Account
+}
+
+type roleChange struct {
+	*dto.LdapSyncChange
+	accountID uuid.UUID
+}
+
+var roleRank = map[accountEnums.Role]int{accountEnums.Member: 1, accountEnums.Supervisor: 2, accountEnums.Admin: 3}
+
+func NewController(databaseRead relational.InterfaceRead, databaseWrite relational.InterfaceWrite) IController {
+	return &Controller{
+		client:                ldapService.NewLDAPClient(),
+		accountRepo:           accountRepo.NewAccountRepository(databaseRead, databaseWrite),
+		companyRepo:           companyRepo.NewCompanyRepository(databaseRead, databaseWrite),
+		repositoryRepo:        repositoryRepo.NewRepository(databaseRead, databaseWrite),
+		accountCompanyRepo:    accountCompanyRepo.NewAccountCompanyRepository(databaseRead, databaseWrite),
+		accountRepositoryRepo: accountRepositoryRepo.NewAccountRepositoryRepository(databaseRead, databaseWrite),
+	}
+}
+
+// Sync reconciles the account_company and account_repository rows with the members of the ldap groups configured
+// in each company and repository. Entities without any group configured are skipped.
+func (c *Controller) Sync(dryRun bool) (*dto.LdapSyncReport, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	defer c.client.Close()
+
+	run := &syncRun{report: dto.NewLdapSyncReport(dryRun), members: map[string][]map[string]string{},
+		accounts: map[string]*authEntities.Account{}}
+
+	companies, err := c.companyRepo.ListAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range *companies {
+		if err := c.syncCompany(run, &(*companies)[index]); err != nil {
+			return nil, err
+		}
+	}
+
+	return run.report, nil
+}
+
+func (c *Controller) syncCompany(run *syncRun, company *accountEntities.Company) error {
+	desired, err := c.getDesiredRoles(run, map[accountEnums.Role][]string{
+		accountEnums.Admin: company.AuthzAdmin, accountEnums.Member: company.AuthzMember})
+	if err != nil {
+		return err
+	}
+
+	if desired != nil {
+		if err := c.syncCompanyRoles(run, company, desired); err != nil {
+			return err
+		}
+	}
+
+	return c.syncRepositories(run, company.CompanyID)
+}
+
+func (c *Controller) syncCompanyRoles(run *syncRun, company *accountEntities.Company,
+	desired map[string]accountEnums.Role) error {
+	current, err := c.companyRepo.GetAllAccountsInCompany(company.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range c.getChanges(run, desired, current) {
+		change.CompanyID, change.Name = company.CompanyID, company.Name
+		if err := c.applyCompanyChange(run, change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) syncRepositories(run *syncRun, companyID uuid.UUID) error {
+	repositories, err := c.repositoryRepo.ListAllInCompany(companyID)
+	if err != nil {
+		return err
+	}
+
+	for index := range *repositories {
+		if err := c.syncRepository(run, &(*repositories)[index]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) syncRepository(run *syncRun, repository *accountEntities.Repository) error {
+	desired, err := c.getDesiredRoles(run, map[accountEnums.Role][]string{accountEnums.Admin: repository.AuthzAdmin,
+		accountEnums.Supervisor: repository.AuthzSupervisor, accountEnums.Member: repository.AuthzMember})
+	if err != nil || desired == nil {
+		return err
+	}
+
+	current, err := c.repositoryRepo.GetAllAccountsInRepository(repository.RepositoryID)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range c.getChanges(run, desired, current) {
+		repositoryID := repository.RepositoryID
+		change.CompanyID, change.RepositoryID, change.Name = repository.CompanyID, &repositoryID, repository.Name
+		if err := c.applyRepositoryChange(run, change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getDesiredRoles returns the role of each username by the ldap groups, keeping the highest role when the user is
+// in more than one group. Returns nil when there is no group configured.
+func (c *Controller) getDesiredRoles(run *syncRun,
+	groupsByRole map[accountEnums.Role][]string) (desired map[string]accountEnums.Role, err error) {
+	for role, groups := range groupsByRole {
+		for _, group := range groups {
+			if strings.TrimSpace(group) == "" {
+				continue
+			}
+
+			if desired == nil {
+				desired = map[string]accountEnums.Role{}
+			}
+
+			if err := c.setDesiredRoleOfMembers(run, desired, strings.TrimSpace(group), role); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return desired, nil
+}
+
+func (c *Controller) setDesiredRoleOfMembers(run *syncRun, desired map[string]accountEnums.Role, group string,
+	role accountEnums.Role) error {
+	members, err := c.getGroupMembers(run, group)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if member["sAMAccountName"] == "" {
+			continue
+		}
+
+		if err := c.setAccountAndCreateIfNotExist(run, member); err != nil {
+			return err
+		}
+
+		if roleRank[role] > roleRank[desired[member["sAMAccountName"]]] {
+			desired[member["sAMAccountName"]] = role
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) getGroupMembers(run *syncRun, group string) ([]map[string]string, error) {
+	if members, ok := run.members[group]; ok {
+		return members, nil
+	}
+
+	members, err := c.client.GetGroupMembers(group)
+	if err != nil {
+		return nil, err
+	}
+
+	run.members[group] = members
+	return members, nil
+}
+
+func (c *Controller) setAccountAndCreateIfNotExist(run *syncRun, member map[string]string) error {
+	username := member["sAMAccountName"]
+	if _, ok := run.accounts[username]; ok {
+		return nil
+	}
+
+	account, err := c.accountRepo.GetByUsername(username)
+	if err == nil && account != nil {
+		run.accounts[username] = account
+		return nil
+	}
+
+	if err != nil && err != errors.ErrNotFoundRecords {
+		return err
+	}
+
+	return c.createAccount(run, member)
+}
+
+func (c *Controller) createAccount(run *syncRun, member map[string]string) error {
+	account := &authEntities.Account{Email: member["mail"], Username: member["sAMAccountName"]}
+	if account.Email == "" {
+		account.Email = account.Username
+	}
+
+	account.SetAccountData()
+	if !run.report.DryRun {
+		if err := c.accountRepo.Create(account); err != nil {
+			return err
+		}
+	}
+
+	run.accounts[account.Username] = account
+	run.report.AddCreatedAccount(account.Username)
+	return nil
+}
+
+func (c *Controller) getChanges(run *syncRun, desired map[string]accountEnums.Role,
+	current *[]roles.AccountRole) (changes []*roleChange) {
+	currentRoles := map[string]bool{}
+	for _, account := range *current {
+		currentRoles[account.Username] = true
+		if change := c.getChangeOfCurrentAccount(&account, desired[account.Username]); change != nil {
+			changes = append(changes, change)
+		}
+	}
+
+	for _, username := range c.getSortedUsernames(desired) {
+		if !currentRoles[username] {
+			changes = append(changes, &roleChange{accountID: run.accounts[username].AccountID,
+				LdapSyncChange: &dto.LdapSyncChange{Action: dto.LdapSyncAdd, Username: username, Role: desired[username]}})
+		}
+	}
+
+	return changes
+}
+
+func (c *Controller) getChangeOfCurrentAccount(account *roles.AccountRole, role accountEnums.Role) *roleChange {
+	change := &roleChange{accountID: account.AccountID, LdapSyncChange: &dto.LdapSyncChange{
+		Username: account.Username, Role: role, PreviousRole: accountEnums.Role(account.Role)}}
+
+	switch {
+	case role == "":
+		change.Action = dto.LdapSyncRemove
+	case role != accountEnums.Role(account.Role):
+		change.Action = dto.LdapSyncUpdate
+	default:
+		return nil
+	}
+
+	return change
+}
+
+func (c *Controller) getSortedUsernames(desired map[string]accountEnums.Role) (usernames []string) {
+	for username := range desired {
+		usernames = append(usernames, username)
+	}
+
+	sort.Strings(usernames)
+	return usernames
+}
+
+func (c *Controller) applyCompanyChange(run *syncRun, change *roleChange) error {
+	run.report.AddChange(change.LdapSyncChange)
+	if run.report.DryRun {
+		return nil
+	}
+
+	switch change.Action {
+	case dto.LdapSyncAdd:
+		return c.accountCompanyRepo.CreateAccountCompany(change.CompanyID, change.accountID, change.Role, nil)
+	case dto.LdapSyncUpdate:
+		return c.accountCompanyRepo.UpdateAccountCompany(&roles.AccountCompany{
+			CompanyID: change.CompanyID, AccountID: change.accountID, Role: change.Role})
+	}
+
+	if err := c.accountRepositoryRepo.DeleteFromAllRepositories(change.accountID, change.CompanyID); err != nil {
+		return err
+	}
+
+	return c.accountCompanyRepo.DeleteAccountCompany(change.accountID, change.CompanyID)
+}
+
+func (c *Controller) applyRepositoryChange(run *syncRun, change *roleChange) error {
+	run.report.AddChange(change.LdapSyncChange)
+	if run.report.DryRun {
+		return nil
+	}
+
+	accountRepository := &roles.AccountRepository{RepositoryID: *change.RepositoryID,
+		AccountID: change.accountID, CompanyID: change.CompanyID, Role: change.Role}
+
+	switch change.Action {
+	case dto.LdapSyncAdd:
+		return c.accountRepositoryRepo.Create(accountRepository, nil)
+	case dto.LdapSyncUpdate:
+		return c.accountRepositoryRepo.UpdateAccountRepository(accountRepository)
+	}
+
+	return c.accountRepositoryRepo.DeleteAccountRepository(change.accountID, *change.RepositoryID)
+}
@@ -0,0 +1,310 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldapsync
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
+	accountCompanyRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_company"
+	accountRepositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_repository"
+	companyRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	repositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	accountEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/account"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	ldapService "github.com/ZupIT/horusec/development-kit/pkg/services/ldap"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/ldap/ldaptest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type testContext struct {
+	server                *ldaptest.Server
+	controller            *Controller
+	accountMock           *accountRepo.Mock
+	companyMock           *companyRepo.Mock
+	repositoryMock        *repositoryRepo.Mock
+	accountCompanyMock    *accountCompanyRepo.Mock
+	accountRepositoryMock *accountRepositoryRepo.Mock
+	company               accountEntities.Company
+	repository            accountEntities.Repository
+}
+
+func newLdapEntries() []ldaptest.Entry {
+	return []ldaptest.Entry{
+		{DN: "cn=admin,dc=horusec,dc=io", Attributes: map[string][]string{"userPassword": {"admin"}}},
+		{DN: "cn=john,ou=users,dc=horusec,dc=io",
+			Attributes: map[string][]string{"sAMAccountName": {"john"}, "mail": {"john@horusec.io"}}},
+		{DN: "cn=mary,ou=users,dc=horusec,dc=io",
+			Attributes: map[string][]string{"sAMAccountName": {"mary"}, "mail": {"mary@horusec.io"}}},
+		{DN: "cn=paul,ou=users,dc=horusec,dc=io",
+			Attributes: map[string][]string{"sAMAccountName": {"paul"}, "mail": {"paul@horusec.io"}}},
+		{DN: "cn=horusec-admins,ou=groups,dc=horusec,dc=io", Attributes: map[string][]string{
+			"cn": {"horusec-admins"}, "member": {"cn=john,ou=users,dc=horusec,dc=io"}}},
+		{DN: "cn=horusec-devs,ou=groups,dc=horusec,dc=io", Attributes: map[string][]string{
+			"cn": {"horusec-devs"}, "member": {"cn=john,ou=users,dc=horusec,dc=io",
+				"cn=mary,ou=users,dc=horusec,dc=io", "cn=paul,ou=users,dc=horusec,dc=io"}}},
+		{DN: "cn=horusec-leads,ou=groups,dc=horusec,dc=io", Attributes: map[string][]string{
+			"cn": {"horusec-leads"}, "member": {"cn=mary,ou=users,dc=horusec,dc=io"}}},
+	}
+}
+
+// newTestContext starts an in-memory ldap where john is admin, mary is lead and paul is developer. In the database
+// mary is already company admin, paul is not yet an account and old is a member that left the ldap groups.
+func newTestContext(t *testing.T) *testContext {
+	server, err := ldaptest.NewServer(newLdapEntries())
+	assert.NoError(t, err)
+
+	ctx := &testContext{server: server, accountMock: &accountRepo.Mock{}, companyMock: &companyRepo.Mock{},
+		repositoryMock: &repositoryRepo.Mock{}, accountCompanyMock: &accountCompanyRepo.Mock{},
+		accountRepositoryMock: &accountRepositoryRepo.Mock{}}
+	ctx.company = accountEntities.Company{CompanyID: uuid.New(), Name: "horusec",
+		AuthzAdmin: []string{"horusec-admins"}, AuthzMember: []string{"horusec-devs"}}
+	ctx.repository = accountEntities.Repository{RepositoryID: uuid.New(), CompanyID: ctx.company.CompanyID,
+		Name: "api", AuthzAdmin: []string{"horusec-admins"}, AuthzSupervisor: []string{"horusec-leads"},
+		AuthzMember: []string{" horusec-devs "}}
+
+	ctx.controller = &Controller{
+		client: &ldapService.Service{Host: server.Host(), Port: server.Port(), Base: "dc=horusec,dc=io",
+			BindDN: "cn=admin,dc=horusec,dc=io", BindPassword: "admin", SkipTLS: true},
+		accountRepo: ctx.accountMock, companyRepo: ctx.companyMock, repositoryRepo: ctx.repositoryMock,
+		accountCompanyRepo: ctx.accountCompanyMock, accountRepositoryRepo: ctx.accountRepositoryMock,
+	}
+
+	return ctx
+}
+
+func (ctx *testContext) mockDatabase() {
+	ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{ctx.company, {CompanyID: uuid.New()}}, nil)
+	ctx.companyMock.On("GetAllAccountsInCompany").Return(&[]roles.AccountRole{
+		{AccountID: uuid.New(), Username: "mary", Role: "admin"},
+		{AccountID: uuid.New(), Username: "old", Role: "member"},
+		{AccountID: uuid.New(), Username: "john", Role: "admin"},
+	}, nil)
+	ctx.repositoryMock.On("ListAllInCompany").Return(&[]accountEntities.Repository{ctx.repository}, nil).Once()
+	ctx.repositoryMock.On("ListAllInCompany").Return(&[]accountEntities.Repository{}, nil)
+	ctx.repositoryMock.On("GetAllAccountsInRepository").Return(&[]roles.AccountRole{}, nil)
+	ctx.accountMock.On("GetByUsername").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+	ctx.accountMock.On("Create").Return(nil)
+	ctx.accountCompanyMock.On("CreateAccountCompany").Return(nil)
+	ctx.accountCompanyMock.On("UpdateAccountCompany").Return(nil)
+	ctx.accountCompanyMock.On("DeleteAccountCompany").Return(nil)
+	ctx.accountRepositoryMock.On("Create").Return(nil)
+	ctx.accountRepositoryMock.On("DeleteFromAllRepositories").Return(nil)
+}
+
+func TestNewController(t *testing.T) {
+	t.Run("should create a new controller", func(t *testing.T) {
+		assert.NotNil(t, NewController(&relational.MockRead{}, &relational.MockWrite{}))
+	})
+}
+
+func TestSync(t *testing.T) {
+	t.Run("should reconcile company and repository roles with ldap groups", func(t *testing.T) {
+		ctx := newTestContext(t)
+		defer ctx.server.Close()
+		ctx.mockDatabase()
+
+		report, err := ctx.controller.Sync(false)
+
+		assert.NoError(t, err)
+		assert.False(t, report.DryRun)
+		assert.ElementsMatch(t, []string{"john", "mary", "paul"}, report.CreatedAccounts)
+		assert.Equal(t, []dto.LdapSyncChange{
+			{Action: dto.LdapSyncUpdate, CompanyID: ctx.company.CompanyID, Name: "horusec", Username: "mary",
+				Role: accountEnums.Member, PreviousRole: accountEnums.Admin},
+			{Action: dto.LdapSyncRemove, CompanyID: ctx.company.CompanyID, Name: "horusec", Username: "old",
+				PreviousRole: accountEnums.Member},
+			{Action: dto.LdapSyncAdd, CompanyID: ctx.company.CompanyID, Name: "horusec", Username: "paul",
+				Role: accountEnums.Member},
+		}, report.Changes[:3])
+		assert.Equal(t, []dto.LdapSyncChange{
+			{Action: dto.LdapSyncAdd, CompanyID: ctx.company.CompanyID, RepositoryID: &ctx.repository.RepositoryID,
+				Name: "api", Username: "john", Role: accountEnums.Admin},
+			{Action: dto.LdapSyncAdd, CompanyID: ctx.company.CompanyID, RepositoryID: &ctx.repository.RepositoryID,
+				Name: "api", Username: "mary", Role: accountEnums.Supervisor},
+			{Action: dto.LdapSyncAdd, CompanyID: ctx.company.CompanyID, RepositoryID: &ctx.repository.RepositoryID,
+				Name: "api", Username: "paul", Role: accountEnums.Member},
+		}, report.Changes[3:])
+		ctx.accountMock.AssertNumberOfCalls(t, "Create", 3)
+		ctx.accountCompanyMock.AssertNumberOfCalls(t, "CreateAccountCompany", 1)
+		ctx.accountCompanyMock.AssertNumberOfCalls(t, "UpdateAccountCompany", 1)
+		ctx.accountCompanyMock.AssertNumberOfCalls(t, "DeleteAccountCompany", 1)
+		ctx.accountRepositoryMock.AssertNumberOfCalls(t, "DeleteFromAllRepositories", 1)
+		ctx.accountRepositoryMock.AssertNumberOfCalls(t, "Create", 3)
+	})
+
+	t.Run("should only report changes when dry run", func(t *testing.T) {
+		ctx := newTestContext(t)
+		defer ctx.server.Close()
+		ctx.mockDatabase()
+
+		report, err := ctx.controller.Sync(true)
+
+		assert.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Len(t, report.CreatedAccounts, 3)
+		assert.Len(t, report.Changes, 6)
+		ctx.accountMock.AssertNotCalled(t, "Create")
+		ctx.accountCompanyMock.AssertNotCalled(t, "CreateAccountCompany")
+		ctx.accountCompanyMock.AssertNotCalled(t, "UpdateAccountCompany")
+		ctx.accountCompanyMock.AssertNotCalled(t, "DeleteAccountCompany")
+		ctx.accountRepositoryMock.AssertNotCalled(t, "DeleteFromAllRepositories")
+		ctx.accountRepositoryMock.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("should use existing accounts and remove repository roles", func(t *testing.T) {
+		ctx := newTestContext(t)
+		defer ctx.server.Close()
+		ctx.company.AuthzAdmin, ctx.company.AuthzMember = nil, []string{""}
+		ctx.repository.AuthzMember, ctx.repository.AuthzSupervisor = nil, nil
+		accountID := uuid.New()
+
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{ctx.company}, nil)
+		ctx.repositoryMock.On("ListAllInCompany").Return(&[]accountEntities.Repository{ctx.repository}, nil)
+		ctx.repositoryMock.On("GetAllAccountsInRepository").Return(&[]roles.AccountRole{
+			{AccountID: uuid.New(), Username: "john", Role: "supervisor"},
+			{AccountID: accountID, Username: "mary", Role: "member"},
+		}, nil)
+		ctx.accountMock.On("GetByUsername").Return(&authEntities.Account{AccountID: uuid.New()}, nil)
+		ctx.accountRepositoryMock.On("UpdateAccountRepository").Return(nil)
+		ctx.accountRepositoryMock.On("DeleteAccountRepository").Return(nil)
+
+		report, err := ctx.controller.Sync(false)
+
+		assert.NoError(t, err)
+		assert.Empty(t, report.CreatedAccounts)
+		assert.Equal(t, 1, report.CountChanges(dto.LdapSyncUpdate))
+		assert.Equal(t, 1, report.CountChanges(dto.LdapSyncRemove))
+		ctx.companyMock.AssertNotCalled(t, "GetAllAccountsInCompany")
+		ctx.accountRepositoryMock.AssertCalled(t, "DeleteAccountRepository")
+	})
+
+	t.Run("should return error when list companies fails", func(t *testing.T) {
+		ctx := newTestContext(t)
+		defer ctx.server.Close()
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{}, errors.New("test"))
+
+		_, err := ctx.controller.Sync(false)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when ldap is not available", func(t *testing.T) {
+		ctx := newTestContext(t)
+		ctx.server.Close()
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{ctx.company}, nil)
+
+		_, err := ctx.controller.Sync(false)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when get account fails", func(t *testing.T) {
+		ctx := newTestContext(t)
+		defer ctx.server.Close()
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{ctx.company}, nil)
+		ctx.accountMock.On("GetByUsername").Return(&authEntities.Account{}, errors.New("test"))
+
+		_, err := ctx.controller.Sync(false)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when create account fails", func(t *testing.T) {
+		ctx := newTestContext(t)
+		defer ctx.server.Close()
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{ctx.company}, nil)
+		ctx.accountMock.On("GetByUsername").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+		ctx.accountMock.On("Create").Return(errors.New("test"))
+
+		_, err := ctx.controller.Sync(false)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when get company accounts fails", func(t *testing.T) {
+		ctx := newTestContext(t)
+		defer ctx.server.Close()
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{ctx.company}, nil)
+		ctx.companyMock.On("GetAllAccountsInCompany").Return(&[]roles.AccountRole{}, errors.New("test"))
+		ctx.accountMock.On("GetByUsername").Return(&authEntities.Account{}, nil)
+
+		_, err := ctx.controller.Sync(false)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when apply company change fails", func(t *testing.T) {
+		ctx := newTestContext(t)
+		defer ctx.server.Close()
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{ctx.company}, nil)
+		ctx.companyMock.On("GetAllAccountsInCompany").Return(&[]roles.AccountRole{}, nil)
+		ctx.accountMock.On("GetByUsername").Return(&authEntities.Account{}, nil)
+		ctx.accountCompanyMock.On("CreateAccountCompany").Return(errors.New("test"))
+
+		_, err := ctx.controller.Sync(false)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error and keep the company role when remove the repository roles fails", func(t *testing.T) {
+		ctx := newTestContext(t)
+		defer ctx.server.Close()
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{ctx.company}, nil)
+		ctx.companyMock.On("GetAllAccountsInCompany").Return(&[]roles.AccountRole{
+			{AccountID: uuid.New(), Username: "old", Role: "member"}}, nil)
+		ctx.accountMock.On("GetByUsername").Return(&authEntities.Account{}, nil)
+		ctx.accountRepositoryMock.On("DeleteFromAllRepositories").Return(errors.New("test"))
+		ctx.accountCompanyMock.On("DeleteAccountCompany").Return(nil)
+
+		_, err := ctx.controller.Sync(false)
+
+		assert.Error(t, err)
+		ctx.accountCompanyMock.AssertNotCalled(t, "DeleteAccountCompany")
+	})
+
+	t.Run("should return error when list repositories fails", func(t *testing.T) {
+		ctx := newTestContext(t)
+		defer ctx.server.Close()
+		ctx.company.AuthzAdmin, ctx.company.AuthzMember = nil, nil
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{ctx.company}, nil)
+		ctx.repositoryMock.On("ListAllInCompany").Return(&[]accountEntities.Repository{}, errors.New("test"))
+
+		_, err := ctx.controller.Sync(false)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when get repository accounts fails", func(t *testing.T) {
+		ctx := newTestContext(t)
+		defer ctx.server.Close()
+		ctx.company.AuthzAdmin, ctx.company.AuthzMember = nil, nil
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{ctx.company}, nil)
+		ctx.repositoryMock.On("ListAllInCompany").Return(&[]accountEntities.Repository{ctx.repository}, nil)
+		ctx.repositoryMock.On("GetAllAccountsInRepository").Return(&[]roles.AccountRole{}, errors.New("test"))
+		ctx.accountMock.On("GetByUsername").Return(&authEntities.Account{}, nil)
+
+		_, err := ctx.controller.Sync(false)
+
+		assert.Error(t, err)
+	})
+}
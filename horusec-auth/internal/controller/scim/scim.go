@@ -0,0 +1,340 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"sync"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
+	accountCompanyRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_company"
+	accountRepositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_repository"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/cache"
+	companyRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	repositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	scimRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/scim"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	scimEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/scim"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/google/uuid"
+)
+
+type IController interface {
+	ListUsers(filter *scimEntities.Filter) (*scimEntities.ListResponse, error)
+	GetUser(accountID uuid.UUID) (*scimEntities.User, error)
+	CreateUser(user *scimEntities.User) (*scimEntities.User, error)
+	ReplaceUser(accountID uuid.UUID, user *scimEntities.User) (*scimEntities.User, error)
+	PatchUser(accountID uuid.UUID, patch *scimEntities.PatchRequest) (*scimEntities.User, error)
+	DeleteUser(accountID uuid.UUID) error
+	ListGroups(filter *scimEntities.Filter) (*scimEntities.ListResponse, error)
+	GetGroup(groupID uuid.UUID) (*scimEntities.Group, error)
+	CreateGroup(group *scimEntities.Group) (*scimEntities.Group, error)
+	ReplaceGroup(groupID uuid.UUID, group *scimEntities.Group) (*scimEntities.Group, error)
+	PatchGroup(groupID uuid.UUID, patch *scimEntities.PatchRequest) (*scimEntities.Group, error)
+	DeleteGroup(groupID uuid.UUID) error
+	SyncMemberships() error
+}
+
+type Controller struct {
+	scimRepo              scimRepo.IRepository
+	accountRepo           accountRepo.IAccount
+	companyRepo           companyRepo.ICompanyRepository
+	repositoryRepo        repositoryRepo.IRepository
+	accountCompanyRepo    accountCompanyRepo.IAccountCompany
+	accountRepositoryRepo accountRepositoryRepo.IAccountRepository
+	cacheRepo             cache.Interface
+	mutex                 sync.Mutex
+}
+
+func NewController(databaseRead relational.InterfaceRead, databaseWrite relational.InterfaceWrite) IController {
+	return &Controller{
+		scimRepo:              scimRepo.NewScimRepository(databaseRead, databaseWrite),
+		accountRepo:           accountRepo.NewAccountRepository(databaseRead, databaseWrite),
+		companyRepo:           companyRepo.NewCompanyRepository(databaseRead, databaseWrite),
+		repositoryRepo:        repositoryRepo.NewRepository(databaseRead, databaseWrite),
+		accountCompanyRepo:    accountCompanyRepo.NewAccountCompanyRepository(databaseRead, databaseWrite),
+		accountRepositoryRepo: accountRepositoryRepo.NewAccountRepositoryRepository(databaseRead, databaseWrite),
+		cacheRepo:             cache.NewCacheRepository(databaseRead, databaseWrite),
+	}
+}
+
+func (c *Controller) ListUsers(filter *scimEntities.Filter) (*scimEntities.ListResponse, error) {
+	accounts, total, err := c.scimRepo.ListUsers(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []*scimEntities.User{}
+	for index := range *accounts {
+		users = append(users, scimEntities.NewUser(&(*accounts)[index]))
+	}
+
+	return scimEntities.NewListResponse(users, total, len(users), filter.StartIndex), nil
+}
+
+func (c *Controller) GetUser(accountID uuid.UUID) (*scimEntities.User, error) {
+	account, err := c.getAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scimEntities.NewUser(account), nil
+}
+
+// CreateUser creates a confirmed account with a random password, the login must be done by the identity provider
+func (c *Controller) CreateUser(user *scimEntities.User) (*scimEntities.User, error) {
+	account := user.ToAccount(&authEntities.Account{Password: uuid.New().String(), IsConfirmed: true})
+	if err := c.checkUserIsUnique(account); err != nil {
+		return nil, err
+	}
+
+	if err := c.accountRepo.Create(account.SetAccountData()); err != nil {
+		return nil, err
+	}
+
+	return scimEntities.NewUser(account), nil
+}
+
+func (c *Controller) ReplaceUser(accountID uuid.UUID, user *scimEntities.User) (*scimEntities.User, error) {
+	account, err := c.getAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.updateUser(account, user)
+}
+
+func (c *Controller) PatchUser(accountID uuid.UUID, patch *scimEntities.PatchRequest) (*scimEntities.User, error) {
+	account, err := c.getAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user := scimEntities.NewUser(account)
+	if err := patch.ApplyToUser(user); err != nil {
+		return nil, err
+	}
+
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c.updateUser(account, user)
+}
+
+func (c *Controller) DeleteUser(accountID uuid.UUID) error {
+	if _, err := c.getAccount(accountID); err != nil {
+		return err
+	}
+
+	return c.accountRepo.DeleteAccount(accountID)
+}
+
+func (c *Controller) getAccount(accountID uuid.UUID) (*authEntities.Account, error) {
+	account, err := c.accountRepo.GetByAccountID(accountID)
+	if err == errors.ErrNotFoundRecords {
+		return nil, errors.ErrorScimResourceNotFound
+	}
+
+	return account, err
+}
+
+func (c *Controller) checkUserIsUnique(account *authEntities.Account) error {
+	for _, get := range []func() (*authEntities.Account, error){
+		func() (*authEntities.Account, error) { return c.accountRepo.GetByEmail(account.Email) },
+		func() (*authEntities.Account, error) { return c.accountRepo.GetByUsername(account.Username) },
+	} {
+		existing, err := get()
+		if err != nil && err != errors.ErrNotFoundRecords {
+			return err
+		}
+
+		if err == nil && existing.AccountID != account.AccountID {
+			return errors.ErrorScimUserAlreadyExists
+		}
+	}
+
+	return nil
+}
+
+// updateUser removes the account of all companies and repositories and its refresh token when it is deactivated
+func (c *Controller) updateUser(account *authEntities.Account, user *scimEntities.User) (*scimEntities.User, error) {
+	wasDisabled := account.IsDisabled
+	user.ToAccount(account)
+	if err := c.checkUserIsUnique(account); err != nil {
+		return nil, err
+	}
+
+	if err := c.accountRepo.UpdateProvisioning(account); err != nil {
+		return nil, err
+	}
+
+	if account.IsDisabled && !wasDisabled {
+		logger.LogWarnWithLevel("{AUDIT} account disabled by scim provisioning",
+			map[string]interface{}{"accountID": account.AccountID.String()})
+		if err := c.scimRepo.DeleteAccountMemberships(account.AccountID); err != nil {
+			return nil, err
+		}
+
+		if err := c.cacheRepo.Del(account.AccountID.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if account.IsDisabled != wasDisabled {
+		c.syncMembershipsAndLogError()
+	}
+
+	return scimEntities.NewUser(account), nil
+}
+
+func (c *Controller) ListGroups(filter *scimEntities.Filter) (*scimEntities.ListResponse, error) {
+	groups, total, err := c.scimRepo.ListGroups(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*scimEntities.Group{}
+	for index := range *groups {
+		members, err := c.scimRepo.ListMembers((*groups)[index].GroupID)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, scimEntities.NewGroup(&(*groups)[index], *members))
+	}
+
+	return scimEntities.NewListResponse(result, total, len(result), filter.StartIndex), nil
+}
+
+func (c *Controller) GetGroup(groupID uuid.UUID) (*scimEntities.Group, error) {
+	group, err := c.getGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := c.scimRepo.ListMembers(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scimEntities.NewGroup(group, *members), nil
+}
+
+// CreateGroup removes the group when its members can not be saved, so the identity provider can retry the creation
+func (c *Controller) CreateGroup(group *scimEntities.Group) (*scimEntities.Group, error) {
+	scimGroup := group.ToScimGroup(&authEntities.ScimGroup{}).SetCreateData()
+	if err := c.scimRepo.CreateGroup(scimGroup); err != nil {
+		return nil, c.checkGroupErrorType(err)
+	}
+
+	if err := c.scimRepo.SetMembers(scimGroup.GroupID, group.GetMemberIDs()); err != nil {
+		_ = c.scimRepo.DeleteGroup(scimGroup.GroupID)
+		return nil, err
+	}
+
+	c.syncMembershipsAndLogError()
+	return c.GetGroup(scimGroup.GroupID)
+}
+
+func (c *Controller) ReplaceGroup(groupID uuid.UUID, group *scimEntities.Group) (*scimEntities.Group, error) {
+	scimGroup, err := c.getGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.updateGroup(scimGroup, group, scimGroup.DisplayName)
+}
+
+func (c *Controller) PatchGroup(groupID uuid.UUID, patch *scimEntities.PatchRequest) (*scimEntities.Group, error) {
+	group, err := c.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousName := group.DisplayName
+	if err := patch.ApplyToGroup(group); err != nil {
+		return nil, err
+	}
+
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c.updateGroup(&authEntities.ScimGroup{GroupID: groupID}, group, previousName)
+}
+
+func (c *Controller) DeleteGroup(groupID uuid.UUID) error {
+	group, err := c.getGroup(groupID)
+	if err != nil {
+		return err
+	}
+
+	if err := c.scimRepo.DeleteGroup(groupID); err != nil {
+		if err == errors.ErrNotFoundRecords {
+			return errors.ErrorScimResourceNotFound
+		}
+
+		return err
+	}
+
+	c.syncMembershipsAndLogError(group.DisplayName)
+	return nil
+}
+
+func (c *Controller) getGroup(groupID uuid.UUID) (*authEntities.ScimGroup, error) {
+	group, err := c.scimRepo.GetGroup(groupID)
+	if err == errors.ErrNotFoundRecords {
+		return nil, errors.ErrorScimResourceNotFound
+	}
+
+	return group, err
+}
+
+// updateGroup revokes the memberships given by the previous display name when the group is renamed
+func (c *Controller) updateGroup(scimGroup *authEntities.ScimGroup, group *scimEntities.Group,
+	previousName string) (*scimEntities.Group, error) {
+	if err := c.scimRepo.UpdateGroup(group.ToScimGroup(scimGroup).SetUpdatedAt()); err != nil {
+		return nil, c.checkGroupErrorType(err)
+	}
+
+	if err := c.scimRepo.SetMembers(scimGroup.GroupID, group.GetMemberIDs()); err != nil {
+		return nil, err
+	}
+
+	if previousName != group.DisplayName {
+		c.syncMembershipsAndLogError(previousName)
+	} else {
+		c.syncMembershipsAndLogError()
+	}
+
+	return c.GetGroup(scimGroup.GroupID)
+}
+
+func (c *Controller) checkGroupErrorType(err error) error {
+	if err.Error() == errors.ErrorAlreadyExistingScimGroupName {
+		return errors.ErrorScimGroupAlreadyExists
+	}
+
+	return err
+}
+
+// syncMembershipsAndLogError does not fail the request because the provisioning was already saved
+func (c *Controller) syncMembershipsAndLogError(removedGroups ...string) {
+	if err := c.syncMemberships(removedGroups); err != nil {
+		logger.LogError(errors.ErrorScimSyncMemberships, err)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	scimEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/scim"
+	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) ListUsers(_ *scimEntities.Filter) (*scimEntities.ListResponse, error) {
+	args := m.MethodCalled("ListUsers")
+	return args.Get(0).(*scimEntities.ListResponse), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetUser(_ uuid.UUID) (*scimEntities.User, error) {
+	args := m.MethodCalled("GetUser")
+	return args.Get(0).(*scimEntities.User), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) CreateUser(_ *scimEntities.User) (*scimEntities.User, error) {
+	args := m.MethodCalled("CreateUser")
+	return args.Get(0).(*scimEntities.User), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) ReplaceUser(_ uuid.UUID, _ *scimEntities.User) (*scimEntities.User, error) {
+	args := m.MethodCalled("ReplaceUser")
+	return args.Get(0).(*scimEntities.User), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) PatchUser(_ uuid.UUID, _ *scimEntities.PatchRequest) (*scimEntities.User, error) {
+	args := m.MethodCalled("PatchUser")
+	return args.Get(0).(*scimEntities.User), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) DeleteUser(_ uuid.UUID) error {
+	args := m.MethodCalled("DeleteUser")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) ListGroups(_ *scimEntities.Filter) (*scimEntities.ListResponse, error) {
+	args := m.MethodCalled("ListGroups")
+	return args.Get(0).(*scimEntities.ListResponse), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) GetGroup(_ uuid.UUID) (*scimEntities.Group, error) {
+	args := m.MethodCalled("GetGroup")
+	return args.Get(0).(*scimEntities.Group), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) CreateGroup(_ *scimEntities.Group) (*scimEntities.Group, error) {
+	args := m.MethodCalled("CreateGroup")
+	return args.Get(0).(*scimEntities.Group), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) ReplaceGroup(_ uuid.UUID, _ *scimEntities.Group) (*scimEntities.Group, error) {
+	args := m.MethodCalled("ReplaceGroup")
+	return args.Get(0).(*scimEntities.Group), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) PatchGroup(_ uuid.UUID, _ *scimEntities.PatchRequest) (*scimEntities.Group, error) {
+	args := m.MethodCalled("PatchGroup")
+	return args.Get(0).(*scimEntities.Group), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) DeleteGroup(_ uuid.UUID) error {
+	args := m.MethodCalled("DeleteGroup")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) SyncMemberships() error {
+	args := m.MethodCalled("SyncMemberships")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
@@ -0,0 +1,432 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
+	accountCompanyRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_company"
+	accountRepositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_repository"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/cache"
+	companyRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	repositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	scimRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/scim"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	scimEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/scim"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type testContext struct {
+	controller            *Controller
+	scimMock              *scimRepo.Mock
+	accountMock           *accountRepo.Mock
+	companyMock           *companyRepo.Mock
+	repositoryMock        *repositoryRepo.Mock
+	accountCompanyMock    *accountCompanyRepo.Mock
+	accountRepositoryMock *accountRepositoryRepo.Mock
+	cacheMock             *cache.Mock
+}
+
+func newTestContext() *testContext {
+	ctx := &testContext{scimMock: &scimRepo.Mock{}, accountMock: &accountRepo.Mock{},
+		companyMock: &companyRepo.Mock{}, repositoryMock: &repositoryRepo.Mock{},
+		accountCompanyMock: &accountCompanyRepo.Mock{}, accountRepositoryMock: &accountRepositoryRepo.Mock{},
+		cacheMock: &cache.Mock{}}
+	ctx.controller = &Controller{scimRepo: ctx.scimMock, accountRepo: ctx.accountMock, companyRepo: ctx.companyMock,
+		repositoryRepo: ctx.repositoryMock, accountCompanyRepo: ctx.accountCompanyMock,
+		accountRepositoryRepo: ctx.accountRepositoryMock, cacheRepo: ctx.cacheMock}
+	ctx.scimMock.On("ListActiveMemberships").Return(&[]authEntities.ScimGroupMembership{}, nil)
+	ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{}, nil)
+	return ctx
+}
+
+func newUser() *scimEntities.User {
+	return &scimEntities.User{UserName: "test", Emails: []scimEntities.Email{{Value: "test@horusec.io"}}}
+}
+
+func TestNewController(t *testing.T) {
+	t.Run("should create a new controller", func(t *testing.T) {
+		assert.NotNil(t, NewController(&relational.MockRead{}, &relational.MockWrite{}))
+	})
+}
+
+func TestMock(t *testing.T) {
+	t.Run("should mock scim controller", func(t *testing.T) {
+		m := &Mock{}
+		m.On("ListUsers").Return(&scimEntities.ListResponse{}, nil)
+		m.On("GetUser").Return(&scimEntities.User{}, nil)
+		m.On("CreateUser").Return(&scimEntities.User{}, nil)
+		m.On("ReplaceUser").Return(&scimEntities.User{}, nil)
+		m.On("PatchUser").Return(&scimEntities.User{}, nil)
+		m.On("DeleteUser").Return(nil)
+		m.On("ListGroups").Return(&scimEntities.ListResponse{}, nil)
+		m.On("GetGroup").Return(&scimEntities.Group{}, nil)
+		m.On("CreateGroup").Return(&scimEntities.Group{}, nil)
+		m.On("ReplaceGroup").Return(&scimEntities.Group{}, nil)
+		m.On("PatchGroup").Return(&scimEntities.Group{}, nil)
+		m.On("DeleteGroup").Return(nil)
+		m.On("SyncMemberships").Return(nil)
+
+		_, _ = m.ListUsers(&scimEntities.Filter{})
+		_, _ = m.GetUser(uuid.New())
+		_, _ = m.CreateUser(&scimEntities.User{})
+		_, _ = m.ReplaceUser(uuid.New(), &scimEntities.User{})
+		_, _ = m.PatchUser(uuid.New(), &scimEntities.PatchRequest{})
+		_ = m.DeleteUser(uuid.New())
+		_, _ = m.ListGroups(&scimEntities.Filter{})
+		_, _ = m.GetGroup(uuid.New())
+		_, _ = m.CreateGroup(&scimEntities.Group{})
+		_, _ = m.ReplaceGroup(uuid.New(), &scimEntities.Group{})
+		_, _ = m.PatchGroup(uuid.New(), &scimEntities.PatchRequest{})
+		_ = m.DeleteGroup(uuid.New())
+		assert.NoError(t, m.SyncMemberships())
+	})
+}
+
+func TestListUsers(t *testing.T) {
+	t.Run("should list users as scim resources", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("ListUsers").Return(&[]authEntities.Account{{AccountID: uuid.New()}}, 3, nil)
+
+		response, err := ctx.controller.ListUsers(&scimEntities.Filter{StartIndex: 2, Count: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, response.TotalResults)
+		assert.Equal(t, 1, response.ItemsPerPage)
+		assert.Equal(t, 2, response.StartIndex)
+	})
+
+	t.Run("should return error when list fails", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("ListUsers").Return(&[]authEntities.Account{}, 0, errors.New("test"))
+
+		_, err := ctx.controller.ListUsers(&scimEntities.Filter{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetUser(t *testing.T) {
+	t.Run("should return not found when account does not exist", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.accountMock.On("GetByAccountID").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+
+		_, err := ctx.controller.GetUser(uuid.New())
+
+		assert.Equal(t, errorsEnums.ErrorScimResourceNotFound, err)
+	})
+
+	t.Run("should return the user", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.accountMock.On("GetByAccountID").Return(&authEntities.Account{Username: "test"}, nil)
+
+		user, err := ctx.controller.GetUser(uuid.New())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test", user.UserName)
+	})
+}
+
+func TestCreateUser(t *testing.T) {
+	t.Run("should create a confirmed account", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.accountMock.On("GetByEmail").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+		ctx.accountMock.On("GetByUsername").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+		ctx.accountMock.On("Create").Return(nil)
+
+		user, err := ctx.controller.CreateUser(newUser())
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, user.ID)
+		assert.True(t, user.IsActive())
+	})
+
+	t.Run("should return conflict when email already exists", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.accountMock.On("GetByEmail").Return(&authEntities.Account{AccountID: uuid.New()}, nil)
+
+		_, err := ctx.controller.CreateUser(newUser())
+
+		assert.Equal(t, errorsEnums.ErrorScimUserAlreadyExists, err)
+	})
+
+	t.Run("should return error when get by username fails", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.accountMock.On("GetByEmail").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+		ctx.accountMock.On("GetByUsername").Return(&authEntities.Account{}, errors.New("test"))
+
+		_, err := ctx.controller.CreateUser(newUser())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPatchUser(t *testing.T) {
+	t.Run("should disable the account and remove its memberships and refresh token", func(t *testing.T) {
+		ctx := newTestContext()
+		account := &authEntities.Account{AccountID: uuid.New(), Username: "test", Email: "test@horusec.io"}
+		ctx.accountMock.On("GetByAccountID").Return(account, nil)
+		ctx.accountMock.On("GetByEmail").Return(account, nil)
+		ctx.accountMock.On("GetByUsername").Return(account, nil)
+		ctx.accountMock.On("UpdateProvisioning").Return(nil)
+		ctx.scimMock.On("DeleteAccountMemberships").Return(nil)
+		ctx.cacheMock.On("Del").Return(nil)
+		patch := &scimEntities.PatchRequest{Operations: []scimEntities.PatchOperation{
+			{Op: "replace", Path: "active", Value: []byte("false")}}}
+
+		user, err := ctx.controller.PatchUser(account.AccountID, patch)
+
+		assert.NoError(t, err)
+		assert.False(t, user.IsActive())
+		assert.True(t, account.IsDisabled)
+		ctx.scimMock.AssertCalled(t, "DeleteAccountMemberships")
+		ctx.scimMock.AssertCalled(t, "ListActiveMemberships")
+		ctx.cacheMock.AssertCalled(t, "Del")
+	})
+
+	t.Run("should return error when refresh token can not be removed", func(t *testing.T) {
+		ctx := newTestContext()
+		account := &authEntities.Account{AccountID: uuid.New(), Username: "test", Email: "test@horusec.io"}
+		ctx.accountMock.On("GetByAccountID").Return(account, nil)
+		ctx.accountMock.On("GetByEmail").Return(account, nil)
+		ctx.accountMock.On("GetByUsername").Return(account, nil)
+		ctx.accountMock.On("UpdateProvisioning").Return(nil)
+		ctx.scimMock.On("DeleteAccountMemberships").Return(nil)
+		ctx.cacheMock.On("Del").Return(errors.New("test"))
+		patch := &scimEntities.PatchRequest{Operations: []scimEntities.PatchOperation{
+			{Op: "replace", Path: "active", Value: []byte("false")}}}
+
+		_, err := ctx.controller.PatchUser(account.AccountID, patch)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should not remove memberships when active does not change", func(t *testing.T) {
+		ctx := newTestContext()
+		account := &authEntities.Account{AccountID: uuid.New(), Username: "test", Email: "test@horusec.io"}
+		ctx.accountMock.On("GetByAccountID").Return(account, nil)
+		ctx.accountMock.On("GetByEmail").Return(account, nil)
+		ctx.accountMock.On("GetByUsername").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+		ctx.accountMock.On("UpdateProvisioning").Return(nil)
+		patch := &scimEntities.PatchRequest{Operations: []scimEntities.PatchOperation{
+			{Op: "replace", Path: "userName", Value: []byte(`"new"`)}}}
+
+		user, err := ctx.controller.PatchUser(account.AccountID, patch)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new", user.UserName)
+		ctx.scimMock.AssertNotCalled(t, "DeleteAccountMemberships")
+		ctx.cacheMock.AssertNotCalled(t, "Del")
+	})
+
+	t.Run("should return error when patch is invalid", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.accountMock.On("GetByAccountID").Return(&authEntities.Account{Username: "test",
+			Email: "test@horusec.io"}, nil)
+		patch := &scimEntities.PatchRequest{Operations: []scimEntities.PatchOperation{
+			{Op: "replace", Path: "userName", Value: []byte(`""`)}}}
+
+		_, err := ctx.controller.PatchUser(uuid.New(), patch)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestReplaceUser(t *testing.T) {
+	t.Run("should return error when update fails", func(t *testing.T) {
+		ctx := newTestContext()
+		account := &authEntities.Account{AccountID: uuid.New()}
+		ctx.accountMock.On("GetByAccountID").Return(account, nil)
+		ctx.accountMock.On("GetByEmail").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+		ctx.accountMock.On("GetByUsername").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+		ctx.accountMock.On("UpdateProvisioning").Return(errors.New("test"))
+
+		_, err := ctx.controller.ReplaceUser(account.AccountID, newUser())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	t.Run("should delete the account", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.accountMock.On("GetByAccountID").Return(&authEntities.Account{}, nil)
+		ctx.accountMock.On("DeleteAccount").Return(nil)
+
+		assert.NoError(t, ctx.controller.DeleteUser(uuid.New()))
+	})
+
+	t.Run("should return not found when account does not exist", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.accountMock.On("GetByAccountID").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+
+		assert.Equal(t, errorsEnums.ErrorScimResourceNotFound, ctx.controller.DeleteUser(uuid.New()))
+	})
+}
+
+func TestListGroups(t *testing.T) {
+	t.Run("should list groups with members", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("ListGroups").Return(&[]authEntities.ScimGroup{{GroupID: uuid.New()}}, 1, nil)
+		ctx.scimMock.On("ListMembers").Return(&[]authEntities.Account{{AccountID: uuid.New()}}, nil)
+
+		response, err := ctx.controller.ListGroups(&scimEntities.Filter{StartIndex: 1})
+
+		assert.NoError(t, err)
+		assert.Len(t, response.Resources.([]*scimEntities.Group)[0].Members, 1)
+	})
+
+	t.Run("should return error when list members fails", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("ListGroups").Return(&[]authEntities.ScimGroup{{GroupID: uuid.New()}}, 1, nil)
+		ctx.scimMock.On("ListMembers").Return(&[]authEntities.Account{}, errors.New("test"))
+
+		_, err := ctx.controller.ListGroups(&scimEntities.Filter{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateGroup(t *testing.T) {
+	t.Run("should create group with members and sync memberships", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("CreateGroup").Return(nil)
+		ctx.scimMock.On("SetMembers").Return(nil)
+		ctx.scimMock.On("GetGroup").Return(&authEntities.ScimGroup{DisplayName: "test"}, nil)
+		ctx.scimMock.On("ListMembers").Return(&[]authEntities.Account{}, nil)
+
+		group, err := ctx.controller.CreateGroup(&scimEntities.Group{DisplayName: "test"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test", group.DisplayName)
+		ctx.scimMock.AssertCalled(t, "ListActiveMemberships")
+	})
+
+	t.Run("should return conflict when display name already exists", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("CreateGroup").Return(errors.New(errorsEnums.ErrorAlreadyExistingScimGroupName))
+
+		_, err := ctx.controller.CreateGroup(&scimEntities.Group{DisplayName: "test"})
+
+		assert.Equal(t, errorsEnums.ErrorScimGroupAlreadyExists, err)
+	})
+
+	t.Run("should remove the group when members are invalid", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("CreateGroup").Return(nil)
+		ctx.scimMock.On("SetMembers").Return(errorsEnums.ErrorScimInvalidMember)
+		ctx.scimMock.On("DeleteGroup").Return(nil)
+
+		_, err := ctx.controller.CreateGroup(&scimEntities.Group{DisplayName: "test"})
+
+		assert.Equal(t, errorsEnums.ErrorScimInvalidMember, err)
+		ctx.scimMock.AssertCalled(t, "DeleteGroup")
+	})
+}
+
+func TestPatchGroup(t *testing.T) {
+	t.Run("should add members to the group", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("GetGroup").Return(&authEntities.ScimGroup{DisplayName: "test"}, nil)
+		ctx.scimMock.On("ListMembers").Return(&[]authEntities.Account{}, nil)
+		ctx.scimMock.On("UpdateGroup").Return(nil)
+		ctx.scimMock.On("SetMembers").Return(nil)
+		patch := &scimEntities.PatchRequest{Operations: []scimEntities.PatchOperation{
+			{Op: "add", Path: "members", Value: []byte(`[{"value": "` + uuid.New().String() + `"}]`)}}}
+
+		_, err := ctx.controller.PatchGroup(uuid.New(), patch)
+
+		assert.NoError(t, err)
+		ctx.scimMock.AssertCalled(t, "SetMembers")
+	})
+
+	t.Run("should return not found when group does not exist", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("GetGroup").Return(&authEntities.ScimGroup{}, errorsEnums.ErrNotFoundRecords)
+
+		_, err := ctx.controller.PatchGroup(uuid.New(), &scimEntities.PatchRequest{})
+
+		assert.Equal(t, errorsEnums.ErrorScimResourceNotFound, err)
+	})
+
+	t.Run("should return error when member is not an uuid", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("GetGroup").Return(&authEntities.ScimGroup{DisplayName: "test"}, nil)
+		ctx.scimMock.On("ListMembers").Return(&[]authEntities.Account{}, nil)
+		patch := &scimEntities.PatchRequest{Operations: []scimEntities.PatchOperation{
+			{Op: "add", Path: "members", Value: []byte(`[{"value": "test"}]`)}}}
+
+		_, err := ctx.controller.PatchGroup(uuid.New(), patch)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestReplaceGroup(t *testing.T) {
+	t.Run("should return error when update fails", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("GetGroup").Return(&authEntities.ScimGroup{}, nil)
+		ctx.scimMock.On("UpdateGroup").Return(errors.New("test"))
+
+		_, err := ctx.controller.ReplaceGroup(uuid.New(), &scimEntities.Group{DisplayName: "test"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteGroup(t *testing.T) {
+	t.Run("should delete group and sync memberships", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("GetGroup").Return(&authEntities.ScimGroup{DisplayName: "test"}, nil)
+		ctx.scimMock.On("DeleteGroup").Return(nil)
+
+		assert.NoError(t, ctx.controller.DeleteGroup(uuid.New()))
+		ctx.scimMock.AssertCalled(t, "ListActiveMemberships")
+	})
+
+	t.Run("should revoke the memberships given by the deleted group", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("GetGroup").Return(&authEntities.ScimGroup{DisplayName: "devs"}, nil)
+		ctx.scimMock.On("DeleteGroup").Return(nil)
+		companyID := uuid.New()
+		ctx.companyMock.ExpectedCalls = nil
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{
+			{CompanyID: companyID, AuthzMember: []string{"devs"}}}, nil)
+		ctx.companyMock.On("GetAllAccountsInCompany").Return(&[]roles.AccountRole{
+			{AccountID: uuid.New(), Role: "member"}}, nil)
+		ctx.repositoryMock.On("ListAllInCompany").Return(&[]accountEntities.Repository{}, nil)
+		ctx.accountCompanyMock.On("DeleteAccountCompany").Return(nil)
+		ctx.accountRepositoryMock.On("DeleteFromAllRepositories").Return(nil)
+
+		assert.NoError(t, ctx.controller.DeleteGroup(uuid.New()))
+		ctx.accountCompanyMock.AssertNumberOfCalls(t, "DeleteAccountCompany", 1)
+		ctx.accountRepositoryMock.AssertNumberOfCalls(t, "DeleteFromAllRepositories", 1)
+	})
+
+	t.Run("should return not found when group does not exist", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.On("GetGroup").Return(&authEntities.ScimGroup{}, errorsEnums.ErrNotFoundRecords)
+
+		assert.Equal(t, errorsEnums.ErrorScimResourceNotFound, ctx.controller.DeleteGroup(uuid.New()))
+		ctx.scimMock.AssertNotCalled(t, "DeleteGroup")
+	})
+}
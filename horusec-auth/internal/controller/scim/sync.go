@@ -0,0 +1,220 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"strings"
+
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	accountEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/account"
+	"github.com/google/uuid"
+)
+
+var roleRank = map[accountEnums.Role]int{accountEnums.Member: 1, accountEnums.Supervisor: 2, accountEnums.Admin: 3}
+
+// SyncMemberships reconciles the account_company and account_repository rows with the active members of the scim
+// groups named in each company and repository. Entities without any existing scim group are skipped.
+func (c *Controller) SyncMemberships() error {
+	return c.syncMemberships(nil)
+}
+
+// syncMemberships handles the removed groups as existing groups without members, so the entities that named only
+// deleted or renamed groups have their memberships revoked
+func (c *Controller) syncMemberships(removedGroups []string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	members, err := c.getGroupMembers(removedGroups)
+	if err != nil {
+		return err
+	}
+
+	companies, err := c.companyRepo.ListAll()
+	if err != nil {
+		return err
+	}
+
+	for index := range *companies {
+		if err := c.syncCompany(members, &(*companies)[index]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) getGroupMembers(removedGroups []string) (map[string][]uuid.UUID, error) {
+	memberships, err := c.scimRepo.ListActiveMemberships()
+	if err != nil {
+		return nil, err
+	}
+
+	members := map[string][]uuid.UUID{}
+	for _, group := range removedGroups {
+		members[group] = []uuid.UUID{}
+	}
+
+	for _, membership := range *memberships {
+		if _, ok := members[membership.DisplayName]; !ok {
+			members[membership.DisplayName] = []uuid.UUID{}
+		}
+
+		if membership.AccountID != uuid.Nil {
+			members[membership.DisplayName] = append(members[membership.DisplayName], membership.AccountID)
+		}
+	}
+
+	return members, nil
+}
+
+func (c *Controller) syncCompany(members map[string][]uuid.UUID, company *accountEntities.Company) error {
+	desired := c.getDesiredRoles(members, map[accountEnums.Role][]string{
+		accountEnums.Admin: company.AuthzAdmin, accountEnums.Member: company.AuthzMember})
+	if desired != nil {
+		current, err := c.companyRepo.GetAllAccountsInCompany(company.CompanyID)
+		if err != nil {
+			return err
+		}
+
+		if err := c.syncCompanyRoles(company.CompanyID, desired, current); err != nil {
+			return err
+		}
+	}
+
+	return c.syncRepositories(members, company.CompanyID)
+}
+
+func (c *Controller) syncCompanyRoles(companyID uuid.UUID, desired map[uuid.UUID]accountEnums.Role,
+	current *[]roles.AccountRole) error {
+	for _, account := range *current {
+		role, ok := desired[account.AccountID]
+		delete(desired, account.AccountID)
+		if err := c.applyCompanyRole(companyID, &account, role, ok); err != nil {
+			return err
+		}
+	}
+
+	for accountID, role := range desired {
+		if err := c.accountCompanyRepo.CreateAccountCompany(companyID, accountID, role, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) applyCompanyRole(companyID uuid.UUID, account *roles.AccountRole, role accountEnums.Role,
+	isDesired bool) error {
+	if !isDesired {
+		if err := c.accountRepositoryRepo.DeleteFromAllRepositories(account.AccountID, companyID); err != nil {
+			return err
+		}
+
+		return c.accountCompanyRepo.DeleteAccountCompany(account.AccountID, companyID)
+	}
+
+	if role == accountEnums.Role(account.Role) {
+		return nil
+	}
+
+	return c.accountCompanyRepo.UpdateAccountCompany(&roles.AccountCompany{
+		CompanyID: companyID, AccountID: account.AccountID, Role: role})
+}
+
+func (c *Controller) syncRepositories(members map[string][]uuid.UUID, companyID uuid.UUID) error {
+	repositories, err := c.repositoryRepo.ListAllInCompany(companyID)
+	if err != nil {
+		return err
+	}
+
+	for index := range *repositories {
+		if err := c.syncRepository(members, &(*repositories)[index]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) syncRepository(members map[string][]uuid.UUID, repository *accountEntities.Repository) error {
+	desired := c.getDesiredRoles(members, map[accountEnums.Role][]string{accountEnums.Admin: repository.AuthzAdmin,
+		accountEnums.Supervisor: repository.AuthzSupervisor, accountEnums.Member: repository.AuthzMember})
+	if desired == nil {
+		return nil
+	}
+
+	current, err := c.repositoryRepo.GetAllAccountsInRepository(repository.RepositoryID)
+	if err != nil {
+		return err
+	}
+
+	for _, account := range *current {
+		role, ok := desired[account.AccountID]
+		delete(desired, account.AccountID)
+		if err := c.applyRepositoryRole(repository, &account, role, ok); err != nil {
+			return err
+		}
+	}
+
+	for accountID, role := range desired {
+		if err := c.accountRepositoryRepo.Create(&roles.AccountRepository{RepositoryID: repository.RepositoryID,
+			AccountID: accountID, CompanyID: repository.CompanyID, Role: role}, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) applyRepositoryRole(repository *accountEntities.Repository, account *roles.AccountRole,
+	role accountEnums.Role, isDesired bool) error {
+	if !isDesired {
+		return c.accountRepositoryRepo.DeleteAccountRepository(account.AccountID, repository.RepositoryID)
+	}
+
+	if role == accountEnums.Role(account.Role) {
+		return nil
+	}
+
+	return c.accountRepositoryRepo.UpdateAccountRepository(&roles.AccountRepository{
+		RepositoryID: repository.RepositoryID, AccountID: account.AccountID, CompanyID: repository.CompanyID, Role: role})
+}
+
+// getDesiredRoles returns the role of each account by the scim groups, keeping the highest role when the account is
+// in more than one group. Returns nil when none of the groups exists.
+func (c *Controller) getDesiredRoles(members map[string][]uuid.UUID,
+	groupsByRole map[accountEnums.Role][]string) (desired map[uuid.UUID]accountEnums.Role) {
+	for role, groups := range groupsByRole {
+		for _, group := range groups {
+			accountIDs, ok := members[strings.TrimSpace(group)]
+			if !ok {
+				continue
+			}
+
+			if desired == nil {
+				desired = map[uuid.UUID]accountEnums.Role{}
+			}
+
+			for _, accountID := range accountIDs {
+				if roleRank[role] > roleRank[desired[accountID]] {
+					desired[accountID] = role
+				}
+			}
+		}
+	}
+
+	return desired
+}
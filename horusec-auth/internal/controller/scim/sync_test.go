@@ -0,0 +1,133 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"errors"
+	"testing"
+
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncMemberships(t *testing.T) {
+	t.Run("should reconcile company and repository roles with scim groups", func(t *testing.T) {
+		ctx := newTestContext()
+		john, mary, paul, old := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+		ctx.scimMock.ExpectedCalls = nil
+		ctx.scimMock.On("ListActiveMemberships").Return(&[]authEntities.ScimGroupMembership{
+			{DisplayName: "admins", AccountID: john}, {DisplayName: "devs", AccountID: john},
+			{DisplayName: "devs", AccountID: mary}, {DisplayName: "devs", AccountID: paul},
+			{DisplayName: "leads", AccountID: uuid.Nil}}, nil)
+		company := accountEntities.Company{CompanyID: uuid.New(), AuthzAdmin: []string{"admins"},
+			AuthzMember: []string{"devs"}}
+		repository := accountEntities.Repository{RepositoryID: uuid.New(), CompanyID: company.CompanyID,
+			AuthzSupervisor: []string{" leads "}}
+		ctx.companyMock.ExpectedCalls = nil
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{company,
+			{CompanyID: uuid.New(), AuthzAdmin: []string{"ldap-group"}}}, nil)
+		ctx.companyMock.On("GetAllAccountsInCompany").Return(&[]roles.AccountRole{
+			{AccountID: mary, Role: "admin"}, {AccountID: old, Role: "member"}, {AccountID: john, Role: "admin"}}, nil)
+		ctx.repositoryMock.On("ListAllInCompany").Return(&[]accountEntities.Repository{repository}, nil).Once()
+		ctx.repositoryMock.On("ListAllInCompany").Return(&[]accountEntities.Repository{}, nil)
+		ctx.repositoryMock.On("GetAllAccountsInRepository").Return(&[]roles.AccountRole{
+			{AccountID: mary, Role: "member"}}, nil)
+		ctx.accountCompanyMock.On("CreateAccountCompany").Return(nil)
+		ctx.accountCompanyMock.On("UpdateAccountCompany").Return(nil)
+		ctx.accountCompanyMock.On("DeleteAccountCompany").Return(nil)
+		ctx.accountRepositoryMock.On("DeleteFromAllRepositories").Return(nil)
+		ctx.accountRepositoryMock.On("DeleteAccountRepository").Return(nil)
+
+		assert.NoError(t, ctx.controller.SyncMemberships())
+
+		ctx.accountCompanyMock.AssertNumberOfCalls(t, "CreateAccountCompany", 1)
+		ctx.accountCompanyMock.AssertNumberOfCalls(t, "UpdateAccountCompany", 1)
+		ctx.accountCompanyMock.AssertNumberOfCalls(t, "DeleteAccountCompany", 1)
+		ctx.accountRepositoryMock.AssertNumberOfCalls(t, "DeleteFromAllRepositories", 1)
+		ctx.accountRepositoryMock.AssertNumberOfCalls(t, "DeleteAccountRepository", 1)
+		ctx.companyMock.AssertNumberOfCalls(t, "GetAllAccountsInCompany", 1)
+	})
+
+	t.Run("should not change memberships of entities without scim group", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.companyMock.ExpectedCalls = nil
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{
+			{CompanyID: uuid.New(), AuthzAdmin: []string{"ldap-group"}}}, nil)
+		ctx.repositoryMock.On("ListAllInCompany").Return(&[]accountEntities.Repository{
+			{RepositoryID: uuid.New(), AuthzMember: []string{"ldap-group"}}}, nil)
+
+		assert.NoError(t, ctx.controller.SyncMemberships())
+		ctx.companyMock.AssertNotCalled(t, "GetAllAccountsInCompany")
+		ctx.repositoryMock.AssertNotCalled(t, "GetAllAccountsInRepository")
+	})
+
+	t.Run("should revoke memberships of removed groups when there is no scim group", func(t *testing.T) {
+		ctx := newTestContext()
+		companyID := uuid.New()
+		ctx.companyMock.ExpectedCalls = nil
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{
+			{CompanyID: companyID, AuthzAdmin: []string{"admins"}}}, nil)
+		ctx.companyMock.On("GetAllAccountsInCompany").Return(&[]roles.AccountRole{
+			{AccountID: uuid.New(), Role: "admin"}}, nil)
+		ctx.repositoryMock.On("ListAllInCompany").Return(&[]accountEntities.Repository{
+			{RepositoryID: uuid.New(), CompanyID: companyID, AuthzAdmin: []string{"admins"}}}, nil)
+		ctx.repositoryMock.On("GetAllAccountsInRepository").Return(&[]roles.AccountRole{
+			{AccountID: uuid.New(), Role: "admin"}}, nil)
+		ctx.accountCompanyMock.On("DeleteAccountCompany").Return(nil)
+		ctx.accountRepositoryMock.On("DeleteFromAllRepositories").Return(nil)
+		ctx.accountRepositoryMock.On("DeleteAccountRepository").Return(nil)
+
+		assert.NoError(t, ctx.controller.syncMemberships([]string{"admins"}))
+		ctx.accountCompanyMock.AssertNumberOfCalls(t, "DeleteAccountCompany", 1)
+		ctx.accountRepositoryMock.AssertNumberOfCalls(t, "DeleteFromAllRepositories", 1)
+		ctx.accountRepositoryMock.AssertNumberOfCalls(t, "DeleteAccountRepository", 1)
+	})
+
+	t.Run("should return error and keep the company role when remove the repository roles fails", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.companyMock.ExpectedCalls = nil
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{
+			{CompanyID: uuid.New(), AuthzAdmin: []string{"admins"}}}, nil)
+		ctx.companyMock.On("GetAllAccountsInCompany").Return(&[]roles.AccountRole{
+			{AccountID: uuid.New(), Role: "admin"}}, nil)
+		ctx.accountRepositoryMock.On("DeleteFromAllRepositories").Return(errors.New("test"))
+		ctx.accountCompanyMock.On("DeleteAccountCompany").Return(nil)
+
+		assert.Error(t, ctx.controller.syncMemberships([]string{"admins"}))
+		ctx.accountCompanyMock.AssertNotCalled(t, "DeleteAccountCompany")
+	})
+
+	t.Run("should return error when list companies fails", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.ExpectedCalls = nil
+		ctx.scimMock.On("ListActiveMemberships").Return(&[]authEntities.ScimGroupMembership{
+			{DisplayName: "devs"}}, nil)
+		ctx.companyMock.ExpectedCalls = nil
+		ctx.companyMock.On("ListAll").Return(&[]accountEntities.Company{}, errors.New("test"))
+
+		assert.Error(t, ctx.controller.SyncMemberships())
+	})
+
+	t.Run("should return error when list memberships fails", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.scimMock.ExpectedCalls = nil
+		ctx.scimMock.On("ListActiveMemberships").Return(&[]authEntities.ScimGroupMembership{}, errors.New("test"))
+
+		assert.Error(t, ctx.controller.SyncMemberships())
+	})
+}
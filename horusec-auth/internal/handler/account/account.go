@@ -23,11 +23,13 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/jwt"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
 	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	"github.com/ZupIT/horusec/horusec-auth/config/app"
 	accountController "github.com/ZupIT/horusec/horusec-auth/internal/controller/account"
+	"github.com/ZupIT/horusec/horusec-auth/internal/services/lockout"
 	authUseCases "github.com/ZupIT/horusec/horusec-auth/internal/usecases/auth"
 	"github.com/go-chi/chi"
 	"github.com/google/uuid"
@@ -38,6 +40,7 @@ import (
 type Handler struct {
 	controller accountController.IAccount
 	useCases   authUseCases.IUseCases
+	lockout    lockout.IService
 }
 
 func NewHandler(broker brokerLib.IBroker, databaseRead SQL.InterfaceRead,
@@ -45,6 +48,7 @@ func NewHandler(broker brokerLib.IBroker, databaseRead SQL.InterfaceRead,
 	return &Handler{
 		controller: accountController.NewAccountController(broker, databaseRead, databaseWrite, cache, appConfig),
 		useCases:   authUseCases.NewAuthUseCases(),
+		lockout:    lockout.NewService(broker, databaseRead, databaseWrite, appConfig),
 	}
 }
 
@@ -423,3 +427,248 @@ func (h *Handler) getAccountUpdateData(w http.ResponseWriter, r *http.Request) (
 
 	return data, nil
 }
+
+// @Tags Account
+// @Description start the two factor enrollment returning the secret to register in the authenticator app!
+// @ID enroll-two-factor
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} http.Response{content=dto.TwoFactorEnrollment} "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 401 {object} http.Response{content=string} "UNAUTHORIZED"
+// @Failure 409 {object} http.Response{content=string} "CONFLICT"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /auth/account/two-factor/enroll [post]
+// @Security ApiKeyAuth
+func (h *Handler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	accountID, err := h.controller.GetAccountID(r.Header.Get("X-Horusec-Authorization"))
+	if err != nil || accountID == uuid.Nil {
+		httpUtil.StatusUnauthorized(w, errors.ErrorDoNotHavePermissionToThisAction)
+		return
+	}
+
+	enrollment, err := h.controller.EnrollTwoFactor(accountID)
+	if err != nil {
+		h.checkTwoFactorErrors(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, enrollment)
+}
+
+// @Tags Account
+// @Description confirm the two factor enrollment with a code of the authenticator app returning the recovery codes!
+// @ID enable-two-factor
+// @Accept  json
+// @Produce  json
+// @Param TwoFactorCode body dto.TwoFactorCode true "authenticator app code"
+// @Success 200 {object} http.Response{content=dto.TwoFactorRecoveryCodes} "OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 401 {object} http.Response{content=string} "UNAUTHORIZED"
+// @Failure 409 {object} http.Response{content=string} "CONFLICT"
+// @Failure 429 {object} http.Response{content=string} "TOO MANY REQUESTS"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /auth/account/two-factor/enable [post]
+// @Security ApiKeyAuth
+func (h *Handler) EnableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	accountID, twoFactorCode, err := h.getTwoFactorData(w, r)
+	if err != nil {
+		return
+	}
+
+	var recoveryCodes *dto.TwoFactorRecoveryCodes
+	if err := h.checkTwoFactorAttempts(w, r, func() (err error) {
+		recoveryCodes, err = h.controller.EnableTwoFactor(accountID, twoFactorCode.Code)
+		return err
+	}); err != nil {
+		return
+	}
+
+	httpUtil.StatusOK(w, recoveryCodes)
+}
+
+// @Tags Account
+// @Description disable the two factor authentication with a code of the authenticator app or a recovery code!
+// @ID disable-two-factor
+// @Accept  json
+// @Produce  json
+// @Param TwoFactorCode body dto.TwoFactorCode true "authenticator app or recovery code"
+// @Success 204 {object} http.Response{content=string} "NO CONTENT"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 401 {object} http.Response{content=string} "UNAUTHORIZED"
+// @Failure 409 {object} http.Response{content=string} "CONFLICT"
+// @Failure 429 {object} http.Response{content=string} "TOO MANY REQUESTS"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /auth/account/two-factor/disable [post]
+// @Security ApiKeyAuth
+func (h *Handler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	accountID, twoFactorCode, err := h.getTwoFactorData(w, r)
+	if err != nil {
+		return
+	}
+
+	if err := h.checkTwoFactorAttempts(w, r, func() error {
+		return h.controller.DisableTwoFactor(accountID, twoFactorCode.Code)
+	}); err != nil {
+		return
+	}
+
+	httpUtil.StatusNoContent(w)
+}
+
+func (h *Handler) getTwoFactorData(w http.ResponseWriter, r *http.Request) (uuid.UUID, *dto.TwoFactorCode, error) {
+	accountID, err := h.controller.GetAccountID(r.Header.Get("X-Horusec-Authorization"))
+	if err != nil || accountID == uuid.Nil {
+		httpUtil.StatusUnauthorized(w, errors.ErrorDoNotHavePermissionToThisAction)
+		return uuid.Nil, nil, errors.ErrorDoNotHavePermissionToThisAction
+	}
+
+	twoFactorCode, err := h.useCases.NewTwoFactorCodeFromReadCloser(r.Body)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return uuid.Nil, nil, err
+	}
+
+	return accountID, twoFactorCode, nil
+}
+
+// checkTwoFactorAttempts counts the invalid codes in the login lockout of the account email, so the codes can not be
+// guessed on these routes while the login is locked
+func (h *Handler) checkTwoFactorAttempts(w http.ResponseWriter, r *http.Request, useCode func() error) error {
+	claims, err := jwt.DecodeToken(r.Header.Get("X-Horusec-Authorization"))
+	if err != nil {
+		httpUtil.StatusUnauthorized(w, errors.ErrorDoNotHavePermissionToThisAction)
+		return err
+	}
+
+	ip := h.lockout.GetClientIP(r)
+	if retryAfter, err := h.lockout.Check(claims.Email, ip); err != nil {
+		httpUtil.StatusTooManyRequests(w, err, retryAfter)
+		return err
+	}
+
+	if err := useCode(); err != nil {
+		if err == errors.ErrorInvalidTwoFactorCode {
+			h.lockout.RegisterFailure(claims.Email, ip)
+		}
+
+		h.checkTwoFactorErrors(w, err)
+		return err
+	}
+
+	return nil
+}
+
+func (h *Handler) checkTwoFactorErrors(w http.ResponseWriter, err error) {
+	switch err {
+	case errors.ErrorInvalidAuthType, errors.ErrorInvalidTwoFactorCode:
+		httpUtil.StatusBadRequest(w, err)
+	case errors.ErrorTwoFactorAlreadyEnabled, errors.ErrorTwoFactorNotEnrolled, errors.ErrorTwoFactorNotEnabled:
+		httpUtil.StatusConflict(w, err)
+	case errors.ErrNotFoundRecords:
+		httpUtil.StatusNotFound(w, err)
+	default:
+		httpUtil.StatusInternalServerError(w, err)
+	}
+}
+
+// @Tags Account
+// @Description create a personal access token to call the horusec apis from scripts, the token is returned only once!
+// @ID create-personal-token
+// @Accept  json
+// @Produce  json
+// @Param CreatePersonalToken body dto.CreatePersonalToken true "personal token description, scopes and expiration"
+// @Success 201 {object} http.Response{content=dto.PersonalTokenCreated} "CREATED"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 401 {object} http.Response{content=string} "UNAUTHORIZED"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /auth/account/personal-tokens [post]
+// @Security ApiKeyAuth
+func (h *Handler) CreatePersonalToken(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("X-Horusec-Authorization")
+	accountID, err := h.controller.GetAccountID(token)
+	if err != nil || accountID == uuid.Nil {
+		httpUtil.StatusUnauthorized(w, errors.ErrorDoNotHavePermissionToThisAction)
+		return
+	}
+
+	personalToken, err := h.useCases.NewPersonalTokenFromReadCloser(r.Body)
+	if err != nil {
+		httpUtil.StatusBadRequest(w, err)
+		return
+	}
+
+	result, err := h.controller.CreatePersonalToken(accountID, token, personalToken)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusCreated(w, result)
+}
+
+// @Tags Account
+// @Description list the personal access tokens of the account!
+// @ID list-personal-tokens
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} http.Response{content=[]auth.PersonalToken} "OK"
+// @Failure 401 {object} http.Response{content=string} "UNAUTHORIZED"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /auth/account/personal-tokens [get]
+// @Security ApiKeyAuth
+func (h *Handler) ListPersonalTokens(w http.ResponseWriter, r *http.Request) {
+	accountID, err := h.controller.GetAccountID(r.Header.Get("X-Horusec-Authorization"))
+	if err != nil || accountID == uuid.Nil {
+		httpUtil.StatusUnauthorized(w, errors.ErrorDoNotHavePermissionToThisAction)
+		return
+	}
+
+	personalTokens, err := h.controller.ListPersonalTokens(accountID)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, personalTokens)
+}
+
+// @Tags Account
+// @Description revoke a personal access token of the account!
+// @ID revoke-personal-token
+// @Accept  json
+// @Produce  json
+// @Param personalTokenID path string true "personalTokenID of the token"
+// @Success 204 {object} http.Response{content=string} "NO CONTENT"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 401 {object} http.Response{content=string} "UNAUTHORIZED"
+// @Failure 404 {object} http.Response{content=string} "NOT FOUND"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /auth/account/personal-tokens/{personalTokenID} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) RevokePersonalToken(w http.ResponseWriter, r *http.Request) {
+	accountID, err := h.controller.GetAccountID(r.Header.Get("X-Horusec-Authorization"))
+	if err != nil || accountID == uuid.Nil {
+		httpUtil.StatusUnauthorized(w, errors.ErrorDoNotHavePermissionToThisAction)
+		return
+	}
+
+	personalTokenID, err := uuid.Parse(chi.URLParam(r, "personalTokenID"))
+	if err != nil {
+		httpUtil.StatusBadRequest(w, errors.ErrorInvalidPersonalTokenID)
+		return
+	}
+
+	h.checkRevokePersonalTokenErrors(w, h.controller.RevokePersonalToken(personalTokenID, accountID))
+}
+
+func (h *Handler) checkRevokePersonalTokenErrors(w http.ResponseWriter, err error) {
+	switch err {
+	case nil:
+		httpUtil.StatusNoContent(w)
+	case errors.ErrNotFoundRecords:
+		httpUtil.StatusNotFound(w, err)
+	default:
+		httpUtil.StatusInternalServerError(w, err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/cache"
@@ -35,6 +36,7 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
 	"github.com/ZupIT/horusec/horusec-auth/config/app"
 	accountController "github.com/ZupIT/horusec/horusec-auth/internal/controller/account"
+	"github.com/ZupIT/horusec/horusec-auth/internal/services/lockout"
 	authUseCases "github.com/ZupIT/horusec/horusec-auth/internal/usecases/auth"
 	"github.com/go-chi/chi"
 	"github.com/google/uuid"
@@ -44,7 +46,7 @@ import (
 
 func TestOptions(t *testing.T) {
 	t.Run("should return status code 204 when options", func(t *testing.T) {
-		handler := NewHandler(nil, nil, nil, nil, nil)
+		handler := NewHandler(nil, nil, nil, nil, &app.Config{})
 
 		r, _ := http.NewRequest(http.MethodOptions, "api/account", nil)
 		w := httptest.NewRecorder()
@@ -1070,3 +1072,268 @@ func TestUpdateAccount(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 }
+
+func TestTwoFactor(t *testing.T) {
+	newLockoutMock := func() *lockout.Mock {
+		lockoutMock := &lockout.Mock{}
+		lockoutMock.On("Check").Return(time.Duration(0), nil)
+		lockoutMock.On("RegisterFailure")
+		lockoutMock.On("GetClientIP").Return("127.0.0.1")
+		return lockoutMock
+	}
+
+	newHandler := func(controllerMock *accountController.Mock) *Handler {
+		return &Handler{controller: controllerMock, useCases: authUseCases.NewAuthUseCases(), lockout: newLockoutMock()}
+	}
+
+	token, _, _ := jwt.CreateToken(&authEntities.Account{AccountID: uuid.New(), Email: "test@horusec.io",
+		Username: "test"}, nil)
+	newRequest := func(body string) *http.Request {
+		r, _ := http.NewRequest(http.MethodPost, "api/account/two-factor", bytes.NewReader([]byte(body)))
+		r.Header.Add("X-Horusec-Authorization", "Bearer "+token)
+		return r
+	}
+
+	t.Run("should return 200 when enroll two factor", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("EnrollTwoFactor").Return(&dto.TwoFactorEnrollment{Secret: "secret"}, nil)
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).EnrollTwoFactor(w, newRequest(""))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 409 when enroll already enabled two factor", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("EnrollTwoFactor").Return(&dto.TwoFactorEnrollment{}, errorsEnum.ErrorTwoFactorAlreadyEnabled)
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).EnrollTwoFactor(w, newRequest(""))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("should return 401 when invalid token", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.Nil, errors.New("test"))
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).EnrollTwoFactor(w, newRequest(""))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = httptest.NewRecorder()
+		newHandler(controllerMock).EnableTwoFactor(w, newRequest(`{"code": "123456"}`))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("should return 200 when enable two factor", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("EnableTwoFactor").Return(
+			&dto.TwoFactorRecoveryCodes{RecoveryCodes: []string{"aaaaa-bbbbb"}}, nil)
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).EnableTwoFactor(w, newRequest(`{"code": "123456"}`))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 400 when enable with invalid body or code", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("EnableTwoFactor").Return(&dto.TwoFactorRecoveryCodes{}, errorsEnum.ErrorInvalidTwoFactorCode)
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).EnableTwoFactor(w, newRequest("invalid"))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = httptest.NewRecorder()
+		newHandler(controllerMock).EnableTwoFactor(w, newRequest(`{"code": "123456"}`))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 204 when disable two factor", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("DisableTwoFactor").Return(nil)
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).DisableTwoFactor(w, newRequest(`{"code": "aaaaa-bbbbb"}`))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("should return 500 when disable two factor fails", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("DisableTwoFactor").Return(errors.New("test"))
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).DisableTwoFactor(w, newRequest(`{"code": "aaaaa-bbbbb"}`))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("should register lockout failure when two factor code is invalid", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("DisableTwoFactor").Return(errorsEnum.ErrorInvalidTwoFactorCode)
+		handler := newHandler(controllerMock)
+
+		w := httptest.NewRecorder()
+		handler.DisableTwoFactor(w, newRequest(`{"code": "000000"}`))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		handler.lockout.(*lockout.Mock).AssertCalled(t, "RegisterFailure")
+	})
+
+	t.Run("should return 429 and not check the code when the account is locked", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		lockoutMock := &lockout.Mock{}
+		lockoutMock.On("GetClientIP").Return("127.0.0.1")
+		lockoutMock.On("Check").Return(time.Minute, errorsEnum.ErrorAccountLocked)
+		handler := newHandler(controllerMock)
+		handler.lockout = lockoutMock
+
+		w := httptest.NewRecorder()
+		handler.EnableTwoFactor(w, newRequest(`{"code": "123456"}`))
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		controllerMock.AssertNotCalled(t, "EnableTwoFactor")
+	})
+}
+
+func TestPersonalTokens(t *testing.T) {
+	newHandler := func(controllerMock *accountController.Mock) *Handler {
+		return &Handler{controller: controllerMock, useCases: authUseCases.NewAuthUseCases()}
+	}
+
+	newRequest := func(method, body, personalTokenID string) *http.Request {
+		r, _ := http.NewRequest(method, "api/account/personal-tokens", bytes.NewReader([]byte(body)))
+		r.Header.Add("X-Horusec-Authorization", "Bearer token")
+		ctx := chi.NewRouteContext()
+		ctx.URLParams.Add("personalTokenID", personalTokenID)
+		return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+	}
+
+	t.Run("should return 201 when create personal token", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("CreatePersonalToken").Return(&dto.PersonalTokenCreated{Token: "hpat_test"}, nil)
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).CreatePersonalToken(w, newRequest(http.MethodPost,
+			`{"description": "ci", "scopes": ["read:analytics"]}`, ""))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("should return 400 when create with invalid scope", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).CreatePersonalToken(w, newRequest(http.MethodPost,
+			`{"description": "ci", "scopes": ["write:all"]}`, ""))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 500 when create personal token fails", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("CreatePersonalToken").Return(&dto.PersonalTokenCreated{}, errors.New("test"))
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).CreatePersonalToken(w, newRequest(http.MethodPost,
+			`{"description": "ci", "scopes": ["read:analytics"]}`, ""))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("should return 401 when invalid token", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.Nil, errors.New("test"))
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).CreatePersonalToken(w, newRequest(http.MethodPost, "", ""))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = httptest.NewRecorder()
+		newHandler(controllerMock).ListPersonalTokens(w, newRequest(http.MethodGet, "", ""))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = httptest.NewRecorder()
+		newHandler(controllerMock).RevokePersonalToken(w, newRequest(http.MethodDelete, "", uuid.New().String()))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("should return 200 when list personal tokens", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("ListPersonalTokens").Return(&[]authEntities.PersonalToken{}, nil)
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).ListPersonalTokens(w, newRequest(http.MethodGet, "", ""))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 500 when list personal tokens fails", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("ListPersonalTokens").Return(&[]authEntities.PersonalToken{}, errors.New("test"))
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).ListPersonalTokens(w, newRequest(http.MethodGet, "", ""))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("should return 204 when revoke personal token", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("RevokePersonalToken").Return(nil)
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).RevokePersonalToken(w, newRequest(http.MethodDelete, "", uuid.New().String()))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("should return 404 when revoke not found personal token", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("RevokePersonalToken").Return(errorsEnum.ErrNotFoundRecords)
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).RevokePersonalToken(w, newRequest(http.MethodDelete, "", uuid.New().String()))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should return 400 when invalid personal token id", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).RevokePersonalToken(w, newRequest(http.MethodDelete, "", "invalid"))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 500 when revoke personal token fails", func(t *testing.T) {
+		controllerMock := &accountController.Mock{}
+		controllerMock.On("GetAccountID").Return(uuid.New(), nil)
+		controllerMock.On("RevokePersonalToken").Return(errors.New("test"))
+
+		w := httptest.NewRecorder()
+		newHandler(controllerMock).RevokePersonalToken(w, newRequest(http.MethodDelete, "", uuid.New().String()))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -16,33 +16,50 @@ package auth
 
 import (
 	netHTTP "net/http"
+	"time"
 
 	authDTO "github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
 	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
 	"github.com/ZupIT/horusec/horusec-auth/config/app"
 	authController "github.com/ZupIT/horusec/horusec-auth/internal/controller/auth"
+	"github.com/ZupIT/horusec/horusec-auth/internal/services/lockout"
 	authUseCases "github.com/ZupIT/horusec/horusec-auth/internal/usecases/auth"
+	"github.com/go-chi/chi"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth"   // [swagger-import]
 	_ "github.com/ZupIT/horusec/development-kit/pkg/entities/http" // [swagger-import]
 )
 
+// unlockConfirmPage posts to the same url, only the click of the account owner unlocks the account
+const unlockConfirmPage = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Horusec</title></head>
+<body>
+<p>Your Horusec account was locked after many failed login attempts.</p>
+<form method="post"><button type="submit">Unlock account</button></form>
+</body>
+</html>`
+
 type Handler struct {
 	authUseCases   authUseCases.IUseCases
 	authController authController.IController
+	lockout        lockout.IService
 	appConfig      *app.Config
 }
 
-func NewAuthHandler(
-	postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite, appConfig *app.Config) *Handler {
+func NewAuthHandler(broker brokerLib.IBroker, postgresRead relational.InterfaceRead,
+	postgresWrite relational.InterfaceWrite, appConfig *app.Config) *Handler {
 	return &Handler{
 		appConfig:      appConfig,
 		authUseCases:   authUseCases.NewAuthUseCases(),
 		authController: authController.NewAuthController(postgresRead, postgresWrite, appConfig),
+		lockout:        lockout.NewService(broker, postgresRead, postgresWrite, appConfig),
 	}
 }
 
@@ -73,6 +90,7 @@ func (h *Handler) Config(w netHTTP.ResponseWriter, _ *netHTTP.Request) {
 // @Param Credentials body dto.Credentials true "auth info"
 // @Success 200 {object} http.Response{content=string} "STATUS OK"
 // @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 429 {object} http.Response{content=string} "TOO MANY REQUESTS"
 // @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
 // @Router /auth/auth/authenticate [post]
 func (h *Handler) AuthByType(w netHTTP.ResponseWriter, r *netHTTP.Request) {
@@ -82,15 +100,44 @@ func (h *Handler) AuthByType(w netHTTP.ResponseWriter, r *netHTTP.Request) {
 		return
 	}
 
+	if retryAfter, err := h.checkLoginAttempts(credentials, r); err != nil {
+		httpUtil.StatusTooManyRequests(w, err, retryAfter)
+		return
+	}
+
 	response, err := h.authController.AuthByType(credentials)
 	if err != nil {
+		h.registerLoginFailure(credentials, r, err)
 		h.checkLoginErrors(w, err)
 		return
 	}
 
+	h.lockout.RegisterSuccess(credentials.Username)
 	httpUtil.StatusOK(w, response)
 }
 
+// checkLoginAttempts is skipped for the oidc authorization code, that login is protected by the provider
+func (h *Handler) checkLoginAttempts(credentials *authDTO.Credentials, r *netHTTP.Request) (time.Duration, error) {
+	if credentials.IsAuthorizationCode() {
+		return 0, nil
+	}
+
+	return h.lockout.Check(credentials.Username, h.lockout.GetClientIP(r))
+}
+
+func (h *Handler) registerLoginFailure(credentials *authDTO.Credentials, r *netHTTP.Request, err error) {
+	if credentials.IsAuthorizationCode() || !h.isInvalidCredentialsError(err) {
+		return
+	}
+
+	h.lockout.RegisterFailure(credentials.Username, h.lockout.GetClientIP(r))
+}
+
+func (h *Handler) isInvalidCredentialsError(err error) bool {
+	return err == errors.ErrorWrongEmailOrPassword || err == errors.ErrNotFoundRecords ||
+		err == errors.ErrorInvalidTwoFactorCode || err == errors.ErrorUserDoesNotExist || err == errors.ErrorUnauthorized
+}
+
 func (h *Handler) getCredentials(r *netHTTP.Request) (*authDTO.Credentials, error) {
 	credentials, err := h.authUseCases.NewCredentialsFromReadCloser(r.Body)
 	if err != nil {
@@ -108,6 +155,8 @@ func (h *Handler) checkLoginErrors(w netHTTP.ResponseWriter, err error) {
 		httpUtil.StatusInternalServerError(w, err)
 	case authEnums.Keycloak:
 		httpUtil.StatusInternalServerError(w, err)
+	case authEnums.OIDC:
+		h.checkLoginErrorsOIDC(w, err)
 	default:
 		httpUtil.StatusInternalServerError(w, err)
 	}
@@ -119,10 +168,83 @@ func (h *Handler) checkLoginErrorsHorusec(w netHTTP.ResponseWriter, err error) {
 		return
 	}
 
-	if err == errors.ErrorAccountEmailNotConfirmed || err == errors.ErrorUserAlreadyLogged {
+	if err == errors.ErrorAccountEmailNotConfirmed || err == errors.ErrorUserAlreadyLogged ||
+		err == errors.ErrorTwoFactorRequired || err == errors.ErrorInvalidTwoFactorCode ||
+		err == errors.ErrorAccountDisabled {
+		httpUtil.StatusForbidden(w, err)
+		return
+	}
+
+	httpUtil.StatusInternalServerError(w, err)
+}
+
+func (h *Handler) checkLoginErrorsOIDC(w netHTTP.ResponseWriter, err error) {
+	if err == errors.ErrorInvalidOIDCState || err == errors.ErrorOIDCCodeExchange ||
+		err == errors.ErrorInvalidOIDCToken || err == errors.ErrorOIDCMissingEmail || err == errors.ErrorAccountDisabled {
 		httpUtil.StatusForbidden(w, err)
 		return
 	}
 
 	httpUtil.StatusInternalServerError(w, err)
 }
+
+// @Tags Auth
+// @Description get the openid connect provider authorization url to start the login!
+// @ID oidc authorization url
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} http.Response{content=dto.OIDCAuthorization} "STATUS OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /auth/auth/oidc/authorize [get]
+func (h *Handler) OIDCAuthorize(w netHTTP.ResponseWriter, _ *netHTTP.Request) {
+	authorization, err := h.authController.GetOIDCAuthorizationURL()
+	if err != nil {
+		if err == errors.ErrorInvalidAuthType {
+			httpUtil.StatusBadRequest(w, err)
+			return
+		}
+
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, authorization)
+}
+
+// @Tags Auth
+// @Description confirm page of the unlock link sent by email, it does not unlock so link scanners can not use the code!
+// @ID unlock account confirm
+// @Produce  html
+// @Param code path string true "unlock code sent by email"
+// @Success 200 {string} string "OK"
+// @Router /auth/auth/unlock/{code} [get]
+func (h *Handler) UnlockConfirm(w netHTTP.ResponseWriter, _ *netHTTP.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(netHTTP.StatusOK)
+	_, _ = w.Write([]byte(unlockConfirmPage))
+}
+
+// @Tags Auth
+// @Description unlock the account locked by failed login attempts with the code sent by email!
+// @ID unlock account
+// @Accept  json
+// @Produce  json
+// @Param code path string true "unlock code sent by email"
+// @Success 303 {object} http.Response{content=string} "SEE OTHER"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /auth/auth/unlock/{code} [post]
+func (h *Handler) Unlock(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	if err := h.lockout.Unlock(chi.URLParam(r, "code")); err != nil {
+		if err == errors.ErrorInvalidUnlockCode {
+			httpUtil.StatusBadRequest(w, err)
+			return
+		}
+
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	netHTTP.Redirect(w, r, env.GetHorusecManagerURL(), netHTTP.StatusSeeOther)
+}
@@ -23,18 +23,29 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
 	"github.com/ZupIT/horusec/horusec-auth/config/app"
 	authController "github.com/ZupIT/horusec/horusec-auth/internal/controller/auth"
+	"github.com/ZupIT/horusec/horusec-auth/internal/services/lockout"
 	authUseCases "github.com/ZupIT/horusec/horusec-auth/internal/usecases/auth"
 	"github.com/stretchr/testify/assert"
 )
 
+func newLockoutMock() *lockout.Mock {
+	lockoutMock := &lockout.Mock{}
+	lockoutMock.On("Check").Return(time.Duration(0), nil)
+	lockoutMock.On("RegisterFailure")
+	lockoutMock.On("RegisterSuccess")
+	lockoutMock.On("GetClientIP").Return("127.0.0.1")
+	return lockoutMock
+}
+
 func TestNewAuthController(t *testing.T) {
 	t.Run("should success create new controller", func(t *testing.T) {
 		appConfig := &app.Config{}
-		handler := NewAuthHandler(nil, nil, appConfig)
+		handler := NewAuthHandler(nil, nil, nil, appConfig)
 		assert.NotEmpty(t, handler)
 	})
 }
@@ -42,7 +53,7 @@ func TestNewAuthController(t *testing.T) {
 func TestOptions(t *testing.T) {
 	t.Run("should return 204 when options", func(t *testing.T) {
 		appConfig := &app.Config{}
-		handler := NewAuthHandler(nil, nil, appConfig)
+		handler := NewAuthHandler(nil, nil, nil, appConfig)
 		r, _ := http.NewRequest(http.MethodOptions, "test", nil)
 		w := httptest.NewRecorder()
 
@@ -62,6 +73,7 @@ func TestAuthByType(t *testing.T) {
 			appConfig:      &app.Config{},
 			authUseCases:   authUseCases.NewAuthUseCases(),
 			authController: controllerMock,
+			lockout:        newLockoutMock(),
 		}
 
 		credentialsBytes, _ := json.Marshal(dto.Credentials{Username: "test", Password: "test"})
@@ -85,6 +97,7 @@ func TestAuthByType(t *testing.T) {
 			appConfig:      &app.Config{},
 			authUseCases:   authUseCases.NewAuthUseCases(),
 			authController: controllerMock,
+			lockout:        newLockoutMock(),
 		}
 
 		credentialsBytes, _ := json.Marshal(dto.Credentials{Username: "test", Password: "test"})
@@ -106,6 +119,7 @@ func TestAuthByType(t *testing.T) {
 			appConfig:      &app.Config{},
 			authUseCases:   authUseCases.NewAuthUseCases(),
 			authController: controllerMock,
+			lockout:        newLockoutMock(),
 		}
 
 		credentialsBytes, _ := json.Marshal(dto.Credentials{})
@@ -123,7 +137,7 @@ func TestAuthByType(t *testing.T) {
 
 func TestHandler_AuthTypes(t *testing.T) {
 	t.Run("should return 200 when get auth types", func(t *testing.T) {
-		handler := NewAuthHandler(nil, nil, &app.Config{
+		handler := NewAuthHandler(nil, nil, nil, &app.Config{
 			AuthType: authEnums.Horusec,
 		})
 
@@ -143,6 +157,7 @@ func TestHandler_AuthTypes(t *testing.T) {
 			},
 			authUseCases:   authUseCases.NewAuthUseCases(),
 			authController: controllerMock,
+			lockout:        newLockoutMock(),
 		}
 
 		r, _ := http.NewRequest(http.MethodGet, "test", nil)
@@ -162,6 +177,7 @@ func TestHandler_AuthTypes(t *testing.T) {
 			appConfig:      &app.Config{AuthType: authEnums.Ldap},
 			authUseCases:   authUseCases.NewAuthUseCases(),
 			authController: controllerMock,
+			lockout:        newLockoutMock(),
 		}
 
 		credentialsBytes, _ := json.Marshal(dto.Credentials{Username: "test", Password: "test"})
@@ -185,6 +201,7 @@ func TestHandler_AuthTypes(t *testing.T) {
 			appConfig:      &app.Config{AuthType: authEnums.Keycloak},
 			authUseCases:   authUseCases.NewAuthUseCases(),
 			authController: controllerMock,
+			lockout:        newLockoutMock(),
 		}
 
 		credentialsBytes, _ := json.Marshal(dto.Credentials{Username: "test", Password: "test"})
@@ -208,6 +225,7 @@ func TestHandler_AuthTypes(t *testing.T) {
 			appConfig:      &app.Config{AuthType: authEnums.Horusec},
 			authUseCases:   authUseCases.NewAuthUseCases(),
 			authController: controllerMock,
+			lockout:        newLockoutMock(),
 		}
 
 		credentialsBytes, _ := json.Marshal(dto.Credentials{Username: "test", Password: "test"})
@@ -231,6 +249,7 @@ func TestHandler_AuthTypes(t *testing.T) {
 			appConfig:      &app.Config{AuthType: authEnums.Horusec},
 			authUseCases:   authUseCases.NewAuthUseCases(),
 			authController: controllerMock,
+			lockout:        newLockoutMock(),
 		}
 
 		credentialsBytes, _ := json.Marshal(dto.Credentials{Username: "test", Password: "test"})
@@ -254,6 +273,7 @@ func TestHandler_AuthTypes(t *testing.T) {
 			appConfig:      &app.Config{AuthType: authEnums.Horusec},
 			authUseCases:   authUseCases.NewAuthUseCases(),
 			authController: controllerMock,
+			lockout:        newLockoutMock(),
 		}
 
 		credentialsBytes, _ := json.Marshal(dto.Credentials{Username: "test", Password: "test"})
@@ -267,4 +287,231 @@ func TestHandler_AuthTypes(t *testing.T) {
 
 		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
+
+	t.Run("should return 403 when invalid oidc state", func(t *testing.T) {
+		controllerMock := &authController.MockAuthController{}
+
+		controllerMock.On("AuthByType").Return(nil, errorsEnums.ErrorInvalidOIDCState)
+
+		handler := Handler{
+			appConfig:      &app.Config{AuthType: authEnums.OIDC},
+			authUseCases:   authUseCases.NewAuthUseCases(),
+			authController: controllerMock,
+			lockout:        newLockoutMock(),
+		}
+
+		credentialsBytes, _ := json.Marshal(dto.Credentials{Code: "code", State: "state"})
+
+		r, _ := http.NewRequest(http.MethodPost, "test", bytes.NewReader(credentialsBytes))
+		w := httptest.NewRecorder()
+
+		handler.AuthByType(w, r)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("should return 500 when something went wrong oidc", func(t *testing.T) {
+		controllerMock := &authController.MockAuthController{}
+
+		controllerMock.On("AuthByType").Return(nil, errors.New("test"))
+
+		handler := Handler{
+			appConfig:      &app.Config{AuthType: authEnums.OIDC},
+			authUseCases:   authUseCases.NewAuthUseCases(),
+			authController: controllerMock,
+			lockout:        newLockoutMock(),
+		}
+
+		credentialsBytes, _ := json.Marshal(dto.Credentials{Code: "code", State: "state"})
+
+		r, _ := http.NewRequest(http.MethodPost, "test", bytes.NewReader(credentialsBytes))
+		w := httptest.NewRecorder()
+
+		handler.AuthByType(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestOIDCAuthorize(t *testing.T) {
+	t.Run("should return 200 when get authorization url", func(t *testing.T) {
+		controllerMock := &authController.MockAuthController{}
+
+		controllerMock.On("GetOIDCAuthorizationURL").Return(
+			&dto.OIDCAuthorization{AuthorizationURL: "http://idp.example.com/authorize", State: "state"}, nil)
+
+		handler := Handler{authController: controllerMock}
+
+		r, _ := http.NewRequest(http.MethodGet, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.OIDCAuthorize(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 400 when auth type is not oidc", func(t *testing.T) {
+		controllerMock := &authController.MockAuthController{}
+
+		controllerMock.On("GetOIDCAuthorizationURL").Return(&dto.OIDCAuthorization{}, errorsEnums.ErrorInvalidAuthType)
+
+		handler := Handler{authController: controllerMock}
+
+		r, _ := http.NewRequest(http.MethodGet, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.OIDCAuthorize(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 500 when something went wrong", func(t *testing.T) {
+		controllerMock := &authController.MockAuthController{}
+
+		controllerMock.On("GetOIDCAuthorizationURL").Return(&dto.OIDCAuthorization{}, errors.New("test"))
+
+		handler := Handler{authController: controllerMock}
+
+		r, _ := http.NewRequest(http.MethodGet, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.OIDCAuthorize(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestAuthByTypeLoginAttempts(t *testing.T) {
+	newRequest := func(credentials dto.Credentials) *http.Request {
+		credentialsBytes, _ := json.Marshal(credentials)
+		r, _ := http.NewRequest(http.MethodPost, "test", bytes.NewReader(credentialsBytes))
+		r.RemoteAddr = "127.0.0.1:8006"
+		return r
+	}
+
+	t.Run("should return 429 when login attempts are blocked", func(t *testing.T) {
+		controllerMock := &authController.MockAuthController{}
+		lockoutMock := &lockout.Mock{}
+		lockoutMock.On("Check").Return(time.Minute, errorsEnums.ErrorAccountLocked)
+		lockoutMock.On("GetClientIP").Return("127.0.0.1")
+		handler := Handler{appConfig: &app.Config{AuthType: authEnums.Horusec},
+			authUseCases: authUseCases.NewAuthUseCases(), authController: controllerMock, lockout: lockoutMock}
+		w := httptest.NewRecorder()
+
+		handler.AuthByType(w, newRequest(dto.Credentials{Username: "test", Password: "test"}))
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "60", w.Header().Get("Retry-After"))
+		controllerMock.AssertNotCalled(t, "AuthByType")
+	})
+
+	t.Run("should register failure when credentials are invalid", func(t *testing.T) {
+		controllerMock := &authController.MockAuthController{}
+		controllerMock.On("AuthByType").Return(nil, errorsEnums.ErrorWrongEmailOrPassword)
+		lockoutMock := newLockoutMock()
+		handler := Handler{appConfig: &app.Config{AuthType: authEnums.Horusec},
+			authUseCases: authUseCases.NewAuthUseCases(), authController: controllerMock, lockout: lockoutMock}
+		w := httptest.NewRecorder()
+
+		handler.AuthByType(w, newRequest(dto.Credentials{Username: "test", Password: "test"}))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		lockoutMock.AssertCalled(t, "RegisterFailure")
+	})
+
+	t.Run("should not register failure when error is not about credentials", func(t *testing.T) {
+		controllerMock := &authController.MockAuthController{}
+		controllerMock.On("AuthByType").Return(nil, errorsEnums.ErrorTwoFactorRequired)
+		lockoutMock := newLockoutMock()
+		handler := Handler{appConfig: &app.Config{AuthType: authEnums.Horusec},
+			authUseCases: authUseCases.NewAuthUseCases(), authController: controllerMock, lockout: lockoutMock}
+		w := httptest.NewRecorder()
+
+		handler.AuthByType(w, newRequest(dto.Credentials{Username: "test", Password: "test"}))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		lockoutMock.AssertNotCalled(t, "RegisterFailure")
+	})
+
+	t.Run("should reset failures when login succeeds", func(t *testing.T) {
+		controllerMock := &authController.MockAuthController{}
+		controllerMock.On("AuthByType").Return(map[string]interface{}{"test": "test"}, nil)
+		lockoutMock := newLockoutMock()
+		handler := Handler{appConfig: &app.Config{AuthType: authEnums.Horusec},
+			authUseCases: authUseCases.NewAuthUseCases(), authController: controllerMock, lockout: lockoutMock}
+		w := httptest.NewRecorder()
+
+		handler.AuthByType(w, newRequest(dto.Credentials{Username: "test", Password: "test"}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		lockoutMock.AssertCalled(t, "RegisterSuccess")
+	})
+
+	t.Run("should not check attempts with oidc authorization code", func(t *testing.T) {
+		controllerMock := &authController.MockAuthController{}
+		controllerMock.On("AuthByType").Return(nil, errorsEnums.ErrorInvalidOIDCState)
+		lockoutMock := newLockoutMock()
+		handler := Handler{appConfig: &app.Config{AuthType: authEnums.OIDC},
+			authUseCases: authUseCases.NewAuthUseCases(), authController: controllerMock, lockout: lockoutMock}
+		w := httptest.NewRecorder()
+
+		handler.AuthByType(w, newRequest(dto.Credentials{Code: "code", State: "state"}))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		lockoutMock.AssertNotCalled(t, "Check")
+		lockoutMock.AssertNotCalled(t, "RegisterFailure")
+	})
+}
+
+func TestUnlockConfirm(t *testing.T) {
+	t.Run("should show the confirm page without unlocking", func(t *testing.T) {
+		lockoutMock := &lockout.Mock{}
+		handler := Handler{lockout: lockoutMock}
+		r, _ := http.NewRequest(http.MethodGet, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.UnlockConfirm(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `<form method="post">`)
+		lockoutMock.AssertNotCalled(t, "Unlock")
+	})
+}
+
+func TestUnlock(t *testing.T) {
+	t.Run("should redirect to manager when unlock account", func(t *testing.T) {
+		lockoutMock := &lockout.Mock{}
+		lockoutMock.On("Unlock").Return(nil)
+		handler := Handler{lockout: lockoutMock}
+		r, _ := http.NewRequest(http.MethodPost, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.Unlock(w, r)
+
+		assert.Equal(t, http.StatusSeeOther, w.Code)
+	})
+
+	t.Run("should return 400 when unlock code is invalid", func(t *testing.T) {
+		lockoutMock := &lockout.Mock{}
+		lockoutMock.On("Unlock").Return(errorsEnums.ErrorInvalidUnlockCode)
+		handler := Handler{lockout: lockoutMock}
+		r, _ := http.NewRequest(http.MethodPost, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.Unlock(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 500 when unlock fails", func(t *testing.T) {
+		lockoutMock := &lockout.Mock{}
+		lockoutMock.On("Unlock").Return(errors.New("test"))
+		handler := Handler{lockout: lockoutMock}
+		r, _ := http.NewRequest(http.MethodPost, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.Unlock(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
 }
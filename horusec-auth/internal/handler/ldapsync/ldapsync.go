@@ -0,0 +1,110 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldapsync
+
+import (
+	"context"
+	netHTTP "net/http"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	authGrpc "github.com/ZupIT/horusec/development-kit/pkg/services/grpc/auth"
+	httpUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http"
+	"github.com/ZupIT/horusec/horusec-auth/config/app"
+	authController "github.com/ZupIT/horusec/horusec-auth/internal/controller/auth"
+	ldapSyncController "github.com/ZupIT/horusec/horusec-auth/internal/controller/ldapsync"
+
+	_ "github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto" // [swagger-import]
+	_ "github.com/ZupIT/horusec/development-kit/pkg/entities/http"     // [swagger-import]
+)
+
+type Handler struct {
+	authController authController.IController
+	controller     ldapSyncController.IController
+	appConfig      *app.Config
+}
+
+func NewHandler(
+	postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite, appConfig *app.Config) *Handler {
+	return &Handler{
+		appConfig:      appConfig,
+		authController: authController.NewAuthController(postgresRead, postgresWrite, appConfig),
+		controller:     ldapSyncController.NewController(postgresRead, postgresWrite),
+	}
+}
+
+func (h *Handler) Options(w netHTTP.ResponseWriter, _ *netHTTP.Request) {
+	httpUtil.StatusNoContent(w)
+}
+
+// @Tags LDAP Sync
+// @Security ApiKeyAuth
+// @Description report the account and role changes that the ldap group sync would apply, without applying them!
+// @ID ldap-sync-dry-run
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} http.Response{content=dto.LdapSyncReport} "STATUS OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 401 {object} http.Response{content=string} "UNAUTHORIZED"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /auth/ldap-sync/dry-run [get]
+func (h *Handler) DryRun(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	h.sync(w, r, true)
+}
+
+// @Tags LDAP Sync
+// @Security ApiKeyAuth
+// @Description sync ldap group membership to company and repository roles!
+// @ID ldap-sync
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} http.Response{content=dto.LdapSyncReport} "STATUS OK"
+// @Failure 400 {object} http.Response{content=string} "BAD REQUEST"
+// @Failure 401 {object} http.Response{content=string} "UNAUTHORIZED"
+// @Failure 500 {object} http.Response{content=string} "INTERNAL SERVER ERROR"
+// @Router /auth/ldap-sync [post]
+func (h *Handler) Sync(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	h.sync(w, r, false)
+}
+
+func (h *Handler) sync(w netHTTP.ResponseWriter, r *netHTTP.Request, dryRun bool) {
+	if h.appConfig.GetAuthType() != authEnums.Ldap {
+		httpUtil.StatusBadRequest(w, errors.ErrorLdapSyncDisabled)
+		return
+	}
+
+	if !h.isApplicationAdmin(r) {
+		httpUtil.StatusUnauthorized(w, errors.ErrorDoNotHavePermissionToThisAction)
+		return
+	}
+
+	report, err := h.controller.Sync(dryRun)
+	if err != nil {
+		httpUtil.StatusInternalServerError(w, err)
+		return
+	}
+
+	httpUtil.StatusOK(w, report)
+}
+
+func (h *Handler) isApplicationAdmin(r *netHTTP.Request) bool {
+	response, err := h.authController.IsAuthorized(context.Background(), &authGrpc.IsAuthorizedData{
+		Token: r.Header.Get("X-Horusec-Authorization"),
+		Role:  authEnums.ApplicationAdmin.ToString(),
+	})
+
+	return err == nil && response.GetIsAuthorized()
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldapsync
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	authGrpc "github.com/ZupIT/horusec/development-kit/pkg/services/grpc/auth"
+	"github.com/ZupIT/horusec/horusec-auth/config/app"
+	authController "github.com/ZupIT/horusec/horusec-auth/internal/controller/auth"
+	ldapSyncController "github.com/ZupIT/horusec/horusec-auth/internal/controller/ldapsync"
+	"github.com/stretchr/testify/assert"
+)
+
+func newHandler(authorized bool, authErr error, syncErr error) (*Handler, *ldapSyncController.Mock) {
+	authMock := &authController.MockAuthController{}
+	authMock.On("IsAuthorized").Return(&authGrpc.IsAuthorizedResponse{IsAuthorized: authorized}, authErr)
+
+	controllerMock := &ldapSyncController.Mock{}
+	controllerMock.On("Sync").Return(dto.NewLdapSyncReport(true), syncErr)
+
+	return &Handler{
+		appConfig:      &app.Config{AuthType: authEnums.Ldap},
+		authController: authMock,
+		controller:     controllerMock,
+	}, controllerMock
+}
+
+func TestNewHandler(t *testing.T) {
+	t.Run("should create a new handler", func(t *testing.T) {
+		assert.NotNil(t, NewHandler(nil, nil, &app.Config{}))
+	})
+}
+
+func TestOptions(t *testing.T) {
+	t.Run("should return 204 when options", func(t *testing.T) {
+		handler, _ := newHandler(true, nil, nil)
+		r, _ := http.NewRequest(http.MethodOptions, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.Options(w, r)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	t.Run("should return 200 with the sync report", func(t *testing.T) {
+		handler, controllerMock := newHandler(true, nil, nil)
+		r, _ := http.NewRequest(http.MethodGet, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.DryRun(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		controllerMock.AssertCalled(t, "Sync")
+	})
+
+	t.Run("should return 400 when auth type is not ldap", func(t *testing.T) {
+		handler, controllerMock := newHandler(true, nil, nil)
+		handler.appConfig.AuthType = authEnums.Horusec
+		r, _ := http.NewRequest(http.MethodGet, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.DryRun(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		controllerMock.AssertNotCalled(t, "Sync")
+	})
+
+	t.Run("should return 401 when is not application admin", func(t *testing.T) {
+		handler, controllerMock := newHandler(false, nil, nil)
+		r, _ := http.NewRequest(http.MethodGet, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.DryRun(w, r)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		controllerMock.AssertNotCalled(t, "Sync")
+	})
+
+	t.Run("should return 401 when check authorization fails", func(t *testing.T) {
+		handler, _ := newHandler(true, errors.New("test"), nil)
+		r, _ := http.NewRequest(http.MethodGet, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.DryRun(w, r)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestSync(t *testing.T) {
+	t.Run("should return 200 with the sync report", func(t *testing.T) {
+		handler, _ := newHandler(true, nil, nil)
+		r, _ := http.NewRequest(http.MethodPost, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.Sync(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 500 when sync fails", func(t *testing.T) {
+		handler, _ := newHandler(true, nil, errors.New("test"))
+		r, _ := http.NewRequest(http.MethodPost, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.Sync(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
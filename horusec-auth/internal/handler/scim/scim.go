@@ -0,0 +1,453 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	netHTTP "net/http"
+	"strconv"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	scimEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/scim"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-auth/config/app"
+	scimController "github.com/ZupIT/horusec/horusec-auth/internal/controller/scim"
+	"github.com/go-chi/chi"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	controller scimController.IController
+	appConfig  *app.Config
+}
+
+func NewHandler(
+	postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite, appConfig *app.Config) *Handler {
+	return &Handler{
+		appConfig:  appConfig,
+		controller: scimController.NewController(postgresRead, postgresWrite),
+	}
+}
+
+// Authenticate allows only the identity provider with the bearer token of HORUSEC_SCIM_TOKEN
+func (h *Handler) Authenticate(next netHTTP.Handler) netHTTP.Handler {
+	return netHTTP.HandlerFunc(func(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+		if h.appConfig.GetScimToken() == "" {
+			h.renderError(w, netHTTP.StatusUnauthorized, "", errors.ErrorScimDisabled)
+			return
+		}
+
+		expected := []byte("Bearer " + h.appConfig.GetScimToken())
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			h.renderError(w, netHTTP.StatusUnauthorized, "", errors.ErrorScimInvalidToken)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// @Tags SCIM
+// @Description list users provisioned by the identity provider, authenticated by the scim bearer token!
+// @ID scim-list-users
+// @Produce  json
+// @Param filter query string false "filter like userName eq \"value\""
+// @Param startIndex query int false "first result, starting at 1"
+// @Param count query int false "results per page, at most 100"
+// @Success 200 {object} scim.ListResponse "STATUS OK"
+// @Failure 400 {object} scim.Error "BAD REQUEST"
+// @Failure 401 {object} scim.Error "UNAUTHORIZED"
+// @Failure 500 {object} scim.Error "INTERNAL SERVER ERROR"
+// @Router /auth/scim/v2/Users [get]
+func (h *Handler) ListUsers(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	filter, err := h.getFilter(r)
+	if err != nil {
+		h.renderError(w, netHTTP.StatusBadRequest, "invalidFilter", err)
+		return
+	}
+
+	response, err := h.controller.ListUsers(filter)
+	h.render(w, netHTTP.StatusOK, response, err)
+}
+
+// @Tags SCIM
+// @Description get an user provisioned by the identity provider!
+// @ID scim-get-user
+// @Produce  json
+// @Param userID path string true "ID of the user"
+// @Success 200 {object} scim.User "STATUS OK"
+// @Failure 401 {object} scim.Error "UNAUTHORIZED"
+// @Failure 404 {object} scim.Error "NOT FOUND"
+// @Failure 500 {object} scim.Error "INTERNAL SERVER ERROR"
+// @Router /auth/scim/v2/Users/{userID} [get]
+func (h *Handler) GetUser(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	accountID, err := h.getID(w, r, "userID")
+	if err != nil {
+		return
+	}
+
+	user, err := h.controller.GetUser(accountID)
+	h.render(w, netHTTP.StatusOK, user, err)
+}
+
+// @Tags SCIM
+// @Description create an user, the account is confirmed and can login only by the identity provider!
+// @ID scim-create-user
+// @Accept  json
+// @Produce  json
+// @Param User body scim.User true "user info"
+// @Success 201 {object} scim.User "CREATED"
+// @Failure 400 {object} scim.Error "BAD REQUEST"
+// @Failure 401 {object} scim.Error "UNAUTHORIZED"
+// @Failure 409 {object} scim.Error "CONFLICT"
+// @Failure 500 {object} scim.Error "INTERNAL SERVER ERROR"
+// @Router /auth/scim/v2/Users [post]
+func (h *Handler) CreateUser(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	user, err := h.getUser(w, r)
+	if err != nil {
+		return
+	}
+
+	user, err = h.controller.CreateUser(user)
+	h.render(w, netHTTP.StatusCreated, user, err)
+}
+
+// @Tags SCIM
+// @Description replace an user, users with active false are disabled and removed from companies and repositories!
+// @ID scim-replace-user
+// @Accept  json
+// @Produce  json
+// @Param userID path string true "ID of the user"
+// @Param User body scim.User true "user info"
+// @Success 200 {object} scim.User "STATUS OK"
+// @Failure 400 {object} scim.Error "BAD REQUEST"
+// @Failure 401 {object} scim.Error "UNAUTHORIZED"
+// @Failure 404 {object} scim.Error "NOT FOUND"
+// @Failure 409 {object} scim.Error "CONFLICT"
+// @Failure 500 {object} scim.Error "INTERNAL SERVER ERROR"
+// @Router /auth/scim/v2/Users/{userID} [put]
+func (h *Handler) ReplaceUser(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	accountID, err := h.getID(w, r, "userID")
+	if err != nil {
+		return
+	}
+
+	user, err := h.getUser(w, r)
+	if err != nil {
+		return
+	}
+
+	user, err = h.controller.ReplaceUser(accountID, user)
+	h.render(w, netHTTP.StatusOK, user, err)
+}
+
+// @Tags SCIM
+// @Description change the user name, external id, emails or active of an user!
+// @ID scim-patch-user
+// @Accept  json
+// @Produce  json
+// @Param userID path string true "ID of the user"
+// @Param PatchOp body scim.PatchRequest true "patch operations"
+// @Success 200 {object} scim.User "STATUS OK"
+// @Failure 400 {object} scim.Error "BAD REQUEST"
+// @Failure 401 {object} scim.Error "UNAUTHORIZED"
+// @Failure 404 {object} scim.Error "NOT FOUND"
+// @Failure 409 {object} scim.Error "CONFLICT"
+// @Failure 500 {object} scim.Error "INTERNAL SERVER ERROR"
+// @Router /auth/scim/v2/Users/{userID} [patch]
+func (h *Handler) PatchUser(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	accountID, err := h.getID(w, r, "userID")
+	if err != nil {
+		return
+	}
+
+	patch, err := h.getPatch(w, r)
+	if err != nil {
+		return
+	}
+
+	user, err := h.controller.PatchUser(accountID, patch)
+	h.render(w, netHTTP.StatusOK, user, err)
+}
+
+// @Tags SCIM
+// @Description delete the account of an user!
+// @ID scim-delete-user
+// @Param userID path string true "ID of the user"
+// @Success 204 "NO CONTENT"
+// @Failure 401 {object} scim.Error "UNAUTHORIZED"
+// @Failure 404 {object} scim.Error "NOT FOUND"
+// @Failure 500 {object} scim.Error "INTERNAL SERVER ERROR"
+// @Router /auth/scim/v2/Users/{userID} [delete]
+func (h *Handler) DeleteUser(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	accountID, err := h.getID(w, r, "userID")
+	if err != nil {
+		return
+	}
+
+	h.render(w, netHTTP.StatusNoContent, nil, h.controller.DeleteUser(accountID))
+}
+
+// @Tags SCIM
+// @Description list groups provisioned by the identity provider, authenticated by the scim bearer token!
+// @ID scim-list-groups
+// @Produce  json
+// @Param filter query string false "filter like displayName eq \"value\""
+// @Param startIndex query int false "first result, starting at 1"
+// @Param count query int false "results per page, at most 100"
+// @Success 200 {object} scim.ListResponse "STATUS OK"
+// @Failure 400 {object} scim.Error "BAD REQUEST"
+// @Failure 401 {object} scim.Error "UNAUTHORIZED"
+// @Failure 500 {object} scim.Error "INTERNAL SERVER ERROR"
+// @Router /auth/scim/v2/Groups [get]
+func (h *Handler) ListGroups(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	filter, err := h.getFilter(r)
+	if err != nil {
+		h.renderError(w, netHTTP.StatusBadRequest, "invalidFilter", err)
+		return
+	}
+
+	response, err := h.controller.ListGroups(filter)
+	h.render(w, netHTTP.StatusOK, response, err)
+}
+
+// @Tags SCIM
+// @Description get a group provisioned by the identity provider with its members!
+// @ID scim-get-group
+// @Produce  json
+// @Param groupID path string true "ID of the group"
+// @Success 200 {object} scim.Group "STATUS OK"
+// @Failure 401 {object} scim.Error "UNAUTHORIZED"
+// @Failure 404 {object} scim.Error "NOT FOUND"
+// @Failure 500 {object} scim.Error "INTERNAL SERVER ERROR"
+// @Router /auth/scim/v2/Groups/{groupID} [get]
+func (h *Handler) GetGroup(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	groupID, err := h.getID(w, r, "groupID")
+	if err != nil {
+		return
+	}
+
+	group, err := h.controller.GetGroup(groupID)
+	h.render(w, netHTTP.StatusOK, group, err)
+}
+
+// @Tags SCIM
+// @Description create a group, its display name gives roles in the companies and repositories with the same group!
+// @ID scim-create-group
+// @Accept  json
+// @Produce  json
+// @Param Group body scim.Group true "group info"
+// @Success 201 {object} scim.Group "CREATED"
+// @Failure 400 {object} scim.Error "BAD REQUEST"
+// @Failure 401 {object} scim.Error "UNAUTHORIZED"
+// @Failure 409 {object} scim.Error "CONFLICT"
+// @Failure 500 {object} scim.Error "INTERNAL SERVER ERROR"
+// @Router /auth/scim/v2/Groups [post]
+func (h *Handler) CreateGroup(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	group, err := h.getGroup(w, r)
+	if err != nil {
+		return
+	}
+
+	group, err = h.controller.CreateGroup(group)
+	h.render(w, netHTTP.StatusCreated, group, err)
+}
+
+// @Tags SCIM
+// @Description replace the display name and members of a group!
+// @ID scim-replace-group
+// @Accept  json
+// @Produce  json
+// @Param groupID path string true "ID of the group"
+// @Param Group body scim.Group true "group info"
+// @Success 200 {object} scim.Group "STATUS OK"
+// @Failure 400 {object} scim.Error "BAD REQUEST"
+// @Failure 401 {object} scim.Error "UNAUTHORIZED"
+// @Failure 404 {object} scim.Error "NOT FOUND"
+// @Failure 409 {object} scim.Error "CONFLICT"
+// @Failure 500 {object} scim.Error "INTERNAL SERVER ERROR"
+// @Router /auth/scim/v2/Groups/{groupID} [put]
+func (h *Handler) ReplaceGroup(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	groupID, err := h.getID(w, r, "groupID")
+	if err != nil {
+		return
+	}
+
+	group, err := h.getGroup(w, r)
+	if err != nil {
+		return
+	}
+
+	group, err = h.controller.ReplaceGroup(groupID, group)
+	h.render(w, netHTTP.StatusOK, group, err)
+}
+
+// @Tags SCIM
+// @Description add, remove or replace members and change the display name of a group!
+// @ID scim-patch-group
+// @Accept  json
+// @Produce  json
+// @Param groupID path string true "ID of the group"
+// @Param PatchOp body scim.PatchRequest true "patch operations"
+// @Success 200 {object} scim.Group "STATUS OK"
+// @Failure 400 {object} scim.Error "BAD REQUEST"
+// @Failure 401 {object} scim.Error "UNAUTHORIZED"
+// @Failure 404 {object} scim.Error "NOT FOUND"
+// @Failure 409 {object} scim.Error "CONFLICT"
+// @Failure 500 {object} scim.Error "INTERNAL SERVER ERROR"
+// @Router /auth/scim/v2/Groups/{groupID} [patch]
+func (h *Handler) PatchGroup(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	groupID, err := h.getID(w, r, "groupID")
+	if err != nil {
+		return
+	}
+
+	patch, err := h.getPatch(w, r)
+	if err != nil {
+		return
+	}
+
+	group, err := h.controller.PatchGroup(groupID, patch)
+	h.render(w, netHTTP.StatusOK, group, err)
+}
+
+// @Tags SCIM
+// @Description delete a group, its members lose the roles given by it!
+// @ID scim-delete-group
+// @Param groupID path string true "ID of the group"
+// @Success 204 "NO CONTENT"
+// @Failure 401 {object} scim.Error "UNAUTHORIZED"
+// @Failure 404 {object} scim.Error "NOT FOUND"
+// @Failure 500 {object} scim.Error "INTERNAL SERVER ERROR"
+// @Router /auth/scim/v2/Groups/{groupID} [delete]
+func (h *Handler) DeleteGroup(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+	groupID, err := h.getID(w, r, "groupID")
+	if err != nil {
+		return
+	}
+
+	h.render(w, netHTTP.StatusNoContent, nil, h.controller.DeleteGroup(groupID))
+}
+
+func (h *Handler) getFilter(r *netHTTP.Request) (*scimEntities.Filter, error) {
+	startIndex, _ := strconv.Atoi(r.URL.Query().Get("startIndex"))
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	return scimEntities.NewFilter(r.URL.Query().Get("filter"), startIndex, count)
+}
+
+func (h *Handler) getID(w netHTTP.ResponseWriter, r *netHTTP.Request, param string) (uuid.UUID, error) {
+	id, err := uuid.Parse(chi.URLParam(r, param))
+	if err != nil {
+		h.renderError(w, netHTTP.StatusNotFound, "", errors.ErrorScimResourceNotFound)
+	}
+
+	return id, err
+}
+
+func (h *Handler) getUser(w netHTTP.ResponseWriter, r *netHTTP.Request) (*scimEntities.User, error) {
+	user := &scimEntities.User{}
+	if err := json.NewDecoder(r.Body).Decode(user); err != nil {
+		h.renderError(w, netHTTP.StatusBadRequest, "invalidSyntax", err)
+		return nil, err
+	}
+
+	if err := user.Validate(); err != nil {
+		h.renderError(w, netHTTP.StatusBadRequest, "invalidValue", err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (h *Handler) getGroup(w netHTTP.ResponseWriter, r *netHTTP.Request) (*scimEntities.Group, error) {
+	group := &scimEntities.Group{}
+	if err := json.NewDecoder(r.Body).Decode(group); err != nil {
+		h.renderError(w, netHTTP.StatusBadRequest, "invalidSyntax", err)
+		return nil, err
+	}
+
+	if err := group.Validate(); err != nil {
+		h.renderError(w, netHTTP.StatusBadRequest, "invalidValue", err)
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (h *Handler) getPatch(w netHTTP.ResponseWriter, r *netHTTP.Request) (*scimEntities.PatchRequest, error) {
+	patch := &scimEntities.PatchRequest{}
+	if err := json.NewDecoder(r.Body).Decode(patch); err != nil {
+		h.renderError(w, netHTTP.StatusBadRequest, "invalidSyntax", err)
+		return nil, err
+	}
+
+	return patch, nil
+}
+
+// render writes the scim responses, they are not wrapped in the content of the horusec responses
+func (h *Handler) render(w netHTTP.ResponseWriter, status int, body interface{}, err error) {
+	if err != nil {
+		h.renderControllerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", scimEntities.ContentType)
+	w.WriteHeader(status)
+	if body != nil {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+func (h *Handler) renderControllerError(w netHTTP.ResponseWriter, err error) {
+	switch err {
+	case errors.ErrorScimResourceNotFound:
+		h.renderError(w, netHTTP.StatusNotFound, "", err)
+	case errors.ErrorScimUserAlreadyExists, errors.ErrorScimGroupAlreadyExists:
+		h.renderError(w, netHTTP.StatusConflict, "uniqueness", err)
+	case errors.ErrorScimInvalidFilter:
+		h.renderError(w, netHTTP.StatusBadRequest, "invalidFilter", err)
+	case errors.ErrorScimInvalidPatchPath, errors.ErrorScimInvalidPatchOperation:
+		h.renderError(w, netHTTP.StatusBadRequest, "invalidPath", err)
+	case errors.ErrorScimInvalidPatchValue, errors.ErrorScimInvalidMember:
+		h.renderError(w, netHTTP.StatusBadRequest, "invalidValue", err)
+	default:
+		h.renderUnexpectedError(w, err)
+	}
+}
+
+// renderUnexpectedError also receives the validation errors of the users and groups changed by patch operations
+func (h *Handler) renderUnexpectedError(w netHTTP.ResponseWriter, err error) {
+	if _, ok := err.(validation.Errors); ok {
+		h.renderError(w, netHTTP.StatusBadRequest, "invalidValue", err)
+		return
+	}
+
+	if _, ok := err.(validation.Error); ok {
+		h.renderError(w, netHTTP.StatusBadRequest, "invalidValue", err)
+		return
+	}
+
+	logger.LogError(errors.ErrorScimUnexpected, err)
+	h.renderError(w, netHTTP.StatusInternalServerError, "", err)
+}
+
+func (h *Handler) renderError(w netHTTP.ResponseWriter, status int, scimType string, err error) {
+	w.Header().Set("Content-Type", scimEntities.ContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(scimEntities.NewError(status, scimType, err))
+}
@@ -0,0 +1,398 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	scimEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/scim"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/horusec-auth/config/app"
+	scimController "github.com/ZupIT/horusec/horusec-auth/internal/controller/scim"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newHandler() (*Handler, *scimController.Mock) {
+	controllerMock := &scimController.Mock{}
+	return &Handler{appConfig: &app.Config{ScimToken: "secret"}, controller: controllerMock}, controllerMock
+}
+
+func newRequest(method, body, param, value string) *http.Request {
+	r, _ := http.NewRequest(method, "test", strings.NewReader(body))
+	ctx := chi.NewRouteContext()
+	ctx.URLParams.Add(param, value)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+}
+
+func getError(w *httptest.ResponseRecorder) *scimEntities.Error {
+	scimError := &scimEntities.Error{}
+	_ = json.NewDecoder(w.Body).Decode(scimError)
+	return scimError
+}
+
+func TestNewHandler(t *testing.T) {
+	t.Run("should create a new handler", func(t *testing.T) {
+		assert.NotNil(t, NewHandler(nil, nil, &app.Config{}))
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	t.Run("should call next when bearer token is valid", func(t *testing.T) {
+		handler, _ := newHandler()
+		r, _ := http.NewRequest(http.MethodGet, "test", nil)
+		r.Header.Set("Authorization", "Bearer secret")
+		w := httptest.NewRecorder()
+
+		handler.Authenticate(next).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 401 when bearer token is invalid", func(t *testing.T) {
+		handler, _ := newHandler()
+		r, _ := http.NewRequest(http.MethodGet, "test", nil)
+		r.Header.Set("Authorization", "Bearer other")
+		w := httptest.NewRecorder()
+
+		handler.Authenticate(next).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, scimEntities.ContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "401", getError(w).Status)
+	})
+
+	t.Run("should return 401 when scim is disabled", func(t *testing.T) {
+		handler, _ := newHandler()
+		handler.appConfig.ScimToken = ""
+		r, _ := http.NewRequest(http.MethodGet, "test", nil)
+		r.Header.Set("Authorization", "Bearer ")
+		w := httptest.NewRecorder()
+
+		handler.Authenticate(next).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, errorsEnums.ErrorScimDisabled.Error(), getError(w).Detail)
+	})
+}
+
+func TestListUsers(t *testing.T) {
+	t.Run("should return 200 with the list response", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("ListUsers").Return(scimEntities.NewListResponse([]*scimEntities.User{}, 0, 0, 1), nil)
+		r, _ := http.NewRequest(http.MethodGet, `test?filter=userName+eq+"test"&startIndex=1&count=10`, nil)
+		w := httptest.NewRecorder()
+
+		handler.ListUsers(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), scimEntities.ListResponseSchema)
+	})
+
+	t.Run("should return 400 when filter is invalid", func(t *testing.T) {
+		handler, _ := newHandler()
+		r, _ := http.NewRequest(http.MethodGet, `test?filter=userName+co+"test"`, nil)
+		w := httptest.NewRecorder()
+
+		handler.ListUsers(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "invalidFilter", getError(w).ScimType)
+	})
+
+	t.Run("should return 400 when filter attribute is not supported", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("ListUsers").Return(&scimEntities.ListResponse{}, errorsEnums.ErrorScimInvalidFilter)
+		r, _ := http.NewRequest(http.MethodGet, `test?filter=title+eq+"test"`, nil)
+		w := httptest.NewRecorder()
+
+		handler.ListUsers(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetUser(t *testing.T) {
+	t.Run("should return 200 with the user", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("GetUser").Return(&scimEntities.User{UserName: "test"}, nil)
+		w := httptest.NewRecorder()
+
+		handler.GetUser(w, newRequest(http.MethodGet, "", "userID", uuid.New().String()))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 404 when id is invalid", func(t *testing.T) {
+		handler, _ := newHandler()
+		w := httptest.NewRecorder()
+
+		handler.GetUser(w, newRequest(http.MethodGet, "", "userID", "test"))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should return 404 when user does not exist", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("GetUser").Return(&scimEntities.User{}, errorsEnums.ErrorScimResourceNotFound)
+		w := httptest.NewRecorder()
+
+		handler.GetUser(w, newRequest(http.MethodGet, "", "userID", uuid.New().String()))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestCreateUser(t *testing.T) {
+	body := `{"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"], "userName": "test",
+		"emails": [{"value": "test@horusec.io", "primary": true}], "active": true}`
+
+	t.Run("should return 201 with the created user", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("CreateUser").Return(&scimEntities.User{UserName: "test"}, nil)
+		w := httptest.NewRecorder()
+
+		handler.CreateUser(w, newRequest(http.MethodPost, body, "", ""))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, scimEntities.ContentType, w.Header().Get("Content-Type"))
+	})
+
+	t.Run("should return 409 when user already exists", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("CreateUser").Return(&scimEntities.User{}, errorsEnums.ErrorScimUserAlreadyExists)
+		w := httptest.NewRecorder()
+
+		handler.CreateUser(w, newRequest(http.MethodPost, body, "", ""))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "uniqueness", getError(w).ScimType)
+	})
+
+	t.Run("should return 400 when user is invalid", func(t *testing.T) {
+		handler, _ := newHandler()
+		w := httptest.NewRecorder()
+
+		handler.CreateUser(w, newRequest(http.MethodPost, `{"userName": "test"}`, "", ""))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when body is invalid", func(t *testing.T) {
+		handler, _ := newHandler()
+		w := httptest.NewRecorder()
+
+		handler.CreateUser(w, newRequest(http.MethodPost, "invalid", "", ""))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "invalidSyntax", getError(w).ScimType)
+	})
+
+	t.Run("should return 500 when create fails", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("CreateUser").Return(&scimEntities.User{}, errors.New("test"))
+		w := httptest.NewRecorder()
+
+		handler.CreateUser(w, newRequest(http.MethodPost, body, "", ""))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestReplaceUser(t *testing.T) {
+	t.Run("should return 200 with the replaced user", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("ReplaceUser").Return(&scimEntities.User{}, nil)
+		w := httptest.NewRecorder()
+
+		handler.ReplaceUser(w, newRequest(http.MethodPut, `{"userName": "test@horusec.io", "active": false}`,
+			"userID", uuid.New().String()))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestPatchUser(t *testing.T) {
+	body := `{"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [{"op": "replace", "path": "active", "value": false}]}`
+
+	t.Run("should return 200 with the patched user", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("PatchUser").Return(&scimEntities.User{}, nil)
+		w := httptest.NewRecorder()
+
+		handler.PatchUser(w, newRequest(http.MethodPatch, body, "userID", uuid.New().String()))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 400 when patch value is invalid", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("PatchUser").Return(&scimEntities.User{}, errorsEnums.ErrorScimInvalidPatchValue)
+		w := httptest.NewRecorder()
+
+		handler.PatchUser(w, newRequest(http.MethodPatch, body, "userID", uuid.New().String()))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "invalidValue", getError(w).ScimType)
+	})
+
+	t.Run("should return 400 when patched user is invalid", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("PatchUser").Return(&scimEntities.User{}, (&scimEntities.User{}).Validate())
+		w := httptest.NewRecorder()
+
+		handler.PatchUser(w, newRequest(http.MethodPatch, body, "userID", uuid.New().String()))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 400 when body is invalid", func(t *testing.T) {
+		handler, _ := newHandler()
+		w := httptest.NewRecorder()
+
+		handler.PatchUser(w, newRequest(http.MethodPatch, "invalid", "userID", uuid.New().String()))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	t.Run("should return 204 when user is deleted", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("DeleteUser").Return(nil)
+		w := httptest.NewRecorder()
+
+		handler.DeleteUser(w, newRequest(http.MethodDelete, "", "userID", uuid.New().String()))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+}
+
+func TestGroups(t *testing.T) {
+	groupID := uuid.New().String()
+	body := `{"displayName": "security-team", "members": [{"value": "` + uuid.New().String() + `"}]}`
+
+	t.Run("should return 200 when list groups", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("ListGroups").Return(&scimEntities.ListResponse{}, nil)
+		r, _ := http.NewRequest(http.MethodGet, "test", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListGroups(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 400 when list groups with invalid filter", func(t *testing.T) {
+		handler, _ := newHandler()
+		r, _ := http.NewRequest(http.MethodGet, "test?filter=invalid", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListGroups(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 200 when get group", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("GetGroup").Return(&scimEntities.Group{}, nil)
+		w := httptest.NewRecorder()
+
+		handler.GetGroup(w, newRequest(http.MethodGet, "", "groupID", groupID))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 201 when create group", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("CreateGroup").Return(&scimEntities.Group{}, nil)
+		w := httptest.NewRecorder()
+
+		handler.CreateGroup(w, newRequest(http.MethodPost, body, "", ""))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("should return 400 when create group with invalid member", func(t *testing.T) {
+		handler, _ := newHandler()
+		w := httptest.NewRecorder()
+
+		handler.CreateGroup(w, newRequest(http.MethodPost, `{"displayName": "test", "members": [{"value": "x"}]}`,
+			"", ""))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should return 409 when group already exists", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("CreateGroup").Return(&scimEntities.Group{}, errorsEnums.ErrorScimGroupAlreadyExists)
+		w := httptest.NewRecorder()
+
+		handler.CreateGroup(w, newRequest(http.MethodPost, body, "", ""))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("should return 200 when replace group", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("ReplaceGroup").Return(&scimEntities.Group{}, nil)
+		w := httptest.NewRecorder()
+
+		handler.ReplaceGroup(w, newRequest(http.MethodPut, body, "groupID", groupID))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 400 when patch group path is invalid", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("PatchGroup").Return(&scimEntities.Group{}, errorsEnums.ErrorScimInvalidPatchPath)
+		w := httptest.NewRecorder()
+
+		handler.PatchGroup(w, newRequest(http.MethodPatch, `{"Operations": []}`, "groupID", groupID))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "invalidPath", getError(w).ScimType)
+	})
+
+	t.Run("should return 204 when delete group", func(t *testing.T) {
+		handler, controllerMock := newHandler()
+		controllerMock.On("DeleteGroup").Return(nil)
+		w := httptest.NewRecorder()
+
+		handler.DeleteGroup(w, newRequest(http.MethodDelete, "", "groupID", groupID))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("should return 404 when delete group with invalid id", func(t *testing.T) {
+		handler, _ := newHandler()
+		w := httptest.NewRecorder()
+
+		handler.DeleteGroup(w, newRequest(http.MethodDelete, "", "groupID", "test"))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobs
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
+	"github.com/ZupIT/horusec/horusec-auth/internal/controller/ldapsync"
+	"github.com/stretchr/testify/assert"
+)
+
+type countController struct {
+	syncCount int32
+}
+
+func (c *countController) Sync(dryRun bool) (*dto.LdapSyncReport, error) {
+	atomic.AddInt32(&c.syncCount, 1)
+	return dto.NewLdapSyncReport(dryRun), nil
+}
+
+func TestLdapSyncJobRun(t *testing.T) {
+	t.Run("should sync ldap groups", func(t *testing.T) {
+		controllerMock := &ldapsync.Mock{}
+		controllerMock.On("Sync").Return(dto.NewLdapSyncReport(false), nil)
+
+		NewLdapSyncJob(controllerMock, time.Minute).Run()
+
+		controllerMock.AssertCalled(t, "Sync")
+	})
+
+	t.Run("should not panic when sync fails", func(t *testing.T) {
+		controllerMock := &ldapsync.Mock{}
+		controllerMock.On("Sync").Return(&dto.LdapSyncReport{}, errors.New("test"))
+
+		assert.NotPanics(t, func() {
+			NewLdapSyncJob(controllerMock, time.Minute).Run()
+		})
+	})
+}
+
+func TestLdapSyncJobStart(t *testing.T) {
+	t.Run("should run job periodically", func(t *testing.T) {
+		controller := &countController{}
+
+		NewLdapSyncJob(controller, time.Millisecond).Start()
+
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&controller.syncCount) >= 2
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("should not start when interval is zero", func(t *testing.T) {
+		controllerMock := &ldapsync.Mock{}
+
+		NewLdapSyncJob(controllerMock, 0).Start()
+
+		controllerMock.AssertNotCalled(t, "Sync")
+	})
+}
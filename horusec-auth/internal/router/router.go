@@ -18,11 +18,14 @@ import (
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	cacheRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/cache"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/middlewares"
 	serverConfig "github.com/ZupIT/horusec/development-kit/pkg/utils/http/server"
 	"github.com/ZupIT/horusec/horusec-auth/config/app"
 	"github.com/ZupIT/horusec/horusec-auth/internal/handler/account"
 	"github.com/ZupIT/horusec/horusec-auth/internal/handler/auth"
 	"github.com/ZupIT/horusec/horusec-auth/internal/handler/health"
+	"github.com/ZupIT/horusec/horusec-auth/internal/handler/ldapsync"
+	"github.com/ZupIT/horusec/horusec-auth/internal/handler/scim"
 	"github.com/ZupIT/horusec/horusec-auth/internal/router/routes"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
@@ -41,8 +44,8 @@ func NewRouter(config *serverConfig.Server) *Router {
 	}
 }
 
-func (r *Router) setMiddleware() {
-	r.EnableRealIP()
+func (r *Router) setMiddleware(appConfig *app.Config) {
+	r.EnableRealIP(appConfig)
 	r.EnableLogger()
 	r.EnableRecover()
 	r.EnableTimeout()
@@ -54,15 +57,17 @@ func (r *Router) setMiddleware() {
 
 func (r *Router) GetRouter(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite,
 	broker brokerLib.IBroker, cache cacheRepository.Interface, appConfig *app.Config) *chi.Mux {
-	r.setMiddleware()
-	r.RouterAuth(postgresRead, postgresWrite, appConfig)
+	r.setMiddleware(appConfig)
+	r.RouterAuth(postgresRead, postgresWrite, broker, appConfig)
 	r.RouterHealth(postgresRead, postgresWrite, appConfig)
 	r.RouterAccount(postgresRead, postgresWrite, broker, cache, appConfig)
+	r.RouterLdapSync(postgresRead, postgresWrite, appConfig)
+	r.RouterScim(postgresRead, postgresWrite, appConfig)
 	return r.router
 }
 
-func (r *Router) EnableRealIP() *Router {
-	r.router.Use(middleware.RealIP)
+func (r *Router) EnableRealIP(appConfig *app.Config) *Router {
+	r.router.Use(middlewares.NewRealIPMiddleware(appConfig.GetTrustedProxies()).RealIP)
 	return r
 }
 
@@ -112,12 +117,15 @@ func (r *Router) RouterHealth(
 	return r
 }
 
-func (r *Router) RouterAuth(
-	postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite, appConfig *app.Config) *Router {
-	handler := auth.NewAuthHandler(postgresRead, postgresWrite, appConfig)
+func (r *Router) RouterAuth(postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite,
+	broker brokerLib.IBroker, appConfig *app.Config) *Router {
+	handler := auth.NewAuthHandler(broker, postgresRead, postgresWrite, appConfig)
 	r.router.Route(routes.AuthHandler, func(router chi.Router) {
 		router.Get("/config", handler.Config)
 		router.Post("/authenticate", handler.AuthByType)
+		router.Get("/oidc/authorize", handler.OIDCAuthorize)
+		router.Get("/unlock/{code}", handler.UnlockConfirm)
+		router.Post("/unlock/{code}", handler.Unlock)
 		router.Options("/", handler.Options)
 	})
 
@@ -140,8 +148,48 @@ func (r *Router) RouterAccount(postgresRead relational.InterfaceRead, postgresWr
 		router.Delete("/delete", handler.DeleteAccount)
 		router.Post("/verify-already-used", handler.VerifyAlreadyInUse)
 		router.Patch("/update", handler.Update)
+		router.Post("/two-factor/enroll", handler.EnrollTwoFactor)
+		router.Post("/two-factor/enable", handler.EnableTwoFactor)
+		router.Post("/two-factor/disable", handler.DisableTwoFactor)
+		router.Post("/personal-tokens", handler.CreatePersonalToken)
+		router.Get("/personal-tokens", handler.ListPersonalTokens)
+		router.Delete("/personal-tokens/{personalTokenID}", handler.RevokePersonalToken)
+		router.Options("/", handler.Options)
+	})
+
+	return r
+}
+
+func (r *Router) RouterLdapSync(
+	postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite, appConfig *app.Config) *Router {
+	handler := ldapsync.NewHandler(postgresRead, postgresWrite, appConfig)
+	r.router.Route(routes.LdapSyncHandler, func(router chi.Router) {
+		router.Post("/", handler.Sync)
+		router.Get("/dry-run", handler.DryRun)
 		router.Options("/", handler.Options)
 	})
 
 	return r
 }
+
+func (r *Router) RouterScim(
+	postgresRead relational.InterfaceRead, postgresWrite relational.InterfaceWrite, appConfig *app.Config) *Router {
+	handler := scim.NewHandler(postgresRead, postgresWrite, appConfig)
+	r.router.Route(routes.ScimHandler, func(router chi.Router) {
+		router.Use(handler.Authenticate)
+		router.Get("/Users", handler.ListUsers)
+		router.Post("/Users", handler.CreateUser)
+		router.Get("/Users/{userID}", handler.GetUser)
+		router.Put("/Users/{userID}", handler.ReplaceUser)
+		router.Patch("/Users/{userID}", handler.PatchUser)
+		router.Delete("/Users/{userID}", handler.DeleteUser)
+		router.Get("/Groups", handler.ListGroups)
+		router.Post("/Groups", handler.CreateGroup)
+		router.Get("/Groups/{groupID}", handler.GetGroup)
+		router.Put("/Groups/{groupID}", handler.ReplaceGroup)
+		router.Patch("/Groups/{groupID}", handler.PatchGroup)
+		router.Delete("/Groups/{groupID}", handler.DeleteGroup)
+	})
+
+	return r
+}
@@ -15,7 +15,9 @@
 package routes
 
 const (
-	HealthHandler  = "/auth/health"
-	AuthHandler    = "/auth/auth"
-	AccountHandler = "/auth/account"
+	HealthHandler   = "/auth/health"
+	AuthHandler     = "/auth/auth"
+	AccountHandler  = "/auth/account"
+	LdapSyncHandler = "/auth/ldap-sync"
+	ScimHandler     = "/auth/scim/v2"
 )
@@ -0,0 +1,55 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountRepositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_repository"
+	customRoleRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/custom_role"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/google/uuid"
+)
+
+type IService interface {
+	HasPermission(accountID, repositoryID uuid.UUID, permission authEnums.Permission) (bool, error)
+}
+
+type Service struct {
+	accountRepositoryRepository accountRepositoryRepo.IAccountRepository
+	customRoleRepository        customRoleRepo.IRepository
+}
+
+func NewService(databaseRead relational.InterfaceRead, databaseWrite relational.InterfaceWrite) IService {
+	return &Service{
+		accountRepositoryRepository: accountRepositoryRepo.NewAccountRepositoryRepository(databaseRead, databaseWrite),
+		customRoleRepository:        customRoleRepo.NewCustomRoleRepository(databaseRead, databaseWrite),
+	}
+}
+
+// HasPermission checks the custom role of the account in the repository, only roles of the repository company count
+func (s *Service) HasPermission(accountID, repositoryID uuid.UUID, permission authEnums.Permission) (bool, error) {
+	accountRepository, err := s.accountRepositoryRepository.GetAccountRepository(accountID, repositoryID)
+	if err != nil || !accountRepository.HasCustomRole() {
+		return false, errors.ErrorUnauthorizedRepositoryMember
+	}
+
+	customRole, err := s.customRoleRepository.Get(*accountRepository.CustomRoleID, accountRepository.CompanyID)
+	if err != nil {
+		return false, errors.ErrorUnauthorizedRepositoryMember
+	}
+
+	return customRole.HasPermission(permission), nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountRepositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_repository"
+	customRoleRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/custom_role"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/roles"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func newService(accountRepository *roles.AccountRepository, customRole *roles.CustomRole, err error) *Service {
+	accountRepositoryMock := &accountRepositoryRepo.Mock{}
+	accountRepositoryMock.On("GetAccountRepository").Return(accountRepository, err)
+	customRoleMock := &customRoleRepo.Mock{}
+	customRoleMock.On("Get").Return(customRole, err)
+
+	return &Service{
+		accountRepositoryRepository: accountRepositoryMock,
+		customRoleRepository:        customRoleMock,
+	}
+}
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("HasPermission").Return(true, nil)
+	hasPermission, err := m.HasPermission(uuid.New(), uuid.New(), authEnums.ManageRepository)
+	assert.NoError(t, err)
+	assert.True(t, hasPermission)
+}
+
+func TestNewService(t *testing.T) {
+	t.Run("should create a new service", func(t *testing.T) {
+		assert.NotNil(t, NewService(&relational.MockRead{}, &relational.MockWrite{}))
+	})
+}
+
+func TestHasPermission(t *testing.T) {
+	customRoleID := uuid.New()
+	customRole := &roles.CustomRole{CustomRoleID: customRoleID,
+		Permissions: pq.StringArray{authEnums.ManageVulnerabilityTypes.ToString()}}
+
+	t.Run("should return true when custom role has the permission", func(t *testing.T) {
+		service := newService(&roles.AccountRepository{CustomRoleID: &customRoleID}, customRole, nil)
+
+		hasPermission, err := service.HasPermission(uuid.New(), uuid.New(), authEnums.ManageVulnerabilityTypes)
+
+		assert.NoError(t, err)
+		assert.True(t, hasPermission)
+	})
+
+	t.Run("should return false when custom role does not have the permission", func(t *testing.T) {
+		service := newService(&roles.AccountRepository{CustomRoleID: &customRoleID}, customRole, nil)
+
+		hasPermission, err := service.HasPermission(uuid.New(), uuid.New(), authEnums.ManageRepositoryTokens)
+
+		assert.NoError(t, err)
+		assert.False(t, hasPermission)
+	})
+
+	t.Run("should return error when account does not have custom role", func(t *testing.T) {
+		service := newService(&roles.AccountRepository{}, customRole, nil)
+
+		hasPermission, err := service.HasPermission(uuid.New(), uuid.New(), authEnums.ManageVulnerabilityTypes)
+
+		assert.Error(t, err)
+		assert.False(t, hasPermission)
+	})
+
+	t.Run("should return error when get account repository fails", func(t *testing.T) {
+		service := newService(&roles.AccountRepository{}, customRole, errors.New("test"))
+
+		hasPermission, err := service.HasPermission(uuid.New(), uuid.New(), authEnums.ManageVulnerabilityTypes)
+
+		assert.Error(t, err)
+		assert.False(t, hasPermission)
+	})
+
+	t.Run("should return error when custom role is not of the repository company", func(t *testing.T) {
+		customRoleMock := &customRoleRepo.Mock{}
+		customRoleMock.On("Get").Return(&roles.CustomRole{}, errors.New("test"))
+		service := newService(&roles.AccountRepository{CustomRoleID: &customRoleID}, customRole, nil)
+		service.customRoleRepository = customRoleMock
+
+		hasPermission, err := service.HasPermission(uuid.New(), uuid.New(), authEnums.ManageVulnerabilityTypes)
+
+		assert.Error(t, err)
+		assert.False(t, hasPermission)
+	})
+}
@@ -22,7 +22,9 @@ import (
 	repositoryAccountCompany "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_company"
 	repoAccountRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_repository"
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/cache"
+	repositoryCompany "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
 	repositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
 	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
 	entityCache "github.com/ZupIT/horusec/development-kit/pkg/entities/cache"
@@ -38,6 +40,7 @@ type Service struct {
 	repoAccountCompany    repositoryAccountCompany.IAccountCompany
 	repoAccountRepository repoAccountRepository.IAccountRepository
 	repositoryRepo        repositoryRepo.IRepository
+	companyRepository     repositoryCompany.ICompanyRepository
 	accountRepository     repositoryAccount.IAccount
 	cacheRepository       cache.Interface
 	authUseCases          authUseCases.IUseCases
@@ -50,6 +53,7 @@ func NewHorusAuthService(
 		repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(postgresRead, postgresWrite),
 		repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(postgresRead, postgresWrite),
 		repositoryRepo:        repositoryRepo.NewRepository(postgresRead, postgresWrite),
+		companyRepository:     repositoryCompany.NewCompanyRepository(postgresRead, postgresWrite),
 		accountRepository:     repositoryAccount.NewAccountRepository(postgresRead, postgresWrite),
 		accountRepositoryRepo: repoAccountRepository.NewAccountRepositoryRepository(postgresRead, postgresWrite),
 		cacheRepository:       cache.NewCacheRepository(postgresRead, postgresWrite),
@@ -61,6 +65,7 @@ func (s *Service) Authenticate(credentials *dto.Credentials) (interface{}, error
 	loginData := &dto.LoginData{
 		Email:    credentials.Username,
 		Password: credentials.Password,
+		Otp:      credentials.Otp,
 	}
 
 	return s.login(loginData)
@@ -76,9 +81,48 @@ func (s *Service) login(loginData *dto.LoginData) (*dto.LoginResponse, error) {
 		return nil, err
 	}
 
+	if err := s.validateTwoFactor(account, loginData.Otp); err != nil {
+		return nil, err
+	}
+
 	return s.setLoginResponse(account)
 }
 
+// validateTwoFactor is the second login step, the otp accepts the authenticator app code or an unused recovery code.
+func (s *Service) validateTwoFactor(account *authEntities.Account, otp string) error {
+	if !account.IsTwoFactorEnabled {
+		return nil
+	}
+
+	if otp == "" {
+		return errors.ErrorTwoFactorRequired
+	}
+
+	if account.UseTotpCode(otp) {
+		return s.updateTwoFactorStep(account)
+	}
+
+	if account.UseRecoveryCode(otp) {
+		return s.accountRepository.UpdateTwoFactor(account)
+	}
+
+	return errors.ErrorInvalidTwoFactorCode
+}
+
+// updateTwoFactorStep denies the code when another login accepted it first
+func (s *Service) updateTwoFactorStep(account *authEntities.Account) error {
+	updated, err := s.accountRepository.UpdateTwoFactorStep(account)
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		return errors.ErrorInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
 func (s *Service) setLoginResponse(account *authEntities.Account) (*dto.LoginResponse, error) {
 	accessToken, expiresAt, _ := jwt.CreateToken(account, nil)
 	refreshToken := jwt.CreateRefreshToken()
@@ -92,7 +136,46 @@ func (s *Service) setLoginResponse(account *authEntities.Account) (*dto.LoginRes
 }
 
 func (s *Service) IsAuthorized(authorizationData *dto.AuthorizationData) (bool, error) {
-	return s.authorizeByRole()[authorizationData.Role](authorizationData)
+	isAuthorized, err := s.authorizeByRole()[authorizationData.Role](authorizationData)
+	if err != nil || !isAuthorized || authorizationData.Role == authEnums.ApplicationAdmin {
+		return isAuthorized, err
+	}
+
+	return s.checkCompanyTwoFactorPolicy(authorizationData)
+}
+
+// checkCompanyTwoFactorPolicy denies the resources of companies that require two factor to accounts without it enabled.
+// When the policy can not be checked the access is denied.
+func (s *Service) checkCompanyTwoFactorPolicy(authorizationData *dto.AuthorizationData) (bool, error) {
+	company, err := s.getCompany(authorizationData)
+	if err != nil {
+		return false, err
+	}
+
+	if !company.RequireTwoFactor {
+		return true, nil
+	}
+
+	accountID, _ := jwt.GetAccountIDByJWTToken(authorizationData.Token)
+	account, err := s.accountRepository.GetByAccountID(accountID)
+	if err != nil || !account.IsTwoFactorEnabled {
+		return false, errors.ErrorTwoFactorRequiredByCompany
+	}
+
+	return true, nil
+}
+
+func (s *Service) getCompany(authorizationData *dto.AuthorizationData) (*accountEntities.Company, error) {
+	if authorizationData.CompanyID != uuid.Nil {
+		return s.companyRepository.GetByID(authorizationData.CompanyID)
+	}
+
+	repository, err := s.repositoryRepo.Get(authorizationData.RepositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.companyRepository.GetByID(repository.CompanyID)
 }
 
 func (s *Service) authorizeByRole() map[authEnums.HorusecRoles]func(*dto.AuthorizationData) (bool, error) {
@@ -24,7 +24,9 @@ import (
 	repositoryAccountCompany "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_company"
 	repoAccountRepository "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account_repository"
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/cache"
+	repositoryCompany "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
 	repositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
 	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
 	entityCache "github.com/ZupIT/horusec/development-kit/pkg/entities/cache"
@@ -34,6 +36,7 @@ import (
 	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/jwt"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/totp"
 	authUseCases "github.com/ZupIT/horusec/horusec-auth/internal/usecases/auth"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
@@ -93,6 +96,7 @@ func TestAuthenticate(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, mockWrite),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, mockWrite),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, mockWrite),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, mockWrite),
 			accountRepository:     repositoryAccount.NewAccountRepository(mockRead, mockWrite),
 			accountRepositoryRepo: repoAccountRepository.NewAccountRepositoryRepository(mockRead, mockWrite),
 			cacheRepository:       cacheRepositoryMock,
@@ -137,6 +141,7 @@ func TestAuthenticate(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, mockWrite),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, mockWrite),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, mockWrite),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, mockWrite),
 			accountRepository:     repositoryAccount.NewAccountRepository(mockRead, mockWrite),
 			accountRepositoryRepo: repoAccountRepository.NewAccountRepositoryRepository(mockRead, mockWrite),
 			cacheRepository:       cacheRepositoryMock,
@@ -176,6 +181,7 @@ func TestAuthenticate(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, mockWrite),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, mockWrite),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, mockWrite),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, mockWrite),
 			accountRepository:     repositoryAccount.NewAccountRepository(mockRead, mockWrite),
 			accountRepositoryRepo: repoAccountRepository.NewAccountRepositoryRepository(mockRead, mockWrite),
 			cacheRepository:       cacheRepositoryMock,
@@ -223,6 +229,7 @@ func TestAuthenticate(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, mockWrite),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, mockWrite),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, mockWrite),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, mockWrite),
 			accountRepository:     repositoryAccount.NewAccountRepository(mockRead, mockWrite),
 			accountRepositoryRepo: repoAccountRepository.NewAccountRepositoryRepository(mockRead, mockWrite),
 			cacheRepository:       cacheRepositoryMock,
@@ -242,6 +249,222 @@ func TestAuthenticate(t *testing.T) {
 	})
 }
 
+func TestAuthenticateTwoFactor(t *testing.T) {
+	secret, _ := totp.NewSecret()
+	newAccount := func() *authEntities.Account {
+		account := &authEntities.Account{
+			AccountID:   uuid.New(),
+			Email:       "test@test.com",
+			Password:    "$2a$10$rkdf/ZuW4Gn1KTDNTRyhdelrwL8GW7mPARwRfLKkCKuq/6vyHu2H.",
+			Username:    "test",
+			IsConfirmed: true,
+		}
+
+		return account.SetTwoFactorSecret(secret).EnableTwoFactor([]string{"aaaaa-bbbbb"})
+	}
+
+	newService := func(accountMock *repositoryAccount.Mock) *Service {
+		cacheRepositoryMock := &cache.Mock{}
+		cacheRepositoryMock.On("Set").Return(nil)
+
+		return &Service{
+			accountRepository: accountMock,
+			cacheRepository:   cacheRepositoryMock,
+			authUseCases:      authUseCases.NewAuthUseCases(),
+		}
+	}
+
+	t.Run("should return two factor required when otp is not informed", func(t *testing.T) {
+		accountMock := &repositoryAccount.Mock{}
+		accountMock.On("GetByEmail").Return(newAccount(), nil)
+
+		result, err := newService(accountMock).Authenticate(&dto.Credentials{Username: "test@test.com", Password: "test"})
+
+		assert.Equal(t, errorsEnum.ErrorTwoFactorRequired, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should success authenticate with totp code", func(t *testing.T) {
+		accountMock := &repositoryAccount.Mock{}
+		accountMock.On("GetByEmail").Return(newAccount(), nil)
+		accountMock.On("UpdateTwoFactorStep").Return(true, nil)
+		code, _ := totp.GenerateCode(secret, time.Now())
+
+		result, err := newService(accountMock).Authenticate(
+			&dto.Credentials{Username: "test@test.com", Password: "test", Otp: code})
+
+		assert.NoError(t, err)
+		assert.True(t, result.(*dto.LoginResponse).IsTwoFactorEnabled)
+		accountMock.AssertCalled(t, "UpdateTwoFactorStep")
+	})
+
+	t.Run("should return error when totp code was already used", func(t *testing.T) {
+		account := newAccount()
+		code, _ := totp.GenerateCode(secret, time.Now())
+		assert.True(t, account.UseTotpCode(code))
+
+		accountMock := &repositoryAccount.Mock{}
+		accountMock.On("GetByEmail").Return(account, nil)
+
+		result, err := newService(accountMock).Authenticate(
+			&dto.Credentials{Username: "test@test.com", Password: "test", Otp: code})
+
+		assert.Equal(t, errorsEnum.ErrorInvalidTwoFactorCode, err)
+		assert.Nil(t, result)
+		accountMock.AssertNotCalled(t, "UpdateTwoFactorStep")
+	})
+
+	t.Run("should return error when totp code was used by a concurrent login", func(t *testing.T) {
+		accountMock := &repositoryAccount.Mock{}
+		accountMock.On("GetByEmail").Return(newAccount(), nil)
+		accountMock.On("UpdateTwoFactorStep").Return(false, nil)
+		code, _ := totp.GenerateCode(secret, time.Now())
+
+		result, err := newService(accountMock).Authenticate(
+			&dto.Credentials{Username: "test@test.com", Password: "test", Otp: code})
+
+		assert.Equal(t, errorsEnum.ErrorInvalidTwoFactorCode, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should return error when failed to save the totp step", func(t *testing.T) {
+		accountMock := &repositoryAccount.Mock{}
+		accountMock.On("GetByEmail").Return(newAccount(), nil)
+		accountMock.On("UpdateTwoFactorStep").Return(false, errors.New("test"))
+		code, _ := totp.GenerateCode(secret, time.Now())
+
+		_, err := newService(accountMock).Authenticate(
+			&dto.Credentials{Username: "test@test.com", Password: "test", Otp: code})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should success authenticate with recovery code and consume it", func(t *testing.T) {
+		accountMock := &repositoryAccount.Mock{}
+		accountMock.On("GetByEmail").Return(newAccount(), nil)
+		accountMock.On("UpdateTwoFactor").Return(nil)
+
+		result, err := newService(accountMock).Authenticate(
+			&dto.Credentials{Username: "test@test.com", Password: "test", Otp: "aaaaa-bbbbb"})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		accountMock.AssertCalled(t, "UpdateTwoFactor")
+	})
+
+	t.Run("should return error when invalid otp", func(t *testing.T) {
+		accountMock := &repositoryAccount.Mock{}
+		accountMock.On("GetByEmail").Return(newAccount(), nil)
+
+		result, err := newService(accountMock).Authenticate(
+			&dto.Credentials{Username: "test@test.com", Password: "test", Otp: "000000"})
+
+		assert.Equal(t, errorsEnum.ErrorInvalidTwoFactorCode, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should not ask otp before validating the password", func(t *testing.T) {
+		accountMock := &repositoryAccount.Mock{}
+		accountMock.On("GetByEmail").Return(newAccount(), nil)
+
+		_, err := newService(accountMock).Authenticate(&dto.Credentials{Username: "test@test.com", Password: "wrong"})
+
+		assert.Equal(t, errorsEnum.ErrorWrongEmailOrPassword, err)
+	})
+}
+
+func TestIsAuthorizedCompanyTwoFactorPolicy(t *testing.T) {
+	newService := func(company *accountEntities.Company, account *authEntities.Account) *Service {
+		mockRead := &relational.MockRead{}
+		mockRead.On("Find").Return(&response.Response{})
+		mockRead.On("SetFilter").Return(&gorm.DB{})
+
+		companyMock := &repositoryCompany.Mock{}
+		companyMock.On("GetByID").Return(company, nil)
+
+		accountMock := &repositoryAccount.Mock{}
+		accountMock.On("GetByAccountID").Return(account, nil)
+
+		return &Service{
+			repoAccountCompany: repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
+			companyRepository:  companyMock,
+			accountRepository:  accountMock,
+		}
+	}
+
+	authorizationData := &dto.AuthorizationData{
+		Token:     generateToken(),
+		Role:      authEnums.CompanyMember,
+		CompanyID: uuid.New(),
+	}
+
+	t.Run("should return error when company requires two factor and account has not enabled", func(t *testing.T) {
+		service := newService(&accountEntities.Company{RequireTwoFactor: true}, &authEntities.Account{})
+
+		result, err := service.IsAuthorized(authorizationData)
+
+		assert.Equal(t, errorsEnum.ErrorTwoFactorRequiredByCompany, err)
+		assert.False(t, result)
+	})
+
+	t.Run("should authorize when company requires two factor and account has enabled", func(t *testing.T) {
+		service := newService(&accountEntities.Company{RequireTwoFactor: true},
+			&authEntities.Account{IsTwoFactorEnabled: true})
+
+		result, err := service.IsAuthorized(authorizationData)
+
+		assert.NoError(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("should authorize when company does not require two factor", func(t *testing.T) {
+		service := newService(&accountEntities.Company{}, &authEntities.Account{})
+
+		result, err := service.IsAuthorized(authorizationData)
+
+		assert.NoError(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("should not authorize when failed to get the company policy", func(t *testing.T) {
+		service := newService(&accountEntities.Company{}, &authEntities.Account{})
+		companyMock := &repositoryCompany.Mock{}
+		companyMock.On("GetByID").Return(&accountEntities.Company{}, errors.New("test"))
+		service.companyRepository = companyMock
+
+		result, err := service.IsAuthorized(authorizationData)
+
+		assert.Error(t, err)
+		assert.False(t, result)
+	})
+
+	t.Run("should check the company of the repository when company id is not informed", func(t *testing.T) {
+		service := newService(&accountEntities.Company{RequireTwoFactor: true}, &authEntities.Account{})
+		repositoryMock := &repositoryRepo.Mock{}
+		repositoryMock.On("Get").Return(&accountEntities.Repository{CompanyID: uuid.New()}, nil)
+		service.repositoryRepo = repositoryMock
+
+		result, err := service.checkCompanyTwoFactorPolicy(&dto.AuthorizationData{Token: generateToken(),
+			RepositoryID: uuid.New()})
+
+		assert.Equal(t, errorsEnum.ErrorTwoFactorRequiredByCompany, err)
+		assert.False(t, result)
+	})
+
+	t.Run("should not authorize when failed to get the repository of the policy", func(t *testing.T) {
+		service := newService(&accountEntities.Company{}, &authEntities.Account{})
+		repositoryMock := &repositoryRepo.Mock{}
+		repositoryMock.On("Get").Return(&accountEntities.Repository{}, errors.New("test"))
+		service.repositoryRepo = repositoryMock
+
+		result, err := service.checkCompanyTwoFactorPolicy(&dto.AuthorizationData{Token: generateToken(),
+			RepositoryID: uuid.New()})
+
+		assert.Error(t, err)
+		assert.False(t, result)
+	})
+}
+
 func TestIsAuthorizedCompanyMember(t *testing.T) {
 	t.Run("should success authenticate with company member", func(t *testing.T) {
 		mockRead := &relational.MockRead{}
@@ -258,6 +481,7 @@ func TestIsAuthorizedCompanyMember(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -288,6 +512,7 @@ func TestIsAuthorizedCompanyMember(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -314,6 +539,7 @@ func TestIsAuthorizedCompanyMember(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -336,6 +562,7 @@ func TestIsAuthorizedCompanyMember(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -370,6 +597,7 @@ func TestIsAuthorizedCompanyAdmin(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -400,6 +628,7 @@ func TestIsAuthorizedCompanyAdmin(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -430,6 +659,7 @@ func TestIsAuthorizedCompanyAdmin(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -452,6 +682,7 @@ func TestIsAuthorizedCompanyAdmin(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -486,6 +717,7 @@ func TestIsAuthorizedRepositoryMember(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -516,6 +748,7 @@ func TestIsAuthorizedRepositoryMember(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -546,6 +779,7 @@ func TestIsAuthorizedRepositoryMember(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -572,6 +806,7 @@ func TestIsAuthorizedRepositoryMember(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -594,6 +829,7 @@ func TestIsAuthorizedRepositoryMember(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -628,6 +864,7 @@ func TestIsAuthorizedRepositoryMember(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -660,6 +897,7 @@ func TestIsAuthorizedRepositorySupervisor(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -690,6 +928,7 @@ func TestIsAuthorizedRepositorySupervisor(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -722,6 +961,7 @@ func TestIsAuthorizedRepositorySupervisor(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -754,6 +994,7 @@ func TestIsAuthorizedRepositorySupervisor(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -780,6 +1021,7 @@ func TestIsAuthorizedRepositorySupervisor(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -806,6 +1048,7 @@ func TestIsAuthorizedRepositorySupervisor(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -840,6 +1083,7 @@ func TestIsAuthorizedRepositoryAdmin(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -872,6 +1116,7 @@ func TestIsAuthorizedRepositoryAdmin(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -902,6 +1147,7 @@ func TestIsAuthorizedRepositoryAdmin(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -932,6 +1178,7 @@ func TestIsAuthorizedRepositoryAdmin(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -958,6 +1205,7 @@ func TestIsAuthorizedRepositoryAdmin(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -984,6 +1232,7 @@ func TestIsAuthorizedRepositoryAdmin(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -1018,6 +1267,7 @@ func TestIsAuthorizedRepositoryAdmin(t *testing.T) {
 			repoAccountCompany:    repositoryAccountCompany.NewAccountCompanyRepository(mockRead, nil),
 			repoAccountRepository: repoAccountRepository.NewAccountRepositoryRepository(mockRead, nil),
 			repositoryRepo:        repositoryRepo.NewRepository(mockRead, nil),
+			companyRepository:     repositoryCompany.NewCompanyRepository(mockRead, nil),
 		}
 
 		authorizationData := &dto.AuthorizationData{
@@ -0,0 +1,260 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockout
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/cache"
+	loginAttemptsRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/login_attempts"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/audit"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	entityCache "github.com/ZupIT/horusec/development-kit/pkg/entities/cache"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	auditEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/audit"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	emailEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/messages"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/queues"
+	auditService "github.com/ZupIT/horusec/development-kit/pkg/services/audit"
+	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-auth/config/app"
+	"github.com/google/uuid"
+)
+
+type IService interface {
+	Check(username, ip string) (time.Duration, error)
+	RegisterFailure(username, ip string)
+	RegisterSuccess(username string)
+	Unlock(code string) error
+	GetClientIP(r *http.Request) string
+}
+
+type Service struct {
+	cacheRepository   cache.Interface
+	loginAttempts     loginAttemptsRepo.IRepository
+	accountRepository accountRepo.IAccount
+	broker            brokerLib.IBroker
+	auditService      auditService.IService
+	appConfig         *app.Config
+}
+
+func NewService(broker brokerLib.IBroker, databaseRead relational.InterfaceRead,
+	databaseWrite relational.InterfaceWrite, appConfig *app.Config) IService {
+	return &Service{
+		cacheRepository:   cache.NewCacheRepository(databaseRead, databaseWrite),
+		loginAttempts:     loginAttemptsRepo.NewLoginAttemptsRepository(databaseRead, databaseWrite),
+		accountRepository: accountRepo.NewAccountRepository(databaseRead, databaseWrite),
+		broker:            broker,
+		auditService:      auditService.NewAuditService(databaseRead, databaseWrite, broker),
+		appConfig:         appConfig,
+	}
+}
+
+// Check returns how long the client must wait before trying to login again with this username or ip
+func (s *Service) Check(username, ip string) (time.Duration, error) {
+	account, _ := s.getAccount(username)
+	accountAttempts := s.getAttempts(s.getAccountKey(username, account))
+	if accountAttempts.IsLocked() {
+		return accountAttempts.RetryAfter(), errors.ErrorAccountLocked
+	}
+
+	ipAttempts := s.getAttempts(authEntities.LoginAttemptsIPKeyPrefix + ip)
+	retryAfter := accountAttempts.RetryAfter()
+	if ipAttempts.RetryAfter() > retryAfter {
+		retryAfter = ipAttempts.RetryAfter()
+	}
+
+	if retryAfter > 0 {
+		return retryAfter, errors.ErrorTooManyLoginAttempts
+	}
+
+	return 0, nil
+}
+
+func (s *Service) RegisterFailure(username, ip string) {
+	account, _ := s.getAccount(username)
+	if s.registerFailure(s.getAccountKey(username, account), s.appConfig.GetLoginMaxAttemptsPerAccount()) {
+		s.onAccountLocked(username, ip, account)
+	}
+
+	if s.registerFailure(authEntities.LoginAttemptsIPKeyPrefix+ip, s.appConfig.GetLoginMaxAttemptsPerIP()) {
+		logger.LogWarnWithLevel("{AUDIT} ip locked after failed login attempts", map[string]interface{}{"ip": ip})
+	}
+}
+
+func (s *Service) RegisterSuccess(username string) {
+	account, _ := s.getAccount(username)
+	if err := s.deleteAccountAttempts(username, account); err != nil {
+		logger.LogError(errors.ErrorRegisterLoginFailure, err)
+	}
+}
+
+func (s *Service) Unlock(code string) error {
+	unlock, err := s.cacheRepository.Get(authEntities.LoginUnlockKeyPrefix + code)
+	if err != nil {
+		return err
+	}
+
+	if len(unlock.Value) == 0 {
+		return errors.ErrorInvalidUnlockCode
+	}
+
+	account, _ := s.getAccount(string(unlock.Value))
+	if err := s.deleteAccountAttempts(string(unlock.Value), account); err != nil {
+		return err
+	}
+
+	s.onAccountUnlocked(string(unlock.Value), account)
+	return s.cacheRepository.Del(authEntities.LoginUnlockKeyPrefix + code)
+}
+
+func (s *Service) GetClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// getAccountKey uses the account id when the account exists, so the failures with its email and username are counted
+// together, and the typed username otherwise
+func (s *Service) getAccountKey(username string, account *authEntities.Account) string {
+	if account == nil {
+		return s.getUsernameKey(username)
+	}
+
+	return authEntities.LoginAttemptsAccountKeyPrefix + account.AccountID.String()
+}
+
+func (s *Service) getUsernameKey(username string) string {
+	return authEntities.LoginAttemptsAccountKeyPrefix + s.normalizeUsername(username)
+}
+
+// deleteAccountAttempts also deletes the failures of the typed username, counted while the account did not exist
+func (s *Service) deleteAccountAttempts(username string, account *authEntities.Account) error {
+	if account != nil {
+		if err := s.loginAttempts.Delete(s.getAccountKey(username, account)); err != nil {
+			return err
+		}
+	}
+
+	return s.loginAttempts.Delete(s.getUsernameKey(username))
+}
+
+func (s *Service) normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+func (s *Service) getAttempts(key string) *authEntities.LoginAttempts {
+	attempts, err := s.loginAttempts.Get(key)
+	if err != nil {
+		logger.LogError(errors.ErrorRegisterLoginFailure, err)
+		return &authEntities.LoginAttempts{}
+	}
+
+	return attempts
+}
+
+// registerFailure returns true only for the failure that locked the key, the count is incremented by the database
+func (s *Service) registerFailure(key string, maxAttempts int) bool {
+	failures, err := s.loginAttempts.IncrementFailures(key, s.appConfig.GetLoginLockout())
+	if err != nil {
+		logger.LogError(errors.ErrorRegisterLoginFailure, err)
+		return false
+	}
+
+	attempts := &authEntities.LoginAttempts{Key: key}
+	locked := attempts.SetFailures(failures, maxAttempts, s.appConfig.GetLoginDelay(), s.appConfig.GetLoginLockout())
+	if err := s.loginAttempts.SetNextAttempt(attempts); err != nil {
+		logger.LogError(errors.ErrorRegisterLoginFailure, err)
+	}
+
+	return locked
+}
+
+// onAccountLocked only audits and notifies existing accounts, the username of the login can be the email or username
+func (s *Service) onAccountLocked(username, ip string, account *authEntities.Account) {
+	logger.LogWarnWithLevel("{AUDIT} account locked after failed login attempts",
+		map[string]interface{}{"username": username, "ip": ip})
+
+	if account == nil {
+		return
+	}
+
+	s.auditService.Publish(audit.NewEvent(auditEnums.AccountLocked, account.AccountID).SetIP(ip))
+	if err := s.sendAccountLockedEmail(account, username, ip); err != nil {
+		logger.LogError(errors.ErrorSendAccountLockedEmail, err)
+	}
+}
+
+func (s *Service) onAccountUnlocked(username string, account *authEntities.Account) {
+	logger.LogWarnWithLevel("{AUDIT} account unlocked by email", map[string]interface{}{"username": username})
+
+	if account != nil {
+		s.auditService.Publish(audit.NewEvent(auditEnums.AccountUnlocked, account.AccountID))
+	}
+}
+
+func (s *Service) sendAccountLockedEmail(account *authEntities.Account, username, ip string) error {
+	if s.appConfig.IsDisabledBroker() {
+		return nil
+	}
+
+	code := uuid.New().String()
+	err := s.cacheRepository.Set(&entityCache.Cache{Key: authEntities.LoginUnlockKeyPrefix + code,
+		Value: []byte(s.normalizeUsername(username))},
+		s.appConfig.GetLoginLockout())
+	if err != nil {
+		return err
+	}
+
+	return s.broker.Publish(queues.HorusecEmail.ToString(), "", "", s.newAccountLockedEmail(account, ip, code))
+}
+
+// getAccount returns a nil account when it does not exist, the username of the login can be the email or username
+func (s *Service) getAccount(username string) (*authEntities.Account, error) {
+	if account, err := s.accountRepository.GetByEmail(username); err == nil {
+		return account, nil
+	}
+
+	account, err := s.accountRepository.GetByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (s *Service) newAccountLockedEmail(account *authEntities.Account, ip, code string) []byte {
+	emailMessage := messages.EmailMessage{
+		To:           account.Email,
+		TemplateName: emailEnum.AccountLocked,
+		Subject:      "[Horusec] Account locked",
+		Data: map[string]interface{}{"Username": account.Username, "IP": ip,
+			"Attempts":    s.appConfig.GetLoginMaxAttemptsPerAccount(),
+			"LockMinutes": int(s.appConfig.GetLoginLockout().Minutes()),
+			"URL":         fmt.Sprintf("%s/auth/auth/unlock/%s", s.appConfig.GetHorusecAPIURL(), code)},
+	}
+
+	return emailMessage.ToBytes()
+}
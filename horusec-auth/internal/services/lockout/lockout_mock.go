@@ -0,0 +1,50 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockout
+
+import (
+	"net/http"
+	"time"
+
+	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Check(_, _ string) (time.Duration, error) {
+	args := m.MethodCalled("Check")
+	return args.Get(0).(time.Duration), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) RegisterFailure(_, _ string) {
+	_ = m.MethodCalled("RegisterFailure")
+}
+
+func (m *Mock) RegisterSuccess(_ string) {
+	_ = m.MethodCalled("RegisterSuccess")
+}
+
+func (m *Mock) Unlock(_ string) error {
+	args := m.MethodCalled("Unlock")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) GetClientIP(_ *http.Request) string {
+	args := m.MethodCalled("GetClientIP")
+	return args.String(0)
+}
@@ -0,0 +1,427 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockout
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
+	cacheRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/cache"
+	loginAttemptsRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/login_attempts"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	entityCache "github.com/ZupIT/horusec/development-kit/pkg/entities/cache"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	auditService "github.com/ZupIT/horusec/development-kit/pkg/services/audit"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/ZupIT/horusec/horusec-auth/config/app"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryCache struct {
+	values map[string][]byte
+}
+
+func (c *memoryCache) Get(key string) (*entityCache.Cache, error) {
+	return &entityCache.Cache{Key: key, Value: c.values[key]}, nil
+}
+
+func (c *memoryCache) Exists(key string) bool {
+	_, ok := c.values[key]
+	return ok
+}
+
+func (c *memoryCache) Set(entity *entityCache.Cache, _ time.Duration) error {
+	c.values[entity.Key] = entity.Value
+	return nil
+}
+
+func (c *memoryCache) Del(key string) error {
+	delete(c.values, key)
+	return nil
+}
+
+// memoryLoginAttempts increments the failures like the login attempts table, without expiration
+type memoryLoginAttempts struct {
+	values map[string]*authEntities.LoginAttempts
+}
+
+func (l *memoryLoginAttempts) Get(key string) (*authEntities.LoginAttempts, error) {
+	if attempts, ok := l.values[key]; ok {
+		return attempts, nil
+	}
+
+	return &authEntities.LoginAttempts{}, nil
+}
+
+func (l *memoryLoginAttempts) IncrementFailures(key string, _ time.Duration) (int, error) {
+	attempts, _ := l.Get(key)
+	attempts.Key = key
+	attempts.Failures++
+	l.values[key] = attempts
+	return attempts.Failures, nil
+}
+
+func (l *memoryLoginAttempts) SetNextAttempt(attempts *authEntities.LoginAttempts) error {
+	l.values[attempts.Key].NextAttemptAt = attempts.NextAttemptAt
+	if attempts.LockedUntil != nil {
+		l.values[attempts.Key].LockedUntil = attempts.LockedUntil
+	}
+
+	return nil
+}
+
+func (l *memoryLoginAttempts) Delete(key string) error {
+	delete(l.values, key)
+	return nil
+}
+
+func newService() (*Service, *memoryCache, *broker.Mock, *accountRepo.Mock) {
+	cache := &memoryCache{values: map[string][]byte{}}
+	brokerMock := &broker.Mock{}
+	brokerMock.On("Publish").Return(nil)
+	accountMock := &accountRepo.Mock{}
+	auditMock := &auditService.Mock{}
+	auditMock.On("Publish")
+
+	return &Service{
+		cacheRepository:   cache,
+		loginAttempts:     &memoryLoginAttempts{values: map[string]*authEntities.LoginAttempts{}},
+		accountRepository: accountMock,
+		broker:            brokerMock,
+		auditService:      auditMock,
+		appConfig: &app.Config{LoginMaxAttemptsPerAcc: 3, LoginMaxAttemptsPerIP: 5, LoginLockoutInMinutes: 15,
+			HorusecAPIURL: "http://localhost:8006"},
+	}, cache, brokerMock, accountMock
+}
+
+func setAccountNotFound(accountMock *accountRepo.Mock) {
+	accountMock.On("GetByEmail").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+	accountMock.On("GetByUsername").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+}
+
+func getUnlockCode(cache *memoryCache) string {
+	for key := range cache.values {
+		if strings.HasPrefix(key, authEntities.LoginUnlockKeyPrefix) {
+			return strings.TrimPrefix(key, authEntities.LoginUnlockKeyPrefix)
+		}
+	}
+
+	return ""
+}
+
+func TestNewService(t *testing.T) {
+	t.Run("should create a new service", func(t *testing.T) {
+		assert.NotNil(t, NewService(nil, &relational.MockRead{}, &relational.MockWrite{}, &app.Config{}))
+	})
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("should allow login without failed attempts", func(t *testing.T) {
+		service, _, _, accountMock := newService()
+		setAccountNotFound(accountMock)
+
+		retryAfter, err := service.Check("test", "127.0.0.1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), retryAfter)
+	})
+
+	t.Run("should ask to wait when the delay of the last failure did not pass", func(t *testing.T) {
+		service, _, _, accountMock := newService()
+		setAccountNotFound(accountMock)
+		service.appConfig.LoginDelayInSeconds = 1
+
+		service.RegisterFailure("test", "127.0.0.1")
+		retryAfter, err := service.Check("test", "127.0.0.2")
+
+		assert.Equal(t, errorsEnums.ErrorTooManyLoginAttempts, err)
+		assert.True(t, retryAfter > 0)
+	})
+
+	t.Run("should ask to wait when the ip has recent failures with other accounts", func(t *testing.T) {
+		service, _, _, accountMock := newService()
+		setAccountNotFound(accountMock)
+		service.appConfig.LoginDelayInSeconds = 1
+
+		service.RegisterFailure("other", "127.0.0.1")
+		_, err := service.Check("test", "127.0.0.1")
+
+		assert.Equal(t, errorsEnums.ErrorTooManyLoginAttempts, err)
+	})
+
+	t.Run("should return error when the ip is locked", func(t *testing.T) {
+		service, _, _, accountMock := newService()
+		setAccountNotFound(accountMock)
+		service.appConfig.LoginMaxAttemptsPerIP = 1
+
+		service.RegisterFailure("other", "127.0.0.1")
+		retryAfter, err := service.Check("test", "127.0.0.1")
+
+		assert.Equal(t, errorsEnums.ErrorTooManyLoginAttempts, err)
+		assert.InDelta(t, 15*time.Minute, retryAfter, float64(time.Second))
+	})
+
+	t.Run("should return error when the account is locked", func(t *testing.T) {
+		service, _, _, accountMock := newService()
+		accountMock.On("GetByEmail").Return(&authEntities.Account{}, errors.New("test"))
+		accountMock.On("GetByUsername").Return(&authEntities.Account{}, errors.New("test"))
+
+		for i := 0; i < 3; i++ {
+			service.RegisterFailure("Test", "127.0.0.1")
+		}
+
+		retryAfter, err := service.Check(" test ", "127.0.0.2")
+
+		assert.Equal(t, errorsEnums.ErrorAccountLocked, err)
+		assert.InDelta(t, 15*time.Minute, retryAfter, float64(time.Second))
+	})
+	t.Run("should count the failures with the email and username of the same account together", func(t *testing.T) {
+		service, _, _, accountMock := newService()
+		accountMock.On("GetByEmail").Return(&authEntities.Account{AccountID: uuid.New(), Username: "test",
+			Email: "test@horusec.io"}, nil)
+
+		service.RegisterFailure("test@horusec.io", "127.0.0.1")
+		service.RegisterFailure("test@horusec.io", "127.0.0.2")
+		service.RegisterFailure("test", "127.0.0.3")
+
+		_, err := service.Check("test", "127.0.0.4")
+		assert.Equal(t, errorsEnums.ErrorAccountLocked, err)
+		_, err = service.Check("test@horusec.io", "127.0.0.4")
+		assert.Equal(t, errorsEnums.ErrorAccountLocked, err)
+	})
+}
+
+func TestRegisterFailure(t *testing.T) {
+	t.Run("should send unlock email when lock an existing account", func(t *testing.T) {
+		service, cache, brokerMock, accountMock := newService()
+		accountMock.On("GetByEmail").Return(&authEntities.Account{}, errors.New("test"))
+		accountMock.On("GetByUsername").Return(&authEntities.Account{Username: "test", Email: "test@horusec.io"}, nil)
+
+		for i := 0; i < 3; i++ {
+			service.RegisterFailure("test", "127.0.0.1")
+		}
+
+		brokerMock.AssertNumberOfCalls(t, "Publish", 1)
+		service.auditService.(*auditService.Mock).AssertNumberOfCalls(t, "Publish", 1)
+		assert.NotEmpty(t, getUnlockCode(cache))
+		assert.Equal(t, "test", string(cache.values[authEntities.LoginUnlockKeyPrefix+getUnlockCode(cache)]))
+	})
+
+	t.Run("should not send email when account does not exist", func(t *testing.T) {
+		service, cache, brokerMock, accountMock := newService()
+		accountMock.On("GetByEmail").Return(&authEntities.Account{}, errors.New("test"))
+		accountMock.On("GetByUsername").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+
+		for i := 0; i < 3; i++ {
+			service.RegisterFailure("test", "127.0.0.1")
+		}
+
+		brokerMock.AssertNotCalled(t, "Publish")
+		assert.Empty(t, getUnlockCode(cache))
+	})
+
+	t.Run("should not send email when broker is disabled", func(t *testing.T) {
+		service, _, brokerMock, accountMock := newService()
+		accountMock.On("GetByEmail").Return(&authEntities.Account{}, errors.New("test"))
+		accountMock.On("GetByUsername").Return(&authEntities.Account{Username: "test", Email: "test@horusec.io"}, nil)
+		service.appConfig.DisabledBroker = true
+
+		for i := 0; i < 3; i++ {
+			service.RegisterFailure("test", "127.0.0.1")
+		}
+
+		brokerMock.AssertNotCalled(t, "Publish")
+	})
+
+	t.Run("should not panic when publish email fails", func(t *testing.T) {
+		service, _, brokerMock, accountMock := newService()
+		accountMock.On("GetByEmail").Return(&authEntities.Account{Email: "test@horusec.io"}, nil)
+		brokerMock.ExpectedCalls = nil
+		brokerMock.On("Publish").Return(errors.New("test"))
+
+		assert.NotPanics(t, func() {
+			for i := 0; i < 3; i++ {
+				service.RegisterFailure("test@horusec.io", "127.0.0.1")
+			}
+		})
+	})
+
+	t.Run("should lock only once when failures pass the max attempts", func(t *testing.T) {
+		service, _, brokerMock, accountMock := newService()
+		accountMock.On("GetByEmail").Return(&authEntities.Account{}, errors.New("test"))
+		accountMock.On("GetByUsername").Return(&authEntities.Account{Username: "test", Email: "test@horusec.io"}, nil)
+
+		for i := 0; i < 5; i++ {
+			service.RegisterFailure("test", "127.0.0.1")
+		}
+
+		brokerMock.AssertNumberOfCalls(t, "Publish", 1)
+		_, err := service.Check("test", "127.0.0.2")
+		assert.Equal(t, errorsEnums.ErrorAccountLocked, err)
+	})
+
+	t.Run("should not lock when increment failures fails", func(t *testing.T) {
+		loginAttemptsMock := &loginAttemptsRepo.Mock{}
+		loginAttemptsMock.On("IncrementFailures").Return(0, errors.New("test"))
+		service, _, brokerMock, accountMock := newService()
+		setAccountNotFound(accountMock)
+		service.loginAttempts = loginAttemptsMock
+
+		assert.NotPanics(t, func() {
+			service.RegisterFailure("test", "127.0.0.1")
+		})
+		loginAttemptsMock.AssertNotCalled(t, "SetNextAttempt")
+		brokerMock.AssertNotCalled(t, "Publish")
+	})
+
+	t.Run("should not panic when set next attempt fails", func(t *testing.T) {
+		loginAttemptsMock := &loginAttemptsRepo.Mock{}
+		loginAttemptsMock.On("IncrementFailures").Return(1, nil)
+		loginAttemptsMock.On("SetNextAttempt").Return(errors.New("test"))
+		service, _, _, accountMock := newService()
+		setAccountNotFound(accountMock)
+		service.loginAttempts = loginAttemptsMock
+
+		assert.NotPanics(t, func() {
+			service.RegisterFailure("test", "127.0.0.1")
+		})
+	})
+}
+
+func TestRegisterSuccess(t *testing.T) {
+	t.Run("should reset the account failed attempts", func(t *testing.T) {
+		service, _, _, accountMock := newService()
+		setAccountNotFound(accountMock)
+		service.appConfig.LoginDelayInSeconds = 1
+		service.RegisterFailure("test", "127.0.0.1")
+
+		service.RegisterSuccess("test")
+
+		loginAttempts := service.loginAttempts.(*memoryLoginAttempts)
+		assert.NotContains(t, loginAttempts.values, authEntities.LoginAttemptsAccountKeyPrefix+"test")
+		assert.Contains(t, loginAttempts.values, authEntities.LoginAttemptsIPKeyPrefix+"127.0.0.1")
+	})
+
+	t.Run("should reset the failed attempts of the account and of the typed username", func(t *testing.T) {
+		service, _, _, accountMock := newService()
+		account := &authEntities.Account{AccountID: uuid.New(), Username: "test", Email: "test@horusec.io"}
+		loginAttempts := service.loginAttempts.(*memoryLoginAttempts)
+		_, _ = loginAttempts.IncrementFailures(authEntities.LoginAttemptsAccountKeyPrefix+"test", time.Minute)
+		accountMock.On("GetByEmail").Return(account, nil)
+		service.RegisterFailure("test", "127.0.0.1")
+
+		service.RegisterSuccess("test")
+
+		assert.NotContains(t, loginAttempts.values, authEntities.LoginAttemptsAccountKeyPrefix+"test")
+		assert.NotContains(t, loginAttempts.values,
+			authEntities.LoginAttemptsAccountKeyPrefix+account.AccountID.String())
+	})
+
+	t.Run("should not panic when delete attempts fails", func(t *testing.T) {
+		loginAttemptsMock := &loginAttemptsRepo.Mock{}
+		loginAttemptsMock.On("Delete").Return(errors.New("test"))
+		service, _, _, accountMock := newService()
+		setAccountNotFound(accountMock)
+		service.loginAttempts = loginAttemptsMock
+
+		assert.NotPanics(t, func() {
+			service.RegisterSuccess("test")
+		})
+	})
+}
+
+func TestNewAccountLockedEmail(t *testing.T) {
+	t.Run("should create email with the unlock url", func(t *testing.T) {
+		service, _, _, _ := newService()
+		emailMessage := &messages.EmailMessage{}
+
+		_ = json.Unmarshal(service.newAccountLockedEmail(
+			&authEntities.Account{Username: "test", Email: "test@horusec.io"}, "127.0.0.1", "code"), emailMessage)
+
+		assert.Equal(t, "test@horusec.io", emailMessage.To)
+		assert.Equal(t, "http://localhost:8006/auth/auth/unlock/code", emailMessage.Data.(map[string]interface{})["URL"])
+		assert.Equal(t, float64(15), emailMessage.Data.(map[string]interface{})["LockMinutes"])
+	})
+}
+
+func TestUnlock(t *testing.T) {
+	t.Run("should unlock the account with the email code", func(t *testing.T) {
+		service, cache, _, accountMock := newService()
+		accountMock.On("GetByEmail").Return(&authEntities.Account{Username: "test", Email: "test@horusec.io"}, nil)
+		for i := 0; i < 3; i++ {
+			service.RegisterFailure("test@horusec.io", "127.0.0.1")
+		}
+
+		assert.NoError(t, service.Unlock(getUnlockCode(cache)))
+
+		_, err := service.Check("test@horusec.io", "127.0.0.2")
+		assert.NoError(t, err)
+		assert.Empty(t, getUnlockCode(cache))
+	})
+
+	t.Run("should return error when code is invalid", func(t *testing.T) {
+		service, _, _, _ := newService()
+
+		assert.Equal(t, errorsEnums.ErrorInvalidUnlockCode, service.Unlock("invalid"))
+	})
+
+	t.Run("should return error when get code fails", func(t *testing.T) {
+		cacheMock := &cacheRepo.Mock{}
+		cacheMock.On("Get").Return(&entityCache.Cache{}, errors.New("test"))
+		service, _, _, _ := newService()
+		service.cacheRepository = cacheMock
+
+		assert.Error(t, service.Unlock("test"))
+	})
+
+	t.Run("should return error when delete attempts fails", func(t *testing.T) {
+		cacheMock := &cacheRepo.Mock{}
+		cacheMock.On("Get").Return(&entityCache.Cache{Value: []byte("test")}, nil)
+		loginAttemptsMock := &loginAttemptsRepo.Mock{}
+		loginAttemptsMock.On("Delete").Return(errors.New("test"))
+		service, _, _, accountMock := newService()
+		setAccountNotFound(accountMock)
+		service.cacheRepository = cacheMock
+		service.loginAttempts = loginAttemptsMock
+
+		assert.Error(t, service.Unlock("test"))
+	})
+}
+
+func TestGetClientIP(t *testing.T) {
+	t.Run("should return the host of the remote address", func(t *testing.T) {
+		service, _, _, _ := newService()
+		r, _ := http.NewRequest(http.MethodPost, "test", nil)
+		r.RemoteAddr = "127.0.0.1:8006"
+
+		assert.Equal(t, "127.0.0.1", service.GetClientIP(r))
+	})
+
+	t.Run("should return the remote address when it has no port", func(t *testing.T) {
+		service, _, _, _ := newService()
+		r, _ := http.NewRequest(http.MethodPost, "test", nil)
+		r.RemoteAddr = "127.0.0.1"
+
+		assert.Equal(t, "127.0.0.1", service.GetClientIP(r))
+	})
+}
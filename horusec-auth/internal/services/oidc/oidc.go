@@ -0,0 +1,301 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/cache"
+	companyRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	repositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
+	cacheEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/cache"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/jwt"
+	oidcService "github.com/ZupIT/horusec/development-kit/pkg/services/oidc"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
+	"github.com/ZupIT/horusec/horusec-auth/internal/services"
+	personalTokenService "github.com/ZupIT/horusec/horusec-auth/internal/services/personal_token"
+	"github.com/google/uuid"
+)
+
+const (
+	EnvAdminGroup     = "HORUSEC_OIDC_ADMIN_GROUP"
+	SessionKeyPrefix  = "oidc-session:"
+	SessionExpiration = 10 * time.Minute
+)
+
+type IService interface {
+	services.IAuthService
+	GetAuthorizationURL() (*dto.OIDCAuthorization, error)
+}
+
+type Service struct {
+	client               oidcService.IService
+	accountRepo          accountRepo.IAccount
+	companyRepo          companyRepo.ICompanyRepository
+	repositoryRepo       repositoryRepo.IRepository
+	cacheRepo            cache.Interface
+	personalTokenService personalTokenService.IService
+}
+
+func NewService(databaseRead relational.InterfaceRead, databaseWrite relational.InterfaceWrite) IService {
+	return &Service{
+		client:               oidcService.NewOIDCService(oidcService.NewConfig()),
+		accountRepo:          accountRepo.NewAccountRepository(databaseRead, databaseWrite),
+		companyRepo:          companyRepo.NewCompanyRepository(databaseRead, databaseWrite),
+		repositoryRepo:       repositoryRepo.NewRepository(databaseRead, databaseWrite),
+		cacheRepo:            cache.NewCacheRepository(databaseRead, databaseWrite),
+		personalTokenService: personalTokenService.NewService(databaseRead, databaseWrite, authEnums.OIDC),
+	}
+}
+
+func (s *Service) GetAuthorizationURL() (*dto.OIDCAuthorization, error) {
+	state, session, err := s.newSession()
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationURL, err := s.client.GetAuthorizationURL(state, session.Nonce,
+		oidcService.NewCodeChallenge(session.CodeVerifier))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OIDCAuthorization{AuthorizationURL: authorizationURL, State: state}, s.cacheRepo.Set(
+		&cacheEntities.Cache{Key: SessionKeyPrefix + state, Value: session.ToBytes()}, SessionExpiration)
+}
+
+func (s *Service) Authenticate(credentials *dto.Credentials) (interface{}, error) {
+	session, err := s.getAndDeleteSession(credentials.State)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.client.ExchangeCode(credentials.Code, session.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.client.ValidateIDToken(token.IDToken, session.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.getAccountAndCreateIfNotExist(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := account.IsAccountEnabled(); err != nil {
+		return nil, err
+	}
+
+	if err := s.personalTokenService.RevokeByGroups(account.AccountID, identity.Groups); err != nil {
+		return nil, err
+	}
+
+	return s.setOIDCAuthResponse(account, identity.Groups)
+}
+
+func (s *Service) IsAuthorized(authzData *dto.AuthorizationData) (bool, error) {
+	claims, err := jwt.DecodeToken(authzData.Token)
+	if err != nil {
+		return false, errors.ErrorUnauthorized
+	}
+
+	allowedGroups, err := s.getAllowedGroups(authzData)
+	if err != nil || !s.containsAny(allowedGroups, claims.Permissions) {
+		return false, errors.ErrorUnauthorized
+	}
+
+	return true, nil
+}
+
+func (s *Service) newSession() (string, *dto.OIDCSession, error) {
+	values := make([]string, 3)
+	for index := range values {
+		value, err := oidcService.NewRandomString()
+		if err != nil {
+			return "", nil, err
+		}
+
+		values[index] = value
+	}
+
+	return values[0], &dto.OIDCSession{CodeVerifier: values[1], Nonce: values[2]}, nil
+}
+
+func (s *Service) getAndDeleteSession(state string) (*dto.OIDCSession, error) {
+	entity, err := s.cacheRepo.Get(SessionKeyPrefix + state)
+	if err != nil || entity.Key == "" {
+		return nil, errors.ErrorInvalidOIDCState
+	}
+
+	session := &dto.OIDCSession{}
+	if err := entity.ConvertValueToEntity(session); err != nil {
+		return nil, errors.ErrorInvalidOIDCState
+	}
+
+	return session, s.cacheRepo.Del(entity.Key)
+}
+
+// getAccountAndCreateIfNotExist finds the account by the issuer and subject of the identity. On the first login an
+// account with the same email is linked only when the provider verified the email, otherwise a new one is created.
+func (s *Service) getAccountAndCreateIfNotExist(identity *oidcService.Identity) (*authEntities.Account, error) {
+	if identity.Email == "" {
+		return nil, errors.ErrorOIDCMissingEmail
+	}
+
+	account, err := s.accountRepo.GetByOIDCIdentity(identity.Issuer, identity.Subject)
+	if err != errors.ErrNotFoundRecords {
+		return account, err
+	}
+
+	account, err = s.accountRepo.GetByEmail(identity.Email)
+	if err == errors.ErrNotFoundRecords {
+		return s.createAccount(identity)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.linkAccount(account, identity)
+}
+
+func (s *Service) createAccount(identity *oidcService.Identity) (*authEntities.Account, error) {
+	account := &authEntities.Account{
+		Email:    identity.Email,
+		Username: s.getUsername(identity),
+	}
+
+	account.SetOIDCIdentity(identity.Issuer, identity.Subject)
+	if err := s.accountRepo.Create(account.SetAccountData()); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (s *Service) linkAccount(account *authEntities.Account,
+	identity *oidcService.Identity) (*authEntities.Account, error) {
+	if !identity.EmailVerified {
+		return nil, errors.ErrorOIDCEmailNotVerified
+	}
+
+	if account.OIDCSubject != "" {
+		return nil, errors.ErrorOIDCAccountLinked
+	}
+
+	return account, s.accountRepo.UpdateOIDCIdentity(account.SetOIDCIdentity(identity.Issuer, identity.Subject))
+}
+
+func (s *Service) getUsername(identity *oidcService.Identity) string {
+	if identity.Username == "" {
+		return identity.Email
+	}
+
+	return identity.Username
+}
+
+func (s *Service) setOIDCAuthResponse(account *authEntities.Account, groups []string) (*dto.OIDCAuthResponse, error) {
+	accessToken, expiresAt, err := jwt.CreateToken(account, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OIDCAuthResponse{
+		AccessToken:        accessToken,
+		ExpiresAt:          expiresAt,
+		Username:           account.Username,
+		Email:              account.Email,
+		IsApplicationAdmin: s.containsAny(s.getApplicationAdminGroups(), groups),
+	}, nil
+}
+
+func (s *Service) getAllowedGroups(authzData *dto.AuthorizationData) ([]string, error) {
+	switch authzData.Role {
+	case authEnums.ApplicationAdmin:
+		return s.getApplicationAdminGroups(), nil
+	case authEnums.CompanyAdmin, authEnums.CompanyMember:
+		return s.getCompanyGroups(authzData.CompanyID, authzData.Role)
+	case authEnums.RepositoryAdmin, authEnums.RepositorySupervisor, authEnums.RepositoryMember:
+		return s.getRepositoryGroups(authzData)
+	}
+
+	return nil, errors.ErrorUnauthorized
+}
+
+func (s *Service) getApplicationAdminGroups() []string {
+	if group := env.GetEnvOrDefault(EnvAdminGroup, ""); group != "" {
+		return []string{group}
+	}
+
+	return []string{}
+}
+
+func (s *Service) getCompanyGroups(companyID uuid.UUID, role authEnums.HorusecRoles) ([]string, error) {
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role == authEnums.CompanyAdmin {
+		return company.GetAuthzAdmin(), nil
+	}
+
+	return append(company.GetAuthzMember(), company.GetAuthzAdmin()...), nil
+}
+
+// getRepositoryGroups returns the repository groups of the role and above it, company admins are always allowed.
+func (s *Service) getRepositoryGroups(authzData *dto.AuthorizationData) ([]string, error) {
+	companyAdmin, err := s.getCompanyGroups(authzData.CompanyID, authEnums.CompanyAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	repository, err := s.repositoryRepo.Get(authzData.RepositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := append(companyAdmin, repository.GetAuthzAdmin()...)
+	switch authzData.Role {
+	case authEnums.RepositorySupervisor:
+		groups = append(groups, repository.GetAuthzSupervisor()...)
+	case authEnums.RepositoryMember:
+		groups = append(groups, append(repository.GetAuthzSupervisor(), repository.GetAuthzMember()...)...)
+	}
+
+	return groups, nil
+}
+
+func (s *Service) containsAny(allowedGroups, userGroups []string) bool {
+	for _, allowedGroup := range allowedGroups {
+		for _, userGroup := range userGroups {
+			if strings.TrimSpace(allowedGroup) == userGroup {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,405 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
+	companyRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/company"
+	repositoryRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/repository"
+	accountEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/account"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
+	cacheEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/cache"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/jwt"
+	oidcService "github.com/ZupIT/horusec/development-kit/pkg/services/oidc"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/repository/response"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/test"
+	personalTokenService "github.com/ZupIT/horusec/horusec-auth/internal/services/personal_token"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryCache map[string]*cacheEntities.Cache
+
+func (m memoryCache) Get(key string) (*cacheEntities.Cache, error) {
+	if entity, ok := m[key]; ok {
+		return entity, nil
+	}
+
+	return &cacheEntities.Cache{}, nil
+}
+
+func (m memoryCache) Exists(key string) bool {
+	_, ok := m[key]
+	return ok
+}
+
+func (m memoryCache) Set(entity *cacheEntities.Cache, _ time.Duration) error {
+	m[entity.Key] = entity
+	return nil
+}
+
+func (m memoryCache) Del(key string) error {
+	delete(m, key)
+	return nil
+}
+
+func newTestService(provider *test.OIDCProvider, databaseRead relational.InterfaceRead,
+	databaseWrite relational.InterfaceWrite) *Service {
+	personalTokenMock := &personalTokenService.Mock{}
+	personalTokenMock.On("RevokeByGroups").Return(nil)
+
+	return &Service{
+		client: oidcService.NewOIDCService(&oidcService.Config{
+			IssuerURL:     provider.URL(),
+			ClientID:      provider.ClientID,
+			RedirectURL:   "http://localhost:8043/auth/oidc/callback",
+			Scopes:        []string{"openid", "email", "profile"},
+			UsernameClaim: "preferred_username",
+			EmailClaim:    "email",
+			GroupsClaims:  []string{"groups"},
+			GroupsMapping: map[string]string{"6f1c2b4e": "horusec-admins"},
+		}),
+		accountRepo:          accountRepo.NewAccountRepository(databaseRead, databaseWrite),
+		cacheRepo:            memoryCache{},
+		personalTokenService: personalTokenMock,
+	}
+}
+
+func TestNewService(t *testing.T) {
+	t.Run("should creates a new service instance", func(t *testing.T) {
+		assert.NotNil(t, NewService(&relational.MockRead{}, &relational.MockWrite{}))
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	provider := test.NewOIDCProvider("horusec")
+	defer provider.Close()
+
+	provider.SetUser(map[string]interface{}{
+		"email":              "horusec@example.com",
+		"preferred_username": "horusec",
+		"groups":             []string{"6f1c2b4e", "developers"},
+	})
+
+	_ = os.Setenv(EnvAdminGroup, "horusec-admins")
+	defer func() { _ = os.Unsetenv(EnvAdminGroup) }()
+
+	t.Run("should login with stand-in provider and create account", func(t *testing.T) {
+		databaseRead := &relational.MockRead{}
+		databaseWrite := &relational.MockWrite{}
+
+		databaseRead.On("SetFilter").Return(&gorm.DB{})
+		databaseRead.On("Find").Return(response.NewResponse(0, errorsEnum.ErrNotFoundRecords, nil))
+		databaseWrite.On("Create").Return(&response.Response{})
+
+		service := newTestService(provider, databaseRead, databaseWrite)
+
+		authorization, err := service.GetAuthorizationURL()
+		assert.NoError(t, err)
+
+		code, state, err := provider.Authorize(authorization.AuthorizationURL)
+		assert.NoError(t, err)
+		assert.Equal(t, authorization.State, state)
+
+		result, err := service.Authenticate(&dto.Credentials{Code: code, State: state})
+		assert.NoError(t, err)
+
+		authResponse := result.(*dto.OIDCAuthResponse)
+		assert.Equal(t, "horusec@example.com", authResponse.Email)
+		assert.Equal(t, "horusec", authResponse.Username)
+		assert.True(t, authResponse.IsApplicationAdmin)
+		databaseWrite.AssertCalled(t, "Create")
+
+		claims, err := jwt.DecodeToken(authResponse.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"horusec-admins", "developers"}, claims.Permissions)
+		service.personalTokenService.(*personalTokenService.Mock).AssertCalled(t, "RevokeByGroups")
+	})
+
+	t.Run("should return error when failed to revoke the personal tokens of lost groups", func(t *testing.T) {
+		databaseRead := &relational.MockRead{}
+
+		databaseRead.On("SetFilter").Return(&gorm.DB{})
+		databaseRead.On("Find").Return(response.NewResponse(1, nil, &authEntities.Account{}))
+
+		personalTokenMock := &personalTokenService.Mock{}
+		personalTokenMock.On("RevokeByGroups").Return(errors.New("test"))
+
+		service := newTestService(provider, databaseRead, &relational.MockWrite{})
+		service.personalTokenService = personalTokenMock
+
+		authorization, _ := service.GetAuthorizationURL()
+		code, state, _ := provider.Authorize(authorization.AuthorizationURL)
+
+		result, err := service.Authenticate(&dto.Credentials{Code: code, State: state})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("should return error when state is reused", func(t *testing.T) {
+		databaseRead := &relational.MockRead{}
+		databaseWrite := &relational.MockWrite{}
+
+		databaseRead.On("SetFilter").Return(&gorm.DB{})
+		databaseRead.On("Find").Return(response.NewResponse(1, nil, &authEntities.Account{}))
+
+		service := newTestService(provider, databaseRead, databaseWrite)
+
+		authorization, _ := service.GetAuthorizationURL()
+		code, state, _ := provider.Authorize(authorization.AuthorizationURL)
+
+		_, err := service.Authenticate(&dto.Credentials{Code: code, State: state})
+		assert.NoError(t, err)
+		databaseWrite.AssertNotCalled(t, "Create")
+
+		_, err = service.Authenticate(&dto.Credentials{Code: code, State: state})
+		assert.Equal(t, errorsEnum.ErrorInvalidOIDCState, err)
+	})
+
+	t.Run("should return error when code is invalid", func(t *testing.T) {
+		service := newTestService(provider, &relational.MockRead{}, &relational.MockWrite{})
+
+		authorization, _ := service.GetAuthorizationURL()
+
+		_, err := service.Authenticate(&dto.Credentials{Code: "invalid", State: authorization.State})
+		assert.Equal(t, errorsEnum.ErrorOIDCCodeExchange, err)
+	})
+
+	t.Run("should return error when provider does not return email", func(t *testing.T) {
+		provider.SetUser(map[string]interface{}{"preferred_username": "horusec"})
+		defer provider.SetUser(map[string]interface{}{"email": "horusec@example.com"})
+
+		service := newTestService(provider, &relational.MockRead{}, &relational.MockWrite{})
+
+		authorization, _ := service.GetAuthorizationURL()
+		code, state, _ := provider.Authorize(authorization.AuthorizationURL)
+
+		_, err := service.Authenticate(&dto.Credentials{Code: code, State: state})
+		assert.Equal(t, errorsEnum.ErrorOIDCMissingEmail, err)
+	})
+
+	t.Run("should return error when failed to create account", func(t *testing.T) {
+		databaseRead := &relational.MockRead{}
+		databaseWrite := &relational.MockWrite{}
+
+		databaseRead.On("SetFilter").Return(&gorm.DB{})
+		databaseRead.On("Find").Return(response.NewResponse(0, errorsEnum.ErrNotFoundRecords, nil))
+		databaseWrite.On("Create").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		service := newTestService(provider, databaseRead, databaseWrite)
+
+		authorization, _ := service.GetAuthorizationURL()
+		code, state, _ := provider.Authorize(authorization.AuthorizationURL)
+
+		_, err := service.Authenticate(&dto.Credentials{Code: code, State: state})
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when provider is unavailable", func(t *testing.T) {
+		service := newTestService(provider, &relational.MockRead{}, &relational.MockWrite{})
+		service.client = oidcService.NewOIDCService(&oidcService.Config{IssuerURL: "http://127.0.0.1:0"})
+
+		_, err := service.GetAuthorizationURL()
+		assert.Equal(t, errorsEnum.ErrorOIDCDiscovery, err)
+	})
+}
+
+func TestGetAccountAndCreateIfNotExist(t *testing.T) {
+	identity := &oidcService.Identity{Issuer: "http://idp.example.com", Subject: "f3a1", Email: "horusec@example.com",
+		EmailVerified: true}
+
+	t.Run("should return account linked to the issuer and subject", func(t *testing.T) {
+		accountMock := &accountRepo.Mock{}
+		accountMock.On("GetByOIDCIdentity").Return(&authEntities.Account{Email: "other@example.com"}, nil)
+
+		account, err := (&Service{accountRepo: accountMock}).getAccountAndCreateIfNotExist(identity)
+		assert.NoError(t, err)
+		assert.Equal(t, "other@example.com", account.Email)
+		accountMock.AssertNotCalled(t, "GetByEmail")
+	})
+
+	t.Run("should link account with the same email when email is verified", func(t *testing.T) {
+		accountMock := &accountRepo.Mock{}
+		accountMock.On("GetByOIDCIdentity").Return(&authEntities.Account{}, errorsEnum.ErrNotFoundRecords)
+		accountMock.On("GetByEmail").Return(&authEntities.Account{AccountID: uuid.New()}, nil)
+		accountMock.On("UpdateOIDCIdentity").Return(nil)
+
+		account, err := (&Service{accountRepo: accountMock}).getAccountAndCreateIfNotExist(identity)
+		assert.NoError(t, err)
+		assert.Equal(t, identity.Issuer, account.OIDCIssuer)
+		assert.Equal(t, identity.Subject, account.OIDCSubject)
+		accountMock.AssertCalled(t, "UpdateOIDCIdentity")
+	})
+
+	t.Run("should return error when email of existing account is not verified", func(t *testing.T) {
+		accountMock := &accountRepo.Mock{}
+		accountMock.On("GetByOIDCIdentity").Return(&authEntities.Account{}, errorsEnum.ErrNotFoundRecords)
+		accountMock.On("GetByEmail").Return(&authEntities.Account{AccountID: uuid.New()}, nil)
+
+		_, err := (&Service{accountRepo: accountMock}).getAccountAndCreateIfNotExist(&oidcService.Identity{
+			Issuer: identity.Issuer, Subject: identity.Subject, Email: identity.Email})
+		assert.Equal(t, errorsEnum.ErrorOIDCEmailNotVerified, err)
+		accountMock.AssertNotCalled(t, "UpdateOIDCIdentity")
+	})
+
+	t.Run("should return error when account of the email is linked to another subject", func(t *testing.T) {
+		accountMock := &accountRepo.Mock{}
+		accountMock.On("GetByOIDCIdentity").Return(&authEntities.Account{}, errorsEnum.ErrNotFoundRecords)
+		accountMock.On("GetByEmail").Return(&authEntities.Account{OIDCSubject: "b7c2"}, nil)
+
+		_, err := (&Service{accountRepo: accountMock}).getAccountAndCreateIfNotExist(identity)
+		assert.Equal(t, errorsEnum.ErrorOIDCAccountLinked, err)
+	})
+
+	t.Run("should create account linked to the identity when email does not exist", func(t *testing.T) {
+		accountMock := &accountRepo.Mock{}
+		accountMock.On("GetByOIDCIdentity").Return(&authEntities.Account{}, errorsEnum.ErrNotFoundRecords)
+		accountMock.On("GetByEmail").Return(&authEntities.Account{}, errorsEnum.ErrNotFoundRecords)
+		accountMock.On("Create").Return(nil)
+
+		account, err := (&Service{accountRepo: accountMock}).getAccountAndCreateIfNotExist(identity)
+		assert.NoError(t, err)
+		assert.Equal(t, identity.Subject, account.OIDCSubject)
+		accountMock.AssertCalled(t, "Create")
+	})
+
+	t.Run("should return error when failed to get account by email", func(t *testing.T) {
+		accountMock := &accountRepo.Mock{}
+		accountMock.On("GetByOIDCIdentity").Return(&authEntities.Account{}, errorsEnum.ErrNotFoundRecords)
+		accountMock.On("GetByEmail").Return(&authEntities.Account{}, errors.New("test"))
+
+		_, err := (&Service{accountRepo: accountMock}).getAccountAndCreateIfNotExist(identity)
+		assert.Error(t, err)
+		accountMock.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestIsAuthorized(t *testing.T) {
+	company := &accountEntities.Company{
+		CompanyID:   uuid.New(),
+		AuthzAdmin:  []string{"company-admin"},
+		AuthzMember: []string{"company-member"},
+	}
+
+	repository := &accountEntities.Repository{
+		RepositoryID:    uuid.New(),
+		AuthzAdmin:      []string{"repository-admin"},
+		AuthzSupervisor: []string{"repository-supervisor"},
+		AuthzMember:     []string{"repository-member"},
+	}
+
+	newService := func() *Service {
+		companyMock := &companyRepo.Mock{}
+		companyMock.On("GetByID").Return(company, nil)
+
+		repositoryMock := &repositoryRepo.Mock{}
+		repositoryMock.On("Get").Return(repository, nil)
+
+		return &Service{companyRepo: companyMock, repositoryRepo: repositoryMock}
+	}
+
+	newAuthzData := func(role authEnums.HorusecRoles, groups ...string) *dto.AuthorizationData {
+		token, _, _ := jwt.CreateToken(&authEntities.Account{AccountID: uuid.New(), Email: "horusec@example.com",
+			Username: "horusec"}, groups)
+		return &dto.AuthorizationData{Token: token, Role: role, CompanyID: company.CompanyID,
+			RepositoryID: repository.RepositoryID}
+	}
+
+	t.Run("should authorize groups mapped to the role", func(t *testing.T) {
+		authorized := []*dto.AuthorizationData{
+			newAuthzData(authEnums.CompanyAdmin, "company-admin"),
+			newAuthzData(authEnums.CompanyMember, "company-member"),
+			newAuthzData(authEnums.CompanyMember, "company-admin"),
+			newAuthzData(authEnums.RepositoryAdmin, "repository-admin"),
+			newAuthzData(authEnums.RepositoryAdmin, "company-admin"),
+			newAuthzData(authEnums.RepositorySupervisor, "repository-supervisor"),
+			newAuthzData(authEnums.RepositoryMember, "repository-member"),
+			newAuthzData(authEnums.RepositoryMember, "repository-admin"),
+		}
+
+		for _, authzData := range authorized {
+			isAuthorized, err := newService().IsAuthorized(authzData)
+			assert.NoError(t, err, authzData.Role)
+			assert.True(t, isAuthorized, authzData.Role)
+		}
+	})
+
+	t.Run("should not authorize groups below the role", func(t *testing.T) {
+		unauthorized := []*dto.AuthorizationData{
+			newAuthzData(authEnums.CompanyAdmin, "company-member"),
+			newAuthzData(authEnums.RepositoryAdmin, "repository-supervisor"),
+			newAuthzData(authEnums.RepositorySupervisor, "repository-member"),
+			newAuthzData(authEnums.RepositoryMember, "company-member"),
+			newAuthzData(authEnums.ApplicationAdmin, "company-admin"),
+			newAuthzData("invalid", "company-admin"),
+		}
+
+		for _, authzData := range unauthorized {
+			isAuthorized, err := newService().IsAuthorized(authzData)
+			assert.Equal(t, errorsEnum.ErrorUnauthorized, err, authzData.Role)
+			assert.False(t, isAuthorized, authzData.Role)
+		}
+	})
+
+	t.Run("should authorize application admin group", func(t *testing.T) {
+		_ = os.Setenv(EnvAdminGroup, "horusec-admins")
+		defer func() { _ = os.Unsetenv(EnvAdminGroup) }()
+
+		isAuthorized, err := newService().IsAuthorized(newAuthzData(authEnums.ApplicationAdmin, "horusec-admins"))
+		assert.NoError(t, err)
+		assert.True(t, isAuthorized)
+	})
+
+	t.Run("should return error when invalid token", func(t *testing.T) {
+		isAuthorized, err := newService().IsAuthorized(&dto.AuthorizationData{Token: "invalid"})
+		assert.Equal(t, errorsEnum.ErrorUnauthorized, err)
+		assert.False(t, isAuthorized)
+	})
+
+	t.Run("should return error when company is not found", func(t *testing.T) {
+		companyMock := &companyRepo.Mock{}
+		companyMock.On("GetByID").Return(&accountEntities.Company{}, errors.New("test"))
+
+		service := &Service{companyRepo: companyMock}
+
+		isAuthorized, err := service.IsAuthorized(newAuthzData(authEnums.RepositoryMember, "company-admin"))
+		assert.Equal(t, errorsEnum.ErrorUnauthorized, err)
+		assert.False(t, isAuthorized)
+	})
+
+	t.Run("should return error when repository is not found", func(t *testing.T) {
+		companyMock := &companyRepo.Mock{}
+		companyMock.On("GetByID").Return(company, nil)
+
+		repositoryMock := &repositoryRepo.Mock{}
+		repositoryMock.On("Get").Return(&accountEntities.Repository{}, errors.New("test"))
+
+		service := &Service{companyRepo: companyMock, repositoryRepo: repositoryMock}
+
+		isAuthorized, err := service.IsAuthorized(newAuthzData(authEnums.RepositoryMember, "company-admin"))
+		assert.Equal(t, errorsEnum.ErrorUnauthorized, err)
+		assert.False(t, isAuthorized)
+	})
+}
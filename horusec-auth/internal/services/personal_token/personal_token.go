@@ -0,0 +1,154 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package personaltoken
+
+import (
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
+	personalTokenRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/personal_token"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/jwt"
+	ldapService "github.com/ZupIT/horusec/development-kit/pkg/services/ldap"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/google/uuid"
+)
+
+type IService interface {
+	Create(accountID uuid.UUID, permissions []string,
+		personalToken *authEntities.PersonalToken) (*dto.PersonalTokenCreated, error)
+	List(accountID uuid.UUID) (*[]authEntities.PersonalToken, error)
+	Revoke(personalTokenID, accountID uuid.UUID) error
+	Exchange(token string, scope authEnums.Scope) (*authEntities.PersonalToken, string, error)
+	RevokeByGroups(accountID uuid.UUID, groups []string) error
+}
+
+type Service struct {
+	personalTokenRepository personalTokenRepo.IRepository
+	accountRepository       accountRepo.IAccount
+	authType                authEnums.AuthorizationType
+	newLDAPClient           func() ldapService.ILDAPService
+}
+
+func NewService(databaseRead relational.InterfaceRead, databaseWrite relational.InterfaceWrite,
+	authType authEnums.AuthorizationType) IService {
+	return &Service{
+		personalTokenRepository: personalTokenRepo.NewPersonalTokenRepository(databaseRead, databaseWrite),
+		accountRepository:       accountRepo.NewAccountRepository(databaseRead, databaseWrite),
+		authType:                authType,
+		newLDAPClient:           ldapService.NewLDAPClient,
+	}
+}
+
+func (s *Service) Create(accountID uuid.UUID, permissions []string,
+	personalToken *authEntities.PersonalToken) (*dto.PersonalTokenCreated, error) {
+	personalToken.SetCreateData(accountID, permissions)
+	if err := s.personalTokenRepository.Create(personalToken); err != nil {
+		return nil, err
+	}
+
+	return &dto.PersonalTokenCreated{PersonalToken: *personalToken, Token: personalToken.GetKey()}, nil
+}
+
+func (s *Service) List(accountID uuid.UUID) (*[]authEntities.PersonalToken, error) {
+	return s.personalTokenRepository.ListByAccountID(accountID)
+}
+
+func (s *Service) Revoke(personalTokenID, accountID uuid.UUID) error {
+	return s.personalTokenRepository.Delete(personalTokenID, accountID)
+}
+
+// RevokeByGroups revokes the tokens of the account holding a group the account is not member anymore, it is used
+// by the oidc login because the groups of the provider are only known when the user logs in
+func (s *Service) RevokeByGroups(accountID uuid.UUID, groups []string) error {
+	personalTokens, err := s.personalTokenRepository.ListByAccountID(accountID)
+	if err != nil {
+		return err
+	}
+
+	for index := range *personalTokens {
+		personalToken := &(*personalTokens)[index]
+		if len(personalToken.FilterPermissions(groups)) == len(personalToken.Permissions) {
+			continue
+		}
+
+		if err := s.personalTokenRepository.Delete(personalToken.PersonalTokenID, accountID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Exchange validates the personal token for the scope of the requested resource and returns a horusec jwt of the
+// token owner, so the role checks of the auth services are the same used by the web application
+func (s *Service) Exchange(token string, scope authEnums.Scope) (*authEntities.PersonalToken, string, error) {
+	personalToken, err := s.personalTokenRepository.GetByValue(authEntities.HashPersonalToken(token))
+	if err != nil {
+		return nil, "", errors.ErrorUnauthorized
+	}
+
+	if personalToken.IsExpired() {
+		return nil, "", errors.ErrorPersonalTokenExpired
+	}
+
+	if !personalToken.HasScope(scope) {
+		return nil, "", errors.ErrorPersonalTokenScopeNotAllowed
+	}
+
+	return s.createAccountToken(personalToken)
+}
+
+func (s *Service) createAccountToken(
+	personalToken *authEntities.PersonalToken) (*authEntities.PersonalToken, string, error) {
+	account, err := s.accountRepository.GetByAccountID(personalToken.AccountID)
+	if err != nil || account.IsAccountEnabled() != nil {
+		return nil, "", errors.ErrorUnauthorized
+	}
+
+	if err := s.setCurrentPermissions(personalToken, account); err != nil {
+		logger.LogError("{PERSONAL_TOKEN} failed to get the current ldap groups", err)
+		return nil, "", errors.ErrorUnauthorized
+	}
+
+	if err := s.personalTokenRepository.UpdateLastUsedAt(personalToken.PersonalTokenID); err != nil {
+		logger.LogError("{PERSONAL_TOKEN} failed to update last used at", err)
+	}
+
+	accountToken, _, err := jwt.CreateToken(account, personalToken.Permissions)
+	return personalToken, accountToken, err
+}
+
+// setCurrentPermissions keeps only the groups saved on creation the ldap user is still member of, so a token never
+// gives more than the current groups of its owner
+func (s *Service) setCurrentPermissions(personalToken *authEntities.PersonalToken,
+	account *authEntities.Account) error {
+	if s.authType != authEnums.Ldap {
+		return nil
+	}
+
+	client := s.newLDAPClient()
+	defer client.Close()
+
+	groups, err := client.GetGroupsOfUsername(account.Username)
+	if err != nil {
+		return err
+	}
+
+	personalToken.Permissions = personalToken.FilterPermissions(groups)
+	return nil
+}
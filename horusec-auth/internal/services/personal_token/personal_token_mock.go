@@ -0,0 +1,53 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package personaltoken
+
+import (
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	mockUtils "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Create(_ uuid.UUID, _ []string, _ *authEntities.PersonalToken) (*dto.PersonalTokenCreated, error) {
+	args := m.MethodCalled("Create")
+	return args.Get(0).(*dto.PersonalTokenCreated), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) List(_ uuid.UUID) (*[]authEntities.PersonalToken, error) {
+	args := m.MethodCalled("List")
+	return args.Get(0).(*[]authEntities.PersonalToken), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock) Revoke(_, _ uuid.UUID) error {
+	args := m.MethodCalled("Revoke")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock) Exchange(_ string, _ authEnums.Scope) (*authEntities.PersonalToken, string, error) {
+	args := m.MethodCalled("Exchange")
+	return args.Get(0).(*authEntities.PersonalToken), args.String(1), mockUtils.ReturnNilOrError(args, 2)
+}
+
+func (m *Mock) RevokeByGroups(_ uuid.UUID, _ []string) error {
+	args := m.MethodCalled("RevokeByGroups")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
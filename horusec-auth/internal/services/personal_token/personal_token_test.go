@@ -0,0 +1,245 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package personaltoken
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
+	accountRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/account"
+	personalTokenRepo "github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/personal_token"
+	authEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/auth"
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/auth/dto"
+	authEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/auth"
+	errorsEnums "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/jwt"
+	ldapService "github.com/ZupIT/horusec/development-kit/pkg/services/ldap"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func getValidPersonalToken(accountID uuid.UUID) *authEntities.PersonalToken {
+	return &authEntities.PersonalToken{
+		PersonalTokenID: uuid.New(),
+		AccountID:       accountID,
+		Scopes:          []string{authEnums.ReadAnalytics.ToString()},
+		Permissions:     []string{"group"},
+		ExpiresAt:       time.Now().Add(time.Hour),
+	}
+}
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("Create").Return(&dto.PersonalTokenCreated{}, nil)
+	m.On("List").Return(&[]authEntities.PersonalToken{}, nil)
+	m.On("Revoke").Return(nil)
+	m.On("Exchange").Return(&authEntities.PersonalToken{}, "token", nil)
+	m.On("RevokeByGroups").Return(nil)
+	_, err := m.Create(uuid.New(), nil, &authEntities.PersonalToken{})
+	assert.NoError(t, err)
+	_, err = m.List(uuid.New())
+	assert.NoError(t, err)
+	assert.NoError(t, m.Revoke(uuid.New(), uuid.New()))
+	_, _, err = m.Exchange("", authEnums.ReadAnalytics)
+	assert.NoError(t, err)
+	assert.NoError(t, m.RevokeByGroups(uuid.New(), nil))
+}
+
+func TestNewService(t *testing.T) {
+	t.Run("should create a new service instance", func(t *testing.T) {
+		assert.NotNil(t, NewService(&relational.MockRead{}, &relational.MockWrite{}, authEnums.Horusec))
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("should create personal token and return its key", func(t *testing.T) {
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("Create").Return(nil)
+		service := &Service{personalTokenRepository: repositoryMock}
+
+		result, err := service.Create(uuid.New(), []string{"group"}, &authEntities.PersonalToken{Description: "ci"})
+		assert.NoError(t, err)
+		assert.True(t, authEntities.IsPersonalToken(result.Token))
+		assert.Equal(t, authEntities.HashPersonalToken(result.Token), result.Value)
+	})
+	t.Run("should return error when failed to create", func(t *testing.T) {
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("Create").Return(errors.New("test"))
+		service := &Service{personalTokenRepository: repositoryMock}
+
+		_, err := service.Create(uuid.New(), nil, &authEntities.PersonalToken{})
+		assert.Error(t, err)
+	})
+}
+
+func TestList(t *testing.T) {
+	t.Run("should list personal tokens of account", func(t *testing.T) {
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("ListByAccountID").Return(&[]authEntities.PersonalToken{{}}, nil)
+		service := &Service{personalTokenRepository: repositoryMock}
+
+		result, err := service.List(uuid.New())
+		assert.NoError(t, err)
+		assert.Len(t, *result, 1)
+	})
+}
+
+func TestRevoke(t *testing.T) {
+	t.Run("should revoke personal token", func(t *testing.T) {
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("Delete").Return(nil)
+		service := &Service{personalTokenRepository: repositoryMock}
+
+		assert.NoError(t, service.Revoke(uuid.New(), uuid.New()))
+	})
+}
+
+func TestRevokeByGroups(t *testing.T) {
+	t.Run("should revoke only the personal tokens holding a lost group", func(t *testing.T) {
+		lostGroupToken := getValidPersonalToken(uuid.New())
+		lostGroupToken.Permissions = []string{"group", "lost"}
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("ListByAccountID").Return(
+			&[]authEntities.PersonalToken{*getValidPersonalToken(uuid.New()), *lostGroupToken}, nil)
+		repositoryMock.On("Delete").Return(nil)
+		service := &Service{personalTokenRepository: repositoryMock}
+
+		assert.NoError(t, service.RevokeByGroups(uuid.New(), []string{"group", "other"}))
+		repositoryMock.AssertNumberOfCalls(t, "Delete", 1)
+	})
+	t.Run("should return error when failed to list the personal tokens", func(t *testing.T) {
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("ListByAccountID").Return(&[]authEntities.PersonalToken{}, errors.New("test"))
+		service := &Service{personalTokenRepository: repositoryMock}
+
+		assert.Error(t, service.RevokeByGroups(uuid.New(), []string{"group"}))
+		repositoryMock.AssertNotCalled(t, "Delete")
+	})
+	t.Run("should return error when failed to revoke a personal token", func(t *testing.T) {
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("ListByAccountID").Return(&[]authEntities.PersonalToken{*getValidPersonalToken(uuid.New())}, nil)
+		repositoryMock.On("Delete").Return(errors.New("test"))
+		service := &Service{personalTokenRepository: repositoryMock}
+
+		assert.Error(t, service.RevokeByGroups(uuid.New(), []string{}))
+	})
+}
+
+func TestExchange(t *testing.T) {
+	t.Run("should return a jwt of the token owner", func(t *testing.T) {
+		account := &authEntities.Account{AccountID: uuid.New(), Email: "test@test.com", Username: "test"}
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("GetByValue").Return(getValidPersonalToken(account.AccountID), nil)
+		repositoryMock.On("UpdateLastUsedAt").Return(nil)
+		accountMock := &accountRepo.Mock{}
+		accountMock.On("GetByAccountID").Return(account, nil)
+		service := &Service{personalTokenRepository: repositoryMock, accountRepository: accountMock}
+
+		_, token, err := service.Exchange("hpat_test", authEnums.ReadAnalytics)
+		assert.NoError(t, err)
+		claims, err := jwt.DecodeToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, account.AccountID.String(), claims.Subject)
+		assert.Equal(t, []string{"group"}, claims.Permissions)
+		repositoryMock.AssertCalled(t, "UpdateLastUsedAt")
+	})
+	t.Run("should return a jwt with only the current ldap groups of the token owner", func(t *testing.T) {
+		account := &authEntities.Account{AccountID: uuid.New(), Email: "test@test.com", Username: "test"}
+		personalToken := getValidPersonalToken(account.AccountID)
+		personalToken.Permissions = []string{"group", "lost"}
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("GetByValue").Return(personalToken, nil)
+		repositoryMock.On("UpdateLastUsedAt").Return(nil)
+		accountMock := &accountRepo.Mock{}
+		accountMock.On("GetByAccountID").Return(account, nil)
+		ldapMock := &ldapService.Mock{}
+		ldapMock.On("GetGroupsOfUsername").Return([]string{"group", "other"}, nil)
+		ldapMock.On("Close")
+		service := &Service{personalTokenRepository: repositoryMock, accountRepository: accountMock,
+			authType: authEnums.Ldap, newLDAPClient: func() ldapService.ILDAPService { return ldapMock }}
+
+		_, token, err := service.Exchange("hpat_test", authEnums.ReadAnalytics)
+		assert.NoError(t, err)
+		claims, err := jwt.DecodeToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"group"}, claims.Permissions)
+		ldapMock.AssertCalled(t, "Close")
+	})
+	t.Run("should return unauthorized when failed to get the current ldap groups", func(t *testing.T) {
+		account := &authEntities.Account{AccountID: uuid.New(), Email: "test@test.com", Username: "test"}
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("GetByValue").Return(getValidPersonalToken(account.AccountID), nil)
+		accountMock := &accountRepo.Mock{}
+		accountMock.On("GetByAccountID").Return(account, nil)
+		ldapMock := &ldapService.Mock{}
+		ldapMock.On("GetGroupsOfUsername").Return([]string{}, errors.New("test"))
+		ldapMock.On("Close")
+		service := &Service{personalTokenRepository: repositoryMock, accountRepository: accountMock,
+			authType: authEnums.Ldap, newLDAPClient: func() ldapService.ILDAPService { return ldapMock }}
+
+		_, _, err := service.Exchange("hpat_test", authEnums.ReadAnalytics)
+		assert.Equal(t, errorsEnums.ErrorUnauthorized, err)
+		repositoryMock.AssertNotCalled(t, "UpdateLastUsedAt")
+	})
+	t.Run("should return unauthorized when token not found", func(t *testing.T) {
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("GetByValue").Return(&authEntities.PersonalToken{}, errorsEnums.ErrNotFoundRecords)
+		service := &Service{personalTokenRepository: repositoryMock}
+
+		_, _, err := service.Exchange("hpat_test", authEnums.ReadAnalytics)
+		assert.Equal(t, errorsEnums.ErrorUnauthorized, err)
+	})
+	t.Run("should return error when token is expired", func(t *testing.T) {
+		personalToken := getValidPersonalToken(uuid.New())
+		personalToken.ExpiresAt = time.Now().Add(-time.Hour)
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("GetByValue").Return(personalToken, nil)
+		service := &Service{personalTokenRepository: repositoryMock}
+
+		_, _, err := service.Exchange("hpat_test", authEnums.ReadAnalytics)
+		assert.Equal(t, errorsEnums.ErrorPersonalTokenExpired, err)
+	})
+	t.Run("should return error when token does not have the scope", func(t *testing.T) {
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("GetByValue").Return(getValidPersonalToken(uuid.New()), nil)
+		service := &Service{personalTokenRepository: repositoryMock}
+
+		_, _, err := service.Exchange("hpat_test", authEnums.ManageRepositories)
+		assert.Equal(t, errorsEnums.ErrorPersonalTokenScopeNotAllowed, err)
+	})
+	t.Run("should return unauthorized when account not found", func(t *testing.T) {
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("GetByValue").Return(getValidPersonalToken(uuid.New()), nil)
+		accountMock := &accountRepo.Mock{}
+		accountMock.On("GetByAccountID").Return(&authEntities.Account{}, errorsEnums.ErrNotFoundRecords)
+		service := &Service{personalTokenRepository: repositoryMock, accountRepository: accountMock}
+
+		_, _, err := service.Exchange("hpat_test", authEnums.ReadAnalytics)
+		assert.Equal(t, errorsEnums.ErrorUnauthorized, err)
+	})
+	t.Run("should return unauthorized when account is disabled", func(t *testing.T) {
+		repositoryMock := &personalTokenRepo.Mock{}
+		repositoryMock.On("GetByValue").Return(getValidPersonalToken(uuid.New()), nil)
+		accountMock := &accountRepo.Mock{}
+		accountMock.On("GetByAccountID").Return(&authEntities.Account{IsDisabled: true}, nil)
+		service := &Service{personalTokenRepository: repositoryMock, accountRepository: accountMock}
+
+		_, _, err := service.Exchange("hpat_test", authEnums.ReadAnalytics)
+		assert.Equal(t, errorsEnums.ErrorUnauthorized, err)
+		repositoryMock.AssertNotCalled(t, "UpdateLastUsedAt")
+	})
+}
@@ -54,6 +54,8 @@ type IUseCases interface {
 	NewRefreshTokenFromReadCloser(body io.ReadCloser) (token string, err error)
 	NewValidateUniqueFromReadCloser(body io.ReadCloser) (validateUnique *dto.ValidateUnique, err error)
 	NewAccountUpdateFromReadCloser(body io.ReadCloser) (*authEntities.Account, error)
+	NewTwoFactorCodeFromReadCloser(body io.ReadCloser) (*dto.TwoFactorCode, error)
+	NewPersonalTokenFromReadCloser(body io.ReadCloser) (*authEntities.PersonalToken, error)
 }
 
 type UseCases struct {
@@ -103,6 +105,7 @@ func (u *UseCases) ToLoginResponse(account *authEntities.Account, accessToken, r
 		Username:           account.Username,
 		IsApplicationAdmin: account.IsApplicationAdmin,
 		Email:              account.Email,
+		IsTwoFactorEnabled: account.IsTwoFactorEnabled,
 	}
 }
 
@@ -121,6 +124,10 @@ func (u *UseCases) ValidateLogin(account *authEntities.Account, loginData *dto.L
 		return errors.ErrorWrongEmailOrPassword
 	}
 
+	if err := account.IsAccountEnabled(); err != nil {
+		return err
+	}
+
 	return account.IsAccountConfirmed()
 }
 
@@ -277,3 +284,34 @@ func (u *UseCases) NewValidateUniqueFromReadCloser(
 
 	return validateUnique, validateUnique.Validate()
 }
+
+func (u *UseCases) NewTwoFactorCodeFromReadCloser(body io.ReadCloser) (*dto.TwoFactorCode, error) {
+	if body == nil {
+		return nil, errors.ErrorErrorEmptyBody
+	}
+
+	twoFactorCode := &dto.TwoFactorCode{}
+	err := json.NewDecoder(body).Decode(&twoFactorCode)
+	_ = body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return twoFactorCode, twoFactorCode.Validate()
+}
+
+func (u *UseCases) NewPersonalTokenFromReadCloser(body io.ReadCloser) (*authEntities.PersonalToken, error) {
+	if body == nil {
+		return nil, errors.ErrorErrorEmptyBody
+	}
+
+	createPersonalToken := &dto.CreatePersonalToken{}
+	err := json.NewDecoder(body).Decode(&createPersonalToken)
+	_ = body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	personalToken := createPersonalToken.ToPersonalToken()
+	return personalToken, personalToken.Validate()
+}
@@ -174,6 +174,26 @@ func TestValidateLogin(t *testing.T) {
 		assert.Equal(t, errorsEnums.ErrorAccountEmailNotConfirmed, err)
 	})
 
+	t.Run("should return error when account is disabled", func(t *testing.T) {
+		account := &authEntities.Account{
+			Email:       "test@test.com",
+			Username:    "test",
+			AccountID:   uuid.New(),
+			IsConfirmed: true,
+			IsDisabled:  true,
+			Password:    "$2a$10$sjCTUO0VLAxW10KwRXAIn.lLuDtUf8xqZHy9CrmJLd77Ief4J21yS",
+		}
+
+		loginData := &dto.LoginData{
+			Email:    "test@test.com",
+			Password: "2131231",
+		}
+
+		useCases := NewAuthUseCases()
+		err := useCases.ValidateLogin(account, loginData)
+		assert.Equal(t, errorsEnums.ErrorAccountDisabled, err)
+	})
+
 	t.Run("should return error when invalid password", func(t *testing.T) {
 		account := &authEntities.Account{
 			Email:       "test@test.com",
@@ -412,6 +432,56 @@ func TestNewResetCodeDataFromReadCloser(t *testing.T) {
 	})
 }
 
+func TestNewTwoFactorCodeFromReadCloser(t *testing.T) {
+	t.Run("should return two factor code from read closer", func(t *testing.T) {
+		readCloser := ioutil.NopCloser(strings.NewReader(`{"code": "123456"}`))
+
+		useCases := NewAuthUseCases()
+		twoFactorCode, err := useCases.NewTwoFactorCodeFromReadCloser(readCloser)
+		assert.NoError(t, err)
+		assert.Equal(t, "123456", twoFactorCode.Code)
+	})
+
+	t.Run("should return error when invalid data", func(t *testing.T) {
+		useCases := NewAuthUseCases()
+
+		_, err := useCases.NewTwoFactorCodeFromReadCloser(ioutil.NopCloser(strings.NewReader("test")))
+		assert.Error(t, err)
+
+		_, err = useCases.NewTwoFactorCodeFromReadCloser(ioutil.NopCloser(strings.NewReader(`{"code": "1"}`)))
+		assert.Error(t, err)
+
+		_, err = useCases.NewTwoFactorCodeFromReadCloser(nil)
+		assert.Equal(t, errorsEnums.ErrorErrorEmptyBody, err)
+	})
+}
+
+func TestNewPersonalTokenFromReadCloser(t *testing.T) {
+	t.Run("should return personal token from read closer", func(t *testing.T) {
+		readCloser := ioutil.NopCloser(strings.NewReader(`{"description": "ci", "scopes": ["read:analytics"]}`))
+
+		useCases := NewAuthUseCases()
+		personalToken, err := useCases.NewPersonalTokenFromReadCloser(readCloser)
+		assert.NoError(t, err)
+		assert.Equal(t, "ci", personalToken.Description)
+		assert.Equal(t, []string{"read:analytics"}, []string(personalToken.Scopes))
+	})
+
+	t.Run("should return error when invalid data", func(t *testing.T) {
+		useCases := NewAuthUseCases()
+
+		_, err := useCases.NewPersonalTokenFromReadCloser(ioutil.NopCloser(strings.NewReader("test")))
+		assert.Error(t, err)
+
+		_, err = useCases.NewPersonalTokenFromReadCloser(
+			ioutil.NopCloser(strings.NewReader(`{"description": "ci", "scopes": ["write:all"]}`)))
+		assert.Error(t, err)
+
+		_, err = useCases.NewPersonalTokenFromReadCloser(nil)
+		assert.Equal(t, errorsEnums.ErrorErrorEmptyBody, err)
+	})
+}
+
 func TestNewEmailDataFromReadCloser(t *testing.T) {
 	data := &dto.EmailData{
 		Email: "test@test.com",
@@ -0,0 +1,144 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compare
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-cli/internal/helpers/messages"
+	"github.com/spf13/cobra"
+)
+
+const (
+	OutputFormatText = "text"
+	OutputFormatJSON = "json"
+)
+
+var ErrInvalidOutputFormat = errors.New("{HORUSEC-CLI} invalid output format, options are: text, json")
+
+type ICompare interface {
+	CreateCobraCmd() *cobra.Command
+}
+
+type Compare struct {
+	outputFormat string
+}
+
+func NewCompareCommand() ICompare {
+	return &Compare{}
+}
+
+func (c *Compare) CreateCobraCmd() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:   "compare [base-analysis.json] [head-analysis.json]",
+		Short: "Compare two analyses saved with the json output",
+		Long: "Compare two analyses saved with the json output and show the new, fixed and unchanged " +
+			"vulnerabilities by hash and the errors of the tools that appeared or disappeared",
+		Example: "horusec compare ./base-analysis.json ./head-analysis.json",
+		Args:    cobra.ExactArgs(2),
+		RunE:    c.runE,
+	}
+	cobraCmd.Flags().StringVarP(&c.outputFormat, "output-format", "o", OutputFormatText,
+		"The format for the output to be shown. Options are: text (stdout), json")
+	return cobraCmd
+}
+
+func (c *Compare) runE(cmd *cobra.Command, args []string) error {
+	if c.outputFormat != OutputFormatText && c.outputFormat != OutputFormatJSON {
+		return ErrInvalidOutputFormat
+	}
+	base, err := c.readAnalysisFile(args[0])
+	if err != nil {
+		return err
+	}
+	head, err := c.readAnalysisFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	comparison := horusec.NewAnalysisComparison(base, head)
+	if c.outputFormat == OutputFormatJSON {
+		return c.printJSON(cmd.OutOrStdout(), comparison)
+	}
+	c.printText(cmd.OutOrStdout(), comparison)
+	return nil
+}
+
+func (c *Compare) readAnalysisFile(path string) (*horusec.Analysis, error) {
+	analysis := &horusec.Analysis{}
+	content, err := ioutil.ReadFile(filepath.Clean(path))
+	if err == nil {
+		err = json.Unmarshal(content, analysis)
+	}
+	if err != nil {
+		logger.LogError(messages.MsgErrorOnReadAnalysisFile+path, err)
+		return nil, err
+	}
+	return analysis, nil
+}
+
+func (c *Compare) printJSON(output io.Writer, comparison *horusec.AnalysisComparison) error {
+	content, err := json.MarshalIndent(comparison, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(output, string(content))
+	return err
+}
+
+func (c *Compare) printText(output io.Writer, comparison *horusec.AnalysisComparison) {
+	_, _ = fmt.Fprintf(output, "Comparing analysis %s with %s\n\n", comparison.BaseAnalysisID,
+		comparison.HeadAnalysisID)
+	c.printBucket(output, "New vulnerabilities", &comparison.New, true)
+	c.printBucket(output, "Fixed vulnerabilities", &comparison.Fixed, true)
+	c.printBucket(output, "Unchanged vulnerabilities", &comparison.Unchanged, false)
+	c.printErrors(output, "Errors that appeared", comparison.AppearedErrors)
+	c.printErrors(output, "Errors that disappeared", comparison.DisappearedErrors)
+}
+
+func (c *Compare) printBucket(output io.Writer, title string, bucket *horusec.ComparisonBucket,
+	withVulnerabilities bool) {
+	_, _ = fmt.Fprintf(output, "%s: %d", title, bucket.Total)
+	for _, value := range []severity.Severity{severity.High, severity.Medium, severity.Low, severity.Audit,
+		severity.Info, severity.NoSec} {
+		if total := bucket.TotalBySeverity[value]; total > 0 {
+			_, _ = fmt.Fprintf(output, " | %s: %d", value, total)
+		}
+	}
+	_, _ = fmt.Fprintln(output)
+	if !withVulnerabilities {
+		return
+	}
+	for index := range bucket.Vulnerabilities {
+		vulnerability := bucket.Vulnerabilities[index]
+		_, _ = fmt.Fprintf(output, "  [%s] %s:%s %s (%s)\n", vulnerability.Severity, vulnerability.File,
+			vulnerability.Line, vulnerability.Details, vulnerability.SecurityTool)
+	}
+}
+
+func (c *Compare) printErrors(output io.Writer, title string, errors []string) {
+	_, _ = fmt.Fprintf(output, "%s: %d\n", title, len(errors))
+	for _, err := range errors {
+		_, _ = fmt.Fprintf(output, "  %s\n", err)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compare
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	_ = os.RemoveAll("./tmp")
+	_ = os.MkdirAll("./tmp", 0750)
+	code := m.Run()
+	_ = os.RemoveAll("./tmp")
+	os.Exit(code)
+}
+
+func writeAnalysisFile(t *testing.T, path, errors string, vulnerabilities ...horusec.Vulnerability) {
+	analysis := &horusec.Analysis{ID: uuid.New(), Errors: errors}
+	for index := range vulnerabilities {
+		analysis.AnalysisVulnerabilities = append(analysis.AnalysisVulnerabilities,
+			horusec.AnalysisVulnerabilities{Vulnerability: vulnerabilities[index]})
+	}
+	content, err := json.MarshalIndent(analysis, "", "  ")
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(path, content, 0600))
+}
+
+func executeCompare(args ...string) (string, error) {
+	output := bytes.NewBufferString("")
+	cobraCmd := NewCompareCommand().CreateCobraCmd()
+	cobraCmd.SetOut(output)
+	cobraCmd.SetErr(output)
+	cobraCmd.SetArgs(args)
+	err := cobraCmd.Execute()
+	return output.String(), err
+}
+
+func TestCompare_CreateCobraCmd(t *testing.T) {
+	writeAnalysisFile(t, "./tmp/base.json", "gosec failed",
+		horusec.Vulnerability{VulnHash: "fixed", Severity: severity.High, File: "main.go", Line: "10"},
+		horusec.Vulnerability{VulnHash: "unchanged", Severity: severity.Low})
+	writeAnalysisFile(t, "./tmp/head.json", "bandit failed",
+		horusec.Vulnerability{VulnHash: "unchanged", Severity: severity.Low},
+		horusec.Vulnerability{VulnHash: "new", Severity: severity.Medium, File: "app.go", Line: "20"})
+
+	t.Run("should print the comparison as text", func(t *testing.T) {
+		output, err := executeCompare("./tmp/base.json", "./tmp/head.json")
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "New vulnerabilities: 1 | MEDIUM: 1")
+		assert.Contains(t, output, "[MEDIUM] app.go:20")
+		assert.Contains(t, output, "Fixed vulnerabilities: 1 | HIGH: 1")
+		assert.Contains(t, output, "[HIGH] main.go:10")
+		assert.Contains(t, output, "Unchanged vulnerabilities: 1 | LOW: 1")
+		assert.Contains(t, output, "Errors that appeared: 1\n  bandit failed")
+		assert.Contains(t, output, "Errors that disappeared: 1\n  gosec failed")
+	})
+
+	t.Run("should print the comparison as json", func(t *testing.T) {
+		output, err := executeCompare("./tmp/base.json", "./tmp/head.json", "--output-format", "json")
+		assert.NoError(t, err)
+
+		comparison := &horusec.AnalysisComparison{}
+		assert.NoError(t, json.Unmarshal([]byte(output), comparison))
+		assert.Equal(t, "new", comparison.New.Vulnerabilities[0].VulnHash)
+		assert.Equal(t, "fixed", comparison.Fixed.Vulnerabilities[0].VulnHash)
+		assert.Equal(t, 1, comparison.Unchanged.TotalBySeverity[severity.Low])
+	})
+
+	t.Run("should return error when output format is invalid", func(t *testing.T) {
+		_, err := executeCompare("./tmp/base.json", "./tmp/head.json", "-o", "sonarqube")
+
+		assert.Equal(t, ErrInvalidOutputFormat, err)
+	})
+
+	t.Run("should return error when file does not exist", func(t *testing.T) {
+		_, err := executeCompare("./tmp/base.json", "./tmp/not-found.json")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when file is not an analysis", func(t *testing.T) {
+		assert.NoError(t, ioutil.WriteFile("./tmp/invalid.json", []byte("invalid"), 0600))
+
+		_, err := executeCompare("./tmp/invalid.json", "./tmp/head.json")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when missing analysis file", func(t *testing.T) {
+		_, err := executeCompare("./tmp/base.json")
+
+		assert.Error(t, err)
+	})
+}
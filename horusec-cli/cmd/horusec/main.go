@@ -16,7 +16,9 @@ package main
 
 import (
 	engine "github.com/ZupIT/horusec-engine"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-cli/cmd/horusec/compare"
 	"github.com/ZupIT/horusec/horusec-cli/cmd/horusec/generate"
 	"github.com/ZupIT/horusec/horusec-cli/cmd/horusec/start"
 	"github.com/ZupIT/horusec/horusec-cli/cmd/horusec/version"
@@ -51,6 +53,7 @@ func init() {
 	rootCmd.AddCommand(version.NewVersionCommand().CreateCobraCmd())
 	rootCmd.AddCommand(startCmd.CreateStartCommand())
 	rootCmd.AddCommand(generateCmd.CreateCobraCmd())
+	rootCmd.AddCommand(compare.NewCompareCommand().CreateCobraCmd())
 
 	cobra.OnInitialize(func() {
 		startCmd.SetGlobalCmd(rootCmd)
@@ -60,7 +63,10 @@ func init() {
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	shutdownTracing := tracing.SetUpTracing("horusec-cli")
+	err := rootCmd.Execute()
+	shutdownTracing()
+	if err != nil {
 		os.Exit(1)
 	} else {
 		os.Exit(0)
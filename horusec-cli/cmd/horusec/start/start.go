@@ -28,6 +28,7 @@ import (
 
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	"github.com/ZupIT/horusec/horusec-cli/internal/controllers/analyser"
+	"github.com/ZupIT/horusec/horusec-cli/internal/controllers/printresults"
 	"github.com/ZupIT/horusec/horusec-cli/internal/utils/prompt"
 	"github.com/spf13/cobra"
 )
@@ -105,10 +106,14 @@ func (s *Start) CreateStartCommand() *cobra.Command {
 		BoolP("enable-commit-author", "G", s.configs.GetEnableCommitAuthor(), "Used to enable or disable search with vulnerability author. Example -G=\"true\"")
 	_ = startCmd.PersistentFlags().
 		StringP("repository-name", "n", s.configs.GetRepositoryName(), "Used to send repository name to horus server. Example -n=\"horus\"")
+	_ = startCmd.PersistentFlags().
+		String("branch-name", s.configs.GetBranchName(), "Used to send the branch of the analysis to horus server, by default is the current git branch. Example --branch-name=\"main\"")
+	_ = startCmd.PersistentFlags().
+		String("commit-hash", s.configs.GetCommitHash(), "Used to send the commit hash of the analysis to horus server, by default is the current git commit. Example --commit-hash=\"a1b2c3\"")
 	_ = startCmd.PersistentFlags().
 		StringSliceP("false-positive", "F", s.configs.GetFalsePositiveHashes(), "Used to ignore a vulnerability by hash and setting it to be of the false positive type. Example -F=\"hash1, hash2\"")
 	_ = startCmd.PersistentFlags().
-		StringSliceP("risk-accept", "R", s.configs.GetRiskAcceptHashes(), "Used to ignore a vulnerability by hash and setting it to be of the risk accept type. Example -R=\"hash3, hash4\"")
+		StringSliceP("risk-accept", "R", s.configs.GetRiskAcceptHashes(), "Used to ignore a vulnerability by hash and setting it to be of the risk accept type. An expiration date can be set after the hash, when expired the vulnerability is considered again. Example -R=\"hash3, hash4:2021-12-31\"")
 	_ = startCmd.PersistentFlags().
 		StringSliceP("tools-ignore", "T", s.configs.GetToolsToIgnore(), "Tools to ignore in the analysis. Available are: GoSec,SecurityCodeScan,Brakeman,Safety,Bandit,NpmAudit,YarnAudit,SpotBugs,HorusecKotlin,HorusecJava,HorusecLeaks,GitLeaks,TfSec,Semgrep,HorusecCsharp,HorusecNodeJS,HorusecKubernetes,Eslint,PhpCS,Flawfinder. Example: -T=\"GoSec, Brakeman\"")
 	_ = startCmd.PersistentFlags().
@@ -119,6 +124,8 @@ func (s *Start) CreateStartCommand() *cobra.Command {
 		BoolP("disable-docker", "D", s.configs.GetEnableCommitAuthor(), "Used to run horusec without docker if enabled it will only run the following tools: horusec-csharp, horusec-kotlin, horusec-kubernetes, horusec-leaks, horusec-nodejs. Example: -D=\"true\"")
 	_ = startCmd.PersistentFlags().
 		BoolP("information-severity", "I", s.configs.GetEnableInformationSeverity(), "Used to enable or disable information severity vulnerabilities, information vulnerabilities can contain a lot of false positives. Example: -I=\"true\"")
+	_ = startCmd.PersistentFlags().
+		Bool("enable-policies", s.configs.GetEnablePolicies(), "Used to exit with code 1 when the analysis was not evaluated as passed in the policies of the company, the repository token is required. Example --enable-policies=\"true\"")
 	return startCmd
 }
 
@@ -132,20 +139,27 @@ func (s *Start) setConfig(startCmd *cobra.Command) {
 func (s *Start) runE(cmd *cobra.Command, _ []string) error {
 	s.setConfig(cmd)
 	totalVulns, err := s.startAnalysis(cmd)
+	if err == printresults.ErrPolicyFailed || err == printresults.ErrPolicyNotEvaluated {
+		s.disableUsage(cmd)
+		return err
+	}
 	if err != nil {
 		return err
 	}
 
 	if totalVulns > 0 && s.configs.GetReturnErrorIfFoundVulnerability() {
-		cmd.SetUsageFunc(func(command *cobra.Command) error {
-			return nil
-		})
-
+		s.disableUsage(cmd)
 		return errors.New("analysis finished with blocking vulnerabilities")
 	}
 	return nil
 }
 
+func (s *Start) disableUsage(cmd *cobra.Command) {
+	cmd.SetUsageFunc(func(command *cobra.Command) error {
+		return nil
+	})
+}
+
 func (s *Start) startAnalysis(cmd *cobra.Command) (totalVulns int, err error) {
 	if err := s.askIfRunInDirectorySelected(s.isRunPromptQuestion(cmd)); err != nil {
 		logger.LogErrorWithLevel(messages.MsgErrorWhenAskDirToRun, err)
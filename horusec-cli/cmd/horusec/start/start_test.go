@@ -29,6 +29,7 @@ import (
 
 	"github.com/ZupIT/horusec/horusec-cli/config"
 	"github.com/ZupIT/horusec/horusec-cli/internal/controllers/analyser"
+	"github.com/ZupIT/horusec/horusec-cli/internal/controllers/printresults"
 	"github.com/ZupIT/horusec/horusec-cli/internal/entities/workdir"
 	"github.com/ZupIT/horusec/horusec-cli/internal/usecases/cli"
 	"github.com/ZupIT/horusec/horusec-cli/internal/utils/prompt"
@@ -165,6 +166,73 @@ func TestStartCommand_Execute(t *testing.T) {
 
 		promptMock.AssertNotCalled(t, "Ask")
 	})
+	t.Run("Should execute command exec and return error because analysis failed in policies", func(t *testing.T) {
+		promptMock := &prompt.Mock{}
+		promptMock.On("Ask").Return("Y", nil)
+
+		stdoutMock := bytes.NewBufferString("")
+		logrus.SetOutput(stdoutMock)
+
+		configs := &config.Config{}
+		configs.SetWorkDir(&workdir.WorkDir{})
+		configs.NewConfigsFromEnvironments()
+		analyserControllerMock := &analyser.Mock{}
+		analyserControllerMock.On("AnalysisDirectory").Return(0, printresults.ErrPolicyFailed)
+
+		requirementsMock := &requirements.Mock{}
+		requirementsMock.On("ValidateDocker")
+
+		cmd := &Start{
+			globalCmd:              globalCmd,
+			useCases:               cli.NewCLIUseCases(),
+			configs:                configs,
+			startPrompt:            promptMock,
+			analyserController:     analyserControllerMock,
+			requirementsController: requirementsMock,
+		}
+
+		cobraCmd := cmd.CreateStartCommand()
+		cobraCmd.SetOut(stdoutMock)
+		cobraCmd.SetArgs([]string{"-p", "./"})
+
+		assert.Equal(t, printresults.ErrPolicyFailed, cobraCmd.Execute())
+
+		promptMock.AssertNotCalled(t, "Ask")
+	})
+	t.Run("Should execute command exec and return error because analysis has no verdict of enabled policies", func(t *testing.T) {
+		promptMock := &prompt.Mock{}
+		promptMock.On("Ask").Return("Y", nil)
+
+		stdoutMock := bytes.NewBufferString("")
+		logrus.SetOutput(stdoutMock)
+
+		configs := &config.Config{}
+		configs.SetWorkDir(&workdir.WorkDir{})
+		configs.NewConfigsFromEnvironments()
+		analyserControllerMock := &analyser.Mock{}
+		analyserControllerMock.On("AnalysisDirectory").Return(0, printresults.ErrPolicyNotEvaluated)
+
+		requirementsMock := &requirements.Mock{}
+		requirementsMock.On("ValidateDocker")
+
+		cmd := &Start{
+			globalCmd:              globalCmd,
+			useCases:               cli.NewCLIUseCases(),
+			configs:                configs,
+			startPrompt:            promptMock,
+			analyserController:     analyserControllerMock,
+			requirementsController: requirementsMock,
+		}
+
+		cobraCmd := cmd.CreateStartCommand()
+		cobraCmd.SetOut(stdoutMock)
+		cobraCmd.SetArgs([]string{"-p", "./", "--enable-policies", "true"})
+
+		assert.Equal(t, printresults.ErrPolicyNotEvaluated, cobraCmd.Execute())
+		assert.True(t, configs.GetEnablePolicies())
+
+		promptMock.AssertNotCalled(t, "Ask")
+	})
 	t.Run("Should execute command exec and return error because found error when ask but run in current folder", func(t *testing.T) {
 		promptMock := &prompt.Mock{}
 		promptMock.On("Ask").Return("", errors.New("some error"))
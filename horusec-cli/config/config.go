@@ -72,6 +72,8 @@ func (c *Config) NewConfigsFromCobraAndLoadsCmdStartFlags(cmd *cobra.Command) IC
 	c.SetCertPath(c.extractFlagValueString(cmd, "certificate-path", c.GetCertPath()))
 	c.SetEnableCommitAuthor(c.extractFlagValueBool(cmd, "enable-commit-author", c.GetEnableCommitAuthor()))
 	c.SetRepositoryName(c.extractFlagValueString(cmd, "repository-name", c.GetRepositoryName()))
+	c.SetBranchName(c.extractFlagValueString(cmd, "branch-name", c.GetBranchName()))
+	c.SetCommitHash(c.extractFlagValueString(cmd, "commit-hash", c.GetCommitHash()))
 	c.SetFalsePositiveHashes(c.extractFlagValueStringSlice(cmd, "false-positive", c.GetFalsePositiveHashes()))
 	c.SetRiskAcceptHashes(c.extractFlagValueStringSlice(cmd, "risk-accept", c.GetRiskAcceptHashes()))
 	c.SetToolsToIgnore(c.extractFlagValueStringSlice(cmd, "tools-ignore", c.GetToolsToIgnore()))
@@ -79,6 +81,7 @@ func (c *Config) NewConfigsFromCobraAndLoadsCmdStartFlags(cmd *cobra.Command) IC
 	c.SetDisableDocker(c.extractFlagValueBool(cmd, "disable-docker", c.GetDisableDocker()))
 	c.SetCustomRulesPath(c.extractFlagValueString(cmd, "custom-rules-path", c.GetCustomRulesPath()))
 	c.SetEnableInformationSeverity(c.extractFlagValueBool(cmd, "information-severity", c.GetEnableInformationSeverity()))
+	c.SetEnablePolicies(c.extractFlagValueBool(cmd, "enable-policies", c.GetEnablePolicies()))
 	return c
 }
 
@@ -105,6 +108,8 @@ func (c *Config) NewConfigsFromViper() IConfig {
 	c.SetCertPath(viper.GetString(c.toLowerCamel(EnvCertPath)))
 	c.SetEnableCommitAuthor(viper.GetBool(c.toLowerCamel(EnvEnableCommitAuthor)))
 	c.SetRepositoryName(viper.GetString(c.toLowerCamel(EnvRepositoryName)))
+	c.SetBranchName(viper.GetString(c.toLowerCamel(EnvBranchName)))
+	c.SetCommitHash(viper.GetString(c.toLowerCamel(EnvCommitHash)))
 	c.SetFalsePositiveHashes(viper.GetStringSlice(c.toLowerCamel(EnvFalsePositiveHashes)))
 	c.SetRiskAcceptHashes(viper.GetStringSlice(c.toLowerCamel(EnvRiskAcceptHashes)))
 	c.SetToolsToIgnore(viper.GetStringSlice(c.toLowerCamel(EnvToolsToIgnore)))
@@ -114,6 +119,7 @@ func (c *Config) NewConfigsFromViper() IConfig {
 	c.SetDisableDocker(viper.GetBool(c.toLowerCamel(EnvDisableDocker)))
 	c.SetCustomRulesPath(viper.GetString(c.toLowerCamel(EnvCustomRulesPath)))
 	c.SetEnableInformationSeverity(viper.GetBool(c.toLowerCamel(EnvEnableInformationSeverity)))
+	c.SetEnablePolicies(viper.GetBool(c.toLowerCamel(EnvEnablePolicies)))
 	return c
 }
 
@@ -136,6 +142,8 @@ func (c *Config) NewConfigsFromEnvironments() IConfig {
 	c.SetCertPath(env.GetEnvOrDefault(EnvCertPath, c.certPath))
 	c.SetEnableCommitAuthor(env.GetEnvOrDefaultBool(EnvEnableCommitAuthor, c.enableCommitAuthor))
 	c.SetRepositoryName(env.GetEnvOrDefault(EnvRepositoryName, c.repositoryName))
+	c.SetBranchName(env.GetEnvOrDefault(EnvBranchName, c.branchName))
+	c.SetCommitHash(env.GetEnvOrDefault(EnvCommitHash, c.commitHash))
 	c.SetFalsePositiveHashes(c.factoryParseInputToSliceString(env.GetEnvOrDefaultInterface(EnvFalsePositiveHashes, c.falsePositiveHashes)))
 	c.SetRiskAcceptHashes(c.factoryParseInputToSliceString(env.GetEnvOrDefaultInterface(EnvRiskAcceptHashes, c.riskAcceptHashes)))
 	c.SetToolsToIgnore(c.factoryParseInputToSliceString(env.GetEnvOrDefaultInterface(EnvToolsToIgnore, c.toolsToIgnore)))
@@ -144,6 +152,7 @@ func (c *Config) NewConfigsFromEnvironments() IConfig {
 	c.SetDisableDocker(env.GetEnvOrDefaultBool(EnvDisableDocker, c.disableDocker))
 	c.SetCustomRulesPath(env.GetEnvOrDefault(EnvCustomRulesPath, c.customRulesPath))
 	c.SetEnableInformationSeverity(env.GetEnvOrDefaultBool(EnvEnableInformationSeverity, c.enableInformationSeverity))
+	c.SetEnablePolicies(env.GetEnvOrDefaultBool(EnvEnablePolicies, c.enablePolicies))
 	return c
 }
 
@@ -327,6 +336,22 @@ func (c *Config) SetRepositoryName(repositoryName string) {
 	c.repositoryName = repositoryName
 }
 
+func (c *Config) GetBranchName() string {
+	return c.branchName
+}
+
+func (c *Config) SetBranchName(branchName string) {
+	c.branchName = branchName
+}
+
+func (c *Config) GetCommitHash() string {
+	return c.commitHash
+}
+
+func (c *Config) SetCommitHash(commitHash string) {
+	c.commitHash = commitHash
+}
+
 func (c *Config) GetRiskAcceptHashes() (output []string) {
 	return c.riskAcceptHashes
 }
@@ -460,6 +485,8 @@ func (c *Config) toMap() map[string]interface{} {
 		"filterPath":                      c.filterPath,
 		"certPath":                        c.certPath,
 		"repositoryName":                  c.repositoryName,
+		"branchName":                      c.branchName,
+		"commitHash":                      c.commitHash,
 		"printOutputType":                 c.printOutputType,
 		"jsonOutputFilePath":              c.jsonOutputFilePath,
 		"projectPath":                     c.projectPath,
@@ -483,6 +510,7 @@ func (c *Config) toMap() map[string]interface{} {
 		"disableDocker":                   c.disableDocker,
 		"customRulesPath":                 c.customRulesPath,
 		"enableInformationSeverity":       c.enableInformationSeverity,
+		"enablePolicies":                  c.enablePolicies,
 	}
 }
 
@@ -517,6 +545,8 @@ func (c *Config) ToMapLowerCase() map[string]interface{} {
 		c.toLowerCamel(EnvCertPath):                        c.GetCertPath(),
 		c.toLowerCamel(EnvEnableCommitAuthor):              c.GetEnableCommitAuthor(),
 		c.toLowerCamel(EnvRepositoryName):                  c.GetRepositoryName(),
+		c.toLowerCamel(EnvBranchName):                      c.GetBranchName(),
+		c.toLowerCamel(EnvCommitHash):                      c.GetCommitHash(),
 		c.toLowerCamel(EnvFalsePositiveHashes):             c.GetFalsePositiveHashes(),
 		c.toLowerCamel(EnvRiskAcceptHashes):                c.GetRiskAcceptHashes(),
 		c.toLowerCamel(EnvToolsToIgnore):                   c.GetToolsToIgnore(),
@@ -526,6 +556,7 @@ func (c *Config) ToMapLowerCase() map[string]interface{} {
 		c.toLowerCamel(EnvDisableDocker):                   c.GetDisableDocker(),
 		c.toLowerCamel(EnvCustomRulesPath):                 c.GetCustomRulesPath(),
 		c.toLowerCamel(EnvEnableInformationSeverity):       c.GetEnableInformationSeverity(),
+		c.toLowerCamel(EnvEnablePolicies):                  c.GetEnablePolicies(),
 	}
 }
 
@@ -610,3 +641,11 @@ func (c *Config) GetEnableInformationSeverity() bool {
 func (c *Config) SetEnableInformationSeverity(enableInformationSeverity bool) {
 	c.enableInformationSeverity = enableInformationSeverity
 }
+
+func (c *Config) GetEnablePolicies() bool {
+	return c.enablePolicies
+}
+
+func (c *Config) SetEnablePolicies(enablePolicies bool) {
+	c.enablePolicies = enablePolicies
+}
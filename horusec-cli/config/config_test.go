@@ -63,6 +63,7 @@ func TestNewHorusecConfig(t *testing.T) {
 		assert.Equal(t, false, configs.GetDisableDocker())
 		assert.Equal(t, "", configs.GetCustomRulesPath())
 		assert.Equal(t, false, configs.GetEnableInformationSeverity())
+		assert.Equal(t, false, configs.GetEnablePolicies())
 	})
 	t.Run("Should change horusec config and return your new values", func(t *testing.T) {
 		currentPath, _ := os.Getwd()
@@ -265,6 +266,7 @@ func TestNewHorusecConfig(t *testing.T) {
 		assert.NoError(t, os.Setenv(EnvDisableDocker, "true"))
 		assert.NoError(t, os.Setenv(EnvCustomRulesPath, "test"))
 		assert.NoError(t, os.Setenv(EnvEnableInformationSeverity, "true"))
+		assert.NoError(t, os.Setenv(EnvEnablePolicies, "true"))
 		configs.NewConfigsFromEnvironments()
 		assert.Equal(t, configFilePath, configs.GetConfigFilePath())
 		assert.Equal(t, "http://horusec.com", configs.GetHorusecAPIUri())
@@ -292,6 +294,7 @@ func TestNewHorusecConfig(t *testing.T) {
 		assert.Equal(t, true, configs.GetDisableDocker())
 		assert.Equal(t, "test", configs.GetCustomRulesPath())
 		assert.Equal(t, true, configs.GetEnableInformationSeverity())
+		assert.Equal(t, true, configs.GetEnablePolicies())
 	})
 	t.Run("Should return horusec config using viper file and override by environment and override by flags", func(t *testing.T) {
 		viper.Reset()
@@ -469,3 +472,18 @@ func TestConfig_ToBytes(t *testing.T) {
 		assert.NotEmpty(t, config.ToBytes(true))
 	})
 }
+
+func TestConfig_BranchAndCommit(t *testing.T) {
+	t.Run("Should set branch and commit from environments", func(t *testing.T) {
+		assert.NoError(t, os.Setenv(EnvBranchName, "main"))
+		assert.NoError(t, os.Setenv(EnvCommitHash, "a1b2c3"))
+		defer os.Unsetenv(EnvBranchName)
+		defer os.Unsetenv(EnvCommitHash)
+
+		config := &Config{}
+		config.NewConfigsFromEnvironments()
+
+		assert.Equal(t, "main", config.GetBranchName())
+		assert.Equal(t, "a1b2c3", config.GetCommitHash())
+	})
+}
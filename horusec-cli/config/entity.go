@@ -138,6 +138,12 @@ const (
 	// Used to send the repository name to the server, must be used together with the company token.
 	// By default is empty
 	EnvRepositoryName = "HORUSEC_CLI_REPOSITORY_NAME"
+	// Used to send the branch of the analysis to the server, it is required by tokens with allowed branches.
+	// By default is the current branch of the git repository in project path
+	EnvBranchName = "HORUSEC_CLI_BRANCH_NAME"
+	// Used to send the commit hash of the analysis to the server.
+	// By default is the current commit of the git repository in project path
+	EnvCommitHash = "HORUSEC_CLI_COMMIT_HASH"
 	// Used to skip vulnerability of type false positive
 	// By default is empty
 	EnvFalsePositiveHashes = "HORUSEC_CLI_FALSE_POSITIVE_HASHES"
@@ -170,6 +176,11 @@ const (
 	// By default is false
 	// Validation: It is mandatory to be in "false", "true"
 	EnvEnableInformationSeverity = "HORUSEC_CLI_ENABLE_INFORMATION_SEVERITY"
+	// Used to exit with code 1 when the analysis did not pass in the policies of the company, including when
+	// horusec-api could not evaluate them or the verdict could not be read.
+	// By default is false
+	// Validation: It is mandatory to be in "false", "true"
+	EnvEnablePolicies = "HORUSEC_CLI_ENABLE_POLICIES"
 )
 
 type Config struct {
@@ -183,6 +194,8 @@ type Config struct {
 	filterPath                      string
 	certPath                        string
 	repositoryName                  string
+	branchName                      string
+	commitHash                      string
 	printOutputType                 string
 	jsonOutputFilePath              string
 	projectPath                     string
@@ -198,6 +211,7 @@ type Config struct {
 	enableCommitAuthor              bool
 	disableDocker                   bool
 	enableInformationSeverity       bool
+	enablePolicies                  bool
 	severitiesToIgnore              []string
 	filesOrPathsToIgnore            []string
 	falsePositiveHashes             []string
@@ -73,6 +73,12 @@ type IConfig interface {
 	GetRepositoryName() string
 	SetRepositoryName(repositoryName string)
 
+	GetBranchName() string
+	SetBranchName(branchName string)
+
+	GetCommitHash() string
+	SetCommitHash(commitHash string)
+
 	GetRiskAcceptHashes() (output []string)
 	SetRiskAcceptHashes(riskAccept []string)
 
@@ -100,6 +106,9 @@ type IConfig interface {
 	GetEnableInformationSeverity() bool
 	SetEnableInformationSeverity(enableInformationSeverity bool)
 
+	GetEnablePolicies() bool
+	SetEnablePolicies(enablePolicies bool)
+
 	GetCustomRulesPath() string
 	SetCustomRulesPath(customRulesPath string)
 
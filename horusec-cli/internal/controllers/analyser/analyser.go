@@ -59,6 +59,7 @@ import (
 	"github.com/ZupIT/horusec/horusec-cli/internal/services/formatters/ruby/brakeman"
 	"github.com/ZupIT/horusec/horusec-cli/internal/services/formatters/shell/shellcheck"
 	"github.com/ZupIT/horusec/horusec-cli/internal/services/formatters/yaml/horuseckubernetes"
+	"github.com/ZupIT/horusec/horusec-cli/internal/services/git"
 	horusecAPI "github.com/ZupIT/horusec/horusec-cli/internal/services/horusapi"
 	"github.com/google/uuid"
 )
@@ -132,12 +133,12 @@ func (a *Analyser) runAnalysis() (totalVulns int, err error) {
 	a.setMonitor(monitor)
 	a.startDetectVulnerabilities(langs)
 
-	return a.sendAnalysisAndStartPrintResults()
+	return a.sendAnalysisAndStartPrintResults(langs)
 }
 
-func (a *Analyser) sendAnalysisAndStartPrintResults() (int, error) {
+func (a *Analyser) sendAnalysisAndStartPrintResults(langs []languages.Language) (int, error) {
 	a.formatAnalysisToPrintAndSendToAPI()
-	a.horusecAPIService.SendAnalysis(a.analysis)
+	a.horusecAPIService.SendAnalysis(a.analysis, langs)
 	analysisSaved := a.horusecAPIService.GetAnalysis(a.analysis.ID)
 	if analysisSaved != nil && analysisSaved.ID != uuid.Nil {
 		a.analysis = analysisSaved
@@ -153,7 +154,8 @@ func (a *Analyser) formatAnalysisToPrintAndSendToAPI() {
 		SetupIDInAnalysisContents().
 		SortVulnerabilitiesByCriticality().
 		SetDefaultVulnerabilityType().
-		SortVulnerabilitiesByType()
+		SortVulnerabilitiesByType().
+		SetGitInfo(git.NewGitService(a.config).GetBranchAndCommit())
 	if !a.config.GetEnableInformationSeverity() {
 		a.analysis = a.analysis.RemoveInfoVulnerabilities()
 	}
@@ -345,5 +347,22 @@ func (a *Analyser) setFalsePositive() {
 			a.config.GetFalsePositiveHashes(), a.config.GetRiskAcceptHashes())
 
 	a.checkIfNoExistHashAndLog(a.config.GetFalsePositiveHashes())
-	a.checkIfNoExistHashAndLog(a.config.GetRiskAcceptHashes())
+	a.checkIfNoExistHashAndLog(a.getRiskAcceptHashesAndLogExpired())
+}
+
+func (a *Analyser) getRiskAcceptHashesAndLogExpired() (hashes []string) {
+	for _, value := range a.config.GetRiskAcceptHashes() {
+		riskAccept, err := horusec.NewRiskAcceptFromString(value)
+		if err != nil {
+			continue
+		}
+
+		if riskAccept.IsExpired(time.Now()) {
+			logger.LogWarnWithLevel(messages.MsgWarnRiskAcceptExpired + value)
+		}
+
+		hashes = append(hashes, riskAccept.VulnHash)
+	}
+
+	return hashes
 }
@@ -25,5 +25,5 @@ type Mock struct {
 
 func (m *Mock) AnalysisDirectory() (totalVulns int, err error) {
 	args := m.MethodCalled("AnalysisDirectory")
-	return args.Get(0).(int), utilsMock.ReturnNilOrError(args, 0)
+	return args.Get(0).(int), utilsMock.ReturnNilOrError(args, 1)
 }
@@ -35,7 +35,9 @@ import (
 )
 
 var (
-	ErrOutputJSON = errors.New("{HORUSEC_CLI} error creating and/or writing to the specified file")
+	ErrOutputJSON         = errors.New("{HORUSEC_CLI} error creating and/or writing to the specified file")
+	ErrPolicyFailed       = errors.New("{HORUSEC_CLI} analysis failed in the policies of the company")
+	ErrPolicyNotEvaluated = errors.New("{HORUSEC_CLI} analysis without verdict of the policies of the company")
 )
 
 type PrintResults struct {
@@ -75,7 +77,7 @@ func (pr *PrintResults) StartPrintResults() (totalVulns int, err error) {
 		logger.LogWarnWithLevel(messages.MsgErrorTimeoutOccurs)
 	}
 
-	return pr.totalVulns, nil
+	return pr.totalVulns, pr.printPolicyVerdict()
 }
 
 func (pr *PrintResults) factoryPrintByType() error {
@@ -311,6 +313,43 @@ func (pr *PrintResults) printResponseAnalysis() {
 	fmt.Print("\n")
 }
 
+func (pr *PrintResults) printPolicyVerdict() error {
+	switch pr.analysis.PolicyStatus {
+	case horusec.PolicyPassed:
+		logger.LogWarnWithLevel(messages.MsgAnalysisPassedInPolicies)
+		fmt.Print("\n")
+		return nil
+	case horusec.PolicyFailed:
+		pr.printPolicyReasons(messages.MsgWarnAnalysisFailedInPolicies)
+		return ErrPolicyFailed
+	}
+
+	return pr.printPolicyNotEvaluated()
+}
+
+// printPolicyNotEvaluated fails only when the policies are enabled, otherwise an empty verdict is an analysis not sent
+// or a company without policies
+func (pr *PrintResults) printPolicyNotEvaluated() error {
+	if pr.configs.GetEnablePolicies() {
+		pr.printPolicyReasons(messages.MsgWarnAnalysisWithoutPolicyVerdict)
+		return ErrPolicyNotEvaluated
+	}
+
+	if pr.analysis.PolicyStatus == horusec.PolicyNotEvaluated {
+		pr.printPolicyReasons(messages.MsgWarnAnalysisNotEvaluatedInPolicies)
+	}
+
+	return nil
+}
+
+func (pr *PrintResults) printPolicyReasons(message string) {
+	logger.LogWarnWithLevel(message)
+	for _, reason := range pr.analysis.PolicyReasons {
+		logger.LogStringAsError(reason)
+	}
+	fmt.Print("\n")
+}
+
 func (pr *PrintResults) logSeparator(isToShow bool) {
 	if isToShow {
 		fmt.Println(fmt.Sprintf("\n==================================================================================\n"))
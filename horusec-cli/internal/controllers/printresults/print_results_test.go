@@ -69,6 +69,61 @@ func TestPrintResults_StartPrintResults(t *testing.T) {
 		assert.Equal(t, 0, totalVulns)
 	})
 
+	t.Run("Should not return errors when analysis passed in policies", func(t *testing.T) {
+		analysis := (&horusec.Analysis{}).SetPolicyVerdict(nil)
+
+		totalVulns, err := NewPrintResults(analysis, &config.Config{}).StartPrintResults()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, totalVulns)
+	})
+
+	t.Run("Should return error when analysis failed in policies", func(t *testing.T) {
+		analysis := (&horusec.Analysis{}).
+			SetPolicyVerdict([]string{"[gate] the language Go is required and was not scanned"})
+
+		_, err := NewPrintResults(analysis, &config.Config{}).StartPrintResults()
+
+		assert.Equal(t, ErrPolicyFailed, err)
+	})
+
+	t.Run("Should not return errors when analysis was not evaluated and policies are disabled", func(t *testing.T) {
+		analysis := (&horusec.Analysis{}).SetPolicyNotEvaluated("test")
+
+		_, err := NewPrintResults(analysis, &config.Config{}).StartPrintResults()
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return error when analysis was not evaluated and policies are enabled", func(t *testing.T) {
+		configs := &config.Config{}
+		configs.SetEnablePolicies(true)
+		analysis := (&horusec.Analysis{}).SetPolicyNotEvaluated("test")
+
+		_, err := NewPrintResults(analysis, configs).StartPrintResults()
+
+		assert.Equal(t, ErrPolicyNotEvaluated, err)
+	})
+
+	t.Run("Should return error when analysis has no verdict and policies are enabled", func(t *testing.T) {
+		configs := &config.Config{}
+		configs.SetEnablePolicies(true)
+
+		_, err := NewPrintResults(&horusec.Analysis{}, configs).StartPrintResults()
+
+		assert.Equal(t, ErrPolicyNotEvaluated, err)
+	})
+
+	t.Run("Should not return errors when analysis passed in policies and policies are enabled", func(t *testing.T) {
+		configs := &config.Config{}
+		configs.SetEnablePolicies(true)
+		analysis := (&horusec.Analysis{}).SetPolicyVerdict(nil)
+
+		_, err := NewPrintResults(analysis, configs).StartPrintResults()
+
+		assert.NoError(t, err)
+	})
+
 	t.Run("Should return not errors because exists error in analysis", func(t *testing.T) {
 		analysis := &horusec.Analysis{
 			Errors: "Exists an error when read analysis",
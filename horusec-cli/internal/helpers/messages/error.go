@@ -28,6 +28,9 @@ const (
 	MsgErrorFalsePositiveNotValid = "False positive is not valid because is duplicated in risk accept: "
 	// USED IN USE CASES: Fired when an risk accept is not allowed in configs
 	MsgErrorRiskAcceptNotValid = "Risk Accept is not valid because is duplicated in false positive: "
+	// USED IN USE CASES: Fired when an risk accept has an invalid expiration date in configs
+	MsgErrorRiskAcceptDateNotValid = "Risk Accept is not valid because the expiration date must be in the format " +
+		"hash:YYYY-MM-DD: "
 	// Fired when an unexpected error occurs when check if the requirements it's ok
 	MsgErrorWhenCheckRequirements = "{HORUSEC_CLI} Error when check if requirements it's ok!"
 	// Fired when an unexpected error occurs when check if the docker is running
@@ -62,6 +65,8 @@ const (
 	MsgErrorDockerRemoveContainer = "{HORUSEC_CLI} Error when remove container of analysis: "
 	// Fired when an unexpected error occurs when try execute command to extract commit authors of an vulnerability
 	MsgErrorGitCommitAuthorsExecute = "{HORUSEC_CLI} Error when execute commit author command: "
+	// Fired when an unexpected error occurs when try execute command to get the current branch and commit
+	MsgErrorGitRevParseExecute = "{HORUSEC_CLI} Error when execute command to get the branch and commit: "
 	// Fired when an unexpected error occurs when try parse output commit authors to struct CommitAuthors
 	MsgErrorGitCommitAuthorsParseOutput = "{HORUSEC_CLI} Error when to parse output to commit author struct: "
 	// Fired when an unexpected error occurs when read spotbugs output
@@ -94,4 +99,5 @@ const (
 	MsgErrorReplayWrong             = "{HORUSEC-CLI} Error on set reply, Please type Y or N. Your current response was: "
 	MsgErrorErrorOnCreateConfigFile = "{HORUSEC-CLI} Error on create config file: "
 	MsgErrorErrorOnReadConfigFile   = "{HORUSEC-CLI} Error on read config file on path: "
+	MsgErrorOnReadAnalysisFile      = "{HORUSEC-CLI} Error on read analysis file on path: "
 )
@@ -38,6 +38,8 @@ const (
 		"TO SEE MORE DETAILS USE THE LOG LEVEL AS DEBUG AND TRY AGAIN"
 	// Fired in print results service when analysis is finished
 	MsgAnalysisFinishedWithoutVulns = "YOUR ANALYSIS HAD FINISHED WITHOUT ANY VULNERABILITY!"
+	// Fired in print results service when the analysis passed in the policies of the company
+	MsgAnalysisPassedInPolicies = "[HORUSEC] YOUR ANALYSIS PASSED IN ALL POLICIES OF THE COMPANY!"
 	// Occurs when o docker is lower version than recommend
 	MsgDockerLowerVersion = "{HORUSEC_CLI} We recommend version 19.03 or higher of the docker." +
 		" Versions prior to this may have problems during execution"
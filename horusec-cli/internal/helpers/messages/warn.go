@@ -35,6 +35,17 @@ const (
 		" after 16 jan 2021, please use tools config option"
 	MsgWarnHashNotExistOnAnalysis = "{HORUSEC_CLI} Hash not found in the " +
 		"list of vulnerabilities pointed out by Horusec: "
+	MsgWarnRiskAcceptExpired = "{HORUSEC_CLI} Risk accept expired and the vulnerability will be " +
+		"considered again: "
+	// Fired in print results service when the analysis failed in the policies of the company
+	MsgWarnAnalysisFailedInPolicies = "[HORUSEC] YOUR ANALYSIS FAILED IN THE POLICIES OF THE COMPANY " +
+		"AND THE EXIT CODE WILL BE 1, REASONS:"
+	// Fired in print results service when the policies are enabled and the analysis has no verdict of horusec-api
+	MsgWarnAnalysisWithoutPolicyVerdict = "[HORUSEC] YOUR ANALYSIS HAS NO VERDICT OF THE POLICIES OF THE COMPANY " +
+		"AND THE EXIT CODE WILL BE 1, REASONS:"
+	// Fired in print results service when horusec-api could not evaluate the policies of the company
+	MsgWarnAnalysisNotEvaluatedInPolicies = "[HORUSEC] YOUR ANALYSIS WAS NOT EVALUATED IN THE POLICIES OF THE " +
+		"COMPANY, REASONS:"
 	MsgWarnInfoVulnerabilitiesDisabled = "{HORUSEC_CLI} Horusec not show info vulnerabilities in this analysis, " +
 		"to see info vulnerabilities add option \"--information-severity=true\". " +
 		"For more details use (horusec start --help) command."
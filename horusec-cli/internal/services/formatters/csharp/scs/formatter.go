@@ -106,6 +106,7 @@ func (f *Formatter) setVulnerabilitySeverityData(scsResult entities.ScsResult) *
 	data := f.getDefaultVulnerabilitySeverity()
 	data.Severity = scsResult.GetSeverity()
 	data.Details = f.removeCsprojPathFromDetails(scsResult.IssueText)
+	data.RuleID = scsResult.ErrorID
 	data.Line = scsResult.GetLine()
 	data.Column = scsResult.GetColumn()
 	data.File = f.GetFilepathFromFilename(scsResult.GetFilename())
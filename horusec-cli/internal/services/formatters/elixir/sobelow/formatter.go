@@ -116,6 +116,7 @@ func (f *Formatter) setOutputData(output string) *entities.Output {
 func (f *Formatter) setVulnerabilityData(output *entities.Output) *horusec.Vulnerability {
 	vulnerability := f.getDefaultVulnerabilitySeverity()
 	vulnerability.Details = output.Title
+	vulnerability.RuleID = output.Title
 	vulnerability.File = output.File
 	vulnerability.Line = output.Line
 	vulnerability = hash.Bind(vulnerability)
@@ -90,6 +90,7 @@ func (f *Formatter) parseOutput(output string) error {
 func (f *Formatter) setVulnerabilityData(result *entities.Result) *horusec.Vulnerability {
 	data := f.getDefaultVulnerabilityData()
 	data.Details = result.Extra.Message
+	data.RuleID = result.CheckID
 	data.Severity = f.getSeverity(result.Extra.Severity)
 	data.Line = strconv.Itoa(result.Start.Line)
 	data.Column = strconv.Itoa(result.Start.Col)
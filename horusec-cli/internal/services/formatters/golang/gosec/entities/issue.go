@@ -17,6 +17,7 @@ package entities
 import "github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
 
 type Issue struct {
+	RuleID     string            `json:"rule_id"`
 	Severity   severity.Severity `json:"severity"`
 	Confidence string            `json:"confidence"`
 	Details    string            `json:"details"`
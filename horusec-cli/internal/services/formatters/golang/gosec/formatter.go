@@ -95,6 +95,7 @@ func (f *Formatter) setupVulnerabilitiesSeveritiesGoSec(issue *entities.Issue) *
 	vulnerability := f.getDefaultVulnerabilitySeverity()
 	vulnerability.Severity = issue.Severity
 	vulnerability.Details = issue.Details
+	vulnerability.RuleID = issue.RuleID
 	vulnerability.Code = f.getCode(issue.Code, issue.Column)
 	vulnerability.Line = issue.Line
 	vulnerability.Column = issue.Column
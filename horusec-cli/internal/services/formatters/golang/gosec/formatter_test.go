@@ -67,13 +67,15 @@ func TestGoLang_StartAnalysis(t *testing.T) {
 
 		dockerAPIControllerMock.On("CreateLanguageAnalysisContainer").Return(outputAnalysis, nil)
 
-		service := formatters.NewFormatterService(&horusec.Analysis{}, dockerAPIControllerMock, config, &horusec.Monitor{})
+		analysis := &horusec.Analysis{}
+		service := formatters.NewFormatterService(analysis, dockerAPIControllerMock, config, &horusec.Monitor{})
 
 		golangAnalyser := NewFormatter(service)
 
 		assert.NotPanics(t, func() {
 			golangAnalyser.StartAnalysis("")
 		})
+		assert.Equal(t, "G501", analysis.AnalysisVulnerabilities[0].Vulnerability.RuleID)
 	})
 
 	t.Run("Should run analysis and return error and up docker_api and save on cache with error", func(t *testing.T) {
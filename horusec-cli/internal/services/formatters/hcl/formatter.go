@@ -94,6 +94,7 @@ func (f *Formatter) setVulnerabilityData(index int, results []entities.Result) *
 	vulnerability := f.getDefaultVulnerabilitySeverity()
 	vulnerability.Severity = severity.High
 	vulnerability.Details = results[index].GetDetails()
+	vulnerability.RuleID = results[index].RuleID
 	vulnerability.Line = results[index].GetStartLine()
 	vulnerability.Code = f.GetCodeWithMaxCharacters(results[index].GetCode(), 0)
 	vulnerability.File = f.RemoveSrcFolderFromPath(results[index].GetFilename())
@@ -153,6 +153,7 @@ func (f *Formatter) setupVulnerabilitiesSeverities(
 	vulnerabilitySeverity horusec.Vulnerability) {
 	vulnerabilitySeverity.Severity = f.parseSpotbugsRankToSeverity(javaOutput, indexSpotBugsIssue)
 	vulnerabilitySeverity.Details = javaOutput.SpotBugsIssue[indexSpotBugsIssue].Type
+	vulnerabilitySeverity.RuleID = javaOutput.SpotBugsIssue[indexSpotBugsIssue].Type
 	vulnerabilitySeverity.Code = f.getVulnerabilitiesSeveritiesCode(javaOutput, indexSpotBugsIssue, indexSourceLine)
 	vulnerabilitySeverity.Line = f.getVulnerabilitiesSeveritiesLine(javaOutput, indexSpotBugsIssue, indexSourceLine)
 	vulnerabilitySeverity.Column = ""
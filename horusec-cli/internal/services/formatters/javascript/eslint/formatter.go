@@ -115,6 +115,7 @@ func (f *Formatter) parseOutputToVuln(filePath, source string, message *entities
 		Language:     languages.Javascript,
 		SecurityTool: tools.Eslint,
 		Details:      message.Message,
+		RuleID:       message.RuleID,
 		Code:         f.getCode(source, message.Line, message.EndLine, message.Column),
 		Severity:     severity.Low,
 	}
@@ -121,6 +121,7 @@ func (f *Formatter) setVulnerabilitySeverityData(issue *entities.Issue) (data *h
 	data = f.getDefaultVulnerabilitySeverity()
 	data.Severity = issue.GetSeverity()
 	data.Details = issue.Overview
+	data.RuleID = strconv.Itoa(issue.ID)
 	data.Code = issue.ModuleName
 	data.Line = f.getVulnerabilityLineByName(f.getVersionText(issue.GetVersion()), data.Code, data.File)
 	data = hash.Bind(data)
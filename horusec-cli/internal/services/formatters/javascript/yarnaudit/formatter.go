@@ -137,6 +137,7 @@ func (f *Formatter) setVulnerabilitySeverityData(output *entities.Issue) *horuse
 	data := f.getDefaultVulnerabilitySeverity()
 	data.Severity = output.GetSeverity()
 	data.Details = output.Overview
+	data.RuleID = strconv.Itoa(output.ID)
 	data.Code = output.ModuleName
 	data.Line = f.getVulnerabilityLineByName(data.Code, output.GetVersion(), data.File)
 	data = vulnhash.Bind(data)
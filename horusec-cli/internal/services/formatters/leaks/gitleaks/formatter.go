@@ -100,6 +100,7 @@ func (f *Formatter) setupVulnerabilitiesSeveritiesGitLeaks(issue *entities.Issue
 	vulnerabilitySeverity = f.getDefaultSeverity()
 	vulnerabilitySeverity.Severity = severity.High
 	vulnerabilitySeverity.Details = issue.Rule
+	vulnerabilitySeverity.RuleID = issue.Rule
 	vulnerabilitySeverity.Code = f.GetCodeWithMaxCharacters(issue.Line, 0)
 	vulnerabilitySeverity.File = issue.File
 	vulnerabilitySeverity = vulnhash.Bind(vulnerabilitySeverity)
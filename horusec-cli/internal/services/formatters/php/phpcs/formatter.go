@@ -103,6 +103,7 @@ func (f *Formatter) setVulnerabilityData(filepath string, result entities.Messag
 	vulnerability := f.getDefaultVulnerabilitySeverity()
 	vulnerability.Severity = severity.Info
 	vulnerability.Details = result.Message
+	vulnerability.RuleID = result.Source
 	vulnerability.Line = result.GetLine()
 	vulnerability.Column = result.GetColumn()
 	vulnerability.File = f.RemoveSrcFolderFromPath(filepath)
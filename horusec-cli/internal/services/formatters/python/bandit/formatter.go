@@ -113,6 +113,7 @@ func (f *Formatter) setupVulnerabilitiesSeveritiesBandit(
 	vulnerabilitySeverity := f.getDefaultVulnerabilitySeverity()
 	vulnerabilitySeverity.Severity = issues[index].IssueSeverity
 	vulnerabilitySeverity.Details = issues[index].IssueText
+	vulnerabilitySeverity.RuleID = issues[index].TestID
 	vulnerabilitySeverity.Code = f.GetCodeWithMaxCharacters(issues[index].Code, 0)
 	vulnerabilitySeverity.Line = strconv.Itoa(issues[index].LineNumber)
 	vulnerabilitySeverity.Confidence = issues[index].IssueConfidence
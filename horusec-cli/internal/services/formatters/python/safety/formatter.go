@@ -118,6 +118,7 @@ func (f *Formatter) setupVulnerabilitiesSeveritiesSafety(
 
 	vulnerabilitySeverity := f.getDefaultVulnerabilitySeverityInSafety()
 	vulnerabilitySeverity.Details = issues[index].Description
+	vulnerabilitySeverity.RuleID = issues[index].ID
 	vulnerabilitySeverity.Code = f.GetCodeWithMaxCharacters(issues[index].Dependency, 0)
 	vulnerabilitySeverity.Line = f.getVulnerabilityLineByName(lineContent, vulnerabilitySeverity.File)
 	vulnerabilitySeverity = hash.Bind(vulnerabilitySeverity)
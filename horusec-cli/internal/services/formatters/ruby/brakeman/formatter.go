@@ -95,6 +95,7 @@ func (f *Formatter) setVulnerabilityData(output *entities.Warning) *horusec.Vuln
 	data.Severity = output.GetSeverity()
 	data.Confidence = output.GetSeverity().ToString()
 	data.Details = output.GetDetails()
+	data.RuleID = output.Type
 	data.Line = output.GetLine()
 	data.File = output.File
 	data.Code = f.GetCodeWithMaxCharacters(output.Code, 0)
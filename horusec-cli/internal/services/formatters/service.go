@@ -242,6 +242,7 @@ func (s *Service) setVulnerabilityDataByFindingIndex(findings []engine.Finding,
 		File:         s.removeHorusecFolder(findings[index].SourceLocation.Filename),
 		Code:         s.GetCodeWithMaxCharacters(findings[index].CodeSample, findings[index].SourceLocation.Column),
 		Details:      findings[index].Name + "\n" + findings[index].Description,
+		RuleID:       findings[index].ID,
 		SecurityTool: tool,
 		Language:     language,
 		Severity:     severity.ParseStringToSeverity(findings[index].Severity),
@@ -21,3 +21,7 @@ func (o *Output) GetLine() string {
 func (o *Output) GetColumn() string {
 	return strconv.Itoa(o.Column)
 }
+
+func (o *Output) GetRuleID() string {
+	return "SC" + strconv.Itoa(o.Code)
+}
@@ -97,6 +97,7 @@ func (f *Formatter) setVulnerabilityData(output *entities.Output) *horusec.Vulne
 	data.Severity = f.parseLevelToSeverity(output.Level)
 	data.Confidence = confidence.Low.ToString()
 	data.Details = output.Message
+	data.RuleID = output.GetRuleID()
 	data.Column = output.GetColumn()
 	data.Line = output.GetLine()
 	data.File = strings.ReplaceAll(output.File, "./", "")
@@ -30,6 +30,7 @@ import (
 
 type IService interface {
 	GetCommitAuthor(line, filePath string) (commitAuthor horusec.CommitAuthor)
+	GetBranchAndCommit() (branch, commit string)
 }
 
 type Service struct {
@@ -53,6 +54,47 @@ func (s *Service) GetCommitAuthor(line, filePath string) (commitAuthor horusec.C
 	return s.getCommitAuthorNotFound()
 }
 
+// GetBranchAndCommit returns the configured values or the current ones of the repository in project path,
+// a detached head has no branch
+func (s *Service) GetBranchAndCommit() (branch, commit string) {
+	branch, commit = s.config.GetBranchName(), s.config.GetCommitHash()
+	if !s.existsGitFolderInPath() {
+		return branch, commit
+	}
+
+	if branch == "" {
+		branch = s.getCurrentBranch()
+	}
+
+	if commit == "" {
+		commit = s.executeRevParse("HEAD")
+	}
+
+	return branch, commit
+}
+
+// getCurrentBranch returns empty on a detached head, when git answers the abbreviated name as HEAD
+func (s *Service) getCurrentBranch() string {
+	branch := s.executeRevParse("--abbrev-ref", "HEAD")
+	if branch == "HEAD" {
+		return ""
+	}
+
+	return branch
+}
+
+func (s *Service) executeRevParse(args ...string) string {
+	cmd := exec.Command("git", append([]string{"rev-parse"}, args...)...)
+	cmd.Dir = s.config.GetProjectPath()
+	response, err := cmd.Output()
+	if err != nil {
+		logger.LogErrorWithLevel(messages.MsgErrorGitRevParseExecute, err, map[string]interface{}{"args": args})
+		return ""
+	}
+
+	return strings.TrimSpace(string(response))
+}
+
 func (s *Service) executeGitBlame(line, filePath string) (commitAuthor horusec.CommitAuthor) {
 	if line == "" || filePath == "" {
 		return s.getCommitAuthorNotFound()
@@ -15,6 +15,7 @@
 package git
 
 import (
+	"os/exec"
 	"testing"
 
 	"github.com/ZupIT/horusec/horusec-cli/config"
@@ -67,3 +68,71 @@ func TestGetCommitAuthor(t *testing.T) {
 		assert.NotEmpty(t, NewGitService(&config.Config{}))
 	})
 }
+
+func TestGetBranchAndCommit(t *testing.T) {
+	t.Run("Should return the configured branch and commit", func(t *testing.T) {
+		c := &config.Config{}
+		c.SetProjectPath("./some_path")
+		c.SetBranchName("main")
+		c.SetCommitHash("a1b2c3")
+
+		branch, commit := NewGitService(c).GetBranchAndCommit()
+
+		assert.Equal(t, "main", branch)
+		assert.Equal(t, "a1b2c3", commit)
+	})
+
+	t.Run("Should return the current commit of the repository", func(t *testing.T) {
+		c := &config.Config{}
+		c.SetProjectPath("../../../../")
+
+		_, commit := NewGitService(c).GetBranchAndCommit()
+
+		assert.Len(t, commit, 40)
+	})
+
+	t.Run("Should return the branch when its name starts with HEAD", func(t *testing.T) {
+		c := &config.Config{}
+		c.SetProjectPath(newGitRepository(t, "HEADER-fix"))
+
+		branch, commit := NewGitService(c).GetBranchAndCommit()
+
+		assert.Equal(t, "HEADER-fix", branch)
+		assert.Len(t, commit, 40)
+	})
+
+	t.Run("Should return empty branch on a detached head", func(t *testing.T) {
+		c := &config.Config{}
+		c.SetProjectPath(newGitRepository(t, "main", "checkout", "--detach"))
+
+		branch, commit := NewGitService(c).GetBranchAndCommit()
+
+		assert.Empty(t, branch)
+		assert.Len(t, commit, 40)
+	})
+
+	t.Run("Should return empty when git command fails", func(t *testing.T) {
+		c := &config.Config{}
+		c.SetProjectPath("./some_path")
+
+		assert.Empty(t, (&Service{config: c}).executeRevParse("HEAD"))
+	})
+}
+
+func newGitRepository(t *testing.T, branch string, lastCommand ...string) string {
+	path := t.TempDir()
+	commands := [][]string{{"init", "-q"}, {"checkout", "-q", "-b", branch},
+		{"-c", "user.name=test", "-c", "user.email=test@horusec.io", "commit", "-q", "--allow-empty", "-m", "test"}}
+	if len(lastCommand) > 0 {
+		commands = append(commands, lastCommand)
+	}
+
+	for _, args := range commands {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = path
+		output, err := cmd.CombinedOutput()
+		assert.NoError(t, err, string(output))
+	}
+
+	return path
+}
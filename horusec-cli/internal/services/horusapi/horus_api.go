@@ -16,23 +16,29 @@ package horusapi
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/languages"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/tools"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/http-request/client"
 	httpResponse "github.com/ZupIT/horusec/development-kit/pkg/utils/http-request/response"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	cliConfig "github.com/ZupIT/horusec/horusec-cli/config"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type IService interface {
-	SendAnalysis(analysis *horusec.Analysis)
+	SendAnalysis(analysis *horusec.Analysis, langs []languages.Language)
 	GetAnalysis(analysisID uuid.UUID) *horusec.Analysis
 }
 
@@ -48,19 +54,23 @@ func NewHorusecAPIService(config cliConfig.IConfig) IService {
 	}
 }
 
-func (s *Service) SendAnalysis(analysis *horusec.Analysis) {
+func (s *Service) SendAnalysis(analysis *horusec.Analysis, langs []languages.Language) {
 	if s.config.IsEmptyRepositoryAuthorization() || s.config.GetIsTimeout() {
 		return
 	}
 
-	response, err := s.sendCreateAnalysisRequest(analysis)
+	ctx, span := tracing.StartSpan(context.Background(), "SendAnalysis", trace.WithSpanKind(trace.SpanKindClient))
+	response, err := s.sendCreateAnalysisRequest(ctx, analysis, langs)
 	if err != nil {
+		tracing.EndSpan(span, err)
 		s.loggerSendError(err)
 		return
 	}
 	defer response.CloseBody()
 
-	s.loggerSendError(s.verifyResponseCreateAnalysis(response))
+	err = s.verifyResponseCreateAnalysis(response)
+	tracing.EndSpan(span, err)
+	s.loggerSendError(err)
 }
 
 func (s *Service) GetAnalysis(analysisID uuid.UUID) *horusec.Analysis {
@@ -94,8 +104,11 @@ func (s *Service) sendFindAnalysisRequest(analysisID uuid.UUID) (httpResponse.In
 	return s.httpUtil.DoRequest(req, tlsConfig)
 }
 
-func (s *Service) sendCreateAnalysisRequest(analysis *horusec.Analysis) (httpResponse.Interface, error) {
-	req, err := http.NewRequest(http.MethodPost, s.getHorusecAPIURL(), bytes.NewReader(s.newRequestData(analysis)))
+// sendCreateAnalysisRequest sends the traceparent header when the tracing is enabled, horusec-api continues the trace
+func (s *Service) sendCreateAnalysisRequest(ctx context.Context, analysis *horusec.Analysis,
+	langs []languages.Language) (httpResponse.Interface, error) {
+	req, err := http.NewRequest(http.MethodPost, s.getHorusecAPIURL(),
+		bytes.NewReader(s.newRequestData(analysis, langs)))
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +119,7 @@ func (s *Service) sendCreateAnalysisRequest(analysis *horusec.Analysis) (httpRes
 	}
 
 	s.addHeaders(req)
+	tracing.InjectHTTPHeaders(ctx, req.Header)
 	return s.httpUtil.DoRequest(req, tlsConfig)
 }
 
@@ -163,15 +177,45 @@ func (s *Service) setTLSConfig() (*tls.Config, error) {
 	return tlsConfig, nil
 }
 
-func (s *Service) newRequestData(analysis *horusec.Analysis) []byte {
+func (s *Service) newRequestData(analysis *horusec.Analysis, langs []languages.Language) []byte {
 	analysisData := &api.AnalysisData{
 		Analysis:       analysis,
 		RepositoryName: s.config.GetRepositoryName(),
+		DisabledTools:  s.getDisabledTools(),
+		Languages:      s.getLanguages(langs),
 	}
 
 	return analysisData.ToBytes()
 }
 
+func (s *Service) getDisabledTools() (disabledTools []string) {
+	for _, tool := range tools.GoSec.Values() {
+		if s.isToolDisabled(tool) {
+			disabledTools = append(disabledTools, tool.ToString())
+		}
+	}
+
+	return disabledTools
+}
+
+func (s *Service) isToolDisabled(tool tools.Tool) bool {
+	for _, toolToIgnore := range s.config.GetToolsToIgnore() {
+		if strings.EqualFold(toolToIgnore, tool.ToString()) {
+			return true
+		}
+	}
+
+	return s.config.GetToolsConfig()[tool].IsToIgnore
+}
+
+func (s *Service) getLanguages(langs []languages.Language) (output []string) {
+	for _, language := range langs {
+		output = append(output, language.ToString())
+	}
+
+	return output
+}
+
 func (s *Service) addHeaders(req *http.Request) {
 	req.Header.Add("X-Horusec-Authorization", s.config.GetRepositoryAuthorization())
 	for key, value := range s.config.GetHeaders() {
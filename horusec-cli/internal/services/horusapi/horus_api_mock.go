@@ -16,6 +16,7 @@ package horusapi
 
 import (
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/languages"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
@@ -24,7 +25,7 @@ type Mock struct {
 	mock.Mock
 }
 
-func (m *Mock) SendAnalysis(analysis *horusec.Analysis) {
+func (m *Mock) SendAnalysis(analysis *horusec.Analysis, langs []languages.Language) {
 	m.MethodCalled("SendAnalysis")
 }
 
@@ -16,6 +16,7 @@ package horusapi
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	http2 "github.com/ZupIT/horusec/development-kit/pkg/entities/http"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/test"
@@ -25,8 +26,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/api"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
 	enumHorusec "github.com/ZupIT/horusec/development-kit/pkg/enums/horusec"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/languages"
+	"github.com/ZupIT/horusec/development-kit/pkg/enums/tools"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/http-request/client"
 	httpResponse "github.com/ZupIT/horusec/development-kit/pkg/utils/http-request/response"
 	cliConfig "github.com/ZupIT/horusec/horusec-cli/config"
@@ -54,7 +58,7 @@ func TestSendAnalysis(t *testing.T) {
 		}
 
 		assert.NotPanics(t, func() {
-			service.SendAnalysis(analysis)
+			service.SendAnalysis(analysis, []languages.Language{languages.Go})
 		})
 	})
 
@@ -83,7 +87,7 @@ func TestSendAnalysis(t *testing.T) {
 		}
 
 		assert.NotPanics(t, func() {
-			service.SendAnalysis(analysis)
+			service.SendAnalysis(analysis, nil)
 		})
 	})
 
@@ -106,7 +110,7 @@ func TestSendAnalysis(t *testing.T) {
 		}
 
 		assert.NotPanics(t, func() {
-			service.SendAnalysis(analysis)
+			service.SendAnalysis(analysis, nil)
 		})
 	})
 
@@ -122,7 +126,7 @@ func TestSendAnalysis(t *testing.T) {
 		}
 
 		assert.NotPanics(t, func() {
-			service.SendAnalysis(analysis)
+			service.SendAnalysis(analysis, nil)
 		})
 	})
 	t.Run("should get analysis with error when set tls in request", func(t *testing.T) {
@@ -141,7 +145,7 @@ func TestSendAnalysis(t *testing.T) {
 		}
 
 		assert.NotPanics(t, func() {
-			service.SendAnalysis(analysis)
+			service.SendAnalysis(analysis, nil)
 		})
 	})
 	t.Run("should get analysis with error when set tls in request", func(t *testing.T) {
@@ -160,7 +164,7 @@ func TestSendAnalysis(t *testing.T) {
 		}
 
 		assert.NotPanics(t, func() {
-			service.SendAnalysis(analysis)
+			service.SendAnalysis(analysis, nil)
 		})
 	})
 	t.Run("should return a new service", func(t *testing.T) {
@@ -254,3 +258,19 @@ func TestService_GetAnalysis(t *testing.T) {
 		assert.Empty(t, analysisResponse)
 	})
 }
+
+func TestNewRequestData(t *testing.T) {
+	t.Run("should send disabled tools and scanned languages to policies evaluation", func(t *testing.T) {
+		config := &cliConfig.Config{}
+		config.SetToolsToIgnore([]string{"gosec"})
+		config.SetToolsConfig(map[string]interface{}{"bandit": map[string]interface{}{"istoignore": true}})
+		service := Service{config: config}
+
+		analysisData := &api.AnalysisData{}
+		assert.NoError(t, json.Unmarshal(service.newRequestData(&horusec.Analysis{},
+			[]languages.Language{languages.Go, languages.Python}), analysisData))
+
+		assert.ElementsMatch(t, []string{tools.GoSec.ToString(), tools.Bandit.ToString()}, analysisData.DisabledTools)
+		assert.Equal(t, []string{languages.Go.ToString(), languages.Python.ToString()}, analysisData.Languages)
+	})
+}
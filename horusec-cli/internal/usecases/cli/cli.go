@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
 	"github.com/ZupIT/horusec/development-kit/pkg/enums/severity"
 	cliConfig "github.com/ZupIT/horusec/horusec-cli/config"
 	"github.com/ZupIT/horusec/horusec-cli/internal/entities/workdir"
@@ -108,7 +109,7 @@ func (au *UseCases) checkIfExistsDuplicatedFalsePositiveHashes(config cliConfig.
 	return func(value interface{}) error {
 		for _, falsePositive := range config.GetFalsePositiveHashes() {
 			for _, riskAccept := range config.GetRiskAcceptHashes() {
-				if falsePositive == riskAccept {
+				if falsePositive == au.getRiskAcceptHash(riskAccept) {
 					return errors.New(messages.MsgErrorFalsePositiveNotValid + falsePositive)
 				}
 			}
@@ -120,8 +121,12 @@ func (au *UseCases) checkIfExistsDuplicatedFalsePositiveHashes(config cliConfig.
 func (au *UseCases) checkIfExistsDuplicatedRiskAcceptHashes(config cliConfig.IConfig) func(value interface{}) error {
 	return func(value interface{}) error {
 		for _, riskAccept := range config.GetRiskAcceptHashes() {
+			if _, err := horusec.NewRiskAcceptFromString(riskAccept); err != nil {
+				return errors.New(messages.MsgErrorRiskAcceptDateNotValid + riskAccept)
+			}
+
 			for _, falsePositive := range config.GetFalsePositiveHashes() {
-				if riskAccept == falsePositive {
+				if au.getRiskAcceptHash(riskAccept) == falsePositive {
 					return errors.New(messages.MsgErrorRiskAcceptNotValid + riskAccept)
 				}
 			}
@@ -130,6 +135,15 @@ func (au *UseCases) checkIfExistsDuplicatedRiskAcceptHashes(config cliConfig.ICo
 	}
 }
 
+func (au *UseCases) getRiskAcceptHash(value string) string {
+	riskAccept, err := horusec.NewRiskAcceptFromString(value)
+	if err != nil {
+		return value
+	}
+
+	return riskAccept.VulnHash
+}
+
 func (au *UseCases) checkAndValidateJSONOutputFilePath(config cliConfig.IConfig) func(value interface{}) error {
 	return func(value interface{}) error {
 		if config.GetPrintOutputType() == outputtype.JSON.ToString() ||
@@ -163,4 +163,28 @@ func TestValidateConfigs(t *testing.T) {
 		err := useCases.ValidateConfigs(config)
 		assert.NoError(t, err)
 	})
+	t.Run("Should return not error when risk accepted has expiration date", func(t *testing.T) {
+		config := cliConfig.NewConfig()
+		config.SetRiskAcceptHashes([]string{"c0d0c85c-8597-49c4-b4fa-b92ecad2a991:2021-12-31"})
+
+		err := useCases.ValidateConfigs(config)
+		assert.NoError(t, err)
+	})
+	t.Run("Should return error when risk accepted has invalid expiration date", func(t *testing.T) {
+		config := cliConfig.NewConfig()
+		config.SetRiskAcceptHashes([]string{"c0d0c85c-8597-49c4-b4fa-b92ecad2a991:31/12/2021"})
+
+		err := useCases.ValidateConfigs(config)
+		assert.Equal(t, "riskAcceptHashes: Risk Accept is not valid because the expiration date must be in the format "+
+			"hash:YYYY-MM-DD: c0d0c85c-8597-49c4-b4fa-b92ecad2a991:31/12/2021.", err.Error())
+	})
+	t.Run("Should return error when is duplicated false positive and risk accepted with expiration date", func(t *testing.T) {
+		hash := "1e836029-4e90-4151-bb4a-d86ef47f96b6"
+		config := cliConfig.NewConfig()
+		config.SetFalsePositiveHashes([]string{hash})
+		config.SetRiskAcceptHashes([]string{hash + ":2021-12-31"})
+
+		err := useCases.ValidateConfigs(config)
+		assert.Error(t, err)
+	})
 }
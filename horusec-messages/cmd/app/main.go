@@ -20,10 +20,12 @@ import (
 
 	"github.com/ZupIT/horusec/horusec-messages/config/swagger"
 
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
 	serverUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http/server"
 	brokerConfig "github.com/ZupIT/horusec/horusec-messages/config/broker"
 	corsConfig "github.com/ZupIT/horusec/horusec-messages/config/cors"
 	mailerConfig "github.com/ZupIT/horusec/horusec-messages/config/mailer"
+	notifierConfig "github.com/ZupIT/horusec/horusec-messages/config/notifier"
 	"github.com/ZupIT/horusec/horusec-messages/internal/router"
 )
 
@@ -35,8 +37,11 @@ import (
 // @contact.url https://github.com/ZupIT/horusec
 // @contact.email horusec@zup.com.br
 func main() {
+	defer tracing.SetUpTracing("horusec-messages")()
+
 	mailer := mailerConfig.SetUp()
-	broker := brokerConfig.SetUp(mailer)
+	notifier := notifierConfig.SetUp()
+	broker := brokerConfig.SetUp(mailer, notifier)
 
 	server := serverUtil.NewServerConfig("8004", corsConfig.NewCorsConfig()).Timeout(10)
 	chiRouter := router.NewRouter(server).GetRouter(mailer, broker)
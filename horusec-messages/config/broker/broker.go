@@ -20,22 +20,26 @@ import (
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
 	"github.com/ZupIT/horusec/development-kit/pkg/services/broker/config"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-messages/internal/events/chat"
 	"github.com/ZupIT/horusec/horusec-messages/internal/events/email"
 	mailerLib "github.com/ZupIT/horusec/horusec-messages/internal/pkg/mailer"
+	notifierLib "github.com/ZupIT/horusec/horusec-messages/internal/pkg/notifier"
 )
 
-func SetUp(mailer mailerLib.IMailer) brokerLib.IBroker {
+func SetUp(mailer mailerLib.IMailer, notifier notifierLib.INotifier) brokerLib.IBroker {
 	broker, err := brokerLib.NewBroker(config.NewBrokerConfig())
 	if err != nil {
 		logger.LogPanic(errors.FailedConnectBroker, err)
 	}
 
-	setUpConsumers(broker, mailer)
+	setUpConsumers(broker, mailer, notifier)
 	return broker
 }
 
-func setUpConsumers(broker brokerLib.IBroker, mailer mailerLib.IMailer) {
-	consumer := email.NewConsumer(mailer)
+func setUpConsumers(broker brokerLib.IBroker, mailer mailerLib.IMailer, notifier notifierLib.INotifier) {
+	emailConsumer := email.NewConsumer(mailer)
+	chatConsumer := chat.NewConsumer(notifier)
 
-	go broker.Consume(queues.HorusecEmail.ToString(), "", "", consumer.SendEmail)
+	go broker.Consume(queues.HorusecEmail.ToString(), "", "", emailConsumer.SendEmail)
+	go broker.Consume(queues.HorusecChat.ToString(), "", "", chatConsumer.SendChatMessage)
 }
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/ZupIT/horusec/horusec-messages/internal/pkg/mailer"
+	"github.com/ZupIT/horusec/horusec-messages/internal/pkg/notifier"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,7 +29,7 @@ func TestSetUp(t *testing.T) {
 		_ = os.Setenv("HORUSEC_BROKER_PASSWORD", "other_password")
 		mailerMock := &mailer.Mock{}
 		assert.Panics(t, func() {
-			SetUp(mailerMock)
+			SetUp(mailerMock, &notifier.Mock{})
 		})
 	})
 
@@ -37,7 +38,7 @@ func TestSetUp(t *testing.T) {
 		_ = os.Setenv("HORUSEC_BROKER_PASSWORD", "guest")
 		mailerMock := &mailer.Mock{}
 		assert.NotPanics(t, func() {
-			SetUp(mailerMock)
+			SetUp(mailerMock, &notifier.Mock{})
 		})
 	})
 }
@@ -0,0 +1,38 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chat
+
+import (
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	notifierLib "github.com/ZupIT/horusec/horusec-messages/internal/pkg/notifier"
+)
+
+type Interface interface {
+	SendChatMessage(*messages.ChatMessage) error
+}
+
+type Controller struct {
+	notifier notifierLib.INotifier
+}
+
+func NewController(notifier notifierLib.INotifier) Interface {
+	return &Controller{
+		notifier: notifier,
+	}
+}
+
+func (c *Controller) SendChatMessage(chatMessage *messages.ChatMessage) error {
+	return c.notifier.Send(chatMessage)
+}
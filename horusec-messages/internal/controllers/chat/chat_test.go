@@ -0,0 +1,50 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chat
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	"github.com/ZupIT/horusec/horusec-messages/internal/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewController(t *testing.T) {
+	t.Run("should successful creates a new controller", func(t *testing.T) {
+		assert.NotEmpty(t, NewController(&notifier.Mock{}))
+	})
+}
+
+func TestSendChatMessage(t *testing.T) {
+	t.Run("should call notifier send without errors", func(t *testing.T) {
+		notifierMock := &notifier.Mock{}
+		notifierMock.On("Send").Return(nil)
+		controller := NewController(notifierMock)
+
+		err := controller.SendChatMessage(&messages.ChatMessage{Channel: notificationEnum.Slack})
+		assert.NoError(t, err)
+		notifierMock.AssertCalled(t, "Send")
+	})
+	t.Run("should return error when notifier fails", func(t *testing.T) {
+		notifierMock := &notifier.Mock{}
+		notifierMock.On("Send").Return(errors.New("test"))
+		controller := NewController(notifierMock)
+
+		assert.Error(t, controller.SendChatMessage(&messages.ChatMessage{Channel: notificationEnum.Teams}))
+	})
+}
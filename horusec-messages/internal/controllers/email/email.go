@@ -20,6 +20,7 @@ import (
 
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
 	messagesEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/messages"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/metrics"
 	emailTemplates "github.com/ZupIT/horusec/horusec-messages/internal/controllers/email/templates"
 	mailerLib "github.com/ZupIT/horusec/horusec-messages/internal/pkg/mailer"
 	"gopkg.in/gomail.v2"
@@ -38,6 +39,10 @@ func NewController(mailer mailerLib.IMailer) Interface {
 	tpl := template.Must(template.New(messagesEnum.EmailConfirmation).Parse(emailTemplates.EmailConfirmationTpl))
 	tpl = template.Must(tpl.New(messagesEnum.ResetPassword).Parse(emailTemplates.ResetPasswordTpl))
 	tpl = template.Must(tpl.New(messagesEnum.OrganizationInvite).Parse(emailTemplates.OrganizationInviteTpl))
+	tpl = template.Must(tpl.New(messagesEnum.RiskAcceptExpiring).Parse(emailTemplates.RiskAcceptExpiringTpl))
+	tpl = template.Must(tpl.New(messagesEnum.RiskAcceptExpired).Parse(emailTemplates.RiskAcceptExpiredTpl))
+	tpl = template.Must(tpl.New(messagesEnum.TokenExpiring).Parse(emailTemplates.TokenExpiringTpl))
+	tpl = template.Must(tpl.New(messagesEnum.AccountLocked).Parse(emailTemplates.AccountLockedTpl))
 
 	return &Controller{
 		mailer: mailer,
@@ -59,7 +64,9 @@ func (c *Controller) SendEmail(emailMessage *messages.EmailMessage) error {
 	msg.SetHeader("To", emailMessage.To)
 	msg.SetBody("text/html", body.String())
 
-	return c.mailer.SendEmail(msg)
+	err = c.mailer.SendEmail(msg)
+	metrics.ObserveEmailSent(emailMessage.TemplateName, err)
+	return err
 }
 
 func (c *Controller) createMessage() *gomail.Message {
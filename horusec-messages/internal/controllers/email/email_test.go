@@ -73,4 +73,40 @@ func TestSendEmail(t *testing.T) {
 
 		mailerMock.AssertNumberOfCalls(t, "SendEmail", 3)
 	})
+
+	t.Run("should call mailer sendEmail with risk accept templates", func(t *testing.T) {
+		mailerMock := &mailer.Mock{}
+		mailerMock.On("SendEmail").Return(nil)
+		mailerMock.On("GetFromHeader").Return("")
+		controller := NewController(mailerMock)
+
+		data := map[string]interface{}{
+			"username":       "test",
+			"repositoryName": "test",
+			"vulnerabilities": []map[string]interface{}{
+				{"vulnHash": "test", "file": "main.go", "line": "1", "severity": "HIGH",
+					"riskAcceptedUntil": "2021-03-01T00:00:00Z"},
+			},
+		}
+
+		assert.NoError(t, controller.SendEmail(&messages.EmailMessage{To: "test@horusec.com.br",
+			TemplateName: "risk-accept-expiring", Data: data}))
+		assert.NoError(t, controller.SendEmail(&messages.EmailMessage{To: "test@horusec.com.br",
+			TemplateName: "risk-accept-expired", Data: data}))
+		mailerMock.AssertNumberOfCalls(t, "SendEmail", 2)
+	})
+
+	t.Run("should call mailer sendEmail with token expiring template", func(t *testing.T) {
+		mailerMock := &mailer.Mock{}
+		mailerMock.On("SendEmail").Return(nil)
+		mailerMock.On("GetFromHeader").Return("")
+		controller := NewController(mailerMock)
+
+		data := map[string]interface{}{"username": "test", "tokenDescription": "test", "suffixValue": "abcde",
+			"expiresAt": "2021-03-01 00:00"}
+
+		assert.NoError(t, controller.SendEmail(&messages.EmailMessage{To: "test@horusec.com.br",
+			TemplateName: "token-expiring", Data: data}))
+		mailerMock.AssertCalled(t, "SendEmail")
+	})
 }
@@ -0,0 +1,56 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chat
+
+import (
+	"encoding/json"
+
+	messagesEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	enumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	brokerPacket "github.com/ZupIT/horusec/development-kit/pkg/services/broker/packet"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
+	"github.com/ZupIT/horusec/horusec-messages/internal/controllers/chat"
+	notifierLib "github.com/ZupIT/horusec/horusec-messages/internal/pkg/notifier"
+)
+
+type Consumer struct {
+	controller chat.Interface
+}
+
+func NewConsumer(notifier notifierLib.INotifier) *Consumer {
+	return &Consumer{controller: chat.NewController(notifier)}
+}
+
+func (c *Consumer) SendChatMessage(packet brokerPacket.IPacket) {
+	var chatMessage *messagesEntities.ChatMessage
+
+	if err := json.Unmarshal(packet.GetBody(), &chatMessage); err != nil {
+		logger.LogError(enumErrors.ErrParsePacketToChatMessage, err)
+		_ = packet.Ack()
+		return
+	}
+
+	_, span := tracing.StartSpan(packet.GetContext(), "send chat message")
+	err := c.controller.SendChatMessage(chatMessage)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		logger.LogError(enumErrors.ErrSendingChatMessage, err)
+	} else {
+		logger.LogInfo("Chat message sent with success")
+	}
+
+	_ = packet.Ack()
+}
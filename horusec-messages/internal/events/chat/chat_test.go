@@ -0,0 +1,72 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/streadway/amqp"
+
+	messagesEntity "github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/broker/packet"
+	"github.com/ZupIT/horusec/horusec-messages/internal/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConsumer(t *testing.T) {
+	t.Run("should successful create a new consumer", func(t *testing.T) {
+		assert.NotEmpty(t, NewConsumer(&notifier.Mock{}))
+	})
+}
+
+func TestSendChatMessage(t *testing.T) {
+	t.Run("should call controller send chat message", func(t *testing.T) {
+		notifierMock := &notifier.Mock{}
+		notifierMock.On("Send").Return(nil)
+
+		chatMessage := messagesEntity.ChatMessage{Channel: notificationEnum.Slack, URL: "http://example.com"}
+		brokerPacket := packet.NewPacket(&amqp.Delivery{Body: chatMessage.ToBytes()})
+
+		NewConsumer(notifierMock).SendChatMessage(brokerPacket)
+
+		notifierMock.AssertCalled(t, "Send")
+	})
+	t.Run("should controller return error when send chat message", func(t *testing.T) {
+		notifierMock := &notifier.Mock{}
+		notifierMock.On("Send").Return(errors.New("unexpected error"))
+
+		chatMessage := messagesEntity.ChatMessage{Channel: notificationEnum.Teams, URL: "http://example.com"}
+		brokerPacket := packet.NewPacket(&amqp.Delivery{Body: chatMessage.ToBytes()})
+
+		NewConsumer(notifierMock).SendChatMessage(brokerPacket)
+
+		notifierMock.AssertCalled(t, "Send")
+	})
+	t.Run("should not call controller send chat message if unmarshal fails", func(t *testing.T) {
+		notifierMock := &notifier.Mock{}
+		notifierMock.On("Send").Return(nil)
+
+		invalidData := struct{ Channel bool }{Channel: true}
+		bytes, _ := json.Marshal(&invalidData)
+		brokerPacket := packet.NewPacket(&amqp.Delivery{Body: bytes})
+
+		NewConsumer(notifierMock).SendChatMessage(brokerPacket)
+
+		notifierMock.AssertNotCalled(t, "Send")
+	})
+}
@@ -20,6 +20,7 @@ import (
 	messagesEntities "github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
 	enumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
 	brokerPacket "github.com/ZupIT/horusec/development-kit/pkg/services/broker/packet"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/logger"
 	"github.com/ZupIT/horusec/horusec-messages/internal/controllers/email"
 	mailerLib "github.com/ZupIT/horusec/horusec-messages/internal/pkg/mailer"
@@ -42,7 +43,10 @@ func (c *Consumer) SendEmail(packet brokerPacket.IPacket) {
 		return
 	}
 
-	if err := c.controller.SendEmail(emailData); err != nil {
+	_, span := tracing.StartSpan(packet.GetContext(), "send email "+emailData.TemplateName)
+	err := c.controller.SendEmail(emailData)
+	tracing.EndSpan(span, err)
+	if err != nil {
 		logger.LogError(enumErrors.ErrSendingEmail, err)
 	} else {
 		logger.LogInfo("E-mail sent with success")
@@ -0,0 +1,44 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+)
+
+// Chat sends the message as plain json to any endpoint, it's used by chats without a dedicated format
+type Chat struct {
+	sender *sender
+}
+
+type chatPayload struct {
+	Title  string               `json:"title"`
+	Text   string               `json:"text"`
+	Link   string               `json:"link,omitempty"`
+	Fields []messages.ChatField `json:"fields,omitempty"`
+}
+
+func NewChat(sender *sender) IChannel {
+	return &Chat{sender: sender}
+}
+
+func (c *Chat) Send(message *messages.ChatMessage) error {
+	return c.sender.post(message.URL, &chatPayload{
+		Title:  message.Title,
+		Text:   message.Text,
+		Link:   message.Link,
+		Fields: message.Fields,
+	})
+}
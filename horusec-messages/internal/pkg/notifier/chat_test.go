@@ -0,0 +1,47 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChat_Send(t *testing.T) {
+	t.Run("should send message as plain json", func(t *testing.T) {
+		body := map[string]interface{}{}
+		server := newTestServer(http.StatusOK, &body)
+		defer server.Close()
+
+		chat := NewChat(newSender(10))
+		err := chat.Send(&messages.ChatMessage{URL: server.URL, Title: "title", Text: "text",
+			Fields: []messages.ChatField{{Name: "HIGH", Value: "1"}}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "title", body["title"])
+		assert.Equal(t, "text", body["text"])
+		assert.Len(t, body["fields"], 1)
+	})
+	t.Run("should return error when server is unavailable", func(t *testing.T) {
+		server := newTestServer(http.StatusInternalServerError, nil)
+		defer server.Close()
+
+		chat := NewChat(newSender(10))
+		assert.Error(t, chat.Send(&messages.ChatMessage{URL: server.URL, Title: "title"}))
+	})
+}
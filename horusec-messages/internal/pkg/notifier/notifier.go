@@ -0,0 +1,85 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"net/http"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/http-request/client"
+	"github.com/ZupIT/horusec/development-kit/pkg/utils/http-request/request"
+)
+
+type INotifier interface {
+	Send(message *messages.ChatMessage) error
+}
+
+type IChannel interface {
+	Send(message *messages.ChatMessage) error
+}
+
+type Notifier struct {
+	channels map[notificationEnum.Channel]IChannel
+}
+
+func NewNotifier(timeout int) INotifier {
+	sender := newSender(timeout)
+
+	return &Notifier{
+		channels: map[notificationEnum.Channel]IChannel{
+			notificationEnum.Slack: NewSlack(sender),
+			notificationEnum.Teams: NewTeams(sender),
+			notificationEnum.Chat:  NewChat(sender),
+		},
+	}
+}
+
+func (n *Notifier) Send(message *messages.ChatMessage) error {
+	channel, ok := n.channels[message.Channel]
+	if !ok {
+		return errorsEnum.ErrorInvalidNotificationChannel
+	}
+
+	return channel.Send(message)
+}
+
+type sender struct {
+	httpRequest request.Interface
+	httpClient  client.Interface
+}
+
+func newSender(timeout int) *sender {
+	return &sender{
+		httpRequest: request.NewHTTPRequest(),
+		httpClient:  client.NewHTTPClient(timeout),
+	}
+}
+
+func (s *sender) post(url string, payload interface{}) error {
+	req, err := s.httpRequest.Request(http.MethodPost, url, payload, map[string]string{"Content-Type": "application/json"})
+	if err != nil {
+		return err
+	}
+
+	res, err := s.httpClient.DoRequest(req, nil)
+	if err != nil {
+		return err
+	}
+
+	defer res.CloseBody()
+	return res.ErrorByStatusCode()
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	errorsEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	notificationEnum "github.com/ZupIT/horusec/development-kit/pkg/enums/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(statusCode int, body *map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if body != nil {
+			_ = json.NewDecoder(r.Body).Decode(body)
+		}
+		w.WriteHeader(statusCode)
+	}))
+}
+
+func TestMock(t *testing.T) {
+	m := &Mock{}
+	m.On("Send").Return(nil)
+	assert.NoError(t, m.Send(&messages.ChatMessage{}))
+}
+
+func TestNewNotifier(t *testing.T) {
+	assert.NotEmpty(t, NewNotifier(10))
+}
+
+func TestNotifier_Send(t *testing.T) {
+	t.Run("should return error when channel is invalid", func(t *testing.T) {
+		n := NewNotifier(10)
+		err := n.Send(&messages.ChatMessage{Channel: "irc", URL: "http://example.com"})
+		assert.Equal(t, errorsEnum.ErrorInvalidNotificationChannel, err)
+	})
+	t.Run("should send message to all valid channels", func(t *testing.T) {
+		server := newTestServer(http.StatusOK, nil)
+		defer server.Close()
+
+		n := NewNotifier(10)
+		for _, channel := range notificationEnum.Unknown.Values() {
+			assert.NoError(t, n.Send(&messages.ChatMessage{Channel: channel, URL: server.URL, Title: "test"}))
+		}
+	})
+	t.Run("should return error when server return status code of error", func(t *testing.T) {
+		server := newTestServer(http.StatusBadRequest, nil)
+		defer server.Close()
+
+		n := NewNotifier(10)
+		err := n.Send(&messages.ChatMessage{Channel: notificationEnum.Slack, URL: server.URL, Title: "test"})
+		assert.Equal(t, errorsEnum.ErrDoHTTPClientSide, err)
+	})
+	t.Run("should return error when url is invalid", func(t *testing.T) {
+		n := NewNotifier(10)
+		err := n.Send(&messages.ChatMessage{Channel: notificationEnum.Chat, URL: "::invalid"})
+		assert.Error(t, err)
+	})
+}
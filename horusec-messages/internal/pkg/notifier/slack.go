@@ -0,0 +1,97 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+)
+
+// Slack incoming webhooks accept at most ten fields in a single section block
+const slackMaxFieldsBySection = 10
+
+type Slack struct {
+	sender *sender
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type   string      `json:"type"`
+	Text   *slackText  `json:"text,omitempty"`
+	Fields []slackText `json:"fields,omitempty"`
+}
+
+type slackPayload struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+func NewSlack(sender *sender) IChannel {
+	return &Slack{sender: sender}
+}
+
+func (s *Slack) Send(message *messages.ChatMessage) error {
+	return s.sender.post(message.URL, s.getPayload(message))
+}
+
+func (s *Slack) getPayload(message *messages.ChatMessage) *slackPayload {
+	payload := &slackPayload{
+		Text: message.Title,
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: message.Title}},
+		},
+	}
+
+	if message.Text != "" {
+		payload.Blocks = append(payload.Blocks, slackBlock{Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: message.Text}})
+	}
+
+	payload.Blocks = append(payload.Blocks, s.getFieldsBlocks(message.Fields)...)
+	return s.setLinkBlock(payload, message.Link)
+}
+
+func (s *Slack) getFieldsBlocks(fields []messages.ChatField) (blocks []slackBlock) {
+	for start := 0; start < len(fields); start += slackMaxFieldsBySection {
+		end := start + slackMaxFieldsBySection
+		if end > len(fields) {
+			end = len(fields)
+		}
+
+		block := slackBlock{Type: "section"}
+		for _, field := range fields[start:end] {
+			block.Fields = append(block.Fields, slackText{Type: "mrkdwn",
+				Text: fmt.Sprintf("*%s*\n%s", field.Name, field.Value)})
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
+
+func (s *Slack) setLinkBlock(payload *slackPayload, link string) *slackPayload {
+	if link != "" {
+		payload.Blocks = append(payload.Blocks, slackBlock{Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("<%s|Open in Horusec>", link)}})
+	}
+
+	return payload
+}
@@ -0,0 +1,63 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlack_Send(t *testing.T) {
+	t.Run("should send message formatted as slack blocks", func(t *testing.T) {
+		body := map[string]interface{}{}
+		server := newTestServer(http.StatusOK, &body)
+		defer server.Close()
+
+		slack := NewSlack(newSender(10))
+		err := slack.Send(&messages.ChatMessage{URL: server.URL, Title: "title", Text: "text",
+			Link: "http://localhost:8043", Fields: []messages.ChatField{{Name: "HIGH", Value: "1"}}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "title", body["text"])
+		assert.Len(t, body["blocks"], 4)
+	})
+}
+
+func TestSlack_GetPayload(t *testing.T) {
+	t.Run("should split fields in sections of ten", func(t *testing.T) {
+		message := &messages.ChatMessage{Title: "title"}
+		for i := 0; i < 15; i++ {
+			message.AddField(strconv.Itoa(i), strconv.Itoa(i))
+		}
+
+		slack := &Slack{}
+		payload := slack.getPayload(message)
+
+		assert.Len(t, payload.Blocks, 3)
+		assert.Len(t, payload.Blocks[1].Fields, 10)
+		assert.Len(t, payload.Blocks[2].Fields, 5)
+	})
+	t.Run("should return only header when message has no content", func(t *testing.T) {
+		slack := &Slack{}
+		payload := slack.getPayload(&messages.ChatMessage{Title: "title"})
+
+		assert.Len(t, payload.Blocks, 1)
+		assert.Equal(t, "header", payload.Blocks[0].Type)
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+)
+
+const (
+	teamsCardType    = "MessageCard"
+	teamsCardContext = "https://schema.org/extensions"
+	teamsThemeColor  = "F9A825"
+)
+
+type Teams struct {
+	sender *sender
+}
+
+type teamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type teamsSection struct {
+	Facts []teamsFact `json:"facts"`
+}
+
+type teamsTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+type teamsAction struct {
+	Type    string        `json:"@type"`
+	Name    string        `json:"name"`
+	Targets []teamsTarget `json:"targets"`
+}
+
+type teamsPayload struct {
+	Type            string         `json:"@type"`
+	Context         string         `json:"@context"`
+	Summary         string         `json:"summary"`
+	ThemeColor      string         `json:"themeColor"`
+	Title           string         `json:"title"`
+	Text            string         `json:"text,omitempty"`
+	Sections        []teamsSection `json:"sections,omitempty"`
+	PotentialAction []teamsAction  `json:"potentialAction,omitempty"`
+}
+
+func NewTeams(sender *sender) IChannel {
+	return &Teams{sender: sender}
+}
+
+func (t *Teams) Send(message *messages.ChatMessage) error {
+	return t.sender.post(message.URL, t.getPayload(message))
+}
+
+func (t *Teams) getPayload(message *messages.ChatMessage) *teamsPayload {
+	payload := &teamsPayload{
+		Type:       teamsCardType,
+		Context:    teamsCardContext,
+		Summary:    message.Title,
+		ThemeColor: teamsThemeColor,
+		Title:      message.Title,
+		Text:       message.Text,
+	}
+
+	if len(message.Fields) > 0 {
+		payload.Sections = []teamsSection{{Facts: t.getFacts(message.Fields)}}
+	}
+
+	if message.Link != "" {
+		payload.PotentialAction = []teamsAction{{Type: "OpenUri", Name: "Open in Horusec",
+			Targets: []teamsTarget{{OS: "default", URI: message.Link}}}}
+	}
+
+	return payload
+}
+
+func (t *Teams) getFacts(fields []messages.ChatField) (facts []teamsFact) {
+	for _, field := range fields {
+		facts = append(facts, teamsFact{Name: field.Name, Value: field.Value})
+	}
+
+	return facts
+}
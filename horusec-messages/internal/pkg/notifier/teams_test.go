@@ -0,0 +1,52 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ZupIT/horusec/development-kit/pkg/entities/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeams_Send(t *testing.T) {
+	t.Run("should send message formatted as teams message card", func(t *testing.T) {
+		body := map[string]interface{}{}
+		server := newTestServer(http.StatusOK, &body)
+		defer server.Close()
+
+		teams := NewTeams(newSender(10))
+		err := teams.Send(&messages.ChatMessage{URL: server.URL, Title: "title", Text: "text",
+			Link: "http://localhost:8043", Fields: []messages.ChatField{{Name: "HIGH", Value: "1"}}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "MessageCard", body["@type"])
+		assert.Equal(t, "title", body["title"])
+		assert.Len(t, body["sections"], 1)
+		assert.Len(t, body["potentialAction"], 1)
+	})
+}
+
+func TestTeams_GetPayload(t *testing.T) {
+	t.Run("should not set sections and actions when message has no fields and link", func(t *testing.T) {
+		teams := &Teams{}
+		payload := teams.getPayload(&messages.ChatMessage{Title: "title"})
+
+		assert.Empty(t, payload.Sections)
+		assert.Empty(t, payload.PotentialAction)
+		assert.Equal(t, "title", payload.Summary)
+	})
+}
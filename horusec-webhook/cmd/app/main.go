@@ -16,6 +16,7 @@ package main
 
 import (
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/adapter"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
 	"log"
 	"net/http"
 
@@ -35,6 +36,8 @@ import (
 // @contact.url https://github.com/ZupIT/horusec
 // @contact.email horusec@zup.com.br
 func main() {
+	defer tracing.SetUpTracing("horusec-webhook")()
+
 	postgresRead := adapter.NewRepositoryRead()
 	broker := brokerConfig.SetUp(postgresRead)
 
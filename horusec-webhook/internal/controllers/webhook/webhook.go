@@ -15,18 +15,24 @@
 package webhook
 
 import (
+	"context"
+
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational/repository/webhook"
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
 	entitiesWebhook "github.com/ZupIT/horusec/development-kit/pkg/entities/webhook"
 	EnumErrors "github.com/ZupIT/horusec/development-kit/pkg/enums/errors"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/metrics"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/env"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/http-request/client"
 	"github.com/ZupIT/horusec/development-kit/pkg/utils/http-request/request"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Interface interface {
-	DispatchRequest(analysis *horusec.Analysis) error
+	DispatchRequest(ctx context.Context, analysis *horusec.Analysis) error
 }
 
 type Controller struct {
@@ -45,7 +51,7 @@ func NewWebhookController(databaseRead relational.InterfaceRead) Interface {
 	}
 }
 
-func (c *Controller) DispatchRequest(analysis *horusec.Analysis) error {
+func (c *Controller) DispatchRequest(ctx context.Context, analysis *horusec.Analysis) error {
 	webhookFound, err := c.webhookRepository.GetByRepositoryID(analysis.RepositoryID)
 	if err != nil {
 		if err == EnumErrors.ErrNotFoundRecords {
@@ -53,11 +59,19 @@ func (c *Controller) DispatchRequest(analysis *horusec.Analysis) error {
 		}
 		return err
 	}
-	return c.sendHTTPRequest(webhookFound, analysis)
+	ctx, span := tracing.StartSpan(ctx, "webhook "+webhookFound.GetMethod(), trace.WithSpanKind(trace.SpanKindClient))
+	err = c.sendHTTPRequest(ctx, webhookFound, analysis)
+	metrics.ObserveWebhookDelivery(err)
+	tracing.EndSpan(span, err)
+	return err
 }
 
-func (c *Controller) sendHTTPRequest(webhookFound *entitiesWebhook.Webhook, analysis *horusec.Analysis) error {
-	req, err := c.httpRequest.Request(webhookFound.GetMethod(), webhookFound.URL, analysis, webhookFound.GetHeaders())
+// sendHTTPRequest sends the traceparent header so the receiver of the webhook can continue the trace
+func (c *Controller) sendHTTPRequest(ctx context.Context, webhookFound *entitiesWebhook.Webhook,
+	analysis *horusec.Analysis) error {
+	headers := webhookFound.GetHeaders()
+	tracing.Inject(ctx, propagation.MapCarrier(headers))
+	req, err := c.httpRequest.Request(webhookFound.GetMethod(), webhookFound.URL, analysis, headers)
 	if err != nil {
 		return err
 	}
@@ -15,6 +15,8 @@
 package webhook
 
 import (
+	"context"
+
 	"github.com/ZupIT/horusec/development-kit/pkg/entities/horusec"
 	utilsMock "github.com/ZupIT/horusec/development-kit/pkg/utils/mock"
 	"github.com/stretchr/testify/mock"
@@ -24,7 +26,7 @@ type Mock struct {
 	mock.Mock
 }
 
-func (m *Mock) DispatchRequest(_ *horusec.Analysis) error {
+func (m *Mock) DispatchRequest(_ context.Context, _ *horusec.Analysis) error {
 	args := m.MethodCalled("DispatchRequest")
 	return utilsMock.ReturnNilOrError(args, 0)
 }
@@ -15,6 +15,7 @@
 package webhook
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -49,7 +50,7 @@ func TestMock_DispatchRequest(t *testing.T) {
 		mockRead.On("SetFilter").Return(conn)
 		mockRead.On("Find").Return(response.NewResponse(0, EnumErrors.ErrNotFoundRecords, nil))
 		c := NewWebhookController(mockRead)
-		err := c.DispatchRequest(test.CreateAnalysisMock())
+		err := c.DispatchRequest(context.Background(), test.CreateAnalysisMock())
 		assert.NoError(t, err)
 	})
 	t.Run("Should return error because unexpected error in webhook in database", func(t *testing.T) {
@@ -57,7 +58,7 @@ func TestMock_DispatchRequest(t *testing.T) {
 		mockRead.On("SetFilter").Return(conn)
 		mockRead.On("Find").Return(response.NewResponse(0, errors.New("unexpected"), nil))
 		c := NewWebhookController(mockRead)
-		err := c.DispatchRequest(test.CreateAnalysisMock())
+		err := c.DispatchRequest(context.Background(), test.CreateAnalysisMock())
 		assert.Error(t, err)
 	})
 	t.Run("Should return error because exists error in mount request", func(t *testing.T) {
@@ -79,7 +80,7 @@ func TestMock_DispatchRequest(t *testing.T) {
 			webhookRepository: webhook.NewWebhookRepository(mockRead, nil),
 			httpRequest:       mockRequest,
 		}
-		err := c.DispatchRequest(analysis)
+		err := c.DispatchRequest(context.Background(), analysis)
 		assert.Error(t, err)
 	})
 	t.Run("Should return error because exists error on execute do request", func(t *testing.T) {
@@ -104,7 +105,7 @@ func TestMock_DispatchRequest(t *testing.T) {
 			httpRequest:       mockRequest,
 			httpClient:        mockClient,
 		}
-		err := c.DispatchRequest(analysis)
+		err := c.DispatchRequest(context.Background(), analysis)
 		assert.Error(t, err)
 	})
 	t.Run("Should return error because request return err client side", func(t *testing.T) {
@@ -131,7 +132,7 @@ func TestMock_DispatchRequest(t *testing.T) {
 			httpRequest:       mockRequest,
 			httpClient:        mockClient,
 		}
-		err := c.DispatchRequest(analysis)
+		err := c.DispatchRequest(context.Background(), analysis)
 		assert.Equal(t, EnumErrors.ErrDoHTTPClientSide, err)
 	})
 	t.Run("Should return error because request return err service side", func(t *testing.T) {
@@ -158,7 +159,7 @@ func TestMock_DispatchRequest(t *testing.T) {
 			httpRequest:       mockRequest,
 			httpClient:        mockClient,
 		}
-		err := c.DispatchRequest(analysis)
+		err := c.DispatchRequest(context.Background(), analysis)
 		assert.Equal(t, EnumErrors.ErrDoHTTPServiceSide, err)
 	})
 	t.Run("Should dispatch request without error", func(t *testing.T) {
@@ -188,7 +189,7 @@ func TestMock_DispatchRequest(t *testing.T) {
 			httpRequest:       mockRequest,
 			httpClient:        mockClient,
 		}
-		err := c.DispatchRequest(analysis)
+		err := c.DispatchRequest(context.Background(), analysis)
 		assert.NoError(t, err)
 	})
 }
@@ -41,7 +41,7 @@ func (c *Consumer) DispatchRequest(packet brokerPacket.IPacket) {
 		_ = packet.Ack()
 		return
 	}
-	if err := c.controller.DispatchRequest(analysis); err != nil {
+	if err := c.controller.DispatchRequest(packet.GetContext(), analysis); err != nil {
 		logger.LogError("Error when dispatch request", err)
 	} else {
 		logger.LogInfo("Webhook Dispatch request with success")
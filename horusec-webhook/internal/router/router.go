@@ -17,6 +17,7 @@ package router
 import (
 	"github.com/ZupIT/horusec/development-kit/pkg/databases/relational"
 	brokerLib "github.com/ZupIT/horusec/development-kit/pkg/services/broker"
+	"github.com/ZupIT/horusec/development-kit/pkg/services/tracing"
 	configUtil "github.com/ZupIT/horusec/development-kit/pkg/utils/http/server"
 	"github.com/ZupIT/horusec/horusec-webhook/internal/handlers/health"
 	"github.com/ZupIT/horusec/horusec-webhook/internal/router/routes"
@@ -45,6 +46,7 @@ func (r *Router) setMiddleware() {
 	r.EnableCompress()
 	r.EnableRequestID()
 	r.EnableCORS()
+	r.EnableTracing()
 	r.RouterMetrics()
 }
 
@@ -89,6 +91,11 @@ func (r *Router) EnableCORS() *Router {
 	return r
 }
 
+func (r *Router) EnableTracing() *Router {
+	r.router.Use(tracing.Middleware)
+	return r
+}
+
 func (r *Router) RouterMetrics() *Router {
 	r.router.Handle("/metrics", promhttp.Handler())
 	return r